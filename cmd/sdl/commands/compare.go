@@ -0,0 +1,88 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/panyam/sdl/lib/core"
+	"github.com/panyam/sdl/lib/types"
+	"github.com/spf13/cobra"
+)
+
+var compareCmd = &cobra.Command{
+	Use:   "compare <baseline.json> <candidate.json>",
+	Short: "Compares the latencies of two sets of run results",
+	Long: `Compares two JSON results files written by 'sdl run --out' and reports,
+for the mean and each percentile, whether the difference between them is
+larger than the sampling error.  A difference is only significant when the
+95% confidence intervals of the two runs do not overlap.`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		baseline, err := readRunResults(args[0])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		candidate, err := readRunResults(args[1])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		base, cand := types.SummarizeRuns(baseline), types.SummarizeRuns(candidate)
+		fmt.Printf("Baseline  (%d runs): %s\n", base.Count, formatRunSummary(base, core.DefaultDisplayPrecision))
+		fmt.Printf("Candidate (%d runs): %s\n\n", cand.Count, formatRunSummary(cand, core.DefaultDisplayPrecision))
+
+		fmt.Printf("%-6s %14s %14s %10s  %s\n", "STAT", "BASELINE", "CANDIDATE", "CHANGE", "VERDICT")
+		for _, row := range []struct {
+			name      string
+			base, can types.Estimate
+		}{
+			{"mean", base.Mean, cand.Mean},
+			{"p50", base.P50, cand.P50},
+			{"p95", base.P95, cand.P95},
+			{"p99", base.P99, cand.P99},
+		} {
+			change := "-"
+			if row.base.Value != 0 {
+				change = fmt.Sprintf("%+.1f%%", (row.can.Value-row.base.Value)/row.base.Value*100)
+			}
+			verdict := "within noise"
+			if !row.base.CI.Overlaps(row.can.CI) {
+				verdict = "significant"
+			}
+			fmt.Printf("%-6s %14s %14s %10s  %s\n", row.name, formatLatencyMillis(row.base.Value, core.DefaultDisplayPrecision),
+				formatLatencyMillis(row.can.Value, core.DefaultDisplayPrecision), change, verdict)
+		}
+	},
+}
+
+func readRunResults(path string) ([]RunResult, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+	var results []RunResult
+	if err := json.Unmarshal(data, &results); err != nil {
+		return nil, fmt.Errorf("parsing JSON from %s: %w", path, err)
+	}
+	return results, nil
+}
+
+// formatRunSummary renders a summary as "mean=12.3ms ±0.4ms p50=... p95=... p99=..."
+// with precision significant figures.
+func formatRunSummary(s types.RunSummary, precision int) string {
+	return fmt.Sprintf("mean=%s ±%s p50=%s p95=%s p99=%s",
+		formatLatencyMillis(s.Mean.Value, precision), formatLatencyMillis(s.Mean.CI.HalfWidth(), precision),
+		formatLatencyMillis(s.P50.Value, precision), formatLatencyMillis(s.P95.Value, precision), formatLatencyMillis(s.P99.Value, precision))
+}
+
+// formatLatencyMillis formats a run latency, which is recorded in milliseconds.
+func formatLatencyMillis(ms float64, precision int) string {
+	return core.FormatDuration(ms/1000, precision)
+}
+
+func init() {
+	AddCommand(compareCmd)
+}
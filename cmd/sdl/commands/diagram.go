@@ -10,15 +10,20 @@ import (
 	"github.com/panyam/sdl/lib/loader"
 	"github.com/panyam/sdl/lib/runtime"
 	"github.com/panyam/sdl/lib/viz"
+	"github.com/panyam/sdl/services"
 	"github.com/spf13/cobra"
 )
 
 var diagramCmd = &cobra.Command{
-	Use:   "diagram <diagram_type> [system_name]",
-	Short: "Generates diagrams of system structure or behavior",
+	Use:     "diagram <diagram_type> [system_name]",
+	Aliases: []string{"graph"},
+	Short:   "Generates diagrams of system structure or behavior",
 	Long: `Generates visual representations of system components and their interactions.
 Diagram types:
   static: Shows component instances and their declared dependencies. Requires a <system_name>.
+          With --with-flows the flows of the system's generators are evaluated and
+          edges are labelled with their rates and the latency they add, and in dot
+          output nodes are colored by utilization (green, yellow, red).
   dynamic: Shows component interactions from a trace file. Requires the --from flag.`,
 	Args: cobra.MinimumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
@@ -46,7 +51,12 @@ Diagram types:
 				os.Exit(1)
 			}
 			fmt.Printf("Generating 'static' diagram for system '%s' from '%s'\n", systemName, dslFilePath)
-			generateStaticDiagram(systemName, outputFile, format)
+			if withFlows, _ := cmd.Flags().GetBool("with-flows"); withFlows {
+				samples, _ := cmd.Flags().GetInt("samples")
+				generateFlowDiagram(systemName, outputFile, format, samples)
+			} else {
+				generateStaticDiagram(systemName, outputFile, format)
+			}
 		} else {
 			fmt.Fprintf(os.Stderr, "Error: Unknown diagram type '%s'. Choose 'static' or 'dynamic'.\n", diagramType)
 			os.Exit(1)
@@ -89,7 +99,7 @@ func generateDynamicDiagram(fromFile, outputFile, format string) {
 
 func generateStaticDiagram(systemName, outputFile, format string) {
 	// 1. Load the SDL file
-	sdlLoader := loader.NewLoader(nil, nil, 10)
+	sdlLoader := loader.NewLoader(nil, nil, loader.DefaultMaxImportDepth)
 	fileStatus, err := sdlLoader.LoadFile(dslFilePath, "", 0)
 	if err != nil || fileStatus.HasErrors() {
 		fmt.Fprintf(os.Stderr, "Error loading or parsing SDL file '%s':\n", dslFilePath)
@@ -142,30 +152,14 @@ func generateStaticDiagram(systemName, outputFile, format string) {
 		}
 	}
 
-	// 3. Get the correct generator based on format
-	var generator viz.StaticDiagramGenerator
-	switch format {
-	case "dot":
-		generator = &viz.DotGenerator{}
-	case "mermaid":
-		generator = &viz.MermaidStaticGenerator{}
-	case "excalidraw":
-		generator = &viz.ExcalidrawGenerator{}
-	case "svg":
-		generator = &viz.SvgGenerator{}
-	default:
-		fmt.Fprintf(os.Stderr, "Static diagram for format '%s' is not supported.\n", format)
-		os.Exit(1)
-	}
-
-	// 4. Create proto SystemDiagram and generate output
+	// 3. Create proto SystemDiagram and generate output
 	diagram := &protos.SystemDiagram{
 		SystemName: systemName,
 		Nodes:      nodes,
 		Edges:      edges,
 	}
 
-	diagramOutput, err := generator.Generate(diagram)
+	diagramOutput, err := staticDiagramGenerator(format, nil).Generate(diagram)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error generating %s diagram: %v\n", format, err)
 		os.Exit(1)
@@ -174,6 +168,62 @@ func generateStaticDiagram(systemName, outputFile, format string) {
 	writeOutput(outputFile, diagramOutput)
 }
 
+// generateFlowDiagram renders the system's diagram after evaluating the flows
+// of its declared generators, see DevEnv.GetFlowDiagram.
+func generateFlowDiagram(systemName, outputFile, format string, samples int) {
+	diagramOutput, err := renderFlowDiagram(dslFilePath, systemName, format, samples)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error generating %s diagram: %v\n", format, err)
+		os.Exit(1)
+	}
+	writeOutput(outputFile, diagramOutput)
+}
+
+func renderFlowDiagram(path, systemName, format string, samples int) (string, error) {
+	dev := services.NewDevEnv(localFileResolver())
+	defer dev.Close()
+	if err := dev.LoadFile(path); err != nil {
+		return "", err
+	}
+	if err := dev.Use(systemName); err != nil {
+		return "", err
+	}
+	if len(dev.ListGenerators()) > 0 {
+		if _, err := dev.EvaluateFlows("runtime"); err != nil {
+			return "", err
+		}
+	}
+	diagram, err := dev.GetFlowDiagram(samples)
+	if err != nil {
+		return "", err
+	}
+	colors := map[string]string{}
+	for _, node := range diagram.Nodes {
+		if node.Utilization > 0 {
+			colors[node.ID] = viz.UtilizationColor(node.Utilization)
+		}
+	}
+	return staticDiagramGenerator(format, colors).Generate(services.ToProtoSystemDiagram(diagram))
+}
+
+// staticDiagramGenerator returns the generator for a format, exiting if it is
+// not supported.  Node colors are only drawn in dot output.
+func staticDiagramGenerator(format string, nodeColors map[string]string) viz.StaticDiagramGenerator {
+	switch format {
+	case "dot":
+		return &viz.DotGenerator{NodeColors: nodeColors}
+	case "mermaid":
+		return &viz.MermaidStaticGenerator{}
+	case "excalidraw":
+		return &viz.ExcalidrawGenerator{}
+	case "svg":
+		return &viz.SvgGenerator{}
+	}
+	fmt.Fprintf(os.Stderr, "Static diagram for format '%s' is not supported.\n", format)
+	os.Exit(1)
+	return nil
+}
+
 func writeOutput(outputFile, content string) {
 	if content == "" {
 		return
@@ -196,4 +246,6 @@ func init() {
 	diagramCmd.Flags().StringP("output", "o", "", "Output file path for the diagram")
 	diagramCmd.Flags().String("from", "", "Path to a JSON trace file (for dynamic diagrams)")
 	diagramCmd.Flags().String("format", "dot", "Output format (dot, mermaid, excalidraw, svg)")
+	diagramCmd.Flags().Bool("with-flows", false, "Label static diagrams with evaluated flow rates and latencies and color nodes by utilization")
+	diagramCmd.Flags().Int("samples", 100, "Calls sampled per method to estimate latencies with --with-flows")
 }
@@ -0,0 +1,76 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/panyam/sdl/lib/decl"
+	"github.com/spf13/cobra"
+)
+
+var diffCmd = &cobra.Command{
+	Use:   "diff <old.sdl> <new.sdl>",
+	Short: "Shows the semantic differences between two SDL files",
+	Long: `Parses both files and reports added, removed and changed components,
+params, dependencies, methods, systems and enums.  Declarations are matched
+by name so formatting, comments and reordering are ignored.`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		changes, err := diffSDLFiles(args[0], args[1])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		if asJSON, _ := cmd.Flags().GetBool("json"); asJSON {
+			if changes == nil {
+				changes = []decl.DeclChange{}
+			}
+			out, _ := json.MarshalIndent(changes, "", "  ")
+			fmt.Println(string(out))
+			return
+		}
+
+		if len(changes) == 0 {
+			fmt.Println("No semantic differences")
+			return
+		}
+		symbols := map[string]string{decl.ChangeAdded: "+", decl.ChangeRemoved: "-", decl.ChangeChanged: "~"}
+		for _, change := range changes {
+			fmt.Printf("%s %-10s %s", symbols[change.Kind], change.Entity, change.Path)
+			if change.Detail != "" {
+				fmt.Printf(": %s", change.Detail)
+			}
+			fmt.Println()
+		}
+		fmt.Printf("\n%d change(s)\n", len(changes))
+	},
+}
+
+// diffSDLFiles parses two SDL files and returns their semantic differences.
+func diffSDLFiles(oldPath, newPath string) ([]decl.DeclChange, error) {
+	oldFile, err := parseSDLFile(oldPath)
+	if err != nil {
+		return nil, err
+	}
+	newFile, err := parseSDLFile(newPath)
+	if err != nil {
+		return nil, err
+	}
+	return decl.DiffFiles(oldFile, newFile), nil
+}
+
+func parseSDLFile(path string) (*decl.FileDecl, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return (&SDLParserAdapter{}).Parse(f, path)
+}
+
+func init() {
+	diffCmd.Flags().Bool("json", false, "Output the changes as JSON")
+	AddCommand(diffCmd)
+}
@@ -0,0 +1,101 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/panyam/sdl/lib/decl"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeSDL(t *testing.T, name, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	return path
+}
+
+// TestDiffLatencyDefaultOnly verifies that files which differ in formatting,
+// declaration order and a single latency default report only that default.
+func TestDiffLatencyDefaultOnly(t *testing.T) {
+	oldPath := writeSDL(t, "old.sdl", `
+component Database {
+  param Latency Duration = 10ms
+  method Query() Bool { return true }
+}
+
+component Server {
+  uses db Database(Latency = 5ms)
+  method Handle() Bool {
+    return self.db.Query()
+  }
+}
+
+system App(server Server) { }
+`)
+	newPath := writeSDL(t, "new.sdl", `
+component Server {
+    uses db Database(Latency = 5ms)
+
+    method Handle() Bool { return self.db.Query() }
+}
+
+// Reordered, reformatted and commented
+component Database {
+    method Query() Bool {
+        return true
+    }
+    param Latency Duration = 20ms
+}
+
+system App(server Server) {
+}
+`)
+
+	changes, err := diffSDLFiles(oldPath, newPath)
+	require.NoError(t, err)
+	require.Len(t, changes, 1, "changes: %v", changes)
+	assert.Equal(t, decl.ChangeChanged, changes[0].Kind)
+	assert.Equal(t, "param", changes[0].Entity)
+	assert.Equal(t, "Database.Latency", changes[0].Path)
+	assert.Contains(t, changes[0].Detail, "default")
+
+	changes, err = diffSDLFiles(oldPath, oldPath)
+	require.NoError(t, err)
+	assert.Empty(t, changes)
+}
+
+// TestDiffStructuralChanges verifies added, removed and changed declarations
+// across components, methods and systems.
+func TestDiffStructuralChanges(t *testing.T) {
+	oldPath := writeSDL(t, "old.sdl", `
+component Cache { method Get() Bool { return true } }
+component Server {
+  method Handle() Bool { return true }
+  method Health() Bool { return true }
+}
+system App(server Server) { }
+`)
+	newPath := writeSDL(t, "new.sdl", `
+component Server {
+  method Handle(id Int) Bool { return true }
+  method Ready() Bool { return false }
+}
+system App(server Server, cache Server) { }
+`)
+
+	changes, err := diffSDLFiles(oldPath, newPath)
+	require.NoError(t, err)
+	var summary []string
+	for _, c := range changes {
+		summary = append(summary, c.Kind+" "+c.Entity+" "+c.Path)
+	}
+	assert.ElementsMatch(t, []string{
+		"removed component Cache",
+		"changed method Server.Handle",
+		"removed method Server.Health",
+		"added method Server.Ready",
+		"changed system App",
+	}, summary)
+}
@@ -0,0 +1,517 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	v1 "github.com/panyam/sdl/gen/go/sdl/v1/models"
+	v1s "github.com/panyam/sdl/gen/go/sdl/v1/services"
+	"github.com/panyam/sdl/lib/core"
+	"github.com/panyam/sdl/lib/loader"
+	"github.com/panyam/sdl/lib/runtime"
+	"github.com/panyam/sdl/lib/types"
+	"github.com/panyam/sdl/services"
+	"github.com/panyam/sdl/services/devenvbe"
+)
+
+// Executor carries out workspace commands.  The REPL only talks to an Executor
+// so the same commands work against a running server or an in-process engine.
+type Executor interface {
+	// Load loads a file and returns the systems it declares, or nil when the
+	// executor cannot report them.
+	Load(filePath string) ([]services.LoadedSystem, error)
+	Use(systemName string) error
+	Set(path, value string) error
+	Run(opts services.RunOptions) (results []types.RunResult, cached bool, err error)
+	// DisplayPrecision returns the significant figures values are shown with.
+	DisplayPrecision() int
+	// ListRuns returns up to limit past runs, newest first, after skipping
+	// offset of them, and the number of runs remembered.
+	ListRuns(offset, limit int) (runs []services.RunRecord, total int, err error)
+	// SetDecisionLog sets where debug runs log their random decisions, nil
+	// to stop logging them.
+	SetDecisionLog(w io.Writer) error
+	AddGenerator(name, component, method string, rate float64, maxRequests int) error
+	// AddPresetGenerator adds a generator whose rate follows the traffic
+	// shape of a runtime.RateProfilePresets preset built from opts.
+	AddPresetGenerator(name, component, method, preset string, opts map[string]string, maxRequests int) error
+	StartGenerators(names ...string) error
+	StopGenerators(names ...string) error
+	// RemoveGenerator removes a generator, and the metrics AddAutoMetrics
+	// created for it when autoMetrics is set.
+	RemoveGenerator(name string, autoMetrics bool) error
+	AddMetric(metric *v1.Metric) error
+	// AddAutoMetrics adds a latency and a throughput metric for the target of
+	// each generator and returns the metrics added.
+	AddAutoMetrics() ([]*v1.Metric, error)
+	// AddMetricTemplate adds a metric of metricType for every instance of a
+	// component type, now and in systems used later, and returns the metrics
+	// added.
+	AddMetricTemplate(component, metricType string) ([]*v1.Metric, error)
+	// MetricData returns the type of a metric and up to points of its latest
+	// values, oldest first.
+	MetricData(name string, points int) (metricType string, values []float64, err error)
+	MeasurementStats() (*runtime.MetricStoreStats, error)
+	ExportMetrics(exporterURL string) error
+	ExportDashboard() (json.RawMessage, error)
+	Attribution(target string, runs int) (*runtime.LatencyAttribution, error)
+	Trace(target string) (*runtime.TraceTree, error)
+	EvaluateFlows() (*runtime.FlowAnalysisResult, error)
+	Close() error
+}
+
+// LocalExecutor runs commands directly against an in-process DevEnv so no
+// server is needed.
+type LocalExecutor struct {
+	Service *devenvbe.WorkspaceService
+	ctx     context.Context
+}
+
+// NewLocalExecutor creates an executor backed by a fresh local workspace.
+func NewLocalExecutor(resolver loader.FileResolver) *LocalExecutor {
+	return &LocalExecutor{
+		Service: devenvbe.NewWorkspaceService(resolver),
+		ctx:     context.Background(),
+	}
+}
+
+func (e *LocalExecutor) Load(filePath string) ([]services.LoadedSystem, error) {
+	if _, err := e.Service.LoadFile(e.ctx, &v1.LoadFileRequest{SdlFilePath: filePath}); err != nil {
+		return nil, err
+	}
+	return e.Service.DevEnv.LoadedSystems(filePath)
+}
+
+func (e *LocalExecutor) Use(systemName string) error {
+	_, err := e.Service.UseSystem(e.ctx, &v1.UseSystemRequest{SystemName: systemName})
+	return err
+}
+
+func (e *LocalExecutor) Set(path, value string) error {
+	_, err := e.Service.SetParameter(e.ctx, &v1.SetParameterRequest{Path: path, NewValue: value})
+	return err
+}
+
+func (e *LocalExecutor) Run(opts services.RunOptions) ([]types.RunResult, bool, error) {
+	return e.Service.DevEnv.RunSimulation(opts)
+}
+
+func (e *LocalExecutor) DisplayPrecision() int {
+	return e.Service.DevEnv.DisplayPrecision()
+}
+
+func (e *LocalExecutor) ListRuns(offset, limit int) ([]services.RunRecord, int, error) {
+	runs, total := e.Service.DevEnv.ListRuns(offset, limit)
+	return runs, total, nil
+}
+
+func (e *LocalExecutor) SetDecisionLog(w io.Writer) error {
+	e.Service.DevEnv.SetDecisionLog(w)
+	return nil
+}
+
+func (e *LocalExecutor) AddGenerator(name, component, method string, rate float64, maxRequests int) error {
+	if maxRequests == 0 {
+		_, err := e.Service.AddGenerator(e.ctx, &v1.AddGeneratorRequest{
+			Generator: &v1.Generator{Name: name, Component: component, Method: method, Rate: rate},
+		})
+		return err
+	}
+	// The request cap is not part of the proto so set it on the runtime generator directly
+	return e.Service.DevEnv.AddGenerator(&runtime.Generator{
+		Generator:   &v1.Generator{Name: name, Component: component, Method: method, Rate: rate},
+		MaxRequests: maxRequests,
+	})
+}
+
+func (e *LocalExecutor) AddPresetGenerator(name, component, method, preset string, opts map[string]string, maxRequests int) error {
+	profile, err := runtime.NewRateProfile(preset, opts)
+	if err != nil {
+		return err
+	}
+	// Profiles are not part of the proto so set it on the runtime generator directly
+	return e.Service.DevEnv.AddGenerator(&runtime.Generator{
+		Generator:   &v1.Generator{Name: name, Component: component, Method: method, Rate: profile.Peak},
+		MaxRequests: maxRequests,
+		Profile:     profile,
+	})
+}
+
+func (e *LocalExecutor) StartGenerators(names ...string) error {
+	if len(names) == 0 {
+		return e.Service.DevEnv.StartAllGenerators()
+	}
+	for _, name := range names {
+		if err := e.Service.DevEnv.StartGenerator(name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *LocalExecutor) StopGenerators(names ...string) error {
+	if len(names) == 0 {
+		return e.Service.DevEnv.StopAllGenerators()
+	}
+	for _, name := range names {
+		if err := e.Service.DevEnv.StopGenerator(name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *LocalExecutor) RemoveGenerator(name string, autoMetrics bool) error {
+	if err := e.Service.DevEnv.RemoveGenerator(name); err != nil {
+		return err
+	}
+	if autoMetrics {
+		return e.Service.DevEnv.RemoveAutoMetrics(name)
+	}
+	return nil
+}
+
+func (e *LocalExecutor) AddMetric(metric *v1.Metric) error {
+	_, err := e.Service.AddMetric(e.ctx, &v1.AddMetricRequest{Metric: metric})
+	return err
+}
+
+func (e *LocalExecutor) AddAutoMetrics() ([]*v1.Metric, error) {
+	return e.Service.DevEnv.AddAutoMetrics()
+}
+
+func (e *LocalExecutor) AddMetricTemplate(component, metricType string) ([]*v1.Metric, error) {
+	return e.Service.DevEnv.AddMetricTemplate(component, metricType)
+}
+
+func (e *LocalExecutor) MetricData(name string, points int) (string, []float64, error) {
+	dev := e.Service.DevEnv
+	latest, err := dev.RecentMetricPoints(name, points)
+	if err != nil {
+		return "", nil, err
+	}
+	metricType := ""
+	for _, metric := range dev.ListMetrics() {
+		if metric.Name == name {
+			metricType = metric.MetricType
+		}
+	}
+	values := make([]float64, len(latest))
+	for i, point := range latest {
+		values[i] = point.Value
+	}
+	return metricType, values, nil
+}
+
+func (e *LocalExecutor) MeasurementStats() (*runtime.MetricStoreStats, error) {
+	return e.Service.DevEnv.MeasurementStats()
+}
+
+func (e *LocalExecutor) ExportMetrics(exporterURL string) error {
+	exporter, err := runtime.NewMetricExporter(exporterURL)
+	if err != nil {
+		return err
+	}
+	e.Service.DevEnv.AddMetricExporter(exporter)
+	return nil
+}
+
+func (e *LocalExecutor) ExportDashboard() (json.RawMessage, error) {
+	return e.Service.DevEnv.ExportGrafanaDashboard()
+}
+
+func (e *LocalExecutor) Attribution(target string, runs int) (*runtime.LatencyAttribution, error) {
+	return e.Service.DevEnv.Attribution(target, runs)
+}
+
+func (e *LocalExecutor) Trace(target string) (*runtime.TraceTree, error) {
+	component, method, err := splitREPLTarget(target)
+	if err != nil {
+		return nil, err
+	}
+	data, err := e.Service.DevEnv.ExecuteTrace(component, method)
+	if err != nil {
+		return nil, err
+	}
+	return runtime.BuildTraceTree(data), nil
+}
+
+func (e *LocalExecutor) EvaluateFlows() (*runtime.FlowAnalysisResult, error) {
+	return e.Service.DevEnv.EvaluateFlows("runtime")
+}
+
+func (e *LocalExecutor) Close() error {
+	return e.Service.DevEnv.Close()
+}
+
+// RemoteExecutor forwards commands to a running `sdl serve` instance.
+type RemoteExecutor struct {
+	WorkspaceID string
+}
+
+// Load does not report the loaded systems as LoadFileResponse has no field
+// for them.
+func (e *RemoteExecutor) Load(filePath string) ([]services.LoadedSystem, error) {
+	return nil, withWorkspaceClient(func(client v1s.WorkspaceServiceClient, ctx context.Context) error {
+		_, err := client.LoadFile(ctx, &v1.LoadFileRequest{WorkspaceId: e.WorkspaceID, SdlFilePath: filePath})
+		return err
+	})
+}
+
+func (e *RemoteExecutor) Use(systemName string) error {
+	return withWorkspaceClient(func(client v1s.WorkspaceServiceClient, ctx context.Context) error {
+		_, err := client.UseSystem(ctx, &v1.UseSystemRequest{WorkspaceId: e.WorkspaceID, SystemName: systemName})
+		return err
+	})
+}
+
+func (e *RemoteExecutor) Set(path, value string) error {
+	return withWorkspaceClient(func(client v1s.WorkspaceServiceClient, ctx context.Context) error {
+		resp, err := client.SetParameter(ctx, &v1.SetParameterRequest{WorkspaceId: e.WorkspaceID, Path: path, NewValue: value})
+		if err != nil {
+			return err
+		}
+		if !resp.Success && resp.ErrorMessage != "" {
+			return fmt.Errorf("%s", resp.ErrorMessage)
+		}
+		return nil
+	})
+}
+
+// Run is not part of the workspace service yet so remote runs only support local mode.
+func (e *RemoteExecutor) Run(opts services.RunOptions) ([]types.RunResult, bool, error) {
+	return nil, false, fmt.Errorf("run is not supported against a server yet, use local mode")
+}
+
+// DisplayPrecision is not reported by the workspace service yet so remote
+// values are shown with the default precision.
+func (e *RemoteExecutor) DisplayPrecision() int {
+	return core.DefaultDisplayPrecision
+}
+
+func (e *RemoteExecutor) ListRuns(offset, limit int) (runs []services.RunRecord, total int, err error) {
+	err = withWorkspaceClient(func(client v1s.WorkspaceServiceClient, ctx context.Context) error {
+		resp, err := client.ListRuns(ctx, &v1.ListRunsRequest{WorkspaceId: e.WorkspaceID, Offset: int32(offset), Limit: int32(limit)})
+		if err != nil {
+			return err
+		}
+		total = int(resp.Total)
+		for _, run := range resp.Runs {
+			record := services.RunRecord{
+				ID:         int(run.Id),
+				Target:     run.Target,
+				Timestamp:  time.Unix(0, int64(run.Timestamp*1e9)),
+				ParamsHash: run.ParamsHash,
+				Cached:     run.Cached,
+			}
+			if summary := run.Summary; summary != nil {
+				record.Summary = types.RunSummary{
+					Count:  int(summary.Count),
+					Errors: int(summary.Errors),
+					Mean:   fromProtoEstimate(summary.Mean),
+					P50:    fromProtoEstimate(summary.P50),
+					P95:    fromProtoEstimate(summary.P95),
+					P99:    fromProtoEstimate(summary.P99),
+				}
+			}
+			runs = append(runs, record)
+		}
+		return nil
+	})
+	return
+}
+
+func fromProtoEstimate(e *v1.LatencyEstimate) types.Estimate {
+	return types.Estimate{Value: e.GetValue(), CI: types.ConfidenceInterval{Low: e.GetCiLow(), High: e.GetCiHigh()}}
+}
+
+func (e *RemoteExecutor) SetDecisionLog(w io.Writer) error {
+	return fmt.Errorf("decision logs are not supported against a server yet, use local mode")
+}
+
+func (e *RemoteExecutor) AddGenerator(name, component, method string, rate float64, maxRequests int) error {
+	if maxRequests != 0 {
+		return fmt.Errorf("generator request counts are not supported against a server yet, use local mode")
+	}
+	return withWorkspaceClient(func(client v1s.WorkspaceServiceClient, ctx context.Context) error {
+		_, err := client.AddGenerator(ctx, &v1.AddGeneratorRequest{
+			WorkspaceId: e.WorkspaceID,
+			Generator:   &v1.Generator{Name: name, Component: component, Method: method, Rate: rate},
+		})
+		return err
+	})
+}
+
+func (e *RemoteExecutor) AddPresetGenerator(name, component, method, preset string, opts map[string]string, maxRequests int) error {
+	return fmt.Errorf("generator presets are not supported against a server yet, use local mode")
+}
+
+func (e *RemoteExecutor) StartGenerators(names ...string) error {
+	return withWorkspaceClient(func(client v1s.WorkspaceServiceClient, ctx context.Context) error {
+		if len(names) == 0 {
+			_, err := client.StartAllGenerators(ctx, &v1.StartAllGeneratorsRequest{WorkspaceId: e.WorkspaceID})
+			return err
+		}
+		for _, name := range names {
+			if _, err := client.StartGenerator(ctx, &v1.StartGeneratorRequest{WorkspaceId: e.WorkspaceID, GeneratorName: name}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (e *RemoteExecutor) StopGenerators(names ...string) error {
+	return withWorkspaceClient(func(client v1s.WorkspaceServiceClient, ctx context.Context) error {
+		if len(names) == 0 {
+			_, err := client.StopAllGenerators(ctx, &v1.StopAllGeneratorsRequest{WorkspaceId: e.WorkspaceID})
+			return err
+		}
+		for _, name := range names {
+			if _, err := client.StopGenerator(ctx, &v1.StopGeneratorRequest{WorkspaceId: e.WorkspaceID, GeneratorName: name}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (e *RemoteExecutor) RemoveGenerator(name string, autoMetrics bool) error {
+	return withWorkspaceClient(func(client v1s.WorkspaceServiceClient, ctx context.Context) error {
+		if _, err := client.DeleteGenerator(ctx, &v1.DeleteGeneratorRequest{WorkspaceId: e.WorkspaceID, GeneratorName: name}); err != nil {
+			return err
+		}
+		if !autoMetrics {
+			return nil
+		}
+		for _, metric := range services.AutoMetricNames(name) {
+			if _, err := client.DeleteMetric(ctx, &v1.DeleteMetricRequest{WorkspaceId: e.WorkspaceID, MetricName: metric}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (e *RemoteExecutor) AddMetric(metric *v1.Metric) error {
+	return withWorkspaceClient(func(client v1s.WorkspaceServiceClient, ctx context.Context) error {
+		_, err := client.AddMetric(ctx, &v1.AddMetricRequest{WorkspaceId: e.WorkspaceID, Metric: metric})
+		return err
+	})
+}
+
+// AddAutoMetrics is not part of the workspace service yet so it only supports local mode.
+func (e *RemoteExecutor) AddAutoMetrics() ([]*v1.Metric, error) {
+	return nil, fmt.Errorf("measure auto is not supported against a server yet, use local mode")
+}
+
+// AddMetricTemplate is not part of the workspace service yet so it only supports local mode.
+func (e *RemoteExecutor) AddMetricTemplate(component, metricType string) ([]*v1.Metric, error) {
+	return nil, fmt.Errorf("measure template is not supported against a server yet, use local mode")
+}
+
+func (e *RemoteExecutor) MetricData(name string, points int) (metricType string, values []float64, err error) {
+	err = withWorkspaceClient(func(client v1s.WorkspaceServiceClient, ctx context.Context) error {
+		listResp, err := client.ListMetrics(ctx, &v1.ListMetricsRequest{WorkspaceId: e.WorkspaceID})
+		if err != nil {
+			return err
+		}
+		for _, metric := range listResp.Metrics {
+			if metric.Name == name {
+				metricType = metric.MetricType
+			}
+		}
+		resp, err := client.QueryMetrics(ctx, &v1.QueryMetricsRequest{
+			WorkspaceId: e.WorkspaceID,
+			MetricName:  name,
+			EndTime:     float64(time.Now().Unix()),
+			Limit:       int32(points),
+		})
+		if err != nil {
+			return err
+		}
+		// Points come back newest first
+		for i := len(resp.Points) - 1; i >= 0; i-- {
+			values = append(values, resp.Points[i].Value)
+		}
+		return nil
+	})
+	return metricType, values, err
+}
+
+func (e *RemoteExecutor) MeasurementStats() (stats *runtime.MetricStoreStats, err error) {
+	err = withWorkspaceClient(func(client v1s.WorkspaceServiceClient, ctx context.Context) error {
+		resp, err := client.GetMeasurementStats(ctx, &v1.GetMeasurementStatsRequest{WorkspaceId: e.WorkspaceID})
+		if err != nil {
+			return err
+		}
+		stats = &runtime.MetricStoreStats{
+			TotalRows:     resp.TotalRows,
+			RowsPerMetric: resp.RowsPerMetric,
+			SizeBytes:     resp.SizeBytes,
+			WriteRate:     resp.WriteRate,
+		}
+		if resp.TotalRows > 0 {
+			stats.OldestTimestamp = time.Unix(int64(resp.OldestTimestamp), 0)
+			stats.NewestTimestamp = time.Unix(int64(resp.NewestTimestamp), 0)
+		}
+		return nil
+	})
+	return
+}
+
+// ExportMetrics is not part of the workspace service yet so it only supports local mode.
+func (e *RemoteExecutor) ExportMetrics(exporterURL string) error {
+	return fmt.Errorf("metric export is not supported against a server yet, use local mode")
+}
+
+// ExportDashboard is not part of the workspace service yet so it only supports local mode.
+func (e *RemoteExecutor) ExportDashboard() (json.RawMessage, error) {
+	return nil, fmt.Errorf("dashboard export is not supported against a server yet, use local mode")
+}
+
+// Attribution is not part of the workspace service yet so it only supports local mode.
+func (e *RemoteExecutor) Attribution(target string, runs int) (*runtime.LatencyAttribution, error) {
+	return nil, fmt.Errorf("attribution is not supported against a server yet, use local mode")
+}
+
+func (e *RemoteExecutor) Trace(target string) (tree *runtime.TraceTree, err error) {
+	component, method, err := splitREPLTarget(target)
+	if err != nil {
+		return nil, err
+	}
+	err = withWorkspaceClient(func(client v1s.WorkspaceServiceClient, ctx context.Context) error {
+		resp, err := client.ExecuteTrace(ctx, &v1.ExecuteTraceRequest{WorkspaceId: e.WorkspaceID, Component: component, Method: method})
+		if err != nil {
+			return err
+		}
+		tree = runtime.BuildTraceTree(runtime.TraceDataFromProto(resp.TraceData))
+		return nil
+	})
+	return
+}
+
+// EvaluateFlows only carries the rates, iteration count and warnings back from
+// a server, not the per iteration history.
+func (e *RemoteExecutor) EvaluateFlows() (result *runtime.FlowAnalysisResult, err error) {
+	err = withWorkspaceClient(func(client v1s.WorkspaceServiceClient, ctx context.Context) error {
+		resp, err := client.EvaluateFlows(ctx, &v1.EvaluateFlowsRequest{WorkspaceId: e.WorkspaceID, Strategy: "runtime"})
+		if err != nil {
+			return err
+		}
+		result = &runtime.FlowAnalysisResult{
+			Strategy:   resp.Strategy,
+			Status:     runtime.FlowStatus(resp.Status),
+			Iterations: int(resp.Iterations),
+			Warnings:   resp.Warnings,
+			Flows:      runtime.FlowData{ComponentRates: resp.ComponentRates},
+		}
+		return nil
+	})
+	return
+}
+
+func (e *RemoteExecutor) Close() error { return nil }
@@ -0,0 +1,86 @@
+package commands
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/panyam/sdl/lib/parser"
+	"github.com/spf13/cobra"
+)
+
+var fmtCmd = &cobra.Command{
+	Use:   "fmt <file|dir|glob...>",
+	Short: "Formats SDL files",
+	Long: `The fmt command lays out SDL files canonically.  Only whitespace changes:
+lines are indented by nesting, trailing whitespace and extra blank lines are
+removed, and comments are kept.  Formatted files are printed unless -w is
+given, in which case they are rewritten in place.
+
+With --check nothing is modified; the files that are not already formatted
+are listed and the command exits non-zero if there are any, making it
+suitable for CI.`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		check, _ := cmd.Flags().GetBool("check")
+		write, _ := cmd.Flags().GetBool("write")
+		if check && write {
+			fmt.Fprintln(os.Stderr, "Error: --check and --write cannot be used together")
+			os.Exit(2)
+		}
+		if code := runFmt(os.Stdout, args, check, write); code != 0 {
+			os.Exit(code)
+		}
+	},
+}
+
+// runFmt formats the files matched by patterns and returns the exit code:
+// 1 when checking and a file is not formatted, 2 when a file cannot be read
+// or parsed.
+func runFmt(out io.Writer, patterns []string, check, write bool) int {
+	files, err := expandSDLPaths(patterns)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+
+	code := 0
+	for _, path := range files {
+		src, err := os.ReadFile(path)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			code = 2
+			continue
+		}
+		formatted, err := parser.Format(src)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", path, err)
+			code = 2
+			continue
+		}
+		switch {
+		case check:
+			if !bytes.Equal(src, formatted) {
+				fmt.Fprintln(out, path)
+				code = max(code, 1)
+			}
+		case write:
+			if !bytes.Equal(src, formatted) {
+				if err := os.WriteFile(path, formatted, 0644); err != nil {
+					fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+					code = 2
+				}
+			}
+		default:
+			out.Write(formatted)
+		}
+	}
+	return code
+}
+
+func init() {
+	fmtCmd.Flags().Bool("check", false, "List files that are not formatted and exit non-zero if there are any")
+	fmtCmd.Flags().BoolP("write", "w", false, "Rewrite files in place")
+	AddCommand(fmtCmd)
+}
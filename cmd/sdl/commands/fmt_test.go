@@ -0,0 +1,60 @@
+package commands
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestFmtCheck verifies that --check reports exactly the files that are not
+// formatted, exits non-zero and leaves them unmodified.
+func TestFmtCheck(t *testing.T) {
+	dir := t.TempDir()
+	formatted := `// A formatted file
+component Server {
+  param Timeout Int = 100
+
+  /* Handles
+     a request */
+  method Handle() Bool {
+    return true
+  }
+}
+
+system App(server Server) {
+}
+`
+	unformatted := `// A file that needs formatting
+component Server {
+      param Timeout Int = 100   
+
+
+    method Handle() Bool {
+  return true
+    }
+}
+system App(server Server) {
+}
+`
+	formattedPath := filepath.Join(dir, "formatted.sdl")
+	unformattedPath := filepath.Join(dir, "unformatted.sdl")
+	require.NoError(t, os.WriteFile(formattedPath, []byte(formatted), 0644))
+	require.NoError(t, os.WriteFile(unformattedPath, []byte(unformatted), 0644))
+
+	var out bytes.Buffer
+	assert.Equal(t, 1, runFmt(&out, []string{filepath.Join(dir, "*.sdl")}, true, false))
+	assert.Equal(t, unformattedPath+"\n", out.String())
+	src, err := os.ReadFile(unformattedPath)
+	require.NoError(t, err)
+	assert.Equal(t, unformatted, string(src), "check must not modify files")
+
+	out.Reset()
+	assert.Equal(t, 0, runFmt(&out, []string{dir}, false, true))
+	out.Reset()
+	assert.Equal(t, 0, runFmt(&out, []string{dir}, true, false))
+	assert.Empty(t, out.String())
+}
@@ -48,6 +48,10 @@ var genAddCmd = &cobra.Command{
 			fmt.Printf("❌ Invalid rate '%s': must be a number\n", rateStr)
 			return
 		}
+		if count, _ := cmd.Flags().GetInt("count"); count != 0 {
+			fmt.Println("❌ --count is not supported against a server yet, use 'gen add ... --count' in 'sdl repl'")
+			return
+		}
 		err = withWorkspaceClient(func(client v1s.WorkspaceServiceClient, ctx context.Context) error {
 			_, err := client.AddGenerator(ctx, &v1.AddGeneratorRequest{
 				Generator: &v1.Generator{
@@ -360,6 +364,7 @@ func init() {
 
 	// Add --apply-flows flag to commands that modify generators
 	genAddCmd.Flags().BoolVar(&applyFlows, "apply-flows", false, "Automatically evaluate and apply flow rates after adding generator")
+	genAddCmd.Flags().Int("count", 0, "Stop the generator after this many calls (0 runs until stopped)")
 	genRemoveCmd.Flags().BoolVar(&applyFlows, "apply-flows", false, "Automatically evaluate and apply flow rates after removing generator")
 	genUpdateCmd.Flags().BoolVar(&applyFlows, "apply-flows", false, "Automatically evaluate and apply flow rates after updating generator")
 	genStartCmd.Flags().BoolVar(&applyFlows, "apply-flows", false, "Automatically evaluate and apply flow rates after starting generator")
@@ -2,6 +2,7 @@ package commands
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 	"strings"
@@ -9,6 +10,8 @@ import (
 
 	v1 "github.com/panyam/sdl/gen/go/sdl/v1/models"
 	v1s "github.com/panyam/sdl/gen/go/sdl/v1/services"
+	"github.com/panyam/sdl/lib/core"
+	"github.com/panyam/sdl/lib/runtime"
 	"github.com/spf13/cobra"
 )
 
@@ -31,7 +34,10 @@ Examples:
   sdl metrics add db_calls database Query Update Insert --type count
   
   # Track utilization for a component (no methods needed)
-  sdl metrics add db_utilization database --type utilization`,
+  sdl metrics add db_utilization database --type utilization
+
+  # Average the numeric values returned (or sampled from Outcomes) by a method
+  sdl metrics add payload_size storage PayloadSize --type value --aggregation avg`,
 	Args: cobra.MinimumNArgs(2),
 	Run: func(cmd *cobra.Command, args []string) {
 		id := args[0]
@@ -58,8 +64,8 @@ Examples:
 		window, _ := cmd.Flags().GetFloat64("window")
 
 		// Validate metric type
-		if metricType != "count" && metricType != "latency" && metricType != "utilization" {
-			fmt.Fprintf(os.Stderr, "Error: Invalid metric type '%s'. Must be 'count', 'latency', or 'utilization'\n", metricType)
+		if metricType != "count" && metricType != "latency" && metricType != "utilization" && metricType != "value" {
+			fmt.Fprintf(os.Stderr, "Error: Invalid metric type '%s'. Must be 'count', 'latency', 'utilization' or 'value'\n", metricType)
 			os.Exit(1)
 		}
 
@@ -183,8 +189,71 @@ var queryMetricsCmd = &cobra.Command{
 	Long:  "Query metric data points. The data is already aggregated according to the metric's configuration.",
 	Args:  cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
-		// TODO: QueryMetrics not yet on WorkspaceService
-		fmt.Println("Metrics query not yet available — will be added when QueryMetrics is on WorkspaceService")
+		metricID := args[0]
+		duration, _ := cmd.Flags().GetDuration("duration")
+		limit, _ := cmd.Flags().GetInt32("limit")
+		asJSON, _ := cmd.Flags().GetBool("json")
+		precision, _ := cmd.Flags().GetInt("precision")
+
+		err := withWorkspaceClient(func(client v1s.WorkspaceServiceClient, ctx context.Context) error {
+			// Look up the metric type so values can be shown in sensible units
+			metricType := ""
+			if listResp, err := client.ListMetrics(ctx, &v1.ListMetricsRequest{}); err == nil {
+				for _, m := range listResp.Metrics {
+					if m.Name == metricID {
+						metricType = m.MetricType
+					}
+				}
+			}
+
+			now := time.Now()
+			resp, err := client.QueryMetrics(ctx, &v1.QueryMetricsRequest{
+				MetricName: metricID,
+				StartTime:  float64(now.Add(-duration).Unix()),
+				EndTime:    float64(now.Unix()),
+				Limit:      limit,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to query metric: %v", err)
+			}
+
+			if asJSON {
+				// Raw values are kept alongside the formatted ones for machine consumption
+				type jsonPoint struct {
+					Timestamp float64 `json:"timestamp"`
+					Value     float64 `json:"value"`
+					Formatted string  `json:"formatted"`
+				}
+				points := make([]jsonPoint, 0, len(resp.Points))
+				for _, p := range resp.Points {
+					points = append(points, jsonPoint{p.Timestamp, p.Value, runtime.FormatMetricValue(metricType, p.Value, precision)})
+				}
+				data, err := json.MarshalIndent(points, "", "  ")
+				if err != nil {
+					return err
+				}
+				fmt.Println(string(data))
+				return nil
+			}
+
+			if len(resp.Points) == 0 {
+				fmt.Printf("No data points for metric '%s'\n", metricID)
+				return nil
+			}
+			fmt.Printf("%-10s %12s\n", "Time", "Value")
+			fmt.Println(strings.Repeat("-", 23))
+			for _, p := range resp.Points {
+				fmt.Printf("%-10s %12s\n",
+					time.Unix(int64(p.Timestamp), 0).Format("15:04:05"),
+					runtime.FormatMetricValue(metricType, p.Value, precision))
+			}
+			return nil
+		})
+
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
 	},
 }
 
@@ -196,7 +265,7 @@ func init() {
 	metricsCmd.AddCommand(queryMetricsCmd)
 
 	// Add metric command flags
-	addMetricCmd.Flags().String("type", "latency", "Metric type: 'count', 'latency', or 'utilization'")
+	addMetricCmd.Flags().String("type", "latency", "Metric type: 'count', 'latency', 'utilization' or 'value'")
 	addMetricCmd.Flags().String("aggregation", "avg", "Aggregation function (e.g., sum, avg, p95)")
 	addMetricCmd.Flags().Float64("window", 10.0, "Aggregation window in seconds")
 
@@ -204,6 +273,7 @@ func init() {
 	queryMetricsCmd.Flags().Duration("duration", 5*time.Minute, "Time duration to query (e.g., 5m, 1h)")
 	queryMetricsCmd.Flags().Int32("limit", 100, "Maximum number of points to return")
 	queryMetricsCmd.Flags().Bool("json", false, "Output as JSON")
+	queryMetricsCmd.Flags().Int("precision", core.DefaultDisplayPrecision, "Significant figures for displayed values")
 
 	// Add to root
 	AddCommand(metricsCmd)
@@ -278,7 +278,7 @@ func plotFromLiveRun(systemName, instanceName, methodName, outputFile, title str
 		os.Exit(1)
 	}
 
-	l := loader.NewLoader(nil, nil, 10)
+	l := loader.NewLoader(nil, nil, loader.DefaultMaxImportDepth)
 	rt := runtime.NewRuntime(l)
 	fi, _ := rt.LoadFile(dslFilePath)
 	system, _ := fi.NewSystem(systemName, true)
@@ -292,7 +292,7 @@ func plotFromLiveRun(systemName, instanceName, methodName, outputFile, title str
 
 	fmt.Printf("Running live simulation for %s.%s.%s...\n", systemName, instanceName, methodName)
 
-	runtime.RunCallInBatches(system, instanceName, methodName, numBatches, batchSize, numWorkers, func(batch int, batchVals []decl.Value) {
+	_, err := runtime.RunCallInBatches(system, instanceName, methodName, numBatches, batchSize, numWorkers, func(batch int, batchVals []decl.Value) {
 		if (batch+1)%10 == 0 || batch == numBatches-1 {
 			log.Printf("... processed batch %d / %d", batch+1, numBatches)
 		}
@@ -317,6 +317,10 @@ func plotFromLiveRun(systemName, instanceName, methodName, outputFile, title str
 			avgVals[batch] = viz.DataPoint{X: timestamp, Y: 0}
 		}
 	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Simulation failed: %v\n", err)
+		os.Exit(1)
+	}
 
 	fmt.Println("Simulation finished. Generating latency plot...")
 	generateLatencyPlot(outputFile, title, avgVals, p50Vals, p90Vals, p99Vals)
@@ -0,0 +1,743 @@
+package commands
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	v1 "github.com/panyam/sdl/gen/go/sdl/v1/models"
+	"github.com/panyam/sdl/lib/loader"
+	"github.com/panyam/sdl/lib/runtime"
+	"github.com/panyam/sdl/lib/types"
+	"github.com/panyam/sdl/services"
+	"github.com/spf13/cobra"
+)
+
+const replHelp = `Commands:
+  load <file>                               Load an SDL file
+  use <system>                              Select the active system
+  set <path> <value>                        Set a parameter value
+  run [--under-load] [--no-cache] <component.method> <calls> [seed]
+                                            Run a batch simulation, --under-load measures while
+                                            the generators drive background load, --no-cache
+                                            recomputes instead of reusing an identical run
+  run --debug <component.method> [seed]     Make one call and print each random decision it
+                                            makes as a line of JSON
+  runs [offset] [limit]                     List past runs newest first (default: the latest 10)
+  attribute <component.method> <calls>      Break down latency by the method it was spent in
+  trace diff <component.method> <path> <value>
+                                            Trace before and after a set and show where latency changed
+  flows [--verbose]                         Solve arrival rates, --verbose shows each iteration's changes
+  gen add <id> <component.method> <rate> [--count n]
+                                            Create a traffic generator, stopping after n calls
+  gen add <id> <component.method> preset:diurnal --peak r [--base r] [--period 24h]
+  gen add <id> <component.method> preset:spike --base r --peak r --at 30s [--for 10s]
+                                            Create a generator following a traffic shape
+  gen start|stop [id...]                    Start or stop generators (all if none given)
+  gen remove <id> [--metrics]               Remove a generator, --metrics also removes its
+                                            measure auto metrics
+  measure <id> <component.method|system> [type] [aggregation]
+                                            Add a metric (default: latency avg), system measures
+                                            every generator call end to end
+  measure auto                              Add latency and throughput metrics named after
+                                            each generator for its target
+  measure template <component> <type>       Add a metric named after each instance of a
+                                            component type, also for instances added later
+  measure data <id> [--points 60]           Chart the latest values of a metric with their
+                                            min, max and last value
+  measure export-to <url>                   Push closed metric windows to statsd://host:port
+                                            or influxdb://host:port/database
+  measure export-dashboard <file>           Write a Grafana dashboard of the metrics
+  stats                                     Show metric store statistics
+  help                                      Show this help
+  exit                                      Leave the REPL`
+
+// REPL reads commands line by line and routes them to an Executor.
+type REPL struct {
+	Executor Executor
+	Out      io.Writer
+	Recorder *SessionRecorder // Records executed commands when set
+}
+
+// NewREPL creates a REPL writing its output to out.
+func NewREPL(executor Executor, out io.Writer) *REPL {
+	return &REPL{Executor: executor, Out: out}
+}
+
+// Run reads and executes commands from in until EOF or an exit command.
+func (r *REPL) Run(in io.Reader) {
+	scanner := bufio.NewScanner(in)
+	for {
+		fmt.Fprint(r.Out, "sdl> ")
+		if !scanner.Scan() {
+			fmt.Fprintln(r.Out)
+			return
+		}
+		quit, err := r.Execute(scanner.Text())
+		if err != nil {
+			fmt.Fprintf(r.Out, "❌ %v\n", err)
+		}
+		if quit {
+			return
+		}
+	}
+}
+
+// Execute runs a single command line.  quit is true when the REPL should exit.
+func (r *REPL) Execute(line string) (quit bool, err error) {
+	args := strings.Fields(line)
+	if len(args) == 0 || strings.HasPrefix(args[0], "#") {
+		return false, nil
+	}
+	if r.Recorder != nil {
+		if err := r.Recorder.Record(line); err != nil {
+			fmt.Fprintf(r.Out, "⚠️  could not record command: %v\n", err)
+		}
+	}
+
+	cmd, args := args[0], args[1:]
+	switch cmd {
+	case "exit", "quit":
+		return true, nil
+	case "help":
+		fmt.Fprintln(r.Out, replHelp)
+	case "load":
+		if len(args) != 1 {
+			return false, fmt.Errorf("usage: load <file>")
+		}
+		systems, err := r.Executor.Load(args[0])
+		if err != nil {
+			return false, err
+		}
+		fmt.Fprintf(r.Out, "✅ Loaded %s\n", args[0])
+		for _, system := range systems {
+			var params []string
+			for _, param := range system.Parameters {
+				params = append(params, param.Name+" "+param.Component)
+			}
+			fmt.Fprintf(r.Out, "   system %s(%s)\n", system.Name, strings.Join(params, ", "))
+			if len(system.EntryPoints) > 0 {
+				fmt.Fprintf(r.Out, "     entry points: %s\n", strings.Join(system.EntryPoints, ", "))
+			}
+		}
+	case "use":
+		if len(args) != 1 {
+			return false, fmt.Errorf("usage: use <system>")
+		}
+		if err := r.Executor.Use(args[0]); err != nil {
+			return false, err
+		}
+		fmt.Fprintf(r.Out, "✅ Now using system: %s\n", args[0])
+	case "set":
+		if len(args) != 2 {
+			return false, fmt.Errorf("usage: set <path> <value>")
+		}
+		if err := r.Executor.Set(args[0], args[1]); err != nil {
+			return false, err
+		}
+		fmt.Fprintf(r.Out, "✅ Set %s = %s\n", args[0], args[1])
+	case "run":
+		return false, r.run(args)
+	case "runs":
+		return false, r.runs(args)
+	case "attribute":
+		return false, r.attribute(args)
+	case "trace":
+		return false, r.trace(args)
+	case "flows":
+		return false, r.flows(args)
+	case "gen":
+		return false, r.gen(args)
+	case "measure":
+		return false, r.measure(args)
+	case "stats":
+		return false, r.stats()
+	default:
+		return false, fmt.Errorf("unknown command '%s', type 'help' for a list of commands", cmd)
+	}
+	return false, nil
+}
+
+func (r *REPL) run(args []string) error {
+	if len(args) > 0 && args[0] == "--debug" {
+		return r.debugRun(args[1:])
+	}
+	underLoad, noCache := slices.Contains(args, "--under-load"), slices.Contains(args, "--no-cache")
+	args = slices.DeleteFunc(args, func(arg string) bool { return arg == "--under-load" || arg == "--no-cache" })
+	if len(args) < 2 || len(args) > 3 {
+		return fmt.Errorf("usage: run [--under-load] [--no-cache] <component.method> <calls> [seed]")
+	}
+	calls, err := strconv.Atoi(args[1])
+	if err != nil {
+		return fmt.Errorf("invalid call count '%s': must be a number", args[1])
+	}
+	opts := services.RunOptions{Target: args[0], Runs: calls, UnderLoad: underLoad, NoCache: noCache}
+	if len(args) == 3 {
+		if opts.Seed, err = strconv.ParseInt(args[2], 10, 64); err != nil {
+			return fmt.Errorf("invalid seed '%s': must be a number", args[2])
+		}
+	}
+
+	results, cached, err := r.Executor.Run(opts)
+	if err != nil {
+		return err
+	}
+	suffix := ""
+	if cached {
+		suffix = " (cached)"
+	} else if underLoad {
+		suffix = " (under load)"
+	}
+	fmt.Fprintf(r.Out, "✅ Ran %s %d times, %s%s\n", args[0], len(results), formatRunSummary(types.SummarizeRuns(results), r.Executor.DisplayPrecision()), suffix)
+	return nil
+}
+
+// debugRun makes a single call and prints the decisions it made before its
+// result.
+func (r *REPL) debugRun(args []string) error {
+	if len(args) < 1 || len(args) > 2 {
+		return fmt.Errorf("usage: run --debug <component.method> [seed]")
+	}
+	opts := services.RunOptions{Target: args[0], Debug: true}
+	if len(args) == 2 {
+		var err error
+		if opts.Seed, err = strconv.ParseInt(args[1], 10, 64); err != nil {
+			return fmt.Errorf("invalid seed '%s': must be a number", args[1])
+		}
+	}
+	if err := r.Executor.SetDecisionLog(r.Out); err != nil {
+		return err
+	}
+	defer r.Executor.SetDecisionLog(nil)
+	results, _, err := r.Executor.Run(opts)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(r.Out, "✅ %s returned %s in %.2fms\n", args[0], results[0].ResultValue, results[0].Latency)
+	return nil
+}
+
+// runs lists a page of the run history.
+func (r *REPL) runs(args []string) error {
+	if len(args) > 2 {
+		return fmt.Errorf("usage: runs [offset] [limit]")
+	}
+	offset, limit := 0, 10
+	for i, arg := range args {
+		n, err := strconv.Atoi(arg)
+		if err != nil || n < 0 {
+			return fmt.Errorf("invalid %s '%s': must be a non-negative integer", []string{"offset", "limit"}[i], arg)
+		}
+		if i == 0 {
+			offset = n
+		} else {
+			limit = n
+		}
+	}
+	runs, total, err := r.Executor.ListRuns(offset, limit)
+	if err != nil {
+		return err
+	}
+	if len(runs) == 0 {
+		fmt.Fprintf(r.Out, "No runs to show (%d in history)\n", total)
+		return nil
+	}
+	fmt.Fprintf(r.Out, "Runs %d-%d of %d, newest first:\n", offset+1, offset+len(runs), total)
+	for _, run := range runs {
+		suffix := ""
+		if run.Cached {
+			suffix = " (cached)"
+		}
+		fmt.Fprintf(r.Out, "  #%d %s %s params=%s %d calls, %s%s\n", run.ID, run.Timestamp.Format(time.RFC3339), run.Target, run.ParamsHash, run.Summary.Count, formatRunSummary(run.Summary, r.Executor.DisplayPrecision()), suffix)
+	}
+	return nil
+}
+
+func (r *REPL) attribute(args []string) error {
+	if len(args) != 2 {
+		return fmt.Errorf("usage: attribute <component.method> <calls>")
+	}
+	calls, err := strconv.Atoi(args[1])
+	if err != nil {
+		return fmt.Errorf("invalid call count '%s': must be a number", args[1])
+	}
+	attribution, err := r.Executor.Attribution(args[0], calls)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(r.Out, "Latency of %s over %d runs: %.2fms total\n", args[0], attribution.Runs, attribution.Total*1000)
+	fmt.Fprintf(r.Out, "%-30s %12s %8s\n", "METHOD", "SELF (ms)", "SHARE")
+	for _, entry := range attribution.Entries() {
+		fmt.Fprintf(r.Out, "%-30s %12.2f %7.1f%%\n", entry.Method, entry.SelfTime*1000, entry.Fraction*100)
+	}
+	return nil
+}
+
+func (r *REPL) trace(args []string) error {
+	if len(args) != 4 || args[0] != "diff" {
+		return fmt.Errorf("usage: trace diff <component.method> <path> <value>")
+	}
+	target, path, value := args[1], args[2], args[3]
+	before, err := r.Executor.Trace(target)
+	if err != nil {
+		return err
+	}
+	if err := r.Executor.Set(path, value); err != nil {
+		return err
+	}
+	after, err := r.Executor.Trace(target)
+	if err != nil {
+		return err
+	}
+
+	diff := runtime.DiffTraces(before, after)
+	fmt.Fprintf(r.Out, "Latency of %s changed by %+.2fms after setting %s = %s\n", target, diff.Delta*1000, path, value)
+	diff.Walk(func(node *runtime.TraceDiffNode, depth int) {
+		if node.Delta == 0 && !node.Mismatched() && !node.BranchChanged() {
+			return
+		}
+		note := ""
+		switch {
+		case node.Before == nil:
+			note = " (only after)"
+		case node.After == nil:
+			note = " (only before)"
+		case node.BranchChanged():
+			note = fmt.Sprintf(" (returned %s, was %s)", node.After.Branch, node.Before.Branch)
+		}
+		label := strings.Repeat("  ", depth+1) + traceDiffLabel(node)
+		fmt.Fprintf(r.Out, "%-40s %+10.2fms%s\n", label, node.Delta*1000, note)
+	})
+	if largest := diff.LargestIncrease(); largest != nil {
+		fmt.Fprintf(r.Out, "Most time added in %s: %+.2fms\n", traceDiffLabel(largest), largest.SelfDelta*1000)
+	}
+	if n := len(diff.Mismatches()); n > 0 {
+		fmt.Fprintf(r.Out, "⚠️  %d calls were only made in one of the traces, a different branch was sampled\n", n)
+	}
+	return nil
+}
+
+func traceDiffLabel(node *runtime.TraceDiffNode) string {
+	if node.Component == "" {
+		return node.Method
+	}
+	return node.Component + "." + node.Method
+}
+
+func (r *REPL) flows(args []string) error {
+	verbose := len(args) == 1 && args[0] == "--verbose"
+	if len(args) > 0 && !verbose {
+		return fmt.Errorf("usage: flows [--verbose]")
+	}
+	result, err := r.Executor.EvaluateFlows()
+	if err != nil {
+		return err
+	}
+	if verbose {
+		for i, iter := range result.History {
+			fmt.Fprintf(r.Out, "Iteration %d: max change %.4g\n", i+1, iter.MaxChange)
+			for _, name := range slices.Sorted(maps.Keys(iter.Changes)) {
+				fmt.Fprintf(r.Out, "  %-40s %+.4g\n", name, iter.Changes[name])
+			}
+		}
+	}
+	fmt.Fprintf(r.Out, "Flows %s after %d iterations (residual %.4g)\n", result.Status, result.Iterations, result.Residual)
+	rates := result.Flows.ComponentRates
+	for _, name := range slices.Sorted(maps.Keys(rates)) {
+		fmt.Fprintf(r.Out, "  %-40s %10.2f rps\n", name, rates[name])
+	}
+	for _, warning := range result.Warnings {
+		fmt.Fprintf(r.Out, "⚠️  %s\n", warning)
+	}
+	return nil
+}
+
+func (r *REPL) gen(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("usage: gen add|start|stop ...")
+	}
+	switch args[0] {
+	case "add":
+		if len(args) >= 4 && strings.HasPrefix(args[3], "preset:") {
+			return r.genAddPreset(args[1], args[2], strings.TrimPrefix(args[3], "preset:"), args[4:])
+		}
+		count := 0
+		if len(args) == 6 && args[4] == "--count" {
+			n, err := strconv.Atoi(args[5])
+			if err != nil || n <= 0 {
+				return fmt.Errorf("invalid count '%s': must be a positive integer", args[5])
+			}
+			count, args = n, args[:4]
+		}
+		if len(args) != 4 {
+			return fmt.Errorf("usage: gen add <id> <component.method> <rate> [--count n]")
+		}
+		component, method, err := splitREPLTarget(args[2])
+		if err != nil {
+			return err
+		}
+		rate, err := strconv.ParseFloat(args[3], 64)
+		if err != nil {
+			return fmt.Errorf("invalid rate '%s': must be a number", args[3])
+		}
+		if err := r.Executor.AddGenerator(args[1], component, method, rate, count); err != nil {
+			return err
+		}
+		fmt.Fprintf(r.Out, "✅ Generator '%s' created (%s.%s at %.2f calls/second)\n", args[1], component, method, rate)
+		if count > 0 {
+			fmt.Fprintf(r.Out, "   Stops after %d calls\n", count)
+		}
+	case "start":
+		if err := r.Executor.StartGenerators(args[1:]...); err != nil {
+			return err
+		}
+		fmt.Fprintln(r.Out, "✅ Generators started")
+	case "stop":
+		if err := r.Executor.StopGenerators(args[1:]...); err != nil {
+			return err
+		}
+		fmt.Fprintln(r.Out, "✅ Generators stopped")
+	case "remove":
+		autoMetrics := len(args) == 3 && args[2] == "--metrics"
+		if len(args) != 2 && !autoMetrics {
+			return fmt.Errorf("usage: gen remove <id> [--metrics]")
+		}
+		if err := r.Executor.RemoveGenerator(args[1], autoMetrics); err != nil {
+			return err
+		}
+		fmt.Fprintf(r.Out, "✅ Generator '%s' removed\n", args[1])
+	default:
+		return fmt.Errorf("unknown gen command '%s'", args[0])
+	}
+	return nil
+}
+
+// genAddPreset adds a generator following a rate profile preset configured
+// by "--name value" options, eg "--peak 100 --period 24h".
+func (r *REPL) genAddPreset(id, target, preset string, args []string) error {
+	usage := fmt.Errorf("usage: gen add <id> <component.method> preset:<name> [--option value]... [--count n]")
+	component, method, err := splitREPLTarget(target)
+	if err != nil {
+		return err
+	}
+	if len(args)%2 != 0 {
+		return usage
+	}
+	count := 0
+	opts := map[string]string{}
+	for i := 0; i < len(args); i += 2 {
+		name, ok := strings.CutPrefix(args[i], "--")
+		if !ok {
+			return usage
+		}
+		if name != "count" {
+			opts[name] = args[i+1]
+			continue
+		}
+		if count, err = strconv.Atoi(args[i+1]); err != nil || count <= 0 {
+			return fmt.Errorf("invalid count '%s': must be a positive integer", args[i+1])
+		}
+	}
+	if err := r.Executor.AddPresetGenerator(id, component, method, preset, opts, count); err != nil {
+		return err
+	}
+	fmt.Fprintf(r.Out, "✅ Generator '%s' created (%s.%s following the %s preset)\n", id, component, method, preset)
+	if count > 0 {
+		fmt.Fprintf(r.Out, "   Stops after %d calls\n", count)
+	}
+	return nil
+}
+
+func (r *REPL) measure(args []string) error {
+	if len(args) > 0 && args[0] == "export-to" {
+		if len(args) != 2 {
+			return fmt.Errorf("usage: measure export-to <url>")
+		}
+		if err := r.Executor.ExportMetrics(args[1]); err != nil {
+			return err
+		}
+		fmt.Fprintf(r.Out, "✅ Exporting metrics to %s\n", args[1])
+		return nil
+	}
+	if len(args) > 0 && args[0] == "auto" {
+		if len(args) != 1 {
+			return fmt.Errorf("usage: measure auto")
+		}
+		metrics, err := r.Executor.AddAutoMetrics()
+		if err != nil {
+			return err
+		}
+		if len(metrics) == 0 {
+			fmt.Fprintln(r.Out, "No metrics added, every generator is already measured")
+		}
+		for _, metric := range metrics {
+			fmt.Fprintf(r.Out, "✅ Added metric '%s' for %s.%s (%s %s)\n", metric.Name, metric.Component, metric.Methods[0], metric.MetricType, metric.Aggregation)
+		}
+		return nil
+	}
+	if len(args) > 0 && args[0] == "template" {
+		if len(args) != 3 {
+			return fmt.Errorf("usage: measure template <component> <type>")
+		}
+		metrics, err := r.Executor.AddMetricTemplate(args[1], args[2])
+		if err != nil {
+			return err
+		}
+		if len(metrics) == 0 {
+			fmt.Fprintf(r.Out, "No metrics added, every instance of %s is already measured\n", args[1])
+		}
+		for _, metric := range metrics {
+			fmt.Fprintf(r.Out, "✅ Added metric '%s' for %s (%s %s)\n", metric.Name, metric.Component, metric.MetricType, metric.Aggregation)
+		}
+		return nil
+	}
+	if len(args) > 0 && args[0] == "data" {
+		return r.measureData(args[1:])
+	}
+	if len(args) > 0 && args[0] == "export-dashboard" {
+		if len(args) != 2 {
+			return fmt.Errorf("usage: measure export-dashboard <file>")
+		}
+		dashboard, err := r.Executor.ExportDashboard()
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(args[1], dashboard, 0644); err != nil {
+			return err
+		}
+		fmt.Fprintf(r.Out, "✅ Wrote Grafana dashboard to %s\n", args[1])
+		return nil
+	}
+	if len(args) < 2 || len(args) > 4 {
+		return fmt.Errorf("usage: measure <id> <component.method|system> [type] [aggregation]")
+	}
+	target, methods := args[1], []string{}
+	if target != runtime.SystemMetricTarget {
+		component, method, err := splitREPLTarget(target)
+		if err != nil {
+			return err
+		}
+		target, methods = component, []string{method}
+	}
+	metric := &v1.Metric{
+		Name:              args[0],
+		Component:         target,
+		Methods:           methods,
+		MetricType:        "latency",
+		Aggregation:       "avg",
+		AggregationWindow: 10,
+		Enabled:           true,
+	}
+	if len(args) > 2 {
+		metric.MetricType = args[2]
+	}
+	if len(args) > 3 {
+		metric.Aggregation = args[3]
+	}
+	if err := r.Executor.AddMetric(metric); err != nil {
+		return err
+	}
+	fmt.Fprintf(r.Out, "✅ Added metric '%s' for %s (%s %s)\n", metric.Name, args[1], metric.MetricType, metric.Aggregation)
+	return nil
+}
+
+// measureData charts the latest values of a metric as a sparkline.
+func (r *REPL) measureData(args []string) error {
+	usage := fmt.Errorf("usage: measure data <id> [--points n]")
+	if len(args) != 1 && len(args) != 3 {
+		return usage
+	}
+	points := 60
+	if len(args) == 3 {
+		if args[1] != "--points" {
+			return usage
+		}
+		n, err := strconv.Atoi(args[2])
+		if err != nil || n <= 0 {
+			return fmt.Errorf("invalid points '%s': must be a positive integer", args[2])
+		}
+		points = n
+	}
+	metricType, values, err := r.Executor.MetricData(args[0], points)
+	if err != nil {
+		return err
+	}
+	if len(values) == 0 {
+		fmt.Fprintf(r.Out, "%s: no data yet\n", args[0])
+		return nil
+	}
+	format := func(value float64) string {
+		return runtime.FormatMetricValue(metricType, value, r.Executor.DisplayPrecision())
+	}
+	fmt.Fprintf(r.Out, "%s (%d points): %s\n", args[0], len(values), formatSeries(values, format))
+	return nil
+}
+
+func (r *REPL) stats() error {
+	stats, err := r.Executor.MeasurementStats()
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(r.Out, "Total rows:   %d\n", stats.TotalRows)
+	fmt.Fprintf(r.Out, "Size:         %s\n", formatBytes(stats.SizeBytes))
+	fmt.Fprintf(r.Out, "Write rate:   %.2f rows/s\n", stats.WriteRate)
+	if stats.TotalRows > 0 {
+		fmt.Fprintf(r.Out, "Oldest:       %s\n", stats.OldestTimestamp.Format(time.RFC3339))
+		fmt.Fprintf(r.Out, "Newest:       %s\n", stats.NewestTimestamp.Format(time.RFC3339))
+	}
+	if len(stats.RowsPerMetric) == 0 {
+		return nil
+	}
+
+	names := make([]string, 0, len(stats.RowsPerMetric))
+	for name := range stats.RowsPerMetric {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	fmt.Fprintf(r.Out, "\n%-30s %10s\n", "METRIC", "ROWS")
+	for _, name := range names {
+		fmt.Fprintf(r.Out, "%-30s %10d\n", name, stats.RowsPerMetric[name])
+	}
+	return nil
+}
+
+// formatBytes renders a byte count using binary units.
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
+// sparkBlocks are the bars of a sparkline, lowest first.
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// sparkline draws one bar per value, scaled between the smallest and largest
+// of them.  A flat series is drawn at mid height.
+func sparkline(values []float64) string {
+	if len(values) == 0 {
+		return ""
+	}
+	lo, hi := slices.Min(values), slices.Max(values)
+	bars := make([]rune, len(values))
+	for i, value := range values {
+		level := (len(sparkBlocks) - 1) / 2
+		if hi > lo {
+			level = int((value-lo)/(hi-lo)*float64(len(sparkBlocks)-1) + 0.5)
+		}
+		bars[i] = sparkBlocks[level]
+	}
+	return string(bars)
+}
+
+// formatSeries renders a non empty series as a sparkline followed by its
+// min, max and last value.
+func formatSeries(values []float64, format func(float64) string) string {
+	return fmt.Sprintf("%s  min %s  max %s  last %s", sparkline(values),
+		format(slices.Min(values)), format(slices.Max(values)), format(values[len(values)-1]))
+}
+
+// splitREPLTarget splits "comp1.comp2.Method" into its component path and method.
+func splitREPLTarget(target string) (component, method string, err error) {
+	idx := strings.LastIndex(target, ".")
+	if idx <= 0 || idx == len(target)-1 {
+		return "", "", fmt.Errorf("target must be of the form comp1.comp2...compN.MethodName, got '%s'", target)
+	}
+	return target[:idx], target[idx+1:], nil
+}
+
+// localFileResolver resolves local paths and http(s) URLs with @stdlib/
+// mounted when it can be found.  Relative imports are also looked for in the
+// directories listed in $SDL_PATH.
+func localFileResolver() loader.FileResolver {
+	cfs := loader.NewCompositeFS()
+	cfs.SetFallback(loader.NewLocalFS("")) // handles relative and absolute paths
+	cfs.Mount("https://", loader.NewHTTPFileSystem(""))
+	cfs.Mount("http://", loader.NewHTTPFileSystem(""))
+	if stdlibPath := findStdlibPath(); stdlibPath != "" {
+		cfs.Mount("@stdlib/", loader.NewLocalFS(stdlibPath))
+	}
+	resolver := loader.NewFileSystemResolver(cfs)
+	if sdlPath := os.Getenv("SDL_PATH"); sdlPath != "" {
+		resolver.SearchPaths = filepath.SplitList(sdlPath)
+	}
+	return resolver
+}
+
+var replCmd = &cobra.Command{
+	Use:   "repl",
+	Short: "Interactive shell with the simulation engine embedded",
+	Long: `Start an interactive shell that runs the simulation engine in-process,
+so no separate 'sdl serve' is needed.  Use --remote to send the same
+commands to a running server instead.
+
+Example:
+  sdl repl
+  sdl> load examples/contacts/contacts.sdl
+  sdl> use ContactsSystem
+  sdl> run server.HandleLookup 1000
+
+A session can be recorded with --record and replayed against a fresh
+workspace with --replay, at its original pace or faster with --speed:
+  sdl repl --record session.recipe
+  sdl repl --replay session.recipe --speed 0
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		var executor Executor
+		if remote, _ := cmd.Flags().GetBool("remote"); remote {
+			executor = &RemoteExecutor{WorkspaceID: workspaceID}
+		} else {
+			executor = NewLocalExecutor(localFileResolver())
+		}
+		defer executor.Close()
+
+		repl := NewREPL(executor, os.Stdout)
+		if recordPath, _ := cmd.Flags().GetString("record"); recordPath != "" {
+			file, err := os.Create(recordPath)
+			if err == nil {
+				defer file.Close()
+				repl.Recorder, err = NewSessionRecorder(file)
+			}
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: cannot record session: %v\n", err)
+				os.Exit(1)
+			}
+		}
+
+		fmt.Println("SDL REPL - type 'help' for commands, 'exit' to quit")
+		if replayPath, _ := cmd.Flags().GetString("replay"); replayPath != "" {
+			speed, _ := cmd.Flags().GetFloat64("speed")
+			quit, err := repl.Replay(loader.NewLocalFS("."), replayPath, speed)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: cannot replay session: %v\n", err)
+				os.Exit(1)
+			}
+			if quit {
+				return
+			}
+		}
+		repl.Run(os.Stdin)
+	},
+}
+
+func init() {
+	replCmd.Flags().Bool("remote", false, "Send commands to a running SDL server instead of the embedded engine")
+	replCmd.Flags().String("record", "", "Record the session's commands to a recipe file")
+	replCmd.Flags().String("replay", "", "Replay a recorded session before reading commands")
+	replCmd.Flags().Float64("speed", 1, "Replay speed relative to the recording, 0 runs commands back to back")
+	AddCommand(replCmd)
+}
@@ -0,0 +1,87 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/panyam/sdl/lib/loader"
+	"github.com/panyam/sdl/tools/shared/recipe"
+)
+
+// recordedAtPrefix starts the comment before each recorded command giving
+// when it ran relative to the start of the session.
+const recordedAtPrefix = "at "
+
+// SessionRecorder writes the commands of a REPL session to a recipe so the
+// session can be replayed with --replay.  Commands are written as "sdl
+// <command>" lines, each after a "# at <offset>" comment timestamping it.
+type SessionRecorder struct {
+	out   io.Writer
+	start time.Time
+	now   func() time.Time
+}
+
+// NewSessionRecorder starts a recording written to out.
+func NewSessionRecorder(out io.Writer) (*SessionRecorder, error) {
+	s := &SessionRecorder{out: out, now: time.Now}
+	s.start = s.now()
+	_, err := fmt.Fprintf(out, "# SDL REPL session recorded %s\n", s.start.Format(time.RFC3339))
+	return s, err
+}
+
+// Record appends a command line to the recording.  Shell escapes (!cmd) are
+// left out as they depend on the machine the session ran on.
+func (s *SessionRecorder) Record(line string) error {
+	line = strings.TrimSpace(line)
+	if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "!") {
+		return nil
+	}
+	offset := s.now().Sub(s.start).Round(time.Millisecond)
+	_, err := fmt.Fprintf(s.out, "# %s%s\nsdl %s\n", recordedAtPrefix, offset, line)
+	return err
+}
+
+// Replay runs the commands of the recorded session (or any recipe) at path,
+// with the recipes it includes expanded by recipe.LoadRecipe, echoing each one
+// as if it had been typed.  speed scales the recorded timing: 1 waits as long
+// as the original session did between commands, 10 replays it ten times
+// faster and 0 runs the commands back to back.  Failing commands are reported
+// and the replay carries on, as it would have in the original session.  quit
+// is true when the session ended with an exit command.  Unreadable recipes
+// and include cycles are returned as errors before any command runs.
+func (r *REPL) Replay(fs loader.FileSystem, path string, speed float64) (quit bool, err error) {
+	loaded, err := recipe.LoadRecipe(fs, path)
+	if err != nil {
+		return false, err
+	}
+	start := time.Now()
+	var at time.Duration
+	for _, cmd := range loaded.Commands {
+		switch cmd.Type {
+		case recipe.CommandTypeComment:
+			if offset, found := strings.CutPrefix(cmd.Description, recordedAtPrefix); found {
+				if d, err := time.ParseDuration(offset); err == nil {
+					at = d
+				}
+			}
+		case recipe.CommandTypeEcho:
+			fmt.Fprintln(r.Out, cmd.Description)
+		case recipe.CommandTypeCommand:
+			if speed > 0 {
+				time.Sleep(time.Until(start.Add(time.Duration(float64(at) / speed))))
+			}
+			line := strings.Join(cmd.Args, " ")
+			fmt.Fprintf(r.Out, "sdl> %s\n", line)
+			var err error
+			if quit, err = r.Execute(line); err != nil {
+				fmt.Fprintf(r.Out, "❌ %v\n", err)
+			}
+			if quit {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
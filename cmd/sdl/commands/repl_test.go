@@ -0,0 +1,326 @@
+package commands
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	goruntime "runtime"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/panyam/sdl/lib/loader"
+	"github.com/panyam/sdl/lib/runtime"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testFixturePath(name string) string {
+	_, filename, _, _ := goruntime.Caller(0)
+	return filepath.Join(filepath.Dir(filename), "..", "..", "..", "test", "fixtures", name)
+}
+
+// TestREPLLocalExecutor drives a sequence of REPL commands against an in-process
+// workspace and verifies each one takes effect without any server running.
+func TestREPLLocalExecutor(t *testing.T) {
+	executor := NewLocalExecutor(loader.NewDefaultFileResolver())
+	defer executor.Close()
+	var out bytes.Buffer
+	repl := NewREPL(executor, &out)
+
+	for _, line := range []string{
+		"load " + testFixturePath("system_with_metrics.sdl"),
+		"use SimpleAppTest",
+		"set app.server.db.Timeout 5",
+		"run app.server.HandleRequest 50 7",
+		"run app.server.HandleRequest 50 7",
+		"run --debug app.server.HandleRequest 7",
+		"attribute app.server.HandleRequest 20",
+		"trace diff app.server.HandleRequest app.server.db.Timeout 10",
+		"measure lat app.server.HealthCheck latency p95",
+		"measure e2e system latency p99",
+		"gen add extra app.server.HealthCheck 5",
+		"gen add daily app.server.HealthCheck preset:diurnal --peak 100 --period 24h",
+		"flows --verbose",
+		"stats",
+		"gen stop",
+	} {
+		quit, err := repl.Execute(line)
+		require.NoError(t, err, "command %q", line)
+		assert.False(t, quit)
+	}
+
+	dev := executor.Service.DevEnv
+	assert.Equal(t, "SimpleAppTest", dev.GetActiveSystemName())
+	assert.NotNil(t, dev.GetGenerator("extra"))
+	require.NotNil(t, dev.GetGenerator("daily").Profile)
+	assert.Equal(t, 100.0, dev.GetGenerator("daily").Rate)
+	assert.NotNil(t, dev.ActiveSystem().FindComponent("app.server"))
+
+	output := out.String()
+	assert.Contains(t, output, "system SimpleAppTest(app SimpleApp)")
+	assert.Contains(t, output, "Now using system: SimpleAppTest")
+	assert.Contains(t, output, "Ran app.server.HandleRequest 50 times")
+	assert.Equal(t, 1, strings.Count(output, "(cached)"), "identical second run should hit the cache")
+	assert.Contains(t, output, "app.server.HandleRequest returned")
+	assert.Contains(t, output, "Latency of app.server.HandleRequest over 20 runs")
+	assert.Contains(t, output, "Latency of app.server.HandleRequest changed by +0.00ms after setting app.server.db.Timeout = 10")
+	assert.Contains(t, output, "Added metric 'lat'")
+	assert.Contains(t, output, "Added metric 'e2e' for system (latency p99)")
+	assert.Contains(t, output, "Iteration 1: max change")
+	assert.Contains(t, output, "Flows converged after")
+	assert.Contains(t, output, "Total rows:")
+
+	quit, err := repl.Execute("exit")
+	assert.NoError(t, err)
+	assert.True(t, quit)
+}
+
+// TestREPLMeasureAuto verifies that measure auto adds a latency and a
+// throughput metric on the target of each generator and that removing a
+// generator with --metrics removes them again.
+func TestREPLMeasureAuto(t *testing.T) {
+	executor := NewLocalExecutor(loader.NewDefaultFileResolver())
+	defer executor.Close()
+	var out bytes.Buffer
+	repl := NewREPL(executor, &out)
+
+	for _, line := range []string{
+		"load " + testFixturePath("system_with_metrics.sdl"),
+		"use SimpleAppTest",
+		"gen add health app.server.HealthCheck 5",
+		"measure auto",
+	} {
+		_, err := repl.Execute(line)
+		require.NoError(t, err, "command %q", line)
+	}
+
+	dev := executor.Service.DevEnv
+	targets := map[string]string{}
+	for _, metric := range dev.ListMetrics() {
+		targets[metric.Name] = fmt.Sprintf("%s %s.%s", metric.MetricType, metric.Component, strings.Join(metric.Methods, ","))
+	}
+	assert.Equal(t, "latency app.server.HealthCheck", targets["health_latency"])
+	assert.Equal(t, "count app.server.HealthCheck", targets["health_throughput"])
+	assert.Equal(t, "latency app.server.HandleRequest", targets["traffic_latency"])
+	assert.Equal(t, "count app.server.HandleRequest", targets["traffic_throughput"])
+	assert.Contains(t, out.String(), "Added metric 'traffic_throughput' for app.server.HandleRequest (count sum)")
+
+	out.Reset()
+	_, err := repl.Execute("measure auto")
+	require.NoError(t, err)
+	assert.Contains(t, out.String(), "every generator is already measured")
+
+	_, err = repl.Execute("gen remove health --metrics")
+	require.NoError(t, err)
+	assert.Nil(t, dev.GetGenerator("health"))
+	var names []string
+	for _, metric := range dev.ListMetrics() {
+		names = append(names, metric.Name)
+	}
+	assert.NotContains(t, names, "health_latency")
+	assert.NotContains(t, names, "health_throughput")
+	assert.Contains(t, names, "traffic_latency")
+}
+
+// TestREPLCommandErrors verifies that malformed or unknown commands are
+// reported as errors instead of reaching the executor.
+func TestREPLCommandErrors(t *testing.T) {
+	executor := NewLocalExecutor(loader.NewDefaultFileResolver())
+	defer executor.Close()
+	repl := NewREPL(executor, &bytes.Buffer{})
+
+	for _, line := range []string{
+		"bogus",
+		"load",
+		"run app.server.HandleRequest lots",
+		"gen add g1 NoMethod 10",
+		"gen add g2 app.server.HealthCheck preset:weekly --peak 10",
+		"gen add g3 app.server.HealthCheck preset:spike --peak 200",
+		"use Missing",
+		"measure export-to",
+		"measure export-to kafka://localhost:9092",
+		"measure export-to influxdb://localhost:8086",
+	} {
+		_, err := repl.Execute(line)
+		assert.Error(t, err, "command %q", line)
+	}
+
+	quit, err := repl.Execute("   ")
+	assert.NoError(t, err)
+	assert.False(t, quit)
+}
+
+// TestREPLRecordAndReplay records a session and verifies replaying it against
+// a fresh workspace reaches the same state.
+func TestREPLRecordAndReplay(t *testing.T) {
+	var recording bytes.Buffer
+	recorder, err := NewSessionRecorder(&recording)
+	require.NoError(t, err)
+	clock := recorder.start
+	recorder.now = func() time.Time { return clock }
+
+	original := NewLocalExecutor(loader.NewDefaultFileResolver())
+	defer original.Close()
+	repl := NewREPL(original, &bytes.Buffer{})
+	repl.Recorder = recorder
+	for _, line := range []string{
+		"load " + testFixturePath("system_with_metrics.sdl"),
+		"# comments are not recorded",
+		"use SimpleAppTest",
+		"!ls",
+		"set app.server.db.Timeout 25",
+		"gen add extra app.server.HealthCheck 5",
+		"measure lat app.server.HealthCheck latency p95",
+	} {
+		clock = clock.Add(1500 * time.Millisecond)
+		repl.Execute(line)
+	}
+
+	recipe := recording.String()
+	assert.Contains(t, recipe, "# at 1.5s\nsdl load ")
+	assert.Contains(t, recipe, "# at 4.5s\nsdl use SimpleAppTest\n")
+	assert.Contains(t, recipe, "# at 9s\nsdl gen add extra app.server.HealthCheck 5\n")
+	assert.NotContains(t, recipe, "comments")
+	assert.NotContains(t, recipe, "ls")
+
+	// Replay a recipe extending the recorded session through an include
+	fs := loader.NewMemoryFS()
+	fs.WriteFile("/sessions/base.recipe", []byte(recipe))
+	fs.WriteFile("/sessions/extended.recipe", []byte("include base.recipe\nsdl set app.server.db.Timeout 30\n"))
+	replayed := NewLocalExecutor(loader.NewDefaultFileResolver())
+	defer replayed.Close()
+	var out bytes.Buffer
+	quit, err := NewREPL(replayed, &out).Replay(fs, "/sessions/extended.recipe", 0)
+	require.NoError(t, err)
+	assert.False(t, quit)
+	assert.Contains(t, out.String(), "sdl> use SimpleAppTest\n✅ Now using system: SimpleAppTest")
+	assert.NotContains(t, out.String(), "❌")
+	assert.Contains(t, out.String(), "sdl> set app.server.db.Timeout 30")
+	repl.Execute("set app.server.db.Timeout 30")
+
+	state := func(executor *LocalExecutor) []string {
+		dev := executor.Service.DevEnv
+		timeout, _ := dev.ActiveSystem().FindComponent("app.server.db").Get("Timeout")
+		out := []string{dev.GetActiveSystemName(), timeout.String()}
+		for _, gen := range dev.ListGenerators() {
+			out = append(out, fmt.Sprintf("gen %s %s.%s %g", gen.Name, gen.Component, gen.Method, gen.Rate))
+		}
+		for _, metric := range dev.ListMetrics() {
+			out = append(out, fmt.Sprintf("metric %s %s %s", metric.Name, metric.MetricType, metric.Aggregation))
+		}
+		return out
+	}
+	assert.Contains(t, state(original), "gen extra app.server.HealthCheck 5")
+	assert.Contains(t, state(original), "metric lat latency p95")
+	assert.Contains(t, state(replayed), "RV(int: 30)")
+	assert.ElementsMatch(t, state(original), state(replayed))
+}
+
+// TestREPLRuns verifies that runs lists a page of past runs, newest first.
+func TestREPLRuns(t *testing.T) {
+	executor := NewLocalExecutor(loader.NewDefaultFileResolver())
+	defer executor.Close()
+	var out bytes.Buffer
+	repl := NewREPL(executor, &out)
+
+	for _, line := range []string{
+		"load " + testFixturePath("system_with_metrics.sdl"),
+		"use SimpleAppTest",
+		"run app.server.HandleRequest 10 1",
+		"run app.server.HealthCheck 20 1",
+	} {
+		_, err := repl.Execute(line)
+		require.NoError(t, err, "command %q", line)
+	}
+
+	out.Reset()
+	_, err := repl.Execute("runs 0 1")
+	require.NoError(t, err)
+	assert.Contains(t, out.String(), "Runs 1-1 of 2, newest first:")
+	assert.Contains(t, out.String(), "#2 ")
+	assert.Contains(t, out.String(), "app.server.HealthCheck params=")
+	assert.Contains(t, out.String(), "20 calls, mean=")
+	assert.NotContains(t, out.String(), "#1 ")
+
+	out.Reset()
+	_, err = repl.Execute("runs 5")
+	require.NoError(t, err)
+	assert.Contains(t, out.String(), "No runs to show (2 in history)")
+
+	_, err = repl.Execute("runs x")
+	assert.ErrorContains(t, err, "invalid offset 'x'")
+}
+
+// TestFormatSeries verifies that a series renders as one bar per value
+// followed by its min, max and last value.
+func TestFormatSeries(t *testing.T) {
+	values := []float64{0.001, 0.002, 0.004, 0.008, 0.003}
+	format := func(v float64) string { return fmt.Sprintf("%gs", v) }
+	line := formatSeries(values, format)
+	spark, summary, _ := strings.Cut(line, "  ")
+	assert.Equal(t, len(values), utf8.RuneCountInString(spark))
+	assert.Equal(t, "▁▂▄█▃", spark)
+	assert.Equal(t, "min 0.001s  max 0.008s  last 0.003s", summary)
+
+	assert.Equal(t, "▄▄▄", sparkline([]float64{5, 5, 5}))
+	assert.Equal(t, "", sparkline(nil))
+}
+
+// TestREPLMeasureData verifies that measure data reports a metric without
+// points as such and charts the windows a running generator fills.
+func TestREPLMeasureData(t *testing.T) {
+	executor := NewLocalExecutor(loader.NewDefaultFileResolver())
+	defer executor.Close()
+	dev := executor.Service.DevEnv
+	clock := runtime.NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	dev.SetClock(clock)
+	var out bytes.Buffer
+	repl := NewREPL(executor, &out)
+
+	for _, line := range []string{
+		"load " + testFixturePath("system_with_metrics.sdl"),
+		"use SimpleAppTest",
+		"measure calls app.server.HandleRequest count sum",
+	} {
+		_, err := repl.Execute(line)
+		require.NoError(t, err, "command %q", line)
+	}
+
+	out.Reset()
+	_, err := repl.Execute("measure data calls --points 30")
+	require.NoError(t, err)
+	assert.Equal(t, "calls: no data yet\n", out.String())
+
+	// Step a 100 rps generator through three 10s windows, letting each
+	// tick's call finish before the next.
+	timers := len(dev.ListMetrics())
+	require.Eventually(t, func() bool { return clock.PendingTimers() == timers }, time.Second, time.Millisecond, "metric windows should be waiting")
+	for _, line := range []string{"gen add load app.server.HandleRequest 100", "gen start load"} {
+		_, err = repl.Execute(line)
+		require.NoError(t, err, "command %q", line)
+	}
+	require.Eventually(t, func() bool { return clock.PendingTimers() == timers+1 }, time.Second, time.Millisecond, "generator ticker should be waiting")
+	load := dev.GetGenerator("load")
+	for step := int64(1); step <= 3000; step++ {
+		clock.Advance(10 * time.Millisecond)
+		require.Eventually(t, func() bool { return load.Emitted() == step }, time.Second, time.Millisecond)
+	}
+	require.Eventually(t, func() bool {
+		points, err := dev.RecentMetricPoints("calls", 30)
+		return err == nil && len(points) >= 2
+	}, time.Second, time.Millisecond)
+
+	out.Reset()
+	_, err = repl.Execute("measure data calls --points 30")
+	require.NoError(t, err)
+	assert.Regexp(t, `^calls \(\d+ points\): [▁▂▃▄▅▆▇█]+  min \S+  max \S+  last \S+\n$`, out.String())
+
+	_, err = repl.Execute("measure data calls --points 0")
+	assert.ErrorContains(t, err, "invalid points '0'")
+	_, err = repl.Execute("measure data calls 30")
+	assert.ErrorContains(t, err, "usage: measure data")
+	_, err = repl.Execute("measure data missing")
+	assert.Error(t, err)
+}
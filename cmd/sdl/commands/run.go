@@ -3,28 +3,51 @@ package commands
 import (
 	"encoding/json"
 	"fmt"
-	"log"
 	"os"
-	"sort"
-	"sync"
 	"time"
 
-	"github.com/panyam/sdl/lib/loader"
 	"github.com/panyam/sdl/lib/runtime"
+	"github.com/panyam/sdl/lib/types"
+	"github.com/panyam/sdl/services"
 	"github.com/spf13/cobra"
 )
 
 var runCmd = &cobra.Command{
-	Use:   "run <system_name> <instance_name> <method_name>",
+	Use:   "run <system_name> <instance_name> <method_name> | run <file_or_url> --system <name> --gen <target:rate>",
 	Short: "Runs a simulation for a specific system method",
 	Long: `Executes a method on a component instance within a system a specified number of times 
 to gather performance and result data. This command is designed for statistical 
 analysis of a system's behavior under simulated load.
 
 The results, including latency, return values, and errors for each run, are
-saved to a JSON file for further analysis by commands like 'sdl plot'.`,
-	Args: cobra.ExactArgs(3),
+saved to a JSON file for further analysis by commands like 'sdl plot'.  With
+--aggregate-only only the latency summary is printed and no results are kept,
+for capacity sweeps of millions of calls.
+
+Given a single SDL file or http(s) URL instead, it loads the model, activates
+--system, drives it with the --gen generators for --for and prints the
+resulting metrics, without needing a server:
+
+  sdl run https://example.com/model.sdl --system Foo --gen server.Handle:50 --for 10s`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 1 && len(args) != 3 {
+			return fmt.Errorf("expected <system_name> <instance_name> <method_name> or a single SDL file or URL, got %d args", len(args))
+		}
+		return nil
+	},
 	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) == 1 {
+			systemName, _ := cmd.Flags().GetString("system")
+			gens, _ := cmd.Flags().GetStringArray("gen")
+			duration, _ := cmd.Flags().GetDuration("for")
+			maxDepth, _ := cmd.Flags().GetInt("max-depth")
+			err := runHeadless(os.Stdout, headlessRun{Source: args[0], System: systemName, Generators: gens, Duration: duration, MaxDepth: maxDepth})
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			return
+		}
 		systemName := args[0]
 		instanceName := args[1]
 		methodName := args[2]
@@ -32,12 +55,21 @@ saved to a JSON file for further analysis by commands like 'sdl plot'.`,
 		totalRuns, _ := cmd.Flags().GetInt("runs")
 		numWorkers, _ := cmd.Flags().GetInt("workers")
 		outputFile, _ := cmd.Flags().GetString("out")
+		primeCalls, _ := cmd.Flags().GetInt("prime")
+		maxDepth, _ := cmd.Flags().GetInt("max-depth")
+		seed, _ := cmd.Flags().GetInt64("seed")
+		noCache, _ := cmd.Flags().GetBool("no-cache")
+		aggregateOnly, _ := cmd.Flags().GetBool("aggregate-only")
 
 		if dslFilePath == "" {
 			fmt.Fprintln(os.Stderr, "Error: DSL file path must be specified with -f or --file.")
 			os.Exit(1)
 		}
-		if outputFile == "" {
+		if maxDepth <= 0 {
+			fmt.Fprintf(os.Stderr, "Error: --max-depth must be positive, got %d.\n", maxDepth)
+			os.Exit(1)
+		}
+		if outputFile == "" && !aggregateOnly {
 			fmt.Fprintln(os.Stderr, "Error: Output file must be specified with --out or -o.")
 			os.Exit(1)
 		}
@@ -45,92 +77,58 @@ saved to a JSON file for further analysis by commands like 'sdl plot'.`,
 		fmt.Printf("Starting simulation for %s.%s.%s...\n", systemName, instanceName, methodName)
 		fmt.Printf("Total Runs: %d, Concurrent Workers: %d\n", totalRuns, numWorkers)
 
-		sdlLoader := loader.NewLoader(nil, nil, 10)
-		fileStatus, err := sdlLoader.LoadFile(dslFilePath, "", 0)
-		if err != nil {
+		// Runs go through a DevEnv so they share the seed and run cache handling
+		// of the REPL and the server
+		dev := services.NewDevEnv(localFileResolver())
+		defer dev.Close()
+		if err := dev.LoadFile(dslFilePath); err != nil {
 			fmt.Fprintf(os.Stderr, "Error loading SDL file '%s': %v\n", dslFilePath, err)
 			os.Exit(1)
 		}
-
-		if !sdlLoader.Validate(fileStatus) {
-			fmt.Fprintf(os.Stderr, "Validation failed for file '%s':\n", dslFilePath)
-			fileStatus.PrintErrors()
+		if err := dev.Use(systemName); err != nil {
+			fmt.Fprintf(os.Stderr, "Error using system '%s': %v\n", systemName, err)
 			os.Exit(1)
 		}
-
-		rt := runtime.NewRuntime(sdlLoader)
-		fileInstance, _ := rt.LoadFile(dslFilePath)
-		system, _ := fileInstance.NewSystem(systemName, true)
-		if system == nil {
-			fmt.Fprintf(os.Stderr, "System '%s' not found in file '%s'.\n", systemName, dslFilePath)
+		if err := dev.SetMaxDepth(maxDepth); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			os.Exit(1)
 		}
 
-		batchSize := totalRuns / 100
-		if batchSize == 0 {
-			batchSize = 1
-		}
-		if batchSize > 1000 {
-			batchSize = 1000
+		if primeCalls > 0 {
+			fmt.Printf("Priming with %d calls (excluded from results)...\n", primeCalls)
 		}
-		numBatches := (totalRuns + batchSize - 1) / batchSize
-
-		allResults := make([]RunResult, 0, totalRuns)
-		resultsChan := make(chan []RunResult, numWorkers)
-		var wg sync.WaitGroup
-		var resultMutex sync.Mutex
-
-		wg.Add(1)
-		go func() {
-			defer wg.Done()
-			for batchResults := range resultsChan {
-				resultMutex.Lock()
-				allResults = append(allResults, batchResults...)
-				resultMutex.Unlock()
-			}
-		}()
-
 		fmt.Println("Simulation in progress...")
 		startTime := time.Now()
-
-		var simTimeCounter float64
-		var simTimeMutex sync.Mutex
-
-		onBatch := func(batch int, batchVals []runtime.Value) {
-			batchResults := make([]RunResult, len(batchVals))
-			for i, val := range batchVals {
-				simTimeMutex.Lock()
-				// Advance the simulated time by the latency of this run
-				simTimeCounter += val.Time
-				currentSimTimeMillis := int64(simTimeCounter * 1000)
-				simTimeMutex.Unlock()
-
-				batchResults[i] = RunResult{
-					Timestamp:   currentSimTimeMillis, // Use synthetic sim time
-					Latency:     val.Time * 1000,      // Latency is the duration of the run itself
-					ResultValue: val.String(),
-					IsError:     false, // Placeholder
-				}
-			}
-			resultsChan <- batchResults
-			if (batch+1)%10 == 0 || batch == numBatches-1 {
-				log.Printf("  ... completed %d / %d batches\n", batch+1, numBatches)
+		opts := services.RunOptions{
+			Target:  instanceName + "." + methodName,
+			Runs:    totalRuns,
+			Workers: numWorkers,
+			Seed:    seed,
+			NoCache: noCache,
+			Prime:   primeCalls,
+		}
+		if aggregateOnly {
+			dev.SetEvalMode(services.EvalModeAggregateOnly)
+			summary, err := dev.SummarizeRun(opts)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Simulation failed: %v\n", err)
+				os.Exit(1)
 			}
+			fmt.Printf("Simulation finished in %v.\n", time.Since(startTime))
+			fmt.Printf("Summarized %d calls.\n", summary.Count)
+			fmt.Printf("Latency: %s\n", formatRunSummary(summary, dev.DisplayPrecision()))
+			return
+		}
+		allResults, _, err := dev.RunSimulation(opts)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Simulation failed: %v\n", err)
+			os.Exit(1)
 		}
-
-		runtime.RunCallInBatches(system, instanceName, methodName, numBatches, batchSize, numWorkers, onBatch)
-
-		close(resultsChan)
-		wg.Wait()
 
 		duration := time.Since(startTime)
 		fmt.Printf("Simulation finished in %v.\n", duration)
 		fmt.Printf("Collected %d results.\n", len(allResults))
-
-		// Sort final results by timestamp before writing
-		sort.Slice(allResults, func(i, j int) bool {
-			return allResults[i].Timestamp < allResults[j].Timestamp
-		})
+		fmt.Printf("Latency: %s\n", formatRunSummary(types.SummarizeRuns(allResults), dev.DisplayPrecision()))
 
 		jsonData, err := json.MarshalIndent(allResults, "", "  ")
 		if err != nil {
@@ -150,5 +148,13 @@ func init() {
 	AddCommand(runCmd)
 	runCmd.Flags().Int("runs", 1000, "Total number of simulation runs to execute.")
 	runCmd.Flags().Int("workers", 50, "Number of concurrent workers to run the simulation.")
-	runCmd.Flags().StringP("out", "o", "", "Output file path for the detailed JSON results (required).")
+	runCmd.Flags().StringP("out", "o", "", "Output file path for the detailed JSON results (required unless --aggregate-only).")
+	runCmd.Flags().Int("max-depth", runtime.DefaultMaxCallDepth, "Maximum method call depth before a run fails, guards against unbounded recursion.")
+	runCmd.Flags().String("system", "", "System to activate when running a single SDL file or URL.")
+	runCmd.Flags().StringArray("gen", nil, "Generator as component.method:rate when running a single SDL file or URL (repeatable).")
+	runCmd.Flags().Duration("for", 10*time.Second, "How long generators run when running a single SDL file or URL.")
+	runCmd.Flags().Int64("seed", 0, "Random seed for the simulation (0 = the system's seed option or else time based).")
+	runCmd.Flags().Bool("no-cache", false, "Bypass cached results and always recompute the run.")
+	runCmd.Flags().Bool("aggregate-only", false, "Only print the latency summary, without keeping each call's result, for very large run counts.")
+	runCmd.Flags().Int("prime", 0, "Number of calls made before measuring to bring stateful components (eg caches) to steady state.")
 }
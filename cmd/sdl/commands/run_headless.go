@@ -0,0 +1,203 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	v1 "github.com/panyam/sdl/gen/go/sdl/v1/models"
+	"github.com/panyam/sdl/lib/loader"
+	"github.com/panyam/sdl/lib/runtime"
+	"github.com/panyam/sdl/services"
+)
+
+// headlessRun is a one shot, non-interactive run: load a model (from disk or
+// a URL), drive it with generators for a while and report the metrics.
+type headlessRun struct {
+	Source     string   // Local path or http(s) URL of the SDL file
+	System     string   // System to activate
+	Generators []string // Each as "component.method:rate"
+	Duration   time.Duration
+	MaxDepth   int
+}
+
+type genSpec struct {
+	target            string
+	component, method string
+	rate              float64
+}
+
+// parseGenSpec parses a "component.method:rate" generator flag.
+func parseGenSpec(spec string) (out genSpec, err error) {
+	target, rateStr, ok := strings.Cut(spec, ":")
+	if !ok {
+		return out, fmt.Errorf("invalid generator '%s', expected component.method:rate", spec)
+	}
+	if out.component, out.method, err = splitREPLTarget(target); err != nil {
+		return out, err
+	}
+	out.target = target
+	if out.rate, err = strconv.ParseFloat(rateStr, 64); err != nil || out.rate <= 0 {
+		return out, fmt.Errorf("invalid rate '%s' in generator '%s': must be a positive number", rateStr, spec)
+	}
+	return out, nil
+}
+
+// validateModel loads and validates the model up front so compile errors fail
+// the run.  DevEnv only logs validation errors.
+func validateModel(resolver loader.FileResolver, source string) (err error) {
+	// Type inference panics with its first error
+	defer func() {
+		if r := recover(); r != nil {
+			inferErr, ok := r.(error)
+			if !ok {
+				panic(r)
+			}
+			err = fmt.Errorf("%s failed validation: %w", source, inferErr)
+		}
+	}()
+	l := loader.NewLoader(nil, resolver, loader.DefaultMaxImportDepth)
+	status, err := l.LoadFile(source, "", 0)
+	if err != nil {
+		return fmt.Errorf("loading %s: %w", source, err)
+	}
+	if !l.Validate(status) {
+		if len(status.Errors) == 0 {
+			return fmt.Errorf("%s failed validation", source)
+		}
+		return fmt.Errorf("%s failed validation: %w", source, errors.Join(status.Errors...))
+	}
+	return nil
+}
+
+func runHeadless(out io.Writer, opts headlessRun) error {
+	if opts.System == "" {
+		return fmt.Errorf("--system is required when running a single SDL file or URL")
+	}
+	if len(opts.Generators) == 0 {
+		return fmt.Errorf("at least one --gen component.method:rate is required")
+	}
+	if opts.Duration <= 0 {
+		return fmt.Errorf("--for must be positive, got %v", opts.Duration)
+	}
+	var specs []genSpec
+	for _, g := range opts.Generators {
+		spec, err := parseGenSpec(g)
+		if err != nil {
+			return err
+		}
+		specs = append(specs, spec)
+	}
+
+	// Both loads share the resolver so a remote file is only fetched once
+	resolver := localFileResolver()
+	if err := validateModel(resolver, opts.Source); err != nil {
+		return err
+	}
+	dev := services.NewDevEnv(resolver)
+	defer dev.Close()
+	if err := dev.LoadFile(opts.Source); err != nil {
+		return err
+	}
+	if err := dev.Use(opts.System); err != nil {
+		return err
+	}
+	if opts.MaxDepth > 0 {
+		if err := dev.SetMaxDepth(opts.MaxDepth); err != nil {
+			return err
+		}
+	}
+
+	var gens []*runtime.Generator
+	for _, spec := range specs {
+		for _, m := range []struct{ suffix, metricType, aggregation string }{
+			{"calls", runtime.MetricCount, "sum"},
+			{"latency", runtime.MetricLatency, "avg"},
+			{"p99", runtime.MetricLatency, "p99"},
+		} {
+			err := dev.AddMetric(&runtime.Metric{Metric: &v1.Metric{
+				Name:              spec.target + " " + m.suffix,
+				Component:         spec.component,
+				Methods:           []string{spec.method},
+				MetricType:        m.metricType,
+				Aggregation:       m.aggregation,
+				AggregationWindow: 1,
+				Enabled:           true,
+			}})
+			if err != nil {
+				return fmt.Errorf("measuring %s: %w", spec.target, err)
+			}
+		}
+		gens = append(gens, &runtime.Generator{Generator: &v1.Generator{
+			Name:      spec.target,
+			Component: spec.component,
+			Method:    spec.method,
+			Rate:      spec.rate,
+		}})
+	}
+
+	fmt.Fprintf(out, "Running %s for %v with %d generator(s)...\n", opts.System, opts.Duration, len(gens))
+	startTime := time.Now()
+	for _, gen := range gens {
+		if err := dev.AddGenerator(gen); err != nil {
+			dev.StopAllGenerators()
+			return fmt.Errorf("generator %s: %w", gen.Name, err)
+		}
+	}
+	for _, warning := range dev.GeneratorWarnings() {
+		fmt.Fprintf(out, "WARNING: %s\n", warning)
+	}
+	saturated, err := dev.CheckSaturation()
+	if err != nil {
+		dev.StopAllGenerators()
+		return fmt.Errorf("evaluating load: %w", err)
+	}
+	for _, sat := range saturated {
+		fmt.Fprintf(out, "WARNING: %s\n", sat)
+	}
+	if len(saturated) > 0 {
+		fmt.Fprintf(out, "WARNING: offered load exceeds capacity so the queue at %s grows without bound; latencies are not reported\n", saturated[0].Component)
+	}
+	time.Sleep(opts.Duration)
+	for _, gen := range gens {
+		gen.Stop(true)
+	}
+	elapsed := time.Since(startTime).Seconds()
+	dev.StopMetrics()
+
+	fmt.Fprintf(out, "\n%-30s %10s %10s %10s\n", "GENERATOR", "TARGET/s", "CALLS", "ACHIEVED/s")
+	for _, gen := range gens {
+		fmt.Fprintf(out, "%-30s %10.2f %10d %10.2f\n", gen.Name, gen.Rate, gen.Emitted(), float64(gen.Emitted())/elapsed)
+	}
+
+	metrics := dev.ListMetrics()
+	slices.SortFunc(metrics, func(a, b *v1.Metric) int { return strings.Compare(a.Name, b.Name) })
+	fmt.Fprintf(out, "\n%-30s %8s %12s %12s %12s\n", "METRIC", "WINDOWS", "MEAN", "MIN", "MAX")
+	for _, m := range metrics {
+		result, err := dev.QueryMetrics(m.Name, runtime.QueryOptions{StartTime: startTime, EndTime: time.Now()})
+		if err != nil {
+			return err
+		}
+		if len(saturated) > 0 && m.MetricType == runtime.MetricLatency {
+			fmt.Fprintf(out, "%-30s %8d %12s %12s %12s\n", m.Name, len(result.Points), "saturated", "-", "-")
+			continue
+		}
+		if len(result.Points) == 0 {
+			fmt.Fprintf(out, "%-30s %8d %12s %12s %12s\n", m.Name, 0, "-", "-", "-")
+			continue
+		}
+		sum, low, high := 0.0, result.Points[0].Value, result.Points[0].Value
+		for _, p := range result.Points {
+			sum += p.Value
+			low, high = min(low, p.Value), max(high, p.Value)
+		}
+		fmt.Fprintf(out, "%-30s %8d %12s %12s %12s\n", m.Name, len(result.Points),
+			dev.FormatMetricValue(m.Name, sum/float64(len(result.Points))),
+			dev.FormatMetricValue(m.Name, low), dev.FormatMetricValue(m.Name, high))
+	}
+	return nil
+}
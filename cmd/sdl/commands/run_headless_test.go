@@ -0,0 +1,85 @@
+package commands
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const headlessModel = `
+component Server {
+    method Handle() Bool {
+        return true
+    }
+}
+
+system App(server Server) {
+}
+`
+
+// serveSDL serves the given files over HTTP, returning 404 for anything else.
+func serveSDL(t *testing.T, files map[string]string) *httptest.Server {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		content, ok := files[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(content))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// TestRunHeadlessFromURL verifies that a model fetched over HTTP is compiled,
+// driven by a generator for the given duration and its metrics printed.
+func TestRunHeadlessFromURL(t *testing.T) {
+	srv := serveSDL(t, map[string]string{"/model.sdl": headlessModel})
+
+	var out bytes.Buffer
+	err := runHeadless(&out, headlessRun{
+		Source:     srv.URL + "/model.sdl",
+		System:     "App",
+		Generators: []string{"server.Handle:50"},
+		Duration:   300 * time.Millisecond,
+	})
+	require.NoError(t, err)
+	assert.Regexp(t, `server\.Handle\s+50\.00\s+1[0-9]\s`, out.String(), "about 15 calls in 300ms at 50/s")
+	assert.Regexp(t, `server\.Handle calls\s+\d+\s+[1-9]`, out.String(), "calls metric should be recorded")
+	assert.Contains(t, out.String(), "server.Handle latency")
+}
+
+// TestRunHeadlessErrors verifies that network failures, compile errors and
+// bad arguments are reported as errors rather than running anything.
+func TestRunHeadlessErrors(t *testing.T) {
+	srv := serveSDL(t, map[string]string{
+		"/model.sdl":   headlessModel,
+		"/syntax.sdl":  "component Server {",
+		"/invalid.sdl": "component Server { uses db Missing() }\nsystem App(server Server) { }",
+	})
+	closed := httptest.NewServer(http.NotFoundHandler())
+	closed.Close()
+
+	run := func(source, system string, gens ...string) error {
+		return runHeadless(&bytes.Buffer{}, headlessRun{Source: source, System: system, Generators: gens, Duration: 10 * time.Millisecond})
+	}
+
+	err := run(srv.URL+"/missing.sdl", "App", "server.Handle:10")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "404")
+
+	err = run(closed.URL+"/model.sdl", "App", "server.Handle:10")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), closed.URL)
+
+	assert.Error(t, run(srv.URL+"/syntax.sdl", "App", "server.Handle:10"))
+	assert.Error(t, run(srv.URL+"/invalid.sdl", "App", "server.Handle:10"))
+	assert.Error(t, run(srv.URL+"/model.sdl", "Nope", "server.Handle:10"))
+	assert.Error(t, run(srv.URL+"/model.sdl", "App", "server.Handle"))
+	assert.Error(t, run(srv.URL+"/model.sdl", "App"))
+}
@@ -1,6 +1,7 @@
 package commands
 
 import (
+	"context"
 	"log"
 	"log/slog"
 	"net/http"
@@ -21,6 +22,10 @@ var (
 	showStats     = true
 	statsInterval = 30 * time.Second
 	loadFiles     []string
+	watchInterval time.Duration
+	workspacesDir string
+
+	shutdownTimeout = 5 * time.Second
 )
 
 // Serve command
@@ -44,6 +49,12 @@ Example:
   # Terminal 1: Start server
   sdl serve
 
+  # Or load a file and recompile it whenever it is saved
+  sdl serve --load examples/contacts/contacts.sdl --watch 1s
+
+  # Or keep workspaces on disk across restarts
+  sdl serve --workspaces-dir ~/.sdl/workspaces
+
   # Terminal 2: Use CLI commands
   sdl load examples/contacts/contacts.sdl
   sdl use ContactsSystem
@@ -61,6 +72,15 @@ Example:
 		}
 		fsResolver := loader.NewFileSystemResolver(cfs)
 		wsSvc := devenvbe.NewWorkspaceService(fsResolver)
+		for _, path := range loadFiles {
+			if err := wsSvc.DevEnv.LoadFile(path); err != nil {
+				slog.Error("Failed to load file", "path", path, "error", err)
+				os.Exit(1)
+			}
+		}
+		if watchInterval > 0 {
+			go wsSvc.DevEnv.WatchFiles(cmd.Context(), watchInterval)
+		}
 
 		// Start gRPC server in background
 		log.Println("gRPC address:", grpcAddress)
@@ -73,7 +93,11 @@ Example:
 		}
 
 		// Build HTTP handler
-		sdlApp, _, _ := server.NewSdlApp(grpcAddress)
+		sdlApp, _, err := server.NewSdlApp(grpcAddress, workspacesDir)
+		if err != nil {
+			slog.Error("Failed to create web app", "error", err)
+			os.Exit(1)
+		}
 		handler := goal.CORS(sdlApp.Handler())
 
 		log.Println("HTTP address:", gatewayAddress)
@@ -91,6 +115,7 @@ Example:
 			skhttp.WithOnShutdown(func() {
 				slog.Info("Stopping gRPC server...")
 				grpcStop <- true
+				shutdownWorkspace(wsSvc)
 			}),
 		); err != nil {
 			slog.Error("Server error", "error", err)
@@ -100,6 +125,24 @@ Example:
 	},
 }
 
+// shutdownWorkspace stops running generators and flushes metrics before the
+// process exits so the last partial windows are not lost.
+func shutdownWorkspace(wsSvc *devenvbe.WorkspaceService) {
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	report, err := wsSvc.Shutdown(ctx)
+	if report.TimedOut {
+		slog.Warn("Timed out waiting for generators to stop", "timeout", shutdownTimeout)
+	}
+	if err != nil {
+		slog.Error("Failed to close metric store", "error", err)
+	}
+	slog.Info("Workspace shut down",
+		"generators", report.GeneratorsStopped,
+		"metrics", report.MetricsFlushed,
+		"points", report.PointsStored)
+}
+
 // findStdlibPath looks for the stdlib directory in common locations.
 func findStdlibPath() string {
 	candidates := []string{
@@ -122,5 +165,7 @@ func init() {
 	serveCmd.Flags().BoolVar(&showStats, "stats", true, "Show periodic statistics")
 	serveCmd.Flags().DurationVar(&statsInterval, "stats-interval", 5*time.Second, "Statistics display interval")
 	serveCmd.Flags().StringSliceVar(&loadFiles, "load", []string{}, "Initial SDL files to load on server startup")
+	serveCmd.Flags().DurationVar(&watchInterval, "watch", 0, "Recompile loaded SDL files when they change, checking at this interval (eg 1s)")
+	serveCmd.Flags().StringVar(&workspacesDir, "workspaces-dir", "", "Directory to persist workspaces in so they survive restarts (default: in memory, seeded from examples/)")
 	rootCmd.AddCommand(serveCmd)
 }
@@ -0,0 +1,104 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/panyam/sdl/services"
+	"github.com/spf13/cobra"
+)
+
+var testCmd = &cobra.Command{
+	Use:   "test <file|dir|glob...>",
+	Short: "Runs the scenarios declared in SDL files",
+	Long: `The test command runs every scenario declared in the systems of each SDL
+file, eg
+
+  scenario LoadTest {
+    generator server.Handle at 50/s for 10s;
+    expect p99 server.Handle < 100ms;
+  }
+
+Scenarios run in virtual time so they finish as fast as they can be
+simulated.  Each scenario passes when all of its expectations hold.  All
+scenarios are run and summarized unless --fail-fast is given, which stops at
+the first scenario that fails.  The command exits with 1 if any scenario
+fails and 2 if a file does not compile, making it suitable for CI.`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		verbose, _ := cmd.Flags().GetBool("verbose")
+		failFast, _ := cmd.Flags().GetBool("fail-fast")
+		if code := runTest(os.Stdout, args, verbose, failFast); code != 0 {
+			os.Exit(code)
+		}
+	},
+}
+
+// runTest runs the scenarios in the files matched by patterns and returns
+// the exit code.  Expectations are listed for failed scenarios, or for all
+// of them when verbose.  With failFast no scenario is run after the first
+// one that fails.
+func runTest(out io.Writer, patterns []string, verbose, failFast bool) int {
+	files, err := expandSDLPaths(patterns)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+
+	code, passed, failed := 0, 0, 0
+	resolver := localFileResolver()
+	for _, path := range files {
+		if failFast && failed > 0 {
+			break
+		}
+		if err := validateModel(resolver, path); err != nil {
+			fmt.Fprintf(out, "ERROR %s: %v\n", path, err)
+			code = 2
+			continue
+		}
+		dev := services.NewDevEnv(resolver)
+		err := dev.LoadFile(path)
+		if err == nil {
+			results, runErr := dev.RunScenarios(failFast)
+			err = runErr
+			for _, result := range results {
+				status := "PASS"
+				if result.Passed {
+					passed++
+				} else {
+					status = "FAIL"
+					failed++
+					code = max(code, 1)
+				}
+				fmt.Fprintf(out, "%s %s.%s (%d calls)\n", status, result.System, result.Name, result.Calls)
+				for _, expect := range result.Expects {
+					if verbose || !result.Passed {
+						mark := "ok"
+						if !expect.Passed {
+							mark = "failed"
+						}
+						fmt.Fprintf(out, "    %-6s expect %s\n", mark, expect)
+					}
+				}
+			}
+		}
+		dev.Close()
+		if err != nil {
+			fmt.Fprintf(out, "ERROR %s: %v\n", path, err)
+			code = 2
+		}
+	}
+	fmt.Fprintf(out, "\n%d passed, %d failed", passed, failed)
+	if failFast && failed > 0 {
+		fmt.Fprintf(out, ", stopped at the first failure")
+	}
+	fmt.Fprintln(out)
+	return code
+}
+
+func init() {
+	testCmd.Flags().BoolP("verbose", "v", false, "List the expectations of passing scenarios too")
+	testCmd.Flags().Bool("fail-fast", false, "Stop at the first scenario that fails")
+	AddCommand(testCmd)
+}
@@ -0,0 +1,46 @@
+package commands
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestRunTestScenarios verifies that scenarios are run as a suite: the
+// passing one is reported briefly, the failing one with the expectation
+// that did not hold, and the exit code reflects the failure.
+func TestRunTestScenarios(t *testing.T) {
+	var out bytes.Buffer
+	code := runTest(&out, []string{"../../../test/fixtures/scenarios.sdl"}, false, false)
+	assert.Equal(t, 1, code)
+	assert.Contains(t, out.String(), "PASS App.Steady (500 calls)\n")
+	assert.Contains(t, out.String(), "FAIL App.TooStrict (300 calls)\n    failed expect avg server.Handle < 10ms (got 15ms)\n")
+	assert.NotContains(t, out.String(), "expect p99", "expectations of passing scenarios are only listed when verbose")
+	assert.Contains(t, out.String(), "1 passed, 1 failed")
+
+	out.Reset()
+	runTest(&out, []string{"../../../test/fixtures/scenarios.sdl"}, true, false)
+	assert.Contains(t, out.String(), "    ok     expect count server.db.Query >= 500 (got 500)\n")
+	assert.Contains(t, out.String(), "    ok     expect path \"Handle > db.Query\" (matched 500 calls)\n")
+}
+
+// TestRunTestFailFast verifies that by default every failing scenario is
+// reported with the values it got, while --fail-fast stops after the first.
+func TestRunTestFailFast(t *testing.T) {
+	fixture := "../../../test/fixtures/failing_scenarios.sdl"
+	var out bytes.Buffer
+	code := runTest(&out, []string{fixture}, false, false)
+	assert.Equal(t, 1, code)
+	assert.Contains(t, out.String(), "FAIL Cache.TooFast (50 calls)\n    failed expect p50 store.Lookup < 5ms (got 20ms)\n")
+	assert.Contains(t, out.String(), "FAIL Cache.TooFew (50 calls)\n    failed expect count store.Lookup >= 100 (got 50)\n")
+	assert.Contains(t, out.String(), "0 passed, 2 failed\n")
+
+	out.Reset()
+	code = runTest(&out, []string{fixture, "../../../test/fixtures/scenarios.sdl"}, false, true)
+	assert.Equal(t, 1, code)
+	assert.Contains(t, out.String(), "FAIL Cache.TooFast")
+	assert.NotContains(t, out.String(), "TooFew")
+	assert.NotContains(t, out.String(), "App.Steady", "files after the first failure are not run")
+	assert.Contains(t, out.String(), "0 passed, 1 failed, stopped at the first failure\n")
+}
@@ -9,6 +9,7 @@ import (
 
 	v1 "github.com/panyam/sdl/gen/go/sdl/v1/models"
 	v1s "github.com/panyam/sdl/gen/go/sdl/v1/services"
+	"github.com/panyam/sdl/lib/runtime"
 	"github.com/spf13/cobra"
 )
 
@@ -80,8 +81,57 @@ Prerequisites:
 	},
 }
 
+var traceExportCmd = &cobra.Command{
+	Use:   "export <component.method> <out.json>",
+	Short: "Exports a trace as a call tree in a versioned JSON schema",
+	Long: `Executes a method call like 'trace' and writes the resulting call tree as JSON
+for external tools. Each node carries its id, parent id, component, method,
+start/end timestamps, total and self simulated latency and the sampled outcome
+(branch). The output has a schema_version field and nodes are listed in
+pre-order so the file can be processed as a stream.`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		methodCallString, outputFile := args[0], args[1]
+		lastDot := strings.LastIndex(methodCallString, ".")
+		if lastDot <= 0 {
+			fmt.Fprintf(os.Stderr, "Error: Invalid method call format. Expected 'component.method', got '%s'\n", methodCallString)
+			os.Exit(1)
+		}
+
+		err := withWorkspaceClient(func(client v1s.WorkspaceServiceClient, ctx context.Context) error {
+			resp, err := client.ExecuteTrace(ctx, &v1.ExecuteTraceRequest{
+				WorkspaceId: workspaceID,
+				Component:   methodCallString[:lastDot],
+				Method:      methodCallString[lastDot+1:],
+			})
+			if err != nil {
+				return fmt.Errorf("trace execution failed: %v", err)
+			}
+
+			out, err := os.Create(outputFile)
+			if err != nil {
+				return fmt.Errorf("error creating %s: %v", outputFile, err)
+			}
+			defer out.Close()
+
+			tree := runtime.BuildTraceTree(runtime.TraceDataFromProto(resp.TraceData))
+			if err := tree.WriteJSON(out); err != nil {
+				return fmt.Errorf("error writing trace to %s: %v", outputFile, err)
+			}
+			fmt.Printf("Trace tree successfully written to %s\n", outputFile)
+			return nil
+		})
+
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	},
+}
+
 func init() {
 	AddCommand(traceCmd)
+	traceCmd.AddCommand(traceExportCmd)
 	traceCmd.Flags().StringP("out", "o", "", "Output detailed trace data to a JSON file (optional)")
 	traceCmd.Flags().Int("depth", 0, "Limit trace depth (0 for unlimited)")
 }
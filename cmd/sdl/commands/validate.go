@@ -1,27 +1,210 @@
 package commands
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
 
-	// Assuming parser is in decl
 	"github.com/panyam/sdl/lib/loader"
+	"github.com/panyam/sdl/services"
 	"github.com/spf13/cobra"
 )
 
 var validateCmd = &cobra.Command{
-	Use:   "validate <dsl_file_path...>",
-	Short: "Parses and semantically checks DSL file(s)",
-	Long: `The validate command parses one or more DSL files to check for syntactic
-correctness and basic semantic validity. It does not run any simulations.`,
-	Args: cobra.MinimumNArgs(1), // Require at least one file path
+	Use:   "validate <file|dir|glob...>",
+	Short: "Parses, type checks and lints SDL files",
+	Long: `The validate command parses and type checks each SDL file and then lints
+the files that compile, eg for params and dependencies that are never used.
+Directories are searched recursively for .sdl files.  With --run-scenarios
+the scenarios of each file that compiles are also run, and every expectation
+that does not hold is reported as an error.  Otherwise no simulations are run.
+It exits non-zero when any file fails, making it suitable for CI.`,
+	Args: cobra.MinimumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("Validating DSL files:")
-		sdlLoader := loader.NewLoader(nil, nil, 10) // Max depth 10
-		sdlLoader.LoadFilesAndValidate(args...)
+		format, _ := cmd.Flags().GetString("format")
+		failOn, _ := cmd.Flags().GetString("fail-on")
+		runScenarios, _ := cmd.Flags().GetBool("run-scenarios")
+		if format != "text" && format != "json" {
+			fmt.Fprintf(os.Stderr, "Error: unknown format %q (expected text or json)\n", format)
+			os.Exit(2)
+		}
+		if failOn != "error" && failOn != "warning" {
+			fmt.Fprintf(os.Stderr, "Error: unknown --fail-on %q (expected error or warning)\n", failOn)
+			os.Exit(2)
+		}
+		if code := runValidate(os.Stdout, args, format, failOn == "warning", runScenarios); code != 0 {
+			os.Exit(code)
+		}
 	},
 }
 
+// ValidateIssue is an error or warning found in a file.
+type ValidateIssue struct {
+	Line    int    `json:"line,omitempty"`
+	Col     int    `json:"col,omitempty"`
+	Message string `json:"message"`
+}
+
+// ValidateFileResult holds the issues found in a single file.
+type ValidateFileResult struct {
+	File     string          `json:"file"`
+	Errors   []ValidateIssue `json:"errors"`
+	Warnings []ValidateIssue `json:"warnings"`
+}
+
+// ValidateReport is the aggregated result of validating a set of files.
+type ValidateReport struct {
+	Files    []ValidateFileResult `json:"files"`
+	Errors   int                  `json:"errors"`
+	Warnings int                  `json:"warnings"`
+	Passed   bool                 `json:"passed"`
+}
+
+// runValidate validates the files matched by patterns, writes the report to
+// out and returns the exit code.  With runScenarios the scenarios of files
+// without errors are run too.
+func runValidate(out io.Writer, patterns []string, format string, failOnWarning, runScenarios bool) int {
+	files, err := expandSDLPaths(patterns)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+
+	report := ValidateReport{Files: []ValidateFileResult{}}
+	resolver := localFileResolver()
+	for _, path := range files {
+		result := ValidateFileResult{File: path, Errors: []ValidateIssue{}, Warnings: []ValidateIssue{}}
+		diags, warnings := services.CompileDiagnostics(resolver, path)
+		for _, d := range diags {
+			result.Errors = append(result.Errors, ValidateIssue{d.Line, d.Col, d.Message})
+		}
+		for _, d := range warnings {
+			result.Warnings = append(result.Warnings, ValidateIssue{d.Line, d.Col, d.Message})
+		}
+		if runScenarios && len(result.Errors) == 0 {
+			result.Errors = append(result.Errors, scenarioIssues(resolver, path)...)
+		}
+		report.Errors += len(result.Errors)
+		report.Warnings += len(result.Warnings)
+		report.Files = append(report.Files, result)
+	}
+	report.Passed = report.Errors == 0 && (!failOnWarning || report.Warnings == 0)
+
+	if format == "json" {
+		data, _ := json.MarshalIndent(report, "", "  ")
+		fmt.Fprintln(out, string(data))
+	} else {
+		printValidateReport(out, report)
+	}
+	if !report.Passed {
+		return 1
+	}
+	return 0
+}
+
+// scenarioIssues runs the scenarios of the file at path and returns an issue
+// for each expectation that does not hold.
+func scenarioIssues(resolver loader.FileResolver, path string) (issues []ValidateIssue) {
+	dev := services.NewDevEnv(resolver)
+	defer dev.Close()
+	if err := dev.LoadFile(path); err != nil {
+		return []ValidateIssue{{Message: err.Error()}}
+	}
+	results, err := dev.RunScenarios(false)
+	if err != nil {
+		return []ValidateIssue{{Message: err.Error()}}
+	}
+	for _, result := range results {
+		for _, expect := range result.Expects {
+			if !expect.Passed {
+				pos := expect.Expect.Pos()
+				issues = append(issues, ValidateIssue{pos.Line, pos.Col,
+					fmt.Sprintf("scenario %s.%s: expect %s", result.System, result.Name, expect)})
+			}
+		}
+	}
+	return
+}
+
+func printValidateReport(out io.Writer, report ValidateReport) {
+	for _, f := range report.Files {
+		status := "OK"
+		if len(f.Errors) > 0 {
+			status = "FAIL"
+		} else if len(f.Warnings) > 0 {
+			status = "WARN"
+		}
+		fmt.Fprintf(out, "%-4s %s\n", status, f.File)
+		for _, e := range f.Errors {
+			fmt.Fprintf(out, "     error: %s\n", formatIssue(f.File, e))
+		}
+		for _, w := range f.Warnings {
+			fmt.Fprintf(out, "     warning: %s\n", formatIssue(f.File, w))
+		}
+	}
+	fmt.Fprintf(out, "\n%d file(s), %d error(s), %d warning(s)\n", len(report.Files), report.Errors, report.Warnings)
+}
+
+func formatIssue(file string, issue ValidateIssue) string {
+	if issue.Line == 0 {
+		return issue.Message
+	}
+	return fmt.Sprintf("%s:%d:%d: %s", file, issue.Line, issue.Col, issue.Message)
+}
+
+// expandSDLPaths turns the command arguments into a sorted list of files.
+// Directories are walked for .sdl files and patterns are globbed.
+func expandSDLPaths(patterns []string) ([]string, error) {
+	seen := map[string]bool{}
+	var files []string
+	add := func(path string) {
+		if !seen[path] {
+			seen[path] = true
+			files = append(files, path)
+		}
+	}
+	for _, pattern := range patterns {
+		if info, err := os.Stat(pattern); err == nil && info.IsDir() {
+			err := filepath.WalkDir(pattern, func(path string, d os.DirEntry, err error) error {
+				if err != nil {
+					return err
+				}
+				if !d.IsDir() && strings.HasSuffix(path, ".sdl") {
+					add(path)
+				}
+				return nil
+			})
+			if err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if strings.ContainsAny(pattern, "*?[") {
+			matches, err := filepath.Glob(pattern)
+			if err != nil {
+				return nil, err
+			}
+			if len(matches) == 0 {
+				return nil, fmt.Errorf("no files match %s", pattern)
+			}
+			for _, m := range matches {
+				add(m)
+			}
+			continue
+		}
+		add(pattern)
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
 func init() {
+	validateCmd.Flags().String("format", "text", "Output format (text, json)")
+	validateCmd.Flags().String("fail-on", "error", "Lowest severity that fails validation (error, warning)")
+	validateCmd.Flags().Bool("run-scenarios", false, "Also run the scenarios of each file and fail on expectations that do not hold")
 	AddCommand(validateCmd)
-	// validateCmd.Flags().BoolP("strict", "s", false, "Enable stricter validation checks")
 }
@@ -0,0 +1,83 @@
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestValidateDirectory verifies that validating a directory aggregates the
+// errors and lint warnings of every file and sets the exit code.
+func TestValidateDirectory(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "clean.sdl"), []byte(`
+component Database {
+  method Query() Bool { return true }
+}
+component Server {
+  uses db Database
+  uses cache Database
+  method Handle() Bool {
+    return self.db.Query()
+  }
+}
+system App(server Server) { }
+`), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "broken.sdl"), []byte(`
+component Server {
+  method Handle() Bool {
+    return self.missing.Query()
+  }
+}
+system App(server Server) { }
+`), 0644))
+
+	var out bytes.Buffer
+	code := runValidate(&out, []string{dir}, "json", false, false)
+	assert.Equal(t, 1, code)
+
+	var report ValidateReport
+	require.NoError(t, json.Unmarshal(out.Bytes(), &report))
+	require.Len(t, report.Files, 2)
+	assert.Equal(t, filepath.Join(dir, "broken.sdl"), report.Files[0].File)
+	assert.NotEmpty(t, report.Files[0].Errors)
+	assert.Empty(t, report.Files[1].Errors)
+	require.Len(t, report.Files[1].Warnings, 1)
+	assert.Equal(t, "dependency 'cache' of component 'Server' is never used", report.Files[1].Warnings[0].Message)
+	assert.Equal(t, 1, report.Warnings)
+	assert.False(t, report.Passed)
+
+	// Warnings alone only fail when asked to
+	cleanPath := filepath.Join(dir, "clean.sdl")
+	out.Reset()
+	assert.Equal(t, 0, runValidate(&out, []string{cleanPath}, "text", false, false))
+	assert.Contains(t, out.String(), "1 file(s), 0 error(s), 1 warning(s)")
+	out.Reset()
+	assert.Equal(t, 1, runValidate(&out, []string{filepath.Join(dir, "c*.sdl")}, "text", true, false))
+	assert.Contains(t, out.String(), "warning: "+cleanPath+":7:3: dependency 'cache'")
+}
+
+// TestValidateRunScenarios verifies that --run-scenarios reports each
+// expectation that does not hold as an error at its position.
+func TestValidateRunScenarios(t *testing.T) {
+	fixture := "../../../test/fixtures/scenarios.sdl"
+	var out bytes.Buffer
+	assert.Equal(t, 0, runValidate(&out, []string{fixture}, "text", false, false))
+
+	out.Reset()
+	code := runValidate(&out, []string{fixture}, "json", false, true)
+	assert.Equal(t, 1, code)
+	var report ValidateReport
+	require.NoError(t, json.Unmarshal(out.Bytes(), &report))
+	require.Len(t, report.Files, 1)
+	require.Len(t, report.Files[0].Errors, 1)
+	issue := report.Files[0].Errors[0]
+	assert.Equal(t, "scenario App.TooStrict: expect avg server.Handle < 10ms (got 15ms)", issue.Message)
+	assert.NotZero(t, issue.Line)
+	assert.False(t, report.Passed)
+}
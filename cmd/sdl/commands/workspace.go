@@ -3,10 +3,12 @@ package commands
 import (
 	"context"
 	"fmt"
+	"os"
 	"strconv"
 
 	v1 "github.com/panyam/sdl/gen/go/sdl/v1/models"
 	v1s "github.com/panyam/sdl/gen/go/sdl/v1/services"
+	"github.com/panyam/sdl/lib/loader"
 	"github.com/spf13/cobra"
 )
 
@@ -195,12 +197,17 @@ var infoCmd = &cobra.Command{
 var executeCmd = &cobra.Command{
 	Use:   "execute [recipe-file]",
 	Short: "Execute a recipe file",
-	Args:  cobra.ExactArgs(1),
+	Long: `Runs the commands of a recipe, with the recipes it includes expanded,
+against the workspace on the server, as 'sdl repl --remote' would.`,
+	Args: cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
-		_, err := makeAPICall[any]("POST", "/api/console/execute", map[string]any{"filePath": args[0]})
-		if err == nil {
-			fmt.Printf("✅ Executed recipe: %s\n", args[0])
+		executor := &RemoteExecutor{WorkspaceID: workspaceID}
+		defer executor.Close()
+		if _, err := NewREPL(executor, os.Stdout).Replay(loader.NewLocalFS("."), args[0], 0); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
 		}
+		fmt.Printf("✅ Executed recipe: %s\n", args[0])
 	},
 }
 
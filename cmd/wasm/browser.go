@@ -5,10 +5,12 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"log"
 
 	protos "github.com/panyam/sdl/gen/go/sdl/v1/models"
 	wasmservices "github.com/panyam/sdl/gen/wasm/go/sdl/v1/services"
+	"github.com/panyam/sdl/lib/loader"
 	"github.com/panyam/sdl/services"
 )
 
@@ -49,6 +51,47 @@ func (f *BrowserWorkspacePage) OnAvailableSystemsChanged(systemNames []string) {
 	}
 }
 
+// OnModelChanged pushes the manifest of the recompiled model and refreshes
+// the system list from it.
+func (f *BrowserWorkspacePage) OnModelChanged(manifest *loader.Manifest) {
+	if f.DevEnvPage == nil {
+		return
+	}
+	_, err := f.DevEnvPage.OnModelChanged(context.Background(), &protos.DevEnvModelChangedRequest{
+		Manifest: services.ToProtoManifest(manifest),
+	})
+	if err != nil {
+		log.Printf("BrowserWorkspacePage: OnModelChanged error: %v", err)
+	}
+	var systemNames []string
+	for _, system := range manifest.Systems {
+		systemNames = append(systemNames, system.Name)
+	}
+	f.OnAvailableSystemsChanged(systemNames)
+}
+
+// OnDiagnostics pushes the diagnostics of a file that failed to recompile and
+// logs each to the console panel.
+func (f *BrowserWorkspacePage) OnDiagnostics(filePath string, diagnostics []services.Diagnostic) {
+	if f.DevEnvPage == nil {
+		return
+	}
+	req := &protos.DevEnvDiagnosticsRequest{FilePath: filePath}
+	for _, diag := range diagnostics {
+		req.Diagnostics = append(req.Diagnostics, &protos.SimulationDiagnostic{
+			Line:    int32(diag.Line),
+			Col:     int32(diag.Col),
+			Message: diag.Message,
+		})
+	}
+	if _, err := f.DevEnvPage.OnDiagnostics(context.Background(), req); err != nil {
+		log.Printf("BrowserWorkspacePage: OnDiagnostics error: %v", err)
+	}
+	for _, diag := range diagnostics {
+		f.LogMessage("error", fmt.Sprintf("%d:%d: %s", diag.Line, diag.Col, diag.Message), filePath)
+	}
+}
+
 func (f *BrowserWorkspacePage) UpdateDiagram(diagram *services.SystemDiagram) {
 	if f.DevEnvPage == nil {
 		return
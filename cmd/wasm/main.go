@@ -10,6 +10,7 @@ package main
 import (
 	"context"
 	"embed"
+	"encoding/json"
 	"fmt"
 	"strings"
 	"syscall/js"
@@ -17,6 +18,7 @@ import (
 	protos "github.com/panyam/sdl/gen/go/sdl/v1/models"
 	wasmservices "github.com/panyam/sdl/gen/wasm/go/sdl/v1/services"
 	"github.com/panyam/sdl/lib/loader"
+	"github.com/panyam/sdl/lib/parser"
 	"github.com/panyam/sdl/services"
 )
 
@@ -140,6 +142,118 @@ func main() {
 	}
 	sdlObj.Set("config", js.ValueOf(configObj))
 
+	// Add metric store utilities
+	metricsObj := map[string]any{
+		"stats": js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+			return metricsStats(devEnv)
+		}),
+	}
+	sdlObj.Set("metrics", js.ValueOf(metricsObj))
+
+	// Add param schema for generating parameter forms
+	schemaObj := map[string]any{
+		"params": js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+			schema, err := devEnv.ParamSchema()
+			if err != nil {
+				return jsError(fmt.Sprintf("Failed to build param schema: %v", err))
+			}
+			return jsSuccess(map[string]interface{}{"schema": string(schema)})
+		}),
+	}
+	sdlObj.Set("schema", js.ValueOf(schemaObj))
+
+	// Add the system manifest for tools that consume the model
+	sdlObj.Set("manifest", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		manifest, err := devEnv.Manifest()
+		if err != nil {
+			return jsError(fmt.Sprintf("Failed to build manifest: %v", err))
+		}
+		data, err := json.Marshal(manifest)
+		if err != nil {
+			return jsError(fmt.Sprintf("Failed to encode manifest: %v", err))
+		}
+		return jsSuccess(map[string]interface{}{"manifest": string(data)})
+	}))
+
+	// Add loading with the systems each file declares and their entry points
+	sdlObj.Set("load", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		if len(args) < 1 {
+			return jsError("load requires file path")
+		}
+		if err := devEnv.LoadFile(args[0].String()); err != nil {
+			return jsError(fmt.Sprintf("Failed to load file: %v", err))
+		}
+		systems, err := devEnv.LoadedSystems(args[0].String())
+		if err != nil {
+			return jsError(fmt.Sprintf("Failed to list systems: %v", err))
+		}
+		data, err := json.Marshal(systems)
+		if err != nil {
+			return jsError(fmt.Sprintf("Failed to encode systems: %v", err))
+		}
+		return jsSuccess(map[string]interface{}{"systems": string(data)})
+	}))
+
+	// Add compiling a file with its errors and warnings kept apart
+	sdlObj.Set("getCompileResult", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		if len(args) < 1 {
+			return jsError("getCompileResult requires file path")
+		}
+		data, err := json.Marshal(services.Compile(fsResolver, args[0].String()))
+		if err != nil {
+			return jsError(fmt.Sprintf("Failed to encode compile result: %v", err))
+		}
+		return jsSuccess(map[string]interface{}{"result": string(data)})
+	}))
+
+	// Add the parsed AST of a source as JSON for external tooling, including
+	// what was parsed before a syntax error
+	sdlObj.Set("parseAST", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		if len(args) < 1 {
+			return jsError("parseAST requires source")
+		}
+		// Syntax errors are reported in the document along with the partial AST
+		data, err := parser.ParseToJSON([]byte(args[0].String()))
+		if data == nil {
+			return jsError(fmt.Sprintf("Failed to encode AST: %v", err))
+		}
+		return jsSuccess(map[string]interface{}{"ast": string(data)})
+	}))
+
+	// Add per-method flow rates and latencies from the last flow evaluation
+	sdlObj.Set("flows", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		samples := 100
+		if len(args) > 0 && args[0].Type() == js.TypeNumber {
+			samples = args[0].Int()
+		}
+		flows, err := devEnv.GetFlows(samples)
+		if err != nil {
+			return jsError(fmt.Sprintf("Failed to get flows: %v", err))
+		}
+		data, err := json.Marshal(flows)
+		if err != nil {
+			return jsError(fmt.Sprintf("Failed to encode flows: %v", err))
+		}
+		return jsSuccess(map[string]interface{}{"flows": string(data)})
+	}))
+
+	// Add a page of the run history, newest first, with the number of runs kept
+	sdlObj.Set("runs", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		offset, limit := 0, 0
+		if len(args) > 0 && args[0].Type() == js.TypeNumber {
+			offset = args[0].Int()
+		}
+		if len(args) > 1 && args[1].Type() == js.TypeNumber {
+			limit = args[1].Int()
+		}
+		runs, total := devEnv.ListRuns(offset, limit)
+		data, err := json.Marshal(runs)
+		if err != nil {
+			return jsError(fmt.Sprintf("Failed to encode runs: %v", err))
+		}
+		return jsSuccess(map[string]interface{}{"runs": string(data), "total": total})
+	}))
+
 	fmt.Println("SDL WASM module loaded successfully")
 
 	// Keep the WASM module running
@@ -320,6 +434,30 @@ func setDevMode(this js.Value, args []js.Value) interface{} {
 
 // Helper functions
 
+// Metric store commands
+func metricsStats(devEnv *services.DevEnv) interface{} {
+	stats, err := devEnv.MeasurementStats()
+	if err != nil {
+		return jsError(fmt.Sprintf("Failed to get metric stats: %v", err))
+	}
+
+	rowsPerMetric := map[string]interface{}{}
+	for name, rows := range stats.RowsPerMetric {
+		rowsPerMetric[name] = rows
+	}
+	result := map[string]interface{}{
+		"totalRows":     stats.TotalRows,
+		"rowsPerMetric": rowsPerMetric,
+		"sizeBytes":     stats.SizeBytes,
+		"writeRate":     stats.WriteRate,
+	}
+	if stats.TotalRows > 0 {
+		result["oldestTimestamp"] = stats.OldestTimestamp.UnixMilli()
+		result["newestTimestamp"] = stats.NewestTimestamp.UnixMilli()
+	}
+	return jsSuccess(result)
+}
+
 func jsError(message string) map[string]interface{} {
 	return map[string]interface{}{
 		"success": false,
@@ -199,6 +199,94 @@ func (*UseSystemResponse) Descriptor() ([]byte, []int) {
 	return file_sdl_v1_models_canvas_service_proto_rawDescGZIP(), []int{3}
 }
 
+type GetManifestRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WorkspaceId   string                 `protobuf:"bytes,1,opt,name=workspace_id,json=workspaceId,proto3" json:"workspace_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetManifestRequest) Reset() {
+	*x = GetManifestRequest{}
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetManifestRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetManifestRequest) ProtoMessage() {}
+
+func (x *GetManifestRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetManifestRequest.ProtoReflect.Descriptor instead.
+func (*GetManifestRequest) Descriptor() ([]byte, []int) {
+	return file_sdl_v1_models_canvas_service_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetManifestRequest) GetWorkspaceId() string {
+	if x != nil {
+		return x.WorkspaceId
+	}
+	return ""
+}
+
+type GetManifestResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Manifest      *Manifest              `protobuf:"bytes,1,opt,name=manifest,proto3" json:"manifest,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetManifestResponse) Reset() {
+	*x = GetManifestResponse{}
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetManifestResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetManifestResponse) ProtoMessage() {}
+
+func (x *GetManifestResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetManifestResponse.ProtoReflect.Descriptor instead.
+func (*GetManifestResponse) Descriptor() ([]byte, []int) {
+	return file_sdl_v1_models_canvas_service_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetManifestResponse) GetManifest() *Manifest {
+	if x != nil {
+		return x.Manifest
+	}
+	return nil
+}
+
 type AddGeneratorRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	WorkspaceId   string                 `protobuf:"bytes,1,opt,name=workspace_id,json=workspaceId,proto3" json:"workspace_id,omitempty"`
@@ -210,7 +298,7 @@ type AddGeneratorRequest struct {
 
 func (x *AddGeneratorRequest) Reset() {
 	*x = AddGeneratorRequest{}
-	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[4]
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -222,7 +310,7 @@ func (x *AddGeneratorRequest) String() string {
 func (*AddGeneratorRequest) ProtoMessage() {}
 
 func (x *AddGeneratorRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[4]
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -235,7 +323,7 @@ func (x *AddGeneratorRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AddGeneratorRequest.ProtoReflect.Descriptor instead.
 func (*AddGeneratorRequest) Descriptor() ([]byte, []int) {
-	return file_sdl_v1_models_canvas_service_proto_rawDescGZIP(), []int{4}
+	return file_sdl_v1_models_canvas_service_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *AddGeneratorRequest) GetWorkspaceId() string {
@@ -268,7 +356,7 @@ type AddGeneratorResponse struct {
 
 func (x *AddGeneratorResponse) Reset() {
 	*x = AddGeneratorResponse{}
-	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[5]
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -280,7 +368,7 @@ func (x *AddGeneratorResponse) String() string {
 func (*AddGeneratorResponse) ProtoMessage() {}
 
 func (x *AddGeneratorResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[5]
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -293,7 +381,7 @@ func (x *AddGeneratorResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AddGeneratorResponse.ProtoReflect.Descriptor instead.
 func (*AddGeneratorResponse) Descriptor() ([]byte, []int) {
-	return file_sdl_v1_models_canvas_service_proto_rawDescGZIP(), []int{5}
+	return file_sdl_v1_models_canvas_service_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *AddGeneratorResponse) GetGenerator() *Generator {
@@ -312,7 +400,7 @@ type ListGeneratorsRequest struct {
 
 func (x *ListGeneratorsRequest) Reset() {
 	*x = ListGeneratorsRequest{}
-	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[6]
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -324,7 +412,7 @@ func (x *ListGeneratorsRequest) String() string {
 func (*ListGeneratorsRequest) ProtoMessage() {}
 
 func (x *ListGeneratorsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[6]
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -337,7 +425,7 @@ func (x *ListGeneratorsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListGeneratorsRequest.ProtoReflect.Descriptor instead.
 func (*ListGeneratorsRequest) Descriptor() ([]byte, []int) {
-	return file_sdl_v1_models_canvas_service_proto_rawDescGZIP(), []int{6}
+	return file_sdl_v1_models_canvas_service_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *ListGeneratorsRequest) GetWorkspaceId() string {
@@ -356,7 +444,7 @@ type ListGeneratorsResponse struct {
 
 func (x *ListGeneratorsResponse) Reset() {
 	*x = ListGeneratorsResponse{}
-	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[7]
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -368,7 +456,7 @@ func (x *ListGeneratorsResponse) String() string {
 func (*ListGeneratorsResponse) ProtoMessage() {}
 
 func (x *ListGeneratorsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[7]
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -381,7 +469,7 @@ func (x *ListGeneratorsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListGeneratorsResponse.ProtoReflect.Descriptor instead.
 func (*ListGeneratorsResponse) Descriptor() ([]byte, []int) {
-	return file_sdl_v1_models_canvas_service_proto_rawDescGZIP(), []int{7}
+	return file_sdl_v1_models_canvas_service_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *ListGeneratorsResponse) GetGenerators() []*Generator {
@@ -401,7 +489,7 @@ type GetGeneratorRequest struct {
 
 func (x *GetGeneratorRequest) Reset() {
 	*x = GetGeneratorRequest{}
-	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[8]
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -413,7 +501,7 @@ func (x *GetGeneratorRequest) String() string {
 func (*GetGeneratorRequest) ProtoMessage() {}
 
 func (x *GetGeneratorRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[8]
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -426,7 +514,7 @@ func (x *GetGeneratorRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetGeneratorRequest.ProtoReflect.Descriptor instead.
 func (*GetGeneratorRequest) Descriptor() ([]byte, []int) {
-	return file_sdl_v1_models_canvas_service_proto_rawDescGZIP(), []int{8}
+	return file_sdl_v1_models_canvas_service_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *GetGeneratorRequest) GetWorkspaceId() string {
@@ -452,7 +540,7 @@ type GetGeneratorResponse struct {
 
 func (x *GetGeneratorResponse) Reset() {
 	*x = GetGeneratorResponse{}
-	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[9]
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -464,7 +552,7 @@ func (x *GetGeneratorResponse) String() string {
 func (*GetGeneratorResponse) ProtoMessage() {}
 
 func (x *GetGeneratorResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[9]
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -477,7 +565,7 @@ func (x *GetGeneratorResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetGeneratorResponse.ProtoReflect.Descriptor instead.
 func (*GetGeneratorResponse) Descriptor() ([]byte, []int) {
-	return file_sdl_v1_models_canvas_service_proto_rawDescGZIP(), []int{9}
+	return file_sdl_v1_models_canvas_service_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *GetGeneratorResponse) GetGenerator() *Generator {
@@ -498,7 +586,7 @@ type UpdateGeneratorRequest struct {
 
 func (x *UpdateGeneratorRequest) Reset() {
 	*x = UpdateGeneratorRequest{}
-	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[10]
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -510,7 +598,7 @@ func (x *UpdateGeneratorRequest) String() string {
 func (*UpdateGeneratorRequest) ProtoMessage() {}
 
 func (x *UpdateGeneratorRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[10]
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -523,7 +611,7 @@ func (x *UpdateGeneratorRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateGeneratorRequest.ProtoReflect.Descriptor instead.
 func (*UpdateGeneratorRequest) Descriptor() ([]byte, []int) {
-	return file_sdl_v1_models_canvas_service_proto_rawDescGZIP(), []int{10}
+	return file_sdl_v1_models_canvas_service_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *UpdateGeneratorRequest) GetWorkspaceId() string {
@@ -556,7 +644,7 @@ type UpdateGeneratorResponse struct {
 
 func (x *UpdateGeneratorResponse) Reset() {
 	*x = UpdateGeneratorResponse{}
-	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[11]
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -568,7 +656,7 @@ func (x *UpdateGeneratorResponse) String() string {
 func (*UpdateGeneratorResponse) ProtoMessage() {}
 
 func (x *UpdateGeneratorResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[11]
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -581,7 +669,7 @@ func (x *UpdateGeneratorResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateGeneratorResponse.ProtoReflect.Descriptor instead.
 func (*UpdateGeneratorResponse) Descriptor() ([]byte, []int) {
-	return file_sdl_v1_models_canvas_service_proto_rawDescGZIP(), []int{11}
+	return file_sdl_v1_models_canvas_service_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *UpdateGeneratorResponse) GetGenerator() *Generator {
@@ -601,7 +689,7 @@ type StartGeneratorRequest struct {
 
 func (x *StartGeneratorRequest) Reset() {
 	*x = StartGeneratorRequest{}
-	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[12]
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -613,7 +701,7 @@ func (x *StartGeneratorRequest) String() string {
 func (*StartGeneratorRequest) ProtoMessage() {}
 
 func (x *StartGeneratorRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[12]
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -626,7 +714,7 @@ func (x *StartGeneratorRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StartGeneratorRequest.ProtoReflect.Descriptor instead.
 func (*StartGeneratorRequest) Descriptor() ([]byte, []int) {
-	return file_sdl_v1_models_canvas_service_proto_rawDescGZIP(), []int{12}
+	return file_sdl_v1_models_canvas_service_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *StartGeneratorRequest) GetWorkspaceId() string {
@@ -651,7 +739,7 @@ type StartGeneratorResponse struct {
 
 func (x *StartGeneratorResponse) Reset() {
 	*x = StartGeneratorResponse{}
-	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[13]
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -663,7 +751,7 @@ func (x *StartGeneratorResponse) String() string {
 func (*StartGeneratorResponse) ProtoMessage() {}
 
 func (x *StartGeneratorResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[13]
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -676,7 +764,7 @@ func (x *StartGeneratorResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StartGeneratorResponse.ProtoReflect.Descriptor instead.
 func (*StartGeneratorResponse) Descriptor() ([]byte, []int) {
-	return file_sdl_v1_models_canvas_service_proto_rawDescGZIP(), []int{13}
+	return file_sdl_v1_models_canvas_service_proto_rawDescGZIP(), []int{15}
 }
 
 type StopGeneratorRequest struct {
@@ -689,7 +777,7 @@ type StopGeneratorRequest struct {
 
 func (x *StopGeneratorRequest) Reset() {
 	*x = StopGeneratorRequest{}
-	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[14]
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -701,7 +789,7 @@ func (x *StopGeneratorRequest) String() string {
 func (*StopGeneratorRequest) ProtoMessage() {}
 
 func (x *StopGeneratorRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[14]
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -714,7 +802,7 @@ func (x *StopGeneratorRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StopGeneratorRequest.ProtoReflect.Descriptor instead.
 func (*StopGeneratorRequest) Descriptor() ([]byte, []int) {
-	return file_sdl_v1_models_canvas_service_proto_rawDescGZIP(), []int{14}
+	return file_sdl_v1_models_canvas_service_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *StopGeneratorRequest) GetWorkspaceId() string {
@@ -739,7 +827,7 @@ type StopGeneratorResponse struct {
 
 func (x *StopGeneratorResponse) Reset() {
 	*x = StopGeneratorResponse{}
-	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[15]
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -751,7 +839,7 @@ func (x *StopGeneratorResponse) String() string {
 func (*StopGeneratorResponse) ProtoMessage() {}
 
 func (x *StopGeneratorResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[15]
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -764,7 +852,7 @@ func (x *StopGeneratorResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StopGeneratorResponse.ProtoReflect.Descriptor instead.
 func (*StopGeneratorResponse) Descriptor() ([]byte, []int) {
-	return file_sdl_v1_models_canvas_service_proto_rawDescGZIP(), []int{15}
+	return file_sdl_v1_models_canvas_service_proto_rawDescGZIP(), []int{17}
 }
 
 type DeleteGeneratorRequest struct {
@@ -778,7 +866,7 @@ type DeleteGeneratorRequest struct {
 
 func (x *DeleteGeneratorRequest) Reset() {
 	*x = DeleteGeneratorRequest{}
-	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[16]
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -790,7 +878,7 @@ func (x *DeleteGeneratorRequest) String() string {
 func (*DeleteGeneratorRequest) ProtoMessage() {}
 
 func (x *DeleteGeneratorRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[16]
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -803,7 +891,7 @@ func (x *DeleteGeneratorRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteGeneratorRequest.ProtoReflect.Descriptor instead.
 func (*DeleteGeneratorRequest) Descriptor() ([]byte, []int) {
-	return file_sdl_v1_models_canvas_service_proto_rawDescGZIP(), []int{16}
+	return file_sdl_v1_models_canvas_service_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *DeleteGeneratorRequest) GetWorkspaceId() string {
@@ -835,7 +923,7 @@ type DeleteGeneratorResponse struct {
 
 func (x *DeleteGeneratorResponse) Reset() {
 	*x = DeleteGeneratorResponse{}
-	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[17]
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -847,7 +935,7 @@ func (x *DeleteGeneratorResponse) String() string {
 func (*DeleteGeneratorResponse) ProtoMessage() {}
 
 func (x *DeleteGeneratorResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[17]
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -860,7 +948,7 @@ func (x *DeleteGeneratorResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteGeneratorResponse.ProtoReflect.Descriptor instead.
 func (*DeleteGeneratorResponse) Descriptor() ([]byte, []int) {
-	return file_sdl_v1_models_canvas_service_proto_rawDescGZIP(), []int{17}
+	return file_sdl_v1_models_canvas_service_proto_rawDescGZIP(), []int{19}
 }
 
 type StartAllGeneratorsRequest struct {
@@ -872,7 +960,7 @@ type StartAllGeneratorsRequest struct {
 
 func (x *StartAllGeneratorsRequest) Reset() {
 	*x = StartAllGeneratorsRequest{}
-	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[18]
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -884,7 +972,7 @@ func (x *StartAllGeneratorsRequest) String() string {
 func (*StartAllGeneratorsRequest) ProtoMessage() {}
 
 func (x *StartAllGeneratorsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[18]
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -897,7 +985,7 @@ func (x *StartAllGeneratorsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StartAllGeneratorsRequest.ProtoReflect.Descriptor instead.
 func (*StartAllGeneratorsRequest) Descriptor() ([]byte, []int) {
-	return file_sdl_v1_models_canvas_service_proto_rawDescGZIP(), []int{18}
+	return file_sdl_v1_models_canvas_service_proto_rawDescGZIP(), []int{20}
 }
 
 func (x *StartAllGeneratorsRequest) GetWorkspaceId() string {
@@ -920,7 +1008,7 @@ type StartAllGeneratorsResponse struct {
 
 func (x *StartAllGeneratorsResponse) Reset() {
 	*x = StartAllGeneratorsResponse{}
-	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[19]
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -932,7 +1020,7 @@ func (x *StartAllGeneratorsResponse) String() string {
 func (*StartAllGeneratorsResponse) ProtoMessage() {}
 
 func (x *StartAllGeneratorsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[19]
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -945,7 +1033,7 @@ func (x *StartAllGeneratorsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StartAllGeneratorsResponse.ProtoReflect.Descriptor instead.
 func (*StartAllGeneratorsResponse) Descriptor() ([]byte, []int) {
-	return file_sdl_v1_models_canvas_service_proto_rawDescGZIP(), []int{19}
+	return file_sdl_v1_models_canvas_service_proto_rawDescGZIP(), []int{21}
 }
 
 func (x *StartAllGeneratorsResponse) GetTotalGenerators() int32 {
@@ -992,7 +1080,7 @@ type StopAllGeneratorsRequest struct {
 
 func (x *StopAllGeneratorsRequest) Reset() {
 	*x = StopAllGeneratorsRequest{}
-	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[20]
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1004,7 +1092,7 @@ func (x *StopAllGeneratorsRequest) String() string {
 func (*StopAllGeneratorsRequest) ProtoMessage() {}
 
 func (x *StopAllGeneratorsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[20]
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1017,7 +1105,7 @@ func (x *StopAllGeneratorsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StopAllGeneratorsRequest.ProtoReflect.Descriptor instead.
 func (*StopAllGeneratorsRequest) Descriptor() ([]byte, []int) {
-	return file_sdl_v1_models_canvas_service_proto_rawDescGZIP(), []int{20}
+	return file_sdl_v1_models_canvas_service_proto_rawDescGZIP(), []int{22}
 }
 
 func (x *StopAllGeneratorsRequest) GetWorkspaceId() string {
@@ -1040,7 +1128,7 @@ type StopAllGeneratorsResponse struct {
 
 func (x *StopAllGeneratorsResponse) Reset() {
 	*x = StopAllGeneratorsResponse{}
-	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[21]
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1052,7 +1140,7 @@ func (x *StopAllGeneratorsResponse) String() string {
 func (*StopAllGeneratorsResponse) ProtoMessage() {}
 
 func (x *StopAllGeneratorsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[21]
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1065,7 +1153,7 @@ func (x *StopAllGeneratorsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StopAllGeneratorsResponse.ProtoReflect.Descriptor instead.
 func (*StopAllGeneratorsResponse) Descriptor() ([]byte, []int) {
-	return file_sdl_v1_models_canvas_service_proto_rawDescGZIP(), []int{21}
+	return file_sdl_v1_models_canvas_service_proto_rawDescGZIP(), []int{23}
 }
 
 func (x *StopAllGeneratorsResponse) GetTotalGenerators() int32 {
@@ -1113,7 +1201,7 @@ type AddMetricRequest struct {
 
 func (x *AddMetricRequest) Reset() {
 	*x = AddMetricRequest{}
-	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[22]
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1125,7 +1213,7 @@ func (x *AddMetricRequest) String() string {
 func (*AddMetricRequest) ProtoMessage() {}
 
 func (x *AddMetricRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[22]
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1138,7 +1226,7 @@ func (x *AddMetricRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AddMetricRequest.ProtoReflect.Descriptor instead.
 func (*AddMetricRequest) Descriptor() ([]byte, []int) {
-	return file_sdl_v1_models_canvas_service_proto_rawDescGZIP(), []int{22}
+	return file_sdl_v1_models_canvas_service_proto_rawDescGZIP(), []int{24}
 }
 
 func (x *AddMetricRequest) GetWorkspaceId() string {
@@ -1164,7 +1252,7 @@ type AddMetricResponse struct {
 
 func (x *AddMetricResponse) Reset() {
 	*x = AddMetricResponse{}
-	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[23]
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1176,7 +1264,7 @@ func (x *AddMetricResponse) String() string {
 func (*AddMetricResponse) ProtoMessage() {}
 
 func (x *AddMetricResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[23]
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1189,7 +1277,7 @@ func (x *AddMetricResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AddMetricResponse.ProtoReflect.Descriptor instead.
 func (*AddMetricResponse) Descriptor() ([]byte, []int) {
-	return file_sdl_v1_models_canvas_service_proto_rawDescGZIP(), []int{23}
+	return file_sdl_v1_models_canvas_service_proto_rawDescGZIP(), []int{25}
 }
 
 func (x *AddMetricResponse) GetMetric() *Metric {
@@ -1209,7 +1297,7 @@ type DeleteMetricRequest struct {
 
 func (x *DeleteMetricRequest) Reset() {
 	*x = DeleteMetricRequest{}
-	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[24]
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[26]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1221,7 +1309,7 @@ func (x *DeleteMetricRequest) String() string {
 func (*DeleteMetricRequest) ProtoMessage() {}
 
 func (x *DeleteMetricRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[24]
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[26]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1234,7 +1322,7 @@ func (x *DeleteMetricRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteMetricRequest.ProtoReflect.Descriptor instead.
 func (*DeleteMetricRequest) Descriptor() ([]byte, []int) {
-	return file_sdl_v1_models_canvas_service_proto_rawDescGZIP(), []int{24}
+	return file_sdl_v1_models_canvas_service_proto_rawDescGZIP(), []int{26}
 }
 
 func (x *DeleteMetricRequest) GetWorkspaceId() string {
@@ -1259,7 +1347,7 @@ type DeleteMetricResponse struct {
 
 func (x *DeleteMetricResponse) Reset() {
 	*x = DeleteMetricResponse{}
-	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[25]
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[27]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1271,7 +1359,7 @@ func (x *DeleteMetricResponse) String() string {
 func (*DeleteMetricResponse) ProtoMessage() {}
 
 func (x *DeleteMetricResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[25]
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[27]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1284,7 +1372,7 @@ func (x *DeleteMetricResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteMetricResponse.ProtoReflect.Descriptor instead.
 func (*DeleteMetricResponse) Descriptor() ([]byte, []int) {
-	return file_sdl_v1_models_canvas_service_proto_rawDescGZIP(), []int{25}
+	return file_sdl_v1_models_canvas_service_proto_rawDescGZIP(), []int{27}
 }
 
 type ListMetricsRequest struct {
@@ -1296,7 +1384,7 @@ type ListMetricsRequest struct {
 
 func (x *ListMetricsRequest) Reset() {
 	*x = ListMetricsRequest{}
-	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[26]
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[28]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1308,7 +1396,7 @@ func (x *ListMetricsRequest) String() string {
 func (*ListMetricsRequest) ProtoMessage() {}
 
 func (x *ListMetricsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[26]
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[28]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1321,7 +1409,7 @@ func (x *ListMetricsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListMetricsRequest.ProtoReflect.Descriptor instead.
 func (*ListMetricsRequest) Descriptor() ([]byte, []int) {
-	return file_sdl_v1_models_canvas_service_proto_rawDescGZIP(), []int{26}
+	return file_sdl_v1_models_canvas_service_proto_rawDescGZIP(), []int{28}
 }
 
 func (x *ListMetricsRequest) GetWorkspaceId() string {
@@ -1340,7 +1428,7 @@ type ListMetricsResponse struct {
 
 func (x *ListMetricsResponse) Reset() {
 	*x = ListMetricsResponse{}
-	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[27]
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[29]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1352,7 +1440,7 @@ func (x *ListMetricsResponse) String() string {
 func (*ListMetricsResponse) ProtoMessage() {}
 
 func (x *ListMetricsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[27]
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[29]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1365,7 +1453,7 @@ func (x *ListMetricsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListMetricsResponse.ProtoReflect.Descriptor instead.
 func (*ListMetricsResponse) Descriptor() ([]byte, []int) {
-	return file_sdl_v1_models_canvas_service_proto_rawDescGZIP(), []int{27}
+	return file_sdl_v1_models_canvas_service_proto_rawDescGZIP(), []int{29}
 }
 
 func (x *ListMetricsResponse) GetMetrics() []*Metric {
@@ -1388,7 +1476,7 @@ type QueryMetricsRequest struct {
 
 func (x *QueryMetricsRequest) Reset() {
 	*x = QueryMetricsRequest{}
-	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[28]
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[30]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1400,7 +1488,7 @@ func (x *QueryMetricsRequest) String() string {
 func (*QueryMetricsRequest) ProtoMessage() {}
 
 func (x *QueryMetricsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[28]
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[30]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1413,7 +1501,7 @@ func (x *QueryMetricsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use QueryMetricsRequest.ProtoReflect.Descriptor instead.
 func (*QueryMetricsRequest) Descriptor() ([]byte, []int) {
-	return file_sdl_v1_models_canvas_service_proto_rawDescGZIP(), []int{28}
+	return file_sdl_v1_models_canvas_service_proto_rawDescGZIP(), []int{30}
 }
 
 func (x *QueryMetricsRequest) GetWorkspaceId() string {
@@ -1460,7 +1548,7 @@ type QueryMetricsResponse struct {
 
 func (x *QueryMetricsResponse) Reset() {
 	*x = QueryMetricsResponse{}
-	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[29]
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[31]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1472,7 +1560,7 @@ func (x *QueryMetricsResponse) String() string {
 func (*QueryMetricsResponse) ProtoMessage() {}
 
 func (x *QueryMetricsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[29]
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[31]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1485,7 +1573,7 @@ func (x *QueryMetricsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use QueryMetricsResponse.ProtoReflect.Descriptor instead.
 func (*QueryMetricsResponse) Descriptor() ([]byte, []int) {
-	return file_sdl_v1_models_canvas_service_proto_rawDescGZIP(), []int{29}
+	return file_sdl_v1_models_canvas_service_proto_rawDescGZIP(), []int{31}
 }
 
 func (x *QueryMetricsResponse) GetPoints() []*MetricPoint {
@@ -1495,33 +1583,28 @@ func (x *QueryMetricsResponse) GetPoints() []*MetricPoint {
 	return nil
 }
 
-type AggregateMetricsRequest struct {
+type GetMeasurementStatsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	WorkspaceId   string                 `protobuf:"bytes,1,opt,name=workspace_id,json=workspaceId,proto3" json:"workspace_id,omitempty"`
-	MetricName    string                 `protobuf:"bytes,2,opt,name=metric_name,json=metricName,proto3" json:"metric_name,omitempty"`
-	StartTime     float64                `protobuf:"fixed64,3,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
-	EndTime       float64                `protobuf:"fixed64,4,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
-	Function      string                 `protobuf:"bytes,5,opt,name=function,proto3" json:"function,omitempty"`
-	WindowSize    float64                `protobuf:"fixed64,6,opt,name=window_size,json=windowSize,proto3" json:"window_size,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AggregateMetricsRequest) Reset() {
-	*x = AggregateMetricsRequest{}
-	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[30]
+func (x *GetMeasurementStatsRequest) Reset() {
+	*x = GetMeasurementStatsRequest{}
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[32]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AggregateMetricsRequest) String() string {
+func (x *GetMeasurementStatsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AggregateMetricsRequest) ProtoMessage() {}
+func (*GetMeasurementStatsRequest) ProtoMessage() {}
 
-func (x *AggregateMetricsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[30]
+func (x *GetMeasurementStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[32]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1532,30 +1615,163 @@ func (x *AggregateMetricsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AggregateMetricsRequest.ProtoReflect.Descriptor instead.
-func (*AggregateMetricsRequest) Descriptor() ([]byte, []int) {
-	return file_sdl_v1_models_canvas_service_proto_rawDescGZIP(), []int{30}
+// Deprecated: Use GetMeasurementStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetMeasurementStatsRequest) Descriptor() ([]byte, []int) {
+	return file_sdl_v1_models_canvas_service_proto_rawDescGZIP(), []int{32}
 }
 
-func (x *AggregateMetricsRequest) GetWorkspaceId() string {
+func (x *GetMeasurementStatsRequest) GetWorkspaceId() string {
 	if x != nil {
 		return x.WorkspaceId
 	}
 	return ""
 }
 
-func (x *AggregateMetricsRequest) GetMetricName() string {
-	if x != nil {
-		return x.MetricName
-	}
-	return ""
-}
-
-func (x *AggregateMetricsRequest) GetStartTime() float64 {
-	if x != nil {
-		return x.StartTime
-	}
-	return 0
+type GetMeasurementStatsResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	TotalRows       int64                  `protobuf:"varint,1,opt,name=total_rows,json=totalRows,proto3" json:"total_rows,omitempty"`
+	RowsPerMetric   map[string]int64       `protobuf:"bytes,2,rep,name=rows_per_metric,json=rowsPerMetric,proto3" json:"rows_per_metric,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	OldestTimestamp float64                `protobuf:"fixed64,3,opt,name=oldest_timestamp,json=oldestTimestamp,proto3" json:"oldest_timestamp,omitempty"` // Unix timestamp in seconds, 0 if the store is empty
+	NewestTimestamp float64                `protobuf:"fixed64,4,opt,name=newest_timestamp,json=newestTimestamp,proto3" json:"newest_timestamp,omitempty"`
+	SizeBytes       int64                  `protobuf:"varint,5,opt,name=size_bytes,json=sizeBytes,proto3" json:"size_bytes,omitempty"`  // Approximate memory (or disk) used by stored points
+	WriteRate       float64                `protobuf:"fixed64,6,opt,name=write_rate,json=writeRate,proto3" json:"write_rate,omitempty"` // Points written per second since the first write
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *GetMeasurementStatsResponse) Reset() {
+	*x = GetMeasurementStatsResponse{}
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMeasurementStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMeasurementStatsResponse) ProtoMessage() {}
+
+func (x *GetMeasurementStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMeasurementStatsResponse.ProtoReflect.Descriptor instead.
+func (*GetMeasurementStatsResponse) Descriptor() ([]byte, []int) {
+	return file_sdl_v1_models_canvas_service_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *GetMeasurementStatsResponse) GetTotalRows() int64 {
+	if x != nil {
+		return x.TotalRows
+	}
+	return 0
+}
+
+func (x *GetMeasurementStatsResponse) GetRowsPerMetric() map[string]int64 {
+	if x != nil {
+		return x.RowsPerMetric
+	}
+	return nil
+}
+
+func (x *GetMeasurementStatsResponse) GetOldestTimestamp() float64 {
+	if x != nil {
+		return x.OldestTimestamp
+	}
+	return 0
+}
+
+func (x *GetMeasurementStatsResponse) GetNewestTimestamp() float64 {
+	if x != nil {
+		return x.NewestTimestamp
+	}
+	return 0
+}
+
+func (x *GetMeasurementStatsResponse) GetSizeBytes() int64 {
+	if x != nil {
+		return x.SizeBytes
+	}
+	return 0
+}
+
+func (x *GetMeasurementStatsResponse) GetWriteRate() float64 {
+	if x != nil {
+		return x.WriteRate
+	}
+	return 0
+}
+
+type AggregateMetricsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WorkspaceId   string                 `protobuf:"bytes,1,opt,name=workspace_id,json=workspaceId,proto3" json:"workspace_id,omitempty"`
+	MetricName    string                 `protobuf:"bytes,2,opt,name=metric_name,json=metricName,proto3" json:"metric_name,omitempty"`
+	StartTime     float64                `protobuf:"fixed64,3,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime       float64                `protobuf:"fixed64,4,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	Function      string                 `protobuf:"bytes,5,opt,name=function,proto3" json:"function,omitempty"`
+	WindowSize    float64                `protobuf:"fixed64,6,opt,name=window_size,json=windowSize,proto3" json:"window_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AggregateMetricsRequest) Reset() {
+	*x = AggregateMetricsRequest{}
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AggregateMetricsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AggregateMetricsRequest) ProtoMessage() {}
+
+func (x *AggregateMetricsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AggregateMetricsRequest.ProtoReflect.Descriptor instead.
+func (*AggregateMetricsRequest) Descriptor() ([]byte, []int) {
+	return file_sdl_v1_models_canvas_service_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *AggregateMetricsRequest) GetWorkspaceId() string {
+	if x != nil {
+		return x.WorkspaceId
+	}
+	return ""
+}
+
+func (x *AggregateMetricsRequest) GetMetricName() string {
+	if x != nil {
+		return x.MetricName
+	}
+	return ""
+}
+
+func (x *AggregateMetricsRequest) GetStartTime() float64 {
+	if x != nil {
+		return x.StartTime
+	}
+	return 0
 }
 
 func (x *AggregateMetricsRequest) GetEndTime() float64 {
@@ -1588,7 +1804,7 @@ type AggregateMetricsResponse struct {
 
 func (x *AggregateMetricsResponse) Reset() {
 	*x = AggregateMetricsResponse{}
-	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[31]
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[35]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1600,7 +1816,7 @@ func (x *AggregateMetricsResponse) String() string {
 func (*AggregateMetricsResponse) ProtoMessage() {}
 
 func (x *AggregateMetricsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[31]
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[35]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1613,7 +1829,7 @@ func (x *AggregateMetricsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AggregateMetricsResponse.ProtoReflect.Descriptor instead.
 func (*AggregateMetricsResponse) Descriptor() ([]byte, []int) {
-	return file_sdl_v1_models_canvas_service_proto_rawDescGZIP(), []int{31}
+	return file_sdl_v1_models_canvas_service_proto_rawDescGZIP(), []int{35}
 }
 
 func (x *AggregateMetricsResponse) GetResults() []*AggregateResult {
@@ -1633,7 +1849,7 @@ type StreamMetricsRequest struct {
 
 func (x *StreamMetricsRequest) Reset() {
 	*x = StreamMetricsRequest{}
-	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[32]
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[36]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1645,7 +1861,7 @@ func (x *StreamMetricsRequest) String() string {
 func (*StreamMetricsRequest) ProtoMessage() {}
 
 func (x *StreamMetricsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[32]
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[36]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1658,7 +1874,7 @@ func (x *StreamMetricsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StreamMetricsRequest.ProtoReflect.Descriptor instead.
 func (*StreamMetricsRequest) Descriptor() ([]byte, []int) {
-	return file_sdl_v1_models_canvas_service_proto_rawDescGZIP(), []int{32}
+	return file_sdl_v1_models_canvas_service_proto_rawDescGZIP(), []int{36}
 }
 
 func (x *StreamMetricsRequest) GetWorkspaceId() string {
@@ -1684,7 +1900,7 @@ type StreamMetricsResponse struct {
 
 func (x *StreamMetricsResponse) Reset() {
 	*x = StreamMetricsResponse{}
-	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[33]
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[37]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1696,7 +1912,7 @@ func (x *StreamMetricsResponse) String() string {
 func (*StreamMetricsResponse) ProtoMessage() {}
 
 func (x *StreamMetricsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[33]
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[37]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1709,7 +1925,7 @@ func (x *StreamMetricsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StreamMetricsResponse.ProtoReflect.Descriptor instead.
 func (*StreamMetricsResponse) Descriptor() ([]byte, []int) {
-	return file_sdl_v1_models_canvas_service_proto_rawDescGZIP(), []int{33}
+	return file_sdl_v1_models_canvas_service_proto_rawDescGZIP(), []int{37}
 }
 
 func (x *StreamMetricsResponse) GetUpdates() []*MetricUpdate {
@@ -1730,7 +1946,7 @@ type ExecuteTraceRequest struct {
 
 func (x *ExecuteTraceRequest) Reset() {
 	*x = ExecuteTraceRequest{}
-	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[34]
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[38]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1742,7 +1958,7 @@ func (x *ExecuteTraceRequest) String() string {
 func (*ExecuteTraceRequest) ProtoMessage() {}
 
 func (x *ExecuteTraceRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[34]
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[38]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1755,7 +1971,7 @@ func (x *ExecuteTraceRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ExecuteTraceRequest.ProtoReflect.Descriptor instead.
 func (*ExecuteTraceRequest) Descriptor() ([]byte, []int) {
-	return file_sdl_v1_models_canvas_service_proto_rawDescGZIP(), []int{34}
+	return file_sdl_v1_models_canvas_service_proto_rawDescGZIP(), []int{38}
 }
 
 func (x *ExecuteTraceRequest) GetWorkspaceId() string {
@@ -1788,7 +2004,7 @@ type ExecuteTraceResponse struct {
 
 func (x *ExecuteTraceResponse) Reset() {
 	*x = ExecuteTraceResponse{}
-	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[35]
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[39]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1800,7 +2016,7 @@ func (x *ExecuteTraceResponse) String() string {
 func (*ExecuteTraceResponse) ProtoMessage() {}
 
 func (x *ExecuteTraceResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[35]
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[39]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1813,7 +2029,7 @@ func (x *ExecuteTraceResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ExecuteTraceResponse.ProtoReflect.Descriptor instead.
 func (*ExecuteTraceResponse) Descriptor() ([]byte, []int) {
-	return file_sdl_v1_models_canvas_service_proto_rawDescGZIP(), []int{35}
+	return file_sdl_v1_models_canvas_service_proto_rawDescGZIP(), []int{39}
 }
 
 func (x *ExecuteTraceResponse) GetTraceData() *TraceData {
@@ -1823,6 +2039,66 @@ func (x *ExecuteTraceResponse) GetTraceData() *TraceData {
 	return nil
 }
 
+type StreamTracesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WorkspaceId   string                 `protobuf:"bytes,1,opt,name=workspace_id,json=workspaceId,proto3" json:"workspace_id,omitempty"`
+	Component     string                 `protobuf:"bytes,2,opt,name=component,proto3" json:"component,omitempty"`
+	Method        string                 `protobuf:"bytes,3,opt,name=method,proto3" json:"method,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamTracesRequest) Reset() {
+	*x = StreamTracesRequest{}
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamTracesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamTracesRequest) ProtoMessage() {}
+
+func (x *StreamTracesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamTracesRequest.ProtoReflect.Descriptor instead.
+func (*StreamTracesRequest) Descriptor() ([]byte, []int) {
+	return file_sdl_v1_models_canvas_service_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *StreamTracesRequest) GetWorkspaceId() string {
+	if x != nil {
+		return x.WorkspaceId
+	}
+	return ""
+}
+
+func (x *StreamTracesRequest) GetComponent() string {
+	if x != nil {
+		return x.Component
+	}
+	return ""
+}
+
+func (x *StreamTracesRequest) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
 type TraceAllPathsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	WorkspaceId   string                 `protobuf:"bytes,1,opt,name=workspace_id,json=workspaceId,proto3" json:"workspace_id,omitempty"`
@@ -1835,7 +2111,7 @@ type TraceAllPathsRequest struct {
 
 func (x *TraceAllPathsRequest) Reset() {
 	*x = TraceAllPathsRequest{}
-	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[36]
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[41]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1847,7 +2123,7 @@ func (x *TraceAllPathsRequest) String() string {
 func (*TraceAllPathsRequest) ProtoMessage() {}
 
 func (x *TraceAllPathsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[36]
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[41]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1860,7 +2136,7 @@ func (x *TraceAllPathsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TraceAllPathsRequest.ProtoReflect.Descriptor instead.
 func (*TraceAllPathsRequest) Descriptor() ([]byte, []int) {
-	return file_sdl_v1_models_canvas_service_proto_rawDescGZIP(), []int{36}
+	return file_sdl_v1_models_canvas_service_proto_rawDescGZIP(), []int{41}
 }
 
 func (x *TraceAllPathsRequest) GetWorkspaceId() string {
@@ -1900,7 +2176,7 @@ type TraceAllPathsResponse struct {
 
 func (x *TraceAllPathsResponse) Reset() {
 	*x = TraceAllPathsResponse{}
-	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[37]
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[42]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1912,7 +2188,7 @@ func (x *TraceAllPathsResponse) String() string {
 func (*TraceAllPathsResponse) ProtoMessage() {}
 
 func (x *TraceAllPathsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[37]
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[42]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1925,7 +2201,7 @@ func (x *TraceAllPathsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TraceAllPathsResponse.ProtoReflect.Descriptor instead.
 func (*TraceAllPathsResponse) Descriptor() ([]byte, []int) {
-	return file_sdl_v1_models_canvas_service_proto_rawDescGZIP(), []int{37}
+	return file_sdl_v1_models_canvas_service_proto_rawDescGZIP(), []int{42}
 }
 
 func (x *TraceAllPathsResponse) GetTraceData() *AllPathsTraceData {
@@ -1946,7 +2222,7 @@ type SetParameterRequest struct {
 
 func (x *SetParameterRequest) Reset() {
 	*x = SetParameterRequest{}
-	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[38]
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[43]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1958,7 +2234,7 @@ func (x *SetParameterRequest) String() string {
 func (*SetParameterRequest) ProtoMessage() {}
 
 func (x *SetParameterRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[38]
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[43]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1971,7 +2247,7 @@ func (x *SetParameterRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SetParameterRequest.ProtoReflect.Descriptor instead.
 func (*SetParameterRequest) Descriptor() ([]byte, []int) {
-	return file_sdl_v1_models_canvas_service_proto_rawDescGZIP(), []int{38}
+	return file_sdl_v1_models_canvas_service_proto_rawDescGZIP(), []int{43}
 }
 
 func (x *SetParameterRequest) GetWorkspaceId() string {
@@ -2007,7 +2283,7 @@ type SetParameterResponse struct {
 
 func (x *SetParameterResponse) Reset() {
 	*x = SetParameterResponse{}
-	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[39]
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[44]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2019,7 +2295,7 @@ func (x *SetParameterResponse) String() string {
 func (*SetParameterResponse) ProtoMessage() {}
 
 func (x *SetParameterResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[39]
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[44]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2032,7 +2308,7 @@ func (x *SetParameterResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SetParameterResponse.ProtoReflect.Descriptor instead.
 func (*SetParameterResponse) Descriptor() ([]byte, []int) {
-	return file_sdl_v1_models_canvas_service_proto_rawDescGZIP(), []int{39}
+	return file_sdl_v1_models_canvas_service_proto_rawDescGZIP(), []int{44}
 }
 
 func (x *SetParameterResponse) GetSuccess() bool {
@@ -2073,7 +2349,7 @@ type GetParametersRequest struct {
 
 func (x *GetParametersRequest) Reset() {
 	*x = GetParametersRequest{}
-	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[40]
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[45]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2085,7 +2361,7 @@ func (x *GetParametersRequest) String() string {
 func (*GetParametersRequest) ProtoMessage() {}
 
 func (x *GetParametersRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[40]
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[45]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2098,7 +2374,7 @@ func (x *GetParametersRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetParametersRequest.ProtoReflect.Descriptor instead.
 func (*GetParametersRequest) Descriptor() ([]byte, []int) {
-	return file_sdl_v1_models_canvas_service_proto_rawDescGZIP(), []int{40}
+	return file_sdl_v1_models_canvas_service_proto_rawDescGZIP(), []int{45}
 }
 
 func (x *GetParametersRequest) GetWorkspaceId() string {
@@ -2124,7 +2400,7 @@ type GetParametersResponse struct {
 
 func (x *GetParametersResponse) Reset() {
 	*x = GetParametersResponse{}
-	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[41]
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[46]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2136,7 +2412,7 @@ func (x *GetParametersResponse) String() string {
 func (*GetParametersResponse) ProtoMessage() {}
 
 func (x *GetParametersResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[41]
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[46]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2149,7 +2425,7 @@ func (x *GetParametersResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetParametersResponse.ProtoReflect.Descriptor instead.
 func (*GetParametersResponse) Descriptor() ([]byte, []int) {
-	return file_sdl_v1_models_canvas_service_proto_rawDescGZIP(), []int{41}
+	return file_sdl_v1_models_canvas_service_proto_rawDescGZIP(), []int{46}
 }
 
 func (x *GetParametersResponse) GetParameters() map[string]string {
@@ -2169,7 +2445,7 @@ type BatchSetParametersRequest struct {
 
 func (x *BatchSetParametersRequest) Reset() {
 	*x = BatchSetParametersRequest{}
-	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[42]
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[47]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2181,7 +2457,7 @@ func (x *BatchSetParametersRequest) String() string {
 func (*BatchSetParametersRequest) ProtoMessage() {}
 
 func (x *BatchSetParametersRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[42]
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[47]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2194,7 +2470,7 @@ func (x *BatchSetParametersRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BatchSetParametersRequest.ProtoReflect.Descriptor instead.
 func (*BatchSetParametersRequest) Descriptor() ([]byte, []int) {
-	return file_sdl_v1_models_canvas_service_proto_rawDescGZIP(), []int{42}
+	return file_sdl_v1_models_canvas_service_proto_rawDescGZIP(), []int{47}
 }
 
 func (x *BatchSetParametersRequest) GetWorkspaceId() string {
@@ -2222,7 +2498,7 @@ type BatchSetParametersResponse struct {
 
 func (x *BatchSetParametersResponse) Reset() {
 	*x = BatchSetParametersResponse{}
-	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[43]
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[48]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2234,7 +2510,7 @@ func (x *BatchSetParametersResponse) String() string {
 func (*BatchSetParametersResponse) ProtoMessage() {}
 
 func (x *BatchSetParametersResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[43]
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[48]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2247,7 +2523,7 @@ func (x *BatchSetParametersResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BatchSetParametersResponse.ProtoReflect.Descriptor instead.
 func (*BatchSetParametersResponse) Descriptor() ([]byte, []int) {
-	return file_sdl_v1_models_canvas_service_proto_rawDescGZIP(), []int{43}
+	return file_sdl_v1_models_canvas_service_proto_rawDescGZIP(), []int{48}
 }
 
 func (x *BatchSetParametersResponse) GetSuccess() bool {
@@ -2281,7 +2557,7 @@ type EvaluateFlowsRequest struct {
 
 func (x *EvaluateFlowsRequest) Reset() {
 	*x = EvaluateFlowsRequest{}
-	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[44]
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[49]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2293,7 +2569,7 @@ func (x *EvaluateFlowsRequest) String() string {
 func (*EvaluateFlowsRequest) ProtoMessage() {}
 
 func (x *EvaluateFlowsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[44]
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[49]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2306,7 +2582,7 @@ func (x *EvaluateFlowsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use EvaluateFlowsRequest.ProtoReflect.Descriptor instead.
 func (*EvaluateFlowsRequest) Descriptor() ([]byte, []int) {
-	return file_sdl_v1_models_canvas_service_proto_rawDescGZIP(), []int{44}
+	return file_sdl_v1_models_canvas_service_proto_rawDescGZIP(), []int{49}
 }
 
 func (x *EvaluateFlowsRequest) GetWorkspaceId() string {
@@ -2326,18 +2602,20 @@ func (x *EvaluateFlowsRequest) GetStrategy() string {
 type EvaluateFlowsResponse struct {
 	state          protoimpl.MessageState `protogen:"open.v1"`
 	Strategy       string                 `protobuf:"bytes,1,opt,name=strategy,proto3" json:"strategy,omitempty"`
-	Status         string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Status         string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"` // converged, partial or failed
 	Iterations     int32                  `protobuf:"varint,3,opt,name=iterations,proto3" json:"iterations,omitempty"`
 	Warnings       []string               `protobuf:"bytes,4,rep,name=warnings,proto3" json:"warnings,omitempty"`
 	ComponentRates map[string]float64     `protobuf:"bytes,5,rep,name=component_rates,json=componentRates,proto3" json:"component_rates,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"fixed64,2,opt,name=value"`
 	FlowEdges      []*FlowEdge            `protobuf:"bytes,6,rep,name=flow_edges,json=flowEdges,proto3" json:"flow_edges,omitempty"`
+	Converged      bool                   `protobuf:"varint,7,opt,name=converged,proto3" json:"converged,omitempty"` // Whether the solver converged within its iterations
+	Residual       float64                `protobuf:"fixed64,8,opt,name=residual,proto3" json:"residual,omitempty"`  // Largest rate change in the final iteration
 	unknownFields  protoimpl.UnknownFields
 	sizeCache      protoimpl.SizeCache
 }
 
 func (x *EvaluateFlowsResponse) Reset() {
 	*x = EvaluateFlowsResponse{}
-	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[45]
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[50]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2349,7 +2627,7 @@ func (x *EvaluateFlowsResponse) String() string {
 func (*EvaluateFlowsResponse) ProtoMessage() {}
 
 func (x *EvaluateFlowsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[45]
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[50]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2362,7 +2640,7 @@ func (x *EvaluateFlowsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use EvaluateFlowsResponse.ProtoReflect.Descriptor instead.
 func (*EvaluateFlowsResponse) Descriptor() ([]byte, []int) {
-	return file_sdl_v1_models_canvas_service_proto_rawDescGZIP(), []int{45}
+	return file_sdl_v1_models_canvas_service_proto_rawDescGZIP(), []int{50}
 }
 
 func (x *EvaluateFlowsResponse) GetStrategy() string {
@@ -2407,28 +2685,667 @@ func (x *EvaluateFlowsResponse) GetFlowEdges() []*FlowEdge {
 	return nil
 }
 
-type GetFlowStateRequest struct {
+func (x *EvaluateFlowsResponse) GetConverged() bool {
+	if x != nil {
+		return x.Converged
+	}
+	return false
+}
+
+func (x *EvaluateFlowsResponse) GetResidual() float64 {
+	if x != nil {
+		return x.Residual
+	}
+	return 0
+}
+
+type GetFlowStateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WorkspaceId   string                 `protobuf:"bytes,1,opt,name=workspace_id,json=workspaceId,proto3" json:"workspace_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetFlowStateRequest) Reset() {
+	*x = GetFlowStateRequest{}
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetFlowStateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFlowStateRequest) ProtoMessage() {}
+
+func (x *GetFlowStateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFlowStateRequest.ProtoReflect.Descriptor instead.
+func (*GetFlowStateRequest) Descriptor() ([]byte, []int) {
+	return file_sdl_v1_models_canvas_service_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *GetFlowStateRequest) GetWorkspaceId() string {
+	if x != nil {
+		return x.WorkspaceId
+	}
+	return ""
+}
+
+type GetFlowStateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	State         *FlowState             `protobuf:"bytes,1,opt,name=state,proto3" json:"state,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetFlowStateResponse) Reset() {
+	*x = GetFlowStateResponse{}
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetFlowStateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFlowStateResponse) ProtoMessage() {}
+
+func (x *GetFlowStateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFlowStateResponse.ProtoReflect.Descriptor instead.
+func (*GetFlowStateResponse) Descriptor() ([]byte, []int) {
+	return file_sdl_v1_models_canvas_service_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *GetFlowStateResponse) GetState() *FlowState {
+	if x != nil {
+		return x.State
+	}
+	return nil
+}
+
+// Evaluated flow through one component method.  Rates are calls per second,
+// service_rate is 0 for components without a capacity of their own and
+// latency_contribution is the mean latency of a call in seconds.
+type FlowEntry struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Component           string                 `protobuf:"bytes,1,opt,name=component,proto3" json:"component,omitempty"`
+	Method              string                 `protobuf:"bytes,2,opt,name=method,proto3" json:"method,omitempty"`
+	ArrivalRate         float64                `protobuf:"fixed64,3,opt,name=arrival_rate,json=arrivalRate,proto3" json:"arrival_rate,omitempty"`
+	ServiceRate         float64                `protobuf:"fixed64,4,opt,name=service_rate,json=serviceRate,proto3" json:"service_rate,omitempty"`
+	Utilization         float64                `protobuf:"fixed64,5,opt,name=utilization,proto3" json:"utilization,omitempty"`
+	LatencyContribution float64                `protobuf:"fixed64,6,opt,name=latency_contribution,json=latencyContribution,proto3" json:"latency_contribution,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *FlowEntry) Reset() {
+	*x = FlowEntry{}
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FlowEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FlowEntry) ProtoMessage() {}
+
+func (x *FlowEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FlowEntry.ProtoReflect.Descriptor instead.
+func (*FlowEntry) Descriptor() ([]byte, []int) {
+	return file_sdl_v1_models_canvas_service_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *FlowEntry) GetComponent() string {
+	if x != nil {
+		return x.Component
+	}
+	return ""
+}
+
+func (x *FlowEntry) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+func (x *FlowEntry) GetArrivalRate() float64 {
+	if x != nil {
+		return x.ArrivalRate
+	}
+	return 0
+}
+
+func (x *FlowEntry) GetServiceRate() float64 {
+	if x != nil {
+		return x.ServiceRate
+	}
+	return 0
+}
+
+func (x *FlowEntry) GetUtilization() float64 {
+	if x != nil {
+		return x.Utilization
+	}
+	return 0
+}
+
+func (x *FlowEntry) GetLatencyContribution() float64 {
+	if x != nil {
+		return x.LatencyContribution
+	}
+	return 0
+}
+
+type GetFlowsRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	WorkspaceId string                 `protobuf:"bytes,1,opt,name=workspace_id,json=workspaceId,proto3" json:"workspace_id,omitempty"`
+	// Calls sampled per method to estimate latencies, 100 if unset
+	Samples       int32 `protobuf:"varint,2,opt,name=samples,proto3" json:"samples,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetFlowsRequest) Reset() {
+	*x = GetFlowsRequest{}
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetFlowsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFlowsRequest) ProtoMessage() {}
+
+func (x *GetFlowsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFlowsRequest.ProtoReflect.Descriptor instead.
+func (*GetFlowsRequest) Descriptor() ([]byte, []int) {
+	return file_sdl_v1_models_canvas_service_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *GetFlowsRequest) GetWorkspaceId() string {
+	if x != nil {
+		return x.WorkspaceId
+	}
+	return ""
+}
+
+func (x *GetFlowsRequest) GetSamples() int32 {
+	if x != nil {
+		return x.Samples
+	}
+	return 0
+}
+
+type GetFlowsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	System        string                 `protobuf:"bytes,1,opt,name=system,proto3" json:"system,omitempty"`
+	Strategy      string                 `protobuf:"bytes,2,opt,name=strategy,proto3" json:"strategy,omitempty"`
+	Flows         []*FlowEntry           `protobuf:"bytes,3,rep,name=flows,proto3" json:"flows,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetFlowsResponse) Reset() {
+	*x = GetFlowsResponse{}
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetFlowsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFlowsResponse) ProtoMessage() {}
+
+func (x *GetFlowsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFlowsResponse.ProtoReflect.Descriptor instead.
+func (*GetFlowsResponse) Descriptor() ([]byte, []int) {
+	return file_sdl_v1_models_canvas_service_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *GetFlowsResponse) GetSystem() string {
+	if x != nil {
+		return x.System
+	}
+	return ""
+}
+
+func (x *GetFlowsResponse) GetStrategy() string {
+	if x != nil {
+		return x.Strategy
+	}
+	return ""
+}
+
+func (x *GetFlowsResponse) GetFlows() []*FlowEntry {
+	if x != nil {
+		return x.Flows
+	}
+	return nil
+}
+
+type GetSystemDiagramRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WorkspaceId   string                 `protobuf:"bytes,1,opt,name=workspace_id,json=workspaceId,proto3" json:"workspace_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSystemDiagramRequest) Reset() {
+	*x = GetSystemDiagramRequest{}
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSystemDiagramRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSystemDiagramRequest) ProtoMessage() {}
+
+func (x *GetSystemDiagramRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSystemDiagramRequest.ProtoReflect.Descriptor instead.
+func (*GetSystemDiagramRequest) Descriptor() ([]byte, []int) {
+	return file_sdl_v1_models_canvas_service_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *GetSystemDiagramRequest) GetWorkspaceId() string {
+	if x != nil {
+		return x.WorkspaceId
+	}
+	return ""
+}
+
+type GetSystemDiagramResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Diagram       *SystemDiagram         `protobuf:"bytes,1,opt,name=diagram,proto3" json:"diagram,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSystemDiagramResponse) Reset() {
+	*x = GetSystemDiagramResponse{}
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSystemDiagramResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSystemDiagramResponse) ProtoMessage() {}
+
+func (x *GetSystemDiagramResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSystemDiagramResponse.ProtoReflect.Descriptor instead.
+func (*GetSystemDiagramResponse) Descriptor() ([]byte, []int) {
+	return file_sdl_v1_models_canvas_service_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *GetSystemDiagramResponse) GetDiagram() *SystemDiagram {
+	if x != nil {
+		return x.Diagram
+	}
+	return nil
+}
+
+type GetUtilizationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WorkspaceId   string                 `protobuf:"bytes,1,opt,name=workspace_id,json=workspaceId,proto3" json:"workspace_id,omitempty"`
+	Components    []string               `protobuf:"bytes,2,rep,name=components,proto3" json:"components,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUtilizationRequest) Reset() {
+	*x = GetUtilizationRequest{}
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUtilizationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUtilizationRequest) ProtoMessage() {}
+
+func (x *GetUtilizationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUtilizationRequest.ProtoReflect.Descriptor instead.
+func (*GetUtilizationRequest) Descriptor() ([]byte, []int) {
+	return file_sdl_v1_models_canvas_service_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *GetUtilizationRequest) GetWorkspaceId() string {
+	if x != nil {
+		return x.WorkspaceId
+	}
+	return ""
+}
+
+func (x *GetUtilizationRequest) GetComponents() []string {
+	if x != nil {
+		return x.Components
+	}
+	return nil
+}
+
+type GetUtilizationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Utilizations  []*UtilizationInfo     `protobuf:"bytes,1,rep,name=utilizations,proto3" json:"utilizations,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUtilizationResponse) Reset() {
+	*x = GetUtilizationResponse{}
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUtilizationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUtilizationResponse) ProtoMessage() {}
+
+func (x *GetUtilizationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUtilizationResponse.ProtoReflect.Descriptor instead.
+func (*GetUtilizationResponse) Descriptor() ([]byte, []int) {
+	return file_sdl_v1_models_canvas_service_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *GetUtilizationResponse) GetUtilizations() []*UtilizationInfo {
+	if x != nil {
+		return x.Utilizations
+	}
+	return nil
+}
+
+// LatencyEstimate is an estimated latency in milliseconds with its 95%
+// confidence interval.
+type LatencyEstimate struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Value         float64                `protobuf:"fixed64,1,opt,name=value,proto3" json:"value,omitempty"`
+	CiLow         float64                `protobuf:"fixed64,2,opt,name=ci_low,json=ciLow,proto3" json:"ci_low,omitempty"`
+	CiHigh        float64                `protobuf:"fixed64,3,opt,name=ci_high,json=ciHigh,proto3" json:"ci_high,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LatencyEstimate) Reset() {
+	*x = LatencyEstimate{}
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LatencyEstimate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LatencyEstimate) ProtoMessage() {}
+
+func (x *LatencyEstimate) ProtoReflect() protoreflect.Message {
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LatencyEstimate.ProtoReflect.Descriptor instead.
+func (*LatencyEstimate) Descriptor() ([]byte, []int) {
+	return file_sdl_v1_models_canvas_service_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *LatencyEstimate) GetValue() float64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *LatencyEstimate) GetCiLow() float64 {
+	if x != nil {
+		return x.CiLow
+	}
+	return 0
+}
+
+func (x *LatencyEstimate) GetCiHigh() float64 {
+	if x != nil {
+		return x.CiHigh
+	}
+	return 0
+}
+
+type RunSummary struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Count         int32                  `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+	Errors        int32                  `protobuf:"varint,2,opt,name=errors,proto3" json:"errors,omitempty"`
+	Mean          *LatencyEstimate       `protobuf:"bytes,3,opt,name=mean,proto3" json:"mean,omitempty"`
+	P50           *LatencyEstimate       `protobuf:"bytes,4,opt,name=p50,proto3" json:"p50,omitempty"`
+	P95           *LatencyEstimate       `protobuf:"bytes,5,opt,name=p95,proto3" json:"p95,omitempty"`
+	P99           *LatencyEstimate       `protobuf:"bytes,6,opt,name=p99,proto3" json:"p99,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RunSummary) Reset() {
+	*x = RunSummary{}
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RunSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunSummary) ProtoMessage() {}
+
+func (x *RunSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunSummary.ProtoReflect.Descriptor instead.
+func (*RunSummary) Descriptor() ([]byte, []int) {
+	return file_sdl_v1_models_canvas_service_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *RunSummary) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *RunSummary) GetErrors() int32 {
+	if x != nil {
+		return x.Errors
+	}
+	return 0
+}
+
+func (x *RunSummary) GetMean() *LatencyEstimate {
+	if x != nil {
+		return x.Mean
+	}
+	return nil
+}
+
+func (x *RunSummary) GetP50() *LatencyEstimate {
+	if x != nil {
+		return x.P50
+	}
+	return nil
+}
+
+func (x *RunSummary) GetP95() *LatencyEstimate {
+	if x != nil {
+		return x.P95
+	}
+	return nil
+}
+
+func (x *RunSummary) GetP99() *LatencyEstimate {
+	if x != nil {
+		return x.P99
+	}
+	return nil
+}
+
+// RunRecord describes a past run of a workspace.  id is the position of the
+// run in the history, 1 for the first, so it is the same on every page.
+type RunRecord struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	WorkspaceId   string                 `protobuf:"bytes,1,opt,name=workspace_id,json=workspaceId,proto3" json:"workspace_id,omitempty"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Target        string                 `protobuf:"bytes,2,opt,name=target,proto3" json:"target,omitempty"`
+	Timestamp     float64                `protobuf:"fixed64,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`                   // Unix timestamp in seconds
+	ParamsHash    string                 `protobuf:"bytes,4,opt,name=params_hash,json=paramsHash,proto3" json:"params_hash,omitempty"` // Equal for runs made with the same parameters
+	Cached        bool                   `protobuf:"varint,5,opt,name=cached,proto3" json:"cached,omitempty"`
+	Summary       *RunSummary            `protobuf:"bytes,6,opt,name=summary,proto3" json:"summary,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetFlowStateRequest) Reset() {
-	*x = GetFlowStateRequest{}
-	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[46]
+func (x *RunRecord) Reset() {
+	*x = RunRecord{}
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[62]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetFlowStateRequest) String() string {
+func (x *RunRecord) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetFlowStateRequest) ProtoMessage() {}
+func (*RunRecord) ProtoMessage() {}
 
-func (x *GetFlowStateRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[46]
+func (x *RunRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[62]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2439,40 +3356,79 @@ func (x *GetFlowStateRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetFlowStateRequest.ProtoReflect.Descriptor instead.
-func (*GetFlowStateRequest) Descriptor() ([]byte, []int) {
-	return file_sdl_v1_models_canvas_service_proto_rawDescGZIP(), []int{46}
+// Deprecated: Use RunRecord.ProtoReflect.Descriptor instead.
+func (*RunRecord) Descriptor() ([]byte, []int) {
+	return file_sdl_v1_models_canvas_service_proto_rawDescGZIP(), []int{62}
 }
 
-func (x *GetFlowStateRequest) GetWorkspaceId() string {
+func (x *RunRecord) GetId() int32 {
 	if x != nil {
-		return x.WorkspaceId
+		return x.Id
+	}
+	return 0
+}
+
+func (x *RunRecord) GetTarget() string {
+	if x != nil {
+		return x.Target
 	}
 	return ""
 }
 
-type GetFlowStateResponse struct {
+func (x *RunRecord) GetTimestamp() float64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *RunRecord) GetParamsHash() string {
+	if x != nil {
+		return x.ParamsHash
+	}
+	return ""
+}
+
+func (x *RunRecord) GetCached() bool {
+	if x != nil {
+		return x.Cached
+	}
+	return false
+}
+
+func (x *RunRecord) GetSummary() *RunSummary {
+	if x != nil {
+		return x.Summary
+	}
+	return nil
+}
+
+// ListRunsRequest pages through the run history newest first.  A limit of 0
+// returns every run after offset.
+type ListRunsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	State         *FlowState             `protobuf:"bytes,1,opt,name=state,proto3" json:"state,omitempty"`
+	WorkspaceId   string                 `protobuf:"bytes,1,opt,name=workspace_id,json=workspaceId,proto3" json:"workspace_id,omitempty"`
+	Offset        int32                  `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetFlowStateResponse) Reset() {
-	*x = GetFlowStateResponse{}
-	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[47]
+func (x *ListRunsRequest) Reset() {
+	*x = ListRunsRequest{}
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[63]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetFlowStateResponse) String() string {
+func (x *ListRunsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetFlowStateResponse) ProtoMessage() {}
+func (*ListRunsRequest) ProtoMessage() {}
 
-func (x *GetFlowStateResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[47]
+func (x *ListRunsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[63]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2483,40 +3439,55 @@ func (x *GetFlowStateResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetFlowStateResponse.ProtoReflect.Descriptor instead.
-func (*GetFlowStateResponse) Descriptor() ([]byte, []int) {
-	return file_sdl_v1_models_canvas_service_proto_rawDescGZIP(), []int{47}
+// Deprecated: Use ListRunsRequest.ProtoReflect.Descriptor instead.
+func (*ListRunsRequest) Descriptor() ([]byte, []int) {
+	return file_sdl_v1_models_canvas_service_proto_rawDescGZIP(), []int{63}
 }
 
-func (x *GetFlowStateResponse) GetState() *FlowState {
+func (x *ListRunsRequest) GetWorkspaceId() string {
 	if x != nil {
-		return x.State
+		return x.WorkspaceId
 	}
-	return nil
+	return ""
 }
 
-type GetSystemDiagramRequest struct {
+func (x *ListRunsRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *ListRunsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListRunsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	WorkspaceId   string                 `protobuf:"bytes,1,opt,name=workspace_id,json=workspaceId,proto3" json:"workspace_id,omitempty"`
+	Runs          []*RunRecord           `protobuf:"bytes,1,rep,name=runs,proto3" json:"runs,omitempty"`
+	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"` // Runs in the history
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetSystemDiagramRequest) Reset() {
-	*x = GetSystemDiagramRequest{}
-	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[48]
+func (x *ListRunsResponse) Reset() {
+	*x = ListRunsResponse{}
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[64]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetSystemDiagramRequest) String() string {
+func (x *ListRunsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetSystemDiagramRequest) ProtoMessage() {}
+func (*ListRunsResponse) ProtoMessage() {}
 
-func (x *GetSystemDiagramRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[48]
+func (x *ListRunsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[64]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2527,40 +3498,57 @@ func (x *GetSystemDiagramRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetSystemDiagramRequest.ProtoReflect.Descriptor instead.
-func (*GetSystemDiagramRequest) Descriptor() ([]byte, []int) {
-	return file_sdl_v1_models_canvas_service_proto_rawDescGZIP(), []int{48}
+// Deprecated: Use ListRunsResponse.ProtoReflect.Descriptor instead.
+func (*ListRunsResponse) Descriptor() ([]byte, []int) {
+	return file_sdl_v1_models_canvas_service_proto_rawDescGZIP(), []int{64}
 }
 
-func (x *GetSystemDiagramRequest) GetWorkspaceId() string {
+func (x *ListRunsResponse) GetRuns() []*RunRecord {
 	if x != nil {
-		return x.WorkspaceId
+		return x.Runs
 	}
-	return ""
+	return nil
 }
 
-type GetSystemDiagramResponse struct {
+func (x *ListRunsResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+// SimulateRequest is a self contained simulation: the model, the system to
+// activate, the traffic to drive it with and the metrics to collect.
+// Exactly one of duration or runs must be set.
+type SimulateRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Diagram       *SystemDiagram         `protobuf:"bytes,1,opt,name=diagram,proto3" json:"diagram,omitempty"`
+	SdlContent    string                 `protobuf:"bytes,1,opt,name=sdl_content,json=sdlContent,proto3" json:"sdl_content,omitempty"`
+	SystemName    string                 `protobuf:"bytes,2,opt,name=system_name,json=systemName,proto3" json:"system_name,omitempty"`
+	Generators    []*Generator           `protobuf:"bytes,3,rep,name=generators,proto3" json:"generators,omitempty"`
+	Duration      float64                `protobuf:"fixed64,4,opt,name=duration,proto3" json:"duration,omitempty"` // Seconds to run the generators for
+	Runs          int32                  `protobuf:"varint,5,opt,name=runs,proto3" json:"runs,omitempty"`          // Or calls each generator makes before stopping
+	Metrics       []*Metric              `protobuf:"bytes,6,rep,name=metrics,proto3" json:"metrics,omitempty"`
+	MaxWallClock  float64                `protobuf:"fixed64,7,opt,name=max_wall_clock,json=maxWallClock,proto3" json:"max_wall_clock,omitempty"`   // Budget of each generator in seconds, 0 for unbounded
+	MaxTraceNodes int64                  `protobuf:"varint,8,opt,name=max_trace_nodes,json=maxTraceNodes,proto3" json:"max_trace_nodes,omitempty"` // Budget of each generator in method calls, 0 for unbounded
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetSystemDiagramResponse) Reset() {
-	*x = GetSystemDiagramResponse{}
-	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[49]
+func (x *SimulateRequest) Reset() {
+	*x = SimulateRequest{}
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[65]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetSystemDiagramResponse) String() string {
+func (x *SimulateRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetSystemDiagramResponse) ProtoMessage() {}
+func (*SimulateRequest) ProtoMessage() {}
 
-func (x *GetSystemDiagramResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[49]
+func (x *SimulateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[65]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2571,41 +3559,93 @@ func (x *GetSystemDiagramResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetSystemDiagramResponse.ProtoReflect.Descriptor instead.
-func (*GetSystemDiagramResponse) Descriptor() ([]byte, []int) {
-	return file_sdl_v1_models_canvas_service_proto_rawDescGZIP(), []int{49}
+// Deprecated: Use SimulateRequest.ProtoReflect.Descriptor instead.
+func (*SimulateRequest) Descriptor() ([]byte, []int) {
+	return file_sdl_v1_models_canvas_service_proto_rawDescGZIP(), []int{65}
 }
 
-func (x *GetSystemDiagramResponse) GetDiagram() *SystemDiagram {
+func (x *SimulateRequest) GetSdlContent() string {
 	if x != nil {
-		return x.Diagram
+		return x.SdlContent
+	}
+	return ""
+}
+
+func (x *SimulateRequest) GetSystemName() string {
+	if x != nil {
+		return x.SystemName
+	}
+	return ""
+}
+
+func (x *SimulateRequest) GetGenerators() []*Generator {
+	if x != nil {
+		return x.Generators
 	}
 	return nil
 }
 
-type GetUtilizationRequest struct {
+func (x *SimulateRequest) GetDuration() float64 {
+	if x != nil {
+		return x.Duration
+	}
+	return 0
+}
+
+func (x *SimulateRequest) GetRuns() int32 {
+	if x != nil {
+		return x.Runs
+	}
+	return 0
+}
+
+func (x *SimulateRequest) GetMetrics() []*Metric {
+	if x != nil {
+		return x.Metrics
+	}
+	return nil
+}
+
+func (x *SimulateRequest) GetMaxWallClock() float64 {
+	if x != nil {
+		return x.MaxWallClock
+	}
+	return 0
+}
+
+func (x *SimulateRequest) GetMaxTraceNodes() int64 {
+	if x != nil {
+		return x.MaxTraceNodes
+	}
+	return 0
+}
+
+// SimulationDiagnostic is a compile error in the submitted content.  line and
+// col are 0 when the error has no position.
+type SimulationDiagnostic struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	WorkspaceId   string                 `protobuf:"bytes,1,opt,name=workspace_id,json=workspaceId,proto3" json:"workspace_id,omitempty"`
-	Components    []string               `protobuf:"bytes,2,rep,name=components,proto3" json:"components,omitempty"`
+	Line          int32                  `protobuf:"varint,1,opt,name=line,proto3" json:"line,omitempty"`
+	Col           int32                  `protobuf:"varint,2,opt,name=col,proto3" json:"col,omitempty"`
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetUtilizationRequest) Reset() {
-	*x = GetUtilizationRequest{}
-	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[50]
+func (x *SimulationDiagnostic) Reset() {
+	*x = SimulationDiagnostic{}
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[66]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetUtilizationRequest) String() string {
+func (x *SimulationDiagnostic) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetUtilizationRequest) ProtoMessage() {}
+func (*SimulationDiagnostic) ProtoMessage() {}
 
-func (x *GetUtilizationRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[50]
+func (x *SimulationDiagnostic) ProtoReflect() protoreflect.Message {
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[66]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2616,47 +3656,101 @@ func (x *GetUtilizationRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetUtilizationRequest.ProtoReflect.Descriptor instead.
-func (*GetUtilizationRequest) Descriptor() ([]byte, []int) {
-	return file_sdl_v1_models_canvas_service_proto_rawDescGZIP(), []int{50}
+// Deprecated: Use SimulationDiagnostic.ProtoReflect.Descriptor instead.
+func (*SimulationDiagnostic) Descriptor() ([]byte, []int) {
+	return file_sdl_v1_models_canvas_service_proto_rawDescGZIP(), []int{66}
 }
 
-func (x *GetUtilizationRequest) GetWorkspaceId() string {
+func (x *SimulationDiagnostic) GetLine() int32 {
 	if x != nil {
-		return x.WorkspaceId
+		return x.Line
+	}
+	return 0
+}
+
+func (x *SimulationDiagnostic) GetCol() int32 {
+	if x != nil {
+		return x.Col
+	}
+	return 0
+}
+
+func (x *SimulationDiagnostic) GetMessage() string {
+	if x != nil {
+		return x.Message
 	}
 	return ""
 }
 
-func (x *GetUtilizationRequest) GetComponents() []string {
+type MetricSeries struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Points        []*MetricPoint         `protobuf:"bytes,1,rep,name=points,proto3" json:"points,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MetricSeries) Reset() {
+	*x = MetricSeries{}
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MetricSeries) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MetricSeries) ProtoMessage() {}
+
+func (x *MetricSeries) ProtoReflect() protoreflect.Message {
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[67]
 	if x != nil {
-		return x.Components
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MetricSeries.ProtoReflect.Descriptor instead.
+func (*MetricSeries) Descriptor() ([]byte, []int) {
+	return file_sdl_v1_models_canvas_service_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *MetricSeries) GetPoints() []*MetricPoint {
+	if x != nil {
+		return x.Points
 	}
 	return nil
 }
 
-type GetUtilizationResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Utilizations  []*UtilizationInfo     `protobuf:"bytes,1,rep,name=utilizations,proto3" json:"utilizations,omitempty"`
+// SimulateResponse holds the collected points of each metric keyed by metric
+// name, or the errors when the model fails to compile.
+type SimulateResponse struct {
+	state         protoimpl.MessageState   `protogen:"open.v1"`
+	MetricSeries  map[string]*MetricSeries `protobuf:"bytes,1,rep,name=metric_series,json=metricSeries,proto3" json:"metric_series,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Errors        []*SimulationDiagnostic  `protobuf:"bytes,2,rep,name=errors,proto3" json:"errors,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetUtilizationResponse) Reset() {
-	*x = GetUtilizationResponse{}
-	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[51]
+func (x *SimulateResponse) Reset() {
+	*x = SimulateResponse{}
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[68]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetUtilizationResponse) String() string {
+func (x *SimulateResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetUtilizationResponse) ProtoMessage() {}
+func (*SimulateResponse) ProtoMessage() {}
 
-func (x *GetUtilizationResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[51]
+func (x *SimulateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_sdl_v1_models_canvas_service_proto_msgTypes[68]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2667,14 +3761,21 @@ func (x *GetUtilizationResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetUtilizationResponse.ProtoReflect.Descriptor instead.
-func (*GetUtilizationResponse) Descriptor() ([]byte, []int) {
-	return file_sdl_v1_models_canvas_service_proto_rawDescGZIP(), []int{51}
+// Deprecated: Use SimulateResponse.ProtoReflect.Descriptor instead.
+func (*SimulateResponse) Descriptor() ([]byte, []int) {
+	return file_sdl_v1_models_canvas_service_proto_rawDescGZIP(), []int{68}
 }
 
-func (x *GetUtilizationResponse) GetUtilizations() []*UtilizationInfo {
+func (x *SimulateResponse) GetMetricSeries() map[string]*MetricSeries {
 	if x != nil {
-		return x.Utilizations
+		return x.MetricSeries
+	}
+	return nil
+}
+
+func (x *SimulateResponse) GetErrors() []*SimulationDiagnostic {
+	if x != nil {
+		return x.Errors
 	}
 	return nil
 }
@@ -2692,7 +3793,11 @@ const file_sdl_v1_models_canvas_service_proto_rawDesc = "" +
 	"\fworkspace_id\x18\x01 \x01(\tR\vworkspaceId\x12\x1f\n" +
 	"\vsystem_name\x18\x02 \x01(\tR\n" +
 	"systemName\"\x13\n" +
-	"\x11UseSystemResponse\"\x8a\x01\n" +
+	"\x11UseSystemResponse\"7\n" +
+	"\x12GetManifestRequest\x12!\n" +
+	"\fworkspace_id\x18\x01 \x01(\tR\vworkspaceId\"C\n" +
+	"\x13GetManifestResponse\x12,\n" +
+	"\bmanifest\x18\x01 \x01(\v2\x10.sdl.v1.ManifestR\bmanifest\"\x8a\x01\n" +
 	"\x13AddGeneratorRequest\x12!\n" +
 	"\fworkspace_id\x18\x01 \x01(\tR\vworkspaceId\x12/\n" +
 	"\tgenerator\x18\x02 \x01(\v2\x11.sdl.v1.GeneratorR\tgenerator\x12\x1f\n" +
@@ -2773,7 +3878,22 @@ const file_sdl_v1_models_canvas_service_proto_rawDesc = "" +
 	"\bend_time\x18\x04 \x01(\x01R\aendTime\x12\x14\n" +
 	"\x05limit\x18\x05 \x01(\x05R\x05limit\"C\n" +
 	"\x14QueryMetricsResponse\x12+\n" +
-	"\x06points\x18\x01 \x03(\v2\x13.sdl.v1.MetricPointR\x06points\"\xd4\x01\n" +
+	"\x06points\x18\x01 \x03(\v2\x13.sdl.v1.MetricPointR\x06points\"?\n" +
+	"\x1aGetMeasurementStatsRequest\x12!\n" +
+	"\fworkspace_id\x18\x01 \x01(\tR\vworkspaceId\"\xf2\x02\n" +
+	"\x1bGetMeasurementStatsResponse\x12\x1d\n" +
+	"\n" +
+	"total_rows\x18\x01 \x01(\x03R\ttotalRows\x12^\n" +
+	"\x0frows_per_metric\x18\x02 \x03(\v26.sdl.v1.GetMeasurementStatsResponse.RowsPerMetricEntryR\rrowsPerMetric\x12)\n" +
+	"\x10oldest_timestamp\x18\x03 \x01(\x01R\x0foldestTimestamp\x12)\n" +
+	"\x10newest_timestamp\x18\x04 \x01(\x01R\x0fnewestTimestamp\x12\x1d\n" +
+	"\n" +
+	"size_bytes\x18\x05 \x01(\x03R\tsizeBytes\x12\x1d\n" +
+	"\n" +
+	"write_rate\x18\x06 \x01(\x01R\twriteRate\x1a@\n" +
+	"\x12RowsPerMetricEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x03R\x05value:\x028\x01\"\xd4\x01\n" +
 	"\x17AggregateMetricsRequest\x12!\n" +
 	"\fworkspace_id\x18\x01 \x01(\tR\vworkspaceId\x12\x1f\n" +
 	"\vmetric_name\x18\x02 \x01(\tR\n" +
@@ -2797,7 +3917,11 @@ const file_sdl_v1_models_canvas_service_proto_rawDesc = "" +
 	"\x06method\x18\x03 \x01(\tR\x06method\"H\n" +
 	"\x14ExecuteTraceResponse\x120\n" +
 	"\n" +
-	"trace_data\x18\x01 \x01(\v2\x11.sdl.v1.TraceDataR\ttraceData\"\x8c\x01\n" +
+	"trace_data\x18\x01 \x01(\v2\x11.sdl.v1.TraceDataR\ttraceData\"n\n" +
+	"\x13StreamTracesRequest\x12!\n" +
+	"\fworkspace_id\x18\x01 \x01(\tR\vworkspaceId\x12\x1c\n" +
+	"\tcomponent\x18\x02 \x01(\tR\tcomponent\x12\x16\n" +
+	"\x06method\x18\x03 \x01(\tR\x06method\"\x8c\x01\n" +
 	"\x14TraceAllPathsRequest\x12!\n" +
 	"\fworkspace_id\x18\x01 \x01(\tR\vworkspaceId\x12\x1c\n" +
 	"\tcomponent\x18\x02 \x01(\tR\tcomponent\x12\x16\n" +
@@ -2834,7 +3958,7 @@ const file_sdl_v1_models_canvas_service_proto_rawDesc = "" +
 	"\aresults\x18\x03 \x03(\v2\x1d.sdl.v1.ParameterUpdateResultR\aresults\"U\n" +
 	"\x14EvaluateFlowsRequest\x12!\n" +
 	"\fworkspace_id\x18\x01 \x01(\tR\vworkspaceId\x12\x1a\n" +
-	"\bstrategy\x18\x02 \x01(\tR\bstrategy\"\xd7\x02\n" +
+	"\bstrategy\x18\x02 \x01(\tR\bstrategy\"\x91\x03\n" +
 	"\x15EvaluateFlowsResponse\x12\x1a\n" +
 	"\bstrategy\x18\x01 \x01(\tR\bstrategy\x12\x16\n" +
 	"\x06status\x18\x02 \x01(\tR\x06status\x12\x1e\n" +
@@ -2844,14 +3968,30 @@ const file_sdl_v1_models_canvas_service_proto_rawDesc = "" +
 	"\bwarnings\x18\x04 \x03(\tR\bwarnings\x12Z\n" +
 	"\x0fcomponent_rates\x18\x05 \x03(\v21.sdl.v1.EvaluateFlowsResponse.ComponentRatesEntryR\x0ecomponentRates\x12/\n" +
 	"\n" +
-	"flow_edges\x18\x06 \x03(\v2\x10.sdl.v1.FlowEdgeR\tflowEdges\x1aA\n" +
+	"flow_edges\x18\x06 \x03(\v2\x10.sdl.v1.FlowEdgeR\tflowEdges\x12\x1c\n" +
+	"\tconverged\x18\a \x01(\bR\tconverged\x12\x1a\n" +
+	"\bresidual\x18\b \x01(\x01R\bresidual\x1aA\n" +
 	"\x13ComponentRatesEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
 	"\x05value\x18\x02 \x01(\x01R\x05value:\x028\x01\"8\n" +
 	"\x13GetFlowStateRequest\x12!\n" +
 	"\fworkspace_id\x18\x01 \x01(\tR\vworkspaceId\"?\n" +
 	"\x14GetFlowStateResponse\x12'\n" +
-	"\x05state\x18\x01 \x01(\v2\x11.sdl.v1.FlowStateR\x05state\"<\n" +
+	"\x05state\x18\x01 \x01(\v2\x11.sdl.v1.FlowStateR\x05state\"\xdc\x01\n" +
+	"\tFlowEntry\x12\x1c\n" +
+	"\tcomponent\x18\x01 \x01(\tR\tcomponent\x12\x16\n" +
+	"\x06method\x18\x02 \x01(\tR\x06method\x12!\n" +
+	"\farrival_rate\x18\x03 \x01(\x01R\varrivalRate\x12!\n" +
+	"\fservice_rate\x18\x04 \x01(\x01R\vserviceRate\x12 \n" +
+	"\vutilization\x18\x05 \x01(\x01R\vutilization\x121\n" +
+	"\x14latency_contribution\x18\x06 \x01(\x01R\x13latencyContribution\"N\n" +
+	"\x0fGetFlowsRequest\x12!\n" +
+	"\fworkspace_id\x18\x01 \x01(\tR\vworkspaceId\x12\x18\n" +
+	"\asamples\x18\x02 \x01(\x05R\asamples\"o\n" +
+	"\x10GetFlowsResponse\x12\x16\n" +
+	"\x06system\x18\x01 \x01(\tR\x06system\x12\x1a\n" +
+	"\bstrategy\x18\x02 \x01(\tR\bstrategy\x12'\n" +
+	"\x05flows\x18\x03 \x03(\v2\x11.sdl.v1.FlowEntryR\x05flows\"<\n" +
 	"\x17GetSystemDiagramRequest\x12!\n" +
 	"\fworkspace_id\x18\x01 \x01(\tR\vworkspaceId\"K\n" +
 	"\x18GetSystemDiagramResponse\x12/\n" +
@@ -2862,7 +4002,59 @@ const file_sdl_v1_models_canvas_service_proto_rawDesc = "" +
 	"components\x18\x02 \x03(\tR\n" +
 	"components\"U\n" +
 	"\x16GetUtilizationResponse\x12;\n" +
-	"\futilizations\x18\x01 \x03(\v2\x17.sdl.v1.UtilizationInfoR\futilizationsB\x8b\x01\n" +
+	"\futilizations\x18\x01 \x03(\v2\x17.sdl.v1.UtilizationInfoR\futilizations\"W\n" +
+	"\x0fLatencyEstimate\x12\x14\n" +
+	"\x05value\x18\x01 \x01(\x01R\x05value\x12\x15\n" +
+	"\x06ci_low\x18\x02 \x01(\x01R\x05ciLow\x12\x17\n" +
+	"\aci_high\x18\x03 \x01(\x01R\x06ciHigh\"\xe8\x01\n" +
+	"\n" +
+	"RunSummary\x12\x14\n" +
+	"\x05count\x18\x01 \x01(\x05R\x05count\x12\x16\n" +
+	"\x06errors\x18\x02 \x01(\x05R\x06errors\x12+\n" +
+	"\x04mean\x18\x03 \x01(\v2\x17.sdl.v1.LatencyEstimateR\x04mean\x12)\n" +
+	"\x03p50\x18\x04 \x01(\v2\x17.sdl.v1.LatencyEstimateR\x03p50\x12)\n" +
+	"\x03p95\x18\x05 \x01(\v2\x17.sdl.v1.LatencyEstimateR\x03p95\x12)\n" +
+	"\x03p99\x18\x06 \x01(\v2\x17.sdl.v1.LatencyEstimateR\x03p99\"\xb8\x01\n" +
+	"\tRunRecord\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x16\n" +
+	"\x06target\x18\x02 \x01(\tR\x06target\x12\x1c\n" +
+	"\ttimestamp\x18\x03 \x01(\x01R\ttimestamp\x12\x1f\n" +
+	"\vparams_hash\x18\x04 \x01(\tR\n" +
+	"paramsHash\x12\x16\n" +
+	"\x06cached\x18\x05 \x01(\bR\x06cached\x12,\n" +
+	"\asummary\x18\x06 \x01(\v2\x12.sdl.v1.RunSummaryR\asummary\"b\n" +
+	"\x0fListRunsRequest\x12!\n" +
+	"\fworkspace_id\x18\x01 \x01(\tR\vworkspaceId\x12\x16\n" +
+	"\x06offset\x18\x02 \x01(\x05R\x06offset\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\"O\n" +
+	"\x10ListRunsResponse\x12%\n" +
+	"\x04runs\x18\x01 \x03(\v2\x11.sdl.v1.RunRecordR\x04runs\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\"\xae\x02\n" +
+	"\x0fSimulateRequest\x12\x1f\n" +
+	"\vsdl_content\x18\x01 \x01(\tR\n" +
+	"sdlContent\x12\x1f\n" +
+	"\vsystem_name\x18\x02 \x01(\tR\n" +
+	"systemName\x121\n" +
+	"\n" +
+	"generators\x18\x03 \x03(\v2\x11.sdl.v1.GeneratorR\n" +
+	"generators\x12\x1a\n" +
+	"\bduration\x18\x04 \x01(\x01R\bduration\x12\x12\n" +
+	"\x04runs\x18\x05 \x01(\x05R\x04runs\x12(\n" +
+	"\ametrics\x18\x06 \x03(\v2\x0e.sdl.v1.MetricR\ametrics\x12$\n" +
+	"\x0emax_wall_clock\x18\a \x01(\x01R\fmaxWallClock\x12&\n" +
+	"\x0fmax_trace_nodes\x18\b \x01(\x03R\rmaxTraceNodes\"V\n" +
+	"\x14SimulationDiagnostic\x12\x12\n" +
+	"\x04line\x18\x01 \x01(\x05R\x04line\x12\x10\n" +
+	"\x03col\x18\x02 \x01(\x05R\x03col\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\";\n" +
+	"\fMetricSeries\x12+\n" +
+	"\x06points\x18\x01 \x03(\v2\x13.sdl.v1.MetricPointR\x06points\"\xf0\x01\n" +
+	"\x10SimulateResponse\x12O\n" +
+	"\rmetric_series\x18\x01 \x03(\v2*.sdl.v1.SimulateResponse.MetricSeriesEntryR\fmetricSeries\x124\n" +
+	"\x06errors\x18\x02 \x03(\v2\x1c.sdl.v1.SimulationDiagnosticR\x06errors\x1aU\n" +
+	"\x11MetricSeriesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12*\n" +
+	"\x05value\x18\x02 \x01(\v2\x14.sdl.v1.MetricSeriesR\x05value:\x028\x01B\x8b\x01\n" +
 	"\n" +
 	"com.sdl.v1B\x12CanvasServiceProtoP\x01Z0github.com/panyam/sdl/gen/go/sdl/v1/models;sdlv1\xa2\x02\x03SXX\xaa\x02\x06Sdl.V1\xca\x02\x06Sdl\\V1\xe2\x02\x12Sdl\\V1\\GPBMetadata\xea\x02\aSdl::V1b\x06proto3"
 
@@ -2878,104 +4070,139 @@ func file_sdl_v1_models_canvas_service_proto_rawDescGZIP() []byte {
 	return file_sdl_v1_models_canvas_service_proto_rawDescData
 }
 
-var file_sdl_v1_models_canvas_service_proto_msgTypes = make([]protoimpl.MessageInfo, 54)
+var file_sdl_v1_models_canvas_service_proto_msgTypes = make([]protoimpl.MessageInfo, 73)
 var file_sdl_v1_models_canvas_service_proto_goTypes = []any{
-	(*LoadFileRequest)(nil),            // 0: sdl.v1.LoadFileRequest
-	(*LoadFileResponse)(nil),           // 1: sdl.v1.LoadFileResponse
-	(*UseSystemRequest)(nil),           // 2: sdl.v1.UseSystemRequest
-	(*UseSystemResponse)(nil),          // 3: sdl.v1.UseSystemResponse
-	(*AddGeneratorRequest)(nil),        // 4: sdl.v1.AddGeneratorRequest
-	(*AddGeneratorResponse)(nil),       // 5: sdl.v1.AddGeneratorResponse
-	(*ListGeneratorsRequest)(nil),      // 6: sdl.v1.ListGeneratorsRequest
-	(*ListGeneratorsResponse)(nil),     // 7: sdl.v1.ListGeneratorsResponse
-	(*GetGeneratorRequest)(nil),        // 8: sdl.v1.GetGeneratorRequest
-	(*GetGeneratorResponse)(nil),       // 9: sdl.v1.GetGeneratorResponse
-	(*UpdateGeneratorRequest)(nil),     // 10: sdl.v1.UpdateGeneratorRequest
-	(*UpdateGeneratorResponse)(nil),    // 11: sdl.v1.UpdateGeneratorResponse
-	(*StartGeneratorRequest)(nil),      // 12: sdl.v1.StartGeneratorRequest
-	(*StartGeneratorResponse)(nil),     // 13: sdl.v1.StartGeneratorResponse
-	(*StopGeneratorRequest)(nil),       // 14: sdl.v1.StopGeneratorRequest
-	(*StopGeneratorResponse)(nil),      // 15: sdl.v1.StopGeneratorResponse
-	(*DeleteGeneratorRequest)(nil),     // 16: sdl.v1.DeleteGeneratorRequest
-	(*DeleteGeneratorResponse)(nil),    // 17: sdl.v1.DeleteGeneratorResponse
-	(*StartAllGeneratorsRequest)(nil),  // 18: sdl.v1.StartAllGeneratorsRequest
-	(*StartAllGeneratorsResponse)(nil), // 19: sdl.v1.StartAllGeneratorsResponse
-	(*StopAllGeneratorsRequest)(nil),   // 20: sdl.v1.StopAllGeneratorsRequest
-	(*StopAllGeneratorsResponse)(nil),  // 21: sdl.v1.StopAllGeneratorsResponse
-	(*AddMetricRequest)(nil),           // 22: sdl.v1.AddMetricRequest
-	(*AddMetricResponse)(nil),          // 23: sdl.v1.AddMetricResponse
-	(*DeleteMetricRequest)(nil),        // 24: sdl.v1.DeleteMetricRequest
-	(*DeleteMetricResponse)(nil),       // 25: sdl.v1.DeleteMetricResponse
-	(*ListMetricsRequest)(nil),         // 26: sdl.v1.ListMetricsRequest
-	(*ListMetricsResponse)(nil),        // 27: sdl.v1.ListMetricsResponse
-	(*QueryMetricsRequest)(nil),        // 28: sdl.v1.QueryMetricsRequest
-	(*QueryMetricsResponse)(nil),       // 29: sdl.v1.QueryMetricsResponse
-	(*AggregateMetricsRequest)(nil),    // 30: sdl.v1.AggregateMetricsRequest
-	(*AggregateMetricsResponse)(nil),   // 31: sdl.v1.AggregateMetricsResponse
-	(*StreamMetricsRequest)(nil),       // 32: sdl.v1.StreamMetricsRequest
-	(*StreamMetricsResponse)(nil),      // 33: sdl.v1.StreamMetricsResponse
-	(*ExecuteTraceRequest)(nil),        // 34: sdl.v1.ExecuteTraceRequest
-	(*ExecuteTraceResponse)(nil),       // 35: sdl.v1.ExecuteTraceResponse
-	(*TraceAllPathsRequest)(nil),       // 36: sdl.v1.TraceAllPathsRequest
-	(*TraceAllPathsResponse)(nil),      // 37: sdl.v1.TraceAllPathsResponse
-	(*SetParameterRequest)(nil),        // 38: sdl.v1.SetParameterRequest
-	(*SetParameterResponse)(nil),       // 39: sdl.v1.SetParameterResponse
-	(*GetParametersRequest)(nil),       // 40: sdl.v1.GetParametersRequest
-	(*GetParametersResponse)(nil),      // 41: sdl.v1.GetParametersResponse
-	(*BatchSetParametersRequest)(nil),  // 42: sdl.v1.BatchSetParametersRequest
-	(*BatchSetParametersResponse)(nil), // 43: sdl.v1.BatchSetParametersResponse
-	(*EvaluateFlowsRequest)(nil),       // 44: sdl.v1.EvaluateFlowsRequest
-	(*EvaluateFlowsResponse)(nil),      // 45: sdl.v1.EvaluateFlowsResponse
-	(*GetFlowStateRequest)(nil),        // 46: sdl.v1.GetFlowStateRequest
-	(*GetFlowStateResponse)(nil),       // 47: sdl.v1.GetFlowStateResponse
-	(*GetSystemDiagramRequest)(nil),    // 48: sdl.v1.GetSystemDiagramRequest
-	(*GetSystemDiagramResponse)(nil),   // 49: sdl.v1.GetSystemDiagramResponse
-	(*GetUtilizationRequest)(nil),      // 50: sdl.v1.GetUtilizationRequest
-	(*GetUtilizationResponse)(nil),     // 51: sdl.v1.GetUtilizationResponse
-	nil,                                // 52: sdl.v1.GetParametersResponse.ParametersEntry
-	nil,                                // 53: sdl.v1.EvaluateFlowsResponse.ComponentRatesEntry
-	(*Generator)(nil),                  // 54: sdl.v1.Generator
-	(*Metric)(nil),                     // 55: sdl.v1.Metric
-	(*MetricPoint)(nil),                // 56: sdl.v1.MetricPoint
-	(*AggregateResult)(nil),            // 57: sdl.v1.AggregateResult
-	(*MetricUpdate)(nil),               // 58: sdl.v1.MetricUpdate
-	(*TraceData)(nil),                  // 59: sdl.v1.TraceData
-	(*AllPathsTraceData)(nil),          // 60: sdl.v1.AllPathsTraceData
-	(*ParameterUpdate)(nil),            // 61: sdl.v1.ParameterUpdate
-	(*ParameterUpdateResult)(nil),      // 62: sdl.v1.ParameterUpdateResult
-	(*FlowEdge)(nil),                   // 63: sdl.v1.FlowEdge
-	(*FlowState)(nil),                  // 64: sdl.v1.FlowState
-	(*SystemDiagram)(nil),              // 65: sdl.v1.SystemDiagram
-	(*UtilizationInfo)(nil),            // 66: sdl.v1.UtilizationInfo
+	(*LoadFileRequest)(nil),             // 0: sdl.v1.LoadFileRequest
+	(*LoadFileResponse)(nil),            // 1: sdl.v1.LoadFileResponse
+	(*UseSystemRequest)(nil),            // 2: sdl.v1.UseSystemRequest
+	(*UseSystemResponse)(nil),           // 3: sdl.v1.UseSystemResponse
+	(*GetManifestRequest)(nil),          // 4: sdl.v1.GetManifestRequest
+	(*GetManifestResponse)(nil),         // 5: sdl.v1.GetManifestResponse
+	(*AddGeneratorRequest)(nil),         // 6: sdl.v1.AddGeneratorRequest
+	(*AddGeneratorResponse)(nil),        // 7: sdl.v1.AddGeneratorResponse
+	(*ListGeneratorsRequest)(nil),       // 8: sdl.v1.ListGeneratorsRequest
+	(*ListGeneratorsResponse)(nil),      // 9: sdl.v1.ListGeneratorsResponse
+	(*GetGeneratorRequest)(nil),         // 10: sdl.v1.GetGeneratorRequest
+	(*GetGeneratorResponse)(nil),        // 11: sdl.v1.GetGeneratorResponse
+	(*UpdateGeneratorRequest)(nil),      // 12: sdl.v1.UpdateGeneratorRequest
+	(*UpdateGeneratorResponse)(nil),     // 13: sdl.v1.UpdateGeneratorResponse
+	(*StartGeneratorRequest)(nil),       // 14: sdl.v1.StartGeneratorRequest
+	(*StartGeneratorResponse)(nil),      // 15: sdl.v1.StartGeneratorResponse
+	(*StopGeneratorRequest)(nil),        // 16: sdl.v1.StopGeneratorRequest
+	(*StopGeneratorResponse)(nil),       // 17: sdl.v1.StopGeneratorResponse
+	(*DeleteGeneratorRequest)(nil),      // 18: sdl.v1.DeleteGeneratorRequest
+	(*DeleteGeneratorResponse)(nil),     // 19: sdl.v1.DeleteGeneratorResponse
+	(*StartAllGeneratorsRequest)(nil),   // 20: sdl.v1.StartAllGeneratorsRequest
+	(*StartAllGeneratorsResponse)(nil),  // 21: sdl.v1.StartAllGeneratorsResponse
+	(*StopAllGeneratorsRequest)(nil),    // 22: sdl.v1.StopAllGeneratorsRequest
+	(*StopAllGeneratorsResponse)(nil),   // 23: sdl.v1.StopAllGeneratorsResponse
+	(*AddMetricRequest)(nil),            // 24: sdl.v1.AddMetricRequest
+	(*AddMetricResponse)(nil),           // 25: sdl.v1.AddMetricResponse
+	(*DeleteMetricRequest)(nil),         // 26: sdl.v1.DeleteMetricRequest
+	(*DeleteMetricResponse)(nil),        // 27: sdl.v1.DeleteMetricResponse
+	(*ListMetricsRequest)(nil),          // 28: sdl.v1.ListMetricsRequest
+	(*ListMetricsResponse)(nil),         // 29: sdl.v1.ListMetricsResponse
+	(*QueryMetricsRequest)(nil),         // 30: sdl.v1.QueryMetricsRequest
+	(*QueryMetricsResponse)(nil),        // 31: sdl.v1.QueryMetricsResponse
+	(*GetMeasurementStatsRequest)(nil),  // 32: sdl.v1.GetMeasurementStatsRequest
+	(*GetMeasurementStatsResponse)(nil), // 33: sdl.v1.GetMeasurementStatsResponse
+	(*AggregateMetricsRequest)(nil),     // 34: sdl.v1.AggregateMetricsRequest
+	(*AggregateMetricsResponse)(nil),    // 35: sdl.v1.AggregateMetricsResponse
+	(*StreamMetricsRequest)(nil),        // 36: sdl.v1.StreamMetricsRequest
+	(*StreamMetricsResponse)(nil),       // 37: sdl.v1.StreamMetricsResponse
+	(*ExecuteTraceRequest)(nil),         // 38: sdl.v1.ExecuteTraceRequest
+	(*ExecuteTraceResponse)(nil),        // 39: sdl.v1.ExecuteTraceResponse
+	(*StreamTracesRequest)(nil),         // 40: sdl.v1.StreamTracesRequest
+	(*TraceAllPathsRequest)(nil),        // 41: sdl.v1.TraceAllPathsRequest
+	(*TraceAllPathsResponse)(nil),       // 42: sdl.v1.TraceAllPathsResponse
+	(*SetParameterRequest)(nil),         // 43: sdl.v1.SetParameterRequest
+	(*SetParameterResponse)(nil),        // 44: sdl.v1.SetParameterResponse
+	(*GetParametersRequest)(nil),        // 45: sdl.v1.GetParametersRequest
+	(*GetParametersResponse)(nil),       // 46: sdl.v1.GetParametersResponse
+	(*BatchSetParametersRequest)(nil),   // 47: sdl.v1.BatchSetParametersRequest
+	(*BatchSetParametersResponse)(nil),  // 48: sdl.v1.BatchSetParametersResponse
+	(*EvaluateFlowsRequest)(nil),        // 49: sdl.v1.EvaluateFlowsRequest
+	(*EvaluateFlowsResponse)(nil),       // 50: sdl.v1.EvaluateFlowsResponse
+	(*GetFlowStateRequest)(nil),         // 51: sdl.v1.GetFlowStateRequest
+	(*GetFlowStateResponse)(nil),        // 52: sdl.v1.GetFlowStateResponse
+	(*FlowEntry)(nil),                   // 53: sdl.v1.FlowEntry
+	(*GetFlowsRequest)(nil),             // 54: sdl.v1.GetFlowsRequest
+	(*GetFlowsResponse)(nil),            // 55: sdl.v1.GetFlowsResponse
+	(*GetSystemDiagramRequest)(nil),     // 56: sdl.v1.GetSystemDiagramRequest
+	(*GetSystemDiagramResponse)(nil),    // 57: sdl.v1.GetSystemDiagramResponse
+	(*GetUtilizationRequest)(nil),       // 58: sdl.v1.GetUtilizationRequest
+	(*GetUtilizationResponse)(nil),      // 59: sdl.v1.GetUtilizationResponse
+	(*LatencyEstimate)(nil),             // 60: sdl.v1.LatencyEstimate
+	(*RunSummary)(nil),                  // 61: sdl.v1.RunSummary
+	(*RunRecord)(nil),                   // 62: sdl.v1.RunRecord
+	(*ListRunsRequest)(nil),             // 63: sdl.v1.ListRunsRequest
+	(*ListRunsResponse)(nil),            // 64: sdl.v1.ListRunsResponse
+	(*SimulateRequest)(nil),             // 65: sdl.v1.SimulateRequest
+	(*SimulationDiagnostic)(nil),        // 66: sdl.v1.SimulationDiagnostic
+	(*MetricSeries)(nil),                // 67: sdl.v1.MetricSeries
+	(*SimulateResponse)(nil),            // 68: sdl.v1.SimulateResponse
+	nil,                                 // 69: sdl.v1.GetMeasurementStatsResponse.RowsPerMetricEntry
+	nil,                                 // 70: sdl.v1.GetParametersResponse.ParametersEntry
+	nil,                                 // 71: sdl.v1.EvaluateFlowsResponse.ComponentRatesEntry
+	nil,                                 // 72: sdl.v1.SimulateResponse.MetricSeriesEntry
+	(*Manifest)(nil),                    // 73: sdl.v1.Manifest
+	(*Generator)(nil),                   // 74: sdl.v1.Generator
+	(*Metric)(nil),                      // 75: sdl.v1.Metric
+	(*MetricPoint)(nil),                 // 76: sdl.v1.MetricPoint
+	(*AggregateResult)(nil),             // 77: sdl.v1.AggregateResult
+	(*MetricUpdate)(nil),                // 78: sdl.v1.MetricUpdate
+	(*TraceData)(nil),                   // 79: sdl.v1.TraceData
+	(*AllPathsTraceData)(nil),           // 80: sdl.v1.AllPathsTraceData
+	(*ParameterUpdate)(nil),             // 81: sdl.v1.ParameterUpdate
+	(*ParameterUpdateResult)(nil),       // 82: sdl.v1.ParameterUpdateResult
+	(*FlowEdge)(nil),                    // 83: sdl.v1.FlowEdge
+	(*FlowState)(nil),                   // 84: sdl.v1.FlowState
+	(*SystemDiagram)(nil),               // 85: sdl.v1.SystemDiagram
+	(*UtilizationInfo)(nil),             // 86: sdl.v1.UtilizationInfo
 }
 var file_sdl_v1_models_canvas_service_proto_depIdxs = []int32{
-	54, // 0: sdl.v1.AddGeneratorRequest.generator:type_name -> sdl.v1.Generator
-	54, // 1: sdl.v1.AddGeneratorResponse.generator:type_name -> sdl.v1.Generator
-	54, // 2: sdl.v1.ListGeneratorsResponse.generators:type_name -> sdl.v1.Generator
-	54, // 3: sdl.v1.GetGeneratorResponse.generator:type_name -> sdl.v1.Generator
-	54, // 4: sdl.v1.UpdateGeneratorRequest.generator:type_name -> sdl.v1.Generator
-	54, // 5: sdl.v1.UpdateGeneratorResponse.generator:type_name -> sdl.v1.Generator
-	55, // 6: sdl.v1.AddMetricRequest.metric:type_name -> sdl.v1.Metric
-	55, // 7: sdl.v1.AddMetricResponse.metric:type_name -> sdl.v1.Metric
-	55, // 8: sdl.v1.ListMetricsResponse.metrics:type_name -> sdl.v1.Metric
-	56, // 9: sdl.v1.QueryMetricsResponse.points:type_name -> sdl.v1.MetricPoint
-	57, // 10: sdl.v1.AggregateMetricsResponse.results:type_name -> sdl.v1.AggregateResult
-	58, // 11: sdl.v1.StreamMetricsResponse.updates:type_name -> sdl.v1.MetricUpdate
-	59, // 12: sdl.v1.ExecuteTraceResponse.trace_data:type_name -> sdl.v1.TraceData
-	60, // 13: sdl.v1.TraceAllPathsResponse.trace_data:type_name -> sdl.v1.AllPathsTraceData
-	52, // 14: sdl.v1.GetParametersResponse.parameters:type_name -> sdl.v1.GetParametersResponse.ParametersEntry
-	61, // 15: sdl.v1.BatchSetParametersRequest.updates:type_name -> sdl.v1.ParameterUpdate
-	62, // 16: sdl.v1.BatchSetParametersResponse.results:type_name -> sdl.v1.ParameterUpdateResult
-	53, // 17: sdl.v1.EvaluateFlowsResponse.component_rates:type_name -> sdl.v1.EvaluateFlowsResponse.ComponentRatesEntry
-	63, // 18: sdl.v1.EvaluateFlowsResponse.flow_edges:type_name -> sdl.v1.FlowEdge
-	64, // 19: sdl.v1.GetFlowStateResponse.state:type_name -> sdl.v1.FlowState
-	65, // 20: sdl.v1.GetSystemDiagramResponse.diagram:type_name -> sdl.v1.SystemDiagram
-	66, // 21: sdl.v1.GetUtilizationResponse.utilizations:type_name -> sdl.v1.UtilizationInfo
-	22, // [22:22] is the sub-list for method output_type
-	22, // [22:22] is the sub-list for method input_type
-	22, // [22:22] is the sub-list for extension type_name
-	22, // [22:22] is the sub-list for extension extendee
-	0,  // [0:22] is the sub-list for field type_name
+	73, // 0: sdl.v1.GetManifestResponse.manifest:type_name -> sdl.v1.Manifest
+	74, // 1: sdl.v1.AddGeneratorRequest.generator:type_name -> sdl.v1.Generator
+	74, // 2: sdl.v1.AddGeneratorResponse.generator:type_name -> sdl.v1.Generator
+	74, // 3: sdl.v1.ListGeneratorsResponse.generators:type_name -> sdl.v1.Generator
+	74, // 4: sdl.v1.GetGeneratorResponse.generator:type_name -> sdl.v1.Generator
+	74, // 5: sdl.v1.UpdateGeneratorRequest.generator:type_name -> sdl.v1.Generator
+	74, // 6: sdl.v1.UpdateGeneratorResponse.generator:type_name -> sdl.v1.Generator
+	75, // 7: sdl.v1.AddMetricRequest.metric:type_name -> sdl.v1.Metric
+	75, // 8: sdl.v1.AddMetricResponse.metric:type_name -> sdl.v1.Metric
+	75, // 9: sdl.v1.ListMetricsResponse.metrics:type_name -> sdl.v1.Metric
+	76, // 10: sdl.v1.QueryMetricsResponse.points:type_name -> sdl.v1.MetricPoint
+	69, // 11: sdl.v1.GetMeasurementStatsResponse.rows_per_metric:type_name -> sdl.v1.GetMeasurementStatsResponse.RowsPerMetricEntry
+	77, // 12: sdl.v1.AggregateMetricsResponse.results:type_name -> sdl.v1.AggregateResult
+	78, // 13: sdl.v1.StreamMetricsResponse.updates:type_name -> sdl.v1.MetricUpdate
+	79, // 14: sdl.v1.ExecuteTraceResponse.trace_data:type_name -> sdl.v1.TraceData
+	80, // 15: sdl.v1.TraceAllPathsResponse.trace_data:type_name -> sdl.v1.AllPathsTraceData
+	70, // 16: sdl.v1.GetParametersResponse.parameters:type_name -> sdl.v1.GetParametersResponse.ParametersEntry
+	81, // 17: sdl.v1.BatchSetParametersRequest.updates:type_name -> sdl.v1.ParameterUpdate
+	82, // 18: sdl.v1.BatchSetParametersResponse.results:type_name -> sdl.v1.ParameterUpdateResult
+	71, // 19: sdl.v1.EvaluateFlowsResponse.component_rates:type_name -> sdl.v1.EvaluateFlowsResponse.ComponentRatesEntry
+	83, // 20: sdl.v1.EvaluateFlowsResponse.flow_edges:type_name -> sdl.v1.FlowEdge
+	84, // 21: sdl.v1.GetFlowStateResponse.state:type_name -> sdl.v1.FlowState
+	53, // 22: sdl.v1.GetFlowsResponse.flows:type_name -> sdl.v1.FlowEntry
+	85, // 23: sdl.v1.GetSystemDiagramResponse.diagram:type_name -> sdl.v1.SystemDiagram
+	86, // 24: sdl.v1.GetUtilizationResponse.utilizations:type_name -> sdl.v1.UtilizationInfo
+	60, // 25: sdl.v1.RunSummary.mean:type_name -> sdl.v1.LatencyEstimate
+	60, // 26: sdl.v1.RunSummary.p50:type_name -> sdl.v1.LatencyEstimate
+	60, // 27: sdl.v1.RunSummary.p95:type_name -> sdl.v1.LatencyEstimate
+	60, // 28: sdl.v1.RunSummary.p99:type_name -> sdl.v1.LatencyEstimate
+	61, // 29: sdl.v1.RunRecord.summary:type_name -> sdl.v1.RunSummary
+	62, // 30: sdl.v1.ListRunsResponse.runs:type_name -> sdl.v1.RunRecord
+	74, // 31: sdl.v1.SimulateRequest.generators:type_name -> sdl.v1.Generator
+	75, // 32: sdl.v1.SimulateRequest.metrics:type_name -> sdl.v1.Metric
+	76, // 33: sdl.v1.MetricSeries.points:type_name -> sdl.v1.MetricPoint
+	72, // 34: sdl.v1.SimulateResponse.metric_series:type_name -> sdl.v1.SimulateResponse.MetricSeriesEntry
+	66, // 35: sdl.v1.SimulateResponse.errors:type_name -> sdl.v1.SimulationDiagnostic
+	67, // 36: sdl.v1.SimulateResponse.MetricSeriesEntry.value:type_name -> sdl.v1.MetricSeries
+	37, // [37:37] is the sub-list for method output_type
+	37, // [37:37] is the sub-list for method input_type
+	37, // [37:37] is the sub-list for extension type_name
+	37, // [37:37] is the sub-list for extension extendee
+	0,  // [0:37] is the sub-list for field type_name
 }
 
 func init() { file_sdl_v1_models_canvas_service_proto_init() }
@@ -2990,7 +4217,7 @@ func file_sdl_v1_models_canvas_service_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_sdl_v1_models_canvas_service_proto_rawDesc), len(file_sdl_v1_models_canvas_service_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   54,
+			NumMessages:   73,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -191,6 +191,176 @@ func (*DevEnvAvailableSystemsResponse) Descriptor() ([]byte, []int) {
 	return file_sdl_v1_models_devenvpage_proto_rawDescGZIP(), []int{3}
 }
 
+// The loaded files were recompiled into a new model
+type DevEnvModelChangedRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Manifest      *Manifest              `protobuf:"bytes,1,opt,name=manifest,proto3" json:"manifest,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DevEnvModelChangedRequest) Reset() {
+	*x = DevEnvModelChangedRequest{}
+	mi := &file_sdl_v1_models_devenvpage_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DevEnvModelChangedRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DevEnvModelChangedRequest) ProtoMessage() {}
+
+func (x *DevEnvModelChangedRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_sdl_v1_models_devenvpage_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DevEnvModelChangedRequest.ProtoReflect.Descriptor instead.
+func (*DevEnvModelChangedRequest) Descriptor() ([]byte, []int) {
+	return file_sdl_v1_models_devenvpage_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *DevEnvModelChangedRequest) GetManifest() *Manifest {
+	if x != nil {
+		return x.Manifest
+	}
+	return nil
+}
+
+type DevEnvModelChangedResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DevEnvModelChangedResponse) Reset() {
+	*x = DevEnvModelChangedResponse{}
+	mi := &file_sdl_v1_models_devenvpage_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DevEnvModelChangedResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DevEnvModelChangedResponse) ProtoMessage() {}
+
+func (x *DevEnvModelChangedResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_sdl_v1_models_devenvpage_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DevEnvModelChangedResponse.ProtoReflect.Descriptor instead.
+func (*DevEnvModelChangedResponse) Descriptor() ([]byte, []int) {
+	return file_sdl_v1_models_devenvpage_proto_rawDescGZIP(), []int{5}
+}
+
+// A changed file failed to recompile and the previous model is kept
+type DevEnvDiagnosticsRequest struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	FilePath      string                  `protobuf:"bytes,1,opt,name=file_path,json=filePath,proto3" json:"file_path,omitempty"`
+	Diagnostics   []*SimulationDiagnostic `protobuf:"bytes,2,rep,name=diagnostics,proto3" json:"diagnostics,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DevEnvDiagnosticsRequest) Reset() {
+	*x = DevEnvDiagnosticsRequest{}
+	mi := &file_sdl_v1_models_devenvpage_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DevEnvDiagnosticsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DevEnvDiagnosticsRequest) ProtoMessage() {}
+
+func (x *DevEnvDiagnosticsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_sdl_v1_models_devenvpage_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DevEnvDiagnosticsRequest.ProtoReflect.Descriptor instead.
+func (*DevEnvDiagnosticsRequest) Descriptor() ([]byte, []int) {
+	return file_sdl_v1_models_devenvpage_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *DevEnvDiagnosticsRequest) GetFilePath() string {
+	if x != nil {
+		return x.FilePath
+	}
+	return ""
+}
+
+func (x *DevEnvDiagnosticsRequest) GetDiagnostics() []*SimulationDiagnostic {
+	if x != nil {
+		return x.Diagnostics
+	}
+	return nil
+}
+
+type DevEnvDiagnosticsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DevEnvDiagnosticsResponse) Reset() {
+	*x = DevEnvDiagnosticsResponse{}
+	mi := &file_sdl_v1_models_devenvpage_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DevEnvDiagnosticsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DevEnvDiagnosticsResponse) ProtoMessage() {}
+
+func (x *DevEnvDiagnosticsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_sdl_v1_models_devenvpage_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DevEnvDiagnosticsResponse.ProtoReflect.Descriptor instead.
+func (*DevEnvDiagnosticsResponse) Descriptor() ([]byte, []int) {
+	return file_sdl_v1_models_devenvpage_proto_rawDescGZIP(), []int{7}
+}
+
 type DevEnvUpdateGeneratorRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
@@ -201,7 +371,7 @@ type DevEnvUpdateGeneratorRequest struct {
 
 func (x *DevEnvUpdateGeneratorRequest) Reset() {
 	*x = DevEnvUpdateGeneratorRequest{}
-	mi := &file_sdl_v1_models_devenvpage_proto_msgTypes[4]
+	mi := &file_sdl_v1_models_devenvpage_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -213,7 +383,7 @@ func (x *DevEnvUpdateGeneratorRequest) String() string {
 func (*DevEnvUpdateGeneratorRequest) ProtoMessage() {}
 
 func (x *DevEnvUpdateGeneratorRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_sdl_v1_models_devenvpage_proto_msgTypes[4]
+	mi := &file_sdl_v1_models_devenvpage_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -226,7 +396,7 @@ func (x *DevEnvUpdateGeneratorRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DevEnvUpdateGeneratorRequest.ProtoReflect.Descriptor instead.
 func (*DevEnvUpdateGeneratorRequest) Descriptor() ([]byte, []int) {
-	return file_sdl_v1_models_devenvpage_proto_rawDescGZIP(), []int{4}
+	return file_sdl_v1_models_devenvpage_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *DevEnvUpdateGeneratorRequest) GetName() string {
@@ -251,7 +421,7 @@ type DevEnvUpdateGeneratorResponse struct {
 
 func (x *DevEnvUpdateGeneratorResponse) Reset() {
 	*x = DevEnvUpdateGeneratorResponse{}
-	mi := &file_sdl_v1_models_devenvpage_proto_msgTypes[5]
+	mi := &file_sdl_v1_models_devenvpage_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -263,7 +433,7 @@ func (x *DevEnvUpdateGeneratorResponse) String() string {
 func (*DevEnvUpdateGeneratorResponse) ProtoMessage() {}
 
 func (x *DevEnvUpdateGeneratorResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_sdl_v1_models_devenvpage_proto_msgTypes[5]
+	mi := &file_sdl_v1_models_devenvpage_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -276,7 +446,7 @@ func (x *DevEnvUpdateGeneratorResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DevEnvUpdateGeneratorResponse.ProtoReflect.Descriptor instead.
 func (*DevEnvUpdateGeneratorResponse) Descriptor() ([]byte, []int) {
-	return file_sdl_v1_models_devenvpage_proto_rawDescGZIP(), []int{5}
+	return file_sdl_v1_models_devenvpage_proto_rawDescGZIP(), []int{9}
 }
 
 type DevEnvRemoveGeneratorRequest struct {
@@ -288,7 +458,7 @@ type DevEnvRemoveGeneratorRequest struct {
 
 func (x *DevEnvRemoveGeneratorRequest) Reset() {
 	*x = DevEnvRemoveGeneratorRequest{}
-	mi := &file_sdl_v1_models_devenvpage_proto_msgTypes[6]
+	mi := &file_sdl_v1_models_devenvpage_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -300,7 +470,7 @@ func (x *DevEnvRemoveGeneratorRequest) String() string {
 func (*DevEnvRemoveGeneratorRequest) ProtoMessage() {}
 
 func (x *DevEnvRemoveGeneratorRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_sdl_v1_models_devenvpage_proto_msgTypes[6]
+	mi := &file_sdl_v1_models_devenvpage_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -313,7 +483,7 @@ func (x *DevEnvRemoveGeneratorRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DevEnvRemoveGeneratorRequest.ProtoReflect.Descriptor instead.
 func (*DevEnvRemoveGeneratorRequest) Descriptor() ([]byte, []int) {
-	return file_sdl_v1_models_devenvpage_proto_rawDescGZIP(), []int{6}
+	return file_sdl_v1_models_devenvpage_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *DevEnvRemoveGeneratorRequest) GetName() string {
@@ -331,7 +501,7 @@ type DevEnvRemoveGeneratorResponse struct {
 
 func (x *DevEnvRemoveGeneratorResponse) Reset() {
 	*x = DevEnvRemoveGeneratorResponse{}
-	mi := &file_sdl_v1_models_devenvpage_proto_msgTypes[7]
+	mi := &file_sdl_v1_models_devenvpage_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -343,7 +513,7 @@ func (x *DevEnvRemoveGeneratorResponse) String() string {
 func (*DevEnvRemoveGeneratorResponse) ProtoMessage() {}
 
 func (x *DevEnvRemoveGeneratorResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_sdl_v1_models_devenvpage_proto_msgTypes[7]
+	mi := &file_sdl_v1_models_devenvpage_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -356,7 +526,7 @@ func (x *DevEnvRemoveGeneratorResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DevEnvRemoveGeneratorResponse.ProtoReflect.Descriptor instead.
 func (*DevEnvRemoveGeneratorResponse) Descriptor() ([]byte, []int) {
-	return file_sdl_v1_models_devenvpage_proto_rawDescGZIP(), []int{7}
+	return file_sdl_v1_models_devenvpage_proto_rawDescGZIP(), []int{11}
 }
 
 type DevEnvUpdateMetricRequest struct {
@@ -369,7 +539,7 @@ type DevEnvUpdateMetricRequest struct {
 
 func (x *DevEnvUpdateMetricRequest) Reset() {
 	*x = DevEnvUpdateMetricRequest{}
-	mi := &file_sdl_v1_models_devenvpage_proto_msgTypes[8]
+	mi := &file_sdl_v1_models_devenvpage_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -381,7 +551,7 @@ func (x *DevEnvUpdateMetricRequest) String() string {
 func (*DevEnvUpdateMetricRequest) ProtoMessage() {}
 
 func (x *DevEnvUpdateMetricRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_sdl_v1_models_devenvpage_proto_msgTypes[8]
+	mi := &file_sdl_v1_models_devenvpage_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -394,7 +564,7 @@ func (x *DevEnvUpdateMetricRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DevEnvUpdateMetricRequest.ProtoReflect.Descriptor instead.
 func (*DevEnvUpdateMetricRequest) Descriptor() ([]byte, []int) {
-	return file_sdl_v1_models_devenvpage_proto_rawDescGZIP(), []int{8}
+	return file_sdl_v1_models_devenvpage_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *DevEnvUpdateMetricRequest) GetName() string {
@@ -419,7 +589,7 @@ type DevEnvUpdateMetricResponse struct {
 
 func (x *DevEnvUpdateMetricResponse) Reset() {
 	*x = DevEnvUpdateMetricResponse{}
-	mi := &file_sdl_v1_models_devenvpage_proto_msgTypes[9]
+	mi := &file_sdl_v1_models_devenvpage_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -431,7 +601,7 @@ func (x *DevEnvUpdateMetricResponse) String() string {
 func (*DevEnvUpdateMetricResponse) ProtoMessage() {}
 
 func (x *DevEnvUpdateMetricResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_sdl_v1_models_devenvpage_proto_msgTypes[9]
+	mi := &file_sdl_v1_models_devenvpage_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -444,7 +614,7 @@ func (x *DevEnvUpdateMetricResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DevEnvUpdateMetricResponse.ProtoReflect.Descriptor instead.
 func (*DevEnvUpdateMetricResponse) Descriptor() ([]byte, []int) {
-	return file_sdl_v1_models_devenvpage_proto_rawDescGZIP(), []int{9}
+	return file_sdl_v1_models_devenvpage_proto_rawDescGZIP(), []int{13}
 }
 
 type DevEnvRemoveMetricRequest struct {
@@ -456,7 +626,7 @@ type DevEnvRemoveMetricRequest struct {
 
 func (x *DevEnvRemoveMetricRequest) Reset() {
 	*x = DevEnvRemoveMetricRequest{}
-	mi := &file_sdl_v1_models_devenvpage_proto_msgTypes[10]
+	mi := &file_sdl_v1_models_devenvpage_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -468,7 +638,7 @@ func (x *DevEnvRemoveMetricRequest) String() string {
 func (*DevEnvRemoveMetricRequest) ProtoMessage() {}
 
 func (x *DevEnvRemoveMetricRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_sdl_v1_models_devenvpage_proto_msgTypes[10]
+	mi := &file_sdl_v1_models_devenvpage_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -481,7 +651,7 @@ func (x *DevEnvRemoveMetricRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DevEnvRemoveMetricRequest.ProtoReflect.Descriptor instead.
 func (*DevEnvRemoveMetricRequest) Descriptor() ([]byte, []int) {
-	return file_sdl_v1_models_devenvpage_proto_rawDescGZIP(), []int{10}
+	return file_sdl_v1_models_devenvpage_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *DevEnvRemoveMetricRequest) GetName() string {
@@ -499,7 +669,7 @@ type DevEnvRemoveMetricResponse struct {
 
 func (x *DevEnvRemoveMetricResponse) Reset() {
 	*x = DevEnvRemoveMetricResponse{}
-	mi := &file_sdl_v1_models_devenvpage_proto_msgTypes[11]
+	mi := &file_sdl_v1_models_devenvpage_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -511,7 +681,7 @@ func (x *DevEnvRemoveMetricResponse) String() string {
 func (*DevEnvRemoveMetricResponse) ProtoMessage() {}
 
 func (x *DevEnvRemoveMetricResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_sdl_v1_models_devenvpage_proto_msgTypes[11]
+	mi := &file_sdl_v1_models_devenvpage_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -524,14 +694,14 @@ func (x *DevEnvRemoveMetricResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DevEnvRemoveMetricResponse.ProtoReflect.Descriptor instead.
 func (*DevEnvRemoveMetricResponse) Descriptor() ([]byte, []int) {
-	return file_sdl_v1_models_devenvpage_proto_rawDescGZIP(), []int{11}
+	return file_sdl_v1_models_devenvpage_proto_rawDescGZIP(), []int{15}
 }
 
 var File_sdl_v1_models_devenvpage_proto protoreflect.FileDescriptor
 
 const file_sdl_v1_models_devenvpage_proto_rawDesc = "" +
 	"\n" +
-	"\x1esdl/v1/models/devenvpage.proto\x12\x06sdl.v1\x1a\x1asdl/v1/models/models.proto\"j\n" +
+	"\x1esdl/v1/models/devenvpage.proto\x12\x06sdl.v1\x1a\x1asdl/v1/models/models.proto\x1a\"sdl/v1/models/canvas_service.proto\"j\n" +
 	"\x1aDevEnvSystemChangedRequest\x12\x1f\n" +
 	"\vsystem_name\x18\x01 \x01(\tR\n" +
 	"systemName\x12+\n" +
@@ -539,7 +709,14 @@ const file_sdl_v1_models_devenvpage_proto_rawDesc = "" +
 	"\x1bDevEnvSystemChangedResponse\"B\n" +
 	"\x1dDevEnvAvailableSystemsRequest\x12!\n" +
 	"\fsystem_names\x18\x01 \x03(\tR\vsystemNames\" \n" +
-	"\x1eDevEnvAvailableSystemsResponse\"c\n" +
+	"\x1eDevEnvAvailableSystemsResponse\"I\n" +
+	"\x19DevEnvModelChangedRequest\x12,\n" +
+	"\bmanifest\x18\x01 \x01(\v2\x10.sdl.v1.ManifestR\bmanifest\"\x1c\n" +
+	"\x1aDevEnvModelChangedResponse\"w\n" +
+	"\x18DevEnvDiagnosticsRequest\x12\x1b\n" +
+	"\tfile_path\x18\x01 \x01(\tR\bfilePath\x12>\n" +
+	"\vdiagnostics\x18\x02 \x03(\v2\x1c.sdl.v1.SimulationDiagnosticR\vdiagnostics\"\x1b\n" +
+	"\x19DevEnvDiagnosticsResponse\"c\n" +
 	"\x1cDevEnvUpdateGeneratorRequest\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12/\n" +
 	"\tgenerator\x18\x02 \x01(\v2\x11.sdl.v1.GeneratorR\tgenerator\"\x1f\n" +
@@ -569,31 +746,39 @@ func file_sdl_v1_models_devenvpage_proto_rawDescGZIP() []byte {
 	return file_sdl_v1_models_devenvpage_proto_rawDescData
 }
 
-var file_sdl_v1_models_devenvpage_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_sdl_v1_models_devenvpage_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
 var file_sdl_v1_models_devenvpage_proto_goTypes = []any{
 	(*DevEnvSystemChangedRequest)(nil),     // 0: sdl.v1.DevEnvSystemChangedRequest
 	(*DevEnvSystemChangedResponse)(nil),    // 1: sdl.v1.DevEnvSystemChangedResponse
 	(*DevEnvAvailableSystemsRequest)(nil),  // 2: sdl.v1.DevEnvAvailableSystemsRequest
 	(*DevEnvAvailableSystemsResponse)(nil), // 3: sdl.v1.DevEnvAvailableSystemsResponse
-	(*DevEnvUpdateGeneratorRequest)(nil),   // 4: sdl.v1.DevEnvUpdateGeneratorRequest
-	(*DevEnvUpdateGeneratorResponse)(nil),  // 5: sdl.v1.DevEnvUpdateGeneratorResponse
-	(*DevEnvRemoveGeneratorRequest)(nil),   // 6: sdl.v1.DevEnvRemoveGeneratorRequest
-	(*DevEnvRemoveGeneratorResponse)(nil),  // 7: sdl.v1.DevEnvRemoveGeneratorResponse
-	(*DevEnvUpdateMetricRequest)(nil),      // 8: sdl.v1.DevEnvUpdateMetricRequest
-	(*DevEnvUpdateMetricResponse)(nil),     // 9: sdl.v1.DevEnvUpdateMetricResponse
-	(*DevEnvRemoveMetricRequest)(nil),      // 10: sdl.v1.DevEnvRemoveMetricRequest
-	(*DevEnvRemoveMetricResponse)(nil),     // 11: sdl.v1.DevEnvRemoveMetricResponse
-	(*Generator)(nil),                      // 12: sdl.v1.Generator
-	(*Metric)(nil),                         // 13: sdl.v1.Metric
+	(*DevEnvModelChangedRequest)(nil),      // 4: sdl.v1.DevEnvModelChangedRequest
+	(*DevEnvModelChangedResponse)(nil),     // 5: sdl.v1.DevEnvModelChangedResponse
+	(*DevEnvDiagnosticsRequest)(nil),       // 6: sdl.v1.DevEnvDiagnosticsRequest
+	(*DevEnvDiagnosticsResponse)(nil),      // 7: sdl.v1.DevEnvDiagnosticsResponse
+	(*DevEnvUpdateGeneratorRequest)(nil),   // 8: sdl.v1.DevEnvUpdateGeneratorRequest
+	(*DevEnvUpdateGeneratorResponse)(nil),  // 9: sdl.v1.DevEnvUpdateGeneratorResponse
+	(*DevEnvRemoveGeneratorRequest)(nil),   // 10: sdl.v1.DevEnvRemoveGeneratorRequest
+	(*DevEnvRemoveGeneratorResponse)(nil),  // 11: sdl.v1.DevEnvRemoveGeneratorResponse
+	(*DevEnvUpdateMetricRequest)(nil),      // 12: sdl.v1.DevEnvUpdateMetricRequest
+	(*DevEnvUpdateMetricResponse)(nil),     // 13: sdl.v1.DevEnvUpdateMetricResponse
+	(*DevEnvRemoveMetricRequest)(nil),      // 14: sdl.v1.DevEnvRemoveMetricRequest
+	(*DevEnvRemoveMetricResponse)(nil),     // 15: sdl.v1.DevEnvRemoveMetricResponse
+	(*Manifest)(nil),                       // 16: sdl.v1.Manifest
+	(*SimulationDiagnostic)(nil),           // 17: sdl.v1.SimulationDiagnostic
+	(*Generator)(nil),                      // 18: sdl.v1.Generator
+	(*Metric)(nil),                         // 19: sdl.v1.Metric
 }
 var file_sdl_v1_models_devenvpage_proto_depIdxs = []int32{
-	12, // 0: sdl.v1.DevEnvUpdateGeneratorRequest.generator:type_name -> sdl.v1.Generator
-	13, // 1: sdl.v1.DevEnvUpdateMetricRequest.metric:type_name -> sdl.v1.Metric
-	2,  // [2:2] is the sub-list for method output_type
-	2,  // [2:2] is the sub-list for method input_type
-	2,  // [2:2] is the sub-list for extension type_name
-	2,  // [2:2] is the sub-list for extension extendee
-	0,  // [0:2] is the sub-list for field type_name
+	16, // 0: sdl.v1.DevEnvModelChangedRequest.manifest:type_name -> sdl.v1.Manifest
+	17, // 1: sdl.v1.DevEnvDiagnosticsRequest.diagnostics:type_name -> sdl.v1.SimulationDiagnostic
+	18, // 2: sdl.v1.DevEnvUpdateGeneratorRequest.generator:type_name -> sdl.v1.Generator
+	19, // 3: sdl.v1.DevEnvUpdateMetricRequest.metric:type_name -> sdl.v1.Metric
+	4,  // [4:4] is the sub-list for method output_type
+	4,  // [4:4] is the sub-list for method input_type
+	4,  // [4:4] is the sub-list for extension type_name
+	4,  // [4:4] is the sub-list for extension extendee
+	0,  // [0:4] is the sub-list for field type_name
 }
 
 func init() { file_sdl_v1_models_devenvpage_proto_init() }
@@ -602,13 +787,14 @@ func file_sdl_v1_models_devenvpage_proto_init() {
 		return
 	}
 	file_sdl_v1_models_models_proto_init()
+	file_sdl_v1_models_canvas_service_proto_init()
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_sdl_v1_models_devenvpage_proto_rawDesc), len(file_sdl_v1_models_devenvpage_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   12,
+			NumMessages:   16,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
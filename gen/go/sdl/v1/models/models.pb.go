@@ -2115,6 +2115,582 @@ func (x *AggregateResult) GetValue() float64 {
 	return 0
 }
 
+// Manifest matches loader.Manifest, a versioned summary of the loaded
+// systems, components and enums for tools that do not parse SDL.
+type Manifest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Version       int32                  `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	Systems       []*ManifestSystem      `protobuf:"bytes,2,rep,name=systems,proto3" json:"systems,omitempty"`
+	Components    []*ManifestComponent   `protobuf:"bytes,3,rep,name=components,proto3" json:"components,omitempty"`
+	Enums         []*ManifestEnum        `protobuf:"bytes,4,rep,name=enums,proto3" json:"enums,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Manifest) Reset() {
+	*x = Manifest{}
+	mi := &file_sdl_v1_models_models_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Manifest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Manifest) ProtoMessage() {}
+
+func (x *Manifest) ProtoReflect() protoreflect.Message {
+	mi := &file_sdl_v1_models_models_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Manifest.ProtoReflect.Descriptor instead.
+func (*Manifest) Descriptor() ([]byte, []int) {
+	return file_sdl_v1_models_models_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *Manifest) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Manifest) GetSystems() []*ManifestSystem {
+	if x != nil {
+		return x.Systems
+	}
+	return nil
+}
+
+func (x *Manifest) GetComponents() []*ManifestComponent {
+	if x != nil {
+		return x.Components
+	}
+	return nil
+}
+
+func (x *Manifest) GetEnums() []*ManifestEnum {
+	if x != nil {
+		return x.Enums
+	}
+	return nil
+}
+
+// ManifestSystem is a system with the component graph it instantiates
+type ManifestSystem struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Name       string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	File       string                 `protobuf:"bytes,2,opt,name=file,proto3" json:"file,omitempty"`
+	Parameters []*ManifestInstance    `protobuf:"bytes,3,rep,name=parameters,proto3" json:"parameters,omitempty"`
+	// Every instance reachable from the parameters by path, eg "app.cache"
+	Instances     []*ManifestInstance `protobuf:"bytes,4,rep,name=instances,proto3" json:"instances,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ManifestSystem) Reset() {
+	*x = ManifestSystem{}
+	mi := &file_sdl_v1_models_models_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ManifestSystem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ManifestSystem) ProtoMessage() {}
+
+func (x *ManifestSystem) ProtoReflect() protoreflect.Message {
+	mi := &file_sdl_v1_models_models_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ManifestSystem.ProtoReflect.Descriptor instead.
+func (*ManifestSystem) Descriptor() ([]byte, []int) {
+	return file_sdl_v1_models_models_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ManifestSystem) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ManifestSystem) GetFile() string {
+	if x != nil {
+		return x.File
+	}
+	return ""
+}
+
+func (x *ManifestSystem) GetParameters() []*ManifestInstance {
+	if x != nil {
+		return x.Parameters
+	}
+	return nil
+}
+
+func (x *ManifestSystem) GetInstances() []*ManifestInstance {
+	if x != nil {
+		return x.Instances
+	}
+	return nil
+}
+
+type ManifestInstance struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Path          string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	Component     string                 `protobuf:"bytes,2,opt,name=component,proto3" json:"component,omitempty"`
+	Uses          []string               `protobuf:"bytes,3,rep,name=uses,proto3" json:"uses,omitempty"` // Paths of the instances this one uses
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ManifestInstance) Reset() {
+	*x = ManifestInstance{}
+	mi := &file_sdl_v1_models_models_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ManifestInstance) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ManifestInstance) ProtoMessage() {}
+
+func (x *ManifestInstance) ProtoReflect() protoreflect.Message {
+	mi := &file_sdl_v1_models_models_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ManifestInstance.ProtoReflect.Descriptor instead.
+func (*ManifestInstance) Descriptor() ([]byte, []int) {
+	return file_sdl_v1_models_models_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ManifestInstance) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *ManifestInstance) GetComponent() string {
+	if x != nil {
+		return x.Component
+	}
+	return ""
+}
+
+func (x *ManifestInstance) GetUses() []string {
+	if x != nil {
+		return x.Uses
+	}
+	return nil
+}
+
+type ManifestComponent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	File          string                 `protobuf:"bytes,2,opt,name=file,proto3" json:"file,omitempty"`
+	Native        bool                   `protobuf:"varint,3,opt,name=native,proto3" json:"native,omitempty"`
+	Params        []*ManifestParam       `protobuf:"bytes,4,rep,name=params,proto3" json:"params,omitempty"`
+	Uses          []*ManifestUses        `protobuf:"bytes,5,rep,name=uses,proto3" json:"uses,omitempty"`
+	Methods       []*ManifestMethod      `protobuf:"bytes,6,rep,name=methods,proto3" json:"methods,omitempty"`
+	Profiles      []*ManifestProfile     `protobuf:"bytes,7,rep,name=profiles,proto3" json:"profiles,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ManifestComponent) Reset() {
+	*x = ManifestComponent{}
+	mi := &file_sdl_v1_models_models_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ManifestComponent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ManifestComponent) ProtoMessage() {}
+
+func (x *ManifestComponent) ProtoReflect() protoreflect.Message {
+	mi := &file_sdl_v1_models_models_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ManifestComponent.ProtoReflect.Descriptor instead.
+func (*ManifestComponent) Descriptor() ([]byte, []int) {
+	return file_sdl_v1_models_models_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ManifestComponent) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ManifestComponent) GetFile() string {
+	if x != nil {
+		return x.File
+	}
+	return ""
+}
+
+func (x *ManifestComponent) GetNative() bool {
+	if x != nil {
+		return x.Native
+	}
+	return false
+}
+
+func (x *ManifestComponent) GetParams() []*ManifestParam {
+	if x != nil {
+		return x.Params
+	}
+	return nil
+}
+
+func (x *ManifestComponent) GetUses() []*ManifestUses {
+	if x != nil {
+		return x.Uses
+	}
+	return nil
+}
+
+func (x *ManifestComponent) GetMethods() []*ManifestMethod {
+	if x != nil {
+		return x.Methods
+	}
+	return nil
+}
+
+func (x *ManifestComponent) GetProfiles() []*ManifestProfile {
+	if x != nil {
+		return x.Profiles
+	}
+	return nil
+}
+
+type ManifestParam struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	DefaultValue  string                 `protobuf:"bytes,3,opt,name=default_value,json=defaultValue,proto3" json:"default_value,omitempty"` // JSON encoded default, empty if there is none
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ManifestParam) Reset() {
+	*x = ManifestParam{}
+	mi := &file_sdl_v1_models_models_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ManifestParam) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ManifestParam) ProtoMessage() {}
+
+func (x *ManifestParam) ProtoReflect() protoreflect.Message {
+	mi := &file_sdl_v1_models_models_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ManifestParam.ProtoReflect.Descriptor instead.
+func (*ManifestParam) Descriptor() ([]byte, []int) {
+	return file_sdl_v1_models_models_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ManifestParam) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ManifestParam) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *ManifestParam) GetDefaultValue() string {
+	if x != nil {
+		return x.DefaultValue
+	}
+	return ""
+}
+
+type ManifestUses struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Component     string                 `protobuf:"bytes,2,opt,name=component,proto3" json:"component,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ManifestUses) Reset() {
+	*x = ManifestUses{}
+	mi := &file_sdl_v1_models_models_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ManifestUses) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ManifestUses) ProtoMessage() {}
+
+func (x *ManifestUses) ProtoReflect() protoreflect.Message {
+	mi := &file_sdl_v1_models_models_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ManifestUses.ProtoReflect.Descriptor instead.
+func (*ManifestUses) Descriptor() ([]byte, []int) {
+	return file_sdl_v1_models_models_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ManifestUses) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ManifestUses) GetComponent() string {
+	if x != nil {
+		return x.Component
+	}
+	return ""
+}
+
+type ManifestMethod struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Params        []*ManifestParam       `protobuf:"bytes,2,rep,name=params,proto3" json:"params,omitempty"`
+	Returns       string                 `protobuf:"bytes,3,opt,name=returns,proto3" json:"returns,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ManifestMethod) Reset() {
+	*x = ManifestMethod{}
+	mi := &file_sdl_v1_models_models_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ManifestMethod) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ManifestMethod) ProtoMessage() {}
+
+func (x *ManifestMethod) ProtoReflect() protoreflect.Message {
+	mi := &file_sdl_v1_models_models_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ManifestMethod.ProtoReflect.Descriptor instead.
+func (*ManifestMethod) Descriptor() ([]byte, []int) {
+	return file_sdl_v1_models_models_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ManifestMethod) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ManifestMethod) GetParams() []*ManifestParam {
+	if x != nil {
+		return x.Params
+	}
+	return nil
+}
+
+func (x *ManifestMethod) GetReturns() string {
+	if x != nil {
+		return x.Returns
+	}
+	return ""
+}
+
+type ManifestProfile struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Methods       []string               `protobuf:"bytes,2,rep,name=methods,proto3" json:"methods,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ManifestProfile) Reset() {
+	*x = ManifestProfile{}
+	mi := &file_sdl_v1_models_models_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ManifestProfile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ManifestProfile) ProtoMessage() {}
+
+func (x *ManifestProfile) ProtoReflect() protoreflect.Message {
+	mi := &file_sdl_v1_models_models_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ManifestProfile.ProtoReflect.Descriptor instead.
+func (*ManifestProfile) Descriptor() ([]byte, []int) {
+	return file_sdl_v1_models_models_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *ManifestProfile) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ManifestProfile) GetMethods() []string {
+	if x != nil {
+		return x.Methods
+	}
+	return nil
+}
+
+type ManifestEnum struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	File          string                 `protobuf:"bytes,2,opt,name=file,proto3" json:"file,omitempty"`
+	Values        []string               `protobuf:"bytes,3,rep,name=values,proto3" json:"values,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ManifestEnum) Reset() {
+	*x = ManifestEnum{}
+	mi := &file_sdl_v1_models_models_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ManifestEnum) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ManifestEnum) ProtoMessage() {}
+
+func (x *ManifestEnum) ProtoReflect() protoreflect.Message {
+	mi := &file_sdl_v1_models_models_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ManifestEnum.ProtoReflect.Descriptor instead.
+func (*ManifestEnum) Descriptor() ([]byte, []int) {
+	return file_sdl_v1_models_models_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *ManifestEnum) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ManifestEnum) GetFile() string {
+	if x != nil {
+		return x.File
+	}
+	return ""
+}
+
+func (x *ManifestEnum) GetValues() []string {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
 var File_sdl_v1_models_models_proto protoreflect.FileDescriptor
 
 const file_sdl_v1_models_models_proto_rawDesc = "" +
@@ -2314,7 +2890,51 @@ const file_sdl_v1_models_models_proto_rawDesc = "" +
 	"\tnew_value\x18\x05 \x01(\tR\bnewValue\"E\n" +
 	"\x0fAggregateResult\x12\x1c\n" +
 	"\ttimestamp\x18\x01 \x01(\x01R\ttimestamp\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\x01R\x05valueB\x84\x01\n" +
+	"\x05value\x18\x02 \x01(\x01R\x05value\"\xbd\x01\n" +
+	"\bManifest\x12\x18\n" +
+	"\aversion\x18\x01 \x01(\x05R\aversion\x120\n" +
+	"\asystems\x18\x02 \x03(\v2\x16.sdl.v1.ManifestSystemR\asystems\x129\n" +
+	"\n" +
+	"components\x18\x03 \x03(\v2\x19.sdl.v1.ManifestComponentR\n" +
+	"components\x12*\n" +
+	"\x05enums\x18\x04 \x03(\v2\x14.sdl.v1.ManifestEnumR\x05enums\"\xaa\x01\n" +
+	"\x0eManifestSystem\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04file\x18\x02 \x01(\tR\x04file\x128\n" +
+	"\n" +
+	"parameters\x18\x03 \x03(\v2\x18.sdl.v1.ManifestInstanceR\n" +
+	"parameters\x126\n" +
+	"\tinstances\x18\x04 \x03(\v2\x18.sdl.v1.ManifestInstanceR\tinstances\"X\n" +
+	"\x10ManifestInstance\x12\x12\n" +
+	"\x04path\x18\x01 \x01(\tR\x04path\x12\x1c\n" +
+	"\tcomponent\x18\x02 \x01(\tR\tcomponent\x12\x12\n" +
+	"\x04uses\x18\x03 \x03(\tR\x04uses\"\x93\x02\n" +
+	"\x11ManifestComponent\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04file\x18\x02 \x01(\tR\x04file\x12\x16\n" +
+	"\x06native\x18\x03 \x01(\bR\x06native\x12-\n" +
+	"\x06params\x18\x04 \x03(\v2\x15.sdl.v1.ManifestParamR\x06params\x12(\n" +
+	"\x04uses\x18\x05 \x03(\v2\x14.sdl.v1.ManifestUsesR\x04uses\x120\n" +
+	"\amethods\x18\x06 \x03(\v2\x16.sdl.v1.ManifestMethodR\amethods\x123\n" +
+	"\bprofiles\x18\a \x03(\v2\x17.sdl.v1.ManifestProfileR\bprofiles\"\\\n" +
+	"\rManifestParam\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12#\n" +
+	"\rdefault_value\x18\x03 \x01(\tR\fdefaultValue\"@\n" +
+	"\fManifestUses\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1c\n" +
+	"\tcomponent\x18\x02 \x01(\tR\tcomponent\"m\n" +
+	"\x0eManifestMethod\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12-\n" +
+	"\x06params\x18\x02 \x03(\v2\x15.sdl.v1.ManifestParamR\x06params\x12\x18\n" +
+	"\areturns\x18\x03 \x01(\tR\areturns\"?\n" +
+	"\x0fManifestProfile\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\amethods\x18\x02 \x03(\tR\amethods\"N\n" +
+	"\fManifestEnum\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04file\x18\x02 \x01(\tR\x04file\x12\x16\n" +
+	"\x06values\x18\x03 \x03(\tR\x06valuesB\x84\x01\n" +
 	"\n" +
 	"com.sdl.v1B\vModelsProtoP\x01Z0github.com/panyam/sdl/gen/go/sdl/v1/models;sdlv1\xa2\x02\x03SXX\xaa\x02\x06Sdl.V1\xca\x02\x06Sdl\\V1\xe2\x02\x12Sdl\\V1\\GPBMetadata\xea\x02\aSdl::V1b\x06proto3"
 
@@ -2330,7 +2950,7 @@ func file_sdl_v1_models_models_proto_rawDescGZIP() []byte {
 	return file_sdl_v1_models_models_proto_rawDescData
 }
 
-var file_sdl_v1_models_models_proto_msgTypes = make([]protoimpl.MessageInfo, 29)
+var file_sdl_v1_models_models_proto_msgTypes = make([]protoimpl.MessageInfo, 38)
 var file_sdl_v1_models_models_proto_goTypes = []any{
 	(*Pagination)(nil),            // 0: sdl.v1.Pagination
 	(*PaginationResponse)(nil),    // 1: sdl.v1.PaginationResponse
@@ -2358,33 +2978,52 @@ var file_sdl_v1_models_models_proto_goTypes = []any{
 	(*ParameterUpdate)(nil),       // 23: sdl.v1.ParameterUpdate
 	(*ParameterUpdateResult)(nil), // 24: sdl.v1.ParameterUpdateResult
 	(*AggregateResult)(nil),       // 25: sdl.v1.AggregateResult
-	nil,                           // 26: sdl.v1.Workspace.SourcesEntry
-	nil,                           // 27: sdl.v1.FlowState.RatesEntry
-	nil,                           // 28: sdl.v1.FlowState.ManualOverridesEntry
-	(*timestamppb.Timestamp)(nil), // 29: google.protobuf.Timestamp
+	(*Manifest)(nil),              // 26: sdl.v1.Manifest
+	(*ManifestSystem)(nil),        // 27: sdl.v1.ManifestSystem
+	(*ManifestInstance)(nil),      // 28: sdl.v1.ManifestInstance
+	(*ManifestComponent)(nil),     // 29: sdl.v1.ManifestComponent
+	(*ManifestParam)(nil),         // 30: sdl.v1.ManifestParam
+	(*ManifestUses)(nil),          // 31: sdl.v1.ManifestUses
+	(*ManifestMethod)(nil),        // 32: sdl.v1.ManifestMethod
+	(*ManifestProfile)(nil),       // 33: sdl.v1.ManifestProfile
+	(*ManifestEnum)(nil),          // 34: sdl.v1.ManifestEnum
+	nil,                           // 35: sdl.v1.Workspace.SourcesEntry
+	nil,                           // 36: sdl.v1.FlowState.RatesEntry
+	nil,                           // 37: sdl.v1.FlowState.ManualOverridesEntry
+	(*timestamppb.Timestamp)(nil), // 38: google.protobuf.Timestamp
 }
 var file_sdl_v1_models_models_proto_depIdxs = []int32{
-	29, // 0: sdl.v1.Workspace.created_at:type_name -> google.protobuf.Timestamp
-	29, // 1: sdl.v1.Workspace.updated_at:type_name -> google.protobuf.Timestamp
-	26, // 2: sdl.v1.Workspace.sources:type_name -> sdl.v1.Workspace.SourcesEntry
+	38, // 0: sdl.v1.Workspace.created_at:type_name -> google.protobuf.Timestamp
+	38, // 1: sdl.v1.Workspace.updated_at:type_name -> google.protobuf.Timestamp
+	35, // 2: sdl.v1.Workspace.sources:type_name -> sdl.v1.Workspace.SourcesEntry
 	3,  // 3: sdl.v1.Workspace.designs:type_name -> sdl.v1.WorkspaceDesign
 	8,  // 4: sdl.v1.MetricUpdate.point:type_name -> sdl.v1.MetricPoint
 	11, // 5: sdl.v1.SystemDiagram.nodes:type_name -> sdl.v1.DiagramNode
 	13, // 6: sdl.v1.SystemDiagram.edges:type_name -> sdl.v1.DiagramEdge
 	12, // 7: sdl.v1.DiagramNode.methods:type_name -> sdl.v1.MethodInfo
-	27, // 8: sdl.v1.FlowState.rates:type_name -> sdl.v1.FlowState.RatesEntry
-	28, // 9: sdl.v1.FlowState.manual_overrides:type_name -> sdl.v1.FlowState.ManualOverridesEntry
+	36, // 8: sdl.v1.FlowState.rates:type_name -> sdl.v1.FlowState.RatesEntry
+	37, // 9: sdl.v1.FlowState.manual_overrides:type_name -> sdl.v1.FlowState.ManualOverridesEntry
 	18, // 10: sdl.v1.TraceData.events:type_name -> sdl.v1.TraceEvent
 	20, // 11: sdl.v1.AllPathsTraceData.root:type_name -> sdl.v1.TraceNode
 	21, // 12: sdl.v1.TraceNode.edges:type_name -> sdl.v1.Edge
 	22, // 13: sdl.v1.TraceNode.groups:type_name -> sdl.v1.GroupInfo
 	20, // 14: sdl.v1.Edge.next_node:type_name -> sdl.v1.TraceNode
-	4,  // 15: sdl.v1.Workspace.SourcesEntry.value:type_name -> sdl.v1.ImportSource
-	16, // [16:16] is the sub-list for method output_type
-	16, // [16:16] is the sub-list for method input_type
-	16, // [16:16] is the sub-list for extension type_name
-	16, // [16:16] is the sub-list for extension extendee
-	0,  // [0:16] is the sub-list for field type_name
+	27, // 15: sdl.v1.Manifest.systems:type_name -> sdl.v1.ManifestSystem
+	29, // 16: sdl.v1.Manifest.components:type_name -> sdl.v1.ManifestComponent
+	34, // 17: sdl.v1.Manifest.enums:type_name -> sdl.v1.ManifestEnum
+	28, // 18: sdl.v1.ManifestSystem.parameters:type_name -> sdl.v1.ManifestInstance
+	28, // 19: sdl.v1.ManifestSystem.instances:type_name -> sdl.v1.ManifestInstance
+	30, // 20: sdl.v1.ManifestComponent.params:type_name -> sdl.v1.ManifestParam
+	31, // 21: sdl.v1.ManifestComponent.uses:type_name -> sdl.v1.ManifestUses
+	32, // 22: sdl.v1.ManifestComponent.methods:type_name -> sdl.v1.ManifestMethod
+	33, // 23: sdl.v1.ManifestComponent.profiles:type_name -> sdl.v1.ManifestProfile
+	30, // 24: sdl.v1.ManifestMethod.params:type_name -> sdl.v1.ManifestParam
+	4,  // 25: sdl.v1.Workspace.SourcesEntry.value:type_name -> sdl.v1.ImportSource
+	26, // [26:26] is the sub-list for method output_type
+	26, // [26:26] is the sub-list for method input_type
+	26, // [26:26] is the sub-list for extension type_name
+	26, // [26:26] is the sub-list for extension extendee
+	0,  // [0:26] is the sub-list for field type_name
 }
 
 func init() { file_sdl_v1_models_models_proto_init() }
@@ -2398,7 +3037,7 @@ func file_sdl_v1_models_models_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_sdl_v1_models_models_proto_rawDesc), len(file_sdl_v1_models_models_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   29,
+			NumMessages:   38,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
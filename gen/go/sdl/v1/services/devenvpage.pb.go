@@ -27,10 +27,11 @@ var File_sdl_v1_services_devenvpage_proto protoreflect.FileDescriptor
 
 const file_sdl_v1_services_devenvpage_proto_rawDesc = "" +
 	"\n" +
-	" sdl/v1/services/devenvpage.proto\x12\x06sdl.v1\x1a\x1bwasmjs/v1/annotations.proto\x1a\x1asdl/v1/models/models.proto\x1a!sdl/v1/models/dashboardpage.proto\x1a\x1esdl/v1/models/devenvpage.proto2\xb2\x06\n" +
+	" sdl/v1/services/devenvpage.proto\x12\x06sdl.v1\x1a\x1bwasmjs/v1/annotations.proto\x1a\x1asdl/v1/models/models.proto\x1a!sdl/v1/models/dashboardpage.proto\x1a\x1esdl/v1/models/devenvpage.proto2\xe1\a\n" +
 	"\rWorkspacePage\x12Z\n" +
 	"\x0fOnSystemChanged\x12\".sdl.v1.DevEnvSystemChangedRequest\x1a#.sdl.v1.DevEnvSystemChangedResponse\x12j\n" +
-	"\x19OnAvailableSystemsChanged\x12%.sdl.v1.DevEnvAvailableSystemsRequest\x1a&.sdl.v1.DevEnvAvailableSystemsResponse\x12L\n" +
+	"\x19OnAvailableSystemsChanged\x12%.sdl.v1.DevEnvAvailableSystemsRequest\x1a&.sdl.v1.DevEnvAvailableSystemsResponse\x12W\n" +
+	"\x0eOnModelChanged\x12!.sdl.v1.DevEnvModelChangedRequest\x1a\".sdl.v1.DevEnvModelChangedResponse\x12L\n" +
 	"\rUpdateDiagram\x12\x1c.sdl.v1.UpdateDiagramRequest\x1a\x1d.sdl.v1.UpdateDiagramResponse\x12^\n" +
 	"\x0fUpdateGenerator\x12$.sdl.v1.DevEnvUpdateGeneratorRequest\x1a%.sdl.v1.DevEnvUpdateGeneratorResponse\x12^\n" +
 	"\x0fRemoveGenerator\x12$.sdl.v1.DevEnvRemoveGeneratorRequest\x1a%.sdl.v1.DevEnvRemoveGeneratorResponse\x12U\n" +
@@ -38,51 +39,60 @@ const file_sdl_v1_services_devenvpage_proto_rawDesc = "" +
 	"\fRemoveMetric\x12!.sdl.v1.DevEnvRemoveMetricRequest\x1a\".sdl.v1.DevEnvRemoveMetricResponse\x12R\n" +
 	"\x0fUpdateFlowRates\x12\x1e.sdl.v1.UpdateFlowRatesRequest\x1a\x1f.sdl.v1.UpdateFlowRatesResponse\x12C\n" +
 	"\n" +
-	"LogMessage\x12\x19.sdl.v1.LogMessageRequest\x1a\x1a.sdl.v1.LogMessageResponse\x1a\x04\xc0\xb5\x18\x01B\x8a\x01\n" +
+	"LogMessage\x12\x19.sdl.v1.LogMessageRequest\x1a\x1a.sdl.v1.LogMessageResponse\x12T\n" +
+	"\rOnDiagnostics\x12 .sdl.v1.DevEnvDiagnosticsRequest\x1a!.sdl.v1.DevEnvDiagnosticsResponse\x1a\x04\xc0\xb5\x18\x01B\x8a\x01\n" +
 	"\n" +
 	"com.sdl.v1B\x0fDevenvpageProtoP\x01Z2github.com/panyam/sdl/gen/go/sdl/v1/services;sdlv1\xa2\x02\x03SXX\xaa\x02\x06Sdl.V1\xca\x02\x06Sdl\\V1\xe2\x02\x12Sdl\\V1\\GPBMetadata\xea\x02\aSdl::V1b\x06proto3"
 
 var file_sdl_v1_services_devenvpage_proto_goTypes = []any{
 	(*models.DevEnvSystemChangedRequest)(nil),     // 0: sdl.v1.DevEnvSystemChangedRequest
 	(*models.DevEnvAvailableSystemsRequest)(nil),  // 1: sdl.v1.DevEnvAvailableSystemsRequest
-	(*models.UpdateDiagramRequest)(nil),           // 2: sdl.v1.UpdateDiagramRequest
-	(*models.DevEnvUpdateGeneratorRequest)(nil),   // 3: sdl.v1.DevEnvUpdateGeneratorRequest
-	(*models.DevEnvRemoveGeneratorRequest)(nil),   // 4: sdl.v1.DevEnvRemoveGeneratorRequest
-	(*models.DevEnvUpdateMetricRequest)(nil),      // 5: sdl.v1.DevEnvUpdateMetricRequest
-	(*models.DevEnvRemoveMetricRequest)(nil),      // 6: sdl.v1.DevEnvRemoveMetricRequest
-	(*models.UpdateFlowRatesRequest)(nil),         // 7: sdl.v1.UpdateFlowRatesRequest
-	(*models.LogMessageRequest)(nil),              // 8: sdl.v1.LogMessageRequest
-	(*models.DevEnvSystemChangedResponse)(nil),    // 9: sdl.v1.DevEnvSystemChangedResponse
-	(*models.DevEnvAvailableSystemsResponse)(nil), // 10: sdl.v1.DevEnvAvailableSystemsResponse
-	(*models.UpdateDiagramResponse)(nil),          // 11: sdl.v1.UpdateDiagramResponse
-	(*models.DevEnvUpdateGeneratorResponse)(nil),  // 12: sdl.v1.DevEnvUpdateGeneratorResponse
-	(*models.DevEnvRemoveGeneratorResponse)(nil),  // 13: sdl.v1.DevEnvRemoveGeneratorResponse
-	(*models.DevEnvUpdateMetricResponse)(nil),     // 14: sdl.v1.DevEnvUpdateMetricResponse
-	(*models.DevEnvRemoveMetricResponse)(nil),     // 15: sdl.v1.DevEnvRemoveMetricResponse
-	(*models.UpdateFlowRatesResponse)(nil),        // 16: sdl.v1.UpdateFlowRatesResponse
-	(*models.LogMessageResponse)(nil),             // 17: sdl.v1.LogMessageResponse
+	(*models.DevEnvModelChangedRequest)(nil),      // 2: sdl.v1.DevEnvModelChangedRequest
+	(*models.UpdateDiagramRequest)(nil),           // 3: sdl.v1.UpdateDiagramRequest
+	(*models.DevEnvUpdateGeneratorRequest)(nil),   // 4: sdl.v1.DevEnvUpdateGeneratorRequest
+	(*models.DevEnvRemoveGeneratorRequest)(nil),   // 5: sdl.v1.DevEnvRemoveGeneratorRequest
+	(*models.DevEnvUpdateMetricRequest)(nil),      // 6: sdl.v1.DevEnvUpdateMetricRequest
+	(*models.DevEnvRemoveMetricRequest)(nil),      // 7: sdl.v1.DevEnvRemoveMetricRequest
+	(*models.UpdateFlowRatesRequest)(nil),         // 8: sdl.v1.UpdateFlowRatesRequest
+	(*models.LogMessageRequest)(nil),              // 9: sdl.v1.LogMessageRequest
+	(*models.DevEnvDiagnosticsRequest)(nil),       // 10: sdl.v1.DevEnvDiagnosticsRequest
+	(*models.DevEnvSystemChangedResponse)(nil),    // 11: sdl.v1.DevEnvSystemChangedResponse
+	(*models.DevEnvAvailableSystemsResponse)(nil), // 12: sdl.v1.DevEnvAvailableSystemsResponse
+	(*models.DevEnvModelChangedResponse)(nil),     // 13: sdl.v1.DevEnvModelChangedResponse
+	(*models.UpdateDiagramResponse)(nil),          // 14: sdl.v1.UpdateDiagramResponse
+	(*models.DevEnvUpdateGeneratorResponse)(nil),  // 15: sdl.v1.DevEnvUpdateGeneratorResponse
+	(*models.DevEnvRemoveGeneratorResponse)(nil),  // 16: sdl.v1.DevEnvRemoveGeneratorResponse
+	(*models.DevEnvUpdateMetricResponse)(nil),     // 17: sdl.v1.DevEnvUpdateMetricResponse
+	(*models.DevEnvRemoveMetricResponse)(nil),     // 18: sdl.v1.DevEnvRemoveMetricResponse
+	(*models.UpdateFlowRatesResponse)(nil),        // 19: sdl.v1.UpdateFlowRatesResponse
+	(*models.LogMessageResponse)(nil),             // 20: sdl.v1.LogMessageResponse
+	(*models.DevEnvDiagnosticsResponse)(nil),      // 21: sdl.v1.DevEnvDiagnosticsResponse
 }
 var file_sdl_v1_services_devenvpage_proto_depIdxs = []int32{
 	0,  // 0: sdl.v1.WorkspacePage.OnSystemChanged:input_type -> sdl.v1.DevEnvSystemChangedRequest
 	1,  // 1: sdl.v1.WorkspacePage.OnAvailableSystemsChanged:input_type -> sdl.v1.DevEnvAvailableSystemsRequest
-	2,  // 2: sdl.v1.WorkspacePage.UpdateDiagram:input_type -> sdl.v1.UpdateDiagramRequest
-	3,  // 3: sdl.v1.WorkspacePage.UpdateGenerator:input_type -> sdl.v1.DevEnvUpdateGeneratorRequest
-	4,  // 4: sdl.v1.WorkspacePage.RemoveGenerator:input_type -> sdl.v1.DevEnvRemoveGeneratorRequest
-	5,  // 5: sdl.v1.WorkspacePage.UpdateMetric:input_type -> sdl.v1.DevEnvUpdateMetricRequest
-	6,  // 6: sdl.v1.WorkspacePage.RemoveMetric:input_type -> sdl.v1.DevEnvRemoveMetricRequest
-	7,  // 7: sdl.v1.WorkspacePage.UpdateFlowRates:input_type -> sdl.v1.UpdateFlowRatesRequest
-	8,  // 8: sdl.v1.WorkspacePage.LogMessage:input_type -> sdl.v1.LogMessageRequest
-	9,  // 9: sdl.v1.WorkspacePage.OnSystemChanged:output_type -> sdl.v1.DevEnvSystemChangedResponse
-	10, // 10: sdl.v1.WorkspacePage.OnAvailableSystemsChanged:output_type -> sdl.v1.DevEnvAvailableSystemsResponse
-	11, // 11: sdl.v1.WorkspacePage.UpdateDiagram:output_type -> sdl.v1.UpdateDiagramResponse
-	12, // 12: sdl.v1.WorkspacePage.UpdateGenerator:output_type -> sdl.v1.DevEnvUpdateGeneratorResponse
-	13, // 13: sdl.v1.WorkspacePage.RemoveGenerator:output_type -> sdl.v1.DevEnvRemoveGeneratorResponse
-	14, // 14: sdl.v1.WorkspacePage.UpdateMetric:output_type -> sdl.v1.DevEnvUpdateMetricResponse
-	15, // 15: sdl.v1.WorkspacePage.RemoveMetric:output_type -> sdl.v1.DevEnvRemoveMetricResponse
-	16, // 16: sdl.v1.WorkspacePage.UpdateFlowRates:output_type -> sdl.v1.UpdateFlowRatesResponse
-	17, // 17: sdl.v1.WorkspacePage.LogMessage:output_type -> sdl.v1.LogMessageResponse
-	9,  // [9:18] is the sub-list for method output_type
-	0,  // [0:9] is the sub-list for method input_type
+	2,  // 2: sdl.v1.WorkspacePage.OnModelChanged:input_type -> sdl.v1.DevEnvModelChangedRequest
+	3,  // 3: sdl.v1.WorkspacePage.UpdateDiagram:input_type -> sdl.v1.UpdateDiagramRequest
+	4,  // 4: sdl.v1.WorkspacePage.UpdateGenerator:input_type -> sdl.v1.DevEnvUpdateGeneratorRequest
+	5,  // 5: sdl.v1.WorkspacePage.RemoveGenerator:input_type -> sdl.v1.DevEnvRemoveGeneratorRequest
+	6,  // 6: sdl.v1.WorkspacePage.UpdateMetric:input_type -> sdl.v1.DevEnvUpdateMetricRequest
+	7,  // 7: sdl.v1.WorkspacePage.RemoveMetric:input_type -> sdl.v1.DevEnvRemoveMetricRequest
+	8,  // 8: sdl.v1.WorkspacePage.UpdateFlowRates:input_type -> sdl.v1.UpdateFlowRatesRequest
+	9,  // 9: sdl.v1.WorkspacePage.LogMessage:input_type -> sdl.v1.LogMessageRequest
+	10, // 10: sdl.v1.WorkspacePage.OnDiagnostics:input_type -> sdl.v1.DevEnvDiagnosticsRequest
+	11, // 11: sdl.v1.WorkspacePage.OnSystemChanged:output_type -> sdl.v1.DevEnvSystemChangedResponse
+	12, // 12: sdl.v1.WorkspacePage.OnAvailableSystemsChanged:output_type -> sdl.v1.DevEnvAvailableSystemsResponse
+	13, // 13: sdl.v1.WorkspacePage.OnModelChanged:output_type -> sdl.v1.DevEnvModelChangedResponse
+	14, // 14: sdl.v1.WorkspacePage.UpdateDiagram:output_type -> sdl.v1.UpdateDiagramResponse
+	15, // 15: sdl.v1.WorkspacePage.UpdateGenerator:output_type -> sdl.v1.DevEnvUpdateGeneratorResponse
+	16, // 16: sdl.v1.WorkspacePage.RemoveGenerator:output_type -> sdl.v1.DevEnvRemoveGeneratorResponse
+	17, // 17: sdl.v1.WorkspacePage.UpdateMetric:output_type -> sdl.v1.DevEnvUpdateMetricResponse
+	18, // 18: sdl.v1.WorkspacePage.RemoveMetric:output_type -> sdl.v1.DevEnvRemoveMetricResponse
+	19, // 19: sdl.v1.WorkspacePage.UpdateFlowRates:output_type -> sdl.v1.UpdateFlowRatesResponse
+	20, // 20: sdl.v1.WorkspacePage.LogMessage:output_type -> sdl.v1.LogMessageResponse
+	21, // 21: sdl.v1.WorkspacePage.OnDiagnostics:output_type -> sdl.v1.DevEnvDiagnosticsResponse
+	11, // [11:22] is the sub-list for method output_type
+	0,  // [0:11] is the sub-list for method input_type
 	0,  // [0:0] is the sub-list for extension type_name
 	0,  // [0:0] is the sub-list for extension extendee
 	0,  // [0:0] is the sub-list for field type_name
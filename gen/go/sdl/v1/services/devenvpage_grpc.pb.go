@@ -23,6 +23,7 @@ const _ = grpc.SupportPackageIsVersion9
 const (
 	WorkspacePage_OnSystemChanged_FullMethodName           = "/sdl.v1.WorkspacePage/OnSystemChanged"
 	WorkspacePage_OnAvailableSystemsChanged_FullMethodName = "/sdl.v1.WorkspacePage/OnAvailableSystemsChanged"
+	WorkspacePage_OnModelChanged_FullMethodName            = "/sdl.v1.WorkspacePage/OnModelChanged"
 	WorkspacePage_UpdateDiagram_FullMethodName             = "/sdl.v1.WorkspacePage/UpdateDiagram"
 	WorkspacePage_UpdateGenerator_FullMethodName           = "/sdl.v1.WorkspacePage/UpdateGenerator"
 	WorkspacePage_RemoveGenerator_FullMethodName           = "/sdl.v1.WorkspacePage/RemoveGenerator"
@@ -30,6 +31,7 @@ const (
 	WorkspacePage_RemoveMetric_FullMethodName              = "/sdl.v1.WorkspacePage/RemoveMetric"
 	WorkspacePage_UpdateFlowRates_FullMethodName           = "/sdl.v1.WorkspacePage/UpdateFlowRates"
 	WorkspacePage_LogMessage_FullMethodName                = "/sdl.v1.WorkspacePage/LogMessage"
+	WorkspacePage_OnDiagnostics_FullMethodName             = "/sdl.v1.WorkspacePage/OnDiagnostics"
 )
 
 // WorkspacePageClient is the client API for WorkspacePage service.
@@ -44,6 +46,8 @@ type WorkspacePageClient interface {
 	OnSystemChanged(ctx context.Context, in *models.DevEnvSystemChangedRequest, opts ...grpc.CallOption) (*models.DevEnvSystemChangedResponse, error)
 	// Notify that the list of available systems has changed
 	OnAvailableSystemsChanged(ctx context.Context, in *models.DevEnvAvailableSystemsRequest, opts ...grpc.CallOption) (*models.DevEnvAvailableSystemsResponse, error)
+	// Push the manifest of the model the loaded files were recompiled into
+	OnModelChanged(ctx context.Context, in *models.DevEnvModelChangedRequest, opts ...grpc.CallOption) (*models.DevEnvModelChangedResponse, error)
 	// Update the system diagram (reuses existing message)
 	UpdateDiagram(ctx context.Context, in *models.UpdateDiagramRequest, opts ...grpc.CallOption) (*models.UpdateDiagramResponse, error)
 	// Upsert a generator by name
@@ -58,6 +62,8 @@ type WorkspacePageClient interface {
 	UpdateFlowRates(ctx context.Context, in *models.UpdateFlowRatesRequest, opts ...grpc.CallOption) (*models.UpdateFlowRatesResponse, error)
 	// Log a message to the console (reuses existing message)
 	LogMessage(ctx context.Context, in *models.LogMessageRequest, opts ...grpc.CallOption) (*models.LogMessageResponse, error)
+	// Report why a changed file failed to recompile
+	OnDiagnostics(ctx context.Context, in *models.DevEnvDiagnosticsRequest, opts ...grpc.CallOption) (*models.DevEnvDiagnosticsResponse, error)
 }
 
 type workspacePageClient struct {
@@ -88,6 +94,16 @@ func (c *workspacePageClient) OnAvailableSystemsChanged(ctx context.Context, in
 	return out, nil
 }
 
+func (c *workspacePageClient) OnModelChanged(ctx context.Context, in *models.DevEnvModelChangedRequest, opts ...grpc.CallOption) (*models.DevEnvModelChangedResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(models.DevEnvModelChangedResponse)
+	err := c.cc.Invoke(ctx, WorkspacePage_OnModelChanged_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *workspacePageClient) UpdateDiagram(ctx context.Context, in *models.UpdateDiagramRequest, opts ...grpc.CallOption) (*models.UpdateDiagramResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(models.UpdateDiagramResponse)
@@ -158,6 +174,16 @@ func (c *workspacePageClient) LogMessage(ctx context.Context, in *models.LogMess
 	return out, nil
 }
 
+func (c *workspacePageClient) OnDiagnostics(ctx context.Context, in *models.DevEnvDiagnosticsRequest, opts ...grpc.CallOption) (*models.DevEnvDiagnosticsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(models.DevEnvDiagnosticsResponse)
+	err := c.cc.Invoke(ctx, WorkspacePage_OnDiagnostics_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // WorkspacePageServer is the server API for WorkspacePage service.
 // All implementations should embed UnimplementedWorkspacePageServer
 // for forward compatibility.
@@ -170,6 +196,8 @@ type WorkspacePageServer interface {
 	OnSystemChanged(context.Context, *models.DevEnvSystemChangedRequest) (*models.DevEnvSystemChangedResponse, error)
 	// Notify that the list of available systems has changed
 	OnAvailableSystemsChanged(context.Context, *models.DevEnvAvailableSystemsRequest) (*models.DevEnvAvailableSystemsResponse, error)
+	// Push the manifest of the model the loaded files were recompiled into
+	OnModelChanged(context.Context, *models.DevEnvModelChangedRequest) (*models.DevEnvModelChangedResponse, error)
 	// Update the system diagram (reuses existing message)
 	UpdateDiagram(context.Context, *models.UpdateDiagramRequest) (*models.UpdateDiagramResponse, error)
 	// Upsert a generator by name
@@ -184,6 +212,8 @@ type WorkspacePageServer interface {
 	UpdateFlowRates(context.Context, *models.UpdateFlowRatesRequest) (*models.UpdateFlowRatesResponse, error)
 	// Log a message to the console (reuses existing message)
 	LogMessage(context.Context, *models.LogMessageRequest) (*models.LogMessageResponse, error)
+	// Report why a changed file failed to recompile
+	OnDiagnostics(context.Context, *models.DevEnvDiagnosticsRequest) (*models.DevEnvDiagnosticsResponse, error)
 }
 
 // UnimplementedWorkspacePageServer should be embedded to have
@@ -199,6 +229,9 @@ func (UnimplementedWorkspacePageServer) OnSystemChanged(context.Context, *models
 func (UnimplementedWorkspacePageServer) OnAvailableSystemsChanged(context.Context, *models.DevEnvAvailableSystemsRequest) (*models.DevEnvAvailableSystemsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method OnAvailableSystemsChanged not implemented")
 }
+func (UnimplementedWorkspacePageServer) OnModelChanged(context.Context, *models.DevEnvModelChangedRequest) (*models.DevEnvModelChangedResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method OnModelChanged not implemented")
+}
 func (UnimplementedWorkspacePageServer) UpdateDiagram(context.Context, *models.UpdateDiagramRequest) (*models.UpdateDiagramResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateDiagram not implemented")
 }
@@ -220,6 +253,9 @@ func (UnimplementedWorkspacePageServer) UpdateFlowRates(context.Context, *models
 func (UnimplementedWorkspacePageServer) LogMessage(context.Context, *models.LogMessageRequest) (*models.LogMessageResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method LogMessage not implemented")
 }
+func (UnimplementedWorkspacePageServer) OnDiagnostics(context.Context, *models.DevEnvDiagnosticsRequest) (*models.DevEnvDiagnosticsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method OnDiagnostics not implemented")
+}
 func (UnimplementedWorkspacePageServer) testEmbeddedByValue() {}
 
 // UnsafeWorkspacePageServer may be embedded to opt out of forward compatibility for this service.
@@ -276,6 +312,24 @@ func _WorkspacePage_OnAvailableSystemsChanged_Handler(srv interface{}, ctx conte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _WorkspacePage_OnModelChanged_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(models.DevEnvModelChangedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkspacePageServer).OnModelChanged(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WorkspacePage_OnModelChanged_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkspacePageServer).OnModelChanged(ctx, req.(*models.DevEnvModelChangedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _WorkspacePage_UpdateDiagram_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(models.UpdateDiagramRequest)
 	if err := dec(in); err != nil {
@@ -402,6 +456,24 @@ func _WorkspacePage_LogMessage_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _WorkspacePage_OnDiagnostics_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(models.DevEnvDiagnosticsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkspacePageServer).OnDiagnostics(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WorkspacePage_OnDiagnostics_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkspacePageServer).OnDiagnostics(ctx, req.(*models.DevEnvDiagnosticsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // WorkspacePage_ServiceDesc is the grpc.ServiceDesc for WorkspacePage service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -417,6 +489,10 @@ var WorkspacePage_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "OnAvailableSystemsChanged",
 			Handler:    _WorkspacePage_OnAvailableSystemsChanged_Handler,
 		},
+		{
+			MethodName: "OnModelChanged",
+			Handler:    _WorkspacePage_OnModelChanged_Handler,
+		},
 		{
 			MethodName: "UpdateDiagram",
 			Handler:    _WorkspacePage_UpdateDiagram_Handler,
@@ -445,6 +521,10 @@ var WorkspacePage_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "LogMessage",
 			Handler:    _WorkspacePage_LogMessage_Handler,
 		},
+		{
+			MethodName: "OnDiagnostics",
+			Handler:    _WorkspacePage_OnDiagnostics_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "sdl/v1/services/devenvpage.proto",
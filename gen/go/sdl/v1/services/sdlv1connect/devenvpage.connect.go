@@ -41,6 +41,9 @@ const (
 	// WorkspacePageOnAvailableSystemsChangedProcedure is the fully-qualified name of the
 	// WorkspacePage's OnAvailableSystemsChanged RPC.
 	WorkspacePageOnAvailableSystemsChangedProcedure = "/sdl.v1.WorkspacePage/OnAvailableSystemsChanged"
+	// WorkspacePageOnModelChangedProcedure is the fully-qualified name of the WorkspacePage's
+	// OnModelChanged RPC.
+	WorkspacePageOnModelChangedProcedure = "/sdl.v1.WorkspacePage/OnModelChanged"
 	// WorkspacePageUpdateDiagramProcedure is the fully-qualified name of the WorkspacePage's
 	// UpdateDiagram RPC.
 	WorkspacePageUpdateDiagramProcedure = "/sdl.v1.WorkspacePage/UpdateDiagram"
@@ -62,6 +65,9 @@ const (
 	// WorkspacePageLogMessageProcedure is the fully-qualified name of the WorkspacePage's LogMessage
 	// RPC.
 	WorkspacePageLogMessageProcedure = "/sdl.v1.WorkspacePage/LogMessage"
+	// WorkspacePageOnDiagnosticsProcedure is the fully-qualified name of the WorkspacePage's
+	// OnDiagnostics RPC.
+	WorkspacePageOnDiagnosticsProcedure = "/sdl.v1.WorkspacePage/OnDiagnostics"
 )
 
 // WorkspacePageClient is a client for the sdl.v1.WorkspacePage service.
@@ -70,6 +76,8 @@ type WorkspacePageClient interface {
 	OnSystemChanged(context.Context, *connect.Request[models.DevEnvSystemChangedRequest]) (*connect.Response[models.DevEnvSystemChangedResponse], error)
 	// Notify that the list of available systems has changed
 	OnAvailableSystemsChanged(context.Context, *connect.Request[models.DevEnvAvailableSystemsRequest]) (*connect.Response[models.DevEnvAvailableSystemsResponse], error)
+	// Push the manifest of the model the loaded files were recompiled into
+	OnModelChanged(context.Context, *connect.Request[models.DevEnvModelChangedRequest]) (*connect.Response[models.DevEnvModelChangedResponse], error)
 	// Update the system diagram (reuses existing message)
 	UpdateDiagram(context.Context, *connect.Request[models.UpdateDiagramRequest]) (*connect.Response[models.UpdateDiagramResponse], error)
 	// Upsert a generator by name
@@ -84,6 +92,8 @@ type WorkspacePageClient interface {
 	UpdateFlowRates(context.Context, *connect.Request[models.UpdateFlowRatesRequest]) (*connect.Response[models.UpdateFlowRatesResponse], error)
 	// Log a message to the console (reuses existing message)
 	LogMessage(context.Context, *connect.Request[models.LogMessageRequest]) (*connect.Response[models.LogMessageResponse], error)
+	// Report why a changed file failed to recompile
+	OnDiagnostics(context.Context, *connect.Request[models.DevEnvDiagnosticsRequest]) (*connect.Response[models.DevEnvDiagnosticsResponse], error)
 }
 
 // NewWorkspacePageClient constructs a client for the sdl.v1.WorkspacePage service. By default, it
@@ -109,6 +119,12 @@ func NewWorkspacePageClient(httpClient connect.HTTPClient, baseURL string, opts
 			connect.WithSchema(workspacePageMethods.ByName("OnAvailableSystemsChanged")),
 			connect.WithClientOptions(opts...),
 		),
+		onModelChanged: connect.NewClient[models.DevEnvModelChangedRequest, models.DevEnvModelChangedResponse](
+			httpClient,
+			baseURL+WorkspacePageOnModelChangedProcedure,
+			connect.WithSchema(workspacePageMethods.ByName("OnModelChanged")),
+			connect.WithClientOptions(opts...),
+		),
 		updateDiagram: connect.NewClient[models.UpdateDiagramRequest, models.UpdateDiagramResponse](
 			httpClient,
 			baseURL+WorkspacePageUpdateDiagramProcedure,
@@ -151,6 +167,12 @@ func NewWorkspacePageClient(httpClient connect.HTTPClient, baseURL string, opts
 			connect.WithSchema(workspacePageMethods.ByName("LogMessage")),
 			connect.WithClientOptions(opts...),
 		),
+		onDiagnostics: connect.NewClient[models.DevEnvDiagnosticsRequest, models.DevEnvDiagnosticsResponse](
+			httpClient,
+			baseURL+WorkspacePageOnDiagnosticsProcedure,
+			connect.WithSchema(workspacePageMethods.ByName("OnDiagnostics")),
+			connect.WithClientOptions(opts...),
+		),
 	}
 }
 
@@ -158,6 +180,7 @@ func NewWorkspacePageClient(httpClient connect.HTTPClient, baseURL string, opts
 type workspacePageClient struct {
 	onSystemChanged           *connect.Client[models.DevEnvSystemChangedRequest, models.DevEnvSystemChangedResponse]
 	onAvailableSystemsChanged *connect.Client[models.DevEnvAvailableSystemsRequest, models.DevEnvAvailableSystemsResponse]
+	onModelChanged            *connect.Client[models.DevEnvModelChangedRequest, models.DevEnvModelChangedResponse]
 	updateDiagram             *connect.Client[models.UpdateDiagramRequest, models.UpdateDiagramResponse]
 	updateGenerator           *connect.Client[models.DevEnvUpdateGeneratorRequest, models.DevEnvUpdateGeneratorResponse]
 	removeGenerator           *connect.Client[models.DevEnvRemoveGeneratorRequest, models.DevEnvRemoveGeneratorResponse]
@@ -165,6 +188,7 @@ type workspacePageClient struct {
 	removeMetric              *connect.Client[models.DevEnvRemoveMetricRequest, models.DevEnvRemoveMetricResponse]
 	updateFlowRates           *connect.Client[models.UpdateFlowRatesRequest, models.UpdateFlowRatesResponse]
 	logMessage                *connect.Client[models.LogMessageRequest, models.LogMessageResponse]
+	onDiagnostics             *connect.Client[models.DevEnvDiagnosticsRequest, models.DevEnvDiagnosticsResponse]
 }
 
 // OnSystemChanged calls sdl.v1.WorkspacePage.OnSystemChanged.
@@ -177,6 +201,11 @@ func (c *workspacePageClient) OnAvailableSystemsChanged(ctx context.Context, req
 	return c.onAvailableSystemsChanged.CallUnary(ctx, req)
 }
 
+// OnModelChanged calls sdl.v1.WorkspacePage.OnModelChanged.
+func (c *workspacePageClient) OnModelChanged(ctx context.Context, req *connect.Request[models.DevEnvModelChangedRequest]) (*connect.Response[models.DevEnvModelChangedResponse], error) {
+	return c.onModelChanged.CallUnary(ctx, req)
+}
+
 // UpdateDiagram calls sdl.v1.WorkspacePage.UpdateDiagram.
 func (c *workspacePageClient) UpdateDiagram(ctx context.Context, req *connect.Request[models.UpdateDiagramRequest]) (*connect.Response[models.UpdateDiagramResponse], error) {
 	return c.updateDiagram.CallUnary(ctx, req)
@@ -212,12 +241,19 @@ func (c *workspacePageClient) LogMessage(ctx context.Context, req *connect.Reque
 	return c.logMessage.CallUnary(ctx, req)
 }
 
+// OnDiagnostics calls sdl.v1.WorkspacePage.OnDiagnostics.
+func (c *workspacePageClient) OnDiagnostics(ctx context.Context, req *connect.Request[models.DevEnvDiagnosticsRequest]) (*connect.Response[models.DevEnvDiagnosticsResponse], error) {
+	return c.onDiagnostics.CallUnary(ctx, req)
+}
+
 // WorkspacePageHandler is an implementation of the sdl.v1.WorkspacePage service.
 type WorkspacePageHandler interface {
 	// Notify that the active system has changed
 	OnSystemChanged(context.Context, *connect.Request[models.DevEnvSystemChangedRequest]) (*connect.Response[models.DevEnvSystemChangedResponse], error)
 	// Notify that the list of available systems has changed
 	OnAvailableSystemsChanged(context.Context, *connect.Request[models.DevEnvAvailableSystemsRequest]) (*connect.Response[models.DevEnvAvailableSystemsResponse], error)
+	// Push the manifest of the model the loaded files were recompiled into
+	OnModelChanged(context.Context, *connect.Request[models.DevEnvModelChangedRequest]) (*connect.Response[models.DevEnvModelChangedResponse], error)
 	// Update the system diagram (reuses existing message)
 	UpdateDiagram(context.Context, *connect.Request[models.UpdateDiagramRequest]) (*connect.Response[models.UpdateDiagramResponse], error)
 	// Upsert a generator by name
@@ -232,6 +268,8 @@ type WorkspacePageHandler interface {
 	UpdateFlowRates(context.Context, *connect.Request[models.UpdateFlowRatesRequest]) (*connect.Response[models.UpdateFlowRatesResponse], error)
 	// Log a message to the console (reuses existing message)
 	LogMessage(context.Context, *connect.Request[models.LogMessageRequest]) (*connect.Response[models.LogMessageResponse], error)
+	// Report why a changed file failed to recompile
+	OnDiagnostics(context.Context, *connect.Request[models.DevEnvDiagnosticsRequest]) (*connect.Response[models.DevEnvDiagnosticsResponse], error)
 }
 
 // NewWorkspacePageHandler builds an HTTP handler from the service implementation. It returns the
@@ -253,6 +291,12 @@ func NewWorkspacePageHandler(svc WorkspacePageHandler, opts ...connect.HandlerOp
 		connect.WithSchema(workspacePageMethods.ByName("OnAvailableSystemsChanged")),
 		connect.WithHandlerOptions(opts...),
 	)
+	workspacePageOnModelChangedHandler := connect.NewUnaryHandler(
+		WorkspacePageOnModelChangedProcedure,
+		svc.OnModelChanged,
+		connect.WithSchema(workspacePageMethods.ByName("OnModelChanged")),
+		connect.WithHandlerOptions(opts...),
+	)
 	workspacePageUpdateDiagramHandler := connect.NewUnaryHandler(
 		WorkspacePageUpdateDiagramProcedure,
 		svc.UpdateDiagram,
@@ -295,12 +339,20 @@ func NewWorkspacePageHandler(svc WorkspacePageHandler, opts ...connect.HandlerOp
 		connect.WithSchema(workspacePageMethods.ByName("LogMessage")),
 		connect.WithHandlerOptions(opts...),
 	)
+	workspacePageOnDiagnosticsHandler := connect.NewUnaryHandler(
+		WorkspacePageOnDiagnosticsProcedure,
+		svc.OnDiagnostics,
+		connect.WithSchema(workspacePageMethods.ByName("OnDiagnostics")),
+		connect.WithHandlerOptions(opts...),
+	)
 	return "/sdl.v1.WorkspacePage/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case WorkspacePageOnSystemChangedProcedure:
 			workspacePageOnSystemChangedHandler.ServeHTTP(w, r)
 		case WorkspacePageOnAvailableSystemsChangedProcedure:
 			workspacePageOnAvailableSystemsChangedHandler.ServeHTTP(w, r)
+		case WorkspacePageOnModelChangedProcedure:
+			workspacePageOnModelChangedHandler.ServeHTTP(w, r)
 		case WorkspacePageUpdateDiagramProcedure:
 			workspacePageUpdateDiagramHandler.ServeHTTP(w, r)
 		case WorkspacePageUpdateGeneratorProcedure:
@@ -315,6 +367,8 @@ func NewWorkspacePageHandler(svc WorkspacePageHandler, opts ...connect.HandlerOp
 			workspacePageUpdateFlowRatesHandler.ServeHTTP(w, r)
 		case WorkspacePageLogMessageProcedure:
 			workspacePageLogMessageHandler.ServeHTTP(w, r)
+		case WorkspacePageOnDiagnosticsProcedure:
+			workspacePageOnDiagnosticsHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -332,6 +386,10 @@ func (UnimplementedWorkspacePageHandler) OnAvailableSystemsChanged(context.Conte
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("sdl.v1.WorkspacePage.OnAvailableSystemsChanged is not implemented"))
 }
 
+func (UnimplementedWorkspacePageHandler) OnModelChanged(context.Context, *connect.Request[models.DevEnvModelChangedRequest]) (*connect.Response[models.DevEnvModelChangedResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("sdl.v1.WorkspacePage.OnModelChanged is not implemented"))
+}
+
 func (UnimplementedWorkspacePageHandler) UpdateDiagram(context.Context, *connect.Request[models.UpdateDiagramRequest]) (*connect.Response[models.UpdateDiagramResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("sdl.v1.WorkspacePage.UpdateDiagram is not implemented"))
 }
@@ -359,3 +417,7 @@ func (UnimplementedWorkspacePageHandler) UpdateFlowRates(context.Context, *conne
 func (UnimplementedWorkspacePageHandler) LogMessage(context.Context, *connect.Request[models.LogMessageRequest]) (*connect.Response[models.LogMessageResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("sdl.v1.WorkspacePage.LogMessage is not implemented"))
 }
+
+func (UnimplementedWorkspacePageHandler) OnDiagnostics(context.Context, *connect.Request[models.DevEnvDiagnosticsRequest]) (*connect.Response[models.DevEnvDiagnosticsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("sdl.v1.WorkspacePage.OnDiagnostics is not implemented"))
+}
@@ -62,6 +62,9 @@ const (
 	// WorkspaceServiceUseSystemProcedure is the fully-qualified name of the WorkspaceService's
 	// UseSystem RPC.
 	WorkspaceServiceUseSystemProcedure = "/sdl.v1.WorkspaceService/UseSystem"
+	// WorkspaceServiceGetManifestProcedure is the fully-qualified name of the WorkspaceService's
+	// GetManifest RPC.
+	WorkspaceServiceGetManifestProcedure = "/sdl.v1.WorkspaceService/GetManifest"
 	// WorkspaceServiceAddGeneratorProcedure is the fully-qualified name of the WorkspaceService's
 	// AddGenerator RPC.
 	WorkspaceServiceAddGeneratorProcedure = "/sdl.v1.WorkspaceService/AddGenerator"
@@ -110,9 +113,15 @@ const (
 	// WorkspaceServiceGetFlowStateProcedure is the fully-qualified name of the WorkspaceService's
 	// GetFlowState RPC.
 	WorkspaceServiceGetFlowStateProcedure = "/sdl.v1.WorkspaceService/GetFlowState"
+	// WorkspaceServiceGetFlowsProcedure is the fully-qualified name of the WorkspaceService's GetFlows
+	// RPC.
+	WorkspaceServiceGetFlowsProcedure = "/sdl.v1.WorkspaceService/GetFlows"
 	// WorkspaceServiceExecuteTraceProcedure is the fully-qualified name of the WorkspaceService's
 	// ExecuteTrace RPC.
 	WorkspaceServiceExecuteTraceProcedure = "/sdl.v1.WorkspaceService/ExecuteTrace"
+	// WorkspaceServiceStreamTracesProcedure is the fully-qualified name of the WorkspaceService's
+	// StreamTraces RPC.
+	WorkspaceServiceStreamTracesProcedure = "/sdl.v1.WorkspaceService/StreamTraces"
 	// WorkspaceServiceTraceAllPathsProcedure is the fully-qualified name of the WorkspaceService's
 	// TraceAllPaths RPC.
 	WorkspaceServiceTraceAllPathsProcedure = "/sdl.v1.WorkspaceService/TraceAllPaths"
@@ -125,6 +134,15 @@ const (
 	// WorkspaceServiceQueryMetricsProcedure is the fully-qualified name of the WorkspaceService's
 	// QueryMetrics RPC.
 	WorkspaceServiceQueryMetricsProcedure = "/sdl.v1.WorkspaceService/QueryMetrics"
+	// WorkspaceServiceGetMeasurementStatsProcedure is the fully-qualified name of the
+	// WorkspaceService's GetMeasurementStats RPC.
+	WorkspaceServiceGetMeasurementStatsProcedure = "/sdl.v1.WorkspaceService/GetMeasurementStats"
+	// WorkspaceServiceListRunsProcedure is the fully-qualified name of the WorkspaceService's ListRuns
+	// RPC.
+	WorkspaceServiceListRunsProcedure = "/sdl.v1.WorkspaceService/ListRuns"
+	// WorkspaceServiceSimulateProcedure is the fully-qualified name of the WorkspaceService's Simulate
+	// RPC.
+	WorkspaceServiceSimulateProcedure = "/sdl.v1.WorkspaceService/Simulate"
 )
 
 // WorkspaceServiceClient is a client for the sdl.v1.WorkspaceService service.
@@ -142,6 +160,8 @@ type WorkspaceServiceClient interface {
 	LoadFile(context.Context, *connect.Request[models.LoadFileRequest]) (*connect.Response[models.LoadFileResponse], error)
 	// Select the active system for simulation
 	UseSystem(context.Context, *connect.Request[models.UseSystemRequest]) (*connect.Response[models.UseSystemResponse], error)
+	// Versioned summary of the systems, components and enums of the loaded files
+	GetManifest(context.Context, *connect.Request[models.GetManifestRequest]) (*connect.Response[models.GetManifestResponse], error)
 	AddGenerator(context.Context, *connect.Request[models.AddGeneratorRequest]) (*connect.Response[models.AddGeneratorResponse], error)
 	UpdateGenerator(context.Context, *connect.Request[models.UpdateGeneratorRequest]) (*connect.Response[models.UpdateGeneratorResponse], error)
 	DeleteGenerator(context.Context, *connect.Request[models.DeleteGeneratorRequest]) (*connect.Response[models.DeleteGeneratorResponse], error)
@@ -158,11 +178,21 @@ type WorkspaceServiceClient interface {
 	EvaluateFlows(context.Context, *connect.Request[models.EvaluateFlowsRequest]) (*connect.Response[models.EvaluateFlowsResponse], error)
 	BatchSetParameters(context.Context, *connect.Request[models.BatchSetParametersRequest]) (*connect.Response[models.BatchSetParametersResponse], error)
 	GetFlowState(context.Context, *connect.Request[models.GetFlowStateRequest]) (*connect.Response[models.GetFlowStateResponse], error)
+	// Flow through every component method carrying traffic, with its service
+	// rate, utilization and mean latency.
+	GetFlows(context.Context, *connect.Request[models.GetFlowsRequest]) (*connect.Response[models.GetFlowsResponse], error)
 	ExecuteTrace(context.Context, *connect.Request[models.ExecuteTraceRequest]) (*connect.Response[models.ExecuteTraceResponse], error)
+	// Runs one traced call and streams its enter and exit events as they happen
+	StreamTraces(context.Context, *connect.Request[models.StreamTracesRequest]) (*connect.ServerStreamForClient[models.TraceEvent], error)
 	TraceAllPaths(context.Context, *connect.Request[models.TraceAllPathsRequest]) (*connect.Response[models.TraceAllPathsResponse], error)
 	GetSystemDiagram(context.Context, *connect.Request[models.GetSystemDiagramRequest]) (*connect.Response[models.GetSystemDiagramResponse], error)
 	GetUtilization(context.Context, *connect.Request[models.GetUtilizationRequest]) (*connect.Response[models.GetUtilizationResponse], error)
 	QueryMetrics(context.Context, *connect.Request[models.QueryMetricsRequest]) (*connect.Response[models.QueryMetricsResponse], error)
+	GetMeasurementStats(context.Context, *connect.Request[models.GetMeasurementStatsRequest]) (*connect.Response[models.GetMeasurementStatsResponse], error)
+	ListRuns(context.Context, *connect.Request[models.ListRunsRequest]) (*connect.Response[models.ListRunsResponse], error)
+	// Compiles and runs a self contained model in an ephemeral workspace,
+	// independent of any workspace's state.
+	Simulate(context.Context, *connect.Request[models.SimulateRequest]) (*connect.Response[models.SimulateResponse], error)
 }
 
 // NewWorkspaceServiceClient constructs a client for the sdl.v1.WorkspaceService service. By
@@ -230,6 +260,12 @@ func NewWorkspaceServiceClient(httpClient connect.HTTPClient, baseURL string, op
 			connect.WithSchema(workspaceServiceMethods.ByName("UseSystem")),
 			connect.WithClientOptions(opts...),
 		),
+		getManifest: connect.NewClient[models.GetManifestRequest, models.GetManifestResponse](
+			httpClient,
+			baseURL+WorkspaceServiceGetManifestProcedure,
+			connect.WithSchema(workspaceServiceMethods.ByName("GetManifest")),
+			connect.WithClientOptions(opts...),
+		),
 		addGenerator: connect.NewClient[models.AddGeneratorRequest, models.AddGeneratorResponse](
 			httpClient,
 			baseURL+WorkspaceServiceAddGeneratorProcedure,
@@ -326,12 +362,24 @@ func NewWorkspaceServiceClient(httpClient connect.HTTPClient, baseURL string, op
 			connect.WithSchema(workspaceServiceMethods.ByName("GetFlowState")),
 			connect.WithClientOptions(opts...),
 		),
+		getFlows: connect.NewClient[models.GetFlowsRequest, models.GetFlowsResponse](
+			httpClient,
+			baseURL+WorkspaceServiceGetFlowsProcedure,
+			connect.WithSchema(workspaceServiceMethods.ByName("GetFlows")),
+			connect.WithClientOptions(opts...),
+		),
 		executeTrace: connect.NewClient[models.ExecuteTraceRequest, models.ExecuteTraceResponse](
 			httpClient,
 			baseURL+WorkspaceServiceExecuteTraceProcedure,
 			connect.WithSchema(workspaceServiceMethods.ByName("ExecuteTrace")),
 			connect.WithClientOptions(opts...),
 		),
+		streamTraces: connect.NewClient[models.StreamTracesRequest, models.TraceEvent](
+			httpClient,
+			baseURL+WorkspaceServiceStreamTracesProcedure,
+			connect.WithSchema(workspaceServiceMethods.ByName("StreamTraces")),
+			connect.WithClientOptions(opts...),
+		),
 		traceAllPaths: connect.NewClient[models.TraceAllPathsRequest, models.TraceAllPathsResponse](
 			httpClient,
 			baseURL+WorkspaceServiceTraceAllPathsProcedure,
@@ -356,6 +404,24 @@ func NewWorkspaceServiceClient(httpClient connect.HTTPClient, baseURL string, op
 			connect.WithSchema(workspaceServiceMethods.ByName("QueryMetrics")),
 			connect.WithClientOptions(opts...),
 		),
+		getMeasurementStats: connect.NewClient[models.GetMeasurementStatsRequest, models.GetMeasurementStatsResponse](
+			httpClient,
+			baseURL+WorkspaceServiceGetMeasurementStatsProcedure,
+			connect.WithSchema(workspaceServiceMethods.ByName("GetMeasurementStats")),
+			connect.WithClientOptions(opts...),
+		),
+		listRuns: connect.NewClient[models.ListRunsRequest, models.ListRunsResponse](
+			httpClient,
+			baseURL+WorkspaceServiceListRunsProcedure,
+			connect.WithSchema(workspaceServiceMethods.ByName("ListRuns")),
+			connect.WithClientOptions(opts...),
+		),
+		simulate: connect.NewClient[models.SimulateRequest, models.SimulateResponse](
+			httpClient,
+			baseURL+WorkspaceServiceSimulateProcedure,
+			connect.WithSchema(workspaceServiceMethods.ByName("Simulate")),
+			connect.WithClientOptions(opts...),
+		),
 	}
 }
 
@@ -370,6 +436,7 @@ type workspaceServiceClient struct {
 	getAllDesignContents *connect.Client[models.GetAllDesignContentsRequest, models.GetAllDesignContentsResponse]
 	loadFile             *connect.Client[models.LoadFileRequest, models.LoadFileResponse]
 	useSystem            *connect.Client[models.UseSystemRequest, models.UseSystemResponse]
+	getManifest          *connect.Client[models.GetManifestRequest, models.GetManifestResponse]
 	addGenerator         *connect.Client[models.AddGeneratorRequest, models.AddGeneratorResponse]
 	updateGenerator      *connect.Client[models.UpdateGeneratorRequest, models.UpdateGeneratorResponse]
 	deleteGenerator      *connect.Client[models.DeleteGeneratorRequest, models.DeleteGeneratorResponse]
@@ -386,11 +453,16 @@ type workspaceServiceClient struct {
 	evaluateFlows        *connect.Client[models.EvaluateFlowsRequest, models.EvaluateFlowsResponse]
 	batchSetParameters   *connect.Client[models.BatchSetParametersRequest, models.BatchSetParametersResponse]
 	getFlowState         *connect.Client[models.GetFlowStateRequest, models.GetFlowStateResponse]
+	getFlows             *connect.Client[models.GetFlowsRequest, models.GetFlowsResponse]
 	executeTrace         *connect.Client[models.ExecuteTraceRequest, models.ExecuteTraceResponse]
+	streamTraces         *connect.Client[models.StreamTracesRequest, models.TraceEvent]
 	traceAllPaths        *connect.Client[models.TraceAllPathsRequest, models.TraceAllPathsResponse]
 	getSystemDiagram     *connect.Client[models.GetSystemDiagramRequest, models.GetSystemDiagramResponse]
 	getUtilization       *connect.Client[models.GetUtilizationRequest, models.GetUtilizationResponse]
 	queryMetrics         *connect.Client[models.QueryMetricsRequest, models.QueryMetricsResponse]
+	getMeasurementStats  *connect.Client[models.GetMeasurementStatsRequest, models.GetMeasurementStatsResponse]
+	listRuns             *connect.Client[models.ListRunsRequest, models.ListRunsResponse]
+	simulate             *connect.Client[models.SimulateRequest, models.SimulateResponse]
 }
 
 // CreateWorkspace calls sdl.v1.WorkspaceService.CreateWorkspace.
@@ -438,6 +510,11 @@ func (c *workspaceServiceClient) UseSystem(ctx context.Context, req *connect.Req
 	return c.useSystem.CallUnary(ctx, req)
 }
 
+// GetManifest calls sdl.v1.WorkspaceService.GetManifest.
+func (c *workspaceServiceClient) GetManifest(ctx context.Context, req *connect.Request[models.GetManifestRequest]) (*connect.Response[models.GetManifestResponse], error) {
+	return c.getManifest.CallUnary(ctx, req)
+}
+
 // AddGenerator calls sdl.v1.WorkspaceService.AddGenerator.
 func (c *workspaceServiceClient) AddGenerator(ctx context.Context, req *connect.Request[models.AddGeneratorRequest]) (*connect.Response[models.AddGeneratorResponse], error) {
 	return c.addGenerator.CallUnary(ctx, req)
@@ -518,11 +595,21 @@ func (c *workspaceServiceClient) GetFlowState(ctx context.Context, req *connect.
 	return c.getFlowState.CallUnary(ctx, req)
 }
 
+// GetFlows calls sdl.v1.WorkspaceService.GetFlows.
+func (c *workspaceServiceClient) GetFlows(ctx context.Context, req *connect.Request[models.GetFlowsRequest]) (*connect.Response[models.GetFlowsResponse], error) {
+	return c.getFlows.CallUnary(ctx, req)
+}
+
 // ExecuteTrace calls sdl.v1.WorkspaceService.ExecuteTrace.
 func (c *workspaceServiceClient) ExecuteTrace(ctx context.Context, req *connect.Request[models.ExecuteTraceRequest]) (*connect.Response[models.ExecuteTraceResponse], error) {
 	return c.executeTrace.CallUnary(ctx, req)
 }
 
+// StreamTraces calls sdl.v1.WorkspaceService.StreamTraces.
+func (c *workspaceServiceClient) StreamTraces(ctx context.Context, req *connect.Request[models.StreamTracesRequest]) (*connect.ServerStreamForClient[models.TraceEvent], error) {
+	return c.streamTraces.CallServerStream(ctx, req)
+}
+
 // TraceAllPaths calls sdl.v1.WorkspaceService.TraceAllPaths.
 func (c *workspaceServiceClient) TraceAllPaths(ctx context.Context, req *connect.Request[models.TraceAllPathsRequest]) (*connect.Response[models.TraceAllPathsResponse], error) {
 	return c.traceAllPaths.CallUnary(ctx, req)
@@ -543,6 +630,21 @@ func (c *workspaceServiceClient) QueryMetrics(ctx context.Context, req *connect.
 	return c.queryMetrics.CallUnary(ctx, req)
 }
 
+// GetMeasurementStats calls sdl.v1.WorkspaceService.GetMeasurementStats.
+func (c *workspaceServiceClient) GetMeasurementStats(ctx context.Context, req *connect.Request[models.GetMeasurementStatsRequest]) (*connect.Response[models.GetMeasurementStatsResponse], error) {
+	return c.getMeasurementStats.CallUnary(ctx, req)
+}
+
+// ListRuns calls sdl.v1.WorkspaceService.ListRuns.
+func (c *workspaceServiceClient) ListRuns(ctx context.Context, req *connect.Request[models.ListRunsRequest]) (*connect.Response[models.ListRunsResponse], error) {
+	return c.listRuns.CallUnary(ctx, req)
+}
+
+// Simulate calls sdl.v1.WorkspaceService.Simulate.
+func (c *workspaceServiceClient) Simulate(ctx context.Context, req *connect.Request[models.SimulateRequest]) (*connect.Response[models.SimulateResponse], error) {
+	return c.simulate.CallUnary(ctx, req)
+}
+
 // WorkspaceServiceHandler is an implementation of the sdl.v1.WorkspaceService service.
 type WorkspaceServiceHandler interface {
 	CreateWorkspace(context.Context, *connect.Request[models.CreateWorkspaceRequest]) (*connect.Response[models.CreateWorkspaceResponse], error)
@@ -558,6 +660,8 @@ type WorkspaceServiceHandler interface {
 	LoadFile(context.Context, *connect.Request[models.LoadFileRequest]) (*connect.Response[models.LoadFileResponse], error)
 	// Select the active system for simulation
 	UseSystem(context.Context, *connect.Request[models.UseSystemRequest]) (*connect.Response[models.UseSystemResponse], error)
+	// Versioned summary of the systems, components and enums of the loaded files
+	GetManifest(context.Context, *connect.Request[models.GetManifestRequest]) (*connect.Response[models.GetManifestResponse], error)
 	AddGenerator(context.Context, *connect.Request[models.AddGeneratorRequest]) (*connect.Response[models.AddGeneratorResponse], error)
 	UpdateGenerator(context.Context, *connect.Request[models.UpdateGeneratorRequest]) (*connect.Response[models.UpdateGeneratorResponse], error)
 	DeleteGenerator(context.Context, *connect.Request[models.DeleteGeneratorRequest]) (*connect.Response[models.DeleteGeneratorResponse], error)
@@ -574,11 +678,21 @@ type WorkspaceServiceHandler interface {
 	EvaluateFlows(context.Context, *connect.Request[models.EvaluateFlowsRequest]) (*connect.Response[models.EvaluateFlowsResponse], error)
 	BatchSetParameters(context.Context, *connect.Request[models.BatchSetParametersRequest]) (*connect.Response[models.BatchSetParametersResponse], error)
 	GetFlowState(context.Context, *connect.Request[models.GetFlowStateRequest]) (*connect.Response[models.GetFlowStateResponse], error)
+	// Flow through every component method carrying traffic, with its service
+	// rate, utilization and mean latency.
+	GetFlows(context.Context, *connect.Request[models.GetFlowsRequest]) (*connect.Response[models.GetFlowsResponse], error)
 	ExecuteTrace(context.Context, *connect.Request[models.ExecuteTraceRequest]) (*connect.Response[models.ExecuteTraceResponse], error)
+	// Runs one traced call and streams its enter and exit events as they happen
+	StreamTraces(context.Context, *connect.Request[models.StreamTracesRequest], *connect.ServerStream[models.TraceEvent]) error
 	TraceAllPaths(context.Context, *connect.Request[models.TraceAllPathsRequest]) (*connect.Response[models.TraceAllPathsResponse], error)
 	GetSystemDiagram(context.Context, *connect.Request[models.GetSystemDiagramRequest]) (*connect.Response[models.GetSystemDiagramResponse], error)
 	GetUtilization(context.Context, *connect.Request[models.GetUtilizationRequest]) (*connect.Response[models.GetUtilizationResponse], error)
 	QueryMetrics(context.Context, *connect.Request[models.QueryMetricsRequest]) (*connect.Response[models.QueryMetricsResponse], error)
+	GetMeasurementStats(context.Context, *connect.Request[models.GetMeasurementStatsRequest]) (*connect.Response[models.GetMeasurementStatsResponse], error)
+	ListRuns(context.Context, *connect.Request[models.ListRunsRequest]) (*connect.Response[models.ListRunsResponse], error)
+	// Compiles and runs a self contained model in an ephemeral workspace,
+	// independent of any workspace's state.
+	Simulate(context.Context, *connect.Request[models.SimulateRequest]) (*connect.Response[models.SimulateResponse], error)
 }
 
 // NewWorkspaceServiceHandler builds an HTTP handler from the service implementation. It returns the
@@ -642,6 +756,12 @@ func NewWorkspaceServiceHandler(svc WorkspaceServiceHandler, opts ...connect.Han
 		connect.WithSchema(workspaceServiceMethods.ByName("UseSystem")),
 		connect.WithHandlerOptions(opts...),
 	)
+	workspaceServiceGetManifestHandler := connect.NewUnaryHandler(
+		WorkspaceServiceGetManifestProcedure,
+		svc.GetManifest,
+		connect.WithSchema(workspaceServiceMethods.ByName("GetManifest")),
+		connect.WithHandlerOptions(opts...),
+	)
 	workspaceServiceAddGeneratorHandler := connect.NewUnaryHandler(
 		WorkspaceServiceAddGeneratorProcedure,
 		svc.AddGenerator,
@@ -738,12 +858,24 @@ func NewWorkspaceServiceHandler(svc WorkspaceServiceHandler, opts ...connect.Han
 		connect.WithSchema(workspaceServiceMethods.ByName("GetFlowState")),
 		connect.WithHandlerOptions(opts...),
 	)
+	workspaceServiceGetFlowsHandler := connect.NewUnaryHandler(
+		WorkspaceServiceGetFlowsProcedure,
+		svc.GetFlows,
+		connect.WithSchema(workspaceServiceMethods.ByName("GetFlows")),
+		connect.WithHandlerOptions(opts...),
+	)
 	workspaceServiceExecuteTraceHandler := connect.NewUnaryHandler(
 		WorkspaceServiceExecuteTraceProcedure,
 		svc.ExecuteTrace,
 		connect.WithSchema(workspaceServiceMethods.ByName("ExecuteTrace")),
 		connect.WithHandlerOptions(opts...),
 	)
+	workspaceServiceStreamTracesHandler := connect.NewServerStreamHandler(
+		WorkspaceServiceStreamTracesProcedure,
+		svc.StreamTraces,
+		connect.WithSchema(workspaceServiceMethods.ByName("StreamTraces")),
+		connect.WithHandlerOptions(opts...),
+	)
 	workspaceServiceTraceAllPathsHandler := connect.NewUnaryHandler(
 		WorkspaceServiceTraceAllPathsProcedure,
 		svc.TraceAllPaths,
@@ -768,6 +900,24 @@ func NewWorkspaceServiceHandler(svc WorkspaceServiceHandler, opts ...connect.Han
 		connect.WithSchema(workspaceServiceMethods.ByName("QueryMetrics")),
 		connect.WithHandlerOptions(opts...),
 	)
+	workspaceServiceGetMeasurementStatsHandler := connect.NewUnaryHandler(
+		WorkspaceServiceGetMeasurementStatsProcedure,
+		svc.GetMeasurementStats,
+		connect.WithSchema(workspaceServiceMethods.ByName("GetMeasurementStats")),
+		connect.WithHandlerOptions(opts...),
+	)
+	workspaceServiceListRunsHandler := connect.NewUnaryHandler(
+		WorkspaceServiceListRunsProcedure,
+		svc.ListRuns,
+		connect.WithSchema(workspaceServiceMethods.ByName("ListRuns")),
+		connect.WithHandlerOptions(opts...),
+	)
+	workspaceServiceSimulateHandler := connect.NewUnaryHandler(
+		WorkspaceServiceSimulateProcedure,
+		svc.Simulate,
+		connect.WithSchema(workspaceServiceMethods.ByName("Simulate")),
+		connect.WithHandlerOptions(opts...),
+	)
 	return "/sdl.v1.WorkspaceService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case WorkspaceServiceCreateWorkspaceProcedure:
@@ -788,6 +938,8 @@ func NewWorkspaceServiceHandler(svc WorkspaceServiceHandler, opts ...connect.Han
 			workspaceServiceLoadFileHandler.ServeHTTP(w, r)
 		case WorkspaceServiceUseSystemProcedure:
 			workspaceServiceUseSystemHandler.ServeHTTP(w, r)
+		case WorkspaceServiceGetManifestProcedure:
+			workspaceServiceGetManifestHandler.ServeHTTP(w, r)
 		case WorkspaceServiceAddGeneratorProcedure:
 			workspaceServiceAddGeneratorHandler.ServeHTTP(w, r)
 		case WorkspaceServiceUpdateGeneratorProcedure:
@@ -820,8 +972,12 @@ func NewWorkspaceServiceHandler(svc WorkspaceServiceHandler, opts ...connect.Han
 			workspaceServiceBatchSetParametersHandler.ServeHTTP(w, r)
 		case WorkspaceServiceGetFlowStateProcedure:
 			workspaceServiceGetFlowStateHandler.ServeHTTP(w, r)
+		case WorkspaceServiceGetFlowsProcedure:
+			workspaceServiceGetFlowsHandler.ServeHTTP(w, r)
 		case WorkspaceServiceExecuteTraceProcedure:
 			workspaceServiceExecuteTraceHandler.ServeHTTP(w, r)
+		case WorkspaceServiceStreamTracesProcedure:
+			workspaceServiceStreamTracesHandler.ServeHTTP(w, r)
 		case WorkspaceServiceTraceAllPathsProcedure:
 			workspaceServiceTraceAllPathsHandler.ServeHTTP(w, r)
 		case WorkspaceServiceGetSystemDiagramProcedure:
@@ -830,6 +986,12 @@ func NewWorkspaceServiceHandler(svc WorkspaceServiceHandler, opts ...connect.Han
 			workspaceServiceGetUtilizationHandler.ServeHTTP(w, r)
 		case WorkspaceServiceQueryMetricsProcedure:
 			workspaceServiceQueryMetricsHandler.ServeHTTP(w, r)
+		case WorkspaceServiceGetMeasurementStatsProcedure:
+			workspaceServiceGetMeasurementStatsHandler.ServeHTTP(w, r)
+		case WorkspaceServiceListRunsProcedure:
+			workspaceServiceListRunsHandler.ServeHTTP(w, r)
+		case WorkspaceServiceSimulateProcedure:
+			workspaceServiceSimulateHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -875,6 +1037,10 @@ func (UnimplementedWorkspaceServiceHandler) UseSystem(context.Context, *connect.
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("sdl.v1.WorkspaceService.UseSystem is not implemented"))
 }
 
+func (UnimplementedWorkspaceServiceHandler) GetManifest(context.Context, *connect.Request[models.GetManifestRequest]) (*connect.Response[models.GetManifestResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("sdl.v1.WorkspaceService.GetManifest is not implemented"))
+}
+
 func (UnimplementedWorkspaceServiceHandler) AddGenerator(context.Context, *connect.Request[models.AddGeneratorRequest]) (*connect.Response[models.AddGeneratorResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("sdl.v1.WorkspaceService.AddGenerator is not implemented"))
 }
@@ -939,10 +1105,18 @@ func (UnimplementedWorkspaceServiceHandler) GetFlowState(context.Context, *conne
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("sdl.v1.WorkspaceService.GetFlowState is not implemented"))
 }
 
+func (UnimplementedWorkspaceServiceHandler) GetFlows(context.Context, *connect.Request[models.GetFlowsRequest]) (*connect.Response[models.GetFlowsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("sdl.v1.WorkspaceService.GetFlows is not implemented"))
+}
+
 func (UnimplementedWorkspaceServiceHandler) ExecuteTrace(context.Context, *connect.Request[models.ExecuteTraceRequest]) (*connect.Response[models.ExecuteTraceResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("sdl.v1.WorkspaceService.ExecuteTrace is not implemented"))
 }
 
+func (UnimplementedWorkspaceServiceHandler) StreamTraces(context.Context, *connect.Request[models.StreamTracesRequest], *connect.ServerStream[models.TraceEvent]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("sdl.v1.WorkspaceService.StreamTraces is not implemented"))
+}
+
 func (UnimplementedWorkspaceServiceHandler) TraceAllPaths(context.Context, *connect.Request[models.TraceAllPathsRequest]) (*connect.Response[models.TraceAllPathsResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("sdl.v1.WorkspaceService.TraceAllPaths is not implemented"))
 }
@@ -958,3 +1132,15 @@ func (UnimplementedWorkspaceServiceHandler) GetUtilization(context.Context, *con
 func (UnimplementedWorkspaceServiceHandler) QueryMetrics(context.Context, *connect.Request[models.QueryMetricsRequest]) (*connect.Response[models.QueryMetricsResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("sdl.v1.WorkspaceService.QueryMetrics is not implemented"))
 }
+
+func (UnimplementedWorkspaceServiceHandler) GetMeasurementStats(context.Context, *connect.Request[models.GetMeasurementStatsRequest]) (*connect.Response[models.GetMeasurementStatsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("sdl.v1.WorkspaceService.GetMeasurementStats is not implemented"))
+}
+
+func (UnimplementedWorkspaceServiceHandler) ListRuns(context.Context, *connect.Request[models.ListRunsRequest]) (*connect.Response[models.ListRunsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("sdl.v1.WorkspaceService.ListRuns is not implemented"))
+}
+
+func (UnimplementedWorkspaceServiceHandler) Simulate(context.Context, *connect.Request[models.SimulateRequest]) (*connect.Response[models.SimulateResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("sdl.v1.WorkspaceService.Simulate is not implemented"))
+}
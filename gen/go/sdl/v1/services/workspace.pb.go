@@ -27,7 +27,7 @@ var File_sdl_v1_services_workspace_proto protoreflect.FileDescriptor
 
 const file_sdl_v1_services_workspace_proto_rawDesc = "" +
 	"\n" +
-	"\x1fsdl/v1/services/workspace.proto\x12\x06sdl.v1\x1a\x1asdl/v1/models/models.proto\x1a%sdl/v1/models/workspace_service.proto\x1a\"sdl/v1/models/canvas_service.proto\x1a\x1cgoogle/api/annotations.proto2\x86 \n" +
+	"\x1fsdl/v1/services/workspace.proto\x12\x06sdl.v1\x1a\x1asdl/v1/models/models.proto\x1a%sdl/v1/models/workspace_service.proto\x1a\"sdl/v1/models/canvas_service.proto\x1a\x1cgoogle/api/annotations.proto2\xd0%\n" +
 	"\x10WorkspaceService\x12m\n" +
 	"\x0fCreateWorkspace\x12\x1e.sdl.v1.CreateWorkspaceRequest\x1a\x1f.sdl.v1.CreateWorkspaceResponse\"\x19\x82\xd3\xe4\x93\x02\x13:\x01*\"\x0e/v1/workspaces\x12f\n" +
 	"\fGetWorkspace\x12\x1b.sdl.v1.GetWorkspaceRequest\x1a\x1c.sdl.v1.GetWorkspaceResponse\"\x1b\x82\xd3\xe4\x93\x02\x15\x12\x13/v1/workspaces/{id}\x12g\n" +
@@ -37,7 +37,8 @@ const file_sdl_v1_services_workspace_proto_rawDesc = "" +
 	"\x10GetDesignContent\x12\x1f.sdl.v1.GetDesignContentRequest\x1a .sdl.v1.GetDesignContentResponse\"C\x82\xd3\xe4\x93\x02=\x12;/v1/workspaces/{workspace_id}/designs/{design_name}/content\x12\x99\x01\n" +
 	"\x14GetAllDesignContents\x12#.sdl.v1.GetAllDesignContentsRequest\x1a$.sdl.v1.GetAllDesignContentsResponse\"6\x82\xd3\xe4\x93\x020\x12./v1/workspaces/{workspace_id}/designs/contents\x12t\n" +
 	"\bLoadFile\x12\x17.sdl.v1.LoadFileRequest\x1a\x18.sdl.v1.LoadFileResponse\"5\x82\xd3\xe4\x93\x02/:\x01*\"*/v1/workspaces/{workspace_id}/actions:load\x12v\n" +
-	"\tUseSystem\x12\x18.sdl.v1.UseSystemRequest\x1a\x19.sdl.v1.UseSystemResponse\"4\x82\xd3\xe4\x93\x02.:\x01*\")/v1/workspaces/{workspace_id}/actions:use\x12~\n" +
+	"\tUseSystem\x12\x18.sdl.v1.UseSystemRequest\x1a\x19.sdl.v1.UseSystemResponse\"4\x82\xd3\xe4\x93\x02.:\x01*\")/v1/workspaces/{workspace_id}/actions:use\x12v\n" +
+	"\vGetManifest\x12\x1a.sdl.v1.GetManifestRequest\x1a\x1b.sdl.v1.GetManifestResponse\".\x82\xd3\xe4\x93\x02(\x12&/v1/workspaces/{workspace_id}/manifest\x12~\n" +
 	"\fAddGenerator\x12\x1b.sdl.v1.AddGeneratorRequest\x1a\x1c.sdl.v1.AddGeneratorResponse\"3\x82\xd3\xe4\x93\x02-:\x01*\"(/v1/workspaces/{workspace_id}/generators\x12\x98\x01\n" +
 	"\x0fUpdateGenerator\x12\x1e.sdl.v1.UpdateGeneratorRequest\x1a\x1f.sdl.v1.UpdateGeneratorResponse\"D\x82\xd3\xe4\x93\x02>:\x01*29/v1/workspaces/{workspace_id}/generators/{generator.name}\x12\x95\x01\n" +
 	"\x0fDeleteGenerator\x12\x1e.sdl.v1.DeleteGeneratorRequest\x1a\x1f.sdl.v1.DeleteGeneratorResponse\"A\x82\xd3\xe4\x93\x02;*9/v1/workspaces/{workspace_id}/generators/{generator_name}\x12\x81\x01\n" +
@@ -53,12 +54,17 @@ const file_sdl_v1_services_workspace_proto_rawDesc = "" +
 	"\rGetParameters\x12\x1c.sdl.v1.GetParametersRequest\x1a\x1d.sdl.v1.GetParametersResponse\"0\x82\xd3\xe4\x93\x02*\x12(/v1/workspaces/{workspace_id}/parameters\x12\x89\x01\n" +
 	"\rEvaluateFlows\x12\x1c.sdl.v1.EvaluateFlowsRequest\x1a\x1d.sdl.v1.EvaluateFlowsResponse\";\x82\xd3\xe4\x93\x025\x123/v1/workspaces/{workspace_id}/flows/{strategy}/eval\x12\x96\x01\n" +
 	"\x12BatchSetParameters\x12!.sdl.v1.BatchSetParametersRequest\x1a\".sdl.v1.BatchSetParametersResponse\"9\x82\xd3\xe4\x93\x023:\x01*\x1a./v1/workspaces/{workspace_id}/parameters:batch\x12~\n" +
-	"\fGetFlowState\x12\x1b.sdl.v1.GetFlowStateRequest\x1a\x1c.sdl.v1.GetFlowStateResponse\"3\x82\xd3\xe4\x93\x02-\x12+/v1/workspaces/{workspace_id}/flows/current\x12\x8b\x01\n" +
-	"\fExecuteTrace\x12\x1b.sdl.v1.ExecuteTraceRequest\x1a\x1c.sdl.v1.ExecuteTraceResponse\"@\x82\xd3\xe4\x93\x02:\x128/v1/workspaces/{workspace_id}/trace/{component}/{method}\x12\x8e\x01\n" +
+	"\fGetFlowState\x12\x1b.sdl.v1.GetFlowStateRequest\x1a\x1c.sdl.v1.GetFlowStateResponse\"3\x82\xd3\xe4\x93\x02-\x12+/v1/workspaces/{workspace_id}/flows/current\x12j\n" +
+	"\bGetFlows\x12\x17.sdl.v1.GetFlowsRequest\x1a\x18.sdl.v1.GetFlowsResponse\"+\x82\xd3\xe4\x93\x02%\x12#/v1/workspaces/{workspace_id}/flows\x12\x8b\x01\n" +
+	"\fExecuteTrace\x12\x1b.sdl.v1.ExecuteTraceRequest\x1a\x1c.sdl.v1.ExecuteTraceResponse\"@\x82\xd3\xe4\x93\x02:\x128/v1/workspaces/{workspace_id}/trace/{component}/{method}\x12\x8a\x01\n" +
+	"\fStreamTraces\x12\x1b.sdl.v1.StreamTracesRequest\x1a\x12.sdl.v1.TraceEvent\"G\x82\xd3\xe4\x93\x02A\x12?/v1/workspaces/{workspace_id}/trace/{component}/{method}:stream0\x01\x12\x8e\x01\n" +
 	"\rTraceAllPaths\x12\x1c.sdl.v1.TraceAllPathsRequest\x1a\x1d.sdl.v1.TraceAllPathsResponse\"@\x82\xd3\xe4\x93\x02:\x128/v1/workspaces/{workspace_id}/paths/{component}/{method}\x12\x84\x01\n" +
 	"\x10GetSystemDiagram\x12\x1f.sdl.v1.GetSystemDiagramRequest\x1a .sdl.v1.GetSystemDiagramResponse\"-\x82\xd3\xe4\x93\x02'\x12%/v1/workspaces/{workspace_id}/diagram\x12\x82\x01\n" +
 	"\x0eGetUtilization\x12\x1d.sdl.v1.GetUtilizationRequest\x1a\x1e.sdl.v1.GetUtilizationResponse\"1\x82\xd3\xe4\x93\x02+\x12)/v1/workspaces/{workspace_id}/utilization\x12\x8c\x01\n" +
-	"\fQueryMetrics\x12\x1b.sdl.v1.QueryMetricsRequest\x1a\x1c.sdl.v1.QueryMetricsResponse\"A\x82\xd3\xe4\x93\x02;\x129/v1/workspaces/{workspace_id}/metrics/{metric_name}/queryB\x89\x01\n" +
+	"\fQueryMetrics\x12\x1b.sdl.v1.QueryMetricsRequest\x1a\x1c.sdl.v1.QueryMetricsResponse\"A\x82\xd3\xe4\x93\x02;\x129/v1/workspaces/{workspace_id}/metrics/{metric_name}/query\x12\x93\x01\n" +
+	"\x13GetMeasurementStats\x12\".sdl.v1.GetMeasurementStatsRequest\x1a#.sdl.v1.GetMeasurementStatsResponse\"3\x82\xd3\xe4\x93\x02-\x12+/v1/workspaces/{workspace_id}/metrics/stats\x12i\n" +
+	"\bListRuns\x12\x17.sdl.v1.ListRunsRequest\x1a\x18.sdl.v1.ListRunsResponse\"*\x82\xd3\xe4\x93\x02$\x12\"/v1/workspaces/{workspace_id}/runs\x12V\n" +
+	"\bSimulate\x12\x17.sdl.v1.SimulateRequest\x1a\x18.sdl.v1.SimulateResponse\"\x17\x82\xd3\xe4\x93\x02\x11:\x01*\"\f/v1/simulateB\x89\x01\n" +
 	"\n" +
 	"com.sdl.v1B\x0eWorkspaceProtoP\x01Z2github.com/panyam/sdl/gen/go/sdl/v1/services;sdlv1\xa2\x02\x03SXX\xaa\x02\x06Sdl.V1\xca\x02\x06Sdl\\V1\xe2\x02\x12Sdl\\V1\\GPBMetadata\xea\x02\aSdl::V1b\x06proto3"
 
@@ -72,57 +78,69 @@ var file_sdl_v1_services_workspace_proto_goTypes = []any{
 	(*models.GetAllDesignContentsRequest)(nil),  // 6: sdl.v1.GetAllDesignContentsRequest
 	(*models.LoadFileRequest)(nil),              // 7: sdl.v1.LoadFileRequest
 	(*models.UseSystemRequest)(nil),             // 8: sdl.v1.UseSystemRequest
-	(*models.AddGeneratorRequest)(nil),          // 9: sdl.v1.AddGeneratorRequest
-	(*models.UpdateGeneratorRequest)(nil),       // 10: sdl.v1.UpdateGeneratorRequest
-	(*models.DeleteGeneratorRequest)(nil),       // 11: sdl.v1.DeleteGeneratorRequest
-	(*models.ListGeneratorsRequest)(nil),        // 12: sdl.v1.ListGeneratorsRequest
-	(*models.StartGeneratorRequest)(nil),        // 13: sdl.v1.StartGeneratorRequest
-	(*models.StopGeneratorRequest)(nil),         // 14: sdl.v1.StopGeneratorRequest
-	(*models.StartAllGeneratorsRequest)(nil),    // 15: sdl.v1.StartAllGeneratorsRequest
-	(*models.StopAllGeneratorsRequest)(nil),     // 16: sdl.v1.StopAllGeneratorsRequest
-	(*models.AddMetricRequest)(nil),             // 17: sdl.v1.AddMetricRequest
-	(*models.DeleteMetricRequest)(nil),          // 18: sdl.v1.DeleteMetricRequest
-	(*models.ListMetricsRequest)(nil),           // 19: sdl.v1.ListMetricsRequest
-	(*models.SetParameterRequest)(nil),          // 20: sdl.v1.SetParameterRequest
-	(*models.GetParametersRequest)(nil),         // 21: sdl.v1.GetParametersRequest
-	(*models.EvaluateFlowsRequest)(nil),         // 22: sdl.v1.EvaluateFlowsRequest
-	(*models.BatchSetParametersRequest)(nil),    // 23: sdl.v1.BatchSetParametersRequest
-	(*models.GetFlowStateRequest)(nil),          // 24: sdl.v1.GetFlowStateRequest
-	(*models.ExecuteTraceRequest)(nil),          // 25: sdl.v1.ExecuteTraceRequest
-	(*models.TraceAllPathsRequest)(nil),         // 26: sdl.v1.TraceAllPathsRequest
-	(*models.GetSystemDiagramRequest)(nil),      // 27: sdl.v1.GetSystemDiagramRequest
-	(*models.GetUtilizationRequest)(nil),        // 28: sdl.v1.GetUtilizationRequest
-	(*models.QueryMetricsRequest)(nil),          // 29: sdl.v1.QueryMetricsRequest
-	(*models.CreateWorkspaceResponse)(nil),      // 30: sdl.v1.CreateWorkspaceResponse
-	(*models.GetWorkspaceResponse)(nil),         // 31: sdl.v1.GetWorkspaceResponse
-	(*models.ListWorkspacesResponse)(nil),       // 32: sdl.v1.ListWorkspacesResponse
-	(*models.DeleteWorkspaceResponse)(nil),      // 33: sdl.v1.DeleteWorkspaceResponse
-	(*models.UpdateWorkspaceResponse)(nil),      // 34: sdl.v1.UpdateWorkspaceResponse
-	(*models.GetDesignContentResponse)(nil),     // 35: sdl.v1.GetDesignContentResponse
-	(*models.GetAllDesignContentsResponse)(nil), // 36: sdl.v1.GetAllDesignContentsResponse
-	(*models.LoadFileResponse)(nil),             // 37: sdl.v1.LoadFileResponse
-	(*models.UseSystemResponse)(nil),            // 38: sdl.v1.UseSystemResponse
-	(*models.AddGeneratorResponse)(nil),         // 39: sdl.v1.AddGeneratorResponse
-	(*models.UpdateGeneratorResponse)(nil),      // 40: sdl.v1.UpdateGeneratorResponse
-	(*models.DeleteGeneratorResponse)(nil),      // 41: sdl.v1.DeleteGeneratorResponse
-	(*models.ListGeneratorsResponse)(nil),       // 42: sdl.v1.ListGeneratorsResponse
-	(*models.StartGeneratorResponse)(nil),       // 43: sdl.v1.StartGeneratorResponse
-	(*models.StopGeneratorResponse)(nil),        // 44: sdl.v1.StopGeneratorResponse
-	(*models.StartAllGeneratorsResponse)(nil),   // 45: sdl.v1.StartAllGeneratorsResponse
-	(*models.StopAllGeneratorsResponse)(nil),    // 46: sdl.v1.StopAllGeneratorsResponse
-	(*models.AddMetricResponse)(nil),            // 47: sdl.v1.AddMetricResponse
-	(*models.DeleteMetricResponse)(nil),         // 48: sdl.v1.DeleteMetricResponse
-	(*models.ListMetricsResponse)(nil),          // 49: sdl.v1.ListMetricsResponse
-	(*models.SetParameterResponse)(nil),         // 50: sdl.v1.SetParameterResponse
-	(*models.GetParametersResponse)(nil),        // 51: sdl.v1.GetParametersResponse
-	(*models.EvaluateFlowsResponse)(nil),        // 52: sdl.v1.EvaluateFlowsResponse
-	(*models.BatchSetParametersResponse)(nil),   // 53: sdl.v1.BatchSetParametersResponse
-	(*models.GetFlowStateResponse)(nil),         // 54: sdl.v1.GetFlowStateResponse
-	(*models.ExecuteTraceResponse)(nil),         // 55: sdl.v1.ExecuteTraceResponse
-	(*models.TraceAllPathsResponse)(nil),        // 56: sdl.v1.TraceAllPathsResponse
-	(*models.GetSystemDiagramResponse)(nil),     // 57: sdl.v1.GetSystemDiagramResponse
-	(*models.GetUtilizationResponse)(nil),       // 58: sdl.v1.GetUtilizationResponse
-	(*models.QueryMetricsResponse)(nil),         // 59: sdl.v1.QueryMetricsResponse
+	(*models.GetManifestRequest)(nil),           // 9: sdl.v1.GetManifestRequest
+	(*models.AddGeneratorRequest)(nil),          // 10: sdl.v1.AddGeneratorRequest
+	(*models.UpdateGeneratorRequest)(nil),       // 11: sdl.v1.UpdateGeneratorRequest
+	(*models.DeleteGeneratorRequest)(nil),       // 12: sdl.v1.DeleteGeneratorRequest
+	(*models.ListGeneratorsRequest)(nil),        // 13: sdl.v1.ListGeneratorsRequest
+	(*models.StartGeneratorRequest)(nil),        // 14: sdl.v1.StartGeneratorRequest
+	(*models.StopGeneratorRequest)(nil),         // 15: sdl.v1.StopGeneratorRequest
+	(*models.StartAllGeneratorsRequest)(nil),    // 16: sdl.v1.StartAllGeneratorsRequest
+	(*models.StopAllGeneratorsRequest)(nil),     // 17: sdl.v1.StopAllGeneratorsRequest
+	(*models.AddMetricRequest)(nil),             // 18: sdl.v1.AddMetricRequest
+	(*models.DeleteMetricRequest)(nil),          // 19: sdl.v1.DeleteMetricRequest
+	(*models.ListMetricsRequest)(nil),           // 20: sdl.v1.ListMetricsRequest
+	(*models.SetParameterRequest)(nil),          // 21: sdl.v1.SetParameterRequest
+	(*models.GetParametersRequest)(nil),         // 22: sdl.v1.GetParametersRequest
+	(*models.EvaluateFlowsRequest)(nil),         // 23: sdl.v1.EvaluateFlowsRequest
+	(*models.BatchSetParametersRequest)(nil),    // 24: sdl.v1.BatchSetParametersRequest
+	(*models.GetFlowStateRequest)(nil),          // 25: sdl.v1.GetFlowStateRequest
+	(*models.GetFlowsRequest)(nil),              // 26: sdl.v1.GetFlowsRequest
+	(*models.ExecuteTraceRequest)(nil),          // 27: sdl.v1.ExecuteTraceRequest
+	(*models.StreamTracesRequest)(nil),          // 28: sdl.v1.StreamTracesRequest
+	(*models.TraceAllPathsRequest)(nil),         // 29: sdl.v1.TraceAllPathsRequest
+	(*models.GetSystemDiagramRequest)(nil),      // 30: sdl.v1.GetSystemDiagramRequest
+	(*models.GetUtilizationRequest)(nil),        // 31: sdl.v1.GetUtilizationRequest
+	(*models.QueryMetricsRequest)(nil),          // 32: sdl.v1.QueryMetricsRequest
+	(*models.GetMeasurementStatsRequest)(nil),   // 33: sdl.v1.GetMeasurementStatsRequest
+	(*models.ListRunsRequest)(nil),              // 34: sdl.v1.ListRunsRequest
+	(*models.SimulateRequest)(nil),              // 35: sdl.v1.SimulateRequest
+	(*models.CreateWorkspaceResponse)(nil),      // 36: sdl.v1.CreateWorkspaceResponse
+	(*models.GetWorkspaceResponse)(nil),         // 37: sdl.v1.GetWorkspaceResponse
+	(*models.ListWorkspacesResponse)(nil),       // 38: sdl.v1.ListWorkspacesResponse
+	(*models.DeleteWorkspaceResponse)(nil),      // 39: sdl.v1.DeleteWorkspaceResponse
+	(*models.UpdateWorkspaceResponse)(nil),      // 40: sdl.v1.UpdateWorkspaceResponse
+	(*models.GetDesignContentResponse)(nil),     // 41: sdl.v1.GetDesignContentResponse
+	(*models.GetAllDesignContentsResponse)(nil), // 42: sdl.v1.GetAllDesignContentsResponse
+	(*models.LoadFileResponse)(nil),             // 43: sdl.v1.LoadFileResponse
+	(*models.UseSystemResponse)(nil),            // 44: sdl.v1.UseSystemResponse
+	(*models.GetManifestResponse)(nil),          // 45: sdl.v1.GetManifestResponse
+	(*models.AddGeneratorResponse)(nil),         // 46: sdl.v1.AddGeneratorResponse
+	(*models.UpdateGeneratorResponse)(nil),      // 47: sdl.v1.UpdateGeneratorResponse
+	(*models.DeleteGeneratorResponse)(nil),      // 48: sdl.v1.DeleteGeneratorResponse
+	(*models.ListGeneratorsResponse)(nil),       // 49: sdl.v1.ListGeneratorsResponse
+	(*models.StartGeneratorResponse)(nil),       // 50: sdl.v1.StartGeneratorResponse
+	(*models.StopGeneratorResponse)(nil),        // 51: sdl.v1.StopGeneratorResponse
+	(*models.StartAllGeneratorsResponse)(nil),   // 52: sdl.v1.StartAllGeneratorsResponse
+	(*models.StopAllGeneratorsResponse)(nil),    // 53: sdl.v1.StopAllGeneratorsResponse
+	(*models.AddMetricResponse)(nil),            // 54: sdl.v1.AddMetricResponse
+	(*models.DeleteMetricResponse)(nil),         // 55: sdl.v1.DeleteMetricResponse
+	(*models.ListMetricsResponse)(nil),          // 56: sdl.v1.ListMetricsResponse
+	(*models.SetParameterResponse)(nil),         // 57: sdl.v1.SetParameterResponse
+	(*models.GetParametersResponse)(nil),        // 58: sdl.v1.GetParametersResponse
+	(*models.EvaluateFlowsResponse)(nil),        // 59: sdl.v1.EvaluateFlowsResponse
+	(*models.BatchSetParametersResponse)(nil),   // 60: sdl.v1.BatchSetParametersResponse
+	(*models.GetFlowStateResponse)(nil),         // 61: sdl.v1.GetFlowStateResponse
+	(*models.GetFlowsResponse)(nil),             // 62: sdl.v1.GetFlowsResponse
+	(*models.ExecuteTraceResponse)(nil),         // 63: sdl.v1.ExecuteTraceResponse
+	(*models.TraceEvent)(nil),                   // 64: sdl.v1.TraceEvent
+	(*models.TraceAllPathsResponse)(nil),        // 65: sdl.v1.TraceAllPathsResponse
+	(*models.GetSystemDiagramResponse)(nil),     // 66: sdl.v1.GetSystemDiagramResponse
+	(*models.GetUtilizationResponse)(nil),       // 67: sdl.v1.GetUtilizationResponse
+	(*models.QueryMetricsResponse)(nil),         // 68: sdl.v1.QueryMetricsResponse
+	(*models.GetMeasurementStatsResponse)(nil),  // 69: sdl.v1.GetMeasurementStatsResponse
+	(*models.ListRunsResponse)(nil),             // 70: sdl.v1.ListRunsResponse
+	(*models.SimulateResponse)(nil),             // 71: sdl.v1.SimulateResponse
 }
 var file_sdl_v1_services_workspace_proto_depIdxs = []int32{
 	0,  // 0: sdl.v1.WorkspaceService.CreateWorkspace:input_type -> sdl.v1.CreateWorkspaceRequest
@@ -134,59 +152,71 @@ var file_sdl_v1_services_workspace_proto_depIdxs = []int32{
 	6,  // 6: sdl.v1.WorkspaceService.GetAllDesignContents:input_type -> sdl.v1.GetAllDesignContentsRequest
 	7,  // 7: sdl.v1.WorkspaceService.LoadFile:input_type -> sdl.v1.LoadFileRequest
 	8,  // 8: sdl.v1.WorkspaceService.UseSystem:input_type -> sdl.v1.UseSystemRequest
-	9,  // 9: sdl.v1.WorkspaceService.AddGenerator:input_type -> sdl.v1.AddGeneratorRequest
-	10, // 10: sdl.v1.WorkspaceService.UpdateGenerator:input_type -> sdl.v1.UpdateGeneratorRequest
-	11, // 11: sdl.v1.WorkspaceService.DeleteGenerator:input_type -> sdl.v1.DeleteGeneratorRequest
-	12, // 12: sdl.v1.WorkspaceService.ListGenerators:input_type -> sdl.v1.ListGeneratorsRequest
-	13, // 13: sdl.v1.WorkspaceService.StartGenerator:input_type -> sdl.v1.StartGeneratorRequest
-	14, // 14: sdl.v1.WorkspaceService.StopGenerator:input_type -> sdl.v1.StopGeneratorRequest
-	15, // 15: sdl.v1.WorkspaceService.StartAllGenerators:input_type -> sdl.v1.StartAllGeneratorsRequest
-	16, // 16: sdl.v1.WorkspaceService.StopAllGenerators:input_type -> sdl.v1.StopAllGeneratorsRequest
-	17, // 17: sdl.v1.WorkspaceService.AddMetric:input_type -> sdl.v1.AddMetricRequest
-	18, // 18: sdl.v1.WorkspaceService.DeleteMetric:input_type -> sdl.v1.DeleteMetricRequest
-	19, // 19: sdl.v1.WorkspaceService.ListMetrics:input_type -> sdl.v1.ListMetricsRequest
-	20, // 20: sdl.v1.WorkspaceService.SetParameter:input_type -> sdl.v1.SetParameterRequest
-	21, // 21: sdl.v1.WorkspaceService.GetParameters:input_type -> sdl.v1.GetParametersRequest
-	22, // 22: sdl.v1.WorkspaceService.EvaluateFlows:input_type -> sdl.v1.EvaluateFlowsRequest
-	23, // 23: sdl.v1.WorkspaceService.BatchSetParameters:input_type -> sdl.v1.BatchSetParametersRequest
-	24, // 24: sdl.v1.WorkspaceService.GetFlowState:input_type -> sdl.v1.GetFlowStateRequest
-	25, // 25: sdl.v1.WorkspaceService.ExecuteTrace:input_type -> sdl.v1.ExecuteTraceRequest
-	26, // 26: sdl.v1.WorkspaceService.TraceAllPaths:input_type -> sdl.v1.TraceAllPathsRequest
-	27, // 27: sdl.v1.WorkspaceService.GetSystemDiagram:input_type -> sdl.v1.GetSystemDiagramRequest
-	28, // 28: sdl.v1.WorkspaceService.GetUtilization:input_type -> sdl.v1.GetUtilizationRequest
-	29, // 29: sdl.v1.WorkspaceService.QueryMetrics:input_type -> sdl.v1.QueryMetricsRequest
-	30, // 30: sdl.v1.WorkspaceService.CreateWorkspace:output_type -> sdl.v1.CreateWorkspaceResponse
-	31, // 31: sdl.v1.WorkspaceService.GetWorkspace:output_type -> sdl.v1.GetWorkspaceResponse
-	32, // 32: sdl.v1.WorkspaceService.ListWorkspaces:output_type -> sdl.v1.ListWorkspacesResponse
-	33, // 33: sdl.v1.WorkspaceService.DeleteWorkspace:output_type -> sdl.v1.DeleteWorkspaceResponse
-	34, // 34: sdl.v1.WorkspaceService.UpdateWorkspace:output_type -> sdl.v1.UpdateWorkspaceResponse
-	35, // 35: sdl.v1.WorkspaceService.GetDesignContent:output_type -> sdl.v1.GetDesignContentResponse
-	36, // 36: sdl.v1.WorkspaceService.GetAllDesignContents:output_type -> sdl.v1.GetAllDesignContentsResponse
-	37, // 37: sdl.v1.WorkspaceService.LoadFile:output_type -> sdl.v1.LoadFileResponse
-	38, // 38: sdl.v1.WorkspaceService.UseSystem:output_type -> sdl.v1.UseSystemResponse
-	39, // 39: sdl.v1.WorkspaceService.AddGenerator:output_type -> sdl.v1.AddGeneratorResponse
-	40, // 40: sdl.v1.WorkspaceService.UpdateGenerator:output_type -> sdl.v1.UpdateGeneratorResponse
-	41, // 41: sdl.v1.WorkspaceService.DeleteGenerator:output_type -> sdl.v1.DeleteGeneratorResponse
-	42, // 42: sdl.v1.WorkspaceService.ListGenerators:output_type -> sdl.v1.ListGeneratorsResponse
-	43, // 43: sdl.v1.WorkspaceService.StartGenerator:output_type -> sdl.v1.StartGeneratorResponse
-	44, // 44: sdl.v1.WorkspaceService.StopGenerator:output_type -> sdl.v1.StopGeneratorResponse
-	45, // 45: sdl.v1.WorkspaceService.StartAllGenerators:output_type -> sdl.v1.StartAllGeneratorsResponse
-	46, // 46: sdl.v1.WorkspaceService.StopAllGenerators:output_type -> sdl.v1.StopAllGeneratorsResponse
-	47, // 47: sdl.v1.WorkspaceService.AddMetric:output_type -> sdl.v1.AddMetricResponse
-	48, // 48: sdl.v1.WorkspaceService.DeleteMetric:output_type -> sdl.v1.DeleteMetricResponse
-	49, // 49: sdl.v1.WorkspaceService.ListMetrics:output_type -> sdl.v1.ListMetricsResponse
-	50, // 50: sdl.v1.WorkspaceService.SetParameter:output_type -> sdl.v1.SetParameterResponse
-	51, // 51: sdl.v1.WorkspaceService.GetParameters:output_type -> sdl.v1.GetParametersResponse
-	52, // 52: sdl.v1.WorkspaceService.EvaluateFlows:output_type -> sdl.v1.EvaluateFlowsResponse
-	53, // 53: sdl.v1.WorkspaceService.BatchSetParameters:output_type -> sdl.v1.BatchSetParametersResponse
-	54, // 54: sdl.v1.WorkspaceService.GetFlowState:output_type -> sdl.v1.GetFlowStateResponse
-	55, // 55: sdl.v1.WorkspaceService.ExecuteTrace:output_type -> sdl.v1.ExecuteTraceResponse
-	56, // 56: sdl.v1.WorkspaceService.TraceAllPaths:output_type -> sdl.v1.TraceAllPathsResponse
-	57, // 57: sdl.v1.WorkspaceService.GetSystemDiagram:output_type -> sdl.v1.GetSystemDiagramResponse
-	58, // 58: sdl.v1.WorkspaceService.GetUtilization:output_type -> sdl.v1.GetUtilizationResponse
-	59, // 59: sdl.v1.WorkspaceService.QueryMetrics:output_type -> sdl.v1.QueryMetricsResponse
-	30, // [30:60] is the sub-list for method output_type
-	0,  // [0:30] is the sub-list for method input_type
+	9,  // 9: sdl.v1.WorkspaceService.GetManifest:input_type -> sdl.v1.GetManifestRequest
+	10, // 10: sdl.v1.WorkspaceService.AddGenerator:input_type -> sdl.v1.AddGeneratorRequest
+	11, // 11: sdl.v1.WorkspaceService.UpdateGenerator:input_type -> sdl.v1.UpdateGeneratorRequest
+	12, // 12: sdl.v1.WorkspaceService.DeleteGenerator:input_type -> sdl.v1.DeleteGeneratorRequest
+	13, // 13: sdl.v1.WorkspaceService.ListGenerators:input_type -> sdl.v1.ListGeneratorsRequest
+	14, // 14: sdl.v1.WorkspaceService.StartGenerator:input_type -> sdl.v1.StartGeneratorRequest
+	15, // 15: sdl.v1.WorkspaceService.StopGenerator:input_type -> sdl.v1.StopGeneratorRequest
+	16, // 16: sdl.v1.WorkspaceService.StartAllGenerators:input_type -> sdl.v1.StartAllGeneratorsRequest
+	17, // 17: sdl.v1.WorkspaceService.StopAllGenerators:input_type -> sdl.v1.StopAllGeneratorsRequest
+	18, // 18: sdl.v1.WorkspaceService.AddMetric:input_type -> sdl.v1.AddMetricRequest
+	19, // 19: sdl.v1.WorkspaceService.DeleteMetric:input_type -> sdl.v1.DeleteMetricRequest
+	20, // 20: sdl.v1.WorkspaceService.ListMetrics:input_type -> sdl.v1.ListMetricsRequest
+	21, // 21: sdl.v1.WorkspaceService.SetParameter:input_type -> sdl.v1.SetParameterRequest
+	22, // 22: sdl.v1.WorkspaceService.GetParameters:input_type -> sdl.v1.GetParametersRequest
+	23, // 23: sdl.v1.WorkspaceService.EvaluateFlows:input_type -> sdl.v1.EvaluateFlowsRequest
+	24, // 24: sdl.v1.WorkspaceService.BatchSetParameters:input_type -> sdl.v1.BatchSetParametersRequest
+	25, // 25: sdl.v1.WorkspaceService.GetFlowState:input_type -> sdl.v1.GetFlowStateRequest
+	26, // 26: sdl.v1.WorkspaceService.GetFlows:input_type -> sdl.v1.GetFlowsRequest
+	27, // 27: sdl.v1.WorkspaceService.ExecuteTrace:input_type -> sdl.v1.ExecuteTraceRequest
+	28, // 28: sdl.v1.WorkspaceService.StreamTraces:input_type -> sdl.v1.StreamTracesRequest
+	29, // 29: sdl.v1.WorkspaceService.TraceAllPaths:input_type -> sdl.v1.TraceAllPathsRequest
+	30, // 30: sdl.v1.WorkspaceService.GetSystemDiagram:input_type -> sdl.v1.GetSystemDiagramRequest
+	31, // 31: sdl.v1.WorkspaceService.GetUtilization:input_type -> sdl.v1.GetUtilizationRequest
+	32, // 32: sdl.v1.WorkspaceService.QueryMetrics:input_type -> sdl.v1.QueryMetricsRequest
+	33, // 33: sdl.v1.WorkspaceService.GetMeasurementStats:input_type -> sdl.v1.GetMeasurementStatsRequest
+	34, // 34: sdl.v1.WorkspaceService.ListRuns:input_type -> sdl.v1.ListRunsRequest
+	35, // 35: sdl.v1.WorkspaceService.Simulate:input_type -> sdl.v1.SimulateRequest
+	36, // 36: sdl.v1.WorkspaceService.CreateWorkspace:output_type -> sdl.v1.CreateWorkspaceResponse
+	37, // 37: sdl.v1.WorkspaceService.GetWorkspace:output_type -> sdl.v1.GetWorkspaceResponse
+	38, // 38: sdl.v1.WorkspaceService.ListWorkspaces:output_type -> sdl.v1.ListWorkspacesResponse
+	39, // 39: sdl.v1.WorkspaceService.DeleteWorkspace:output_type -> sdl.v1.DeleteWorkspaceResponse
+	40, // 40: sdl.v1.WorkspaceService.UpdateWorkspace:output_type -> sdl.v1.UpdateWorkspaceResponse
+	41, // 41: sdl.v1.WorkspaceService.GetDesignContent:output_type -> sdl.v1.GetDesignContentResponse
+	42, // 42: sdl.v1.WorkspaceService.GetAllDesignContents:output_type -> sdl.v1.GetAllDesignContentsResponse
+	43, // 43: sdl.v1.WorkspaceService.LoadFile:output_type -> sdl.v1.LoadFileResponse
+	44, // 44: sdl.v1.WorkspaceService.UseSystem:output_type -> sdl.v1.UseSystemResponse
+	45, // 45: sdl.v1.WorkspaceService.GetManifest:output_type -> sdl.v1.GetManifestResponse
+	46, // 46: sdl.v1.WorkspaceService.AddGenerator:output_type -> sdl.v1.AddGeneratorResponse
+	47, // 47: sdl.v1.WorkspaceService.UpdateGenerator:output_type -> sdl.v1.UpdateGeneratorResponse
+	48, // 48: sdl.v1.WorkspaceService.DeleteGenerator:output_type -> sdl.v1.DeleteGeneratorResponse
+	49, // 49: sdl.v1.WorkspaceService.ListGenerators:output_type -> sdl.v1.ListGeneratorsResponse
+	50, // 50: sdl.v1.WorkspaceService.StartGenerator:output_type -> sdl.v1.StartGeneratorResponse
+	51, // 51: sdl.v1.WorkspaceService.StopGenerator:output_type -> sdl.v1.StopGeneratorResponse
+	52, // 52: sdl.v1.WorkspaceService.StartAllGenerators:output_type -> sdl.v1.StartAllGeneratorsResponse
+	53, // 53: sdl.v1.WorkspaceService.StopAllGenerators:output_type -> sdl.v1.StopAllGeneratorsResponse
+	54, // 54: sdl.v1.WorkspaceService.AddMetric:output_type -> sdl.v1.AddMetricResponse
+	55, // 55: sdl.v1.WorkspaceService.DeleteMetric:output_type -> sdl.v1.DeleteMetricResponse
+	56, // 56: sdl.v1.WorkspaceService.ListMetrics:output_type -> sdl.v1.ListMetricsResponse
+	57, // 57: sdl.v1.WorkspaceService.SetParameter:output_type -> sdl.v1.SetParameterResponse
+	58, // 58: sdl.v1.WorkspaceService.GetParameters:output_type -> sdl.v1.GetParametersResponse
+	59, // 59: sdl.v1.WorkspaceService.EvaluateFlows:output_type -> sdl.v1.EvaluateFlowsResponse
+	60, // 60: sdl.v1.WorkspaceService.BatchSetParameters:output_type -> sdl.v1.BatchSetParametersResponse
+	61, // 61: sdl.v1.WorkspaceService.GetFlowState:output_type -> sdl.v1.GetFlowStateResponse
+	62, // 62: sdl.v1.WorkspaceService.GetFlows:output_type -> sdl.v1.GetFlowsResponse
+	63, // 63: sdl.v1.WorkspaceService.ExecuteTrace:output_type -> sdl.v1.ExecuteTraceResponse
+	64, // 64: sdl.v1.WorkspaceService.StreamTraces:output_type -> sdl.v1.TraceEvent
+	65, // 65: sdl.v1.WorkspaceService.TraceAllPaths:output_type -> sdl.v1.TraceAllPathsResponse
+	66, // 66: sdl.v1.WorkspaceService.GetSystemDiagram:output_type -> sdl.v1.GetSystemDiagramResponse
+	67, // 67: sdl.v1.WorkspaceService.GetUtilization:output_type -> sdl.v1.GetUtilizationResponse
+	68, // 68: sdl.v1.WorkspaceService.QueryMetrics:output_type -> sdl.v1.QueryMetricsResponse
+	69, // 69: sdl.v1.WorkspaceService.GetMeasurementStats:output_type -> sdl.v1.GetMeasurementStatsResponse
+	70, // 70: sdl.v1.WorkspaceService.ListRuns:output_type -> sdl.v1.ListRunsResponse
+	71, // 71: sdl.v1.WorkspaceService.Simulate:output_type -> sdl.v1.SimulateResponse
+	36, // [36:72] is the sub-list for method output_type
+	0,  // [0:36] is the sub-list for method input_type
 	0,  // [0:0] is the sub-list for extension type_name
 	0,  // [0:0] is the sub-list for extension extendee
 	0,  // [0:0] is the sub-list for field type_name
@@ -30,6 +30,7 @@ const (
 	WorkspaceService_GetAllDesignContents_FullMethodName = "/sdl.v1.WorkspaceService/GetAllDesignContents"
 	WorkspaceService_LoadFile_FullMethodName             = "/sdl.v1.WorkspaceService/LoadFile"
 	WorkspaceService_UseSystem_FullMethodName            = "/sdl.v1.WorkspaceService/UseSystem"
+	WorkspaceService_GetManifest_FullMethodName          = "/sdl.v1.WorkspaceService/GetManifest"
 	WorkspaceService_AddGenerator_FullMethodName         = "/sdl.v1.WorkspaceService/AddGenerator"
 	WorkspaceService_UpdateGenerator_FullMethodName      = "/sdl.v1.WorkspaceService/UpdateGenerator"
 	WorkspaceService_DeleteGenerator_FullMethodName      = "/sdl.v1.WorkspaceService/DeleteGenerator"
@@ -46,11 +47,16 @@ const (
 	WorkspaceService_EvaluateFlows_FullMethodName        = "/sdl.v1.WorkspaceService/EvaluateFlows"
 	WorkspaceService_BatchSetParameters_FullMethodName   = "/sdl.v1.WorkspaceService/BatchSetParameters"
 	WorkspaceService_GetFlowState_FullMethodName         = "/sdl.v1.WorkspaceService/GetFlowState"
+	WorkspaceService_GetFlows_FullMethodName             = "/sdl.v1.WorkspaceService/GetFlows"
 	WorkspaceService_ExecuteTrace_FullMethodName         = "/sdl.v1.WorkspaceService/ExecuteTrace"
+	WorkspaceService_StreamTraces_FullMethodName         = "/sdl.v1.WorkspaceService/StreamTraces"
 	WorkspaceService_TraceAllPaths_FullMethodName        = "/sdl.v1.WorkspaceService/TraceAllPaths"
 	WorkspaceService_GetSystemDiagram_FullMethodName     = "/sdl.v1.WorkspaceService/GetSystemDiagram"
 	WorkspaceService_GetUtilization_FullMethodName       = "/sdl.v1.WorkspaceService/GetUtilization"
 	WorkspaceService_QueryMetrics_FullMethodName         = "/sdl.v1.WorkspaceService/QueryMetrics"
+	WorkspaceService_GetMeasurementStats_FullMethodName  = "/sdl.v1.WorkspaceService/GetMeasurementStats"
+	WorkspaceService_ListRuns_FullMethodName             = "/sdl.v1.WorkspaceService/ListRuns"
+	WorkspaceService_Simulate_FullMethodName             = "/sdl.v1.WorkspaceService/Simulate"
 )
 
 // WorkspaceServiceClient is the client API for WorkspaceService service.
@@ -81,6 +87,8 @@ type WorkspaceServiceClient interface {
 	LoadFile(ctx context.Context, in *models.LoadFileRequest, opts ...grpc.CallOption) (*models.LoadFileResponse, error)
 	// Select the active system for simulation
 	UseSystem(ctx context.Context, in *models.UseSystemRequest, opts ...grpc.CallOption) (*models.UseSystemResponse, error)
+	// Versioned summary of the systems, components and enums of the loaded files
+	GetManifest(ctx context.Context, in *models.GetManifestRequest, opts ...grpc.CallOption) (*models.GetManifestResponse, error)
 	AddGenerator(ctx context.Context, in *models.AddGeneratorRequest, opts ...grpc.CallOption) (*models.AddGeneratorResponse, error)
 	UpdateGenerator(ctx context.Context, in *models.UpdateGeneratorRequest, opts ...grpc.CallOption) (*models.UpdateGeneratorResponse, error)
 	DeleteGenerator(ctx context.Context, in *models.DeleteGeneratorRequest, opts ...grpc.CallOption) (*models.DeleteGeneratorResponse, error)
@@ -97,11 +105,21 @@ type WorkspaceServiceClient interface {
 	EvaluateFlows(ctx context.Context, in *models.EvaluateFlowsRequest, opts ...grpc.CallOption) (*models.EvaluateFlowsResponse, error)
 	BatchSetParameters(ctx context.Context, in *models.BatchSetParametersRequest, opts ...grpc.CallOption) (*models.BatchSetParametersResponse, error)
 	GetFlowState(ctx context.Context, in *models.GetFlowStateRequest, opts ...grpc.CallOption) (*models.GetFlowStateResponse, error)
+	// Flow through every component method carrying traffic, with its service
+	// rate, utilization and mean latency.
+	GetFlows(ctx context.Context, in *models.GetFlowsRequest, opts ...grpc.CallOption) (*models.GetFlowsResponse, error)
 	ExecuteTrace(ctx context.Context, in *models.ExecuteTraceRequest, opts ...grpc.CallOption) (*models.ExecuteTraceResponse, error)
+	// Runs one traced call and streams its enter and exit events as they happen
+	StreamTraces(ctx context.Context, in *models.StreamTracesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[models.TraceEvent], error)
 	TraceAllPaths(ctx context.Context, in *models.TraceAllPathsRequest, opts ...grpc.CallOption) (*models.TraceAllPathsResponse, error)
 	GetSystemDiagram(ctx context.Context, in *models.GetSystemDiagramRequest, opts ...grpc.CallOption) (*models.GetSystemDiagramResponse, error)
 	GetUtilization(ctx context.Context, in *models.GetUtilizationRequest, opts ...grpc.CallOption) (*models.GetUtilizationResponse, error)
 	QueryMetrics(ctx context.Context, in *models.QueryMetricsRequest, opts ...grpc.CallOption) (*models.QueryMetricsResponse, error)
+	GetMeasurementStats(ctx context.Context, in *models.GetMeasurementStatsRequest, opts ...grpc.CallOption) (*models.GetMeasurementStatsResponse, error)
+	ListRuns(ctx context.Context, in *models.ListRunsRequest, opts ...grpc.CallOption) (*models.ListRunsResponse, error)
+	// Compiles and runs a self contained model in an ephemeral workspace,
+	// independent of any workspace's state.
+	Simulate(ctx context.Context, in *models.SimulateRequest, opts ...grpc.CallOption) (*models.SimulateResponse, error)
 }
 
 type workspaceServiceClient struct {
@@ -202,6 +220,16 @@ func (c *workspaceServiceClient) UseSystem(ctx context.Context, in *models.UseSy
 	return out, nil
 }
 
+func (c *workspaceServiceClient) GetManifest(ctx context.Context, in *models.GetManifestRequest, opts ...grpc.CallOption) (*models.GetManifestResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(models.GetManifestResponse)
+	err := c.cc.Invoke(ctx, WorkspaceService_GetManifest_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *workspaceServiceClient) AddGenerator(ctx context.Context, in *models.AddGeneratorRequest, opts ...grpc.CallOption) (*models.AddGeneratorResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(models.AddGeneratorResponse)
@@ -362,6 +390,16 @@ func (c *workspaceServiceClient) GetFlowState(ctx context.Context, in *models.Ge
 	return out, nil
 }
 
+func (c *workspaceServiceClient) GetFlows(ctx context.Context, in *models.GetFlowsRequest, opts ...grpc.CallOption) (*models.GetFlowsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(models.GetFlowsResponse)
+	err := c.cc.Invoke(ctx, WorkspaceService_GetFlows_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *workspaceServiceClient) ExecuteTrace(ctx context.Context, in *models.ExecuteTraceRequest, opts ...grpc.CallOption) (*models.ExecuteTraceResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(models.ExecuteTraceResponse)
@@ -372,6 +410,25 @@ func (c *workspaceServiceClient) ExecuteTrace(ctx context.Context, in *models.Ex
 	return out, nil
 }
 
+func (c *workspaceServiceClient) StreamTraces(ctx context.Context, in *models.StreamTracesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[models.TraceEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &WorkspaceService_ServiceDesc.Streams[0], WorkspaceService_StreamTraces_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[models.StreamTracesRequest, models.TraceEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type WorkspaceService_StreamTracesClient = grpc.ServerStreamingClient[models.TraceEvent]
+
 func (c *workspaceServiceClient) TraceAllPaths(ctx context.Context, in *models.TraceAllPathsRequest, opts ...grpc.CallOption) (*models.TraceAllPathsResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(models.TraceAllPathsResponse)
@@ -412,6 +469,36 @@ func (c *workspaceServiceClient) QueryMetrics(ctx context.Context, in *models.Qu
 	return out, nil
 }
 
+func (c *workspaceServiceClient) GetMeasurementStats(ctx context.Context, in *models.GetMeasurementStatsRequest, opts ...grpc.CallOption) (*models.GetMeasurementStatsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(models.GetMeasurementStatsResponse)
+	err := c.cc.Invoke(ctx, WorkspaceService_GetMeasurementStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *workspaceServiceClient) ListRuns(ctx context.Context, in *models.ListRunsRequest, opts ...grpc.CallOption) (*models.ListRunsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(models.ListRunsResponse)
+	err := c.cc.Invoke(ctx, WorkspaceService_ListRuns_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *workspaceServiceClient) Simulate(ctx context.Context, in *models.SimulateRequest, opts ...grpc.CallOption) (*models.SimulateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(models.SimulateResponse)
+	err := c.cc.Invoke(ctx, WorkspaceService_Simulate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // WorkspaceServiceServer is the server API for WorkspaceService service.
 // All implementations should embed UnimplementedWorkspaceServiceServer
 // for forward compatibility.
@@ -440,6 +527,8 @@ type WorkspaceServiceServer interface {
 	LoadFile(context.Context, *models.LoadFileRequest) (*models.LoadFileResponse, error)
 	// Select the active system for simulation
 	UseSystem(context.Context, *models.UseSystemRequest) (*models.UseSystemResponse, error)
+	// Versioned summary of the systems, components and enums of the loaded files
+	GetManifest(context.Context, *models.GetManifestRequest) (*models.GetManifestResponse, error)
 	AddGenerator(context.Context, *models.AddGeneratorRequest) (*models.AddGeneratorResponse, error)
 	UpdateGenerator(context.Context, *models.UpdateGeneratorRequest) (*models.UpdateGeneratorResponse, error)
 	DeleteGenerator(context.Context, *models.DeleteGeneratorRequest) (*models.DeleteGeneratorResponse, error)
@@ -456,11 +545,21 @@ type WorkspaceServiceServer interface {
 	EvaluateFlows(context.Context, *models.EvaluateFlowsRequest) (*models.EvaluateFlowsResponse, error)
 	BatchSetParameters(context.Context, *models.BatchSetParametersRequest) (*models.BatchSetParametersResponse, error)
 	GetFlowState(context.Context, *models.GetFlowStateRequest) (*models.GetFlowStateResponse, error)
+	// Flow through every component method carrying traffic, with its service
+	// rate, utilization and mean latency.
+	GetFlows(context.Context, *models.GetFlowsRequest) (*models.GetFlowsResponse, error)
 	ExecuteTrace(context.Context, *models.ExecuteTraceRequest) (*models.ExecuteTraceResponse, error)
+	// Runs one traced call and streams its enter and exit events as they happen
+	StreamTraces(*models.StreamTracesRequest, grpc.ServerStreamingServer[models.TraceEvent]) error
 	TraceAllPaths(context.Context, *models.TraceAllPathsRequest) (*models.TraceAllPathsResponse, error)
 	GetSystemDiagram(context.Context, *models.GetSystemDiagramRequest) (*models.GetSystemDiagramResponse, error)
 	GetUtilization(context.Context, *models.GetUtilizationRequest) (*models.GetUtilizationResponse, error)
 	QueryMetrics(context.Context, *models.QueryMetricsRequest) (*models.QueryMetricsResponse, error)
+	GetMeasurementStats(context.Context, *models.GetMeasurementStatsRequest) (*models.GetMeasurementStatsResponse, error)
+	ListRuns(context.Context, *models.ListRunsRequest) (*models.ListRunsResponse, error)
+	// Compiles and runs a self contained model in an ephemeral workspace,
+	// independent of any workspace's state.
+	Simulate(context.Context, *models.SimulateRequest) (*models.SimulateResponse, error)
 }
 
 // UnimplementedWorkspaceServiceServer should be embedded to have
@@ -497,6 +596,9 @@ func (UnimplementedWorkspaceServiceServer) LoadFile(context.Context, *models.Loa
 func (UnimplementedWorkspaceServiceServer) UseSystem(context.Context, *models.UseSystemRequest) (*models.UseSystemResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UseSystem not implemented")
 }
+func (UnimplementedWorkspaceServiceServer) GetManifest(context.Context, *models.GetManifestRequest) (*models.GetManifestResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetManifest not implemented")
+}
 func (UnimplementedWorkspaceServiceServer) AddGenerator(context.Context, *models.AddGeneratorRequest) (*models.AddGeneratorResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method AddGenerator not implemented")
 }
@@ -545,9 +647,15 @@ func (UnimplementedWorkspaceServiceServer) BatchSetParameters(context.Context, *
 func (UnimplementedWorkspaceServiceServer) GetFlowState(context.Context, *models.GetFlowStateRequest) (*models.GetFlowStateResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetFlowState not implemented")
 }
+func (UnimplementedWorkspaceServiceServer) GetFlows(context.Context, *models.GetFlowsRequest) (*models.GetFlowsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFlows not implemented")
+}
 func (UnimplementedWorkspaceServiceServer) ExecuteTrace(context.Context, *models.ExecuteTraceRequest) (*models.ExecuteTraceResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ExecuteTrace not implemented")
 }
+func (UnimplementedWorkspaceServiceServer) StreamTraces(*models.StreamTracesRequest, grpc.ServerStreamingServer[models.TraceEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamTraces not implemented")
+}
 func (UnimplementedWorkspaceServiceServer) TraceAllPaths(context.Context, *models.TraceAllPathsRequest) (*models.TraceAllPathsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method TraceAllPaths not implemented")
 }
@@ -560,6 +668,15 @@ func (UnimplementedWorkspaceServiceServer) GetUtilization(context.Context, *mode
 func (UnimplementedWorkspaceServiceServer) QueryMetrics(context.Context, *models.QueryMetricsRequest) (*models.QueryMetricsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method QueryMetrics not implemented")
 }
+func (UnimplementedWorkspaceServiceServer) GetMeasurementStats(context.Context, *models.GetMeasurementStatsRequest) (*models.GetMeasurementStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMeasurementStats not implemented")
+}
+func (UnimplementedWorkspaceServiceServer) ListRuns(context.Context, *models.ListRunsRequest) (*models.ListRunsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRuns not implemented")
+}
+func (UnimplementedWorkspaceServiceServer) Simulate(context.Context, *models.SimulateRequest) (*models.SimulateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Simulate not implemented")
+}
 func (UnimplementedWorkspaceServiceServer) testEmbeddedByValue() {}
 
 // UnsafeWorkspaceServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -742,6 +859,24 @@ func _WorkspaceService_UseSystem_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _WorkspaceService_GetManifest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(models.GetManifestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkspaceServiceServer).GetManifest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WorkspaceService_GetManifest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkspaceServiceServer).GetManifest(ctx, req.(*models.GetManifestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _WorkspaceService_AddGenerator_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(models.AddGeneratorRequest)
 	if err := dec(in); err != nil {
@@ -1030,6 +1165,24 @@ func _WorkspaceService_GetFlowState_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _WorkspaceService_GetFlows_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(models.GetFlowsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkspaceServiceServer).GetFlows(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WorkspaceService_GetFlows_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkspaceServiceServer).GetFlows(ctx, req.(*models.GetFlowsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _WorkspaceService_ExecuteTrace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(models.ExecuteTraceRequest)
 	if err := dec(in); err != nil {
@@ -1048,6 +1201,17 @@ func _WorkspaceService_ExecuteTrace_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _WorkspaceService_StreamTraces_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(models.StreamTracesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(WorkspaceServiceServer).StreamTraces(m, &grpc.GenericServerStream[models.StreamTracesRequest, models.TraceEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type WorkspaceService_StreamTracesServer = grpc.ServerStreamingServer[models.TraceEvent]
+
 func _WorkspaceService_TraceAllPaths_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(models.TraceAllPathsRequest)
 	if err := dec(in); err != nil {
@@ -1120,6 +1284,60 @@ func _WorkspaceService_QueryMetrics_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _WorkspaceService_GetMeasurementStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(models.GetMeasurementStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkspaceServiceServer).GetMeasurementStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WorkspaceService_GetMeasurementStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkspaceServiceServer).GetMeasurementStats(ctx, req.(*models.GetMeasurementStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WorkspaceService_ListRuns_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(models.ListRunsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkspaceServiceServer).ListRuns(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WorkspaceService_ListRuns_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkspaceServiceServer).ListRuns(ctx, req.(*models.ListRunsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WorkspaceService_Simulate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(models.SimulateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkspaceServiceServer).Simulate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WorkspaceService_Simulate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkspaceServiceServer).Simulate(ctx, req.(*models.SimulateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // WorkspaceService_ServiceDesc is the grpc.ServiceDesc for WorkspaceService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -1163,6 +1381,10 @@ var WorkspaceService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UseSystem",
 			Handler:    _WorkspaceService_UseSystem_Handler,
 		},
+		{
+			MethodName: "GetManifest",
+			Handler:    _WorkspaceService_GetManifest_Handler,
+		},
 		{
 			MethodName: "AddGenerator",
 			Handler:    _WorkspaceService_AddGenerator_Handler,
@@ -1227,6 +1449,10 @@ var WorkspaceService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetFlowState",
 			Handler:    _WorkspaceService_GetFlowState_Handler,
 		},
+		{
+			MethodName: "GetFlows",
+			Handler:    _WorkspaceService_GetFlows_Handler,
+		},
 		{
 			MethodName: "ExecuteTrace",
 			Handler:    _WorkspaceService_ExecuteTrace_Handler,
@@ -1247,7 +1473,25 @@ var WorkspaceService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "QueryMetrics",
 			Handler:    _WorkspaceService_QueryMetrics_Handler,
 		},
+		{
+			MethodName: "GetMeasurementStats",
+			Handler:    _WorkspaceService_GetMeasurementStats_Handler,
+		},
+		{
+			MethodName: "ListRuns",
+			Handler:    _WorkspaceService_ListRuns_Handler,
+		},
+		{
+			MethodName: "Simulate",
+			Handler:    _WorkspaceService_Simulate_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamTraces",
+			Handler:       _WorkspaceService_StreamTraces_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "sdl/v1/services/workspace.proto",
 }
@@ -185,6 +185,15 @@ func (c *WorkspacePageClient) OnAvailableSystemsChanged(ctx context.Context, req
 	)
 }
 
+// OnModelChanged calls the browser-provided OnModelChanged method synchronously.
+// The JavaScript implementation returns the result directly (SYNC invocation style).
+func (c *WorkspacePageClient) OnModelChanged(ctx context.Context, req *v1models.DevEnvModelChangedRequest) (*v1models.DevEnvModelChangedResponse, error) {
+	// SYNC invocation style: browser method returns immediately
+	return wasm.CallBrowserService[*v1models.DevEnvModelChangedRequest, *v1models.DevEnvModelChangedResponse](
+		c.channel, ctx, "WorkspacePage", "onModelChanged", req,
+	)
+}
+
 // UpdateDiagram calls the browser-provided UpdateDiagram method synchronously.
 // The JavaScript implementation returns the result directly (SYNC invocation style).
 func (c *WorkspacePageClient) UpdateDiagram(ctx context.Context, req *v1models.UpdateDiagramRequest) (*v1models.UpdateDiagramResponse, error) {
@@ -247,3 +256,12 @@ func (c *WorkspacePageClient) LogMessage(ctx context.Context, req *v1models.LogM
 		c.channel, ctx, "WorkspacePage", "logMessage", req,
 	)
 }
+
+// OnDiagnostics calls the browser-provided OnDiagnostics method synchronously.
+// The JavaScript implementation returns the result directly (SYNC invocation style).
+func (c *WorkspacePageClient) OnDiagnostics(ctx context.Context, req *v1models.DevEnvDiagnosticsRequest) (*v1models.DevEnvDiagnosticsResponse, error) {
+	// SYNC invocation style: browser method returns immediately
+	return wasm.CallBrowserService[*v1models.DevEnvDiagnosticsRequest, *v1models.DevEnvDiagnosticsResponse](
+		c.channel, ctx, "WorkspacePage", "onDiagnostics", req,
+	)
+}
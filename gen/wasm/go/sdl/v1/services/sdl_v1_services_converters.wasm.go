@@ -6,6 +6,49 @@
 
 package sdl
 
+import (
+	"context"
+	"fmt"
+	"syscall/js"
+
+	wasm "github.com/panyam/protoc-gen-go-wasmjs/pkg/wasm"
+	v1models "github.com/panyam/sdl/gen/go/sdl/v1/models"
+)
+
 // =============================================================================
 // Server Stream Wrappers
 // =============================================================================
+
+// serverStreamWrapperStreamTraces implements the StreamTraces_ServerStream interface for StreamTraces
+type serverStreamWrapperStreamTraces struct {
+	ctx      context.Context
+	callback js.Value
+}
+
+func (s *serverStreamWrapperStreamTraces) Send(resp *v1models.TraceEvent) error {
+	// Marshal response
+	marshaller := wasm.GetGlobalMarshaller()
+	responseJSON, err := marshaller.Marshal(resp, wasm.MarshalOptions{
+		UseProtoNames:   false,
+		EmitUnpopulated: false,
+		UseEnumNumbers:  false,
+	})
+	if err != nil {
+		s.callback.Invoke(js.Null(), fmt.Sprintf("Failed to marshal response: %v", err), true)
+		return err
+	}
+
+	// Call callback with response, no error, not done - returns boolean to continue
+	shouldContinue := s.callback.Invoke(string(responseJSON), js.Null(), false)
+
+	// Check if JS wants to stop the stream
+	if !shouldContinue.Bool() {
+		return fmt.Errorf("stream cancelled by client")
+	}
+
+	return nil
+}
+
+func (s *serverStreamWrapperStreamTraces) Context() context.Context {
+	return s.ctx
+}
@@ -161,6 +161,9 @@ func (exports *Sdl_v1ServicesExports) RegisterAPI() {
 			"useSystem": js.FuncOf(func(this js.Value, args []js.Value) any {
 				return exports.workspaceServiceUseSystem(this, args)
 			}),
+			"getManifest": js.FuncOf(func(this js.Value, args []js.Value) any {
+				return exports.workspaceServiceGetManifest(this, args)
+			}),
 			"addGenerator": js.FuncOf(func(this js.Value, args []js.Value) any {
 				return exports.workspaceServiceAddGenerator(this, args)
 			}),
@@ -209,9 +212,15 @@ func (exports *Sdl_v1ServicesExports) RegisterAPI() {
 			"getFlowState": js.FuncOf(func(this js.Value, args []js.Value) any {
 				return exports.workspaceServiceGetFlowState(this, args)
 			}),
+			"getFlows": js.FuncOf(func(this js.Value, args []js.Value) any {
+				return exports.workspaceServiceGetFlows(this, args)
+			}),
 			"executeTrace": js.FuncOf(func(this js.Value, args []js.Value) any {
 				return exports.workspaceServiceExecuteTrace(this, args)
 			}),
+			"streamTraces": js.FuncOf(func(this js.Value, args []js.Value) any {
+				return exports.workspaceServiceStreamTraces(this, args)
+			}),
 			"traceAllPaths": js.FuncOf(func(this js.Value, args []js.Value) any {
 				return exports.workspaceServiceTraceAllPaths(this, args)
 			}),
@@ -224,6 +233,15 @@ func (exports *Sdl_v1ServicesExports) RegisterAPI() {
 			"queryMetrics": js.FuncOf(func(this js.Value, args []js.Value) any {
 				return exports.workspaceServiceQueryMetrics(this, args)
 			}),
+			"getMeasurementStats": js.FuncOf(func(this js.Value, args []js.Value) any {
+				return exports.workspaceServiceGetMeasurementStats(this, args)
+			}),
+			"listRuns": js.FuncOf(func(this js.Value, args []js.Value) any {
+				return exports.workspaceServiceListRuns(this, args)
+			}),
+			"simulate": js.FuncOf(func(this js.Value, args []js.Value) any {
+				return exports.workspaceServiceSimulate(this, args)
+			}),
 		},
 	}
 	js.Global().Set("sdl", js.ValueOf(sdl))
@@ -2059,6 +2077,54 @@ func (exports *Sdl_v1ServicesExports) workspaceServiceUseSystem(this js.Value, a
 	return wasm.CreateJSResponse(true, "Success", json.RawMessage(responseJSON))
 }
 
+// workspaceServiceGetManifest handles the GetManifest method for WorkspaceService
+func (exports *Sdl_v1ServicesExports) workspaceServiceGetManifest(this js.Value, args []js.Value) any {
+	if exports.WorkspaceService == nil {
+		return wasm.CreateJSResponse(false, "WorkspaceService not initialized", nil)
+	}
+	// Synchronous method
+	if len(args) < 1 {
+		return wasm.CreateJSResponse(false, "Request JSON required", nil)
+	}
+
+	requestJSON := args[0].String()
+	if requestJSON == "" {
+		return wasm.CreateJSResponse(false, "Request JSON is empty", nil)
+	}
+
+	// Parse request
+	req := &v1models.GetManifestRequest{}
+	marshaller := wasm.GetGlobalMarshaller()
+	if err := marshaller.Unmarshal([]byte(requestJSON), req, wasm.UnmarshalOptions{
+		DiscardUnknown: true,
+		AllowPartial:   true, // Allow partial messages for better compatibility
+	}); err != nil {
+		return wasm.CreateJSResponse(false, fmt.Sprintf("Failed to parse request: %v", err), nil)
+	}
+
+	// Create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// Call service method
+	resp, err := exports.WorkspaceService.GetManifest(ctx, req)
+	if err != nil {
+		return wasm.CreateJSResponse(false, fmt.Sprintf("Service call failed: %v", err), nil)
+	}
+
+	// Marshal response with options for better TypeScript compatibility
+	responseJSON, err := marshaller.Marshal(resp, wasm.MarshalOptions{
+		UseProtoNames:   false, // Use JSON names (camelCase) instead of proto names
+		EmitUnpopulated: true,  // Emit zero values to avoid undefined in JavaScript
+		UseEnumNumbers:  false, // Use enum string values
+	})
+	if err != nil {
+		return wasm.CreateJSResponse(false, fmt.Sprintf("Failed to marshal response: %v", err), nil)
+	}
+
+	return wasm.CreateJSResponse(true, "Success", json.RawMessage(responseJSON))
+}
+
 // workspaceServiceAddGenerator handles the AddGenerator method for WorkspaceService
 func (exports *Sdl_v1ServicesExports) workspaceServiceAddGenerator(this js.Value, args []js.Value) any {
 	if exports.WorkspaceService == nil {
@@ -2827,6 +2893,54 @@ func (exports *Sdl_v1ServicesExports) workspaceServiceGetFlowState(this js.Value
 	return wasm.CreateJSResponse(true, "Success", json.RawMessage(responseJSON))
 }
 
+// workspaceServiceGetFlows handles the GetFlows method for WorkspaceService
+func (exports *Sdl_v1ServicesExports) workspaceServiceGetFlows(this js.Value, args []js.Value) any {
+	if exports.WorkspaceService == nil {
+		return wasm.CreateJSResponse(false, "WorkspaceService not initialized", nil)
+	}
+	// Synchronous method
+	if len(args) < 1 {
+		return wasm.CreateJSResponse(false, "Request JSON required", nil)
+	}
+
+	requestJSON := args[0].String()
+	if requestJSON == "" {
+		return wasm.CreateJSResponse(false, "Request JSON is empty", nil)
+	}
+
+	// Parse request
+	req := &v1models.GetFlowsRequest{}
+	marshaller := wasm.GetGlobalMarshaller()
+	if err := marshaller.Unmarshal([]byte(requestJSON), req, wasm.UnmarshalOptions{
+		DiscardUnknown: true,
+		AllowPartial:   true, // Allow partial messages for better compatibility
+	}); err != nil {
+		return wasm.CreateJSResponse(false, fmt.Sprintf("Failed to parse request: %v", err), nil)
+	}
+
+	// Create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// Call service method
+	resp, err := exports.WorkspaceService.GetFlows(ctx, req)
+	if err != nil {
+		return wasm.CreateJSResponse(false, fmt.Sprintf("Service call failed: %v", err), nil)
+	}
+
+	// Marshal response with options for better TypeScript compatibility
+	responseJSON, err := marshaller.Marshal(resp, wasm.MarshalOptions{
+		UseProtoNames:   false, // Use JSON names (camelCase) instead of proto names
+		EmitUnpopulated: true,  // Emit zero values to avoid undefined in JavaScript
+		UseEnumNumbers:  false, // Use enum string values
+	})
+	if err != nil {
+		return wasm.CreateJSResponse(false, fmt.Sprintf("Failed to marshal response: %v", err), nil)
+	}
+
+	return wasm.CreateJSResponse(true, "Success", json.RawMessage(responseJSON))
+}
+
 // workspaceServiceExecuteTrace handles the ExecuteTrace method for WorkspaceService
 func (exports *Sdl_v1ServicesExports) workspaceServiceExecuteTrace(this js.Value, args []js.Value) any {
 	if exports.WorkspaceService == nil {
@@ -2875,6 +2989,63 @@ func (exports *Sdl_v1ServicesExports) workspaceServiceExecuteTrace(this js.Value
 	return wasm.CreateJSResponse(true, "Success", json.RawMessage(responseJSON))
 }
 
+// workspaceServiceStreamTraces handles the StreamTraces method for WorkspaceService
+func (exports *Sdl_v1ServicesExports) workspaceServiceStreamTraces(this js.Value, args []js.Value) any {
+	if exports.WorkspaceService == nil {
+		return wasm.CreateJSResponse(false, "WorkspaceService not initialized", nil)
+	}
+	// Server streaming method: expect request JSON and callback function
+	if len(args) < 2 {
+		return wasm.CreateJSResponse(false, "Request JSON and callback function required for streaming method", nil)
+	}
+
+	requestJSON := args[0].String()
+	if requestJSON == "" {
+		return wasm.CreateJSResponse(false, "Request JSON is empty", nil)
+	}
+
+	callback := args[1]
+	if callback.Type() != js.TypeFunction {
+		return wasm.CreateJSResponse(false, "Second argument must be a callback function", nil)
+	}
+
+	// Parse request
+	req := &v1models.StreamTracesRequest{}
+	marshaller := wasm.GetGlobalMarshaller()
+	if err := marshaller.Unmarshal([]byte(requestJSON), req, wasm.UnmarshalOptions{
+		DiscardUnknown: true,
+		AllowPartial:   true,
+	}); err != nil {
+		return wasm.CreateJSResponse(false, fmt.Sprintf("Failed to parse request: %v", err), nil)
+	}
+
+	// Start streaming in goroutine to avoid blocking
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		// Create a stream wrapper that implements StreamTraces_ServerStream
+		streamWrapper := &serverStreamWrapperStreamTraces{
+			ctx:      ctx,
+			callback: callback,
+		}
+
+		// Call the server streaming method with the correct signature
+		err := exports.WorkspaceService.StreamTraces(req, streamWrapper)
+		if err != nil {
+			// Call callback with error and done=true
+			callback.Invoke(js.Null(), err.Error(), true)
+			return
+		}
+
+		// Signal completion
+		callback.Invoke(js.Null(), js.Null(), true)
+	}()
+
+	// Return immediately for streaming methods
+	return wasm.CreateJSResponse(true, "Server streaming started", nil)
+}
+
 // workspaceServiceTraceAllPaths handles the TraceAllPaths method for WorkspaceService
 func (exports *Sdl_v1ServicesExports) workspaceServiceTraceAllPaths(this js.Value, args []js.Value) any {
 	if exports.WorkspaceService == nil {
@@ -3066,3 +3237,147 @@ func (exports *Sdl_v1ServicesExports) workspaceServiceQueryMetrics(this js.Value
 
 	return wasm.CreateJSResponse(true, "Success", json.RawMessage(responseJSON))
 }
+
+// workspaceServiceGetMeasurementStats handles the GetMeasurementStats method for WorkspaceService
+func (exports *Sdl_v1ServicesExports) workspaceServiceGetMeasurementStats(this js.Value, args []js.Value) any {
+	if exports.WorkspaceService == nil {
+		return wasm.CreateJSResponse(false, "WorkspaceService not initialized", nil)
+	}
+	// Synchronous method
+	if len(args) < 1 {
+		return wasm.CreateJSResponse(false, "Request JSON required", nil)
+	}
+
+	requestJSON := args[0].String()
+	if requestJSON == "" {
+		return wasm.CreateJSResponse(false, "Request JSON is empty", nil)
+	}
+
+	// Parse request
+	req := &v1models.GetMeasurementStatsRequest{}
+	marshaller := wasm.GetGlobalMarshaller()
+	if err := marshaller.Unmarshal([]byte(requestJSON), req, wasm.UnmarshalOptions{
+		DiscardUnknown: true,
+		AllowPartial:   true, // Allow partial messages for better compatibility
+	}); err != nil {
+		return wasm.CreateJSResponse(false, fmt.Sprintf("Failed to parse request: %v", err), nil)
+	}
+
+	// Create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// Call service method
+	resp, err := exports.WorkspaceService.GetMeasurementStats(ctx, req)
+	if err != nil {
+		return wasm.CreateJSResponse(false, fmt.Sprintf("Service call failed: %v", err), nil)
+	}
+
+	// Marshal response with options for better TypeScript compatibility
+	responseJSON, err := marshaller.Marshal(resp, wasm.MarshalOptions{
+		UseProtoNames:   false, // Use JSON names (camelCase) instead of proto names
+		EmitUnpopulated: true,  // Emit zero values to avoid undefined in JavaScript
+		UseEnumNumbers:  false, // Use enum string values
+	})
+	if err != nil {
+		return wasm.CreateJSResponse(false, fmt.Sprintf("Failed to marshal response: %v", err), nil)
+	}
+
+	return wasm.CreateJSResponse(true, "Success", json.RawMessage(responseJSON))
+}
+
+// workspaceServiceListRuns handles the ListRuns method for WorkspaceService
+func (exports *Sdl_v1ServicesExports) workspaceServiceListRuns(this js.Value, args []js.Value) any {
+	if exports.WorkspaceService == nil {
+		return wasm.CreateJSResponse(false, "WorkspaceService not initialized", nil)
+	}
+	// Synchronous method
+	if len(args) < 1 {
+		return wasm.CreateJSResponse(false, "Request JSON required", nil)
+	}
+
+	requestJSON := args[0].String()
+	if requestJSON == "" {
+		return wasm.CreateJSResponse(false, "Request JSON is empty", nil)
+	}
+
+	// Parse request
+	req := &v1models.ListRunsRequest{}
+	marshaller := wasm.GetGlobalMarshaller()
+	if err := marshaller.Unmarshal([]byte(requestJSON), req, wasm.UnmarshalOptions{
+		DiscardUnknown: true,
+		AllowPartial:   true, // Allow partial messages for better compatibility
+	}); err != nil {
+		return wasm.CreateJSResponse(false, fmt.Sprintf("Failed to parse request: %v", err), nil)
+	}
+
+	// Create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// Call service method
+	resp, err := exports.WorkspaceService.ListRuns(ctx, req)
+	if err != nil {
+		return wasm.CreateJSResponse(false, fmt.Sprintf("Service call failed: %v", err), nil)
+	}
+
+	// Marshal response with options for better TypeScript compatibility
+	responseJSON, err := marshaller.Marshal(resp, wasm.MarshalOptions{
+		UseProtoNames:   false, // Use JSON names (camelCase) instead of proto names
+		EmitUnpopulated: true,  // Emit zero values to avoid undefined in JavaScript
+		UseEnumNumbers:  false, // Use enum string values
+	})
+	if err != nil {
+		return wasm.CreateJSResponse(false, fmt.Sprintf("Failed to marshal response: %v", err), nil)
+	}
+
+	return wasm.CreateJSResponse(true, "Success", json.RawMessage(responseJSON))
+}
+
+// workspaceServiceSimulate handles the Simulate method for WorkspaceService
+func (exports *Sdl_v1ServicesExports) workspaceServiceSimulate(this js.Value, args []js.Value) any {
+	if exports.WorkspaceService == nil {
+		return wasm.CreateJSResponse(false, "WorkspaceService not initialized", nil)
+	}
+	// Synchronous method
+	if len(args) < 1 {
+		return wasm.CreateJSResponse(false, "Request JSON required", nil)
+	}
+
+	requestJSON := args[0].String()
+	if requestJSON == "" {
+		return wasm.CreateJSResponse(false, "Request JSON is empty", nil)
+	}
+
+	// Parse request
+	req := &v1models.SimulateRequest{}
+	marshaller := wasm.GetGlobalMarshaller()
+	if err := marshaller.Unmarshal([]byte(requestJSON), req, wasm.UnmarshalOptions{
+		DiscardUnknown: true,
+		AllowPartial:   true, // Allow partial messages for better compatibility
+	}); err != nil {
+		return wasm.CreateJSResponse(false, fmt.Sprintf("Failed to parse request: %v", err), nil)
+	}
+
+	// Create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// Call service method
+	resp, err := exports.WorkspaceService.Simulate(ctx, req)
+	if err != nil {
+		return wasm.CreateJSResponse(false, fmt.Sprintf("Service call failed: %v", err), nil)
+	}
+
+	// Marshal response with options for better TypeScript compatibility
+	responseJSON, err := marshaller.Marshal(resp, wasm.MarshalOptions{
+		UseProtoNames:   false, // Use JSON names (camelCase) instead of proto names
+		EmitUnpopulated: true,  // Emit zero values to avoid undefined in JavaScript
+		UseEnumNumbers:  false, // Use enum string values
+	})
+	if err != nil {
+		return wasm.CreateJSResponse(false, fmt.Sprintf("Failed to marshal response: %v", err), nil)
+	}
+
+	return wasm.CreateJSResponse(true, "Success", json.RawMessage(responseJSON))
+}
@@ -102,6 +102,8 @@ type WorkspaceServiceServer interface {
 	LoadFile(context.Context, *v1models.LoadFileRequest) (*v1models.LoadFileResponse, error)
 	/** Select the active system for simulation */
 	UseSystem(context.Context, *v1models.UseSystemRequest) (*v1models.UseSystemResponse, error)
+	/** Versioned summary of the systems, components and enums of the loaded files */
+	GetManifest(context.Context, *v1models.GetManifestRequest) (*v1models.GetManifestResponse, error)
 	AddGenerator(context.Context, *v1models.AddGeneratorRequest) (*v1models.AddGeneratorResponse, error)
 	UpdateGenerator(context.Context, *v1models.UpdateGeneratorRequest) (*v1models.UpdateGeneratorResponse, error)
 	DeleteGenerator(context.Context, *v1models.DeleteGeneratorRequest) (*v1models.DeleteGeneratorResponse, error)
@@ -118,11 +120,27 @@ type WorkspaceServiceServer interface {
 	EvaluateFlows(context.Context, *v1models.EvaluateFlowsRequest) (*v1models.EvaluateFlowsResponse, error)
 	BatchSetParameters(context.Context, *v1models.BatchSetParametersRequest) (*v1models.BatchSetParametersResponse, error)
 	GetFlowState(context.Context, *v1models.GetFlowStateRequest) (*v1models.GetFlowStateResponse, error)
+	/** Flow through every component method carrying traffic, with its service
+	rate, utilization and mean latency. */
+	GetFlows(context.Context, *v1models.GetFlowsRequest) (*v1models.GetFlowsResponse, error)
 	ExecuteTrace(context.Context, *v1models.ExecuteTraceRequest) (*v1models.ExecuteTraceResponse, error)
+	/** Runs one traced call and streams its enter and exit events as they happen */
+	StreamTraces(*v1models.StreamTracesRequest, StreamTraces_ServerStream) error
 	TraceAllPaths(context.Context, *v1models.TraceAllPathsRequest) (*v1models.TraceAllPathsResponse, error)
 	GetSystemDiagram(context.Context, *v1models.GetSystemDiagramRequest) (*v1models.GetSystemDiagramResponse, error)
 	GetUtilization(context.Context, *v1models.GetUtilizationRequest) (*v1models.GetUtilizationResponse, error)
 	QueryMetrics(context.Context, *v1models.QueryMetricsRequest) (*v1models.QueryMetricsResponse, error)
+	GetMeasurementStats(context.Context, *v1models.GetMeasurementStatsRequest) (*v1models.GetMeasurementStatsResponse, error)
+	ListRuns(context.Context, *v1models.ListRunsRequest) (*v1models.ListRunsResponse, error)
+	/** Compiles and runs a self contained model in an ephemeral workspace,
+	independent of any workspace's state. */
+	Simulate(context.Context, *v1models.SimulateRequest) (*v1models.SimulateResponse, error)
 }
 
 // Server stream interfaces for streaming methods
+
+// StreamTraces_ServerStream is the server stream interface for StreamTraces
+type StreamTraces_ServerStream interface {
+	Send(*v1models.TraceEvent) error
+	Context() context.Context
+}
@@ -2,6 +2,7 @@ package components
 
 import (
 	"math"
+	"sync/atomic"
 
 	sc "github.com/panyam/sdl/lib/core"
 )
@@ -24,8 +25,21 @@ type CacheWithContention struct {
 	MaxThroughput float64 // Maximum requests per second
 	arrivalRate   float64 // Current arrival rate (λ)
 
+	// Cold start modeling - the cache starts empty and its hit rate rises
+	// linearly with the reads it serves until it reaches HitRate.
+	// 0 means the cache starts warm.
+	WarmupReads int64
+	reads       atomic.Int64
+
+	// Entries the cache holds and seconds an entry lives once cached, 0 for
+	// no expiry.  With both set, reads that would hit can find their entry
+	// expired (see hitRate).
+	Size int64
+	TTL  float64
+
 	// Pre-calculated outcomes
 	readOutcomes  *Outcomes[sc.AccessResult]
+	readHitRate   float64 // HitRate readOutcomes was calculated with
 	writeOutcomes *Outcomes[sc.AccessResult]
 }
 
@@ -122,6 +136,35 @@ func (c *CacheWithContention) calculateQueueingDelay() float64 {
 
 // calculateReadOutcomes generates probabilistic outcomes including contention
 func (c *CacheWithContention) calculateReadOutcomes() {
+	c.readHitRate = c.hitRate(c.WarmupReads, c.arrivalRate)
+	c.readOutcomes = c.readOutcomesWithHitRate(c.readHitRate)
+}
+
+// hitRate returns the hit rate of a read made after reads others at the
+// given arrival rate.  While warming up HitRate is scaled by the fraction of
+// WarmupReads served.  With a TTL each of the Size entries sees its share of
+// the hits, r = arrivalRate × HitRate / Size, and is read r×TTL times before
+// it expires and the next read misses, so r×TTL / (1 + r×TTL) of those reads
+// hit.
+func (c *CacheWithContention) hitRate(reads int64, arrivalRate float64) float64 {
+	hitRate := c.HitRate
+	if reads < c.WarmupReads {
+		hitRate *= float64(reads) / float64(c.WarmupReads)
+	}
+	if c.TTL > 0 && c.Size > 0 && arrivalRate > 0 {
+		readsPerEntry := arrivalRate * c.HitRate / float64(c.Size) * c.TTL
+		hitRate *= readsPerEntry / (1 + readsPerEntry)
+	}
+	return hitRate
+}
+
+// EffectiveHitRate returns the hit rate the next read sees, see hitRate.
+func (c *CacheWithContention) EffectiveHitRate() float64 {
+	return c.hitRate(c.reads.Load(), c.arrivalRate)
+}
+
+// readOutcomesWithHitRate generates read outcomes for the given hit rate
+func (c *CacheWithContention) readOutcomesWithHitRate(hitRate float64) *Outcomes[sc.AccessResult] {
 	outcomes := &Outcomes[sc.AccessResult]{And: sc.AndAccessResults}
 	totalProb := 1.0
 	queueDelay := c.calculateQueueingDelay()
@@ -141,7 +184,7 @@ func (c *CacheWithContention) calculateReadOutcomes() {
 	}
 
 	// Hits
-	hitProb := c.HitRate * totalProb
+	hitProb := hitRate * totalProb
 	if hitProb > 1e-9 {
 		for _, hitBucket := range c.HitLatency.Buckets {
 			prob := hitProb * (hitBucket.Weight / c.HitLatency.TotalWeight())
@@ -154,7 +197,7 @@ func (c *CacheWithContention) calculateReadOutcomes() {
 	}
 
 	// Misses
-	missProb := (1.0 - c.HitRate) * totalProb
+	missProb := (1.0 - hitRate) * totalProb
 	if missProb > 1e-9 {
 		for _, missBucket := range c.MissLatency.Buckets {
 			prob := missProb * (missBucket.Weight / c.MissLatency.TotalWeight())
@@ -166,7 +209,7 @@ func (c *CacheWithContention) calculateReadOutcomes() {
 		}
 	}
 
-	c.readOutcomes = outcomes
+	return outcomes
 }
 
 // calculateWriteOutcomes generates probabilistic outcomes including contention
@@ -203,14 +246,36 @@ func (c *CacheWithContention) calculateWriteOutcomes() {
 	c.writeOutcomes = outcomes
 }
 
-// Read simulates a cache read with contention
+// Read simulates a cache read with contention.  While the cache is warming
+// up the hit rate is scaled by the fraction of WarmupReads served so far.
 func (c *CacheWithContention) Read() *Outcomes[sc.AccessResult] {
-	if c.readOutcomes == nil {
+	if reads := c.reads.Add(1); reads <= c.WarmupReads {
+		return c.readOutcomesWithHitRate(c.hitRate(reads-1, c.arrivalRate))
+	}
+	if c.readOutcomes == nil || c.readHitRate != c.hitRate(c.WarmupReads, c.arrivalRate) {
 		c.calculateReadOutcomes()
 	}
 	return c.readOutcomes
 }
 
+// Get is a Read under the name a read-through cache is usually called by: it
+// succeeds on a hit and fails on a miss, after which the caller goes to the
+// backing store.
+func (c *CacheWithContention) Get() *Outcomes[sc.AccessResult] {
+	return c.Read()
+}
+
+// IsWarm returns true once the cache has served WarmupReads reads.
+func (c *CacheWithContention) IsWarm() bool {
+	return c.reads.Load() >= c.WarmupReads
+}
+
+// HasRunState implements Stateful: reads change the hit rate of later reads
+// until the cache is warm.
+func (c *CacheWithContention) HasRunState() bool {
+	return !c.IsWarm()
+}
+
 // Write simulates a cache write with contention
 func (c *CacheWithContention) Write() *Outcomes[sc.AccessResult] {
 	if c.writeOutcomes == nil {
@@ -273,9 +338,14 @@ func (c *CacheWithContention) GetFlowPattern(methodName string, inputRate float6
 	queueTime := 0.0
 
 	switch methodName {
-	case "Read":
+	case "Read", "Get":
+		// A read succeeds only on a hit so callers send just the misses on
+		// to the backing store
+		hitRate := c.hitRate(c.reads.Load(), currentRate)
+		successRate *= hitRate
+
 		// Weighted average of hit and miss times
-		baseServiceTime = c.HitRate*0.0001 + (1-c.HitRate)*0.0002
+		baseServiceTime = hitRate*0.0001 + (1-hitRate)*0.0002
 	case "Write":
 		baseServiceTime = 0.00012
 	default:
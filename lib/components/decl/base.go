@@ -26,11 +26,13 @@ func NewNWBase[W WrappedComponent](name string, wrapped W) NWBase[W] {
 
 // SetArrivalRate sets the arrival rate for a specific method.
 // This forwards the rate to the underlying disk for contention modeling.
+// Components that do not model contention return an error wrapping
+// components.ErrNoArrivalRates.
 func (s *NWBase[W]) SetArrivalRate(method string, rate float64) error {
 	if setter, ok := any(s.Wrapped).(interface{ SetArrivalRate(string, float64) error }); ok {
 		return setter.SetArrivalRate(method, rate)
 	}
-	return fmt.Errorf("WrappedComponent %T has no SetArrivalRate", s.Wrapped)
+	return fmt.Errorf("component %s (%T) %w", s.Name, s.Wrapped, components.ErrNoArrivalRates)
 }
 
 // GetArrivalRate returns the arrival rate for a specific method.
@@ -88,6 +90,12 @@ func (b *NWBase[W]) GetUtilizationInfo() []components.UtilizationInfo {
 	return []components.UtilizationInfo{}
 }
 
+// HasRunState delegates to the wrapped component if it is stateful
+func (b *NWBase[W]) HasRunState() bool {
+	stateful, ok := any(b.Wrapped).(components.Stateful)
+	return ok && stateful.HasRunState()
+}
+
 func (n *NWBase[W]) Set(name string, value decl.Value) error {
 	n.Modified = true
 
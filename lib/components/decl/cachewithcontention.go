@@ -19,6 +19,11 @@ func (c *CacheWithContention) Read() any {
 	return OutcomesToValue(c.Wrapped.Read())
 }
 
+// GetMethod implements the SDL method Get, see runtime.InvokeMethod.
+func (c *CacheWithContention) GetMethod() any {
+	return OutcomesToValue(c.Wrapped.Get())
+}
+
 func (c *CacheWithContention) Write() any {
 	return OutcomesToValue(c.Wrapped.Write())
 }
@@ -0,0 +1,32 @@
+package decl
+
+import (
+	"math/rand"
+
+	"github.com/panyam/sdl/lib/components"
+	"github.com/panyam/sdl/lib/decl"
+)
+
+type LoadBalancer struct {
+	NWBase[*components.LoadBalancer]
+}
+
+func NewLoadBalancer(name string) *LoadBalancer {
+	return &LoadBalancer{NWBase: NewNWBase(name, components.NewLoadBalancer(name))}
+}
+
+func (lb *LoadBalancer) Route() (v decl.Value) {
+	return OutcomesToValue(lb.Wrapped.Route())
+}
+
+// ForwardTarget sends each call to Route on to Method of a backend picked by
+// the load balancer.
+func (lb *LoadBalancer) ForwardTarget(method string, rnd *rand.Rand) (target, targetMethod string) {
+	if method != "Route" {
+		return "", ""
+	}
+	if backend := lb.Wrapped.PickBackend(rnd); backend != "" {
+		return backend, lb.Wrapped.Method
+	}
+	return "", ""
+}
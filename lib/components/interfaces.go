@@ -1,5 +1,7 @@
 package components
 
+import "errors"
+
 // FlowPattern describes the traffic behavior of a component method
 type FlowPattern struct {
 	// Output traffic to other components
@@ -34,6 +36,18 @@ type UtilizationProvider interface {
 	GetUtilizationInfo() []UtilizationInfo
 }
 
+// Stateful is an interface for components whose outcomes depend on the calls
+// they have already served, eg a cache that is still warming up.
+type Stateful interface {
+	// HasRunState returns true while calls can still change the outcomes of later calls.
+	HasRunState() bool
+}
+
+// ErrNoArrivalRates is returned when an arrival rate is set on a component
+// that does not model contention, which behaves as if it had infinite
+// capacity.
+var ErrNoArrivalRates = errors.New("does not model arrival rates")
+
 // UtilizationInfo represents utilization information for a resource.
 type UtilizationInfo struct {
 	// ResourceName identifies the resource (e.g., "pool", "disk", "cpu")
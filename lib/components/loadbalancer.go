@@ -0,0 +1,171 @@
+package components
+
+import (
+	"math"
+	"math/rand"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"github.com/panyam/sdl/lib/core"
+)
+
+// Load balancing strategies supported by LoadBalancer
+const (
+	LBRoundRobin     = "roundrobin"
+	LBWeighted       = "weighted"
+	LBConsistentHash = "consistenthash"
+)
+
+// LoadBalancer routes each request to one of a set of backends.
+//
+// Backends are named by their system level instance names (comma separated)
+// and are all invoked via the same method (Method).  The share of traffic a
+// backend receives depends on the Strategy:
+//
+//   - roundrobin:     every backend gets an even share.
+//   - weighted:       shares are proportional to Weights (e.g. "3,1").
+//   - consistenthash: even for uniformly distributed keys.  Skew > 0 models a
+//     zipf-like key distribution where backend i receives a share
+//     proportional to 1/(i+1)^Skew.
+//
+// Route takes RoutingLatency to pick a backend with PickBackend, and the
+// evaluator then calls Method on that backend, so the outcome of a call is
+// the outcome of the chosen backend.
+type LoadBalancer struct {
+	Name string
+
+	Strategy string  // One of roundrobin, weighted, consistenthash
+	Backends string  // Comma separated backend instance names
+	Method   string  // Method invoked on the chosen backend
+	Weights  string  // Comma separated weights (weighted strategy only)
+	Skew     float64 // Key skew for consistenthash (0 = uniform)
+
+	// Latency of the routing decision itself
+	RoutingLatency float64
+
+	// Requests routed so far, to rotate through the backends in roundrobin
+	routed atomic.Uint64
+}
+
+// Init initializes the LoadBalancer with default parameters.
+func (lb *LoadBalancer) Init() {
+	if lb.Strategy == "" {
+		lb.Strategy = LBRoundRobin
+	}
+	if lb.Method == "" {
+		lb.Method = "Handle"
+	}
+	if lb.RoutingLatency == 0 {
+		lb.RoutingLatency = 0.00005 // 50µs
+	}
+}
+
+// NewLoadBalancer creates and initializes a new LoadBalancer component.
+func NewLoadBalancer(name string) *LoadBalancer {
+	lb := &LoadBalancer{Name: name}
+	lb.Init()
+	return lb
+}
+
+// BackendNames returns the list of configured backend instance names.
+func (lb *LoadBalancer) BackendNames() (out []string) {
+	for _, b := range strings.Split(lb.Backends, ",") {
+		if b = strings.TrimSpace(b); b != "" {
+			out = append(out, b)
+		}
+	}
+	return
+}
+
+// Shares returns the fraction of traffic each backend receives.  The
+// returned slice is parallel to BackendNames and sums to 1 (unless there
+// are no backends).
+func (lb *LoadBalancer) Shares() []float64 {
+	n := len(lb.BackendNames())
+	if n == 0 {
+		return nil
+	}
+	shares := make([]float64, n)
+	switch lb.Strategy {
+	case LBWeighted:
+		weights := strings.Split(lb.Weights, ",")
+		for i := range shares {
+			shares[i] = 1
+			if i < len(weights) {
+				if w, err := strconv.ParseFloat(strings.TrimSpace(weights[i]), 64); err == nil && w >= 0 {
+					shares[i] = w
+				}
+			}
+		}
+	case LBConsistentHash:
+		for i := range shares {
+			shares[i] = 1 / math.Pow(float64(i+1), lb.Skew)
+		}
+	default:
+		for i := range shares {
+			shares[i] = 1
+		}
+	}
+
+	total := 0.0
+	for _, s := range shares {
+		total += s
+	}
+	if total <= 0 {
+		for i := range shares {
+			shares[i] = 1 / float64(n)
+		}
+		return shares
+	}
+	for i := range shares {
+		shares[i] /= total
+	}
+	return shares
+}
+
+// PickBackend returns the backend the next request goes to, or "" when there
+// are no backends.  Roundrobin rotates through the backends in order while
+// the other strategies draw one with the probabilities of Shares.
+func (lb *LoadBalancer) PickBackend(rnd *rand.Rand) string {
+	backends := lb.BackendNames()
+	if len(backends) == 0 {
+		return ""
+	}
+	if lb.Strategy != LBWeighted && lb.Strategy != LBConsistentHash {
+		return backends[(lb.routed.Add(1)-1)%uint64(len(backends))]
+	}
+	r := rnd.Float64()
+	for i, share := range lb.Shares() {
+		if r < share {
+			return backends[i]
+		}
+		r -= share
+	}
+	return backends[len(backends)-1]
+}
+
+// Route models the routing decision.  It always succeeds after a small
+// routing latency, the chosen backend is called separately.
+func (lb *LoadBalancer) Route() *core.Outcomes[core.AccessResult] {
+	return (&core.Outcomes[core.AccessResult]{And: core.AndAccessResults}).
+		Add(1.0, core.AccessResult{Success: true, Latency: core.Duration(lb.RoutingLatency)})
+}
+
+// GetFlowPattern implements FlowAnalyzable interface for LoadBalancer.
+// Route splits its arrival rate across the backends according to the strategy.
+func (lb *LoadBalancer) GetFlowPattern(methodName string, inputRate float64) FlowPattern {
+	outflows := map[string]float64{}
+	if methodName == "Route" {
+		backends := lb.BackendNames()
+		for i, share := range lb.Shares() {
+			outflows[backends[i]+"."+lb.Method] += inputRate * share
+		}
+	}
+	return FlowPattern{
+		Outflows:      outflows,
+		SuccessRate:   1.0,
+		Amplification: 1.0,
+		ServiceTime:   lb.RoutingLatency,
+	}
+}
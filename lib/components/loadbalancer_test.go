@@ -0,0 +1,53 @@
+package components
+
+import (
+	"testing"
+)
+
+func TestLoadBalancer_WeightedSplit(t *testing.T) {
+	lb := NewLoadBalancer("lb")
+	lb.Strategy = LBWeighted
+	lb.Backends = "app1, app2"
+	lb.Weights = "3,1"
+
+	pattern := lb.GetFlowPattern("Route", 100)
+	if len(pattern.Outflows) != 2 {
+		t.Fatalf("Expected 2 outflows, got %d: %v", len(pattern.Outflows), pattern.Outflows)
+	}
+	if !approxEqualTest(pattern.Outflows["app1.Handle"], 75, 1e-9) {
+		t.Errorf("app1 rate mismatch: exp 75, got %.4f", pattern.Outflows["app1.Handle"])
+	}
+	if !approxEqualTest(pattern.Outflows["app2.Handle"], 25, 1e-9) {
+		t.Errorf("app2 rate mismatch: exp 25, got %.4f", pattern.Outflows["app2.Handle"])
+	}
+}
+
+func TestLoadBalancer_EvenAndSkewedSplits(t *testing.T) {
+	lb := NewLoadBalancer("lb")
+	lb.Backends = "a,b,c,d"
+	lb.Method = "Get"
+
+	for _, strategy := range []string{LBRoundRobin, LBConsistentHash} {
+		lb.Strategy = strategy
+		for target, rate := range lb.GetFlowPattern("Route", 40).Outflows {
+			if !approxEqualTest(rate, 10, 1e-9) {
+				t.Errorf("%s: %s expected 10 RPS, got %.4f", strategy, target, rate)
+			}
+		}
+	}
+
+	// With skew the first backend is the hottest and shares still add up
+	lb.Strategy = LBConsistentHash
+	lb.Skew = 1
+	shares := lb.Shares()
+	total := 0.0
+	for i, s := range shares {
+		total += s
+		if i > 0 && s >= shares[i-1] {
+			t.Errorf("Expected decreasing shares with skew, got %v", shares)
+		}
+	}
+	if !approxEqualTest(total, 1, 1e-9) {
+		t.Errorf("Shares should sum to 1, got %.4f", total)
+	}
+}
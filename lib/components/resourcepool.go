@@ -42,6 +42,11 @@ type ResourcePool struct {
 	ArrivalRate float64 // λ: Average rate requests for this pool arrive (items/sec)
 	AvgHoldTime float64 // Ts: Average time resource is held once acquired (seconds/item)
 
+	// MaxThroughput is the rate (items/sec) the whole pool can serve.  When
+	// set the hold time is derived from it as Size / MaxThroughput in place
+	// of AvgHoldTime.
+	MaxThroughput float64
+
 	// --- Computed Metrics (for observability) ---
 	lastUtilization float64 // Last calculated utilization (ρ)
 }
@@ -72,13 +77,20 @@ func NewResourcePool(name string) *ResourcePool {
 	return rp
 }
 
+// HoldTime returns the average hold time of a resource, derived from
+// MaxThroughput when it is set.
+func (rp *ResourcePool) HoldTime() float64 {
+	if rp.MaxThroughput > 0 {
+		return float64(rp.Size) / rp.MaxThroughput
+	}
+	return rp.AvgHoldTime
+}
+
 // calculateMMCMetrics performs the M/M/c calculation and returns (isStable, avgWaitTimeQ).
 // This is extracted from the Acquire method for testing purposes.
 func (rp *ResourcePool) calculateMMCMetrics() (bool, float64) {
-	if rp.AvgHoldTime < 1e-12 {
-		rp.AvgHoldTime = 1e-12 // Avoid division by zero
-	}
-	serviceRate := 1.0 / rp.AvgHoldTime
+	holdTime := max(rp.HoldTime(), 1e-12) // Avoid division by zero
+	serviceRate := 1.0 / holdTime
 	offeredLoad := rp.ArrivalRate / serviceRate
 	utilization := offeredLoad / float64(rp.Size)
 	rp.lastUtilization = utilization // Store for observability
@@ -117,7 +129,7 @@ func (rp *ResourcePool) calculateMMCMetrics() (bool, float64) {
 
 // Acquire predicts the queuing delay for acquiring one resource from the pool.
 // It dynamically calculates the steady-state M/M/c queuing delay (Wq) based on
-// the component's current ArrivalRate and AvgHoldTime parameters.
+// the component's current ArrivalRate and hold time.
 //
 // Returns Outcomes[AccessResult]:
 //   - Success=true with queuing delay if the pool is stable (utilization < 1).
@@ -190,10 +202,10 @@ func (rp *ResourcePool) GetTotalArrivalRate() float64 {
 // GetUtilization returns the current utilization (ρ) of the resource pool.
 // Values close to 1.0 indicate the system is approaching instability.
 func (rp *ResourcePool) GetUtilization() float64 {
-	if rp.AvgHoldTime < 1e-12 || rp.Size == 0 {
+	if rp.HoldTime() < 1e-12 || rp.Size == 0 {
 		return 0
 	}
-	serviceRate := 1.0 / rp.AvgHoldTime
+	serviceRate := 1.0 / rp.HoldTime()
 	offeredLoad := rp.ArrivalRate / serviceRate
 	return offeredLoad / float64(rp.Size)
 }
@@ -226,7 +238,7 @@ func (rp *ResourcePool) GetFlowPattern(methodName string, inputRate float64) Flo
 		}
 
 		// Calculate utilization and stability
-		serviceRate := 1.0 / rp.AvgHoldTime
+		serviceRate := 1.0 / rp.HoldTime()
 		utilization := currentArrivalRate / (serviceRate * float64(rp.Size))
 
 		// Determine success rate based on utilization
@@ -243,7 +255,7 @@ func (rp *ResourcePool) GetFlowPattern(methodName string, inputRate float64) Flo
 			Outflows:      map[string]float64{}, // ResourcePool is typically a leaf node
 			SuccessRate:   successRate,
 			Amplification: 1.0, // Input rate = output rate for successful acquisitions
-			ServiceTime:   rp.AvgHoldTime,
+			ServiceTime:   rp.HoldTime(),
 		}
 
 	case "Release":
@@ -0,0 +1,64 @@
+package core
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// DefaultDisplayPrecision is the number of significant figures used when
+// displaying values for humans.
+const DefaultDisplayPrecision = 3
+
+// durationUnits are the units FormatDuration picks from, largest first.
+var durationUnits = []struct {
+	Suffix string
+	Scale  float64 // Seconds per unit
+}{
+	{"s", 1},
+	{"ms", 1e-3},
+	{"µs", 1e-6},
+	{"ns", 1e-9},
+}
+
+// FormatSignificant formats v with the given number of significant figures
+// without resorting to exponent notation and with trailing zeros removed.
+// eg FormatSignificant(12.345, 3) = "12.3", FormatSignificant(1500, 3) = "1500"
+func FormatSignificant(v float64, precision int) string {
+	if precision <= 0 {
+		precision = DefaultDisplayPrecision
+	}
+	if v == 0 || math.IsNaN(v) || math.IsInf(v, 0) {
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	magnitude := int(math.Floor(math.Log10(math.Abs(v))))
+	decimals := max(0, precision-1-magnitude)
+	out := strconv.FormatFloat(v, 'f', decimals, 64)
+	if strings.Contains(out, ".") {
+		out = strings.TrimRight(strings.TrimRight(out, "0"), ".")
+	}
+	return out
+}
+
+// FormatDuration formats a duration (in seconds) in the largest unit in which
+// it is at least 1, eg 0.00015 -> "150µs", 0.0123 -> "12.3ms", 2.5 -> "2.5s".
+func FormatDuration(d Duration, precision int) string {
+	abs := math.Abs(d)
+	if abs == 0 || math.IsNaN(d) || math.IsInf(d, 0) {
+		return FormatSignificant(d, precision) + "s"
+	}
+	unit := durationUnits[len(durationUnits)-1]
+	for _, u := range durationUnits {
+		if abs >= u.Scale {
+			unit = u
+			break
+		}
+	}
+	scaled := d / unit.Scale
+	// Rounding may push a value into the next unit up (eg 999.96µs -> 1000µs)
+	formatted := FormatSignificant(scaled, precision)
+	if f, _ := strconv.ParseFloat(formatted, 64); math.Abs(f) >= 1000 && unit.Scale < 1 {
+		return FormatDuration(math.Copysign(math.Abs(f)*unit.Scale, d), precision)
+	}
+	return formatted + unit.Suffix
+}
@@ -0,0 +1,49 @@
+package core
+
+import (
+	"testing"
+)
+
+func TestFormatDuration(t *testing.T) {
+	cases := []struct {
+		d        Duration
+		expected string
+	}{
+		{0, "0s"},
+		{0.0000001, "100ns"},
+		{0.00015, "150µs"},
+		{0.0012345, "1.23ms"},
+		{0.0123, "12.3ms"},
+		{0.25, "250ms"},
+		{1, "1s"},
+		{2.5, "2.5s"},
+		{123.456, "123s"},
+		{0.00099996, "1ms"},
+		{-0.002, "-2ms"},
+	}
+	for _, c := range cases {
+		if got := FormatDuration(c.d, 3); got != c.expected {
+			t.Errorf("FormatDuration(%g): expected %q, got %q", c.d, c.expected, got)
+		}
+	}
+}
+
+func TestFormatSignificant(t *testing.T) {
+	cases := []struct {
+		v         float64
+		precision int
+		expected  string
+	}{
+		{12.345, 3, "12.3"},
+		{1500, 3, "1500"},
+		{0.000123456, 2, "0.00012"},
+		{9.996, 3, "10"},
+		{0.5, 3, "0.5"},
+		{42, 0, "42"},
+	}
+	for _, c := range cases {
+		if got := FormatSignificant(c.v, c.precision); got != c.expected {
+			t.Errorf("FormatSignificant(%g, %d): expected %q, got %q", c.v, c.precision, c.expected, got)
+		}
+	}
+}
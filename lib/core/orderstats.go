@@ -0,0 +1,96 @@
+package core
+
+import "slices"
+
+// MaxOf returns the distribution of waiting for one draw from each of
+// outcomes made independently and in parallel: the wait takes as long as the
+// slowest draw and succeeds only if every draw does.  This is the order
+// statistic that amplifies the tail of a fan out, eg the p99 of waiting for
+// 10 backends is near the p99.9 of one.
+//
+// Over the distinct latencies x of the inputs, with G(x) the probability a
+// draw succeeds within x and F(x) that it finishes within x, the wait
+// succeeds within x with probability the product of the G(x) and finishes
+// within x with probability the product of the F(x).
+func MaxOf(outcomes ...*Outcomes[AccessResult]) *Outcomes[AccessResult] {
+	if len(outcomes) == 0 {
+		return nil
+	}
+	var latencies []Duration
+	for _, o := range outcomes {
+		for _, bucket := range o.Buckets {
+			latencies = append(latencies, bucket.Value.Latency)
+		}
+	}
+	slices.Sort(latencies)
+	latencies = slices.Compact(latencies)
+
+	succeeded, finished := make([]float64, len(latencies)), make([]float64, len(latencies))
+	for i := range latencies {
+		succeeded[i], finished[i] = 1, 1
+	}
+	for _, o := range outcomes {
+		total := o.TotalWeight()
+		if total <= 0 {
+			return nil
+		}
+		g, f := cumulativeAccessResults(o, latencies)
+		for i := range latencies {
+			succeeded[i] *= g[i] / total
+			finished[i] *= f[i] / total
+		}
+	}
+
+	out := &Outcomes[AccessResult]{And: AndAccessResults}
+	prevSucceeded, prevFailed := 0.0, 0.0
+	for i, latency := range latencies {
+		failed := finished[i] - succeeded[i]
+		if weight := succeeded[i] - prevSucceeded; weight > 0 {
+			out.Add(weight, AccessResult{true, latency})
+		}
+		if weight := failed - prevFailed; weight > 0 {
+			out.Add(weight, AccessResult{false, latency})
+		}
+		prevSucceeded, prevFailed = succeeded[i], failed
+	}
+	return out
+}
+
+// MaxOfN returns the distribution of waiting for n independent draws from
+// o, see MaxOf.
+func MaxOfN(o *Outcomes[AccessResult], n int) *Outcomes[AccessResult] {
+	if n <= 0 {
+		return nil
+	}
+	outcomes := make([]*Outcomes[AccessResult], n)
+	for i := range outcomes {
+		outcomes[i] = o
+	}
+	return MaxOf(outcomes...)
+}
+
+// cumulativeAccessResults returns the weight of o that succeeded and that
+// finished within each of the ascending latencies.
+func cumulativeAccessResults(o *Outcomes[AccessResult], latencies []Duration) (succeeded, finished []float64) {
+	buckets := slices.Clone(o.Buckets)
+	slices.SortFunc(buckets, func(a, b Bucket[AccessResult]) int {
+		if a.Value.Latency < b.Value.Latency {
+			return -1
+		} else if a.Value.Latency > b.Value.Latency {
+			return 1
+		}
+		return 0
+	})
+	succeeded, finished = make([]float64, len(latencies)), make([]float64, len(latencies))
+	next, g, f := 0, 0.0, 0.0
+	for i, latency := range latencies {
+		for ; next < len(buckets) && buckets[next].Value.Latency <= latency; next++ {
+			f += buckets[next].Weight
+			if buckets[next].Value.Success {
+				g += buckets[next].Weight
+			}
+		}
+		succeeded[i], finished[i] = g, f
+	}
+	return
+}
@@ -0,0 +1,55 @@
+package core
+
+import (
+	"math"
+	"testing"
+)
+
+// TestMaxOfNFanOut verifies that waiting for 10 backends amplifies the tail:
+// the p99 of the wait is the latency the slowest of 10 draws reaches 99% of
+// the time, not the p99 of one backend.
+func TestMaxOfNFanOut(t *testing.T) {
+	backend := &Outcomes[AccessResult]{}
+	backend.Add(90, AccessResult{true, Millis(10)})
+	backend.Add(9, AccessResult{true, Millis(50)})
+	backend.Add(1, AccessResult{true, Millis(200)})
+
+	fanOut := MaxOfN(backend, 10)
+	if !approxEqual(fanOut.TotalWeight(), 1, 1e-9) {
+		t.Errorf("TotalWeight mismatch: expected 1, got %f", fanOut.TotalWeight())
+	}
+	// P(max <= 10ms) = 0.9^10 and P(max <= 50ms) = 0.99^10 ~ 0.904, so the
+	// p99 of the max is 200ms although a single backend's p99 is 50ms
+	if p99 := PercentileLatency(backend, 0.99); !approxEqual(p99, Millis(50), 1e-9) {
+		t.Errorf("backend P99 mismatch: expected 50ms, got %f", p99)
+	}
+	if p99 := PercentileLatency(fanOut, 0.99); !approxEqual(p99, Millis(200), 1e-9) {
+		t.Errorf("fan out P99 mismatch: expected 200ms, got %f", p99)
+	}
+	if p50 := PercentileLatency(fanOut, 0.5); !approxEqual(p50, Millis(50), 1e-9) {
+		t.Errorf("fan out P50 mismatch: expected 50ms, got %f", p50)
+	}
+	expectedMean := Millis(10)*math.Pow(0.9, 10) + Millis(50)*(math.Pow(0.99, 10)-math.Pow(0.9, 10)) + Millis(200)*(1-math.Pow(0.99, 10))
+	if mean := MeanLatency(fanOut); !approxEqual(mean, expectedMean, 1e-12) {
+		t.Errorf("fan out mean mismatch: expected %f, got %f", expectedMean, mean)
+	}
+}
+
+// TestMaxOfFailures verifies that a wait for independent draws succeeds only
+// when every draw does.
+func TestMaxOfFailures(t *testing.T) {
+	backend := &Outcomes[AccessResult]{}
+	backend.Add(0.999, AccessResult{true, Millis(10)})
+	backend.Add(0.001, AccessResult{false, Millis(1)})
+
+	fanOut := MaxOf(backend, backend, backend)
+	if availability := Availability(fanOut); !approxEqual(availability, math.Pow(0.999, 3), 1e-12) {
+		t.Errorf("Availability mismatch: expected %f, got %f", math.Pow(0.999, 3), availability)
+	}
+	if !approxEqual(fanOut.TotalWeight(), 1, 1e-9) {
+		t.Errorf("TotalWeight mismatch: expected 1, got %f", fanOut.TotalWeight())
+	}
+	if MaxOfN(backend, 0) != nil {
+		t.Errorf("expected no distribution for zero draws")
+	}
+}
@@ -1,7 +1,9 @@
 package core
 
 import (
+	"fmt"
 	"log"
+	"math"
 	"math/rand"
 )
 
@@ -236,44 +238,41 @@ func And[V Outcome, U Outcome, Z Outcome](this *Outcomes[V], that *Outcomes[U],
 // The caller should provide a properly seeded rand.Rand source.
 // Returns the zero value of V and false if outcomes are nil, empty, or have zero total weight.
 func (o *Outcomes[V]) Sample(rng *rand.Rand) (result V, ok bool) {
+	idx, ok := o.SampleIndex(rng)
+	if ok {
+		result = o.Buckets[idx].Value
+	}
+	return
+}
+
+// SampleIndex draws a bucket like Sample but returns its index, eg to
+// report which branch of a distribution was taken.
+func (o *Outcomes[V]) SampleIndex(rng *rand.Rand) (idx int, ok bool) {
 	if o == nil || o.Len() == 0 || rng == nil {
 		// Cannot sample from nil or empty distribution, or without RNG
-		ok = false
-		return // Returns zero value for V
+		return -1, false
 	}
 
 	totalWeight := o.TotalWeight()
 	if totalWeight <= 1e-12 { // Consider total weight effectively zero
 		// Cannot sample if total weight is zero
-		ok = false
-		return // Returns zero value for V
+		return -1, false
 	}
 
 	// Generate a random float64 between 0.0 and totalWeight
 	target := rng.Float64() * totalWeight
 
 	cumulativeWeight := 0.0
-	for _, bucket := range o.Buckets {
+	for i, bucket := range o.Buckets {
 		cumulativeWeight += bucket.Weight
 		if cumulativeWeight >= target {
-			result = bucket.Value // Found the bucket
-			ok = true
-			return
+			return i, true // Found the bucket
 		}
 	}
 
 	// Should not be reached if totalWeight > 0, but as a fallback,
-	// return the last bucket's value if floating point issues occur.
-	// Check Len again just in case.
-	if o.Len() > 0 {
-		result = o.Buckets[o.Len()-1].Value
-		ok = true
-		return
-	}
-
-	// Should be truly unreachable now
-	ok = false
-	return
+	// return the last bucket if floating point issues occur.
+	return o.Len() - 1, true
 }
 
 // GetValue returns the value if there's exactly one bucket, otherwise returns zero value.
@@ -300,3 +299,63 @@ func (o *Outcomes[V]) ScaleWeights(factor float64) {
 	// Remove zero-weight buckets? Optional cleanup.
 	// o.Buckets = slices.DeleteFunc(o.Buckets, func(b Bucket[V]) bool { return b.Weight < 1e-12 })
 }
+
+// WeightTolerance is the relative amount sampling weights may be off, eg
+// through floating point drift, and still be accepted.
+const WeightTolerance = 1e-3
+
+// NormalizeWeights cleans up sampling weights computed from expressions so
+// numeric noise does not skew or break sampling.  Weights that are negative
+// by no more than the tolerance are clamped to 0.
+//
+// If total is positive the weights are out of total: they must sum to it
+// within the tolerance, or to at most it when partial is set (the rest going
+// to a default), and are rescaled to sum to it exactly.  Otherwise they are
+// relative weights that must have a positive sum and are rescaled to sum to
+// 1 when within the tolerance of it.
+func NormalizeWeights(weights []float64, total float64, partial bool) ([]float64, error) {
+	scale := total
+	if total <= 0 {
+		scale = 0
+		for _, w := range weights {
+			scale += max(w, 0)
+		}
+	}
+	tolerance := WeightTolerance * scale
+
+	out := make([]float64, len(weights))
+	sum := 0.0
+	for idx, w := range weights {
+		if w < -tolerance {
+			return nil, fmt.Errorf("weight %d is negative (%g)", idx, w)
+		}
+		out[idx] = max(w, 0)
+		sum += out[idx]
+	}
+
+	target := total
+	if total <= 0 {
+		if sum <= 0 {
+			return nil, fmt.Errorf("weights sum to %g, they must have a positive sum", sum)
+		}
+		if math.Abs(sum-1) > WeightTolerance {
+			return out, nil
+		}
+		target = 1
+	} else if sum > total+tolerance {
+		return nil, fmt.Errorf("weights sum to %g, more than their total of %g", sum, total)
+	} else if sum < total-tolerance {
+		if !partial {
+			return nil, fmt.Errorf("weights sum to %g, less than their total of %g", sum, total)
+		}
+		return out, nil
+	} else if partial && sum <= total {
+		return out, nil
+	}
+	if sum > 0 {
+		for idx := range out {
+			out[idx] *= target / sum
+		}
+	}
+	return out, nil
+}
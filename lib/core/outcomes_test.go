@@ -2,7 +2,9 @@ package core
 
 import (
 	"log"
+	"math"
 	"math/rand"
+	"strings"
 	"testing"
 	"time"
 )
@@ -129,3 +131,50 @@ func TestOutcomes_GetValue(t *testing.T) {
 		t.Errorf("GetValue returned wrong value: exp 10, got %d", v)
 	}
 }
+
+// TestNormalizeWeights verifies that weights off by numeric noise are
+// renormalized while negative ones or ones far from their total are rejected.
+func TestNormalizeWeights(t *testing.T) {
+	weights, err := NormalizeWeights([]float64{0.6, 0.3999}, 0, false)
+	if err != nil {
+		t.Fatalf("weights summing to 0.9999 should be accepted: %v", err)
+	}
+	if sum := weights[0] + weights[1]; math.Abs(sum-1) > 1e-12 {
+		t.Errorf("expected weights renormalized to 1, got %g", sum)
+	}
+
+	// Relative weights are left as they are
+	weights, _ = NormalizeWeights([]float64{80, 20}, 0, false)
+	if weights[0] != 80 || weights[1] != 20 {
+		t.Errorf("expected relative weights unchanged, got %v", weights)
+	}
+
+	// Drift below zero is clamped, within a total too
+	weights, err = NormalizeWeights([]float64{100.05, -1e-6}, 100, false)
+	if err != nil || weights[0] != 100 || weights[1] != 0 {
+		t.Errorf("expected [100 0], got %v (%v)", weights, err)
+	}
+
+	// A default takes what the cases leave
+	weights, err = NormalizeWeights([]float64{50, 20}, 100, true)
+	if err != nil || weights[0] != 50 || weights[1] != 20 {
+		t.Errorf("expected partial weights unchanged, got %v (%v)", weights, err)
+	}
+
+	for _, tc := range []struct {
+		weights []float64
+		total   float64
+		partial bool
+		err     string
+	}{
+		{[]float64{-0.1}, 0, false, "weight 0 is negative"},
+		{[]float64{0.2, -0.3}, 0, false, "weight 1 is negative"},
+		{[]float64{0, 0}, 0, false, "they must have a positive sum"},
+		{[]float64{70, 40}, 100, true, "more than their total of 100"},
+		{[]float64{70, 20}, 100, false, "less than their total of 100"},
+	} {
+		if _, err := NormalizeWeights(tc.weights, tc.total, tc.partial); err == nil || !strings.Contains(err.Error(), tc.err) {
+			t.Errorf("NormalizeWeights(%v, %g): expected error containing %q, got %v", tc.weights, tc.total, tc.err, err)
+		}
+	}
+}
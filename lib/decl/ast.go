@@ -27,10 +27,48 @@ type Node interface {
 	PrettyPrint(cp CodePrinter)
 }
 
-// Annotations are tings that can be added to other constructs via @ syntax
+// Annotation is an `@name` or `@name(arg = value, ...)` marker placed before a
+// declaration, eg `@memoize(ttl = 5s)` before a method.
 type Annotation struct {
-	Node
-	Key *IdentifierExpr
+	NodeInfo
+	Name *IdentifierExpr
+	Args []*AssignmentStmt
+}
+
+func (a *Annotation) String() string {
+	if len(a.Args) == 0 {
+		return "@" + a.Name.Value
+	}
+	var parts []string
+	for _, arg := range a.Args {
+		parts = append(parts, fmt.Sprintf("%s = %s", arg.Var.Value, arg.Value))
+	}
+	return fmt.Sprintf("@%s(%s)", a.Name.Value, strings.Join(parts, ", "))
+}
+func (a *Annotation) PrettyPrint(cp CodePrinter) {
+	cp.Print(a.String())
+}
+
+// Arg returns the value of the named argument or nil if it is not given.
+func (a *Annotation) Arg(name string) Expr {
+	for _, arg := range a.Args {
+		if arg.Var.Value == name {
+			return arg.Value
+		}
+	}
+	return nil
+}
+
+// KnownAnnotations are the annotations that may be placed on a method, with
+// the types of their arguments.  Durations are Floats in seconds.
+var KnownAnnotations = map[string]map[string]*Type{
+	"memoize": {
+		"ttl":      FloatType, // How long an outcome stays cached
+		"hit_rate": FloatType, // Probability a call is served from the cache
+	},
+	"coalesce": {
+		"window": FloatType, // How long a call is in flight for identical calls to join
+	},
 }
 
 // --- Base Struct ---
@@ -44,15 +82,43 @@ func (n *NodeInfo) stmtNode()     {}
 
 // --- Top Level declarations ---
 
-// OptionsDecl represents `options { ... }` (structure TBD)
+// OptionsDecl represents `options { name = value, ... }`.  Options at the top
+// level of a file are defaults for every system in it and options inside a
+// system override them.
 type OptionsDecl struct {
 	NodeInfo
-	Body *BlockStmt // Placeholder for options assignments?
+	Options []*AssignmentStmt
 }
 
-func (o *OptionsDecl) systemBodyItemNode()        {}
-func (o *OptionsDecl) String() string             { return "options { ... }" }
-func (o *OptionsDecl) PrettyPrint(cp CodePrinter) { cp.Println("options { ... }") }
+func (o *OptionsDecl) systemBodyItemNode() {}
+func (o *OptionsDecl) String() string {
+	var parts []string
+	for _, opt := range o.Options {
+		parts = append(parts, fmt.Sprintf("%s = %s", opt.Var.Value, opt.Value))
+	}
+	return "options { " + strings.Join(parts, ", ") + " }"
+}
+func (o *OptionsDecl) PrettyPrint(cp CodePrinter) {
+	cp.Println("options {")
+	WithIndent(1, cp, func(cp CodePrinter) {
+		for _, opt := range o.Options {
+			cp.Printf("%s = ", opt.Var.Value)
+			opt.Value.PrettyPrint(cp)
+			cp.Println("")
+		}
+	})
+	cp.Println("}")
+}
+
+// KnownOptions are the options that may be set in an options block, with
+// their types.
+var KnownOptions = map[string]*Type{
+	"seed":               IntType,   // Default random seed for runs
+	"max_depth":          IntType,   // Maximum nested call depth
+	"precision":          IntType,   // Significant figures when displaying values
+	"strict_params":      BoolType,  // Reject runtime sets of params fixed by uses overrides
+	"branch_probability": FloatType, // Flow probability of if conditions whose outcome is unknown
+}
 
 // EnumDecl represents `enum Name { Val1, Val2, ... };`
 type EnumDecl struct {
@@ -105,15 +171,23 @@ type ImportDecl struct {
 	Alias        *IdentifierExpr
 	ImportedItem *IdentifierExpr
 
+	// Declared with `export ... from` so files importing this one can import
+	// the item from it too
+	Exported bool
+
 	ResolvedFullPath string // Full path to the imported item, resolved after loading (recursively if needed)
 	ResolvedItem     Node   // Resolves to a ComponentDecl or Method or Param in the ResolvedFullPath module
 }
 
 func (i *ImportDecl) String() string {
+	keyword := "import"
+	if i.Exported {
+		keyword = "export"
+	}
 	if i.Alias != nil {
-		return fmt.Sprintf("import %s as %s from '%s';", i.ImportedItem.Value, i.Alias.Value, i.Path)
+		return fmt.Sprintf("%s %s as %s from '%s';", keyword, i.ImportedItem.Value, i.Alias.Value, i.Path)
 	} else {
-		return fmt.Sprintf("import %s from '%s';", i.ImportedItem.Value, i.Path)
+		return fmt.Sprintf("%s %s from '%s';", keyword, i.ImportedItem.Value, i.Path)
 	}
 }
 
@@ -151,6 +225,13 @@ type SystemDecl struct {
 	// Resolved during inference from metric(...) calls in Body
 	Metrics []*MetricSpec
 
+	// Scenarios in Body, checked during inference
+	Scenarios []*ScenarioDecl
+
+	// Effective options resolved during inference: the file's options
+	// overridden by those in Body
+	Options map[string]Value
+
 	// File declaration this System is declared in
 	ParentFileDecl *FileDecl
 }
@@ -213,7 +294,7 @@ type MetricSpec struct {
 	Name          string  // Metric identifier (e.g., "request_latency")
 	ComponentPath string  // Dot-separated component path (e.g., "arch.webserver")
 	MethodName    string  // Target method name (e.g., "RequestRide"), empty for utilization
-	MetricType    string  // "count", "latency", "utilization"
+	MetricType    string  // "count", "latency", "utilization", "value"
 	Aggregation   string  // "sum", "avg", "min", "max", "p50", "p90", "p95", "p99"
 	Window        float64 // Aggregation window in seconds (default 10.0)
 }
@@ -301,6 +382,86 @@ type ExpectationsDecl struct {
 
 func (e *ExpectationsDecl) String() string { return "expect { ... }" }
 
+// ScenarioDecl is a load test embedded in a system body:
+//
+//	scenario LoadTest {
+//	  generator server.Handle at 50/s for 10s;
+//	  expect p99 server.Handle < 100ms;
+//	}
+//
+// Generators drive the system in virtual time and every expectation is
+// checked against the latencies recorded while they ran.
+type ScenarioDecl struct {
+	NodeInfo
+	Name       *IdentifierExpr
+	Generators []*ScenarioGenerator
+	Expects    []*ScenarioExpect
+
+	// Resolved during inference: the longest generator's duration in seconds
+	Duration float64
+}
+
+func (s *ScenarioDecl) systemBodyItemNode() {}
+func (s *ScenarioDecl) String() string {
+	return fmt.Sprintf("scenario %s { ... }", s.Name)
+}
+func (s *ScenarioDecl) PrettyPrint(cp CodePrinter) {
+	cp.Printf("scenario %s {\n", s.Name.Value)
+	WithIndent(1, cp, func(cp CodePrinter) {
+		for _, g := range s.Generators {
+			cp.Println(g.String())
+		}
+		for _, e := range s.Expects {
+			cp.Println(e.String())
+		}
+	})
+	cp.Println("}")
+}
+
+// ScenarioGenerator is `generator target at rate for duration` in a scenario,
+// eg `generator server.Handle at 50/s for 10s`.
+type ScenarioGenerator struct {
+	NodeInfo
+	Keyword  *IdentifierExpr // "generator"
+	Target   Expr
+	At       *IdentifierExpr // "at"
+	RateExpr Expr            // count/unit, eg 50/s or 600/min
+	For      Expr            // duration literal
+
+	// Resolved during inference
+	ComponentPath string
+	MethodName    string
+	Rate          float64 // Calls per second
+	Duration      float64 // Seconds
+}
+
+func (g *ScenarioGenerator) String() string {
+	return fmt.Sprintf("generator %s at %s for %s;", g.Target, g.RateExpr, g.For)
+}
+func (g *ScenarioGenerator) PrettyPrint(cp CodePrinter) { cp.Print(g.String()) }
+
+// ScenarioExpect is `expect aggregation target op threshold` in a scenario,
+// eg `expect p99 server.Handle < 100ms`, or `expect path "spec"` (or nopath)
+// about the calls made by every generated call, eg
+// `expect path "Handle > cache.Get -> db.Query"`.
+type ScenarioExpect struct {
+	NodeInfo
+	Aggregation *IdentifierExpr // avg, min, max, p50, p90, p95, p99, count, path or nopath
+	Condition   Expr            // target op threshold, or the path spec
+
+	// Resolved during inference from Condition
+	ComponentPath string
+	MethodName    string
+	Operator      string
+	Threshold     float64 // Seconds for latencies, calls for count
+	Path          string  // Spec of path and nopath expectations
+}
+
+func (e *ScenarioExpect) String() string {
+	return fmt.Sprintf("expect %s %s;", e.Aggregation, e.Condition)
+}
+func (e *ScenarioExpect) PrettyPrint(cp CodePrinter) { cp.Print(e.String()) }
+
 // Aggregator declarations - For now they can only be native
 // Aggregators are used to select from a set of futures being waited on
 type AggregatorDecl struct {
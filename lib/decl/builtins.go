@@ -1 +1,64 @@
 package decl
+
+// Conversion builtins are available in every file without an import and take
+// precedence over declarations of the same name.
+const (
+	BuiltinFloat    = "float"    // float(x Int|Float) Float
+	BuiltinInt      = "int"      // int(x Int|Float) Int, truncating towards zero
+	BuiltinDuration = "duration" // duration(x Int|Float, unit String) Float, in seconds
+	BuiltinString   = "string"   // string(x Int|Float|Bool|String) String
+)
+
+// IsConversionBuiltin reports whether name is one of the conversion builtins.
+func IsConversionBuiltin(name string) bool {
+	switch name {
+	case BuiltinFloat, BuiltinInt, BuiltinDuration, BuiltinString:
+		return true
+	}
+	return false
+}
+
+// Enum builtins take the name of an enum, eg values(Status), and are
+// available like the conversion builtins.
+const (
+	BuiltinValues = "values" // values(E) List[E], in declaration order
+	BuiltinCount  = "count"  // count(E) Int
+)
+
+// IsEnumBuiltin reports whether name is one of the enum builtins.
+func IsEnumBuiltin(name string) bool {
+	return name == BuiltinValues || name == BuiltinCount
+}
+
+// DurationUnits are the units accepted by duration() with their length in
+// seconds, matching the suffixes of duration literals.
+var DurationUnits = map[string]float64{
+	"ns":  1e-9,
+	"us":  1e-6,
+	"ms":  1e-3,
+	"s":   1,
+	"min": 60,
+	"hr":  3600,
+}
+
+// RateUnits are the units a rate literal such as 50/s or 3000/min is per,
+// with their length in seconds.  Rates are normalized to per second.
+var RateUnits = map[string]float64{
+	"s":   1,
+	"min": 60,
+	"hr":  3600,
+}
+
+// SizeUnits are the suffixes of size literals with their size in bytes.
+// KB, MB, GB and TB are decimal and KiB, MiB, GiB and TiB are binary.
+var SizeUnits = map[string]float64{
+	"B":   1,
+	"KB":  1e3,
+	"MB":  1e6,
+	"GB":  1e9,
+	"TB":  1e12,
+	"KiB": 1 << 10,
+	"MiB": 1 << 20,
+	"GiB": 1 << 30,
+	"TiB": 1 << 40,
+}
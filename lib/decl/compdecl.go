@@ -1,6 +1,9 @@
 package decl
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 // --- ComponentDecl Definition ---
 
@@ -12,7 +15,7 @@ import "fmt"
 type ComponentDecl struct {
 	NodeInfo
 	Name *IdentifierExpr         // ComponentDecl type name
-	Body []ComponentDeclBodyItem // ParamDecl, UsesDecl, MethodDecl
+	Body []ComponentDeclBodyItem // ParamDecl, UsesDecl, MethodDecl, ProfileDecl
 
 	// Marks whether a component is native or not
 	// Native components should still be declared if not defined.
@@ -33,6 +36,8 @@ type ComponentDecl struct {
 	uses    map[string]*UsesDecl   // Processed dependencies map[local_name]*UsesDecl
 	methods map[string]*MethodDecl // Processed methods map[method_name]*MethodDef
 
+	profiles map[string]*ProfileDecl // Alternate method bodies by profile name
+
 	// File declaration this Component is declared in
 	ParentFileDecl *FileDecl
 }
@@ -84,6 +89,43 @@ func (d *ComponentDecl) GetParam(name string) (out *ParamDecl, err error) {
 	return
 }
 
+// ParamRefs returns the names of the component's params that e refers to,
+// either bare (PoolSize) or through self (self.PoolSize), in order.
+func (d *ComponentDecl) ParamRefs(e Expr) (names []string) {
+	var walk func(e Expr)
+	walk = func(e Expr) {
+		switch e := e.(type) {
+		case *IdentifierExpr:
+			if p, _ := d.GetParam(e.Value); p != nil {
+				names = append(names, e.Value)
+			}
+		case *MemberAccessExpr:
+			if recv, ok := e.Receiver.(*IdentifierExpr); ok && recv.Value == "self" {
+				if p, _ := d.GetParam(e.Member.Value); p != nil {
+					names = append(names, e.Member.Value)
+				}
+			} else {
+				walk(e.Receiver)
+			}
+		case *BinaryExpr:
+			walk(e.Left)
+			walk(e.Right)
+		case *UnaryExpr:
+			walk(e.Right)
+		case *TupleExpr:
+			for _, child := range e.Children {
+				walk(child)
+			}
+		case *CallExpr:
+			for _, arg := range e.ArgList {
+				walk(arg)
+			}
+		}
+	}
+	walk(e)
+	return
+}
+
 func (d *ComponentDecl) Methods() (out map[string]*MethodDecl, err error) {
 	err = d.Resolve()
 	out = d.methods
@@ -98,6 +140,21 @@ func (d *ComponentDecl) GetMethod(name string) (out *MethodDecl, err error) {
 	return
 }
 
+// Profiles returns the component's profiles by name.
+func (d *ComponentDecl) Profiles() (out map[string]*ProfileDecl, err error) {
+	err = d.Resolve()
+	out = d.profiles
+	return
+}
+
+func (d *ComponentDecl) GetProfile(name string) (out *ProfileDecl, err error) {
+	profiles, err := d.Profiles()
+	if err == nil {
+		out = profiles[name]
+	}
+	return
+}
+
 func (d *ComponentDecl) Dependencies() (out []*UsesDecl, err error) {
 	err = d.Resolve()
 	out = d.usesList
@@ -119,6 +176,7 @@ func (d *ComponentDecl) Resolve() error {
 	d.params = map[string]*ParamDecl{}
 	d.uses = map[string]*UsesDecl{}      // Processed dependencies map[local_name]*UsesDecl
 	d.methods = map[string]*MethodDecl{} // Processed dependencies map[local_name]*UsesDecl
+	d.profiles = map[string]*ProfileDecl{}
 
 	// Process body
 	for _, item := range d.Body {
@@ -144,6 +202,20 @@ func (d *ComponentDecl) Resolve() error {
 			}
 			d.methods[methodName] = bodyNode
 			bodyNode.BoundComponent = d
+		case *ProfileDecl:
+			profileName := bodyNode.Name.Value
+			if _, exists := d.profiles[profileName]; exists {
+				return fmt.Errorf("duplicate profile '%s'", profileName)
+			}
+			d.profiles[profileName] = bodyNode
+			bodyNode.methods = map[string]*MethodDecl{}
+			for _, method := range bodyNode.Methods {
+				if _, exists := bodyNode.methods[method.Name.Value]; exists {
+					return fmt.Errorf("duplicate method definition '%s' in profile '%s'", method.Name.Value, profileName)
+				}
+				bodyNode.methods[method.Name.Value] = method
+				method.BoundComponent = d
+			}
 			/* Disable recursive components for now
 			case *ComponentDecl:
 				// Handle nested definitions - recursive processing
@@ -194,12 +266,13 @@ type ComponentDeclBodyItem interface {
 	componentBodyItemNode()
 }
 
-// ParamDecl represents `param name: TypeDecl [= defaultExpr];`
+// ParamDecl represents `param name: TypeDecl [= defaultExpr] [in constraint];`
 type ParamDecl struct {
 	NodeInfo
 	Name         *IdentifierExpr
 	TypeDecl     *TypeDecl
-	DefaultValue Expr // Optional
+	DefaultValue Expr             // Optional
+	Constraint   *ParamConstraint // Optional
 }
 
 func (p *ParamDecl) Equals(another *ParamDecl) bool {
@@ -211,6 +284,9 @@ func (p *ParamDecl) String() string {
 	if p.DefaultValue != nil {
 		s += fmt.Sprintf(" = %s", p.DefaultValue)
 	}
+	if p.Constraint != nil {
+		s += " in " + p.Constraint.String()
+	}
 	return s + ";"
 }
 
@@ -223,6 +299,143 @@ func (p *ParamDecl) PrettyPrint(cp CodePrinter) {
 		cp.Print(" = ")
 		p.DefaultValue.PrettyPrint(cp)
 	}
+	if p.Constraint != nil {
+		cp.Print(" in " + p.Constraint.String())
+	}
+}
+
+// ParamConstraint restricts the values of a param to either an inclusive
+// range, `in [0, 1]`, or a set of allowed values, `in {1, 2, 4}`.  Bounds and
+// values must be literals (optionally negated).
+type ParamConstraint struct {
+	NodeInfo
+	Min, Max Expr   // Set for a range
+	Allowed  []Expr // Set for a list of allowed values
+}
+
+func (c *ParamConstraint) IsRange() bool { return c.Min != nil }
+
+func (c *ParamConstraint) String() string {
+	if c.IsRange() {
+		return fmt.Sprintf("[%s, %s]", constantText(c.Min), constantText(c.Max))
+	}
+	var parts []string
+	for _, v := range c.Allowed {
+		parts = append(parts, constantText(v))
+	}
+	return "{" + strings.Join(parts, ", ") + "}"
+}
+
+// Check returns an error describing the allowed values if value violates
+// the constraint.
+func (c *ParamConstraint) Check(value Value) error {
+	if c.IsRange() {
+		x, ok := numericConstant(value.Value)
+		if !ok {
+			return fmt.Errorf("value %s is not numeric, must be in %s", valueText(value), c)
+		}
+		low, _ := ConstantValue(c.Min)
+		high, _ := ConstantValue(c.Max)
+		lo, lowOk := numericConstant(low.Value)
+		hi, highOk := numericConstant(high.Value)
+		if !lowOk || !highOk {
+			return fmt.Errorf("range %s must have numeric literal bounds", c)
+		}
+		if x < lo || x > hi {
+			return fmt.Errorf("value %s is out of range, must be in %s", valueText(value), c)
+		}
+		return nil
+	}
+	for _, e := range c.Allowed {
+		if value.Type != nil && value.Type.Tag == TypeTagEnum {
+			if idx, ok := EnumVariant(e, value.Type.Info.(*EnumDecl)); ok && idx == value.Value {
+				return nil
+			}
+		} else if allowed, ok := ConstantValue(e); ok && sameConstant(allowed.Value, value.Value) {
+			return nil
+		}
+	}
+	return fmt.Errorf("value %s is not allowed, must be one of %s", valueText(value), c)
+}
+
+// EnumVariant returns the index of the variant of enumDecl that e names, eg
+// Policy.LRU.
+func EnumVariant(e Expr, enumDecl *EnumDecl) (int, bool) {
+	access, ok := e.(*MemberAccessExpr)
+	if !ok {
+		return -1, false
+	}
+	receiver, ok := access.Receiver.(*IdentifierExpr)
+	if !ok || receiver.Value != enumDecl.Name.Value {
+		return -1, false
+	}
+	idx := enumDecl.IndexOfVariant(access.Member.Value)
+	return idx, idx >= 0
+}
+
+// ConstantValue returns the value of a literal, or a negated numeric literal.
+func ConstantValue(e Expr) (Value, bool) {
+	switch e := e.(type) {
+	case *LiteralExpr:
+		return e.Value, true
+	case *UnaryExpr:
+		if e.Operator != "-" {
+			return Nil, false
+		}
+		v, ok := ConstantValue(e.Right)
+		if !ok {
+			return Nil, false
+		}
+		switch x := v.Value.(type) {
+		case int64:
+			return Value{Type: v.Type, Value: -x}, true
+		case float64:
+			return Value{Type: v.Type, Value: -x}, true
+		}
+	}
+	return Nil, false
+}
+
+func constantText(e Expr) string {
+	if v, ok := ConstantValue(e); ok {
+		return valueText(v)
+	}
+	return e.String()
+}
+
+func valueText(v Value) string {
+	if v.Type != nil && v.Type.Tag == TypeTagEnum {
+		if idx, ok := v.Value.(int); ok {
+			enumDecl := v.Type.Info.(*EnumDecl)
+			if idx >= 0 && idx < len(enumDecl.Values) {
+				return enumDecl.Name.Value + "." + enumDecl.Values[idx].Value
+			}
+		}
+	}
+	if str, ok := v.Value.(string); ok {
+		return fmt.Sprintf("%q", str)
+	}
+	return fmt.Sprint(v.Value)
+}
+
+func numericConstant(v any) (float64, bool) {
+	switch x := v.(type) {
+	case int64:
+		return float64(x), true
+	case float64:
+		return x, true
+	}
+	return 0, false
+}
+
+// sameConstant compares two constants, treating ints and floats as numbers.
+func sameConstant(a, b any) bool {
+	x, xOk := numericConstant(a)
+	y, yOk := numericConstant(b)
+	if xOk || yOk {
+		return xOk && yOk && x == y
+	}
+	return a == b
 }
 
 // UsesDecl represents `uses varName: ComponentType [{ overrides }];`
@@ -232,6 +445,11 @@ type UsesDecl struct {
 	ComponentName *IdentifierExpr   // Type name of the dependency
 	Overrides     []*AssignmentStmt // When overrides are specified - the component is also instantiated otherwise it must be set in a system or in the enclosing component's uses decl
 
+	// Args are the overrides as written, including positional ones (with a nil
+	// Var) which inference matches to params in declaration order and adds
+	// to Overrides
+	Args []*AssignmentStmt
+
 	// Resolved ComponentDecl after type checking
 	ResolvedComponent *ComponentDecl
 }
@@ -257,11 +475,17 @@ func (u *UsesDecl) PrettyPrint(cp CodePrinter) {
 // MethodDecl represents `method name(params) [: returnType] { body }`
 type MethodDecl struct {
 	NodeInfo
-	Name       *IdentifierExpr
-	Parameters []*ParamDecl // Signature parameters (can be empty)
-	ReturnType *TypeDecl    // Optional return type (primitive or enum)
-	Body       *BlockStmt
-	IsNative   bool
+	Annotations []*Annotation
+	Name        *IdentifierExpr
+	Parameters  []*ParamDecl // Signature parameters (can be empty)
+	ReturnType  *TypeDecl    // Optional return type (primitive or enum)
+	Body        *BlockStmt
+	IsNative    bool
+
+	// Outcome distribution of a native method, eg
+	// `native method Query() Float = dist { ... }`, sampled for each
+	// call in place of running native code.  Nil for other methods.
+	Outcomes *DistributeExpr
 
 	// The componentDecl this is a method in if it is a component method
 	// can be nil if it is a global/top level method
@@ -288,7 +512,52 @@ func (o *MethodDecl) String() string {
 	return fmt.Sprintf("method %s(...) %s { ... }", o.Name, retType)
 }
 
+// GetAnnotation returns the method's annotation with the given name or nil.
+func (m *MethodDecl) GetAnnotation(name string) *Annotation {
+	for _, a := range m.Annotations {
+		if a.Name.Value == name {
+			return a
+		}
+	}
+	return nil
+}
+
+// Memoize returns the TTL (in seconds) and hit rate of the method's @memoize
+// annotation.  hitRate is negative when the annotation leaves it to be derived
+// from the method's arrival rate.
+func (m *MethodDecl) Memoize() (ttl, hitRate float64, ok bool) {
+	a := m.GetAnnotation("memoize")
+	if a == nil {
+		return 0, 0, false
+	}
+	hitRate = -1
+	if lit, isLit := a.Arg("ttl").(*LiteralExpr); isLit {
+		ttl, _ = lit.Value.Value.(float64)
+	}
+	if lit, isLit := a.Arg("hit_rate").(*LiteralExpr); isLit {
+		hitRate, _ = lit.Value.Value.(float64)
+	}
+	return ttl, hitRate, true
+}
+
+// Coalesce returns the window (in seconds) of the method's @coalesce
+// annotation during which identical calls join one in flight.
+func (m *MethodDecl) Coalesce() (window float64, ok bool) {
+	a := m.GetAnnotation("coalesce")
+	if a == nil {
+		return 0, false
+	}
+	if lit, isLit := a.Arg("window").(*LiteralExpr); isLit {
+		window, _ = lit.Value.Value.(float64)
+	}
+	return window, true
+}
+
 func (m *MethodDecl) PrettyPrint(cp CodePrinter) {
+	for _, a := range m.Annotations {
+		a.PrettyPrint(cp)
+		cp.Println("")
+	}
 	paramStr := ""
 	for idx, param := range m.Parameters {
 		if idx > 0 {
@@ -308,3 +577,40 @@ func (m *MethodDecl) PrettyPrint(cp CodePrinter) {
 	cp.Unindent(1)
 	cp.Printf("}")
 }
+
+// ProfileDecl represents `profile name { method ... }`, an alternate set of
+// bodies for some of the component's methods, eg a fixed latency stand in
+// for a detailed model.  A profile is selected per instance at runtime.
+type ProfileDecl struct {
+	NodeInfo
+	Name    *IdentifierExpr
+	Methods []*MethodDecl
+
+	methods map[string]*MethodDecl
+}
+
+func (p *ProfileDecl) componentBodyItemNode() {}
+
+// GetMethod returns the profile's override of a method, or nil.
+func (p *ProfileDecl) GetMethod(name string) *MethodDecl {
+	return p.methods[name]
+}
+
+func (p *ProfileDecl) String() string {
+	body := ""
+	for _, m := range p.Methods {
+		body += m.String() + "\n"
+	}
+	return fmt.Sprintf("profile %s { %s }", p.Name, body)
+}
+
+func (p *ProfileDecl) PrettyPrint(cp CodePrinter) {
+	cp.Printf("profile %s {", p.Name.Value)
+	WithIndent(1, cp, func(cp CodePrinter) {
+		for _, m := range p.Methods {
+			m.PrettyPrint(cp)
+			cp.Println("")
+		}
+	})
+	cp.Printf("}")
+}
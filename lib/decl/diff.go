@@ -0,0 +1,344 @@
+package decl
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/panyam/sdl/lib/core"
+)
+
+// Kinds of changes reported by DiffFiles
+const (
+	ChangeAdded   = "added"
+	ChangeRemoved = "removed"
+	ChangeChanged = "changed"
+)
+
+// DeclChange is a single semantic difference between two files.
+type DeclChange struct {
+	Kind   string `json:"kind"`             // added, removed or changed
+	Entity string `json:"entity"`           // component, param, uses, method, system, enum, import, aggregator
+	Path   string `json:"path"`             // eg "Server.HandleRequest"
+	Detail string `json:"detail,omitempty"` // What changed, eg "default 10ms -> 20ms"
+}
+
+func (c DeclChange) String() string {
+	out := fmt.Sprintf("%s %s %s", c.Kind, c.Entity, c.Path)
+	if c.Detail != "" {
+		out += ": " + c.Detail
+	}
+	return out
+}
+
+// DiffFiles compares the declarations of two parsed files and returns their
+// semantic differences.  Declarations are matched by name so reordering,
+// formatting and source positions are ignored.  Both files only need to be
+// parsed, not resolved.
+func DiffFiles(old, new *FileDecl) (changes []DeclChange) {
+	oldDecls, newDecls := namedDecls(old), namedDecls(new)
+	for _, key := range sortedKeys(oldDecls, newDecls) {
+		entity, name, _ := strings.Cut(key, " ")
+		a, b := oldDecls[key], newDecls[key]
+		switch {
+		case b == nil:
+			changes = append(changes, DeclChange{Kind: ChangeRemoved, Entity: entity, Path: name})
+		case a == nil:
+			changes = append(changes, DeclChange{Kind: ChangeAdded, Entity: entity, Path: name})
+		default:
+			changes = append(changes, diffDecl(entity, name, a, b)...)
+		}
+	}
+	return
+}
+
+func diffDecl(entity, name string, a, b Node) (changes []DeclChange) {
+	switch a := a.(type) {
+	case *ComponentDecl:
+		b := b.(*ComponentDecl)
+		if a.IsNative != b.IsNative {
+			changes = append(changes, DeclChange{ChangeChanged, entity, name, fmt.Sprintf("native %t -> %t", a.IsNative, b.IsNative)})
+		}
+		oldItems, newItems := componentItems(a), componentItems(b)
+		for _, key := range sortedKeys(oldItems, newItems) {
+			itemEntity, itemName, _ := strings.Cut(key, " ")
+			path := name + "." + itemName
+			x, y := oldItems[key], newItems[key]
+			switch {
+			case y == nil:
+				changes = append(changes, DeclChange{Kind: ChangeRemoved, Entity: itemEntity, Path: path})
+			case x == nil:
+				changes = append(changes, DeclChange{Kind: ChangeAdded, Entity: itemEntity, Path: path})
+			default:
+				changes = append(changes, diffDecl(itemEntity, path, x, y)...)
+			}
+		}
+	case *ParamDecl:
+		b := b.(*ParamDecl)
+		if !sameNode(a.TypeDecl, b.TypeDecl) {
+			changes = append(changes, DeclChange{ChangeChanged, entity, name, fmt.Sprintf("type %s -> %s", typeText(a.TypeDecl), typeText(b.TypeDecl))})
+		}
+		if !sameNode(a.DefaultValue, b.DefaultValue) {
+			changes = append(changes, DeclChange{ChangeChanged, entity, name, fmt.Sprintf("default %s -> %s", exprText(a.DefaultValue, a.TypeDecl), exprText(b.DefaultValue, b.TypeDecl))})
+		}
+		if !sameNode(a.Constraint, b.Constraint) {
+			changes = append(changes, DeclChange{ChangeChanged, entity, name, fmt.Sprintf("constraint %s -> %s", constraintText(a.Constraint), constraintText(b.Constraint))})
+		}
+	case *UsesDecl:
+		b := b.(*UsesDecl)
+		if a.ComponentName.Value != b.ComponentName.Value {
+			changes = append(changes, DeclChange{ChangeChanged, entity, name, fmt.Sprintf("component %s -> %s", a.ComponentName, b.ComponentName)})
+		}
+		if !sameNode(a.Overrides, b.Overrides) {
+			changes = append(changes, DeclChange{ChangeChanged, entity, name, fmt.Sprintf("overrides (%s) -> (%s)", overridesText(a.Overrides), overridesText(b.Overrides))})
+		}
+	case *MethodDecl:
+		b := b.(*MethodDecl)
+		if sig, other := methodSignature(a), methodSignature(b); sig != other {
+			changes = append(changes, DeclChange{ChangeChanged, entity, name, fmt.Sprintf("signature %s -> %s", sig, other)})
+		}
+		if !sameNode(a.Body, b.Body) {
+			changes = append(changes, DeclChange{ChangeChanged, entity, name, "body changed"})
+		}
+	case *SystemDecl:
+		b := b.(*SystemDecl)
+		if !sameNode(a.Parameters, b.Parameters) {
+			changes = append(changes, DeclChange{ChangeChanged, entity, name, fmt.Sprintf("parameters (%s) -> (%s)", paramsText(a.Parameters), paramsText(b.Parameters))})
+		}
+		// Statements in a system body are declarative so their order does not matter
+		for _, item := range unmatched(a.Body, b.Body) {
+			changes = append(changes, DeclChange{ChangeChanged, entity, name, fmt.Sprintf("removed %s", item)})
+		}
+		for _, item := range unmatched(b.Body, a.Body) {
+			changes = append(changes, DeclChange{ChangeChanged, entity, name, fmt.Sprintf("added %s", item)})
+		}
+	case *EnumDecl:
+		b := b.(*EnumDecl)
+		if !sameNode(a.Values, b.Values) {
+			changes = append(changes, DeclChange{ChangeChanged, entity, name, fmt.Sprintf("values (%s) -> (%s)", identsText(a.Values), identsText(b.Values))})
+		}
+	default:
+		if !sameNode(a, b) {
+			changes = append(changes, DeclChange{Kind: ChangeChanged, Entity: entity, Path: name})
+		}
+	}
+	return
+}
+
+// namedDecls indexes the top level declarations of a file by "entity name".
+func namedDecls(file *FileDecl) map[string]Node {
+	out := map[string]Node{}
+	for _, node := range file.Declarations {
+		switch n := node.(type) {
+		case *ComponentDecl:
+			out["component "+n.Name.Value] = n
+		case *SystemDecl:
+			out["system "+n.Name.Value] = n
+		case *EnumDecl:
+			out["enum "+n.Name.Value] = n
+		case *AggregatorDecl:
+			out["aggregator "+n.Name.Value] = n
+		case *MethodDecl:
+			out["method "+n.Name.Value] = n
+		case *ImportDecl:
+			out["import "+n.ImportedAs()] = n
+		}
+	}
+	return out
+}
+
+// componentItems indexes the params, dependencies and methods of a component by "entity name".
+func componentItems(comp *ComponentDecl) map[string]Node {
+	out := map[string]Node{}
+	for _, item := range comp.Body {
+		switch n := item.(type) {
+		case *ParamDecl:
+			out["param "+n.Name.Value] = n
+		case *UsesDecl:
+			out["uses "+n.Name.Value] = n
+		case *MethodDecl:
+			out["method "+n.Name.Value] = n
+		case *ProfileDecl:
+			out["profile "+n.Name.Value] = n
+		}
+	}
+	return out
+}
+
+func sortedKeys(a, b map[string]Node) []string {
+	var keys []string
+	for k := range a {
+		keys = append(keys, k)
+	}
+	for k := range b {
+		if _, ok := a[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// unmatched returns the items of a that have no structurally equal item in b,
+// each item in b matching at most once.
+func unmatched[T any](a, b []T) (out []T) {
+	used := make([]bool, len(b))
+	for _, x := range a {
+		found := false
+		for j, y := range b {
+			if !used[j] && sameNode(x, y) {
+				used[j], found = true, true
+				break
+			}
+		}
+		if !found {
+			out = append(out, x)
+		}
+	}
+	return
+}
+
+func methodSignature(m *MethodDecl) string {
+	out := "(" + paramsText(m.Parameters) + ")"
+	if m.ReturnType != nil {
+		out += " " + typeText(m.ReturnType)
+	}
+	return out
+}
+
+func paramsText(params []*ParamDecl) string {
+	var parts []string
+	for _, p := range params {
+		part := p.Name.Value + " " + typeText(p.TypeDecl)
+		if p.DefaultValue != nil {
+			part += " = " + exprText(p.DefaultValue, p.TypeDecl)
+		}
+		parts = append(parts, part)
+	}
+	return strings.Join(parts, ", ")
+}
+
+func overridesText(overrides []*AssignmentStmt) string {
+	var parts []string
+	for _, o := range overrides {
+		parts = append(parts, o.Var.Value+" = "+exprText(o.Value, nil))
+	}
+	return strings.Join(parts, ", ")
+}
+
+func identsText(idents []*IdentifierExpr) string {
+	var parts []string
+	for _, i := range idents {
+		parts = append(parts, i.Value)
+	}
+	return strings.Join(parts, ", ")
+}
+
+func constraintText(c *ParamConstraint) string {
+	if c == nil {
+		return "<none>"
+	}
+	return c.String()
+}
+
+func typeText(t *TypeDecl) string {
+	if t == nil {
+		return "<none>"
+	}
+	if len(t.Args) == 0 {
+		return t.Name
+	}
+	var args []string
+	for _, arg := range t.Args {
+		args = append(args, typeText(arg))
+	}
+	return fmt.Sprintf("%s[%s]", t.Name, strings.Join(args, ", "))
+}
+
+// exprText renders an expression for display.  Literals are shown as written
+// where possible, eg durations (stored in seconds) are shown with their unit.
+func exprText(e Expr, t *TypeDecl) string {
+	if e == nil {
+		return "<none>"
+	}
+	lit, ok := e.(*LiteralExpr)
+	if !ok {
+		return e.String()
+	}
+	if f, ok := lit.Value.Value.(float64); ok && t != nil && t.Name == "Duration" {
+		return core.FormatDuration(f, core.DefaultDisplayPrecision)
+	}
+	if str, ok := lit.Value.Value.(string); ok {
+		return fmt.Sprintf("%q", str)
+	}
+	return fmt.Sprint(lit.Value.Value)
+}
+
+var nodeInfoType = reflect.TypeOf(NodeInfo{})
+
+// sameNode compares two AST nodes structurally, ignoring source positions,
+// unexported (inferred) state and references to resolved declarations.
+func sameNode(a, b any) bool {
+	return sameValue(reflect.ValueOf(a), reflect.ValueOf(b))
+}
+
+func sameValue(a, b reflect.Value) bool {
+	if !a.IsValid() || !b.IsValid() {
+		return a.IsValid() == b.IsValid()
+	}
+	if a.Type() != b.Type() {
+		return false
+	}
+	switch a.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if a.IsNil() || b.IsNil() {
+			return a.IsNil() == b.IsNil()
+		}
+		return sameValue(a.Elem(), b.Elem())
+	case reflect.Struct:
+		if a.Type() == nodeInfoType {
+			return true
+		}
+		for i := range a.NumField() {
+			field := a.Type().Field(i)
+			if !field.IsExported() || isBackReference(field.Name) {
+				continue
+			}
+			if !sameValue(a.Field(i), b.Field(i)) {
+				return false
+			}
+		}
+		return true
+	case reflect.Slice, reflect.Array:
+		if a.Len() != b.Len() {
+			return false
+		}
+		for i := range a.Len() {
+			if !sameValue(a.Index(i), b.Index(i)) {
+				return false
+			}
+		}
+		return true
+	case reflect.Map:
+		if a.Len() != b.Len() {
+			return false
+		}
+		for _, key := range a.MapKeys() {
+			if !sameValue(a.MapIndex(key), b.MapIndex(key)) {
+				return false
+			}
+		}
+		return true
+	case reflect.Func, reflect.Chan:
+		return a.IsNil() == b.IsNil()
+	}
+	return a.Equal(b)
+}
+
+// isBackReference reports fields that point back up (or across) the tree
+// and are filled in during resolution rather than parsing.
+func isBackReference(name string) bool {
+	return strings.HasPrefix(name, "Resolved") || strings.HasPrefix(name, "Parent") ||
+		strings.HasPrefix(name, "Bound") || name == "ComponentDecl"
+}
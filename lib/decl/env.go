@@ -19,10 +19,10 @@ type Env[T any] struct {
 
 // NewEnv[T] creates a new environment nested within an outer one.
 // If outer is nil then returns a fresh top-level environment.
-// Useful for function calls or block scopes.
+// Useful for function calls or block scopes.  The store is only allocated
+// on the first Set as most block scopes never declare anything.
 func NewEnv[T any](outer *Env[T]) *Env[T] {
-	s := make(map[string]*Ref[T])
-	return &Env[T]{store: s, outer: outer}
+	return &Env[T]{outer: outer}
 }
 
 // Get retrieves a value by name. It checks the current environment first,
@@ -46,6 +46,9 @@ func (e *Env[T]) Get(name string) (out T, found bool) {
 }
 
 func (e *Env[T]) Set(key string, value T) {
+	if e.store == nil {
+		e.store = make(map[string]*Ref[T])
+	}
 	// Create or update the VarState
 	e.store[key] = &Ref[T]{Value: value}
 }
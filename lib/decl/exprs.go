@@ -118,9 +118,14 @@ func (e *UnaryExpr) PrettyPrint(cp CodePrinter) {
 type LiteralExpr struct {
 	ExprBase
 	Value Value
-	// Duration specific fields could be added if needed after parsing
-	// DurationUnit string
-	// NumericValue float64
+	// Unit a duration literal was written with, eg "ms".  Durations are
+	// Floats in seconds so this is the only thing that marks them.
+	DurationUnit string
+	// Likewise the unit a rate literal is per, eg "min" for 3000/min, and
+	// the unit of a size literal, eg "MiB".  Rates are Floats per second
+	// and sizes Floats in bytes.
+	RateUnit string
+	SizeUnit string
 }
 
 func (l *LiteralExpr) Equals(another *LiteralExpr) bool {
@@ -269,16 +274,35 @@ func (e *GoExpr) PrettyPrint(cp CodePrinter) {
 	cp.Print(e.String())
 }
 
-// WaitExpr represents `delay durationExpr;`
+// WaitPolicy decides how the failure of a future affects a wait.  A future
+// fails when its result is false.
+type WaitPolicy int
+
+const (
+	WaitDefault    WaitPolicy = iota // Failures are left to the aggregator
+	WaitRequireAll                   // `requireAll`: any failed future fails the calling method
+	WaitAnyOf                        // `anyOf(n)`: the results of the futures that succeeded, if at least n did
+)
+
+// WaitExpr represents `wait a, b`, optionally with a policy or an aggregator
 type WaitExpr struct {
 	ExprBase
 	FutureNames      []*IdentifierExpr // Must evaluate to Duration outcome
 	AggregatorName   *IdentifierExpr
 	AggregatorParams map[string]Expr
+	Policy           WaitPolicy
+	AnyOfCount       Expr // Minimum successful futures for WaitAnyOf
 }
 
 func (d *WaitExpr) String() string {
-	return fmt.Sprintf("wait %s;", strings.Join(gfn.Map(d.FutureNames, func(i *IdentifierExpr) string { return i.Value }), ", "))
+	futures := strings.Join(gfn.Map(d.FutureNames, func(i *IdentifierExpr) string { return i.Value }), ", ")
+	switch d.Policy {
+	case WaitRequireAll:
+		return fmt.Sprintf("wait %s requireAll;", futures)
+	case WaitAnyOf:
+		return fmt.Sprintf("wait %s anyOf(%s);", futures, d.AnyOfCount)
+	}
+	return fmt.Sprintf("wait %s;", futures)
 }
 
 func (w *WaitExpr) PrettyPrint(cp CodePrinter) {
@@ -23,6 +23,7 @@ type FileDecl struct {
 	nativeMethods  map[string]*MethodDecl
 	importList     []*ImportDecl // Keep original list for iteration order if needed
 	systems        map[string]*SystemDecl
+	options        []*OptionsDecl // Top level options blocks in declaration order
 }
 
 func (f *FileDecl) PrettyPrint(cp CodePrinter) {
@@ -104,6 +105,13 @@ func (f *FileDecl) Aggregators() (map[string]*AggregatorDecl, error) {
 	return f.aggregators, nil
 }
 
+// GetOptions returns the top level options blocks of this FileDecl.
+func (f *FileDecl) GetOptions() (out []*OptionsDecl, err error) {
+	err = f.Resolve()
+	out = f.options
+	return
+}
+
 // Get a map of all the native methods encountered in this FileDecl
 func (f *FileDecl) GetNativeMethods() (out map[string]*MethodDecl, err error) {
 	err = f.Resolve()
@@ -169,7 +177,7 @@ func (f *FileDecl) Resolve() error {
 			}
 
 		case *OptionsDecl:
-			log.Printf("Found OptionsDecl (TODO: Implement processing)")
+			f.options = append(f.options, node)
 
 		case *ImportDecl:
 			if err := f.RegisterImport(node); err != nil {
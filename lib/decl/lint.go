@@ -0,0 +1,166 @@
+package decl
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// LintIssue is a likely mistake that does not stop a file from compiling.
+type LintIssue struct {
+	Pos     Location
+	Message string
+}
+
+func (l LintIssue) String() string {
+	return fmt.Sprintf("%s: %s", l.Pos.LineColStr(), l.Message)
+}
+
+// Lint reports imports the file never refers to, and params and dependencies
+// of the file's components that are never referenced by the component's
+// methods or overrides.  Native components are skipped as their params are
+// read by Go code.
+func Lint(file *FileDecl) (issues []LintIssue) {
+	issues = lintImports(file)
+	for _, node := range file.Declarations {
+		comp, ok := node.(*ComponentDecl)
+		if !ok || comp.IsNative {
+			continue
+		}
+		used := map[string]bool{}
+		for _, item := range comp.Body {
+			switch n := item.(type) {
+			case *MethodDecl:
+				collectIdentifiers(reflect.ValueOf(n.Body), used)
+			case *UsesDecl:
+				collectIdentifiers(reflect.ValueOf(n.Overrides), used)
+			case *ParamDecl:
+				collectIdentifiers(reflect.ValueOf(n.DefaultValue), used)
+			}
+		}
+		for _, item := range comp.Body {
+			switch n := item.(type) {
+			case *ParamDecl:
+				if !used[n.Name.Value] {
+					issues = append(issues, LintIssue{n.Pos(), fmt.Sprintf("param '%s' of component '%s' is never used", n.Name.Value, comp.Name.Value)})
+				}
+			case *UsesDecl:
+				if !used[n.Name.Value] {
+					issues = append(issues, LintIssue{n.Pos(), fmt.Sprintf("dependency '%s' of component '%s' is never used", n.Name.Value, comp.Name.Value)})
+				}
+			}
+		}
+	}
+	return
+}
+
+// lintImports reports imported names that are not referred to outside of
+// the import declarations.
+func lintImports(file *FileDecl) (issues []LintIssue) {
+	used := map[string]bool{}
+	var imports []*ImportDecl
+	for _, node := range file.Declarations {
+		if imp, ok := node.(*ImportDecl); ok {
+			// Re-exports are there for importers of the file
+			if !imp.Exported {
+				imports = append(imports, imp)
+			}
+		} else {
+			collectIdentifiers(reflect.ValueOf(node), used)
+		}
+	}
+	for _, imp := range imports {
+		if !used[imp.ImportedAs()] {
+			issues = append(issues, LintIssue{imp.ImportedItem.Pos(), fmt.Sprintf("import '%s' is never used", imp.ImportedAs())})
+		}
+	}
+	return
+}
+
+// collectIdentifiers records the name of every identifier and named type in
+// the tree rooted at v.  Matching by name alone errs towards treating things
+// as used.
+func collectIdentifiers(v reflect.Value, names map[string]bool) {
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return
+		}
+		switch n := v.Interface().(type) {
+		case *IdentifierExpr:
+			names[n.Value] = true
+			return
+		case *TypeDecl:
+			names[n.Name] = true
+		case *LiteralExpr:
+			return // Values may point back into resolved declarations
+		}
+		collectIdentifiers(v.Elem(), names)
+	case reflect.Struct:
+		for i := range v.NumField() {
+			field := v.Type().Field(i)
+			if field.IsExported() && !isBackReference(field.Name) {
+				collectIdentifiers(v.Field(i), names)
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for i := range v.Len() {
+			collectIdentifiers(v.Index(i), names)
+		}
+	case reflect.Map:
+		for _, key := range v.MapKeys() {
+			collectIdentifiers(v.MapIndex(key), names)
+		}
+	}
+}
+
+// HasNoEffect reports whether calling a method can have no measurable effect:
+// it is not native and its body neither calls anything, so adds no latency
+// and reaches no dependency, nor samples or waits on anything.
+func HasNoEffect(method *MethodDecl) bool {
+	if method.IsNative || method.Outcomes != nil || (method.BoundComponent != nil && method.BoundComponent.IsNative) {
+		return false
+	}
+	return !hasEffect(reflect.ValueOf(method.Body))
+}
+
+// hasEffect reports whether the tree rooted at v makes a call (other than to
+// a conversion builtin), samples a distribution or waits on futures.
+func hasEffect(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return false
+		}
+		switch n := v.Interface().(type) {
+		case *CallExpr:
+			if ident, ok := n.Function.(*IdentifierExpr); !ok || !(IsConversionBuiltin(ident.Value) || IsEnumBuiltin(ident.Value)) {
+				return true
+			}
+		case *SampleExpr, *DistributeExpr, *WaitExpr:
+			return true
+		case *IdentifierExpr, *LiteralExpr:
+			return false
+		}
+		return hasEffect(v.Elem())
+	case reflect.Struct:
+		for i := range v.NumField() {
+			field := v.Type().Field(i)
+			if field.IsExported() && !isBackReference(field.Name) && hasEffect(v.Field(i)) {
+				return true
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for i := range v.Len() {
+			if hasEffect(v.Index(i)) {
+				return true
+			}
+		}
+	case reflect.Map:
+		for _, key := range v.MapKeys() {
+			if hasEffect(v.MapIndex(key)) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -38,20 +38,28 @@ func (b *BlockStmt) PrettyPrint(cp CodePrinter) {
 	}
 }
 
-// ForStmt represents `for expression { stmt }`
+// ForStmt represents `for expression { stmt }`, or `for v in list { stmt }`
+// which binds Var to each element of the list in Condition.
 type ForStmt struct {
 	NodeInfo
+	Var       *IdentifierExpr // nil unless iterating a list
 	Condition Expr
 	Body      Stmt
 }
 
 func (l *ForStmt) systemBodyItemNode() {} // Allow let at system level
 func (l *ForStmt) String() string {
+	if l.Var != nil {
+		return fmt.Sprintf("for %s in %s { %s }", l.Var.Value, l.Condition.String(), l.Body.String())
+	}
 	return fmt.Sprintf("for %s { %s }", l.Condition.String(), l.Body.String())
 }
 
 func (f *ForStmt) PrettyPrint(cp CodePrinter) {
 	cp.Print("for ")
+	if f.Var != nil {
+		cp.Print(f.Var.Value + " in ")
+	}
 	f.Condition.PrettyPrint(cp)
 	cp.Println(" {")
 	cp.Indent(1)
@@ -63,13 +71,35 @@ func (f *ForStmt) PrettyPrint(cp CodePrinter) {
 // LetStmt represents `let var = expr;`
 type LetStmt struct {
 	NodeInfo
-	Variables []*IdentifierExpr
-	Value     Expr
+	Variables []*IdentifierExpr // All identifiers bound by this let (in order)
+
+	// The (possibly nested) destructuring pattern, eg let (a, (b, c)) = ...
+	// When nil the pattern is derived from Variables.
+	Pattern *LetPattern
+	Value   Expr
 }
 
 func (l *LetStmt) systemBodyItemNode() {} // Allow let at system level
 
+// TargetPattern returns the pattern the value of this let is bound to.
+func (l *LetStmt) TargetPattern() *LetPattern {
+	if l.Pattern != nil {
+		return l.Pattern
+	}
+	if len(l.Variables) == 1 {
+		return &LetPattern{NodeInfo: l.Variables[0].NodeInfo, Ident: l.Variables[0]}
+	}
+	out := &LetPattern{NodeInfo: l.NodeInfo}
+	for _, v := range l.Variables {
+		out.Children = append(out.Children, &LetPattern{NodeInfo: v.NodeInfo, Ident: v})
+	}
+	return out
+}
+
 func (l *LetStmt) String() string {
+	if l.Pattern != nil {
+		return fmt.Sprintf("let %s = %s;", l.Pattern.String(), l.Value)
+	}
 	return fmt.Sprintf("let %s = %s;", strings.Join(gfn.Map(l.Variables, func(i *IdentifierExpr) string { return i.String() }), ", "), l.Value)
 }
 
@@ -77,6 +107,44 @@ func (l *LetStmt) PrettyPrint(cp CodePrinter) {
 	cp.Print(l.String())
 }
 
+// LetPattern is the target of a let binding - either a single identifier or
+// a (possibly nested) tuple of patterns.
+type LetPattern struct {
+	NodeInfo
+	Ident    *IdentifierExpr // Set for leaf patterns
+	Children []*LetPattern   // Set for tuple patterns
+}
+
+func (p *LetPattern) IsTuple() bool {
+	return p.Ident == nil
+}
+
+// Leaves returns all identifiers in the pattern in left to right order.
+func (p *LetPattern) Leaves() (out []*IdentifierExpr) {
+	if !p.IsTuple() {
+		return []*IdentifierExpr{p.Ident}
+	}
+	for _, child := range p.Children {
+		out = append(out, child.Leaves()...)
+	}
+	return
+}
+
+// Shape describes the structure of the pattern with leaves as "_", eg (_, (_, _))
+func (p *LetPattern) Shape() string {
+	if !p.IsTuple() {
+		return "_"
+	}
+	return "(" + strings.Join(gfn.Map(p.Children, func(c *LetPattern) string { return c.Shape() }), ", ") + ")"
+}
+
+func (p *LetPattern) String() string {
+	if !p.IsTuple() {
+		return p.Ident.String()
+	}
+	return "(" + strings.Join(gfn.Map(p.Children, func(c *LetPattern) string { return c.String() }), ", ") + ")"
+}
+
 // SetStmt represents `MemberAccessExpr = value`
 type SetStmt struct {
 	NodeInfo
@@ -107,6 +175,19 @@ func (e *ExprStmt) PrettyPrint(cp CodePrinter) {
 	cp.Print(e.String())
 }
 
+// SpawnStmt represents `spawn call;`, a fire and forget call.  The call runs
+// and adds to the load of its target but not to the latency of the caller,
+// which does not wait for it, eg for logging or analytics.
+type SpawnStmt struct {
+	NodeInfo
+	Call Expr
+}
+
+func (s *SpawnStmt) String() string { return fmt.Sprintf("spawn %s;", s.Call) }
+func (s *SpawnStmt) PrettyPrint(cp CodePrinter) {
+	cp.Print(s.String())
+}
+
 // ReturnStmt represents `return expr;`
 type ReturnStmt struct {
 	NodeInfo
@@ -124,11 +205,23 @@ type IfStmt struct {
 	Condition Expr // Must evaluate to bool outcome
 	Then      *BlockStmt
 	Else      Stmt // Can be another IfStmt or a BlockStmt
+
+	// Set for `if let v = expr { ... }` where Condition is the value bound
+	// to v in the Then block, which only runs if the value is not nil
+	Binding *IdentifierExpr
 }
 
-func (i *IfStmt) String() string { return fmt.Sprintf("if (%s) { ... } else { ... }", i.Condition) }
+func (i *IfStmt) String() string {
+	if i.Binding != nil {
+		return fmt.Sprintf("if let %s = (%s) { ... } else { ... }", i.Binding, i.Condition)
+	}
+	return fmt.Sprintf("if (%s) { ... } else { ... }", i.Condition)
+}
 func (i *IfStmt) PrettyPrint(cp CodePrinter) {
 	cp.Print("if ")
+	if i.Binding != nil {
+		cp.Print("let " + i.Binding.Value + " = ")
+	}
 	i.Condition.PrettyPrint(cp)
 	cp.Print(" {")
 	cp.Indent(1)
@@ -452,6 +452,86 @@ func BoolValue(val bool) (out Value) {
 	return
 }
 
+// Arithmetic applies +, -, *, / or % to two numbers, or + to two strings.
+// The result is an Int when both operands are Ints and a Float otherwise.
+func Arithmetic(op string, left, right Value) (Value, error) {
+	if op == "+" && left.Type.Equals(StrType) && right.Type.Equals(StrType) {
+		return StringValue(left.StringVal() + right.StringVal()), nil
+	}
+	if left.Type.Equals(IntType) && right.Type.Equals(IntType) {
+		x, y := left.IntVal(), right.IntVal()
+		switch op {
+		case "+":
+			return IntValue(x + y), nil
+		case "-":
+			return IntValue(x - y), nil
+		case "*":
+			return IntValue(x * y), nil
+		case "/", "%":
+			if y == 0 {
+				return Nil, fmt.Errorf("integer division by zero")
+			}
+			if op == "/" {
+				return IntValue(x / y), nil
+			}
+			return IntValue(x % y), nil
+		}
+		return Nil, fmt.Errorf("unsupported arithmetic operator '%s'", op)
+	}
+	x, xOk := numericConstant(left.Value)
+	y, yOk := numericConstant(right.Value)
+	if !xOk || !yOk {
+		return Nil, fmt.Errorf("operator '%s' cannot be applied to %s and %s", op, left.Type, right.Type)
+	}
+	switch op {
+	case "+":
+		return FloatValue(x + y), nil
+	case "-":
+		return FloatValue(x - y), nil
+	case "*":
+		return FloatValue(x * y), nil
+	case "/":
+		return FloatValue(x / y), nil
+	}
+	return Nil, fmt.Errorf("unsupported arithmetic operator '%s' for %s and %s", op, left.Type, right.Type)
+}
+
+// Compare applies ==, !=, <, <=, > or >= to two values.  Numbers compare by
+// value across Int and Float, while bools, strings and enum variants of the
+// same type only support == and !=.
+func Compare(op string, left, right Value) (Value, error) {
+	if x, xOk := numericConstant(left.Value); xOk {
+		if y, yOk := numericConstant(right.Value); yOk {
+			switch op {
+			case "==":
+				return BoolValue(x == y), nil
+			case "!=":
+				return BoolValue(x != y), nil
+			case "<":
+				return BoolValue(x < y), nil
+			case "<=":
+				return BoolValue(x <= y), nil
+			case ">":
+				return BoolValue(x > y), nil
+			case ">=":
+				return BoolValue(x >= y), nil
+			}
+			return Nil, fmt.Errorf("unsupported comparison operator '%s'", op)
+		}
+	}
+	if left.Type == nil || right.Type == nil || !left.Type.Equals(right.Type) ||
+		(left.Type.Tag != TypeTagSimple && left.Type.Tag != TypeTagEnum) {
+		return Nil, fmt.Errorf("operator '%s' cannot be applied to %s and %s", op, left.Type, right.Type)
+	}
+	switch op {
+	case "==":
+		return BoolValue(left.Value == right.Value), nil
+	case "!=":
+		return BoolValue(left.Value != right.Value), nil
+	}
+	return Nil, fmt.Errorf("operator '%s' cannot be applied to %s and %s", op, left.Type, right.Type)
+}
+
 // Value specific to references of members inside components
 type RefValue struct {
 	Receiver Value
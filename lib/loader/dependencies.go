@@ -0,0 +1,77 @@
+package loader
+
+import (
+	"slices"
+
+	"github.com/panyam/sdl/lib/decl"
+)
+
+// Dependents returns the components and systems in the files that use the
+// named component, directly or through other components, sorted by name.
+// Systems use the components of their parameters.  The files must have been
+// validated.
+func Dependents(compName string, files ...*decl.FileDecl) []string {
+	_, usedBy := dependencyEdges(files)
+	return reachable(compName, usedBy)
+}
+
+// Dependencies returns the components the named component or system uses,
+// directly or through other components, sorted by name.  The files must have
+// been validated.
+func Dependencies(name string, files ...*decl.FileDecl) []string {
+	uses, _ := dependencyEdges(files)
+	return reachable(name, uses)
+}
+
+// dependencyEdges maps each component and system to the names of the
+// components it uses and each component to the names of those using it.
+func dependencyEdges(files []*decl.FileDecl) (uses, usedBy map[string][]string) {
+	uses = map[string][]string{}
+	usedBy = map[string][]string{}
+	add := func(from, to string) {
+		if !slices.Contains(uses[from], to) {
+			uses[from] = append(uses[from], to)
+			usedBy[to] = append(usedBy[to], from)
+		}
+	}
+	for _, file := range files {
+		components, _ := file.GetComponents()
+		for name, comp := range components {
+			deps, _ := comp.Dependencies()
+			for _, dep := range deps {
+				if dep.ResolvedComponent != nil {
+					add(name, dep.ResolvedComponent.Name.Value)
+				}
+			}
+		}
+		systems, _ := file.GetSystems()
+		for name, sys := range systems {
+			for _, param := range sys.Parameters {
+				if comp := componentOf(param.Name.InferredType()); comp != nil {
+					add(name, comp.Name.Value)
+				}
+			}
+		}
+	}
+	return
+}
+
+// reachable returns the names reachable from start along edges, excluding
+// start unless it is part of a cycle.
+func reachable(start string, edges map[string][]string) []string {
+	seen := map[string]bool{}
+	var out []string
+	pending := slices.Clone(edges[start])
+	for len(pending) > 0 {
+		name := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		out = append(out, name)
+		pending = append(pending, edges[name]...)
+	}
+	slices.Sort(out)
+	return out
+}
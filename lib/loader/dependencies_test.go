@@ -0,0 +1,45 @@
+package loader
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestDependents verifies that dependents and dependencies are followed
+// transitively through a chain of components and into systems.
+func TestDependents(t *testing.T) {
+	fs, errs := validateSource(t, `
+component Disk {
+  method Read() Bool { return true }
+}
+component Cache {
+  method Get() Bool { return true }
+}
+component Database {
+  uses disk Disk()
+  method Query() Bool { return self.disk.Read() }
+}
+component Api {
+  uses db Database()
+  uses cache Cache()
+  method Handle() Bool { return self.db.Query() }
+}
+component Batch {
+  uses db Database()
+  method Run() Bool { return self.db.Query() }
+}
+system App(api Api) { }
+`)
+	require.Empty(t, errs)
+
+	assert.Equal(t, []string{"Api", "App", "Batch", "Database"}, Dependents("Disk", fs.FileDecl))
+	assert.Equal(t, []string{"Api", "App"}, Dependents("Cache", fs.FileDecl))
+	assert.Equal(t, []string{"App"}, Dependents("Api", fs.FileDecl))
+	assert.Empty(t, Dependents("App", fs.FileDecl))
+
+	assert.Equal(t, []string{"Api", "Cache", "Database", "Disk"}, Dependencies("App", fs.FileDecl))
+	assert.Equal(t, []string{"Database", "Disk"}, Dependencies("Batch", fs.FileDecl))
+	assert.Empty(t, Dependencies("Disk", fs.FileDecl))
+}
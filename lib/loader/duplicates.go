@@ -0,0 +1,83 @@
+package loader
+
+import (
+	"github.com/panyam/sdl/lib/decl"
+)
+
+// topLevelDecl is a named top level declaration found while checking for duplicates.
+type topLevelDecl struct {
+	kind string
+	name *IdentifierExpr
+	node Node
+}
+
+// declaredName returns the name and kind of a top level declaration, or nil for
+// declarations that do not introduce a name.
+func declaredName(node Node) *topLevelDecl {
+	switch n := node.(type) {
+	case *ComponentDecl:
+		return &topLevelDecl{"component", n.Name, n}
+	case *SystemDecl:
+		return &topLevelDecl{"system", n.Name, n}
+	case *EnumDecl:
+		return &topLevelDecl{"enum", n.Name, n}
+	case *AggregatorDecl:
+		return &topLevelDecl{"aggregator", n.Name, n}
+	case *MethodDecl:
+		return &topLevelDecl{"native method", n.Name, n}
+	case *ImportDecl:
+		name := n.Alias
+		if name == nil {
+			name = n.ImportedItem
+		}
+		if n.Exported {
+			return &topLevelDecl{"export", name, n}
+		}
+		return &topLevelDecl{"import", name, n}
+	}
+	return nil
+}
+
+// CheckDuplicateDeclarations walks the top level declarations of a file before
+// they are resolved and reports every name that is declared more than once,
+// along with the positions of both declarations.  A local declaration that
+// reuses the name of an imported symbol is reported as shadowing the import.
+func CheckDuplicateDeclarations(fileDecl *decl.FileDecl) (errs []error) {
+	seen := map[string]*topLevelDecl{}
+	for _, node := range fileDecl.Declarations {
+		current := declaredName(node)
+		if current == nil || current.name == nil {
+			continue
+		}
+		name := current.name.Value
+		first, exists := seen[name]
+		if !exists {
+			seen[name] = current
+			continue
+		}
+
+		firstImport, firstIsImport := first.node.(*ImportDecl)
+		currImport, currIsImport := current.node.(*ImportDecl)
+		switch {
+		case firstIsImport && !currIsImport:
+			errs = append(errs, InfErrorf(current.name.Pos(), "%s '%s' shadows '%s' imported from %q at %s",
+				current.kind, name, firstImport.ImportedItem.Value, importPath(firstImport), first.name.Pos().LineColStr()))
+		case currIsImport && !firstIsImport:
+			errs = append(errs, InfErrorf(current.name.Pos(), "%s '%s' from %q conflicts with %s '%s' declared at %s",
+				current.kind, name, importPath(currImport), first.kind, name, first.name.Pos().LineColStr()))
+		default:
+			errs = append(errs, InfErrorf(current.name.Pos(), "duplicate declaration of %s '%s', previously declared as %s at %s",
+				current.kind, name, first.kind, first.name.Pos().LineColStr()))
+		}
+	}
+	return
+}
+
+func importPath(imp *ImportDecl) string {
+	if imp.Path != nil {
+		if path, ok := imp.Path.Value.Value.(string); ok {
+			return path
+		}
+	}
+	return ""
+}
@@ -0,0 +1,56 @@
+package loader
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// loadSources writes each named source into a temp dir and loads the first one.
+func loadSources(t *testing.T, sources ...[2]string) (*FileStatus, error) {
+	t.Helper()
+	dir := t.TempDir()
+	for _, src := range sources {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, src[0]), []byte(src[1]), 0644))
+	}
+	return NewLoader(nil, nil, 10).LoadFile(filepath.Join(dir, sources[0][0]), "", 0)
+}
+
+// TestDuplicateDeclarationInFile verifies that declaring the same top level
+// name twice in one file is reported with the positions of both declarations.
+func TestDuplicateDeclarationInFile(t *testing.T) {
+	fs, err := loadSources(t, [2]string{"main.sdl", `component Foo {
+  method M() Bool { return true }
+}
+enum Foo { A, B }
+`})
+	require.Error(t, err)
+	require.Len(t, fs.Errors, 1)
+	msg := fs.Errors[0].Error()
+	assert.Contains(t, msg, "Line 4, Col 6: duplicate declaration of enum 'Foo'")
+	assert.Contains(t, msg, "previously declared as component at Line 1, Col 11")
+}
+
+// TestLocalDeclarationShadowsImport verifies that a local declaration reusing
+// an imported name is reported as shadowing rather than as a plain duplicate.
+func TestLocalDeclarationShadowsImport(t *testing.T) {
+	fs, err := loadSources(t,
+		[2]string{"main.sdl", `import Bar from "./lib.sdl"
+
+component Bar {
+  method N() Bool { return true }
+}
+`},
+		[2]string{"lib.sdl", `component Bar {
+  method M() Bool { return true }
+}
+`})
+	require.Error(t, err)
+	require.Len(t, fs.Errors, 1)
+	msg := fs.Errors[0].Error()
+	assert.Contains(t, msg, "Line 3, Col 11: component 'Bar' shadows 'Bar' imported from \"./lib.sdl\" at Line 1, Col 8")
+	assert.NotContains(t, msg, "duplicate declaration")
+}
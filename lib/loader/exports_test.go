@@ -0,0 +1,96 @@
+package loader
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const cacheLib = `component Cache {
+  method Get() Bool { return true }
+}
+`
+
+const dbLib = `component DB {
+  method Query() Bool { return true }
+}
+`
+
+// TestReexportThroughFacade verifies that a component re-exported by a facade
+// can be imported from the facade and used as if imported from its origin.
+func TestReexportThroughFacade(t *testing.T) {
+	fs, err := loadSources(t,
+		[2]string{"main.sdl", `import FastCache, DB from "./prelude.sdl"
+
+component App {
+  uses cache FastCache()
+  uses db DB()
+  method Serve() Bool {
+    self.db.Query()
+    return self.cache.Get()
+  }
+}
+system Main(app App) { }
+`},
+		[2]string{"prelude.sdl", `export Cache as FastCache from "./cache.sdl"
+export DB from "./db.sdl"
+`},
+		[2]string{"cache.sdl", cacheLib},
+		[2]string{"db.sdl", dbLib})
+	require.NoError(t, err)
+	l := NewLoader(nil, nil, 10)
+	fs, err = l.LoadFile(fs.FullPath, "", 0)
+	require.NoError(t, err)
+	require.True(t, l.Validate(fs), fs.Errors)
+
+	imports, err := fs.FileDecl.Imports()
+	require.NoError(t, err)
+	cache := imports["FastCache"]
+	require.NotNil(t, cache)
+	assert.Equal(t, "Cache", cache.ResolvedItem.(*ComponentDecl).Name.Value)
+	assert.Contains(t, cache.ResolvedFullPath, "cache.sdl")
+}
+
+// TestReexportVisibility verifies that names a facade only imports stay
+// private to it and that re-exports may not collide with other names.
+func TestReexportVisibility(t *testing.T) {
+	fs, err := loadSources(t,
+		[2]string{"main.sdl", `import Cache from "./prelude.sdl"
+`},
+		[2]string{"prelude.sdl", `import Cache from "./cache.sdl"
+export DB from "./db.sdl"
+
+component Local {
+  uses cache Cache()
+}
+`},
+		[2]string{"cache.sdl", cacheLib},
+		[2]string{"db.sdl", dbLib})
+	require.Error(t, err)
+	require.NotEmpty(t, fs.Errors)
+	assert.Contains(t, fs.Errors[0].Error(), "'Cache' is imported by")
+	assert.Contains(t, fs.Errors[0].Error(), "but not exported from it")
+
+	fs, err = loadSources(t,
+		[2]string{"prelude.sdl", `export DB from "./db.sdl"
+
+component DB {
+  method Query() Bool { return false }
+}
+`},
+		[2]string{"db.sdl", dbLib})
+	require.Error(t, err)
+	require.Len(t, fs.Errors, 1)
+	assert.Contains(t, fs.Errors[0].Error(), "component 'DB' shadows 'DB' imported from \"./db.sdl\"")
+
+	fs, err = loadSources(t,
+		[2]string{"prelude.sdl", `export Cache as Store from "./cache.sdl"
+export DB as Store from "./db.sdl"
+`},
+		[2]string{"cache.sdl", cacheLib},
+		[2]string{"db.sdl", dbLib})
+	require.Error(t, err)
+	require.Len(t, fs.Errors, 1)
+	assert.Contains(t, fs.Errors[0].Error(), "duplicate declaration of export 'Store'")
+}
@@ -39,27 +39,50 @@ func (c *CompositeFS) SetFallback(fs FileSystem) {
 	c.fallback = fs
 }
 
+// Mount serves paths under prefix from fs.  Prefixes are directories so
+// "/examples" and "/examples/" are the same mount and neither matches
+// "/examples2/x.sdl".
 func (c *CompositeFS) Mount(prefix string, fs FileSystem) {
+	if prefix != "" && !strings.HasSuffix(prefix, "://") {
+		prefix = strings.TrimSuffix(NormalizePath(prefix), "/") + "/"
+	}
 	c.mu.Lock()
 	defer c.mu.Unlock()
 	c.filesystems[prefix] = fs
 }
 
-func (c *CompositeFS) findFS(path string) (FileSystem, string) {
+// MountFor returns the prefix of the mount serving path, or "" when the
+// fallback serves it.  mounted is false if no filesystem serves path.
+func (c *CompositeFS) MountFor(path string) (prefix string, mounted bool) {
+	prefix, fs := c.longestMount(NormalizePath(path))
+	if fs != nil {
+		return prefix, true
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return "", c.fallback != nil
+}
+
+// longestMount returns the longest mount prefix of a normalized path and its
+// filesystem, or a nil filesystem if no mount matches.
+func (c *CompositeFS) longestMount(path string) (bestMatch string, bestFS FileSystem) {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
-	
-	// Check for longest prefix match
-	var bestMatch string
-	var bestFS FileSystem
-	
 	for prefix, fs := range c.filesystems {
-		if strings.HasPrefix(path, prefix) && len(prefix) > len(bestMatch) {
+		matches := strings.HasPrefix(path, prefix) || path+"/" == prefix
+		if matches && len(prefix) > len(bestMatch) {
 			bestMatch = prefix
 			bestFS = fs
 		}
 	}
-	
+	return
+}
+
+func (c *CompositeFS) findFS(path string) (FileSystem, string) {
+	path = NormalizePath(path)
+
+	// Check for longest prefix match
+	bestMatch, bestFS := c.longestMount(path)
 	if bestFS != nil {
 		// Strip the mount prefix so the underlying FS sees paths relative to its root.
 		// Exception: URL-based mounts (containing "://") keep the full path because
@@ -67,11 +90,16 @@ func (c *CompositeFS) findFS(path string) (FileSystem, string) {
 		adjustedPath := path
 		if !strings.Contains(bestMatch, "://") && !strings.Contains(bestMatch, ".com/") {
 			adjustedPath = strings.TrimPrefix(path, bestMatch)
+			if path+"/" == bestMatch {
+				adjustedPath = ""
+			}
 		}
 		return bestFS, adjustedPath
 	}
 	
 	// Use fallback if available
+	c.mu.RLock()
+	defer c.mu.RUnlock()
 	if c.fallback != nil {
 		return c.fallback, path
 	}
@@ -143,15 +171,35 @@ func NewReadOnlyLocalFS(basePath string) *LocalFS {
 	return &LocalFS{basePath: basePath, readOnly: true}
 }
 
-func (l *LocalFS) resolvePath(path string) string {
-	if filepath.IsAbs(path) {
-		return path
+// resolvePath maps a path to the disk.  Read-only filesystems refuse paths
+// that lead outside their base, eg with "..", so a mount cannot be used to
+// read arbitrary files.
+func (l *LocalFS) resolvePath(path string) (string, error) {
+	fullPath := filepath.FromSlash(NormalizePath(path))
+	if !filepath.IsAbs(fullPath) {
+		fullPath = filepath.Join(l.basePath, fullPath)
 	}
-	return filepath.Join(l.basePath, path)
+	if l.readOnly {
+		base, err := filepath.Abs(l.basePath)
+		if err != nil {
+			return "", err
+		}
+		abs, err := filepath.Abs(fullPath)
+		if err != nil {
+			return "", err
+		}
+		if rel, err := filepath.Rel(base, abs); err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return "", fmt.Errorf("path %s is outside the read-only filesystem at %s", path, l.basePath)
+		}
+	}
+	return fullPath, nil
 }
 
 func (l *LocalFS) ReadFile(path string) ([]byte, error) {
-	fullPath := l.resolvePath(path)
+	fullPath, err := l.resolvePath(path)
+	if err != nil {
+		return nil, err
+	}
 	return os.ReadFile(fullPath)
 }
 
@@ -159,7 +207,10 @@ func (l *LocalFS) WriteFile(path string, data []byte) error {
 	if l.readOnly {
 		return fmt.Errorf("filesystem is read-only")
 	}
-	fullPath := l.resolvePath(path)
+	fullPath, err := l.resolvePath(path)
+	if err != nil {
+		return err
+	}
 	dir := filepath.Dir(fullPath)
 	if err := os.MkdirAll(dir, 0755); err != nil {
 		return err
@@ -168,7 +219,10 @@ func (l *LocalFS) WriteFile(path string, data []byte) error {
 }
 
 func (l *LocalFS) ListFiles(dir string) ([]string, error) {
-	fullPath := l.resolvePath(dir)
+	fullPath, err := l.resolvePath(dir)
+	if err != nil {
+		return nil, err
+	}
 	entries, err := os.ReadDir(fullPath)
 	if err != nil {
 		return nil, err
@@ -177,15 +231,18 @@ func (l *LocalFS) ListFiles(dir string) ([]string, error) {
 	var files []string
 	for _, entry := range entries {
 		if !entry.IsDir() {
-			files = append(files, filepath.Join(dir, entry.Name()))
+			files = append(files, NormalizePath(filepath.Join(dir, entry.Name())))
 		}
 	}
 	return files, nil
 }
 
 func (l *LocalFS) Exists(path string) bool {
-	fullPath := l.resolvePath(path)
-	_, err := os.Stat(fullPath)
+	fullPath, err := l.resolvePath(path)
+	if err != nil {
+		return false
+	}
+	_, err = os.Stat(fullPath)
 	return err == nil
 }
 
@@ -209,7 +266,7 @@ func (m *MemoryFS) ReadFile(path string) ([]byte, error) {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 	
-	data, exists := m.files[path]
+	data, exists := m.files[NormalizePath(path)]
 	if !exists {
 		return nil, fmt.Errorf("file not found: %s", path)
 	}
@@ -220,7 +277,7 @@ func (m *MemoryFS) WriteFile(path string, data []byte) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 	
-	m.files[path] = append([]byte(nil), data...) // Store a copy
+	m.files[NormalizePath(path)] = append([]byte(nil), data...) // Store a copy
 	return nil
 }
 
@@ -241,7 +298,7 @@ func (m *MemoryFS) Exists(path string) bool {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 	
-	_, exists := m.files[path]
+	_, exists := m.files[NormalizePath(path)]
 	return exists
 }
 
@@ -255,7 +312,7 @@ func (m *MemoryFS) PreloadFiles(files map[string][]byte) {
 	defer m.mu.Unlock()
 	
 	for path, content := range files {
-		m.files[path] = append([]byte(nil), content...)
+		m.files[NormalizePath(path)] = append([]byte(nil), content...)
 	}
 }
 
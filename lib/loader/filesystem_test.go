@@ -1,6 +1,10 @@
 package loader
 
 import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
 	"testing"
 	"strings"
 )
@@ -130,4 +134,155 @@ system UberMVP(arch UberMVPArch) {
 	
 	// Now run the exact same test scenario
 	testScenario(t, cfs)
-}
\ No newline at end of file
+}
+
+func TestNormalizePath(t *testing.T) {
+	cases := map[string]string{
+		"./a/b.sdl":                "a/b.sdl",
+		`lib\..\stdlib\common.sdl`: "stdlib/common.sdl",
+		"/x/./y/../z.sdl":          "/x/z.sdl",
+		"../a.sdl":                 "../a.sdl",
+		`C:\sdl\a.sdl`:             "C:/sdl/a.sdl",
+		"@stdlib//common.sdl":      "@stdlib/common.sdl",
+		"https://h.com/a/../b.sdl": "https://h.com/b.sdl",
+		"":                         "",
+	}
+	for in, want := range cases {
+		if got := NormalizePath(in); got != want {
+			t.Errorf("NormalizePath(%q) = %q, want %q", in, got, want)
+		}
+	}
+	if !IsAbsPath("C:/sdl/a.sdl") || !IsAbsPath("/a.sdl") || IsAbsPath("a/b.sdl") {
+		t.Errorf("IsAbsPath did not recognise absolute paths")
+	}
+}
+
+// TestFileSystemResolverTraversal verifies that relative imports with ".."
+// and Windows separators resolve to one canonical path within mounts,
+// whether or not the mount prefix ends with a slash.
+func TestFileSystemResolverTraversal(t *testing.T) {
+	workspace := NewMemoryFS()
+	workspace.WriteFile("lib/common.sdl", []byte("component C {}"))
+	stdlib := NewMemoryFS()
+	stdlib.WriteFile("common.sdl", []byte("component S {}"))
+
+	cfs := NewCompositeFS()
+	cfs.Mount("/workspace", workspace)
+	cfs.Mount("@stdlib/", stdlib)
+	r := NewFileSystemResolver(cfs)
+
+	cases := []struct{ importer, imp, want string }{
+		{"/workspace/designs/app.sdl", "../lib/common.sdl", "/workspace/lib/common.sdl"},
+		{"/workspace/designs/app.sdl", `..\lib\.\common.sdl`, "/workspace/lib/common.sdl"},
+		{`\workspace\designs\app.sdl`, "../lib/common.sdl", "/workspace/lib/common.sdl"},
+		{"/workspace/designs/app.sdl", "/workspace/designs/../lib/common.sdl", "/workspace/lib/common.sdl"},
+		{"@stdlib/net/http.sdl", "../common.sdl", "@stdlib/common.sdl"},
+		{"", "./@stdlib/common.sdl", "@stdlib/common.sdl"},
+	}
+	for _, c := range cases {
+		_, canonical, err := r.Resolve(c.importer, c.imp, false)
+		if err != nil {
+			t.Errorf("Resolve(%q, %q) failed: %v", c.importer, c.imp, err)
+		} else if canonical != c.want {
+			t.Errorf("Resolve(%q, %q) = %q, want %q", c.importer, c.imp, canonical, c.want)
+		}
+	}
+
+	if _, err := cfs.ListFiles("/workspace"); err != nil {
+		t.Errorf("ListFiles on the mount root failed: %v", err)
+	}
+	if cfs.Exists("/workspaces/lib/common.sdl") {
+		t.Errorf("/workspace mount should not match /workspaces")
+	}
+}
+
+// TestReadOnlyLocalFSRejectsTraversal verifies that ".." cannot be used to
+// read outside a read-only mount while writable filesystems allow it.
+func TestReadOnlyLocalFSRejectsTraversal(t *testing.T) {
+	root := t.TempDir()
+	base := filepath.Join(root, "demos")
+	if err := os.MkdirAll(filepath.Join(base, "sub"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	os.WriteFile(filepath.Join(base, "sub", "app.sdl"), []byte("component A {}"), 0644)
+	os.WriteFile(filepath.Join(root, "secret.sdl"), []byte("component Secret {}"), 0644)
+
+	readOnly := NewReadOnlyLocalFS(base)
+	if _, err := readOnly.ReadFile(`sub\..\sub/app.sdl`); err != nil {
+		t.Errorf("ReadFile within the mount failed: %v", err)
+	}
+	for _, p := range []string{"../secret.sdl", `sub\..\..\secret.sdl`, filepath.Join(root, "secret.sdl")} {
+		if _, err := readOnly.ReadFile(p); err == nil || !strings.Contains(err.Error(), "outside the read-only filesystem") {
+			t.Errorf("ReadFile(%q) should be rejected, got %v", p, err)
+		}
+		if readOnly.Exists(p) {
+			t.Errorf("Exists(%q) should be false", p)
+		}
+	}
+
+	if _, err := NewLocalFS(base).ReadFile("../secret.sdl"); err != nil {
+		t.Errorf("writable LocalFS should allow ..: %v", err)
+	}
+
+	cfs := NewCompositeFS()
+	cfs.Mount("/demos/", readOnly)
+	content, canonical, err := NewFileSystemResolver(cfs).Resolve("/demos/sub/app.sdl", "./app.sdl", true)
+	if err != nil {
+		t.Fatalf("Resolve within the mount failed: %v", err)
+	}
+	data, _ := io.ReadAll(content)
+	if canonical != "/demos/sub/app.sdl" || string(data) != "component A {}" {
+		t.Errorf("Resolve = %q, %q", canonical, data)
+	}
+}
+
+// TestFileSystemResolverReportsAttempts verifies that relative imports are
+// also looked for in the search paths and that a missing import lists every
+// path it was looked for at with the mount each was looked up in.
+func TestFileSystemResolverReportsAttempts(t *testing.T) {
+	workspace := NewMemoryFS()
+	workspace.WriteFile("designs/app.sdl", []byte("component App {}"))
+	shared := NewMemoryFS()
+	shared.WriteFile("common.sdl", []byte("component C {}"))
+
+	cfs := NewCompositeFS()
+	cfs.Mount("/workspace", workspace)
+	cfs.Mount("/shared", shared)
+	cfs.SetFallback(NewMemoryFS())
+	r := NewFileSystemResolver(cfs)
+	r.SearchPaths = []string{"/shared", "vendor"}
+
+	if _, canonical, err := r.Resolve("/workspace/designs/app.sdl", "common.sdl", false); err != nil || canonical != "/shared/common.sdl" {
+		t.Errorf("Resolve(common.sdl) = %q, %v, want it found in the /shared search path", canonical, err)
+	}
+
+	_, _, err := r.Resolve("/workspace/designs/app.sdl", "missing.sdl", false)
+	var notFound *ImportNotFoundError
+	if !errors.As(err, &notFound) {
+		t.Fatalf("Resolve(missing.sdl) error = %v, want an *ImportNotFoundError", err)
+	}
+	want := []ImportAttempt{
+		{Path: "/workspace/designs/missing.sdl", Reason: "relative to importer", Where: "mount /workspace/"},
+		{Path: "/shared/missing.sdl", Reason: "search path /shared", Where: "mount /shared/"},
+		{Path: "vendor/missing.sdl", Reason: "search path vendor", Where: "fallback filesystem"},
+	}
+	if len(notFound.Attempts) != len(want) {
+		t.Fatalf("attempts = %+v, want %+v", notFound.Attempts, want)
+	}
+	for i, attempt := range notFound.Attempts {
+		attempt.Err = nil
+		if attempt != want[i] {
+			t.Errorf("attempt %d = %+v, want %+v", i, attempt, want[i])
+		}
+	}
+	for _, line := range []string{
+		"file not found: missing.sdl (imported by /workspace/designs/app.sdl), tried:",
+		"\n  /workspace/designs/missing.sdl (relative to importer, mount /workspace/: ",
+		"\n  /shared/missing.sdl (search path /shared, mount /shared/: ",
+		"\n  vendor/missing.sdl (search path vendor, fallback filesystem: ",
+	} {
+		if !strings.Contains(err.Error(), line) {
+			t.Errorf("error %q does not contain %q", err, line)
+		}
+	}
+}
@@ -4,13 +4,19 @@ import (
 	"bytes"
 	"fmt"
 	"io"
-	"path/filepath"
+	"net/url"
+	"path"
+	"slices"
 	"strings"
 )
 
 // FileSystemResolver implements FileResolver using a FileSystem backend
 type FileSystemResolver struct {
 	fs FileSystem
+
+	// Directories relative imports are looked for in, in order, when they
+	// are not found relative to the importing file
+	SearchPaths []string
 }
 
 // NewFileSystemResolver creates a resolver that uses the provided FileSystem
@@ -18,70 +24,176 @@ func NewFileSystemResolver(fs FileSystem) *FileSystemResolver {
 	return &FileSystemResolver{fs: fs}
 }
 
-// Resolve implements the FileResolver interface
+// ImportNotFoundError is returned when an import is not found at any of the
+// paths it was looked for at, which it lists in the order they were tried.
+type ImportNotFoundError struct {
+	ImportPath string
+	Importer   string
+	Attempts   []ImportAttempt
+}
+
+// ImportAttempt is a path an import was looked for at.
+type ImportAttempt struct {
+	Path   string
+	Reason string // Why the path was tried, eg "relative to importer"
+	Where  string // Filesystem the path was looked up in, eg "mount @stdlib/"
+	Err    error  // Why reading the path failed, if more is known than that it is missing
+}
+
+func (e *ImportNotFoundError) Error() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "file not found: %s", e.ImportPath)
+	if e.Importer != "" {
+		fmt.Fprintf(&sb, " (imported by %s)", e.Importer)
+	}
+	sb.WriteString(", tried:")
+	for _, attempt := range e.Attempts {
+		fmt.Fprintf(&sb, "\n  %s (%s", attempt.Path, attempt.Reason)
+		if attempt.Where != "" {
+			fmt.Fprintf(&sb, ", %s", attempt.Where)
+		}
+		if attempt.Err != nil {
+			fmt.Fprintf(&sb, ": %v", attempt.Err)
+		}
+		sb.WriteString(")")
+	}
+	return sb.String()
+}
+
+// Unwrap returns why reading each attempted path failed, eg so a connection
+// error of a remote filesystem can be detected.
+func (e *ImportNotFoundError) Unwrap() (errs []error) {
+	for _, attempt := range e.Attempts {
+		if attempt.Err != nil {
+			errs = append(errs, attempt.Err)
+		}
+	}
+	return
+}
+
+// Resolve implements the FileResolver interface.  The import is looked for
+// at each candidate path in turn and a *ImportNotFoundError lists them all
+// if it is at none.
 func (r *FileSystemResolver) Resolve(importerPath, importPath string, open bool) (content io.ReadCloser, canonicalPath string, err error) {
-	// Resolve the import path
-	resolvedPath := r.resolveImportPath(importPath, importerPath)
-	
-	// Check if file exists
-	if !r.fs.Exists(resolvedPath) {
-		return nil, "", fmt.Errorf("file not found: %s", resolvedPath)
+	notFound := &ImportNotFoundError{ImportPath: importPath, Importer: importerPath}
+	for _, attempt := range r.candidates(importPath, importerPath) {
+		if r.fs.Exists(attempt.Path) {
+			canonicalPath = attempt.Path
+			break
+		}
+		// Remote filesystems fail for reasons other than a missing file (eg
+		// connection refused, HTTP 500) so record why the read failed
+		if _, err := r.fs.ReadFile(attempt.Path); err != nil {
+			attempt.Err = err
+		}
+		attempt.Where = r.describeMount(attempt.Path)
+		notFound.Attempts = append(notFound.Attempts, attempt)
 	}
-	
-	// Use the resolved path as canonical path
-	canonicalPath = resolvedPath
-	
+	if canonicalPath == "" {
+		return nil, "", notFound
+	}
+
 	if !open {
 		// Just return the canonical path without opening
 		return nil, canonicalPath, nil
 	}
-	
+
 	// Read the file content
-	data, err := r.fs.ReadFile(resolvedPath)
+	data, err := r.fs.ReadFile(canonicalPath)
 	if err != nil {
-		return nil, "", fmt.Errorf("failed to read file %s: %w", resolvedPath, err)
+		return nil, "", fmt.Errorf("failed to read file %s: %w", canonicalPath, err)
 	}
-	
+
 	// Wrap in a ReadCloser
 	content = io.NopCloser(bytes.NewReader(data))
-	
+
 	return content, canonicalPath, nil
 }
 
-// resolveImportPath resolves an import path relative to the importing file
-func (r *FileSystemResolver) resolveImportPath(importPath, importerPath string) string {
-	// Handle different import types
-	
-	// 1. Named imports (@stdlib/, @examples/, etc.)
-	if strings.HasPrefix(importPath, "@") {
-		// These are handled as-is by the filesystem mount
-		return importPath
+// candidates returns the paths an import is looked for at, in order: named
+// (@stdlib/...), URL, GitHub and absolute imports as written, which the
+// filesystem mounts handle, and relative imports relative to the importing
+// file (or the root if there is no importer) and then to each search path.
+func (r *FileSystemResolver) candidates(importPath, importerPath string) (out []ImportAttempt) {
+	importPath = NormalizePath(importPath)
+	if strings.HasPrefix(importPath, "@") || strings.Contains(importPath, "://") ||
+		strings.HasPrefix(importPath, "github.com/") || IsAbsPath(importPath) {
+		return []ImportAttempt{{Path: importPath, Reason: "as written"}}
 	}
-	
-	// 2. URL imports (https://, http://)
-	if strings.Contains(importPath, "://") {
-		return importPath
+
+	if importerPath == "" {
+		out = append(out, ImportAttempt{Path: importPath, Reason: "relative to the root"})
+	} else {
+		out = append(out, ImportAttempt{Path: resolveRelative(importPath, NormalizePath(importerPath)), Reason: "relative to importer"})
 	}
-	
-	// 3. GitHub imports (github.com/...)
-	if strings.HasPrefix(importPath, "github.com/") {
-		// Let the GitHub filesystem handle the transformation
-		return importPath
+	for _, dir := range r.SearchPaths {
+		candidate := path.Join(NormalizePath(dir), importPath)
+		if !slices.ContainsFunc(out, func(a ImportAttempt) bool { return a.Path == candidate }) {
+			out = append(out, ImportAttempt{Path: candidate, Reason: "search path " + dir})
+		}
 	}
-	
-	// 4. Absolute paths
-	if strings.HasPrefix(importPath, "/") {
-		return importPath
+	return
+}
+
+// resolveRelative resolves a relative import against the path of the
+// importing file.
+func resolveRelative(importPath, importerPath string) string {
+	if strings.Contains(importerPath, "://") {
+		base, err := url.Parse(importerPath)
+		if err == nil {
+			if ref, err := url.Parse(importPath); err == nil {
+				return base.ResolveReference(ref).String()
+			}
+		}
 	}
-	
-	// 5. Relative paths - resolve relative to the importer's directory
-	if importerPath == "" {
-		// If no importer path, treat as relative to root
-		return importPath
+	return path.Join(path.Dir(importerPath), importPath)
+}
+
+// describeMount names the filesystem of a CompositeFS that path is looked up
+// in, or returns "" for other filesystems.
+func (r *FileSystemResolver) describeMount(p string) string {
+	cfs, ok := r.fs.(*CompositeFS)
+	if !ok {
+		return ""
 	}
-	
-	dir := filepath.Dir(importerPath)
-	return filepath.Join(dir, importPath)
+	prefix, mounted := cfs.MountFor(p)
+	switch {
+	case !mounted:
+		return "no filesystem mounted"
+	case prefix == "":
+		return "fallback filesystem"
+	}
+	return "mount " + prefix
+}
+
+// NormalizePath spells a path the same way on every platform: separators
+// become forward slashes and "." and ".." elements are resolved, so
+// `lib\..\stdlib\common.sdl` and "./stdlib/common.sdl" both become
+// "stdlib/common.sdl".  A ".." that climbs above a relative path is kept.
+// URLs only have their path cleaned.
+func NormalizePath(p string) string {
+	if p == "" {
+		return ""
+	}
+	if strings.Contains(p, "://") {
+		u, err := url.Parse(p)
+		if err != nil || u.Path == "" {
+			return p
+		}
+		u.Path = path.Clean(u.Path)
+		return u.String()
+	}
+	return path.Clean(strings.ReplaceAll(p, "\\", "/"))
+}
+
+// IsAbsPath reports whether a normalized path is absolute, either rooted at
+// "/" or at a Windows drive such as "C:/".
+func IsAbsPath(p string) bool {
+	if strings.HasPrefix(p, "/") {
+		return true
+	}
+	return len(p) >= 3 && p[1] == ':' && p[2] == '/' &&
+		(('a' <= p[0] && p[0] <= 'z') || ('A' <= p[0] && p[0] <= 'Z'))
 }
 
 // CreateDefaultFileSystem creates a composite filesystem suitable for server usage
@@ -15,6 +15,7 @@ type SetStmt = decl.SetStmt
 type ForStmt = decl.ForStmt
 type ReturnStmt = decl.ReturnStmt
 type ExprStmt = decl.ExprStmt
+type SpawnStmt = decl.SpawnStmt
 type DelayStmt = decl.DelayStmt
 type TypeDecl = decl.TypeDecl
 type ParamDecl = decl.ParamDecl
@@ -47,6 +48,7 @@ type OptionsDecl = decl.OptionsDecl
 type ImportDecl = decl.ImportDecl
 type GeneratorSpec = decl.GeneratorSpec
 type MetricSpec = decl.MetricSpec
+type ScenarioDecl = decl.ScenarioDecl
 
 var SplitMemberAccessTarget = decl.SplitMemberAccessTarget
 
@@ -3,6 +3,8 @@ package loader
 import (
 	"fmt"
 	"log"
+	"maps"
+	"slices"
 	"strings"
 
 	"github.com/panyam/goutils/fn"
@@ -34,13 +36,78 @@ type Inference struct {
 
 	// Inference starts the root file
 	rootFile *FileDecl
+
+	// Options from the file's top level options blocks, the defaults for its systems
+	fileOptions map[string]Value
+
+	Options InferenceOptions
+
+	// Likely mistakes that do not stop the file from compiling
+	Warnings []error
+
+	// Let variables referenced so far, to warn about unused ones
+	usedLets map[*IdentifierExpr]bool
+}
+
+// InferenceOptions tune how strictly types are checked.
+type InferenceOptions struct {
+	// Whether an Int may be used where a Float is expected (param defaults,
+	// declared types, call arguments and mixed arithmetic or comparisons).
+	// When false each such use is an error asking for an explicit float(x).
+	AllowImplicitIntFloatPromotion bool
+
+	// Whether warnings, eg shadowed or unused let variables, are errors.
+	Strict bool
+}
+
+func DefaultInferenceOptions() InferenceOptions {
+	return InferenceOptions{AllowImplicitIntFloatPromotion: true}
 }
 
 func NewInference(fp string, fd *FileDecl) *Inference {
 	return &Inference{
 		filePath: fp,
 		rootFile: fd,
+		Options:  DefaultInferenceOptions(),
+		usedLets: map[*IdentifierExpr]bool{},
+	}
+}
+
+// Warnf records a likely mistake, or an error in strict mode.
+func (i *Inference) Warnf(pos Location, format string, args ...any) {
+	if i.Options.Strict {
+		i.Errorf(pos, format, args...)
+		return
 	}
+	i.Warnings = append(i.Warnings, InfErrorf(pos, format, args...))
+}
+
+// promotesIntToFloat reports whether a value of type from is used as type to
+// by promoting an Int to a Float.  When promotions are not allowed this is
+// an error, reported against expr, though still reported as a promotion so
+// callers do not also flag a type mismatch.
+func (i *Inference) promotesIntToFloat(from, to *Type, expr Expr) bool {
+	if !from.Equals(IntType) || !to.Equals(FloatType) {
+		return false
+	}
+	if !i.Options.AllowImplicitIntFloatPromotion {
+		i.Errorf(expr.Pos(), "implicit promotion of int to float is not allowed, convert it explicitly with %s", floatConversionHint(expr))
+	}
+	return true
+}
+
+// floatConversionHint suggests how to write expr as a float.
+func floatConversionHint(expr Expr) string {
+	if lit, ok := expr.(*LiteralExpr); ok {
+		if v, isInt := lit.Value.Value.(int64); isInt {
+			return fmt.Sprintf("%d.0 or float(%d)", v, v)
+		}
+	}
+	switch e := expr.(type) {
+	case *IdentifierExpr, *MemberAccessExpr:
+		return fmt.Sprintf("float(%s)", e)
+	}
+	return "float(...)"
 }
 
 // Begins type inference starting at the root file
@@ -64,6 +131,12 @@ func (i *Inference) Eval(rootEnv *Env[Node]) bool {
 	aggregators, _ := file.Aggregators()
 	nativeMethods, _ := file.GetNativeMethods()
 
+	fileOptions, _ := file.GetOptions()
+	i.fileOptions = map[string]Value{}
+	for _, opts := range fileOptions {
+		i.EvalForOptionsDecl(opts, i.fileOptions)
+	}
+
 	// Handle Aggregators: Infer types for system declarations
 	for _, agg := range aggregators {
 		i.EvalForAggregator(agg, rootScope.Push()) // System scope can see globals/imports from rootEnv
@@ -72,6 +145,7 @@ func (i *Inference) Eval(rootEnv *Env[Node]) bool {
 	// Handle native methods
 	for _, method := range nativeMethods {
 		i.EvalForMethodSignature(method, nil, rootScope) // No component context
+		i.EvalForMethodOutcomes(method, nil, rootScope)
 	}
 
 	// First pass: Resolve TypeDecls in component parameter defaults, method parameters, and method return types.
@@ -116,6 +190,7 @@ func (i *Inference) EvalForAggregator(agg *AggregatorDecl, rootScope *TypeScope)
 
 func (i *Inference) EvalForComponent(compDecl *ComponentDecl, rootScope *TypeScope) (success bool) {
 	params, _ := compDecl.Params()
+	i.checkParamDefaultOrder(compDecl, params)
 
 	for _, paramDecl := range params { // Assuming direct field access or appropriate getter
 		i.EvalForParamDecl(paramDecl, compDecl, rootScope)
@@ -140,6 +215,11 @@ func (i *Inference) EvalForComponent(compDecl *ComponentDecl, rootScope *TypeSco
 		rootScope.env.Set(usesDecl.Name.Value, compDefinition)
 		usesDecl.Name.SetInferredType(instanceType)
 		usesDecl.ResolvedComponent = compDefinition
+		i.resolveUsesArgs(usesDecl)
+	}
+	for _, usesDecl := range usesDecls {
+		i.checkDependencyOverrides(usesDecl, compDecl, usesDecls)
+		i.checkParamOverrides(usesDecl, compDecl)
 	}
 
 	// Method signatures
@@ -147,16 +227,296 @@ func (i *Inference) EvalForComponent(compDecl *ComponentDecl, rootScope *TypeSco
 	for _, method := range methods {
 		// First see if signatures are well typed
 		i.EvalForMethodSignature(method, compDecl, rootScope)
+		i.checkAnnotations(method, compDecl)
 
 		// Then enter body with a new scope
 		if method.Body != nil {
 			methodScope := rootScope.PushMethod(compDecl, method)
 			i.EvalForBlockStmt(method.Body, methodScope)
 		}
+		i.EvalForMethodOutcomes(method, compDecl, rootScope)
+	}
+
+	// Profiles can only swap in new bodies for methods the component declares
+	profiles, _ := compDecl.Profiles()
+	for _, name := range slices.Sorted(maps.Keys(profiles)) {
+		for _, method := range profiles[name].Methods {
+			base := methods[method.Name.Value]
+			if base == nil {
+				i.Errorf(method.Name.Pos(), "profile '%s' of component '%s' overrides method '%s' which the component does not declare", name, compDecl.Name.Value, method.Name.Value)
+				continue
+			}
+			i.EvalForMethodSignature(method, compDecl, rootScope)
+			i.checkAnnotations(method, compDecl)
+			if !sameSignature(method, base) {
+				i.Errorf(method.Name.Pos(), "method '%s' in profile '%s' of component '%s' must have the same signature as the method it overrides", method.Name.Value, name, compDecl.Name.Value)
+				continue
+			}
+			if method.Body != nil {
+				methodScope := rootScope.PushMethod(compDecl, method)
+				i.EvalForBlockStmt(method.Body, methodScope)
+			}
+		}
 	}
 	return
 }
 
+// sameSignature reports whether two methods take and return the same
+// (resolved) types.
+func sameSignature(a, b *MethodDecl) bool {
+	resolved := func(t *TypeDecl) *Type {
+		if t == nil {
+			return nil
+		}
+		return t.ResolvedType()
+	}
+	if len(a.Parameters) != len(b.Parameters) {
+		return false
+	}
+	for idx, param := range a.Parameters {
+		if !resolved(param.TypeDecl).Equals(resolved(b.Parameters[idx].TypeDecl)) {
+			return false
+		}
+	}
+	return resolved(a.ReturnType).Equals(resolved(b.ReturnType))
+}
+
+// checkAnnotations ensures a method's annotations are known and that their
+// arguments are literals of the right types.
+func (i *Inference) checkAnnotations(method *MethodDecl, compDecl *ComponentDecl) {
+	seen := map[string]bool{}
+	for _, a := range method.Annotations {
+		name := a.Name.Value
+		argTypes, known := decl.KnownAnnotations[name]
+		if !known {
+			i.Errorf(a.Pos(), "unknown annotation '@%s' on method '%s' (expected one of %s)", name, method.Name.Value, strings.Join(slices.Sorted(maps.Keys(decl.KnownAnnotations)), ", "))
+			continue
+		}
+		if seen[name] {
+			i.Errorf(a.Pos(), "annotation '@%s' is given more than once on method '%s'", name, method.Name.Value)
+			continue
+		}
+		seen[name] = true
+		args := map[string]float64{}
+		for _, arg := range a.Args {
+			argName := arg.Var.Value
+			argType, known := argTypes[argName]
+			if !known {
+				i.Errorf(arg.Var.Pos(), "unknown argument '%s' for '@%s' (expected one of %s)", argName, name, strings.Join(slices.Sorted(maps.Keys(argTypes)), ", "))
+				continue
+			}
+			value, isConst := decl.ConstantValue(arg.Value)
+			if !isConst {
+				i.Errorf(arg.Value.Pos(), "argument '%s' of '@%s' must be a literal value, found %s", argName, name, arg.Value.String())
+				continue
+			}
+			if !value.Type.Equals(argType) {
+				i.Errorf(arg.Value.Pos(), "argument '%s' of '@%s' must be a %s, found %s", argName, name, annotationArgKind(name, argName, argType), value.Type.String())
+				continue
+			}
+			args[argName], _ = value.Value.(float64)
+		}
+		if name == "coalesce" {
+			if window, ok := args["window"]; !ok {
+				i.Errorf(a.Pos(), "'@coalesce' on method '%s.%s' requires a window, eg @coalesce(window = 20ms)", compDecl.Name.Value, method.Name.Value)
+			} else if window <= 0 {
+				i.Errorf(a.Arg("window").Pos(), "window of '@coalesce' must be positive")
+			}
+		}
+		if name != "memoize" {
+			continue
+		}
+		if ttl, ok := args["ttl"]; !ok {
+			i.Errorf(a.Pos(), "'@memoize' on method '%s.%s' requires a ttl, eg @memoize(ttl = 5s)", compDecl.Name.Value, method.Name.Value)
+		} else if ttl <= 0 {
+			i.Errorf(a.Arg("ttl").Pos(), "ttl of '@memoize' must be positive")
+		}
+		if hitRate, ok := args["hit_rate"]; ok && (hitRate < 0 || hitRate > 1) {
+			i.Errorf(a.Arg("hit_rate").Pos(), "hit_rate of '@memoize' must be between 0 and 1, found %g", hitRate)
+		}
+	}
+}
+
+func annotationArgKind(annotation, arg string, argType *Type) string {
+	if annotation == "memoize" && arg == "ttl" || annotation == "coalesce" && arg == "window" {
+		return "duration"
+	}
+	return argType.String()
+}
+
+// resolveUsesArgs matches the positional args of a uses declaration to the
+// used component's params in declaration order and checks that every named
+// arg is a param or dependency, unless the component is native, and that
+// none is assigned twice.  Overrides
+// is rebuilt from the args in the order written.
+func (i *Inference) resolveUsesArgs(usesDecl *UsesDecl) {
+	if usesDecl.Args == nil {
+		return
+	}
+	comp := usesDecl.ResolvedComponent
+	params, _ := comp.Params()
+	overrides := []*AssignmentStmt{}
+	assigned := map[string]bool{}
+	named := false
+	for idx, arg := range usesDecl.Args {
+		if arg.Var == nil {
+			if named {
+				i.Errorf(arg.Pos(), "positional argument after named arguments in 'uses %s %s'", usesDecl.Name.Value, comp.Name.Value)
+				continue
+			}
+			if idx >= len(params) {
+				i.Errorf(arg.Pos(), "too many arguments in 'uses %s %s': component '%s' declares %d params", usesDecl.Name.Value, comp.Name.Value, comp.Name.Value, len(params))
+				continue
+			}
+			ident := decl.NewIdent(params[idx].Name.Value)
+			ident.NodeInfo = arg.NodeInfo
+			arg = &AssignmentStmt{NodeInfo: arg.NodeInfo, Var: ident, Value: arg.Value}
+		} else {
+			named = true
+			param, _ := comp.GetParam(arg.Var.Value)
+			dep, _ := comp.GetDependency(arg.Var.Value)
+			if param == nil && dep == nil && !comp.IsNative { // Native params are only known to the runtime
+				i.Errorf(arg.Var.Pos(), "'%s' is not a param or dependency of component '%s'", arg.Var.Value, comp.Name.Value)
+				continue
+			}
+		}
+		if assigned[arg.Var.Value] {
+			i.Errorf(arg.Pos(), "'%s' of '%s' is assigned more than once", arg.Var.Value, usesDecl.Name.Value)
+			continue
+		}
+		assigned[arg.Var.Value] = true
+		overrides = append(overrides, arg)
+	}
+	if assigned["MaxThroughput"] && assigned["AvgHoldTime"] {
+		i.Warnf(usesDecl.Pos(), "'%s' sets both MaxThroughput and AvgHoldTime, the hold time is derived from MaxThroughput so AvgHoldTime is ignored", usesDecl.Name.Value)
+	}
+	usesDecl.Overrides = overrides
+}
+
+// checkDependencyOverrides ensures that overrides wiring a dependency of the
+// used component assign an instance of that dependency's component, listing
+// the instances in compDecl that would satisfy it when they do not.
+func (i *Inference) checkDependencyOverrides(usesDecl *UsesDecl, compDecl *ComponentDecl, instances []*UsesDecl) {
+	for _, override := range usesDecl.Overrides {
+		dep, _ := usesDecl.ResolvedComponent.GetDependency(override.Var.Value)
+		if dep == nil {
+			continue
+		}
+		var assigned *UsesDecl
+		for _, inst := range instances {
+			if inst.Name.Value == instanceName(override.Value) {
+				assigned = inst
+			}
+		}
+		if assigned == nil || assigned.ComponentName.Value == dep.ComponentName.Value {
+			continue
+		}
+		var candidates []string
+		for _, inst := range instances {
+			if inst != usesDecl && inst.ComponentName.Value == dep.ComponentName.Value {
+				candidates = append(candidates, inst.Name.Value)
+			}
+		}
+		hint := fmt.Sprintf("no %s instances in component '%s'", dep.ComponentName.Value, compDecl.Name.Value)
+		if len(candidates) > 0 {
+			hint = "compatible instances: " + strings.Join(candidates, ", ")
+		}
+		i.Errorf(override.Value.Pos(), "dependency '%s' of '%s' in component '%s' must be a %s but '%s' is a %s (%s)",
+			dep.Name.Value, usesDecl.Name.Value, compDecl.Name.Value, dep.ComponentName.Value,
+			assigned.Name.Value, assigned.ComponentName.Value, hint)
+	}
+}
+
+// checkParamOverrides ensures that literal values overriding params of the
+// used component satisfy the params' constraints and are not negative timeouts.  Computed values can only
+// be checked when the component is initialized.
+func (i *Inference) checkParamOverrides(usesDecl *UsesDecl, compDecl *ComponentDecl) {
+	for _, override := range usesDecl.Overrides {
+		param, _ := usesDecl.ResolvedComponent.GetParam(override.Var.Value)
+		if param != nil {
+			i.checkTimeoutValue(param.Name.Value, override.Value)
+		}
+		if param == nil || param.Constraint == nil {
+			continue
+		}
+		paramType := param.Name.InferredType()
+		if param.TypeDecl != nil && param.TypeDecl.ResolvedType() != nil {
+			paramType = param.TypeDecl.ResolvedType()
+		}
+		value, ok := constraintValue(override.Value, paramType)
+		if !ok {
+			continue
+		}
+		if err := param.Constraint.Check(value); err != nil {
+			i.Errorf(override.Value.Pos(), "override of parameter '%s' in 'uses %s %s' in component '%s' violates its constraint: %v",
+				param.Name.Value, usesDecl.Name.Value, usesDecl.ComponentName.Value, compDecl.Name.Value, err)
+		}
+	}
+}
+
+// instanceName returns the instance an override value refers to, as either
+// "name" or "self.name", or "" for any other expression.
+func instanceName(e Expr) string {
+	switch e := e.(type) {
+	case *IdentifierExpr:
+		return e.Value
+	case *MemberAccessExpr:
+		if recv, ok := e.Receiver.(*IdentifierExpr); ok && recv.Value == "self" {
+			return e.Member.Value
+		}
+	}
+	return ""
+}
+
+// checkParamDefaultOrder ensures param defaults only refer to params declared
+// before them, so defaults can be evaluated in declaration order.
+func (i *Inference) checkParamDefaultOrder(compDecl *ComponentDecl, params []*ParamDecl) {
+	order := map[string]int{}
+	for idx, param := range params {
+		order[param.Name.Value] = idx
+	}
+	for idx, param := range params {
+		if param.DefaultValue == nil {
+			continue
+		}
+		for _, ref := range compDecl.ParamRefs(param.DefaultValue) {
+			if order[ref] < idx {
+				continue
+			}
+			if cycle := paramDefaultCycle(compDecl, ref, param.Name.Value, map[string]bool{}); cycle != nil {
+				i.Errorf(param.DefaultValue.Pos(), "circular reference in default values of parameters in component '%s': %s",
+					compDecl.Name.Value, strings.Join(append([]string{param.Name.Value}, cycle...), " -> "))
+			} else {
+				i.Errorf(param.DefaultValue.Pos(), "default value of parameter '%s' in component '%s' refers to parameter '%s' which is declared after it",
+					param.Name.Value, compDecl.Name.Value, ref)
+			}
+			return
+		}
+	}
+}
+
+// paramDefaultCycle returns the chain of params whose defaults lead from
+// param `from` back to `to`, or nil if there is none.
+func paramDefaultCycle(compDecl *ComponentDecl, from, to string, visited map[string]bool) []string {
+	if from == to {
+		return []string{to}
+	}
+	if visited[from] {
+		return nil
+	}
+	visited[from] = true
+	param, _ := compDecl.GetParam(from)
+	if param == nil || param.DefaultValue == nil {
+		return nil
+	}
+	for _, ref := range compDecl.ParamRefs(param.DefaultValue) {
+		if rest := paramDefaultCycle(compDecl, ref, to, visited); rest != nil {
+			return append([]string{from}, rest...)
+		}
+	}
+	return nil
+}
+
 func (i *Inference) EvalForParamDecl(paramDecl *ParamDecl, compDecl *ComponentDecl, rootScope *TypeScope) (success bool) {
 	var resolvedParamType *Type
 
@@ -201,9 +561,7 @@ func (i *Inference) EvalForParamDecl(paramDecl *ParamDecl, compDecl *ComponentDe
 				// either from its TypeDecl or inferred from the default value itself.
 				if resolvedParamType != nil { // If we have an expected type for the param
 					if !defaultValueActualType.Equals(resolvedParamType) {
-						// Allow int to float promotion for default value
-						isPromotion := defaultValueActualType.Equals(IntType) && resolvedParamType.Equals(FloatType)
-						if !isPromotion {
+						if !i.promotesIntToFloat(defaultValueActualType, resolvedParamType, paramDecl.DefaultValue) {
 							i.Errorf(paramDecl.DefaultValue.Pos(), "type mismatch for default value of parameter '%s' in component '%s': parameter type is %s, default value type is %s", paramDecl.Name.Value, compDecl.Name.Value, resolvedParamType.String(), defaultValueActualType.String())
 						}
 					}
@@ -215,9 +573,96 @@ func (i *Inference) EvalForParamDecl(paramDecl *ParamDecl, compDecl *ComponentDe
 			}
 		}
 	}
+	if paramDecl.DefaultValue != nil {
+		i.checkTimeoutValue(paramDecl.Name.Value, paramDecl.DefaultValue)
+	}
+	if paramDecl.Constraint != nil {
+		i.checkParamConstraint(paramDecl, compDecl, resolvedParamType)
+	}
 	return
 }
 
+// checkParamConstraint ensures a param's constraint is made of literals that
+// suit the param's type and that a literal default value satisfies it.
+func (i *Inference) checkParamConstraint(paramDecl *ParamDecl, compDecl *ComponentDecl, paramType *Type) {
+	c := paramDecl.Constraint
+	name, compName := paramDecl.Name.Value, compDecl.Name.Value
+	exprs := c.Allowed
+	if c.IsRange() {
+		exprs = []Expr{c.Min, c.Max}
+		if paramType != nil && !paramType.Equals(IntType) && !paramType.Equals(FloatType) {
+			i.Errorf(c.Pos(), "range constraint on parameter '%s' in component '%s' requires a numeric type, found %s", name, compName, paramType.String())
+			return
+		}
+	}
+	for _, e := range exprs {
+		if paramType != nil && paramType.Tag == decl.TypeTagEnum {
+			if _, ok := decl.EnumVariant(e, paramType.Info.(*decl.EnumDecl)); !ok {
+				i.Errorf(e.Pos(), "constraint on parameter '%s' in component '%s' must list values of %s, found %s", name, compName, paramType.String(), e.String())
+				return
+			}
+		} else if _, ok := decl.ConstantValue(e); !ok {
+			i.Errorf(e.Pos(), "constraint on parameter '%s' in component '%s' must use literal values, found %s", name, compName, e.String())
+			return
+		}
+	}
+	// min itself is only outside the range if the bounds are swapped or not numeric
+	if low, _ := decl.ConstantValue(c.Min); c.IsRange() {
+		if err := c.Check(low); err != nil {
+			i.Errorf(c.Pos(), "invalid range %s for parameter '%s' in component '%s': %v", c, name, compName, err)
+			return
+		}
+	}
+	if paramDecl.DefaultValue != nil {
+		if value, ok := constraintValue(paramDecl.DefaultValue, paramType); ok {
+			if err := c.Check(value); err != nil {
+				i.Errorf(paramDecl.DefaultValue.Pos(), "default value of parameter '%s' in component '%s' violates its constraint: %v", name, compName, err)
+			}
+		}
+	}
+}
+
+// negativeConstant returns the text of e if it is a negative numeric
+// literal.
+func negativeConstant(e Expr) (string, bool) {
+	value, _ := decl.ConstantValue(e)
+	switch v := value.Value.(type) {
+	case int64:
+		return fmt.Sprint(v), v < 0
+	case float64:
+		return fmt.Sprintf("%g", v), v < 0
+	}
+	return "", false
+}
+
+// isTimeoutParam reports whether a param holds a timeout, eg Timeout or
+// ConnectTimeout, which like a latency cannot be negative.
+func isTimeoutParam(name string) bool {
+	return strings.HasSuffix(strings.ToLower(name), "timeout")
+}
+
+// checkTimeoutValue reports a negative literal given to a timeout param as
+// its default or an override.
+func (i *Inference) checkTimeoutValue(paramName string, value Expr) {
+	if !isTimeoutParam(paramName) {
+		return
+	}
+	if v, negative := negativeConstant(value); negative {
+		i.Errorf(value.Pos(), "timeout %s of %s is negative, timeouts cannot be negative", paramName, v)
+	}
+}
+
+// constraintValue returns the value of e if it can be checked against a
+// constraint before the component is initialized, ie a literal or a variant
+// of paramType when it is an enum.
+func constraintValue(e Expr, paramType *Type) (Value, bool) {
+	if paramType != nil && paramType.Tag == decl.TypeTagEnum {
+		idx, ok := decl.EnumVariant(e, paramType.Info.(*decl.EnumDecl))
+		return Value{Type: paramType, Value: idx}, ok
+	}
+	return decl.ConstantValue(e)
+}
+
 // Infer/Check types for a method signature.  The body is not evaluated here
 func (i *Inference) EvalForMethodSignature(method *MethodDecl, compDecl *ComponentDecl, rootScope *TypeScope) (errors []error) {
 	compName := "global"
@@ -247,6 +692,33 @@ func (i *Inference) EvalForMethodSignature(method *MethodDecl, compDecl *Compone
 	return
 }
 
+// EvalForMethodOutcomes checks that the outcome distribution attached to a
+// native method yields values of the method's return type.
+func (i *Inference) EvalForMethodOutcomes(method *MethodDecl, compDecl *ComponentDecl, rootScope *TypeScope) (ok bool) {
+	if method.Outcomes == nil {
+		return true
+	}
+	compName := "global"
+	if compDecl != nil {
+		compName = compDecl.Name.Value
+	}
+	outcomesType, ok := i.EvalForExprType(method.Outcomes, rootScope.PushMethod(compDecl, method))
+	if !ok {
+		return false
+	}
+	var returnType *Type = NilType
+	if method.ReturnType != nil {
+		if returnType = method.ReturnType.ResolvedType(); returnType == nil {
+			return false
+		}
+	}
+	valueType := outcomesType.Info.(*Type)
+	if !valueType.Equals(returnType) {
+		return i.Errorf(method.Name.Pos(), "outcomes of native method '%s.%s' must be %s, got %s", compName, method.Name.Value, returnType.String(), valueType.String())
+	}
+	return true
+}
+
 func (i *Inference) EvalForStmt(stmt Stmt, scope *TypeScope) (returnType *Type, ok bool) {
 	ok = true
 	switch s := stmt.(type) {
@@ -256,12 +728,18 @@ func (i *Inference) EvalForStmt(stmt Stmt, scope *TypeScope) (returnType *Type,
 		return i.EvalForSetStmt(s, scope)
 	case *ExprStmt:
 		return i.EvalForExprType(s.Expression, scope)
-	case *ReturnStmt:
-		var actualReturnType *Type = NilType
-		if s.ReturnValue != nil {
-			return i.EvalForExprType(s.ReturnValue, scope)
+	case *SpawnStmt:
+		// The caller does not wait for a spawned call so it gets nothing back
+		if _, isCall := s.Call.(*CallExpr); !isCall {
+			i.Errorf(s.Call.Pos(), "spawn expects a method call, got '%s'", s.Call)
+			return nil, false
 		}
-		return actualReturnType, ok
+		if _, ok = i.EvalForExprType(s.Call, scope); !ok {
+			return nil, false
+		}
+		return decl.VoidType, true
+	case *ReturnStmt:
+		return i.EvalForReturnStmt(s, scope)
 	case *IfStmt:
 		return i.EvalForIfStmt(s, scope)
 	case *ForStmt:
@@ -329,8 +807,7 @@ func (i *Inference) EvalForExprType(expr Expr, scope *TypeScope) (inferred *Type
 	expr.SetInferredType(inferred)
 
 	if expr.DeclaredType() != nil && !expr.DeclaredType().Equals(inferred) {
-		isIntToFloatPromotion := expr.DeclaredType().Equals(FloatType) && inferred.Equals(IntType)
-		if !isIntToFloatPromotion {
+		if !i.promotesIntToFloat(inferred, expr.DeclaredType(), expr) {
 			i.Errorf(expr.Pos(), "type mismatch for '%s': inferred type %s, but declared type is %s", expr.String(), inferred.String(), expr.DeclaredType().String())
 			return nil, false
 		}
@@ -354,6 +831,11 @@ func (i *Inference) EvalForIdentifierExpr(expr *IdentifierExpr, scope *TypeScope
 	if !ok {
 		return nil, i.Errorf(expr.Pos(), "identifier '%s' not found", expr.Value)
 	}
+	if node, _ := scope.env.Get(expr.Value); node != nil {
+		if ident, isLet := node.(*IdentifierExpr); isLet {
+			i.usedLets[ident] = true
+		}
+	}
 	if t == nil {
 		return nil, i.Errorf(expr.Pos(), "identifier '%s' resolved but its type is nil (internal error)", expr.Value)
 	}
@@ -438,6 +920,8 @@ func (i *Inference) EvalForBinaryExpr(expr *BinaryExpr, scope *TypeScope) (*Type
 	if !lok || !rok || leftType == nil || rightType == nil {
 		return nil, i.Errorf(expr.Pos(), "could not determine type for one or both operands for binary expr ('%s')", expr.Operator)
 	}
+	// Params read through self compare and combine by their value
+	leftType, rightType = derefParamType(leftType), derefParamType(rightType)
 
 	switch expr.Operator {
 	case "+", "-", "*", "/":
@@ -446,6 +930,8 @@ func (i *Inference) EvalForBinaryExpr(expr *BinaryExpr, scope *TypeScope) (*Type
 		}
 		if (leftType.Equals(IntType) || leftType.Equals(FloatType)) &&
 			(rightType.Equals(IntType) || rightType.Equals(FloatType)) {
+			i.promotesIntToFloat(leftType, rightType, expr.Left)
+			i.promotesIntToFloat(rightType, leftType, expr.Right)
 			return FloatType, true
 		}
 		if expr.Operator == "+" && leftType.Equals(StrType) && rightType.Equals(StrType) {
@@ -464,22 +950,34 @@ func (i *Inference) EvalForBinaryExpr(expr *BinaryExpr, scope *TypeScope) (*Type
 		isRightNumeric := rightType.Equals(IntType) || rightType.Equals(FloatType)
 
 		if isLeftNumeric && isRightNumeric {
-			return BoolType, true
-		}
-		if leftType.Equals(rightType) {
-			/*
-				if leftType.Tag == decl. TypeTagEnum {
-					return BoolType, true
+			i.promotesIntToFloat(leftType, rightType, expr.Left)
+			i.promotesIntToFloat(rightType, leftType, expr.Right)
+			if left, right := i.unitKind(expr.Left, scope), i.unitKind(expr.Right, scope); left != "" && right != "" && left != right {
+				return nil, i.Errorf(expr.Pos(), "cannot compare a %s with a %s", left, right)
+			}
+			if i.isDurationExpr(expr.Left, scope) {
+				if bare, ok := bareNumber(expr.Right); ok {
+					return nil, i.Errorf(expr.Right.Pos(), "cannot compare a duration with the bare number %s, add a unit (eg %sms)", bare, bare)
 				}
-				if leftType.Name == "List" || leftType.Name == "Tuple" || leftType.Name == "Outcomes" ||
-					(leftType.OriginalDecl != nil && leftType.IsComponentType()) ||
-					leftType.Name == "OpNode" || leftType.Name == "MethodReference" {
-					return nil, i.Errorf(expr.Pos(), "type mismatch for comparison operator '%s': cannot compare complex type %s", expr.Operator, leftType.String())
+			}
+			if i.isDurationExpr(expr.Right, scope) {
+				if bare, ok := bareNumber(expr.Left); ok {
+					return nil, i.Errorf(expr.Left.Pos(), "cannot compare a duration with the bare number %s, add a unit (eg %sms)", bare, bare)
 				}
-			*/
-			log.Println("TODO - Binary logical operators not implemented.  Just returning BoolType")
+			}
 			return BoolType, true
 		}
+		if leftType.Tag == decl.TypeTagEnum || rightType.Tag == decl.TypeTagEnum {
+			return i.evalEnumComparison(expr, leftType, rightType)
+		}
+		if leftType.Equals(rightType) {
+			// Bools and strings can only be tested for equality
+			equality := expr.Operator == "==" || expr.Operator == "!="
+			if equality && (leftType.Equals(BoolType) || leftType.Equals(StrType)) {
+				return BoolType, true
+			}
+			return nil, i.Errorf(expr.Pos(), "type mismatch for comparison operator '%s': cannot compare %s values", expr.Operator, leftType.String())
+		}
 		return nil, i.Errorf(expr.Pos(), "type mismatch for comparison operator '%s': cannot compare %s and %s", expr.Operator, leftType.String(), rightType.String())
 
 	case "&&", "||":
@@ -493,6 +991,96 @@ func (i *Inference) EvalForBinaryExpr(expr *BinaryExpr, scope *TypeScope) (*Type
 	}
 }
 
+// evalEnumComparison checks a comparison with an enum value.  Only values of
+// the same enum, the same declaration rather than one of the same name, can
+// be compared and only for equality since members have no order.
+func (i *Inference) evalEnumComparison(expr *BinaryExpr, leftType, rightType *Type) (*Type, bool) {
+	enumName := func(t *Type) string {
+		if t.Tag == decl.TypeTagEnum {
+			return "enum " + t.Info.(*EnumDecl).Name.Value
+		}
+		return t.String()
+	}
+	if leftType.Tag != rightType.Tag {
+		return nil, i.Errorf(expr.Pos(), "type mismatch for comparison operator '%s': cannot compare %s and %s", expr.Operator, enumName(leftType), enumName(rightType))
+	}
+	if leftType.Info.(*EnumDecl) != rightType.Info.(*EnumDecl) {
+		return nil, i.Errorf(expr.Pos(), "cannot compare values of different enums: %s declared at %s and %s declared at %s", enumName(leftType), leftType.Info.(*EnumDecl).Pos().LineColStr(), enumName(rightType), rightType.Info.(*EnumDecl).Pos().LineColStr())
+	}
+	if expr.Operator != "==" && expr.Operator != "!=" {
+		return nil, i.Errorf(expr.Pos(), "cannot order values of %s with '%s', enum values can only be compared with == and !=", enumName(leftType), expr.Operator)
+	}
+	return BoolType, true
+}
+
+func derefParamType(t *Type) *Type {
+	if t.Tag == decl.TypeTagRef {
+		if info, ok := t.Info.(*decl.RefTypeInfo); ok && info.ParamType != nil {
+			return info.ParamType
+		}
+	}
+	return t
+}
+
+// isDurationExpr reports whether e is written in time units: a duration
+// literal, a param defaulting to one, or a sum or negation of those.
+func (i *Inference) isDurationExpr(e Expr, scope *TypeScope) bool {
+	switch e := e.(type) {
+	case *LiteralExpr:
+		return e.DurationUnit != ""
+	case *UnaryExpr:
+		return e.Operator == "-" && i.isDurationExpr(e.Right, scope)
+	case *BinaryExpr:
+		return (e.Operator == "+" || e.Operator == "-") && (i.isDurationExpr(e.Left, scope) || i.isDurationExpr(e.Right, scope))
+	case *IdentifierExpr:
+		if param, ok := scope.env.Get(e.Value); ok {
+			if param, ok := param.(*ParamDecl); ok && param.DefaultValue != nil {
+				return i.isDurationExpr(param.DefaultValue, scope)
+			}
+		}
+	case *MemberAccessExpr:
+		if self, ok := e.Receiver.(*IdentifierExpr); ok && self.Value == "self" && scope.Component() != nil {
+			if param, _ := scope.Component().GetParam(e.Member.Value); param != nil && param.DefaultValue != nil {
+				return i.isDurationExpr(param.DefaultValue, scope)
+			}
+		}
+	}
+	return false
+}
+
+// unitKind names what e is measured in when it is written with a unit:
+// "duration", "rate" or "size".
+func (i *Inference) unitKind(e Expr, scope *TypeScope) string {
+	if i.isDurationExpr(e, scope) {
+		return "duration"
+	}
+	if lit, ok := e.(*LiteralExpr); ok {
+		if lit.RateUnit != "" {
+			return "rate"
+		}
+		if lit.SizeUnit != "" {
+			return "size"
+		}
+	}
+	return ""
+}
+
+// bareNumber returns the text of a non-zero number literal written without a
+// unit.  Zero means the same in every unit so is not reported.
+func bareNumber(e Expr) (string, bool) {
+	lit, ok := e.(*LiteralExpr)
+	if !ok || lit.DurationUnit != "" || lit.RateUnit != "" || lit.SizeUnit != "" {
+		return "", false
+	}
+	switch v := lit.Value.Value.(type) {
+	case int64:
+		return fmt.Sprint(v), v != 0
+	case float64:
+		return fmt.Sprint(v), v != 0
+	}
+	return "", false
+}
+
 func (i *Inference) EvalForUnaryExpr(expr *UnaryExpr, scope *TypeScope) (*Type, bool) {
 	rightType, ok := i.EvalForExprType(expr.Right, scope)
 	if !ok || rightType == nil {
@@ -506,6 +1094,11 @@ func (i *Inference) EvalForUnaryExpr(expr *UnaryExpr, scope *TypeScope) (*Type,
 		}
 		return BoolType, true
 	case "-":
+		// Arithmetic may still produce negative durations at runtime
+		if lit, ok := expr.Right.(*LiteralExpr); ok && lit.DurationUnit != "" {
+			seconds, _ := lit.Value.Value.(float64)
+			return nil, i.Errorf(expr.Pos(), "duration -%.6g%s is negative, durations cannot be negative", seconds/decl.DurationUnits[lit.DurationUnit], lit.DurationUnit)
+		}
 		if rightType.Equals(IntType) || rightType.Equals(FloatType) {
 			return rightType, true
 		}
@@ -515,7 +1108,88 @@ func (i *Inference) EvalForUnaryExpr(expr *UnaryExpr, scope *TypeScope) (*Type,
 	}
 }
 
+// EvalForConversionCall checks a call to one of the conversion builtins
+// against its fixed signature.
+func (i *Inference) EvalForConversionCall(name string, expr *CallExpr, scope *TypeScope) (*Type, bool) {
+	expectedArgs := 1
+	if name == decl.BuiltinDuration {
+		expectedArgs = 2
+	}
+	if expr.IsNamed || expr.NumArgs() != expectedArgs {
+		return nil, i.Errorf(expr.Pos(), "argument count mismatch for call to '%s': expected %d, got %d", name, expectedArgs, expr.NumArgs())
+	}
+
+	valueType, ok := i.EvalForExprType(expr.ArgList[0], scope)
+	if !ok || valueType == nil {
+		return nil, i.Errorf(expr.ArgList[0].Pos(), "could not determine type for argument 1 of call to '%s'", name)
+	}
+	valueType = derefParamType(valueType)
+	isNumeric := valueType.Equals(IntType) || valueType.Equals(FloatType)
+	switch name {
+	case decl.BuiltinString:
+		if !isNumeric && !valueType.Equals(BoolType) && !valueType.Equals(StrType) {
+			return nil, i.Errorf(expr.ArgList[0].Pos(), "cannot convert %s to string with '%s'", valueType.String(), name)
+		}
+		return StrType, true
+	case decl.BuiltinDuration:
+		if !isNumeric {
+			return nil, i.Errorf(expr.ArgList[0].Pos(), "cannot convert %s to a duration with '%s'", valueType.String(), name)
+		}
+		unitType, ok := i.EvalForExprType(expr.ArgList[1], scope)
+		if !ok || unitType == nil || !derefParamType(unitType).Equals(StrType) {
+			return nil, i.Errorf(expr.ArgList[1].Pos(), "unit of '%s' must be a String", name)
+		}
+		if lit, isLit := expr.ArgList[1].(*LiteralExpr); isLit {
+			if unit, _ := lit.Value.GetString(); decl.DurationUnits[unit] == 0 {
+				return nil, i.Errorf(lit.Pos(), "unknown duration unit '%s', expected one of ns, us, ms, s, min or hr", unit)
+			}
+		}
+		return FloatType, true
+	case decl.BuiltinInt:
+		if !isNumeric {
+			return nil, i.Errorf(expr.ArgList[0].Pos(), "cannot convert %s to int with '%s'", valueType.String(), name)
+		}
+		return IntType, true
+	default:
+		if !isNumeric {
+			return nil, i.Errorf(expr.ArgList[0].Pos(), "cannot convert %s to float with '%s'", valueType.String(), name)
+		}
+		return FloatType, true
+	}
+}
+
+// EvalForEnumCall checks a call to one of the enum builtins, whose only
+// argument must name an enum.
+func (i *Inference) EvalForEnumCall(name string, expr *CallExpr, scope *TypeScope) (*Type, bool) {
+	if expr.IsNamed || expr.NumArgs() != 1 {
+		return nil, i.Errorf(expr.Pos(), "argument count mismatch for call to '%s': expected 1, got %d", name, expr.NumArgs())
+	}
+	ident, isIdent := expr.ArgList[0].(*IdentifierExpr)
+	if isIdent {
+		node, _ := scope.env.Get(ident.Value)
+		_, isIdent = node.(*EnumDecl)
+	}
+	if !isIdent {
+		return nil, i.Errorf(expr.ArgList[0].Pos(), "argument of '%s' must name an enum", name)
+	}
+	enumType, ok := i.EvalForExprType(ident, scope)
+	if !ok {
+		return nil, false
+	}
+	if name == decl.BuiltinCount {
+		return IntType, true
+	}
+	return ListType(enumType), true
+}
+
 func (i *Inference) EvalForCallExpr(expr *CallExpr, scope *TypeScope) (*Type, bool) {
+	if ident, isIdent := expr.Function.(*IdentifierExpr); isIdent && decl.IsConversionBuiltin(ident.Value) {
+		return i.EvalForConversionCall(ident.Value, expr, scope)
+	}
+	if ident, isIdent := expr.Function.(*IdentifierExpr); isIdent && decl.IsEnumBuiltin(ident.Value) {
+		return i.EvalForEnumCall(ident.Value, expr, scope)
+	}
+
 	funcType, ok := i.EvalForExprType(expr.Function, scope)
 	if !ok || funcType == nil {
 		return nil, i.Errorf(expr.Function.Pos(), "could not determine type of function/method being called ('%s')", expr.Function.String())
@@ -586,16 +1260,25 @@ func (i *Inference) EvalForCallExpr(expr *CallExpr, scope *TypeScope) (*Type, bo
 			*/
 		}
 	} else {
+		isDelay := methodDecl.IsNative && methodDecl.Name.Value == "delay"
 		for idx, argExpr := range expr.ArgList {
 			argType, ok := i.EvalForExprType(argExpr, scope)
 			if !ok || argType == nil {
 				return nil, i.Errorf(argExpr.Pos(), "could not determine type for argument %d of call to '%s'", idx+1, funcNameForError)
 			}
+			// A delay may be a distribution of durations sampled on each call
+			if isDelay && argType.Tag == decl.TypeTagOutcomes {
+				if elemType := argType.Info.(*Type); !elemType.Equals(FloatType) && !elemType.Equals(IntType) {
+					return nil, i.Errorf(expr.Pos(), "delay expects a duration or a distribution of durations, got %s", argType.String())
+				}
+				continue
+			}
 			if !argType.Equals(expectedParamTypes[idx]) {
-				isIntToFloat := argType.Equals(IntType) && expectedParamTypes[idx].Equals(FloatType)
+				isIntToFloat := i.promotesIntToFloat(argType, expectedParamTypes[idx], argExpr)
 				if !isIntToFloat && argType.Tag == decl.TypeTagRef {
 					rti := argType.Info.(*decl.RefTypeInfo)
 					if rti.ParamType.Equals(IntType) || rti.ParamType.Equals(FloatType) {
+						i.promotesIntToFloat(rti.ParamType, expectedParamTypes[idx], argExpr)
 						isIntToFloat = true
 					}
 				}
@@ -605,6 +1288,12 @@ func (i *Inference) EvalForCallExpr(expr *CallExpr, scope *TypeScope) (*Type, bo
 				}
 			}
 		}
+		// A delay is a latency so cannot be negative
+		if isDelay && expr.NumArgs() == 1 {
+			if v, negative := negativeConstant(expr.ArgList[0]); negative {
+				return nil, i.Errorf(expr.ArgList[0].Pos(), "delay of %s is negative, latencies cannot be negative", v)
+			}
+		}
 	}
 	return returnType, true
 }
@@ -652,6 +1341,8 @@ func (inf *Inference) EvalForDistributeExpr(expr *DistributeExpr, scope *TypeSco
 		if !ok {
 			return nil, false
 		}
+		// Weights may be params, eg self.ErrorRate
+		condType = derefParamType(condType)
 		if !(condType.Equals(FloatType) || condType.Equals(IntType)) {
 			return nil, inf.Errorf(caseExpr.Condition.Pos(), "condition of distribute case %d must be numeric (for weight), got %s", i, condType.String())
 		}
@@ -716,6 +1407,7 @@ func (i *Inference) EvalForSampleExpr(expr *SampleExpr, scope *TypeScope) (*Type
 // If there are multiple return statements (due to multiple paths) they all should be the same
 // Return type is the Future[ReturnType]
 func (i *Inference) EvalForGoExpr(expr *GoExpr, scope *TypeScope) (returnType *Type, ok bool) {
+	ok = true
 	var loopType *Type
 	if expr.LoopExpr != nil {
 		loopType, ok = i.EvalForExprType(expr.LoopExpr, scope)
@@ -739,7 +1431,7 @@ func (i *Inference) EvalForGoExpr(expr *GoExpr, scope *TypeScope) (returnType *T
 // EvalForWaitExpr ensures that the Aggregator input types matches the future types.
 func (i *Inference) EvalForWaitExpr(expr *WaitExpr, scope *TypeScope) (returnedType *Type, ok bool) {
 	ok = true
-	var futureTypes []*Type
+	var futureTypes, resultTypes []*Type
 	for _, ftIdent := range expr.FutureNames {
 		ftType, ok2 := i.EvalForExprType(ftIdent, scope)
 		ok = ok && ok2
@@ -748,9 +1440,14 @@ func (i *Inference) EvalForWaitExpr(expr *WaitExpr, scope *TypeScope) (returnedT
 		} else {
 			resFutureType := decl.ResolvedFutureType(ftType)
 			futureTypes = append(futureTypes, resFutureType)
+			resultTypes = append(resultTypes, ftType.Info.(*decl.FutureTypeInfo).ResultType)
 		}
 	}
 
+	if expr.Policy != decl.WaitDefault {
+		return i.evalForWaitPolicy(expr, resultTypes, scope, ok)
+	}
+
 	if expr.AggregatorName == nil {
 		if len(futureTypes) == 1 {
 			return futureTypes[0], ok
@@ -774,6 +1471,37 @@ func (i *Inference) EvalForWaitExpr(expr *WaitExpr, scope *TypeScope) (returnedT
 	return
 }
 
+// evalForWaitPolicy types a wait with a failure policy.  `requireAll` yields
+// the results of all futures like a plain wait, while `anyOf(n)` yields a list
+// of the results of the futures that succeeded so they must all be of the
+// same type.  Both fail the calling method by returning false from it.
+func (i *Inference) evalForWaitPolicy(expr *WaitExpr, resultTypes []*Type, scope *TypeScope, ok bool) (*Type, bool) {
+	if method := scope.Method(); method != nil && method.ReturnType != nil {
+		if rt := method.ReturnType.ResolvedType(); rt != nil && !rt.Equals(BoolType) {
+			ok = i.Errorf(expr.Pos(), "wait with a failure policy returns false on failure, so method '%s' must return bool, not %s", method.Name.Value, rt.String())
+		}
+	}
+	if !ok || len(resultTypes) == 0 {
+		return nil, false
+	}
+	if expr.Policy == decl.WaitRequireAll {
+		if len(resultTypes) == 1 {
+			return resultTypes[0], ok
+		}
+		return TupleType(resultTypes...), ok
+	}
+	countType, ok2 := i.EvalForExprType(expr.AnyOfCount, scope)
+	if !ok2 || countType == nil || !countType.Equals(IntType) {
+		return nil, i.Errorf(expr.AnyOfCount.Pos(), "anyOf expects an int count of futures")
+	}
+	for _, ft := range resultTypes[1:] {
+		if !ft.Equals(resultTypes[0]) {
+			return nil, i.Errorf(expr.Pos(), "futures waited on with anyOf must have the same type, found %s and %s", resultTypes[0].String(), ft.String())
+		}
+	}
+	return ListType(resultTypes[0]), ok
+}
+
 // EvalForIndexExpr infers the type of an IndexExpr (e.g., list[0], string[1]).
 func (i *Inference) EvalForIndexExpr(expr *IndexExpr, scope *TypeScope) (*Type, bool) {
 	receiverType, ok := i.EvalForExprType(expr.Receiver, scope)
@@ -857,60 +1585,134 @@ func (i *Inference) EvalForSetStmt(s *SetStmt, scope *TypeScope) (returnType *Ty
 	// Evaluate the lhs type
 	lhsType, ok := i.EvalForExprType(s.TargetExpr, scope)
 	if !ok || lhsType == nil {
-		return
+		return nil, i.Errorf(s.TargetExpr.Pos(), "cannot assign to %s: it does not resolve to a component parameter", s.TargetExpr)
 	}
 
 	// LHS MUST be a RefType
 	if lhsType.Tag != decl.TypeTagRef {
-		return nil, i.Errorf(s.Pos(), "Cannot assign to a non ref type lhs.  Found: %s", lhsType.String())
+		return nil, i.Errorf(s.TargetExpr.Pos(), "cannot assign to %s: %s, only component parameters can be set", s.TargetExpr, notAssignableReason(s.TargetExpr, lhsType))
 	}
 
 	// Make sure lhs ref type's param type and valtype match
-	if !lhsType.Info.(*decl.RefTypeInfo).ParamType.Equals(valType) {
-		return nil, i.Errorf(s.Pos(), "LHS Type (%s) != RHS Type (%s)", lhsType.String(), valType.String())
+	ref := lhsType.Info.(*decl.RefTypeInfo)
+	if !ref.ParamType.Equals(valType) {
+		return nil, i.Errorf(s.Pos(), "cannot assign a value of type %s to %s : %s (declared in component '%s')", valType, s.TargetExpr, ref.ParamType, ref.Component.Name.Value)
 	}
 	return
 }
 
+// notAssignableReason explains why target, of type t, cannot be set.
+func notAssignableReason(target Expr, t *Type) string {
+	if _, isLit := target.(*LiteralExpr); isLit {
+		return "it is a literal"
+	}
+	switch t.Tag {
+	case decl.TypeTagMethod:
+		if info := t.Info.(*decl.MethodTypeInfo); info.Method != nil {
+			return fmt.Sprintf("it is method '%s'", info.Method.Name.Value)
+		}
+		return "it is a method"
+	case decl.TypeTagEnum:
+		return fmt.Sprintf("it is a value of enum %s", t)
+	}
+	if _, isIdent := target.(*IdentifierExpr); isIdent {
+		return fmt.Sprintf("it is a local value of type %s", t)
+	}
+	return fmt.Sprintf("it is a computed value of type %s", t)
+}
+
 func (i *Inference) EvalForLetStmt(l *LetStmt, scope *TypeScope) (returnType *Type, ok bool) {
 	valType, ok := i.EvalForExprType(l.Value, scope)
 	if !ok || valType == nil {
 		i.Errorf(l.Pos(), "cannot infer types for (%s) in Let stmt", strings.Join(fn.Map(l.Variables, func(v *IdentifierExpr) string { return v.Value }), ", "))
 		return nil, false
 	}
-	if len(l.Variables) == 1 {
-		varIdent := l.Variables[0]
-		if errSet := scope.Set(varIdent.Value, varIdent, valType); errSet != nil {
-			ok = ok && i.Errorf(varIdent.Pos(), "%v", errSet)
-		}
-	} else if len(l.Variables) > 1 {
-		if valType.Tag == decl.TypeTagTuple && len(valType.Info.([]*Type)) == len(l.Variables) {
-			childTypes := valType.Info.([]*Type)
-			for idx, varIdent := range l.Variables {
-				elemType := childTypes[idx]
-				if errSet := scope.Set(varIdent.Value, varIdent, elemType); errSet != nil {
-					ok = i.Errorf(varIdent.Pos(), "%v", errSet)
-				}
-			}
-		} else {
-			i.Errorf(l.Pos(), "let statement assigns to %d variables, but value type %s is not a matching tuple of %d elements", len(l.Variables), valType.String(), len(l.Variables))
-		}
+	pattern := l.TargetPattern()
+	if !i.bindLetPattern(pattern, valType, scope) {
+		ok = i.Errorf(l.Pos(), "let pattern %s expects a value of shape %s, but value type %s has shape %s", pattern.String(), pattern.Shape(), valType.String(), typeShape(valType))
 	}
 	return
 }
 
+// bindLetPattern recursively matches a let pattern against the structure of
+// valType binding each leaf identifier with its element type.  Returns false
+// if the shapes do not match.
+func (i *Inference) bindLetPattern(pattern *decl.LetPattern, valType *Type, scope *TypeScope) bool {
+	if !pattern.IsTuple() {
+		i.checkLetShadowing(pattern.Ident, scope)
+		if errSet := scope.Set(pattern.Ident.Value, pattern.Ident, valType); errSet != nil {
+			i.Errorf(pattern.Ident.Pos(), "%v", errSet)
+		}
+		scope.lets = append(scope.lets, pattern.Ident)
+		return true
+	}
+	if valType.Tag != decl.TypeTagTuple {
+		return false
+	}
+	childTypes := valType.Info.([]*Type)
+	if len(childTypes) != len(pattern.Children) {
+		return false
+	}
+	for idx, child := range pattern.Children {
+		if !i.bindLetPattern(child, childTypes[idx], scope) {
+			return false
+		}
+	}
+	return true
+}
+
+// checkLetShadowing warns if a let variable hides an earlier let variable or
+// a parameter of the enclosing method.
+func (i *Inference) checkLetShadowing(ident *IdentifierExpr, scope *TypeScope) {
+	node, found := scope.env.Get(ident.Value)
+	if !found {
+		return
+	}
+	switch prev := node.(type) {
+	case *IdentifierExpr:
+		i.Warnf(ident.Pos(), "let variable '%s' shadows an earlier binding at %s", ident.Value, prev.Pos().LineColStr())
+	case *ParamDecl:
+		if method := scope.Method(); method != nil && slices.Contains(method.Parameters, prev) {
+			i.Warnf(ident.Pos(), "let variable '%s' shadows a parameter of method '%s'", ident.Value, method.Name.Value)
+		}
+	}
+}
+
+// typeShape describes the tuple structure of a type with non tuple elements as "_"
+func typeShape(t *Type) string {
+	if t.Tag != decl.TypeTagTuple {
+		return "_"
+	}
+	return "(" + strings.Join(fn.Map(t.Info.([]*Type), typeShape), ", ") + ")"
+}
+
 func (i *Inference) EvalForIfStmt(s *IfStmt, scope *TypeScope) (returnType *Type, ok bool) {
 	condType, ok2 := i.EvalForExprType(s.Condition, scope)
 	ok = ok && ok2
-	if ok2 && condType != nil && !condType.Equals(BoolType) {
+	thenScope := scope
+	if s.Binding != nil {
+		// The binding is only visible in the Then block, where it is never nil
+		thenScope = scope.Push()
+		if ok2 && condType != nil && (condType.Equals(NilType) || condType.Equals(decl.VoidType)) {
+			i.Errorf(s.Condition.Pos(), "if let value %s is always nil", s.Condition)
+		} else if ok2 && condType != nil {
+			i.checkLetShadowing(s.Binding, scope)
+			if errSet := thenScope.Set(s.Binding.Value, s.Binding, derefParamType(condType)); errSet != nil {
+				i.Errorf(s.Binding.Pos(), "%v", errSet)
+			}
+		}
+	} else if ok2 && condType != nil && !condType.Equals(BoolType) {
 		i.Errorf(s.Condition.Pos(), "if condition must be boolean, got %s", condType.String())
 	}
 
 	var thenType, elseType *Type
 	if s.Then != nil {
-		thenType, ok2 = i.EvalForStmt(s.Then, scope)
+		thenType, ok2 = i.EvalForStmt(s.Then, thenScope)
 		ok = ok && ok2
 	}
+	if s.Binding != nil && !i.usedLets[s.Binding] && !strings.HasPrefix(s.Binding.Value, "_") {
+		i.Warnf(s.Binding.Pos(), "if let variable '%s' is never used", s.Binding.Value)
+	}
 	if s.Else != nil {
 		elseType, ok = i.EvalForStmt(s.Else, scope)
 		ok = ok && ok2
@@ -925,40 +1727,63 @@ func (i *Inference) EvalForForStmt(f *ForStmt, scope *TypeScope) (returnType *Ty
 	if !condOk {
 		return nil, false
 	}
-	if !condType.Equals(BoolType) && !condType.Equals(IntType) {
+	bodyScope := scope.Push()
+	if f.Var != nil {
+		condType = derefParamType(condType)
+		if condType.Tag != decl.TypeTagList {
+			return nil, i.Errorf(f.Condition.Pos(), "for loop can only iterate over a List, found: %s", condType.String())
+		}
+		if errSet := bodyScope.Set(f.Var.Value, f.Var, condType.Info.(*Type)); errSet != nil {
+			ok = i.Errorf(f.Var.Pos(), "%v", errSet)
+		}
+	} else if !condType.Equals(BoolType) && !condType.Equals(IntType) {
 		ok = i.Errorf(f.Pos(), "For loop condition can be bool or int, found: %s", condType.String())
 	}
 
 	// Evaluate block
-	bodyScope := scope.Push()
 	bodyType, ok2 := i.EvalForStmt(f.Body, bodyScope)
 	ok = ok && ok2
 	returnType = ListType(bodyType)
 	return
 }
 
-/*
-	if currentMethod := scope.Method(); currentMethod != nil {
-		var expectedReturnType *Type = NilType
-		if currentMethod.ReturnType != nil {
-			// Ensure the method's ReturnType (a TypeDecl) is resolved to a *Type
-			resolvedExpectedType := currentMethod.ReturnType.ResolvedType() // Or .ResolveType(scope)
-			if resolvedExpectedType == nil {
-				i.Errorf(currentMethod.ReturnType.Pos(), "method '%s' has an unresolvable return TypeDecl", currentMethod.Name.Value)
-				return false
-			}
-			expectedReturnType = resolvedExpectedType
+// EvalForReturnStmt checks a returned value against the return type of the
+// enclosing method, allowing an int for a float and a distribution of the
+// return type.  A return without a value
+// is only valid in a method that does not declare a return type.
+func (i *Inference) EvalForReturnStmt(s *ReturnStmt, scope *TypeScope) (returnType *Type, ok bool) {
+	returnType, ok = NilType, true
+	if s.ReturnValue != nil {
+		if returnType, ok = i.EvalForExprType(s.ReturnValue, scope); !ok {
+			return
 		}
-		if !actualReturnType.Equals(expectedReturnType) {
-			isPromotion := actualReturnType.Equals(IntType) && expectedReturnType.Equals(FloatType)
-			if !isPromotion {
-				i.Errorf(s.Pos(), "return type mismatch for method '%s': expected %s, got %s", currentMethod.Name.Value, expectedReturnType.String(), actualReturnType.String())
-			}
+	}
+	method := scope.Method()
+	if method == nil {
+		return
+	}
+	if method.ReturnType == nil {
+		if s.ReturnValue != nil {
+			ok = i.Errorf(s.Pos(), "method '%s' does not declare a return type but returns %s", method.Name.Value, returnType.String())
 		}
-	} else {
-		i.Errorf(s.Pos(), "return statement found outside of a method definition")
+		return
 	}
-*/
+	expected := method.ReturnType.ResolvedType()
+	if expected == nil {
+		return // Reported when the signature was resolved
+	}
+	if s.ReturnValue == nil {
+		return returnType, i.Errorf(s.Pos(), "missing return value in method '%s', expected %s", method.Name.Value, expected.String())
+	}
+	actual := derefParamType(returnType)
+	if actual.Tag == decl.TypeTagOutcomes && expected.Tag != decl.TypeTagOutcomes {
+		actual = actual.Info.(*Type) // Sampled when the method returns
+	}
+	if !actual.Equals(expected) && !i.promotesIntToFloat(actual, expected, s.ReturnValue) {
+		ok = i.Errorf(s.ReturnValue.Pos(), "return type mismatch in method '%s': expected %s, got %s", method.Name.Value, expected.String(), actual.String())
+	}
+	return
+}
 
 func (i *Inference) EvalForBlockStmt(block *BlockStmt, parentScope *TypeScope) (returnType *Type, ok bool) {
 	ok = true
@@ -972,6 +1797,11 @@ func (i *Inference) EvalForBlockStmt(block *BlockStmt, parentScope *TypeScope) (
 			break
 		}
 	}
+	for _, ident := range blockScope.lets {
+		if !i.usedLets[ident] && !strings.HasPrefix(ident.Value, "_") {
+			i.Warnf(ident.Pos(), "let variable '%s' is never used", ident.Value)
+		}
+	}
 	return
 }
 
@@ -996,9 +1826,22 @@ func (i *Inference) EvalForSystemDecl(systemDecl *SystemDecl, nodeScope *TypeSco
 		param.Name.SetInferredType(instanceType)
 	}
 
-	// Process body items — only ExprStmt (generator/metric calls) allowed
+	// System options start from the file's and are overridden by the system's own
+	systemDecl.Options = maps.Clone(i.fileOptions)
+	systemOptions := map[string]Value{}
+
+	// Process body items — generator/metric calls and options
 	for _, item := range systemDecl.Body {
 		switch it := item.(type) {
+		case *OptionsDecl:
+			ok = i.EvalForOptionsDecl(it, systemOptions) && ok
+		case *ScenarioDecl:
+			if slices.ContainsFunc(systemDecl.Scenarios, func(s *ScenarioDecl) bool { return s.Name.Value == it.Name.Value }) {
+				ok = i.Errorf(it.Name.Pos(), "scenario '%s' is declared more than once", it.Name.Value)
+				continue
+			}
+			ok = i.EvalForScenarioDecl(it, systemDecl) && ok
+			systemDecl.Scenarios = append(systemDecl.Scenarios, it)
 		case *ExprStmt:
 			callExpr, isCall := it.Expression.(*CallExpr)
 			if !isCall {
@@ -1040,9 +1883,187 @@ func (i *Inference) EvalForSystemDecl(systemDecl *SystemDecl, nodeScope *TypeSco
 			ok = false
 		}
 	}
+	maps.Copy(systemDecl.Options, systemOptions)
+	return
+}
+
+// EvalForOptionsDecl checks that each option in the block is known, set once
+// and given a literal of the option's type, and records its value in out.
+func (i *Inference) EvalForOptionsDecl(opts *OptionsDecl, out map[string]Value) (ok bool) {
+	ok = true
+	seen := map[string]bool{}
+	for _, opt := range opts.Options {
+		name := opt.Var.Value
+		optType, known := decl.KnownOptions[name]
+		if !known {
+			i.Errorf(opt.Var.Pos(), "unknown option '%s' (expected one of %s)", name, strings.Join(slices.Sorted(maps.Keys(decl.KnownOptions)), ", "))
+			ok = false
+			continue
+		}
+		if seen[name] {
+			i.Errorf(opt.Var.Pos(), "option '%s' is set more than once", name)
+			ok = false
+			continue
+		}
+		seen[name] = true
+		value, isConst := decl.ConstantValue(opt.Value)
+		if !isConst {
+			i.Errorf(opt.Value.Pos(), "option '%s' must be a literal value, found %s", name, opt.Value.String())
+			ok = false
+			continue
+		}
+		if !value.Type.Equals(optType) {
+			i.Errorf(opt.Value.Pos(), "option '%s' must be of type %s, found %s", name, optType.String(), value.Type.String())
+			ok = false
+			continue
+		}
+		out[name] = value
+	}
+	return
+}
+
+// scenarioAggregations are the aggregations a scenario may expect of a
+// method's calls.  count is the number of calls, the rest are of latencies.
+var scenarioAggregations = []string{"count", "avg", "min", "max", "p50", "p90", "p95", "p99", "path", "nopath"}
+
+// EvalForScenarioDecl checks that a scenario's targets are methods of the
+// system's components, that rates, durations and thresholds have units and
+// resolves them into seconds.
+func (i *Inference) EvalForScenarioDecl(scenario *ScenarioDecl, systemDecl *SystemDecl) (ok bool) {
+	ok = true
+	if len(scenario.Generators) == 0 {
+		ok = i.Errorf(scenario.Pos(), "scenario '%s' has no generators", scenario.Name.Value)
+	}
+	for _, gen := range scenario.Generators {
+		if gen.Keyword.Value != "generator" {
+			ok = i.Errorf(gen.Keyword.Pos(), "unknown scenario statement '%s' (expected generator or expect)", gen.Keyword.Value)
+			continue
+		}
+		if gen.At.Value != "at" {
+			ok = i.Errorf(gen.At.Pos(), "expected 'at' after the generator's target, found '%s'", gen.At.Value)
+			continue
+		}
+		var found bool
+		if gen.ComponentPath, gen.MethodName, found = i.scenarioTarget(gen.Target, systemDecl); !found {
+			ok = false
+		}
+
+		// Rates are written as count/unit, eg 50/s
+		count, per := scenarioRate(gen.RateExpr)
+		if per == 0 {
+			ok = i.Errorf(gen.RateExpr.Pos(), "generator rate must be a count per s, min or hr, eg 50/s")
+		} else if count <= 0 {
+			ok = i.Errorf(gen.RateExpr.Pos(), "generator rate must be positive")
+		} else {
+			gen.Rate = count / per
+		}
+
+		if lit, isLit := gen.For.(*LiteralExpr); !isLit || lit.DurationUnit == "" {
+			ok = i.Errorf(gen.For.Pos(), "generator duration must be a duration such as 10s")
+		} else if gen.Duration, _ = extractNumericValue(lit); gen.Duration <= 0 {
+			ok = i.Errorf(gen.For.Pos(), "generator duration must be positive")
+		}
+		scenario.Duration = max(scenario.Duration, gen.Duration)
+	}
+
+	for _, expect := range scenario.Expects {
+		agg := expect.Aggregation.Value
+		if !slices.Contains(scenarioAggregations, agg) {
+			ok = i.Errorf(expect.Aggregation.Pos(), "unknown aggregation '%s' (expected one of %s)", agg, strings.Join(scenarioAggregations, ", "))
+			continue
+		}
+		if agg == "path" || agg == "nopath" {
+			spec, err := "", fmt.Errorf("not a literal")
+			if lit, isLit := expect.Condition.(*LiteralExpr); isLit {
+				spec, err = lit.Value.GetString()
+			}
+			if err != nil || strings.TrimSpace(spec) == "" {
+				ok = i.Errorf(expect.Condition.Pos(), "%s expectations take a path spec string, eg \"Handle > db.Query\"", agg)
+			}
+			expect.Path = spec
+			continue
+		}
+		cond, isCond := expect.Condition.(*decl.BinaryExpr)
+		if !isCond || !slices.Contains([]string{"<", "<=", ">", ">="}, cond.Operator) {
+			ok = i.Errorf(expect.Condition.Pos(), "expectation must compare a method with a threshold, eg server.Handle < 100ms")
+			continue
+		}
+		expect.Operator = cond.Operator
+		var found bool
+		if expect.ComponentPath, expect.MethodName, found = i.scenarioTarget(cond.Left, systemDecl); !found {
+			ok = false
+		}
+
+		lit, isLit := cond.Right.(*LiteralExpr)
+		threshold, err := 0.0, fmt.Errorf("not a literal")
+		if isLit {
+			threshold, err = extractNumericValue(lit)
+		}
+		switch {
+		case err != nil:
+			ok = i.Errorf(cond.Right.Pos(), "expectation threshold must be a number or duration literal")
+		case agg == "count" && lit.DurationUnit != "":
+			ok = i.Errorf(cond.Right.Pos(), "count expectations take a number of calls, not a duration")
+		case agg != "count" && lit.DurationUnit == "" && threshold != 0:
+			number, _ := bareNumber(lit)
+			ok = i.Errorf(cond.Right.Pos(), "%s is a latency, add a unit to the threshold %s (eg %sms)", agg, number, number)
+		default:
+			expect.Threshold = threshold
+		}
+	}
 	return
 }
 
+// scenarioRate splits a rate such as 50/s into the count and the seconds it
+// is per, which is 0 if the rate is not of that form.  Rate literals are
+// already per second.
+func scenarioRate(e Expr) (count, per float64) {
+	if lit, isLit := e.(*LiteralExpr); isLit && lit.RateUnit != "" {
+		count, _ = extractNumericValue(lit)
+		return count, 1
+	}
+	rate, isRate := e.(*decl.BinaryExpr)
+	if !isRate || rate.Operator != "/" {
+		return 0, 0
+	}
+	unit, isUnit := rate.Right.(*IdentifierExpr)
+	count, err := extractNumericValue(rate.Left)
+	if !isUnit || err != nil {
+		return 0, 0
+	}
+	return count, decl.RateUnits[unit.Value]
+}
+
+// scenarioTarget resolves a scenario target such as server.cache.Get by
+// following the system's parameter and the uses of its components.
+func (i *Inference) scenarioTarget(target Expr, systemDecl *SystemDecl) (path, method string, ok bool) {
+	path, method = SplitMemberAccessTarget(target)
+	if path == "" {
+		return "", "", i.Errorf(target.Pos(), "scenario target must be a method such as server.Handle")
+	}
+	names := strings.Split(path, ".")
+	var comp *ComponentDecl
+	for _, param := range systemDecl.Parameters {
+		if param.Name.Value == names[0] {
+			comp = componentOf(param.Name.InferredType())
+		}
+	}
+	if comp == nil {
+		return "", "", i.Errorf(target.Pos(), "'%s' is not a component of system '%s'", names[0], systemDecl.Name.Value)
+	}
+	for _, name := range names[1:] {
+		dep, _ := comp.GetDependency(name)
+		if dep == nil || dep.ResolvedComponent == nil {
+			return "", "", i.Errorf(target.Pos(), "component '%s' does not use '%s'", comp.Name.Value, name)
+		}
+		comp = dep.ResolvedComponent
+	}
+	if m, _ := comp.GetMethod(method); m == nil {
+		return "", "", i.Errorf(target.Pos(), "component '%s' has no method '%s'", comp.Name.Value, method)
+	}
+	return path, method, true
+}
+
 // resolveGeneratorCall validates and extracts a GeneratorSpec from a generator(...) CallExpr.
 //
 // Supported forms:
@@ -1051,6 +2072,7 @@ func (i *Inference) EvalForSystemDecl(systemDecl *SystemDecl, nodeScope *TypeSco
 //	generator("name", target.path.Method, rate(count, interval))
 //	generator("name", target.path.Method, rate(count), duration)
 //	generator("name", target.path.Method, rate(count, interval), duration)
+//	generator("name", target.path.Method, 50/s [, duration])
 func resolveGeneratorCall(call *CallExpr) (*GeneratorSpec, error) {
 	args := call.ArgList
 	if len(args) < 3 || len(args) > 4 {
@@ -1078,42 +2100,55 @@ func resolveGeneratorCall(call *CallExpr) (*GeneratorSpec, error) {
 		return nil, fmt.Errorf("second argument (target) must be a dotted path like arch.component.Method, got %s", args[1])
 	}
 
-	// Arg 3: rate(...) call
-	rateCall, ok := args[2].(*CallExpr)
+	// Arg 3: rate(...) call or a rate literal such as 50/s
+	if err := resolveGeneratorRate(spec, args[2]); err != nil {
+		return nil, err
+	}
+
+	// Arg 4: optional duration
+	if len(args) == 4 {
+		spec.Duration, err = extractNumericValue(args[3])
+		if err != nil {
+			return nil, fmt.Errorf("duration: %w", err)
+		}
+	}
+
+	return spec, nil
+}
+
+// resolveGeneratorRate sets the rate of a generator from a rate literal,
+// which is per second, or a rate(count [, interval]) call.
+func resolveGeneratorRate(spec *GeneratorSpec, arg Expr) (err error) {
+	if lit, isLit := arg.(*LiteralExpr); isLit && lit.RateUnit != "" {
+		spec.Rate, err = extractNumericValue(lit)
+		return
+	}
+	rateCall, ok := arg.(*CallExpr)
 	if !ok {
-		return nil, fmt.Errorf("third argument must be rate(count) or rate(count, interval), got %T", args[2])
+		return fmt.Errorf("third argument must be a rate such as 50/s, rate(count) or rate(count, interval), got %T", arg)
 	}
 	rateFuncIdent, ok := rateCall.Function.(*IdentifierExpr)
 	if !ok || rateFuncIdent.Value != "rate" {
-		return nil, fmt.Errorf("third argument must be rate(...), got %s", rateCall.Function)
+		return fmt.Errorf("third argument must be rate(...), got %s", rateCall.Function)
 	}
 	if len(rateCall.ArgList) < 1 || len(rateCall.ArgList) > 2 {
-		return nil, fmt.Errorf("rate() expects 1-2 arguments (count [, interval]), got %d", len(rateCall.ArgList))
+		return fmt.Errorf("rate() expects 1-2 arguments (count [, interval]), got %d", len(rateCall.ArgList))
 	}
 
 	// rate count (first arg)
 	spec.Rate, err = extractNumericValue(rateCall.ArgList[0])
 	if err != nil {
-		return nil, fmt.Errorf("rate count: %w", err)
+		return fmt.Errorf("rate count: %w", err)
 	}
 
 	// rate interval (optional second arg — duration literal)
 	if len(rateCall.ArgList) == 2 {
 		spec.RateInterval, err = extractNumericValue(rateCall.ArgList[1])
 		if err != nil {
-			return nil, fmt.Errorf("rate interval: %w", err)
-		}
-	}
-
-	// Arg 4: optional duration
-	if len(args) == 4 {
-		spec.Duration, err = extractNumericValue(args[3])
-		if err != nil {
-			return nil, fmt.Errorf("duration: %w", err)
+			return fmt.Errorf("rate interval: %w", err)
 		}
 	}
-
-	return spec, nil
+	return nil
 }
 
 // extractNumericValue gets a float64 from a LiteralExpr (int or float or duration).
@@ -1165,7 +2200,7 @@ func resolveMetricCall(call *CallExpr) (*MetricSpec, error) {
 		return nil, fmt.Errorf("second argument (target) must be a dotted path, got %s", args[1])
 	}
 
-	// Arg 3: metric type (string literal — "count", "latency", "utilization")
+	// Arg 3: metric type (string literal — "count", "latency", "utilization", "value")
 	typeLit, ok := args[2].(*LiteralExpr)
 	if !ok {
 		return nil, fmt.Errorf("third argument (type) must be a string literal, got %T", args[2])
@@ -1174,9 +2209,9 @@ func resolveMetricCall(call *CallExpr) (*MetricSpec, error) {
 	if err != nil {
 		return nil, fmt.Errorf("third argument (type) must be a string, got %s", typeLit.Value.Type.String())
 	}
-	validTypes := map[string]bool{"count": true, "latency": true, "utilization": true}
+	validTypes := map[string]bool{"count": true, "latency": true, "utilization": true, "value": true}
 	if !validTypes[typeStr] {
-		return nil, fmt.Errorf("invalid metric type %q (expected count, latency, or utilization)", typeStr)
+		return nil, fmt.Errorf("invalid metric type %q (expected count, latency, utilization or value)", typeStr)
 	}
 	spec.MetricType = typeStr
 
@@ -1186,7 +2221,7 @@ func resolveMetricCall(call *CallExpr) (*MetricSpec, error) {
 		spec.Aggregation = "avg"
 	case "count":
 		spec.Aggregation = "sum"
-	case "utilization":
+	case "utilization", "value":
 		spec.Aggregation = "avg"
 	}
 
@@ -0,0 +1,1022 @@
+package loader
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/panyam/sdl/lib/decl"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// validateSource writes the SDL source to a temp file, loads and validates it
+// and returns the resulting file status along with any validation errors.
+// Validation stops (panics) on the first inference error so that is recovered here.
+func validateSource(t *testing.T, source string) (fs *FileStatus, errs []error) {
+	t.Helper()
+	return validateSourceWith(t, source, DefaultInferenceOptions())
+}
+
+func validateSourceWith(t *testing.T, source string, options InferenceOptions) (fs *FileStatus, errs []error) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "test.sdl")
+	require.NoError(t, os.WriteFile(path, []byte(source), 0644))
+
+	l := NewLoader(nil, nil, 10)
+	l.InferenceOptions = options
+	fs, err := l.LoadFile(path, "", 0)
+	require.NoError(t, err)
+	defer func() {
+		if r := recover(); r != nil {
+			err, ok := r.(error)
+			require.True(t, ok, "unexpected panic: %v", r)
+			errs = append(fs.Errors, err)
+		}
+	}()
+	l.Validate(fs)
+	return fs, fs.Errors
+}
+
+// TestInferNestedLetDestructuring verifies that a nested let pattern binds
+// each leaf with the type of the matching element in the value's tuple.
+func TestInferNestedLetDestructuring(t *testing.T) {
+	fs, errs := validateSource(t, `
+component Nested {
+  method Run() Bool {
+    let (a, (b, c)) = (1, (true, "x"))
+    let ok = b
+    return ok
+  }
+}
+`)
+	require.Empty(t, errs)
+
+	comp, err := fs.FileDecl.GetComponent("Nested")
+	require.NoError(t, err)
+	method, err := comp.GetMethod("Run")
+	require.NoError(t, err)
+
+	let := method.Body.Statements[0].(*LetStmt)
+	require.Len(t, let.Variables, 3)
+	assert.Equal(t, "a", let.Variables[0].Value)
+	assert.Equal(t, IntType, let.Variables[0].InferredType())
+	assert.Equal(t, BoolType, let.Variables[1].InferredType())
+	assert.Equal(t, StrType, let.Variables[2].InferredType())
+}
+
+// TestInferNestedLetShapeMismatch verifies that destructuring a value whose
+// tuple structure does not match the pattern reports both shapes.
+func TestInferNestedLetShapeMismatch(t *testing.T) {
+	_, errs := validateSource(t, `
+component Nested {
+  method Run() Bool {
+    let (a, (b, c)) = (1, true)
+    return true
+  }
+}
+`)
+	require.Len(t, errs, 1)
+	assert.Contains(t, errs[0].Error(), "expects a value of shape (_, (_, _))")
+	assert.Contains(t, errs[0].Error(), "has shape (_, _)")
+}
+
+// TestInferParamConstraintDefault verifies that a param's default value must
+// satisfy its own constraint.
+func TestInferParamConstraintDefault(t *testing.T) {
+	_, errs := validateSource(t, `
+component Cache {
+  param HitRate Float = 0.8 in [0, 1]
+  param Size Int = -5 in [-10, 10]
+  param Policy String = "lru" in {"lru", "lfu"}
+}
+`)
+	require.Empty(t, errs)
+
+	_, errs = validateSource(t, `
+component Cache {
+  param Size Int = 0 in [1, 1000]
+}
+`)
+	require.Len(t, errs, 1)
+	assert.Contains(t, errs[0].Error(), "default value of parameter 'Size'")
+	assert.Contains(t, errs[0].Error(), "[1, 1000]")
+
+	_, errs = validateSource(t, `
+component Cache {
+  param Policy String = "random" in {"lru", "lfu"}
+}
+`)
+	require.Len(t, errs, 1)
+	assert.Contains(t, errs[0].Error(), "must be one of")
+
+	_, errs = validateSource(t, `
+component Cache {
+  param Policy String = "lru" in [0, 1]
+}
+`)
+	require.Len(t, errs, 1)
+	assert.Contains(t, errs[0].Error(), "requires a numeric type")
+}
+
+// TestInferParamConstraintEnum verifies that an enum param can be limited to
+// some of its variants and that defaults and overrides are checked.
+func TestInferParamConstraintEnum(t *testing.T) {
+	_, errs := validateSource(t, `
+enum Tier { Hot, Warm, Cold }
+component Store {
+  param Placement Tier = Tier.Hot in {Tier.Hot, Tier.Warm}
+}
+component App {
+  uses store Store(Placement = Tier.Warm)
+}
+`)
+	require.Empty(t, errs)
+
+	_, errs = validateSource(t, `
+enum Tier { Hot, Warm, Cold }
+component Store {
+  param Placement Tier = Tier.Cold in {Tier.Hot, Tier.Warm}
+}
+`)
+	require.Len(t, errs, 1)
+	assert.Contains(t, errs[0].Error(), "value Tier.Cold is not allowed, must be one of {Tier.Hot, Tier.Warm}")
+
+	_, errs = validateSource(t, `
+enum Tier { Hot, Warm, Cold }
+component Store {
+  param Placement Tier = Tier.Hot in {Tier.Hot, Tier.Warm}
+}
+component App {
+  uses store Store(Placement = Tier.Cold)
+}
+`)
+	require.Len(t, errs, 1)
+	assert.Contains(t, errs[0].Error(), "override of parameter 'Placement'")
+
+	_, errs = validateSource(t, `
+enum Tier { Hot, Warm, Cold }
+component Store {
+  param Placement Tier = Tier.Hot in {"hot"}
+}
+`)
+	require.Len(t, errs, 1)
+	assert.Contains(t, errs[0].Error(), "must list values of")
+}
+
+// TestInferParamConstraintOverride verifies that a literal override of a
+// param on a uses declaration must satisfy the param's constraint.
+func TestInferParamConstraintOverride(t *testing.T) {
+	_, errs := validateSource(t, `
+component Cache {
+  param HitRate Float = 0.8 in [0, 1]
+}
+component App {
+  uses cache Cache(HitRate = 0.5)
+}
+`)
+	require.Empty(t, errs)
+
+	_, errs = validateSource(t, `
+component Cache {
+  param HitRate Float = 0.8 in [0, 1]
+}
+component App {
+  uses cache Cache(HitRate = 1.5)
+}
+`)
+	require.Len(t, errs, 1)
+	assert.Contains(t, errs[0].Error(), "override of parameter 'HitRate' in 'uses cache Cache' in component 'App' violates its constraint")
+	assert.Contains(t, errs[0].Error(), "[0, 1]")
+}
+
+// TestInferSpawn verifies that spawn accepts a method call and rejects
+// anything else.
+func TestInferSpawn(t *testing.T) {
+	_, errs := validateSource(t, `
+component DB {
+  method Write() Bool { return true }
+}
+component App {
+  uses db DB()
+  method Handle() Bool {
+    spawn self.db.Write()
+    return true
+  }
+}
+`)
+	require.Empty(t, errs)
+
+	_, errs = validateSource(t, `
+component App {
+  param Limit Int = 10
+  method Handle() Bool {
+    spawn self.Limit
+    return true
+  }
+}
+`)
+	require.Len(t, errs, 1)
+	assert.Contains(t, errs[0].Error(), "spawn expects a method call, got 'self.Limit'")
+}
+
+// TestInferOptionsOverride verifies that options in a system override the
+// file's options and that unknown or mistyped options are rejected.
+func TestInferOptionsOverride(t *testing.T) {
+	fs, errs := validateSource(t, `
+options { max_depth = 64, seed = 7 }
+
+component Server {
+  method Handle() Bool { return true }
+}
+
+system App(server Server) {
+  options { max_depth = 8, strict_params = true }
+}
+
+system Other(server Server) {
+}
+`)
+	require.Empty(t, errs)
+	app, _ := fs.FileDecl.GetSystem("App")
+	assert.Equal(t, int64(8), app.Options["max_depth"].Value)
+	assert.Equal(t, int64(7), app.Options["seed"].Value)
+	assert.Equal(t, true, app.Options["strict_params"].Value)
+	other, _ := fs.FileDecl.GetSystem("Other")
+	assert.Equal(t, int64(64), other.Options["max_depth"].Value)
+	assert.NotContains(t, other.Options, "strict_params")
+
+	_, errs = validateSource(t, `options { depth = 3 }`)
+	require.Len(t, errs, 1)
+	assert.Contains(t, errs[0].Error(), "unknown option 'depth'")
+
+	_, errs = validateSource(t, `
+component Server {
+  method Handle() Bool { return true }
+}
+
+system App(server Server) {
+  options { max_depth = "deep" }
+}
+`)
+	require.Len(t, errs, 1)
+	assert.Contains(t, errs[0].Error(), "option 'max_depth' must be of type int, found string")
+}
+
+// TestInferDependencyOverrideCandidates verifies that wiring a dependency to
+// an instance of the wrong component errors and names the instances that fit.
+func TestInferDependencyOverrideCandidates(t *testing.T) {
+	const components = `
+component Database {
+  method Query() Bool { return true }
+}
+component Cache {
+  method Get() Bool { return true }
+}
+component Server {
+  uses db Database
+  method Handle() Bool { return self.db.Query() }
+}
+`
+	_, errs := validateSource(t, components+`
+component Stack {
+  uses primary Database()
+  uses cache Cache()
+  uses replica Database()
+  uses server Server(db = replica)
+}
+`)
+	require.Empty(t, errs)
+
+	_, errs = validateSource(t, components+`
+component Stack {
+  uses primary Database()
+  uses cache Cache()
+  uses replica Database()
+  uses server Server(db = cache)
+}
+`)
+	require.Len(t, errs, 1)
+	assert.Contains(t, errs[0].Error(), "dependency 'db' of 'server' in component 'Stack' must be a Database but 'cache' is a Cache")
+	assert.Contains(t, errs[0].Error(), "compatible instances: primary, replica")
+
+	_, errs = validateSource(t, components+`
+component Stack {
+  uses cache Cache()
+  uses server Server(db = self.cache)
+}
+`)
+	require.Len(t, errs, 1)
+	assert.Contains(t, errs[0].Error(), "no Database instances in component 'Stack'")
+}
+
+// TestInferPositionalUsesArgs verifies that positional args of a uses
+// declaration are matched to params in declaration order before the named
+// ones, and that misplaced, unknown or repeated args are errors.
+func TestInferPositionalUsesArgs(t *testing.T) {
+	const components = `
+component Database {
+  param PoolSize Int = 10
+  param Timeout Float = 1s
+  param Replicas Int = 1
+  method Query() Bool { return true }
+}
+`
+	file, errs := validateSource(t, components+`
+component Stack {
+  uses db Database(20, Replicas = 3)
+}
+`)
+	require.Empty(t, errs)
+	stack, _ := file.FileDecl.GetComponent("Stack")
+	db, _ := stack.GetDependency("db")
+	require.Len(t, db.Overrides, 2)
+	assert.Equal(t, "PoolSize", db.Overrides[0].Var.Value)
+	assert.Equal(t, "Replicas", db.Overrides[1].Var.Value)
+
+	for args, expected := range map[string]string{
+		"PoolSize = 20, 5s": "positional argument after named arguments in 'uses db Database'",
+		"20, 5s, 2, 4":      "too many arguments in 'uses db Database': component 'Database' declares 3 params",
+		"20, PoolSize = 30": "'PoolSize' of 'db' is assigned more than once",
+		"20, Size = 30":     "'Size' is not a param or dependency of component 'Database'",
+	} {
+		_, errs := validateSource(t, components+"component Stack {\n  uses db Database("+args+")\n}\n")
+		require.NotEmpty(t, errs, args)
+		assert.Contains(t, errs[0].Error(), expected, args)
+	}
+}
+
+// TestInferReturnTypes verifies that returned values are checked against
+// the method's return type, with int to float promotion, and that a bare
+// return is only allowed in methods without a return type.
+func TestInferReturnTypes(t *testing.T) {
+	_, errs := validateSource(t, `
+component C {
+  method Delay() Float { return 1 }
+  method Wait() Float { return 5ms }
+  method Log() { return; }
+  method Pair(n Int) (Int, Bool) { return (n, true) }
+}
+`)
+	require.Empty(t, errs)
+
+	for body, expected := range map[string]string{
+		`method Wait() Float { return "oops" }`: "return type mismatch in method 'Wait': expected float, got string",
+		`method Ok() Bool { return; }`:          "missing return value in method 'Ok', expected bool",
+		`method Log() { return 1 }`:             "method 'Log' does not declare a return type but returns int",
+	} {
+		_, errs := validateSource(t, "component C {\n  "+body+"\n}\n")
+		require.NotEmpty(t, errs, body)
+		assert.Contains(t, errs[0].Error(), expected, body)
+	}
+}
+
+// TestInferWaitPolicies verifies the result types of waits with a failure
+// policy and that the calling method must be able to fail with false.
+func TestInferWaitPolicies(t *testing.T) {
+	fs, errs := validateSource(t, `
+component C {
+  method Ok() Bool { return true }
+  method Count() Int { return 1 }
+  method All() Bool {
+    let a = go self.Ok()
+    let b = go self.Count()
+    let both = wait a, b requireAll
+    let some = wait a anyOf(1)
+    return true
+  }
+}
+`)
+	require.Empty(t, errs)
+	comp, err := fs.FileDecl.GetComponent("C")
+	require.NoError(t, err)
+	method, err := comp.GetMethod("All")
+	require.NoError(t, err)
+	assert.Equal(t, "Tuple([bool int])", method.Body.Statements[2].(*LetStmt).Value.InferredType().String())
+	assert.Equal(t, "List[bool]", method.Body.Statements[3].(*LetStmt).Value.InferredType().String())
+
+	for body, expected := range map[string]string{
+		"method Run() Int {\n let a = go self.Ok()\n let _r = wait a requireAll\n return 1 }":                                "so method 'Run' must return bool, not int",
+		"method Run() Bool {\n let a = go self.Ok()\n let b = go self.Count()\n let _r = wait a, b anyOf(1)\n return true }": "futures waited on with anyOf must have the same type, found bool and int",
+		"method Run() Bool {\n let a = go self.Ok()\n let _r = wait a anyOf(true)\n return true }":                           "anyOf expects an int count of futures",
+	} {
+		_, errs := validateSource(t, "component C {\n  method Ok() Bool { return true }\n  method Count() Int { return 1 }\n  "+body+"\n}\n")
+		require.NotEmpty(t, errs, body)
+		assert.Contains(t, errs[0].Error(), expected, body)
+	}
+}
+
+// TestInferRatesAndSizes verifies that rate and size literals are floats in
+// per second and bytes, that generators take rates directly and that they
+// cannot be compared with other kinds of units.
+func TestInferRatesAndSizes(t *testing.T) {
+	fs, errs := validateSource(t, `
+component Server {
+  param Capacity = 10MiB
+  param MaxRate = 3000/min
+  method Handle() Bool { return self.Capacity > 1MB }
+}
+component Arch { uses server Server() }
+system App(arch Arch) {
+  generator("perSecond", arch.server.Handle, 50/s)
+  generator("perMinute", arch.server.Handle, 3000/min)
+}
+`)
+	require.Empty(t, errs)
+	system, err := fs.FileDecl.GetSystem("App")
+	require.NoError(t, err)
+	require.Len(t, system.Generators, 2)
+	assert.Equal(t, 50.0, system.Generators[0].Rate)
+	assert.Equal(t, 50.0, system.Generators[1].Rate)
+	assert.Equal(t, 1.0, system.Generators[1].RateInterval)
+
+	comp, err := fs.FileDecl.GetComponent("Server")
+	require.NoError(t, err)
+	capacity, _ := comp.GetParam("Capacity")
+	assert.Equal(t, "float", capacity.Name.InferredType().String())
+
+	for body, expected := range map[string]string{
+		"return 10ms < 1MB":  "cannot compare a duration with a size",
+		"return 50/s > 1KiB": "cannot compare a rate with a size",
+	} {
+		_, errs := validateSource(t, "component C {\n  method Run() Bool { "+body+" }\n}\n")
+		require.NotEmpty(t, errs, body)
+		assert.Contains(t, errs[0].Error(), expected, body)
+	}
+}
+
+// TestInferLetWarnings verifies that a let hiding an earlier binding or a
+// method parameter and a let that is never read are warned about, and are
+// errors in strict mode.
+func TestInferLetWarnings(t *testing.T) {
+	for source, expected := range map[string]string{
+		"let x = 1\n    if true { let x = 2 return x > 0 }\n    return x > 0": "let variable 'x' shadows an earlier binding at Line 3, Col 9",
+		"let n = 2\n    return n > 0":                                         "let variable 'n' shadows a parameter of method 'Run'",
+		"let unused = 1\n    return true":                                     "let variable 'unused' is never used",
+	} {
+		src := "component C {\n  method Run(n Int) Bool {\n    " + source + "\n  }\n}\n"
+		fs, errs := validateSource(t, src)
+		require.Empty(t, errs, source)
+		require.Len(t, fs.Warnings, 1, source)
+		assert.Contains(t, fs.Warnings[0].Error(), expected, source)
+
+		_, errs = validateSourceWith(t, src, InferenceOptions{AllowImplicitIntFloatPromotion: true, Strict: true})
+		require.NotEmpty(t, errs, source)
+		assert.Contains(t, errs[0].Error(), expected, source)
+	}
+
+	fs, errs := validateSource(t, `
+component C {
+  method Run(n Int) Bool {
+    let (a, _b) = (n, 2)
+    return a > 0
+  }
+}
+`)
+	require.Empty(t, errs)
+	assert.Empty(t, fs.Warnings)
+}
+
+func TestInferMemoizeAnnotation(t *testing.T) {
+	const source = `
+component Cache {
+  %s
+  method Get() Bool { return true }
+}
+`
+	_, errs := validateSource(t, fmt.Sprintf(source, "@memoize(ttl = 5s, hit_rate = 0.5)\n  @coalesce(window = 20ms)"))
+	require.Empty(t, errs)
+
+	for annotation, expected := range map[string]string{
+		"@memoize(ttl = 5)":                  "argument 'ttl' of '@memoize' must be a duration, found int",
+		"@memoize(hit_rate = 0.5)":           "'@memoize' on method 'Cache.Get' requires a ttl",
+		"@memoize(ttl = 5s, hit_rate = 1.5)": "hit_rate of '@memoize' must be between 0 and 1, found 1.5",
+		"@memoize(ttl = 5s, size = 10)":      "unknown argument 'size' for '@memoize' (expected one of hit_rate, ttl)",
+		"@cached(ttl = 5s)":                  "unknown annotation '@cached' on method 'Get' (expected one of coalesce, memoize)",
+		"@coalesce":                          "'@coalesce' on method 'Cache.Get' requires a window",
+		"@coalesce(window = 20)":             "argument 'window' of '@coalesce' must be a duration, found int",
+		"@coalesce(window = 0s)":             "window of '@coalesce' must be positive",
+	} {
+		_, errs := validateSource(t, fmt.Sprintf(source, annotation))
+		require.Len(t, errs, 1, annotation)
+		assert.Contains(t, errs[0].Error(), expected)
+	}
+}
+
+// TestInferParamDefaultReferences verifies that a default may refer to params
+// declared before it but not to later ones or, through other defaults, to itself.
+func TestInferParamDefaultReferences(t *testing.T) {
+	_, errs := validateSource(t, `
+component Pool {
+  param PoolSize Int = 10
+  param MaxConns Int = PoolSize * 2
+  param Ratio = MaxConns / 4.0
+  method Get() Bool { return true }
+}
+`)
+	require.Empty(t, errs)
+
+	for params, expected := range map[string]string{
+		"param A Int = B\n  param B Int = C\n  param C Int = A": "circular reference in default values of parameters in component 'Pool': A -> B -> C -> A",
+		"param A Int = A + 1":                    "circular reference in default values of parameters in component 'Pool': A -> A",
+		"param A Int = B * 2\n  param B Int = 3": "default value of parameter 'A' in component 'Pool' refers to parameter 'B' which is declared after it",
+	} {
+		_, errs := validateSource(t, "component Pool {\n  "+params+"\n}\n")
+		require.Len(t, errs, 1, params)
+		assert.Contains(t, errs[0].Error(), expected)
+	}
+}
+
+func TestInferProfiles(t *testing.T) {
+	_, errs := validateSource(t, `
+component DB {
+  method Query(key String) Bool { return true }
+  profile Fast {
+    method Query(key String) Bool { return false }
+  }
+}
+`)
+	require.Empty(t, errs)
+
+	for profile, expected := range map[string]string{
+		"method Missing() Bool { return true }":     "profile 'Fast' of component 'DB' overrides method 'Missing' which the component does not declare",
+		"method Query() Bool { return true }":       "method 'Query' in profile 'Fast' of component 'DB' must have the same signature as the method it overrides",
+		"method Query(key String) Int { return 1 }": "method 'Query' in profile 'Fast' of component 'DB' must have the same signature as the method it overrides",
+	} {
+		_, errs := validateSource(t, "component DB {\n  method Query(key String) Bool { return true }\n  profile Fast {\n    "+profile+"\n  }\n}\n")
+		require.Len(t, errs, 1, profile)
+		assert.Contains(t, errs[0].Error(), expected)
+	}
+}
+
+func TestInferNativeMethodOutcomes(t *testing.T) {
+	_, errs := validateSource(t, `
+native method Query(slow Bool) Float = dist { 90 => 5ms, 10 => 50ms }
+native component Disk {
+  method Read() Bool = dist { 99 => true, 1 => false }
+}
+`)
+	require.Empty(t, errs)
+
+	for method, expected := range map[string]string{
+		"Query() Bool = dist { 1 => 5ms }":   "outcomes of native method 'global.Query' must be bool, got float",
+		"Query() = dist { 1 => true }":       "outcomes of native method 'global.Query' must be nil, got bool",
+		`Query() Int = dist { "a" => 1 }`:    "condition of distribute case 0 must be numeric",
+		"Query(n Int) Int = dist { 1 => m }": "identifier 'm' not found",
+	} {
+		_, errs := validateSource(t, "native method "+method+"\n")
+		require.NotEmpty(t, errs, method)
+		assert.Contains(t, errs[0].Error(), expected, method)
+	}
+}
+
+// TestInferConversionBuiltins verifies the conversion builtins are typed by
+// their fixed signatures without being imported.
+func TestInferConversionBuiltins(t *testing.T) {
+	_, errs := validateSource(t, `
+component C {
+  param Retries Int = int(2.9)
+  param Timeout Float = float(self.Retries) * duration(5, "ms")
+  method Label() String {
+    return "retries: " + string(self.Retries) + ", slow: " + string(self.Timeout > 1s)
+  }
+}
+`)
+	require.Empty(t, errs)
+
+	for expr, expected := range map[string]string{
+		`int("abc")`:         "cannot convert string to int with 'int'",
+		`float(true)`:        "cannot convert bool to float with 'float'",
+		`duration(5, "day")`: "unknown duration unit 'day'",
+		`duration(5, 1)`:     "unit of 'duration' must be a String",
+		`duration(5)`:        "argument count mismatch for call to 'duration': expected 2, got 1",
+		`string(1, 2)`:       "argument count mismatch for call to 'string': expected 1, got 2",
+	} {
+		_, errs := validateSource(t, "component C {\n  method Run() {\n    let x = "+expr+"\n  }\n}\n")
+		require.NotEmpty(t, errs, expr)
+		assert.Contains(t, errs[0].Error(), expected, expr)
+	}
+}
+
+// TestInferEnumBuiltins verifies the types of values() and count() and of
+// the variable of a loop over the values.
+func TestInferEnumBuiltins(t *testing.T) {
+	_, errs := validateSource(t, `
+enum Status { Ok, Slow, Down }
+component C {
+  param Routes Int = count(Status)
+  method Worst() Status {
+    let worst = Status.Ok
+    for s in values(Status) {
+      if s == Status.Down {
+        return s
+      }
+    }
+    return worst
+  }
+}
+`)
+	require.Empty(t, errs)
+
+	for stmt, expected := range map[string]string{
+		`let x = values(3)`:                         "argument of 'values' must name an enum",
+		"let s = Status.Ok\n    let x = count(s)":   "argument of 'count' must name an enum",
+		`let x = count(Status, Status)`:             "argument count mismatch for call to 'count': expected 1, got 2",
+		`for s in 3 { }`:                            "for loop can only iterate over a List, found: int",
+		`for s in values(Status) { let x = s + 1 }`: "type mismatch",
+	} {
+		_, errs := validateSource(t, "enum Status { Ok, Slow, Down }\ncomponent C {\n  method Run() {\n    "+stmt+"\n  }\n}\n")
+		require.NotEmpty(t, errs, stmt)
+		assert.Contains(t, errs[0].Error(), expected, stmt)
+	}
+}
+
+// TestInferStrictNumerics verifies that without implicit promotion using an
+// Int where a Float is expected is an error suggesting a conversion.
+func TestInferStrictNumerics(t *testing.T) {
+	stdlib, err := filepath.Abs("../../examples/stdlib/common.sdl")
+	require.NoError(t, err)
+	const source = `
+import delay from "%s"
+component Server {
+  param Timeout Float = %s
+  param Retries Int = 3
+  method Handle(budget Float) Bool {
+    delay(%s)
+    return %s
+  }
+  method Run() Bool {
+    return self.Handle(%s)
+  }
+}
+`
+	fixed := []string{"100ms", "float(self.Retries)", "self.Timeout > 0.0", "2.0"}
+	promoted := []string{"100", "self.Retries", "self.Timeout > 0", "2"}
+	expected := []string{"100.0 or float(100)", "float(self.Retries)", "0.0 or float(0)", "2.0 or float(2)"}
+	render := func(args []string) string {
+		return fmt.Sprintf(source, stdlib, args[0], args[1], args[2], args[3])
+	}
+	strict := InferenceOptions{AllowImplicitIntFloatPromotion: false}
+
+	_, errs := validateSource(t, render(promoted))
+	require.Empty(t, errs)
+	_, errs = validateSourceWith(t, render(fixed), strict)
+	require.Empty(t, errs)
+
+	for idx := range promoted {
+		args := slices.Clone(fixed)
+		args[idx] = promoted[idx]
+		_, errs := validateSourceWith(t, render(args), strict)
+		require.Len(t, errs, 1, promoted[idx])
+		assert.Contains(t, errs[0].Error(), "implicit promotion of int to float is not allowed, convert it explicitly with "+expected[idx])
+	}
+}
+
+// TestInferDurationComparisons verifies that durations compare with
+// durations and zero, and that comparing one with a bare number asks for a
+// unit.
+func TestInferDurationComparisons(t *testing.T) {
+	_, errs := validateSource(t, `
+enum Mode { Fast, Slow }
+component Server {
+  param Timeout Float = 100ms
+  param Limit Float = 2s
+  param Name String = "a"
+  method Check(mode Mode) Bool {
+    if self.Timeout > 50ms { return false }
+    if self.Timeout + 10ms >= self.Limit { return false }
+    if Timeout > 0 { return mode == Mode.Fast }
+    return self.Name != "b"
+  }
+}
+`)
+	require.Empty(t, errs)
+
+	for cond, expected := range map[string]string{
+		"self.Timeout > 100":  "cannot compare a duration with the bare number 100, add a unit (eg 100ms)",
+		"2.5 <= self.Timeout": "cannot compare a duration with the bare number 2.5, add a unit (eg 2.5ms)",
+		"-Timeout < 1":        "cannot compare a duration with the bare number 1",
+		`self.Name < "b"`:     "cannot compare string values",
+		"true < false":        "cannot compare bool values",
+	} {
+		_, errs := validateSource(t, "component Server {\n  param Timeout Float = 100ms\n  param Name String = \"a\"\n  method Check() Bool {\n    return "+cond+"\n  }\n}\n")
+		require.Len(t, errs, 1, cond)
+		assert.Contains(t, errs[0].Error(), expected, cond)
+	}
+}
+
+// TestInferNegativeDurations verifies that zero durations are accepted while
+// negative duration literals and negative delays are rejected.  Arithmetic on
+// durations may still go negative.
+func TestInferNegativeDurations(t *testing.T) {
+	stdlib, err := filepath.Abs("../../examples/stdlib/common.sdl")
+	require.NoError(t, err)
+	const source = `
+import delay from "%s"
+component Server {
+  param Timeout = 0ms
+  param Budget = 5ms - 10ms
+  method Handle() Bool {
+    delay(%s)
+    return self.Timeout <= 0s
+  }
+}
+`
+	_, errs := validateSource(t, fmt.Sprintf(source, stdlib, "0ms"))
+	require.Empty(t, errs)
+
+	for arg, expected := range map[string]string{
+		"-5ms":   "duration -5ms is negative, durations cannot be negative",
+		"-1.5s":  "duration -1.5s is negative, durations cannot be negative",
+		"-0.005": "delay of -0.005 is negative, latencies cannot be negative",
+	} {
+		_, errs := validateSource(t, fmt.Sprintf(source, stdlib, arg))
+		require.Len(t, errs, 1, arg)
+		assert.Contains(t, errs[0].Error(), expected, arg)
+	}
+}
+
+// TestInferDelayDistribution verifies that delay takes a distribution of
+// durations but not of other types.
+func TestInferDelayDistribution(t *testing.T) {
+	stdlib, err := filepath.Abs("../../examples/stdlib/common.sdl")
+	require.NoError(t, err)
+	const source = `
+import delay from "%s"
+component Server {
+  method Handle() Bool {
+    delay(%s)
+    return true
+  }
+}
+`
+	_, errs := validateSource(t, fmt.Sprintf(source, stdlib, "dist { 90 => 5ms, 10 => 50ms }"))
+	require.Empty(t, errs)
+
+	_, errs = validateSource(t, fmt.Sprintf(source, stdlib, "dist { 90 => true, 10 => false }"))
+	require.Len(t, errs, 1)
+	assert.Contains(t, errs[0].Error(), "delay expects a duration or a distribution of durations, got Outcomes[bool]")
+	assert.Contains(t, errs[0].Error(), "Line 5, Col 5")
+}
+
+// TestInferThroughputAndHoldTime verifies that giving a pool both a
+// throughput and a hold time warns that the hold time is ignored.
+func TestInferThroughputAndHoldTime(t *testing.T) {
+	stdlib, err := filepath.Abs("../../examples/stdlib/common.sdl")
+	require.NoError(t, err)
+	const source = `
+import ResourcePool from "%s"
+component Server {
+  uses pool ResourcePool(%s)
+  method Handle() Bool { return self.pool.Acquire() }
+}
+`
+	fs, errs := validateSource(t, fmt.Sprintf(source, stdlib, "Size = 4, MaxThroughput = 20/s"))
+	require.Empty(t, errs)
+	assert.Empty(t, fs.Warnings)
+
+	fs, errs = validateSource(t, fmt.Sprintf(source, stdlib, "Size = 4, MaxThroughput = 20/s, AvgHoldTime = 100ms"))
+	require.Empty(t, errs)
+	require.Len(t, fs.Warnings, 1)
+	assert.Contains(t, fs.Warnings[0].Error(), "'pool' sets both MaxThroughput and AvgHoldTime, the hold time is derived from MaxThroughput so AvgHoldTime is ignored")
+}
+
+// TestInferNegativeTimeouts verifies that timeout params, like delays, may be
+// zero but reject negative literal defaults and overrides.
+func TestInferNegativeTimeouts(t *testing.T) {
+	const source = `
+component Database {
+  param Timeout Float = %s
+  param ConnectTimeout Float = 1s
+  param Offset Float = -1
+}
+component Server {
+  uses db Database(ConnectTimeout = %s)
+}
+`
+	_, errs := validateSource(t, fmt.Sprintf(source, "0ms", "5s - 10s"))
+	require.Empty(t, errs)
+
+	for _, c := range []struct{ timeout, connect, expected string }{
+		{"-100", "1s", "timeout Timeout of -100 is negative, timeouts cannot be negative"},
+		{"0.5", "-0.25", "timeout ConnectTimeout of -0.25 is negative, timeouts cannot be negative"},
+	} {
+		_, errs := validateSource(t, fmt.Sprintf(source, c.timeout, c.connect))
+		require.Len(t, errs, 1, c.expected)
+		assert.Contains(t, errs[0].Error(), c.expected)
+	}
+}
+
+func TestInferScenarios(t *testing.T) {
+	const components = `
+component Database {
+  method Query() Bool { return true }
+}
+component Server {
+  uses db Database()
+  method Handle() Bool { return self.db.Query() }
+}
+`
+	_, errs := validateSource(t, components+`
+system App(server Server) {
+  scenario Load {
+    generator server.Handle at 600/min for 10s;
+    generator server.db.Query at 5/s for 1min;
+    expect p99 server.Handle < 100ms;
+    expect count server.db.Query >= 100;
+    expect nopath "Handle > Query -> Query"
+  }
+}
+`)
+	require.Empty(t, errs)
+
+	for body, expected := range map[string]string{
+		"generator server.Missing at 5/s for 1s;":                                "component 'Server' has no method 'Missing'",
+		"generator server.cache.Get at 5/s for 1s;":                              "component 'Server' does not use 'cache'",
+		"generator other.Handle at 5/s for 1s;":                                  "'other' is not a component of system 'App'",
+		"generator server.Handle at 5 for 1s;":                                   "generator rate must be a count per s, min or hr",
+		"generator server.Handle at 5/d for 1s;":                                 "generator rate must be a count per s, min or hr",
+		"generator server.Handle at 5/s for 10;":                                 "generator duration must be a duration such as 10s",
+		"generator server.Handle every 5/s for 1s;":                              "expected 'at' after the generator's target, found 'every'",
+		"load server.Handle at 5/s for 1s;":                                      "unknown scenario statement 'load'",
+		"generator server.Handle at 5/s for 1s; expect p42 server.Handle < 1s":   "unknown aggregation 'p42'",
+		"generator server.Handle at 5/s for 1s; expect p99 server.Handle == 1s":  "expectation must compare a method with a threshold",
+		"generator server.Handle at 5/s for 1s; expect p99 server.Handle < 100":  "p99 is a latency, add a unit to the threshold 100 (eg 100ms)",
+		"generator server.Handle at 5/s for 1s; expect count server.Handle < 1s": "count expectations take a number of calls, not a duration",
+		"expect p99 server.Handle < 1s":                                          "scenario 'Load' has no generators",
+		"generator server.Handle at 5/s for 1s; expect path server.Handle":       "path expectations take a path spec string",
+	} {
+		_, errs := validateSource(t, components+"system App(server Server) {\n  scenario Load { "+body+" }\n}\n")
+		require.Len(t, errs, 1, body)
+		assert.Contains(t, errs[0].Error(), expected, body)
+	}
+}
+
+// TestInferSetStmtDiagnostics verifies that set statements explain why their
+// target cannot be assigned and, for a parameter of another type, show the
+// reference chain with its type.
+func TestInferSetStmtDiagnostics(t *testing.T) {
+	fs, errs := validateSource(t, `
+component Pool {
+  param Size = 10
+}
+component DB {
+  uses pool Pool()
+  method Query() Bool { return true }
+}
+component Arch {
+  uses db DB()
+}
+`)
+	require.Empty(t, errs)
+	env := decl.NewEnv[Node](nil)
+	require.Empty(t, fs.FileDecl.AddToScope(env))
+	arch, err := fs.FileDecl.GetComponent("Arch")
+	require.NoError(t, err)
+	path := func(names ...string) Expr {
+		var expr Expr = &IdentifierExpr{Value: "self"}
+		for _, name := range names {
+			expr = decl.NewMemberAccessExpr(expr, name)
+		}
+		return expr
+	}
+	set := func(target Expr, value Value) string {
+		inf := NewInference("test.sdl", fs.FileDecl)
+		_, ok := inf.EvalForSetStmt(&SetStmt{TargetExpr: target, Value: &LiteralExpr{Value: value}}, NewRootTypeScope(env).PushComponent(arch))
+		if ok {
+			return ""
+		}
+		require.NotEmpty(t, inf.Errors)
+		return inf.Errors[len(inf.Errors)-1].Error()
+	}
+
+	assert.Empty(t, set(path("db", "pool", "Size"), decl.IntValue(20)))
+	assert.Contains(t, set(path("db", "pool", "Size"), decl.StringValue("big")), "cannot assign a value of type string to self.db.pool.Size : int (declared in component 'Pool')")
+	assert.Contains(t, set(path("db", "Query"), decl.BoolValue(true)), "cannot assign to self.db.Query: it is method 'Query', only component parameters can be set")
+	assert.Contains(t, set(&LiteralExpr{Value: decl.IntValue(5)}, decl.IntValue(6)), "it is a literal")
+	assert.Contains(t, set(path("db", "Missing"), decl.IntValue(1)), "cannot assign to self.db.Missing: it does not resolve to a component parameter")
+}
+
+// TestInferIfLet verifies that an if let binding takes the type of its value
+// in the then branch only, where member access on it resolves.
+func TestInferIfLet(t *testing.T) {
+	fs, errs := validateSource(t, `
+component DB {
+  method Query() Bool { return true }
+}
+component App {
+  uses db DB()
+  method Run() Bool {
+    if let d = self.db {
+      return d.Query()
+    }
+    return false
+  }
+  method Count(k Int) Int {
+    if let n = k {
+      return n + 1
+    }
+    return 0
+  }
+}
+`)
+	require.Empty(t, errs)
+	assert.Empty(t, fs.Warnings)
+	comp, err := fs.FileDecl.GetComponent("App")
+	require.NoError(t, err)
+	run, err := comp.GetMethod("Run")
+	require.NoError(t, err)
+	binding := run.Body.Statements[0].(*decl.IfStmt).Binding
+	require.NotNil(t, binding)
+	assert.Equal(t, "Component(DB)", binding.InferredType().String())
+	count, err := comp.GetMethod("Count")
+	require.NoError(t, err)
+	assert.Equal(t, IntType, count.Body.Statements[0].(*decl.IfStmt).Binding.InferredType())
+
+	_, errs = validateSource(t, `
+component C {
+  method Run(k Int) Bool {
+    if let v = k {
+      return v > 0
+    } else {
+      return v > 0
+    }
+  }
+}
+`)
+	require.Len(t, errs, 1)
+	assert.Contains(t, errs[0].Error(), "identifier 'v' not found")
+	assert.Contains(t, errs[0].Error(), "Line 7")
+
+	_, errs = validateSource(t, `
+component C {
+  method Nothing() { }
+  method Run() Bool {
+    if let v = self.Nothing() {
+      return true
+    }
+    return false
+  }
+}
+`)
+	require.NotEmpty(t, errs)
+	assert.Contains(t, errs[0].Error(), "is always nil")
+}
+
+// TestInferEnumComparisons verifies that values of the same enum compare
+// for equality, while values of different enums, even ones of the same name,
+// cannot be compared and enum values cannot be ordered.
+func TestInferEnumComparisons(t *testing.T) {
+	const source = `
+enum Color { Red, Green }
+enum Size { Big, Small }
+component C {
+  method Check(c Color, s Size) Bool {
+    return %s
+  }
+}
+`
+	for _, cond := range []string{"c == Color.Red", "Color.Green != c", "(s == Size.Big) && (c != Color.Red)"} {
+		_, errs := validateSource(t, fmt.Sprintf(source, cond))
+		require.Empty(t, errs, cond)
+	}
+
+	for cond, expected := range map[string]string{
+		"c == s":         "cannot compare values of different enums: enum Color declared at Line 2, Col 1 and enum Size declared at Line 3, Col 1",
+		"c != Size.Big":  "cannot compare values of different enums: enum Color",
+		"c == 1":         "cannot compare enum Color and int",
+		"c < Color.Red":  "cannot order values of enum Color with '<', enum values can only be compared with == and !=",
+		"Color.Red >= c": "cannot order values of enum Color with '>='",
+	} {
+		_, errs := validateSource(t, fmt.Sprintf(source, cond))
+		require.Len(t, errs, 1, cond)
+		assert.Contains(t, errs[0].Error(), expected, cond)
+	}
+
+	// An imported enum of the same name is a different enum
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "other.sdl"), []byte("enum Color { Red, Blue }\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "main.sdl"), []byte(`import Color as OtherColor from "./other.sdl"
+enum Color { Red, Green }
+component C {
+  method Check(c Color, o OtherColor) Bool {
+    return c == OtherColor.Red
+  }
+}
+`), 0644))
+	l := NewLoader(nil, nil, 10)
+	fs, err := l.LoadFile(filepath.Join(dir, "main.sdl"), "", 0)
+	require.NoError(t, err)
+	func() {
+		defer func() {
+			if r := recover(); r != nil {
+				fs.Errors = append(fs.Errors, r.(error))
+			}
+		}()
+		l.Validate(fs)
+	}()
+	require.Len(t, fs.Errors, 1)
+	assert.Contains(t, fs.Errors[0].Error(), "cannot compare values of different enums: enum Color declared at Line 2, Col 1 and enum Color declared at Line 1, Col 1")
+}
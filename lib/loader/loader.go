@@ -1,9 +1,12 @@
 package loader
 
 import (
+	"errors"
 	"fmt"
 	"log"
 	"os"
+	"slices"
+	"strings"
 	"sync" // To handle potential concurrent loads if needed later, though starting sequential.
 	"time"
 
@@ -27,6 +30,9 @@ type FileStatus struct {
 
 	// Files imported from this file as an easy map
 	ImportedFiles map[string]bool
+
+	// Likely mistakes found when the file was last validated
+	Warnings []error
 }
 
 func (f *FileStatus) AddImports(imported ...string) {
@@ -38,21 +44,47 @@ func (f *FileStatus) AddImports(imported ...string) {
 	}
 }
 
+// DefaultMaxImportDepth is the import depth limit callers pass to NewLoader
+// unless they need another.  It is a guard against runaway import chains
+// rather than a limit legitimate models should reach.
+const DefaultMaxImportDepth = 32
+
+// ImportDepthError is returned when an import chain is longer than the
+// Loader's MaxImportDepth.
+type ImportDepthError struct {
+	Limit int
+
+	// Files from the root to the import that went too deep
+	Chain []string
+}
+
+func (e *ImportDepthError) Error() string {
+	return fmt.Sprintf("max import depth (%d) exceeded: %s", e.Limit, strings.Join(e.Chain, " -> "))
+}
+
 // Loader handles parsing and recursively loading imported SDL files.
 type Loader struct {
 	parser   Parser
 	resolver FileResolver
-	maxDepth int
+
+	// Most files an import chain may hold, counting the root, eg 1 loads the
+	// root only and 2 its direct imports too.  0 or negative means no limit.
+	MaxImportDepth int
+
+	// Options files are type checked with
+	InferenceOptions InferenceOptions
 
 	// Internal state during a load operation
 	mutex        sync.Mutex // Protects shared state if concurrency is added
 	fileStatuses map[string]*FileStatus
 	// loadedFiles  map[string]*decl.FileDecl
 	pending map[string]bool // Tracks files currently being loaded in the recursion stack for cycle detection
+	chain   []string        // Files currently being loaded, from the root down
 }
 
 // NewLoader creates a new SDL loader.
-// maxDepth specifies the maximum import recursion depth (0 means no limit, 1 means root only, etc.).
+// maxDepth sets MaxImportDepth, where 0 (or a negative value) means no limit;
+// pass DefaultMaxImportDepth for the usual guard.
 func NewLoader(parser Parser, resolver FileResolver, maxDepth int) *Loader {
 	if parser == nil {
 		parser = &SDLParserAdapter{}
@@ -61,11 +93,12 @@ func NewLoader(parser Parser, resolver FileResolver, maxDepth int) *Loader {
 		resolver = NewDefaultFileResolver()
 	}
 	return &Loader{
-		parser:       parser,
-		resolver:     resolver,
-		maxDepth:     maxDepth,
-		fileStatuses: make(map[string]*FileStatus),
-		pending:      make(map[string]bool),
+		parser:           parser,
+		resolver:         resolver,
+		MaxImportDepth:   maxDepth,
+		fileStatuses:     make(map[string]*FileStatus),
+		pending:          make(map[string]bool),
+		InferenceOptions: DefaultInferenceOptions(),
 	}
 }
 
@@ -102,20 +135,19 @@ func (l *Loader) LoadFiles(paths ...string) (allValid bool, results map[string]*
 //
 // importerDir - Directory relative to which the filePath is resolved if it is a relative path.
 func (l *Loader) LoadFile(filePath string, importerPath string, depth int) (*FileStatus, error) {
-	// 1. Check Max Depth
-	// Note: depth 0 is the root, depth 1 is its direct imports, etc.
-	// maxDepth 1 means only root file. maxDepth 2 means root + direct imports.
-	if l.maxDepth > 0 && depth >= l.maxDepth {
-		return nil, fmt.Errorf("max import depth (%d) exceeded near '%s'", l.maxDepth, filePath)
-	}
-
-	// 2. Resolve the path using the resolver to get the canonical path
+	// 1. Resolve the path using the resolver to get the canonical path
 	contentReader, canonicalPath, err := l.resolver.Resolve(importerPath, filePath, true)
 	if err != nil {
 		return nil, err
 	}
 	defer contentReader.Close() // Ensure the reader is closed
 
+	// 2. Check Max Depth
+	// Note: depth 0 is the root, depth 1 is its direct imports, etc.
+	if l.MaxImportDepth > 0 && depth >= l.MaxImportDepth {
+		return nil, &ImportDepthError{Limit: l.MaxImportDepth, Chain: append(slices.Clone(l.chain), canonicalPath)}
+	}
+
 	// Use canonicalPath for all checks and storage from now on
 	// 3. Check if already loaded
 	fileStatus, found := l.fileStatuses[canonicalPath]
@@ -133,6 +165,8 @@ func (l *Loader) LoadFile(filePath string, importerPath string, depth int) (*Fil
 	// 5. Mark as pending
 	l.pending[canonicalPath] = true
 	defer delete(l.pending, canonicalPath) // Ensure cleanup on return
+	l.chain = append(l.chain, canonicalPath)
+	defer func() { l.chain = l.chain[:len(l.chain)-1] }()
 
 	// 6. Parse the file content
 	// log.Printf("Parsing: %s (Importer: %s, Depth: %d)", canonicalPath, importerPath, depth) // VDebug
@@ -148,6 +182,12 @@ func (l *Loader) LoadFile(filePath string, importerPath string, depth int) (*Fil
 	fileStatus.LastParsed = time.Now()
 
 	// 8. Recursively load imports
+	// Report names declared more than once before Resolve silently picks one
+	if errs := CheckDuplicateDeclarations(fileDecl); len(errs) > 0 {
+		fileStatus.Errors = append(fileStatus.Errors, errs...)
+		return fileStatus, fmt.Errorf("duplicate declarations in '%s': %w", canonicalPath, errors.Join(errs...))
+	}
+
 	// First, call Resolve on the FileDecl itself to populate internal maps
 	if err := fileDecl.Resolve(); err != nil {
 		fileStatus.Errors = append(fileStatus.Errors, err)
@@ -182,7 +222,13 @@ func (l *Loader) LoadFile(filePath string, importerPath string, depth int) (*Fil
 			return fileStatus, err
 		}
 		def, _ := importedFS.FileDecl.GetDefinition(importDecl.ImportedItem.Value)
-		importDecl.ResolvedFullPath = importedFS.FullPath // Store the resolved path in the import declaration
+		def, defPath, err := followReexport(importedFS.FileDecl, importDecl.ImportedItem.Value, def)
+		if err != nil {
+			err := fmt.Errorf("in file %s: %w", canonicalPath, err)
+			fileStatus.Errors = append(fileStatus.Errors, err)
+			return fileStatus, err
+		}
+		importDecl.ResolvedFullPath = defPath // Store the resolved path in the import declaration
 		importDecl.ResolvedItem = def
 		fileStatus.AddImports(importedFS.FullPath)
 	}
@@ -305,8 +351,10 @@ func (l *Loader) validateFileDecl(fs *FileStatus, fileDecl *decl.FileDecl, visit
 	// Assuming decl.InferTypesForFile signature: func(file *decl.FileDecl, typeEnv *decl.Env[decl.Node]) []error
 	// PP(fileDecl)
 	inf := NewInference(fs.FullPath, fileDecl)
+	inf.Options = l.InferenceOptions
 	inf.MaxErrors = 1
 	inf.Eval(currentScope)
+	fs.Warnings = inf.Warnings
 	if inf.HasErrors() {
 		fs.AddErrors(inf.Errors...)
 	}
@@ -318,6 +366,21 @@ func (l *Loader) validateFileDecl(fs *FileStatus, fileDecl *decl.FileDecl, visit
 	return !fs.HasErrors()
 }
 
+// followReexport returns what a file offers its importers for its
+// definition of name, along with the path of the file declaring it.  Items
+// the file re-exports with `export ... from` resolve to their definition in
+// the file they come from while items it only imports are private to it.
+func followReexport(fileDecl *decl.FileDecl, name string, def Node) (Node, string, error) {
+	imp, ok := def.(*ImportDecl)
+	if !ok {
+		return def, fileDecl.FullPath, nil
+	}
+	if !imp.Exported {
+		return nil, "", fmt.Errorf("'%s' is imported by '%s' but not exported from it, use export %s from %q there to re-export it", name, fileDecl.FullPath, imp.ImportedItem.Value, importPath(imp))
+	}
+	return imp.ResolvedItem, imp.ResolvedFullPath, nil
+}
+
 func (l *Loader) AddImportedAliasesToScope(fs *FileStatus, currentScope *decl.Env[decl.Node]) {
 	fileDecl := fs.FileDecl
 	// 2. Add imported symbols to the scope, respecting aliases
@@ -345,6 +408,9 @@ func (l *Loader) AddImportedAliasesToScope(fs *FileStatus, currentScope *decl.En
 
 		// Use GetDefinition to find the imported symbol
 		def, err := importedFS.FileDecl.GetDefinition(importedItemOriginalName)
+		if err == nil {
+			def, _, err = followReexport(importedFS.FileDecl, importedItemOriginalName, def)
+		}
 		if err != nil {
 			fs.AddErrors(fmt.Errorf("in file %s: error getting definition for '%s' from '%s': %w", fs.FullPath, importedItemOriginalName, importPathStr, err))
 			continue
@@ -1,9 +1,18 @@
 package loader
 
 import (
+	"errors"
+	"fmt"
+	"io"
 	"log"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
 	"testing"
 
+	"github.com/panyam/sdl/lib/decl"
+
 	_ "github.com/stretchr/testify/assert"
 	_ "github.com/stretchr/testify/require"
 )
@@ -28,3 +37,127 @@ func TestBitly(t *testing.T) {
 		}
 	}
 }
+
+// largeSDL writes count components and a system using them to w.
+func largeSDL(w io.Writer, count int) {
+	for i := range count {
+		fmt.Fprintf(w, `component C%d {
+  param Delay = 10ms
+  method Handle() Bool {
+    delay(self.Delay)
+    return sample dist { 99 => true, 1 => false }
+  }
+}
+`, i)
+	}
+	fmt.Fprintln(w, "system Big(arch C0) {\n}")
+}
+
+func TestParseStream(t *testing.T) {
+	const count = 20000
+	r, w := io.Pipe()
+	go func() {
+		largeSDL(w, count)
+		w.Close()
+	}()
+
+	heapInUse := func() uint64 {
+		var m runtime.MemStats
+		runtime.GC()
+		runtime.ReadMemStats(&m)
+		return m.HeapInuse
+	}
+	var components, systems int
+	var baseline, peak uint64
+	err := ParseStream(r, func(d decl.Node) error {
+		switch d.(type) {
+		case *decl.ComponentDecl:
+			components++
+		case *decl.SystemDecl:
+			systems++
+		}
+		if components%2000 == 0 {
+			heap := heapInUse()
+			if baseline == 0 {
+				baseline = heap
+			}
+			peak = max(peak, heap)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if components != count || systems != 1 {
+		t.Fatalf("got %d components and %d systems, want %d and 1", components, systems, count)
+	}
+	// Holding every declaration would grow the heap by tens of MB.
+	if growth := int64(peak) - int64(baseline); growth > 8<<20 {
+		t.Errorf("heap grew by %d bytes while streaming", growth)
+	}
+
+	stop := errors.New("stop")
+	seen := 0
+	r, w = io.Pipe()
+	go func() {
+		largeSDL(w, 100)
+		w.Close()
+	}()
+	err = ParseStream(r, func(d decl.Node) error {
+		seen++
+		if seen == 3 {
+			return stop
+		}
+		return nil
+	})
+	r.Close()
+	if err != stop || seen != 3 {
+		t.Errorf("got %v after %d declarations, want stop after 3", err, seen)
+	}
+}
+
+// TestMaxImportDepth verifies that an import chain longer than the loader's
+// MaxImportDepth errors with the chain, and loads once the limit is raised or
+// removed.
+func TestMaxImportDepth(t *testing.T) {
+	dir := t.TempDir()
+	for i := range 5 {
+		src := fmt.Sprintf("component C%d { method M() Bool { return true } }\n", i)
+		if i < 4 {
+			src = fmt.Sprintf("import C%d from \"./f%d.sdl\"\n", i+1, i+1) + src
+		}
+		if err := os.WriteFile(filepath.Join(dir, fmt.Sprintf("f%d.sdl", i)), []byte(src), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	root := filepath.Join(dir, "f0.sdl")
+
+	l := NewLoader(nil, nil, 4)
+	_, err := l.LoadFile(root, "", 0)
+	var depthErr *ImportDepthError
+	if !errors.As(err, &depthErr) {
+		t.Fatalf("expected an ImportDepthError, got %v", err)
+	}
+	if depthErr.Limit != 4 || len(depthErr.Chain) != 5 {
+		t.Fatalf("expected limit 4 and a chain of 5 files, got %d and %v", depthErr.Limit, depthErr.Chain)
+	}
+	want := []string{"f0.sdl", "f1.sdl", "f2.sdl", "f3.sdl", "f4.sdl"}
+	for i, path := range depthErr.Chain {
+		if filepath.Base(path) != want[i] {
+			t.Errorf("chain[%d] = %s, want %s", i, path, want[i])
+		}
+	}
+	if !strings.Contains(err.Error(), "max import depth (4) exceeded: ") || !strings.Contains(err.Error(), "f3.sdl -> ") {
+		t.Errorf("error does not show the import chain: %v", err)
+	}
+
+	l = NewLoader(nil, nil, 4)
+	l.MaxImportDepth = 5
+	if _, err := l.LoadFile(root, "", 0); err != nil {
+		t.Fatalf("expected the chain to load with a limit of 5, got %v", err)
+	}
+
+	if _, err := NewLoader(nil, nil, 0).LoadFile(root, "", 0); err != nil {
+		t.Fatalf("expected a max depth of 0 to be unlimited, got %v", err)
+	}
+}
@@ -0,0 +1,274 @@
+package loader
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+
+	"github.com/panyam/sdl/lib/decl"
+)
+
+// ManifestVersion is bumped whenever a field of the manifest changes meaning
+// or is removed.  Adding fields does not bump it.
+const ManifestVersion = 1
+
+// Manifest is a machine readable summary of loaded files for tools that
+// should not depend on the AST.  Every list is sorted by name or path so the
+// same files always produce the same JSON.
+type Manifest struct {
+	Version    int                 `json:"version"`
+	Systems    []ManifestSystem    `json:"systems"`
+	Components []ManifestComponent `json:"components"`
+	Enums      []ManifestEnum      `json:"enums"`
+}
+
+// ManifestSystem is a system with the component graph it instantiates.
+type ManifestSystem struct {
+	Name       string             `json:"name"`
+	File       string             `json:"file"`
+	Parameters []ManifestInstance `json:"parameters"`
+	// Instances lists every instance reachable from the parameters by path,
+	// eg "app.cache", with the paths of the instances it uses.
+	Instances []ManifestInstance `json:"instances"`
+}
+
+type ManifestInstance struct {
+	Path      string   `json:"path"`
+	Component string   `json:"component"`
+	Uses      []string `json:"uses,omitempty"`
+}
+
+type ManifestComponent struct {
+	Name     string            `json:"name"`
+	File     string            `json:"file"`
+	Native   bool              `json:"native,omitempty"`
+	Params   []ManifestParam   `json:"params"`
+	Uses     []ManifestUses    `json:"uses"`
+	Methods  []ManifestMethod  `json:"methods"`
+	Profiles []ManifestProfile `json:"profiles,omitempty"`
+}
+
+type ManifestParam struct {
+	Name    string `json:"name"`
+	Type    string `json:"type"`
+	Default any    `json:"default,omitempty"`
+}
+
+type ManifestUses struct {
+	Name      string `json:"name"`
+	Component string `json:"component"`
+}
+
+type ManifestMethod struct {
+	Name    string          `json:"name"`
+	Params  []ManifestParam `json:"params"`
+	Returns string          `json:"returns,omitempty"`
+}
+
+type ManifestProfile struct {
+	Name    string   `json:"name"`
+	Methods []string `json:"methods"`
+}
+
+type ManifestEnum struct {
+	Name   string   `json:"name"`
+	File   string   `json:"file"`
+	Values []string `json:"values"`
+}
+
+// BuildManifest summarises the systems, components and enums declared in the
+// files.  Declarations imported into several files are listed once.  The
+// files must have been validated.
+func BuildManifest(files ...*decl.FileDecl) (*Manifest, error) {
+	m := &Manifest{
+		Version:    ManifestVersion,
+		Systems:    []ManifestSystem{},
+		Components: []ManifestComponent{},
+		Enums:      []ManifestEnum{},
+	}
+	seen := map[decl.Node]bool{}
+	for _, file := range files {
+		components, err := file.GetComponents()
+		if err != nil {
+			return nil, err
+		}
+		for _, comp := range components {
+			if seen[comp] {
+				continue
+			}
+			seen[comp] = true
+			mc, err := manifestComponent(comp, file.FullPath)
+			if err != nil {
+				return nil, err
+			}
+			m.Components = append(m.Components, mc)
+		}
+
+		enums, err := file.GetEnums()
+		if err != nil {
+			return nil, err
+		}
+		for _, enum := range enums {
+			if seen[enum] {
+				continue
+			}
+			seen[enum] = true
+			me := ManifestEnum{Name: enum.Name.Value, File: file.FullPath, Values: []string{}}
+			for _, v := range enum.Values {
+				me.Values = append(me.Values, v.Value)
+			}
+			m.Enums = append(m.Enums, me)
+		}
+
+		systems, err := file.GetSystems()
+		if err != nil {
+			return nil, err
+		}
+		for _, sys := range systems {
+			if seen[sys] {
+				continue
+			}
+			seen[sys] = true
+			ms := ManifestSystem{Name: sys.Name.Value, File: file.FullPath, Parameters: []ManifestInstance{}, Instances: []ManifestInstance{}}
+			for _, param := range sys.Parameters {
+				comp := componentOf(param.Name.InferredType())
+				if comp == nil {
+					continue
+				}
+				ms.Parameters = append(ms.Parameters, ManifestInstance{Path: param.Name.Value, Component: comp.Name.Value})
+				if err := addInstances(&ms.Instances, param.Name.Value, comp, map[*decl.ComponentDecl]bool{}); err != nil {
+					return nil, err
+				}
+			}
+			slices.SortFunc(ms.Instances, func(a, b ManifestInstance) int { return cmp.Compare(a.Path, b.Path) })
+			m.Systems = append(m.Systems, ms)
+		}
+	}
+	slices.SortFunc(m.Systems, func(a, b ManifestSystem) int { return cmp.Compare(a.Name, b.Name) })
+	slices.SortFunc(m.Components, func(a, b ManifestComponent) int { return cmp.Compare(a.Name, b.Name) })
+	slices.SortFunc(m.Enums, func(a, b ManifestEnum) int { return cmp.Compare(a.Name, b.Name) })
+	return m, nil
+}
+
+// addInstances adds the instance at path and those it uses.  Recursive
+// dependencies are only expanded once, as in addParamPaths.
+func addInstances(out *[]ManifestInstance, path string, comp *decl.ComponentDecl, visiting map[*decl.ComponentDecl]bool) error {
+	if visiting[comp] {
+		return nil
+	}
+	visiting[comp] = true
+	defer delete(visiting, comp)
+
+	deps, err := comp.Dependencies()
+	if err != nil {
+		return err
+	}
+	inst := ManifestInstance{Path: path, Component: comp.Name.Value}
+	for _, dep := range deps {
+		inst.Uses = append(inst.Uses, path+"."+dep.Name.Value)
+	}
+	slices.Sort(inst.Uses)
+	*out = append(*out, inst)
+	for _, dep := range deps {
+		if dep.ResolvedComponent != nil {
+			if err := addInstances(out, path+"."+dep.Name.Value, dep.ResolvedComponent, visiting); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func manifestComponent(comp *decl.ComponentDecl, file string) (ManifestComponent, error) {
+	mc := ManifestComponent{
+		Name:    comp.Name.Value,
+		File:    file,
+		Native:  comp.IsNative,
+		Params:  []ManifestParam{},
+		Uses:    []ManifestUses{},
+		Methods: []ManifestMethod{},
+	}
+	params, err := comp.Params()
+	if err != nil {
+		return mc, err
+	}
+	for _, param := range params {
+		mc.Params = append(mc.Params, manifestParam(param))
+	}
+	deps, err := comp.Dependencies()
+	if err != nil {
+		return mc, err
+	}
+	for _, dep := range deps {
+		mc.Uses = append(mc.Uses, ManifestUses{Name: dep.Name.Value, Component: dep.ComponentName.Value})
+	}
+	methods, err := comp.Methods()
+	if err != nil {
+		return mc, err
+	}
+	for _, method := range methods {
+		mm := ManifestMethod{Name: method.Name.Value, Params: []ManifestParam{}, Returns: typeDeclName(method.ReturnType)}
+		for _, param := range method.Parameters {
+			mm.Params = append(mm.Params, manifestParam(param))
+		}
+		mc.Methods = append(mc.Methods, mm)
+	}
+	profiles, err := comp.Profiles()
+	if err != nil {
+		return mc, err
+	}
+	for _, profile := range profiles {
+		mp := ManifestProfile{Name: profile.Name.Value}
+		for _, method := range profile.Methods {
+			mp.Methods = append(mp.Methods, method.Name.Value)
+		}
+		slices.Sort(mp.Methods)
+		mc.Profiles = append(mc.Profiles, mp)
+	}
+	slices.SortFunc(mc.Params, func(a, b ManifestParam) int { return cmp.Compare(a.Name, b.Name) })
+	slices.SortFunc(mc.Uses, func(a, b ManifestUses) int { return cmp.Compare(a.Name, b.Name) })
+	slices.SortFunc(mc.Methods, func(a, b ManifestMethod) int { return cmp.Compare(a.Name, b.Name) })
+	slices.SortFunc(mc.Profiles, func(a, b ManifestProfile) int { return cmp.Compare(a.Name, b.Name) })
+	return mc, nil
+}
+
+// manifestParam reports the param's type as written and its default when it
+// is a constant or an enum value.
+func manifestParam(param *decl.ParamDecl) ManifestParam {
+	mp := ManifestParam{Name: param.Name.Value, Type: typeDeclName(param.TypeDecl)}
+	if mp.Type == "" {
+		if t := param.Name.InferredType(); t != nil && t.Tag == decl.TypeTagSimple {
+			mp.Type = t.String()
+		}
+	}
+	if param.DefaultValue != nil {
+		if v, ok := decl.ConstantValue(param.DefaultValue); ok {
+			mp.Default = v.Value
+		} else if access, ok := param.DefaultValue.(*decl.MemberAccessExpr); ok && isEnumParam(param) {
+			mp.Default = access.Member.Value
+		}
+	}
+	return mp
+}
+
+func isEnumParam(param *decl.ParamDecl) bool {
+	t := param.Name.InferredType()
+	if param.TypeDecl != nil && param.TypeDecl.ResolvedType() != nil {
+		t = param.TypeDecl.ResolvedType()
+	}
+	return t != nil && t.Tag == decl.TypeTagEnum
+}
+
+func typeDeclName(t *decl.TypeDecl) string {
+	if t == nil {
+		return ""
+	}
+	if len(t.Args) == 0 {
+		return t.Name
+	}
+	args := make([]string, len(t.Args))
+	for i, arg := range t.Args {
+		args[i] = typeDeclName(arg)
+	}
+	return t.Name + "[" + strings.Join(args, ", ") + "]"
+}
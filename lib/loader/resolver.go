@@ -19,6 +19,7 @@ func NewDefaultFileResolver() *DefaultFileResolver {
 func (r *DefaultFileResolver) Resolve(importerPath, importPath string, open bool) (io.ReadCloser, string, error) {
 	var resolvedPath string
 
+	importPath = filepath.FromSlash(NormalizePath(importPath))
 	if filepath.IsAbs(importPath) {
 		resolvedPath = importPath
 	} else {
@@ -45,5 +46,5 @@ func (r *DefaultFileResolver) Resolve(importerPath, importPath string, open bool
 		}
 	}
 
-	return file, canonicalPath, nil
+	return file, filepath.ToSlash(canonicalPath), nil
 }
@@ -0,0 +1,191 @@
+package loader
+
+import (
+	"encoding/json"
+
+	"github.com/panyam/sdl/lib/decl"
+)
+
+// ParamSchema describes the files as a JSON Schema for generating parameter
+// forms.  Each component's settable params are a definition under $defs and
+// each system is a property listing the param paths (eg "app.cache.HitRate")
+// that can be overridden in it along with their type, default and
+// constraints.  The files must have been validated.
+func ParamSchema(files ...*decl.FileDecl) (json.RawMessage, error) {
+	defs := map[string]any{}
+	systems := map[string]any{}
+	for _, file := range files {
+		components, err := file.GetComponents()
+		if err != nil {
+			return nil, err
+		}
+		for name, comp := range components {
+			params, err := componentParamSchemas(comp)
+			if err != nil {
+				return nil, err
+			}
+			defs[name] = objectSchema(params)
+		}
+		sysDecls, err := file.GetSystems()
+		if err != nil {
+			return nil, err
+		}
+		for name, sys := range sysDecls {
+			paths := map[string]any{}
+			for _, param := range sys.Parameters {
+				comp := componentOf(param.Name.InferredType())
+				if comp == nil {
+					continue
+				}
+				if err := addParamPaths(paths, param.Name.Value, comp, map[*decl.ComponentDecl]bool{}); err != nil {
+					return nil, err
+				}
+			}
+			systems[name] = objectSchema(paths)
+		}
+	}
+	return json.Marshal(map[string]any{
+		"$schema":    "https://json-schema.org/draft/2020-12/schema",
+		"type":       "object",
+		"$defs":      defs,
+		"properties": systems,
+	})
+}
+
+// addParamPaths adds the params of comp, and of its dependencies, under the
+// given instance path.  Recursive dependencies are only expanded once.
+func addParamPaths(paths map[string]any, prefix string, comp *decl.ComponentDecl, visiting map[*decl.ComponentDecl]bool) error {
+	if visiting[comp] {
+		return nil
+	}
+	visiting[comp] = true
+	defer delete(visiting, comp)
+
+	params, err := componentParamSchemas(comp)
+	if err != nil {
+		return err
+	}
+	for name, schema := range params {
+		paths[prefix+"."+name] = schema
+	}
+	deps, err := comp.Dependencies()
+	if err != nil {
+		return err
+	}
+	for _, dep := range deps {
+		if dep.ResolvedComponent != nil {
+			if err := addParamPaths(paths, prefix+"."+dep.Name.Value, dep.ResolvedComponent, visiting); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func componentParamSchemas(comp *decl.ComponentDecl) (map[string]any, error) {
+	params, err := comp.Params()
+	if err != nil {
+		return nil, err
+	}
+	out := map[string]any{}
+	for _, param := range params {
+		out[param.Name.Value] = paramSchema(param)
+	}
+	return out, nil
+}
+
+// paramSchema maps a param's type, default and constraint to a JSON Schema.
+// Enum params are strings limited to the enum's values, or to those allowed
+// by the constraint.
+func paramSchema(param *decl.ParamDecl) map[string]any {
+	schema := map[string]any{}
+	paramType := param.Name.InferredType()
+	if param.TypeDecl != nil && param.TypeDecl.ResolvedType() != nil {
+		paramType = param.TypeDecl.ResolvedType()
+	}
+	enumDecl := enumOf(paramType)
+	if enumDecl != nil {
+		schema["type"] = "string"
+		var values []string
+		for _, v := range enumDecl.Values {
+			values = append(values, v.Value)
+		}
+		schema["enum"] = values
+	} else if jsonType := jsonSchemaType(paramType); jsonType != "" {
+		schema["type"] = jsonType
+	}
+
+	if param.DefaultValue != nil {
+		if v, ok := decl.ConstantValue(param.DefaultValue); ok {
+			schema["default"] = v.Value
+		} else if access, ok := param.DefaultValue.(*decl.MemberAccessExpr); ok && enumDecl != nil {
+			schema["default"] = access.Member.Value
+		}
+	}
+
+	if c := param.Constraint; c != nil {
+		if c.IsRange() {
+			if low, ok := decl.ConstantValue(c.Min); ok {
+				schema["minimum"] = low.Value
+			}
+			if high, ok := decl.ConstantValue(c.Max); ok {
+				schema["maximum"] = high.Value
+			}
+		} else {
+			var allowed []any
+			for _, e := range c.Allowed {
+				if enumDecl != nil {
+					if idx, ok := decl.EnumVariant(e, enumDecl); ok {
+						allowed = append(allowed, enumDecl.Values[idx].Value)
+					}
+				} else if v, ok := decl.ConstantValue(e); ok {
+					allowed = append(allowed, v.Value)
+				}
+			}
+			if len(allowed) > 0 {
+				schema["enum"] = allowed
+			}
+		}
+	}
+	return schema
+}
+
+func jsonSchemaType(t *decl.Type) string {
+	switch {
+	case t == nil:
+		return ""
+	case t.Equals(decl.IntType):
+		return "integer"
+	case t.Equals(decl.FloatType):
+		return "number"
+	case t.Equals(decl.BoolType):
+		return "boolean"
+	case t.Equals(decl.StrType):
+		return "string"
+	}
+	return ""
+}
+
+func enumOf(t *decl.Type) *decl.EnumDecl {
+	if t == nil || t.Tag != decl.TypeTagEnum {
+		return nil
+	}
+	enumDecl, _ := t.Info.(*decl.EnumDecl)
+	return enumDecl
+}
+
+func componentOf(t *decl.Type) *decl.ComponentDecl {
+	if t == nil || t.Tag != decl.TypeTagComponent {
+		return nil
+	}
+	comp, _ := t.Info.(*decl.ComponentDecl)
+	return comp
+}
+
+func objectSchema(properties map[string]any) map[string]any {
+	return map[string]any{
+		"type":                 "object",
+		"properties":           properties,
+		"additionalProperties": false,
+	}
+}
@@ -17,6 +17,7 @@ type TypeScope struct {
 	outer         *TypeScope
 	currComponent *ComponentDecl
 	currMethod    *MethodDecl
+	lets          []*IdentifierExpr // Variables bound by let statements in this scope
 }
 
 // NewRootTypeScope creates a top-level scope, using the provided Env.
@@ -130,6 +131,9 @@ func (ts *TypeScope) Get(name string) (*Type, bool) {
 			}
 			return nil, false
 		case *ParamDecl:
+			if n.TypeDecl == nil { // Typed from its default value
+				return n.Name.InferredType(), n.Name.InferredType() != nil
+			}
 			if t := n.TypeDecl.ResolvedType(); t == nil {
 				log.Println("param type not infered not found: ", name, node, reflect.TypeOf(node))
 				panic("Param Decl does not have its type set")
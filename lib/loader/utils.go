@@ -28,3 +28,11 @@ func (pa *SDLParserAdapter) Parse(input io.Reader, sourceName string) (*decl.Fil
 	}
 	return ast, nil
 }
+
+// ParseStream parses SDL from input and calls onDecl with each top level
+// declaration as it is parsed, without building the whole file's AST.  It is
+// meant for tools that only scan declarations, eg to index or list them, in
+// files too large to hold in memory.  Imports are reported but not resolved.
+func ParseStream(input io.Reader, onDecl func(decl.Node) error) error {
+	return parser.ParseStream(input, onDecl)
+}
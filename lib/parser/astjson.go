@@ -0,0 +1,183 @@
+package parser
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/panyam/sdl/lib/decl"
+)
+
+// ASTSchemaVersion is the version of the JSON produced by ParseToJSON.  It is
+// bumped whenever a change to the encoding could break existing consumers.
+const ASTSchemaVersion = 1
+
+// ASTDocument is the JSON document produced by ParseToJSON.
+//
+// Every node is an object with its Go type name in "type", its span in "pos"
+// and "end" and its exported fields keyed by field name, eg:
+//
+//	{"type": "ParamDecl", "pos": {...}, "end": {...}, "Name": {...}, "TypeDecl": {...}}
+//
+// Child nodes are nested in the same way, literal values are encoded as
+// {"type": <type>, "value": <value>} and nil fields are left out.
+type ASTDocument struct {
+	SchemaVersion int             `json:"schema_version"`
+	AST           map[string]any  `json:"ast"`
+	Errors        []ASTParseError `json:"errors,omitempty"`
+}
+
+// ASTParseError is a syntax error in an ASTDocument.
+type ASTParseError struct {
+	Message string `json:"message"`
+	Line    int    `json:"line,omitempty"`
+	Col     int    `json:"col,omitempty"`
+}
+
+// ParseToJSON parses src and encodes its FileDecl as JSON for tools outside
+// of Go, see ASTDocument.  If src does not parse the document holds the top
+// level declarations parsed before the error along with the error, which is
+// also returned.
+func ParseToJSON(src []byte) ([]byte, error) {
+	doc := ASTDocument{SchemaVersion: ASTSchemaVersion}
+	_, file, parseErr := Parse(bytes.NewReader(src))
+	if parseErr != nil {
+		file = &FileDecl{}
+		ParseStream(bytes.NewReader(src), func(d Node) error {
+			file.Declarations = append(file.Declarations, d)
+			file.StopPos = d.End()
+			return nil
+		})
+		docErr := ASTParseError{Message: parseErr.Error()}
+		var syntaxErr *SyntaxError
+		if errors.As(parseErr, &syntaxErr) {
+			docErr = ASTParseError{Message: syntaxErr.Msg, Line: syntaxErr.Pos.Line, Col: syntaxErr.Pos.Col}
+		}
+		doc.Errors = append(doc.Errors, docErr)
+	}
+	doc.AST, _ = encodeASTValue(reflect.ValueOf(file), map[uintptr]bool{}).(map[string]any)
+
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode AST: %w", err)
+	}
+	return data, parseErr
+}
+
+var (
+	nodeType     = reflect.TypeOf((*Node)(nil)).Elem()
+	valueType    = reflect.TypeOf(decl.Value{})
+	typeType     = reflect.TypeOf((*decl.Type)(nil))
+	nodeInfoType = reflect.TypeOf(decl.NodeInfo{})
+)
+
+// encodeASTValue converts a value from the AST into its JSON form.  visiting
+// holds the nodes being encoded so back references to a parent (eg the
+// component a method is bound to) are left out instead of recursing forever.
+func encodeASTValue(v reflect.Value, visiting map[uintptr]bool) any {
+	for v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	switch {
+	case v.Type() == valueType:
+		value := v.Interface().(decl.Value)
+		out := map[string]any{"value": value.Value}
+		if value.Type != nil {
+			out["type"] = value.Type.String()
+		}
+		if value.Value != nil && !isJSONScalar(reflect.ValueOf(value.Value)) {
+			out["value"] = fmt.Sprint(value.Value)
+		}
+		return out
+	case v.Type() == typeType:
+		if v.IsNil() {
+			return nil
+		}
+		return v.Interface().(*decl.Type).String()
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() || visiting[v.Pointer()] {
+			return nil
+		}
+		visiting[v.Pointer()] = true
+		defer delete(visiting, v.Pointer())
+		if v.Elem().Kind() != reflect.Struct {
+			return encodeASTValue(v.Elem(), visiting)
+		}
+		out := map[string]any{}
+		if node, ok := v.Interface().(Node); ok && v.Type().Implements(nodeType) {
+			out["type"] = v.Elem().Type().Name()
+			out["pos"] = encodeLocation(node.Pos())
+			out["end"] = encodeLocation(node.End())
+		}
+		encodeASTFields(v.Elem(), out, visiting)
+		return out
+	case reflect.Struct:
+		out := map[string]any{}
+		encodeASTFields(v, out, visiting)
+		return out
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			return nil
+		}
+		out := make([]any, v.Len())
+		for i := range out {
+			out[i] = encodeASTValue(v.Index(i), visiting)
+		}
+		return out
+	case reflect.Map:
+		if v.IsNil() || v.Type().Key().Kind() != reflect.String {
+			return nil
+		}
+		out := map[string]any{}
+		iter := v.MapRange()
+		for iter.Next() {
+			out[iter.Key().String()] = encodeASTValue(iter.Value(), visiting)
+		}
+		return out
+	case reflect.Func, reflect.Chan, reflect.UnsafePointer, reflect.Invalid:
+		return nil
+	}
+	return v.Interface()
+}
+
+// encodeASTFields adds the exported fields of a struct to out, flattening
+// embedded structs such as ExprBase.
+func encodeASTFields(v reflect.Value, out map[string]any, visiting map[uintptr]bool) {
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		if field.Type == nodeInfoType {
+			continue
+		}
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			encodeASTFields(v.Field(i), out, visiting)
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if encoded := encodeASTValue(v.Field(i), visiting); encoded != nil {
+			out[field.Name] = encoded
+		}
+	}
+}
+
+func encodeLocation(loc Location) map[string]int {
+	return map[string]int{"offset": loc.Pos, "line": loc.Line, "col": loc.Col}
+}
+
+func isJSONScalar(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Bool, reflect.String, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
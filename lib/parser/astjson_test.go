@@ -0,0 +1,79 @@
+package parser
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseToJSON(t *testing.T) {
+	src := `component Cache {
+  param Size Int = 100
+  method Get(key String) Bool {
+    return true
+  }
+}
+`
+	data, err := ParseToJSON([]byte(src))
+	require.NoError(t, err)
+
+	var doc struct {
+		SchemaVersion int `json:"schema_version"`
+		AST           struct {
+			Declarations []struct {
+				Type string
+				Pos  map[string]int
+				Body []map[string]any
+			}
+		}
+		Errors []ASTParseError
+	}
+	require.NoError(t, json.Unmarshal(data, &doc))
+	assert.Equal(t, ASTSchemaVersion, doc.SchemaVersion)
+	assert.Empty(t, doc.Errors)
+	require.Len(t, doc.AST.Declarations, 1)
+	comp := doc.AST.Declarations[0]
+	assert.Equal(t, "ComponentDecl", comp.Type)
+	assert.Equal(t, map[string]int{"offset": 0, "line": 1, "col": 1}, comp.Pos)
+	require.Len(t, comp.Body, 2)
+
+	param := comp.Body[0]
+	assert.Equal(t, "ParamDecl", param["type"])
+	assert.Equal(t, map[string]any{"offset": 20.0, "line": 2.0, "col": 3.0}, param["pos"])
+	assert.Equal(t, "Size", param["Name"].(map[string]any)["Value"])
+	defaultValue := param["DefaultValue"].(map[string]any)
+	assert.Equal(t, "LiteralExpr", defaultValue["type"])
+	assert.Equal(t, map[string]any{"type": "int", "value": 100.0}, defaultValue["Value"])
+
+	method := comp.Body[1]
+	assert.Equal(t, "MethodDecl", method["type"])
+	assert.Equal(t, 3.0, method["pos"].(map[string]any)["line"])
+	assert.Equal(t, "Get", method["Name"].(map[string]any)["Value"])
+	params := method["Parameters"].([]any)
+	require.Len(t, params, 1)
+	assert.Equal(t, "ParamDecl", params[0].(map[string]any)["type"])
+}
+
+func TestParseToJSONPartial(t *testing.T) {
+	src := `component A {
+  param X Int = 1
+}
+
+component B {
+  param = 2
+}
+`
+	data, err := ParseToJSON([]byte(src))
+	require.Error(t, err)
+	require.NotNil(t, data)
+
+	var doc ASTDocument
+	require.NoError(t, json.Unmarshal(data, &doc))
+	require.Len(t, doc.Errors, 1)
+	assert.Equal(t, 6, doc.Errors[0].Line)
+	decls := doc.AST["Declarations"].([]any)
+	require.Len(t, decls, 1)
+	assert.Equal(t, "ComponentDecl", decls[0].(map[string]any)["type"])
+}
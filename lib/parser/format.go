@@ -0,0 +1,101 @@
+package parser
+
+import (
+	"bytes"
+	"strings"
+)
+
+// FormatIndent is the indentation used for each level of nesting.
+const FormatIndent = "  "
+
+// Format returns src laid out canonically.  Only whitespace is changed so
+// comments are kept where they are: each line is indented by how deeply it
+// is nested in braces, brackets and parens, trailing whitespace is removed,
+// runs of blank lines are collapsed to one and the file ends with a single
+// newline.  Formatting is idempotent.  src must parse.
+func Format(src []byte) ([]byte, error) {
+	if _, _, err := Parse(bytes.NewReader(src)); err != nil {
+		return nil, err
+	}
+
+	var out bytes.Buffer
+	depth := 0
+	inComment, inString := false, false
+	blank := false
+	for _, line := range strings.Split(string(src), "\n") {
+		// Lines continuing a block comment or string are kept as they are
+		if inComment || inString {
+			if inComment {
+				line = strings.TrimRight(line, " \t\r")
+			}
+			out.WriteString(line)
+			out.WriteByte('\n')
+			depth, inComment, inString = scanNesting(line, depth, inComment, inString)
+			continue
+		}
+
+		content := strings.TrimSpace(line)
+		if content == "" {
+			blank = out.Len() > 0
+			continue
+		}
+		if blank {
+			out.WriteByte('\n')
+			blank = false
+		}
+		out.WriteString(strings.Repeat(FormatIndent, max(depth-leadingClosers(content), 0)))
+		out.WriteString(content)
+		out.WriteByte('\n')
+		depth, inComment, inString = scanNesting(content, depth, false, false)
+	}
+	return out.Bytes(), nil
+}
+
+// leadingClosers counts the closing brackets a line starts with, which are
+// indented at the level of their opening line.
+func leadingClosers(content string) (n int) {
+	for _, ch := range content {
+		switch ch {
+		case '}', ')', ']':
+			n++
+		case ' ', '\t':
+		default:
+			return
+		}
+	}
+	return
+}
+
+// scanNesting updates the nesting depth with the brackets on a line,
+// ignoring those in strings and comments, and reports whether the line ends
+// inside a block comment or string.
+func scanNesting(line string, depth int, inComment, inString bool) (int, bool, bool) {
+	for i := 0; i < len(line); i++ {
+		ch := line[i]
+		switch {
+		case inComment:
+			if ch == '*' && i+1 < len(line) && line[i+1] == '/' {
+				inComment = false
+				i++
+			}
+		case inString:
+			if ch == '\\' {
+				i++
+			} else if ch == '"' {
+				inString = false
+			}
+		case ch == '/' && i+1 < len(line) && line[i+1] == '/':
+			return depth, false, false
+		case ch == '/' && i+1 < len(line) && line[i+1] == '*':
+			inComment = true
+			i++
+		case ch == '"':
+			inString = true
+		case ch == '{' || ch == '(' || ch == '[':
+			depth++
+		case ch == '}' || ch == ')' || ch == ']':
+			depth--
+		}
+	}
+	return depth, inComment, inString
+}
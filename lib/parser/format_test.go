@@ -0,0 +1,54 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormat(t *testing.T) {
+	input := `
+
+component Server {
+uses db Database(
+Timeout = 100
+    )
+	param Name String = "a { not a brace"   
+ // trailing } comment
+      /* multi
+   line { comment */
+method Handle() Bool {
+
+
+        return self.db.Query()
+}
+}
+
+
+`
+	expected := `component Server {
+  uses db Database(
+    Timeout = 100
+  )
+  param Name String = "a { not a brace"
+  // trailing } comment
+  /* multi
+   line { comment */
+  method Handle() Bool {
+
+    return self.db.Query()
+  }
+}
+`
+	out, err := Format([]byte(input))
+	require.NoError(t, err)
+	assert.Equal(t, expected, string(out))
+
+	again, err := Format(out)
+	require.NoError(t, err)
+	assert.Equal(t, expected, string(again), "formatting should be idempotent")
+
+	_, err = Format([]byte("component {"))
+	assert.Error(t, err)
+}
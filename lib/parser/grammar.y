@@ -4,6 +4,7 @@ package parser
 import (
     // "reflect"
     "log"
+    "bytes"
     "fmt"
     "io"
 )
@@ -43,8 +44,10 @@ func yyerrok(lexer SDLLexer) {
     stmt        Stmt
     typeDecl    *TypeDecl
     paramDecl   *ParamDecl
+    paramConstraint *ParamConstraint
     usesDecl    *UsesDecl
     methodDef   *MethodDecl
+    profileDecl *ProfileDecl
     // instanceDecl removed: InstanceDecl no longer in grammar
     analyzeDecl *AnalyzeDecl
     expectBlock *ExpectationsDecl
@@ -62,7 +65,9 @@ func yyerrok(lexer SDLLexer) {
     goExpr         *GoExpr
     forStmt         *ForStmt
     assignStmt     *AssignmentStmt
-    // optionsDecl removed
+    optionsDecl    *OptionsDecl
+    scenarioDecl   *ScenarioDecl
+    scenarioItems  []Node
     enumDecl       *EnumDecl
     importDecl     *ImportDecl
     waitExpr *WaitExpr
@@ -88,6 +93,10 @@ func yyerrok(lexer SDLLexer) {
     distributeExprCaseList []*CaseExpr
     expectStmtList     []*ExpectStmt
     methodSigItemList []*MethodDecl
+    annotation        *Annotation
+    annotationList    []*Annotation
+    letPattern        *LetPattern
+    letPatternList    []*LetPattern
 
     // Add field to store position for simple tokens if needed
     // posInfo     NodeInfo
@@ -95,13 +104,13 @@ func yyerrok(lexer SDLLexer) {
 
 // --- Tokens ---
 // Keywords (assume lexer returns token type, parser might need pos for some)
-%token<node> SYSTEM USES AGGREGATOR METHOD ANALYZE EXPECT LET IF ELSE SAMPLE DISTRIBUTE DEFAULT RETURN DELAY WAIT GO GOBATCH USING SWITCH CASE FOR 
+%token<node> SYSTEM USES AGGREGATOR METHOD ANALYZE EXPECT LET IF ELSE SAMPLE DISTRIBUTE DEFAULT RETURN DELAY WAIT GO GOBATCH USING REQUIREALL ANYOF SWITCH CASE FOR IN SCENARIO SPAWN
 
 // Marking these as nodes so can be returned as Node for their locations
-%token<node> USE NATIVE LSQUARE RSQUARE LBRACE RBRACE OPTIONS ENUM COMPONENT PARAM IMPORT FROM AS
+%token<node> USE NATIVE LSQUARE RSQUARE LBRACE RBRACE OPTIONS PROFILE ENUM COMPONENT PARAM IMPORT FROM AS EXPORT
 
 // Operators and Punctuation (assume lexer returns token type, use $N.(Node).Pos() if $N is a literal/ident)
-%token<node> ASSIGN COLON LPAREN RPAREN COMMA DOT ARROW LET_ASSIGN  SEMICOLON 
+%token<node> ASSIGN COLON LPAREN RPAREN COMMA DOT ARROW LET_ASSIGN  SEMICOLON AT
 
 %token<node>  INT FLOAT BOOL STRING DURATION
 
@@ -127,12 +136,17 @@ func yyerrok(lexer SDLLexer) {
 %type <compBodyItem> NativeComponentBodyItem 
 %type <compBodyItemList> NativeComponentBodyItemList NativeComponentBodyItemOptList
 %type <sysBodyItemList>  SystemBodyItemOptList 
-// OptionsDecl type removed
+%type <optionsDecl>  OptionsDecl
+%type <scenarioDecl> ScenarioDecl
+%type <scenarioItems> ScenarioItemOptList
+%type <node>         ScenarioItem
 %type <enumDecl>     EnumDecl
 %type <identList>    CommaIdentifierList 
+%type <letPattern>   LetPattern
+%type <letPatternList> LetPatternList
 %type <importDecl>   ImportItem
 %type <importDeclList>   ImportDecl ImportList
-%type <stmt>         Stmt IfStmtElseOpt LetStmt ExprStmt ReturnStmt 
+%type <stmt>         Stmt IfStmtElseOpt LetStmt ExprStmt ReturnStmt SpawnStmt
 // %type <delayStmt>    DelayStmt 
 %type <sampleExpr>    SampleExpr 
 // %type <assignStmt>   AssignStmt
@@ -142,15 +156,21 @@ func yyerrok(lexer SDLLexer) {
 %type <expr>         Expression UnaryExpr PrimaryExpr LiteralExpr CallExpr MemberAccessExpr IndexExpr LeafExpr ParenExpr  WaitExpr
 %type <chainedExpr>         ChainedExpr
 %type <paramDecl>    ParamDecl MethodParamDecl
+%type <paramConstraint> ParamConstraintOpt
 %type <paramList>    MethodParamList MethodParamListOpt
 %type <typeDecl>     TypeDecl
 %type <typeDeclList>     TypeDeclList
 %type <usesDecl>     UsesDecl
-%type <methodDef>    MethodDecl MethodSigDecl
+%type <methodDef>    MethodDecl MethodSigDecl NativeMethodSigDecl AnnotatedMethodDecl
+%type <methodSigItemList> AnnotatedMethodDeclList AnnotatedMethodDeclOptList
+%type <profileDecl>  ProfileDecl
+%type <annotation>   Annotation
+%type <annotationList> AnnotationList
 // InstanceDecl type removed from grammar
 %type <forStmt>   ForStmt
 %type <assignStmt>   Assignment
-%type <assignList>   AssignList  AssignListOpt
+%type <assignList>   AssignList  AssignListOpt UsesArgList UsesArgListOpt
+%type <assignStmt>   UsesArg
 %type <ifStmt>       IfStmt
 // %type <exprList>     CommaSepExprListOpt
 // %type <exprMap>     KWArgListOpt
@@ -195,11 +215,11 @@ DeclarationList:
     /* empty */         { $$ = []Node{} }
     | DeclarationList SEMICOLON { $$ = $1 }
     | DeclarationList TopLevelDeclaration {
-        $$ = append($1, $2)
+        $$ = SDLlex.(*Lexer).addDecl($1, $2)
     }
     | DeclarationList ImportDecl {
         for _, imp := range $2 {
-          $1 = append($1, imp)
+          $1 = SDLlex.(*Lexer).addDecl($1, imp)
         }
         $$ = $1
     }
@@ -209,14 +229,23 @@ TopLevelDeclaration:
       ComponentDecl { $$ = $1 }
     | SystemDecl    { $$ = $1 }
     | AggregatorDecl { $$ = $1 }
-    | NATIVE METHOD MethodSigDecl   { 
+    | NATIVE METHOD NativeMethodSigDecl   { 
         $3.IsNative = true
         $$ = $3
     }
     | EnumDecl      { $$ = $1 }
+    | OptionsDecl   { $$ = $1 }
     ;
 
-// OptionsDecl removed — was never used in practice.
+// File level options are defaults that options in a system override
+OptionsDecl:
+    OPTIONS LBRACE AssignListOpt RBRACE {
+        $$ = &OptionsDecl{
+            NodeInfo: NewNodeInfo($1.(Node).Pos(), $4.(Node).End()),
+            Options: $3,
+        }
+    }
+    ;
 
 ComponentDecl:
     NATIVE COMPONENT IDENTIFIER LBRACE NativeComponentBodyItemOptList RBRACE { // COMPONENT($1) ... RBRACE($5)
@@ -259,6 +288,14 @@ ImportDecl:
         }
         $$ = $2
     }
+    | EXPORT ImportList FROM STRING_LITERAL {
+        path := $4.(*LiteralExpr)
+        for _, imp := range $2 {
+          imp.Path = path
+          imp.Exported = true
+        }
+        $$ = $2
+    }
     ;
 
 ImportList : ImportItem             { $$ = []*ImportDecl{$1}; }
@@ -287,6 +324,15 @@ MethodSigDecl:
     }
     ;
 
+// Native methods can model their outcome instead of being implemented in Go
+NativeMethodSigDecl:
+    MethodSigDecl { $$ = $1 }
+    | MethodSigDecl ASSIGN DistributeExpr {
+        $1.Outcomes = $3
+        $$ = $1
+    }
+    ;
+
 NativeComponentBodyItemOptList:
                 /* empty */ { $$ = []ComponentDeclBodyItem{} }
               | NativeComponentBodyItemList { $$ = $1 }
@@ -299,7 +345,7 @@ NativeComponentBodyItemList:
 
 NativeComponentBodyItem:
       ParamDecl   { $$ = $1 }
-    | METHOD MethodSigDecl   { $$ = $2 }
+    | METHOD NativeMethodSigDecl   { $$ = $2 }
     ;
 
 
@@ -316,31 +362,99 @@ ComponentBodyItemList:
 ComponentBodyItem:
       ParamDecl   { $$ = $1 }
     | UsesDecl    { $$ = $1 }
-    | MethodDecl   { $$ = $1 }
+    | AnnotatedMethodDecl   { $$ = $1 }
+    | ProfileDecl   { $$ = $1 }
     | ComponentDecl { $$ = $1 } // Allow nested components
     ;
 
+AnnotatedMethodDecl:
+      MethodDecl   { $$ = $1 }
+    | AnnotationList MethodDecl {
+        $2.Annotations = $1
+        $2.NodeInfo.StartPos = $1[0].Pos()
+        $$ = $2
+    }
+    ;
+
+ProfileDecl:
+    PROFILE IDENTIFIER LBRACE AnnotatedMethodDeclOptList RBRACE {
+        $$ = &ProfileDecl{
+            NodeInfo: NewNodeInfo($1.(Node).Pos(), $5.(Node).End()),
+            Name: $2,
+            Methods: $4,
+        }
+    }
+    ;
+
+AnnotatedMethodDeclOptList:
+      /* empty */ { $$ = []*MethodDecl{} }
+    | AnnotatedMethodDeclList { $$ = $1 }
+    ;
+
+AnnotatedMethodDeclList:
+      AnnotatedMethodDecl { $$ = []*MethodDecl{$1} }
+    | AnnotatedMethodDeclList AnnotatedMethodDecl { $$ = append($1, $2) }
+    ;
+
+AnnotationList:
+      Annotation { $$ = []*Annotation{$1} }
+    | AnnotationList Annotation { $$ = append($1, $2) }
+    ;
+
+Annotation:
+    AT IDENTIFIER {
+        $$ = &Annotation{NodeInfo: NewNodeInfo($1.Pos(), $2.End()), Name: $2}
+    }
+    | AT IDENTIFIER LPAREN AssignListOpt RPAREN {
+        $$ = &Annotation{NodeInfo: NewNodeInfo($1.Pos(), $5.End()), Name: $2, Args: $4}
+    }
+    ;
+
 ParamDecl:
-    PARAM IDENTIFIER TypeDecl { // PARAM($1) ... 
+    PARAM IDENTIFIER TypeDecl ParamConstraintOpt { // PARAM($1) ... 
         $$ = &ParamDecl{
             NodeInfo: NewNodeInfo($1.(Node).Pos(), $3.End()),
             Name: $2,
             TypeDecl: $3, // TypeDecl also needs to have NodeInfo
+            Constraint: $4,
         }
+        if $4 != nil { $$.NodeInfo.StopPos = $4.End() }
     }
-    | PARAM IDENTIFIER ASSIGN Expression { // PARAM($1) ... 
+    | PARAM IDENTIFIER ASSIGN Expression ParamConstraintOpt { // PARAM($1) ... 
         $$ = &ParamDecl{
             NodeInfo: NewNodeInfo($1.(Node).Pos(), $4.End()),
             Name: $2,
             DefaultValue: $4,
+            Constraint: $5,
         }
+        if $5 != nil { $$.NodeInfo.StopPos = $5.End() }
     }
-    | PARAM IDENTIFIER TypeDecl ASSIGN Expression { // PARAM($1) ... 
+    | PARAM IDENTIFIER TypeDecl ASSIGN Expression ParamConstraintOpt { // PARAM($1) ... 
         $$ = &ParamDecl{
             NodeInfo: NewNodeInfo($1.(Node).Pos(), $5.End()),
             Name: $2,
             TypeDecl: $3,
             DefaultValue: $5,
+            Constraint: $6,
+        }
+        if $6 != nil { $$.NodeInfo.StopPos = $6.End() }
+    }
+    ;
+
+// Optional "in [min, max]" or "in {a, b, c}" restricting a param's values
+ParamConstraintOpt:
+    /* empty */ { $$ = nil }
+    | IN LSQUARE Expression COMMA Expression RSQUARE {
+        $$ = &ParamConstraint{
+            NodeInfo: NewNodeInfo($1.(Node).Pos(), $6.(Node).End()),
+            Min: $3,
+            Max: $5,
+        }
+    }
+    | IN LBRACE CommaSepExprList RBRACE {
+        $$ = &ParamConstraint{
+            NodeInfo: NewNodeInfo($1.(Node).Pos(), $4.(Node).End()),
+            Allowed: $3,
         }
     }
     ;
@@ -394,16 +508,38 @@ UsesDecl:
          }
     }
     // Optional: Add syntax for overrides within uses? Like `uses x: T { p1 = v1; }`
-    | USES IDENTIFIER IDENTIFIER LPAREN AssignListOpt RPAREN {
+    | USES IDENTIFIER IDENTIFIER LPAREN UsesArgListOpt RPAREN {
         $$ = &UsesDecl{
              NodeInfo: NewNodeInfo($1.(Node).Pos(), $6.End()),
              Name: $2,
              ComponentName: $3,
-             Overrides: $5,
+             Args: $5,
+             Overrides: []*AssignmentStmt{},
          }
+        for _, arg := range $5 {
+            if arg.Var != nil {
+                $$.Overrides = append($$.Overrides, arg)
+            }
+        }
     }
     ;
 
+// Overrides of a used component, by name or by position like a constructor
+UsesArgListOpt:
+      /* empty */  { $$ = []*AssignmentStmt{} }
+    | UsesArgList { $$ = $1 }
+    ;
+
+UsesArgList:
+    UsesArg             { $$ = []*AssignmentStmt{$1} }
+    | UsesArgList COMMA UsesArg { $$ = append($1, $3) }
+    ;
+
+UsesArg:
+    Assignment { $$ = $1 }
+    | Expression { $$ = &AssignmentStmt{NodeInfo: NewNodeInfo($1.Pos(), $1.End()), Value: $1} }
+    ;
+
 MethodDecl:
     METHOD MethodSigDecl BlockStmt { // METHOD($1) ... BlockStmt($6)
         $2.Body = $3
@@ -479,12 +615,66 @@ SystemBodyItemOptList:
     ;
 
 SystemBodyItem:
-            // System bodies contain only function-call expressions:
-            // generator(...), metric(...), etc.
-            // OptionsDecl and LetStmt removed — no longer needed after component/system unification.
+            // System bodies contain function-call expressions:
+            // generator(...), metric(...), etc. and options overriding the file's.
+            // LetStmt removed — no longer needed after component/system unification.
               ExprStmt { $$=$1 }
+            | OptionsDecl { $$=$1 }
+            | ScenarioDecl { $$=$1 }
             ;
 
+// Scenarios are load tests run by `sdl test`:
+//   scenario Name { generator a.b.M at 50/s for 10s; expect p99 a.b.M < 100ms; }
+// The generator and at words are checked during inference.
+ScenarioDecl:
+    SCENARIO IDENTIFIER LBRACE ScenarioItemOptList RBRACE {
+        scenario := &ScenarioDecl{
+            NodeInfo: NewNodeInfo($1.(Node).Pos(), $5.(Node).End()),
+            Name: $2,
+        }
+        for _, item := range $4 {
+            switch it := item.(type) {
+            case *ScenarioGenerator:
+                scenario.Generators = append(scenario.Generators, it)
+            case *ScenarioExpect:
+                scenario.Expects = append(scenario.Expects, it)
+            }
+        }
+        $$ = scenario
+    }
+    ;
+
+ScenarioItemOptList:
+    /* empty */  { $$ = []Node{} }
+    | ScenarioItemOptList ScenarioItem {
+        $$ = $1
+        if $2 != nil {
+            $$ = append($$, $2)
+        }
+    }
+    ;
+
+ScenarioItem:
+    IDENTIFIER Expression IDENTIFIER Expression FOR Expression {
+        $$ = &ScenarioGenerator{
+            NodeInfo: NewNodeInfo($1.Pos(), $6.End()),
+            Keyword: $1,
+            Target: $2,
+            At: $3,
+            RateExpr: $4,
+            For: $6,
+        }
+    }
+    | EXPECT IDENTIFIER Expression {
+        $$ = &ScenarioExpect{
+            NodeInfo: NewNodeInfo($1.(Node).Pos(), $3.End()),
+            Aggregation: $2,
+            Condition: $3,
+        }
+    }
+    | SEMICOLON { $$ = nil }
+    ;
+
 AssignListOpt:
       /* empty */  { $$ = []*AssignmentStmt{} }
     | AssignList { $$ = $1 }
@@ -520,6 +710,7 @@ Stmt:
     | ExprStmt       { $$ = $1 }
     | ForStmt       { $$ = $1 }
     | ReturnStmt     { $$ = $1 }
+    | SpawnStmt      { $$ = $1 }
     | IfStmt         { $$ = $1 }
     | SwitchStmt      { $$ = $1 }
     | BlockStmt      { $$ = $1 }
@@ -536,18 +727,42 @@ BlockStmt:
 ForStmt: FOR Expression Stmt {
         $$ = &ForStmt{NodeInfo: NewNodeInfo($1.(Node).Pos(), $3.End()), Condition: $2, Body: $3 }
        }
+       | FOR IDENTIFIER IN Expression Stmt {
+        $$ = &ForStmt{NodeInfo: NewNodeInfo($1.(Node).Pos(), $5.End()), Var: $2, Condition: $4, Body: $5 }
+       }
        ;
 
 LetStmt:
-    LET CommaIdentifierList ASSIGN Expression { // LET($1) ... 
+    LET LetPatternList ASSIGN Expression { // LET($1) ... 
+         pattern := $2[0]
+         if len($2) > 1 {
+             pattern = &LetPattern{NodeInfo: NewNodeInfo($2[0].Pos(), $2[len($2)-1].End()), Children: $2}
+         }
          $$ = &LetStmt{
              NodeInfo: NewNodeInfo($1.(Node).Pos(), $4.End()),
-             Variables: $2,
+             Variables: pattern.Leaves(),
+             Pattern: pattern,
              Value: $4,
           }
     }
     ;
 
+LetPatternList:
+    LetPattern                        { $$ = []*LetPattern{$1} }
+    | LetPatternList COMMA LetPattern { $$ = append($1, $3) }
+    ;
+
+LetPattern:
+    IDENTIFIER { $$ = &LetPattern{NodeInfo: $1.NodeInfo, Ident: $1} }
+    | LPAREN LetPatternList RPAREN {
+        if len($2) == 1 {
+            $$ = $2[0]  // (a) is just a
+        } else {
+            $$ = &LetPattern{NodeInfo: NewNodeInfo($1.(Node).Pos(), $3.(Node).End()), Children: $2}
+        }
+    }
+    ;
+
 /*
 AssignStmt: // Rule for simple assignment `a = b;` if needed as statement
     IDENTIFIER ASSIGN Expression {
@@ -568,6 +783,12 @@ ReturnStmt:
     | RETURN SEMICOLON          { $$ = &ReturnStmt{ NodeInfo: NewNodeInfo($1.(Node).Pos(), $2.(Node).End()), ReturnValue: nil } }
     ;
 
+// Any expression is accepted, as for go, so the call does not conflict with
+// calls chained onto it.  Inference rejects spawning anything but a call.
+SpawnStmt:
+    SPAWN Expression { $$ = &SpawnStmt{ NodeInfo: NewNodeInfo($1.(Node).Pos(), $2.End()), Call: $2 } }
+    ;
+
 // DelayStmt: DELAY Expression { $$ = &DelayStmt{ NodeInfo: NewNodeInfo($1.(Node).Pos(), $2.End()), Duration: $2 } } ;
 
 WaitExpr:
@@ -577,6 +798,16 @@ WaitExpr:
          $$ = &WaitExpr{  FutureNames: idents }
          $$.(*WaitExpr).NodeInfo = NewNodeInfo($1.Pos(), endNode.End())
     }
+    | WAIT CommaIdentifierList REQUIREALL {
+         idents := $2
+         $$ = &WaitExpr{  FutureNames: idents, Policy: WaitRequireAll }
+         $$.(*WaitExpr).NodeInfo = NewNodeInfo($1.Pos(), $3.End())
+    }
+    | WAIT CommaIdentifierList ANYOF LPAREN Expression RPAREN {
+         idents := $2
+         $$ = &WaitExpr{  FutureNames: idents, Policy: WaitAnyOf, AnyOfCount: $5 }
+         $$.(*WaitExpr).NodeInfo = NewNodeInfo($1.Pos(), $6.End())
+    }
     | WAIT CommaIdentifierList USING CallExpr { // WAIT($1) IDENTIFIER($2) ... 
         idents := $2
         endNode := idents[len(idents)-1] // End at the last identifier in the list
@@ -629,6 +860,17 @@ IfStmt:
           Else: $4,
         }
     }
+    | IF LET IDENTIFIER ASSIGN Expression BlockStmt IfStmtElseOpt {
+        endNode := Stmt($6)
+        if $7 != nil { endNode = $7 }
+        $$ = &IfStmt{
+          NodeInfo: NewNodeInfo($1.(Node).Pos(), endNode.End()),
+          Binding: $3,
+          Condition: $5,
+          Then: $6,
+          Else: $7,
+        }
+    }
     ;
 
 IfStmtElseOpt:
@@ -902,8 +1144,11 @@ type LexerInterface interface {
 // Parse takes an input stream and attempts to parse it according to the SDL grammar. 22222
 // It returns the root of the Abstract Syntax Tree (*FileDecl) if successful, or an error.
 func Parse(input io.Reader) (*Lexer, *FileDecl, error) {
-	// Reset global result before parsing
-	lexer := NewLexer(input)
+	src, err := io.ReadAll(input)
+	if err != nil {
+		return nil, nil, err
+	}
+	lexer := NewLexer(bytes.NewReader(src))
 	// Set yyDebug = 3 for verbose parser debugging output
 	// yyDebug = 3
 	resultCode := SDLParse(lexer) // Call the LALR parser generated by goyacc
@@ -912,7 +1157,7 @@ func Parse(input io.Reader) (*Lexer, *FileDecl, error) {
 		// A syntax error occurred. The lexer's Error method should have been called
 		// and stored the error message.
 		if lexer.lastError != nil {
-			return lexer, nil, lexer.lastError
+			return lexer, nil, keywordAsNameError(src, lexer)
 		}
 		// Fallback error message if lexer didn't store one
 		return lexer, nil, fmt.Errorf("syntax error near byte %d (Line %d, Col %d)", lexer.location.Pos, lexer.location.Line, lexer.location.Col)
@@ -927,6 +1172,26 @@ func Parse(input io.Reader) (*Lexer, *FileDecl, error) {
 	return lexer, lexer.parseResult, nil
 }
 
+// ParseStream parses input and calls onDecl with each top level declaration
+// as soon as it is parsed instead of collecting them into a FileDecl, so that
+// memory use is bounded by the largest declaration rather than the whole
+// file.  Parsing stops at the first error returned by onDecl.
+func ParseStream(input io.Reader, onDecl func(Node) error) error {
+	lexer := NewLexer(input)
+	lexer.onDecl = onDecl
+	resultCode := SDLParse(lexer)
+	if lexer.declErr != nil {
+		return lexer.declErr
+	}
+	if resultCode != 0 {
+		if lexer.lastError != nil {
+			return lexer.lastError
+		}
+		return fmt.Errorf("syntax error near byte %d (Line %d, Col %d)", lexer.location.Pos, lexer.location.Line, lexer.location.Col)
+	}
+	return nil
+}
+
 // The parser expects the lexer variable to be named yyLex.
 // We can satisfy this by creating a global or passing it via SDLParseWithLexer.
 // Using SDLParseWithLexer is cleaner.
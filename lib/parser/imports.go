@@ -10,11 +10,14 @@ type Expr = decl.Expr
 type ExprBase = decl.ExprBase
 type Stmt = decl.Stmt
 type LetStmt = decl.LetStmt
+type LetPattern = decl.LetPattern
 type ForStmt = decl.ForStmt
 type ReturnStmt = decl.ReturnStmt
 type ExprStmt = decl.ExprStmt
+type SpawnStmt = decl.SpawnStmt
 type TypeDecl = decl.TypeDecl
 type ParamDecl = decl.ParamDecl
+type ParamConstraint = decl.ParamConstraint
 type ComponentDecl = decl.ComponentDecl
 type SystemDecl = decl.SystemDecl
 type AggregatorDecl = decl.AggregatorDecl
@@ -27,6 +30,7 @@ type FileDecl = decl.FileDecl
 type Type = decl.Type
 type UsesDecl = decl.UsesDecl
 type MethodDecl = decl.MethodDecl
+type ProfileDecl = decl.ProfileDecl
 type InstanceDecl = decl.InstanceDecl
 type AnalyzeDecl = decl.AnalyzeDecl
 type ExpectationsDecl = decl.ExpectationsDecl
@@ -41,6 +45,10 @@ type GoExpr = decl.GoExpr
 type WaitExpr = decl.WaitExpr
 type AssignmentStmt = decl.AssignmentStmt
 type OptionsDecl = decl.OptionsDecl
+type ScenarioDecl = decl.ScenarioDecl
+type ScenarioGenerator = decl.ScenarioGenerator
+type ScenarioExpect = decl.ScenarioExpect
+type Annotation = decl.Annotation
 type ImportDecl = decl.ImportDecl
 
 // Slices for lists
@@ -96,3 +104,6 @@ var NewNamedCallExpr = decl.NewNamedCallExpr
 var NewDistributeExpr = decl.NewDistributeExpr
 var NewGoExpr = decl.NewGoExpr
 var NewWaitExpr = decl.NewWaitExpr
+
+const WaitRequireAll = decl.WaitRequireAll
+const WaitAnyOf = decl.WaitAnyOf
@@ -8,6 +8,8 @@ import (
 	"strconv"
 	"strings"
 	"unicode"
+
+	"github.com/panyam/sdl/lib/decl"
 )
 
 // Ensure EOF is defined
@@ -28,17 +30,39 @@ type Lexer struct {
 	tokenStart    Location // Byte offset where the current token started
 	tokenText     string   // Raw text of the current token
 	lastTokenCode int      // <-- Added: Store the last token returned by Lex
+	isKeyword     bool     // Whether the current token is a keyword
+
+	// Offset of a keyword to lex as an identifier, -1 for none
+	keywordAsIdentAt int
 
 	// Current line and column (rune-based) in the input
 	location Location
 
 	parseResult *FileDecl // Field to store the final AST root, set by the parser
+
+	// Set by ParseStream to receive declarations instead of collecting them
+	onDecl  func(Node) error
+	declErr error
+}
+
+// addDecl appends a parsed top level declaration to decls, or hands it to
+// onDecl when streaming.  An error from onDecl ends the input so that
+// parsing stops.
+func (l *Lexer) addDecl(decls []Node, d Node) []Node {
+	if l.onDecl == nil {
+		return append(decls, d)
+	}
+	if l.declErr == nil {
+		l.declErr = l.onDecl(d)
+	}
+	return decls
 }
 
 // NewLexer creates a New lexer instance
 func NewLexer(r io.Reader) *Lexer {
 	return &Lexer{
-		reader: bufio.NewReader(r),
+		reader:           bufio.NewReader(r),
+		keywordAsIdentAt: -1,
 		location: Location{
 			Pos:  0,
 			Line: 1,
@@ -47,16 +71,45 @@ func NewLexer(r io.Reader) *Lexer {
 	}
 }
 
+// SyntaxError is a lexing or parsing error at a position in the source.
+type SyntaxError struct {
+	Pos  Location
+	Near string // Text of the offending token, if any
+	Msg  string
+}
+
+func (e *SyntaxError) Error() string {
+	if e.Near != "" {
+		return fmt.Sprintf("Line: %d, Col: %d - Error near '%s' --- %s", e.Pos.Line, e.Pos.Col, e.Near, e.Msg)
+	}
+	return fmt.Sprintf("Line: %d, Col: %d - %s", e.Pos.Line, e.Pos.Col, e.Msg)
+}
+
 // Error is called by the parser (or lexer itself) on an error.
 func (l *Lexer) Error(s string) {
-	if l.Text() != "" {
-		l.lastError = fmt.Errorf("Line: %d, Col: %d - Error near '%s' --- %s", l.tokenStart.Line, l.tokenStart.Col, l.Text(), s)
-	} else {
-		l.lastError = fmt.Errorf("Line: %d, Col: %d - %s", l.tokenStart.Line, l.tokenStart.Col, s)
-	}
+	l.lastError = &SyntaxError{Pos: l.tokenStart, Near: l.Text(), Msg: s}
 	// fmt.Println(s) // For immediate feedback during development
 }
 
+// keywordAsNameError explains a syntax error at a keyword that is being used
+// as a name.  The source is parsed again with the keyword lexed as an
+// identifier and if that gets past it a name was expected there.
+func keywordAsNameError(src []byte, failed *Lexer) error {
+	if !failed.isKeyword {
+		return failed.lastError
+	}
+	retry := NewLexer(bytes.NewReader(src))
+	retry.keywordAsIdentAt = failed.tokenStart.Pos
+	if SDLParse(retry) != 0 && retry.tokenStart.Pos <= failed.tokenStart.Pos {
+		return failed.lastError
+	}
+	return &SyntaxError{
+		Pos:  failed.tokenStart,
+		Near: failed.tokenText,
+		Msg:  fmt.Sprintf("'%s' is a reserved keyword and cannot be used as a name here", failed.tokenText),
+	}
+}
+
 // Pos returns the start byte offset of the most recently lexed token.
 func (l *Lexer) Pos() Location {
 	return l.tokenStart
@@ -268,10 +321,16 @@ func (l *Lexer) scanIdentifierOrKeyword() (tok int, text string) {
 		return GO, text
 	case "gobatch":
 		return GOBATCH, text
+	case "spawn":
+		return SPAWN, text
 	case "aggregator":
 		return AGGREGATOR, text
 	case "using":
 		return USING, text
+	case "requireAll":
+		return REQUIREALL, text
+	case "anyOf":
+		return ANYOF, text
 	// case "log": return LOG, text
 	case "switch":
 		return SWITCH, text
@@ -281,12 +340,18 @@ func (l *Lexer) scanIdentifierOrKeyword() (tok int, text string) {
 		return ENUM, text
 	case "import":
 		return IMPORT, text
+	case "export":
+		return EXPORT, text
 	case "from":
 		return FROM, text
 	case "as":
 		return AS, text
 	case "options":
 		return OPTIONS, text
+	case "profile":
+		return PROFILE, text
+	case "scenario":
+		return SCENARIO, text
 	case "true":
 		return BOOL_LITERAL, text
 	case "false":
@@ -295,6 +360,8 @@ func (l *Lexer) scanIdentifierOrKeyword() (tok int, text string) {
 		return UNARY_OP, text
 	case "for":
 		return FOR, text
+	case "in":
+		return IN, text
 	default:
 		return IDENTIFIER, text
 	}
@@ -325,6 +392,51 @@ func (l *Lexer) scanNumber() (tok int, text string) {
 	return INT_LITERAL, text
 }
 
+// unitSuffix returns the longest of units the input continues with after
+// skipping offset runes, provided it is not followed by more of a name.
+func (l *Lexer) unitSuffix(offset int, units map[string]float64) (longest string) {
+	for unit := range units {
+		if len(unit) <= len(longest) {
+			continue
+		}
+		matched := true
+		for i, ch := range unit {
+			if l.peekN(offset+i) != ch {
+				matched = false
+				break
+			}
+		}
+		if next := l.peekN(offset + len(unit)); matched && next != '_' && !unicode.IsLetter(next) && !unicode.IsDigit(next) {
+			longest = unit
+		}
+	}
+	return
+}
+
+// unitLiteral consumes the unit of a rate or size literal and returns its
+// value in the base unit as a float literal, eg 3000/min is 50 per second
+// and 1KiB is 1024 bytes.
+func (l *Lexer) unitLiteral(numText, unit string, start Location, lval *SDLSymType) int {
+	for range len(unit) {
+		l.read()
+	}
+	l.tokenText += unit
+	value, err := strconv.ParseFloat(numText, 64)
+	if err != nil {
+		l.Error(fmt.Sprintf("Invalid number: %s", numText))
+	}
+	var lit *LiteralExpr
+	if per, isRate := strings.CutPrefix(unit, "/"); isRate {
+		lit = NewLiteralExpr(FloatValue(value/decl.RateUnits[per]), start, l.location)
+		lit.RateUnit = per
+	} else {
+		lit = NewLiteralExpr(FloatValue(value*decl.SizeUnits[unit]), start, l.location)
+		lit.SizeUnit = unit
+	}
+	lval.expr = lit
+	return FLOAT_LITERAL
+}
+
 func (l *Lexer) scanString() (tok int, content string) {
 	l.buf.Reset()
 	l.tokenText = "\""
@@ -368,7 +480,7 @@ func (l *Lexer) scanString() (tok int, content string) {
 
 // Lex is the main lexing function called by the parser.
 func (l *Lexer) Lex(lval *SDLSymType) int {
-	if l.skipWhitespace() {
+	if l.declErr != nil || l.skipWhitespace() {
 		l.lastTokenCode = eof
 		return eof
 	}
@@ -377,6 +489,7 @@ func (l *Lexer) Lex(lval *SDLSymType) int {
 	l.tokenStart.Line = l.location.Line
 	l.tokenStart.Col = l.location.Col
 	l.tokenText = "" // Reset for current token
+	l.isKeyword = false
 
 	r := l.peek()
 	if r == eof {
@@ -389,6 +502,10 @@ func (l *Lexer) Lex(lval *SDLSymType) int {
 	if unicode.IsLetter(r) || r == '_' {
 		tok, text := l.scanIdentifierOrKeyword()
 		l.tokenText = text
+		l.isKeyword = tok != IDENTIFIER
+		if l.isKeyword && startPosSnapshot.Pos == l.keywordAsIdentAt {
+			tok = IDENTIFIER
+		}
 		endPos := l.location
 
 		switch tok {
@@ -412,6 +529,14 @@ func (l *Lexer) Lex(lval *SDLSymType) int {
 		numEndPos := l.location
 		l.tokenText = numText
 
+		// Rates such as 50/s and sizes such as 10MB are normalized Floats
+		if unit := l.unitSuffix(1, decl.RateUnits); unit != "" && l.peek() == '/' {
+			return l.unitLiteral(numText, "/"+unit, startPosSnapshot, lval)
+		}
+		if unit := l.unitSuffix(0, decl.SizeUnits); unit != "" {
+			return l.unitLiteral(numText, unit, startPosSnapshot, lval)
+		}
+
 		unit := ""
 		if l.hasPrefix("ms", false) {
 			unit = "ms"
@@ -442,7 +567,9 @@ func (l *Lexer) Lex(lval *SDLSymType) int {
 				l.tokenText += unit
 				dur := parseDuration(numText, unit)
 				durVal, _ := NewValue(FloatType, dur)
-				lval.expr = NewLiteralExpr(durVal, startPosSnapshot, l.location)
+				lit := NewLiteralExpr(durVal, startPosSnapshot, l.location)
+				lit.DurationUnit = unit
+				lval.expr = lit
 				return DURATION_LITERAL
 			}
 		}
@@ -481,7 +608,7 @@ func (l *Lexer) Lex(lval *SDLSymType) int {
 
 	// Handle multi-character operators
 	switch r {
-	case ';', '{', '}', '(', ')', ',', '.', '[', ']':
+	case ';', '{', '}', '(', ')', ',', '.', '[', ']', '@':
 		l.read()
 		lval.node = NewTokenNode(startPosSnapshot, currentEndPos, l.tokenText)
 		return map[rune]int{
@@ -494,12 +621,13 @@ func (l *Lexer) Lex(lval *SDLSymType) int {
 			')': RPAREN,
 			',': COMMA,
 			'.': DOT,
+			'@': AT,
 		}[r]
 	default:
 	}
 
 	// Collect all operator characters
-	opchars := "<>&^%$#@!*~=/|:+-"
+	opchars := "<>&^%$#!*~=/|:+-"
 	var out []rune
 	for l.peek() > 0 && strings.IndexRune(opchars, l.peek()) >= 0 {
 		out = append(out, l.peek())
@@ -558,6 +686,7 @@ var testTokenNames = map[int]string{
 	WAIT:             "WAIT",
 	GO:               "GO",
 	GOBATCH:          "GOBATCH",
+	SPAWN:            "SPAWN",
 	AGGREGATOR:       "AGGREGATOR",
 	USING:            "USING",
 	// LOG:              "LOG",
@@ -565,9 +694,11 @@ var testTokenNames = map[int]string{
 	CASE:       "CASE",
 	ENUM:       "ENUM",
 	IMPORT:     "IMPORT",
+	EXPORT:     "EXPORT",
 	FROM:       "FROM",
 	AS:         "AS",
 	OPTIONS:    "OPTIONS",
+	PROFILE:    "PROFILE",
 	FOR:        "FOR",
 	INT:        "int", // Keyword for type
 	FLOAT:      "float",
@@ -585,6 +716,7 @@ var testTokenNames = map[int]string{
 	RPAREN:     "RPAREN",
 	COMMA:      "COMMA",
 	DOT:        "DOT",
+	AT:         "AT",
 	ARROW:      "ARROW",
 	LET_ASSIGN: "LET_ASSIGN",
 	BINARY_OP:  "BINARY_OP",
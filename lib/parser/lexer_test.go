@@ -98,7 +98,7 @@ func TestLexer_KeywordsAndIdentifiers(t *testing.T) {
 }
 
 func TestLexer_Literals(t *testing.T) {
-	input := `123 45.67 "hello world" true false 10ms 5s 100us 20ns 1.5s`
+	input := `123 45.67 "hello world" true false 10ms 5s 100us 20ns 1.5s 0ms 0s`
 	expected := []expectedToken{
 		{INT_LITERAL, "123", 0, 3, 1, 1, IntValue(123), ""},
 		{FLOAT_LITERAL, "45.67", 4, 9, 1, 5, FloatValue(45.67), ""},
@@ -110,6 +110,8 @@ func TestLexer_Literals(t *testing.T) {
 		{DURATION_LITERAL, "100us", 43, 48, 1, 44, FloatValue(parseDuration("100", "us")), ""},
 		{DURATION_LITERAL, "20ns", 49, 53, 1, 50, FloatValue(parseDuration("20", "ns")), ""},
 		{DURATION_LITERAL, "1.5s", 54, 58, 1, 55, FloatValue(parseDuration("1.5", "s")), ""},
+		{DURATION_LITERAL, "0ms", 59, 62, 1, 60, FloatValue(0), ""},
+		{DURATION_LITERAL, "0s", 63, 65, 1, 64, FloatValue(0), ""},
 	}
 	runLexerTest(t, input, expected, false)
 }
@@ -263,6 +265,29 @@ func TestLexer_ComplexDurations(t *testing.T) {
 	runLexerTest(t, input2, expected2, true)
 }
 
+func TestLexer_RatesAndSizes(t *testing.T) {
+	input := `50/s 3000/min 10MB 10MiB 1.5GB 10/sec`
+	expected := []expectedToken{
+		{FLOAT_LITERAL, "50/s", 0, 4, 1, 1, FloatValue(50), ""},
+		{FLOAT_LITERAL, "3000/min", 5, 13, 1, 6, FloatValue(50), ""},
+		{FLOAT_LITERAL, "10MB", 14, 18, 1, 15, FloatValue(10_000_000), ""},
+		{FLOAT_LITERAL, "10MiB", 19, 24, 1, 20, FloatValue(10 * 1024 * 1024), ""},
+		{FLOAT_LITERAL, "1.5GB", 25, 30, 1, 26, FloatValue(1.5e9), ""},
+		// Not a rate unit so this stays a division
+		{INT_LITERAL, "10", 31, 33, 1, 32, IntValue(10), ""},
+		{BINARY_OP, "/", 33, 34, 1, 34, Nil, ""},
+		{IDENTIFIER, "sec", 34, 37, 1, 35, Nil, "sec"},
+	}
+	runLexerTest(t, input, expected, false)
+
+	lexer := NewLexer(strings.NewReader("3000/min 1KiB"))
+	lval := &SDLSymType{}
+	lexer.Lex(lval)
+	assert.Equal(t, "min", lval.expr.(*LiteralExpr).RateUnit)
+	lexer.Lex(lval)
+	assert.Equal(t, "KiB", lval.expr.(*LiteralExpr).SizeUnit)
+}
+
 func TestLexer_DivisionAndMultilineComments(t *testing.T) {
 	input := "a / b /* comment * test */ c /**/ d"
 	expected := []expectedToken{
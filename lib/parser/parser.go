@@ -9,6 +9,7 @@ import __yyfmt__ "fmt"
 
 import (
 	// "reflect"
+	"bytes"
 	"fmt"
 	"io"
 	"log"
@@ -29,7 +30,7 @@ func yyerrok(lexer SDLLexer) {
 	///ErrFlag = 0
 }
 
-//line grammar.y:30
+//line grammar.y:31
 type SDLSymType struct {
 	yys int
 	// Basic types from lexer
@@ -42,13 +43,15 @@ type SDLSymType struct {
 	aggregatorDecl *AggregatorDecl
 	node           Node // Generic interface for lists and for accessing NodeInfo
 	// tokenNode   TokenNode // Generic interface for lists and for accessing NodeInfo
-	expr        Expr
-	chainedExpr *ChainedExpr
-	stmt        Stmt
-	typeDecl    *TypeDecl
-	paramDecl   *ParamDecl
-	usesDecl    *UsesDecl
-	methodDef   *MethodDecl
+	expr            Expr
+	chainedExpr     *ChainedExpr
+	stmt            Stmt
+	typeDecl        *TypeDecl
+	paramDecl       *ParamDecl
+	paramConstraint *ParamConstraint
+	usesDecl        *UsesDecl
+	methodDef       *MethodDecl
+	profileDecl     *ProfileDecl
 	// instanceDecl removed: InstanceDecl no longer in grammar
 	analyzeDecl *AnalyzeDecl
 	expectBlock *ExpectationsDecl
@@ -62,14 +65,16 @@ type SDLSymType struct {
 	switchStmt *SwitchStmt
 	caseStmt   *CaseStmt
 
-	tupleExpr  *TupleExpr
-	goExpr     *GoExpr
-	forStmt    *ForStmt
-	assignStmt *AssignmentStmt
-	// optionsDecl removed
-	enumDecl   *EnumDecl
-	importDecl *ImportDecl
-	waitExpr   *WaitExpr
+	tupleExpr     *TupleExpr
+	goExpr        *GoExpr
+	forStmt       *ForStmt
+	assignStmt    *AssignmentStmt
+	optionsDecl   *OptionsDecl
+	scenarioDecl  *ScenarioDecl
+	scenarioItems []Node
+	enumDecl      *EnumDecl
+	importDecl    *ImportDecl
+	waitExpr      *WaitExpr
 	// delayStmt *DelayStmt
 	sampleExpr *SampleExpr
 
@@ -92,6 +97,10 @@ type SDLSymType struct {
 	distributeExprCaseList []*CaseExpr
 	expectStmtList         []*ExpectStmt
 	methodSigItemList      []*MethodDecl
+	annotation             *Annotation
+	annotationList         []*Annotation
+	letPattern             *LetPattern
+	letPatternList         []*LetPattern
 
 	// Add field to store position for simple tokens if needed
 	// posInfo     NodeInfo
@@ -115,60 +124,68 @@ const WAIT = 57360
 const GO = 57361
 const GOBATCH = 57362
 const USING = 57363
-const SWITCH = 57364
-const CASE = 57365
-const FOR = 57366
-const USE = 57367
-const NATIVE = 57368
-const LSQUARE = 57369
-const RSQUARE = 57370
-const LBRACE = 57371
-const RBRACE = 57372
-const OPTIONS = 57373
-const ENUM = 57374
-const COMPONENT = 57375
-const PARAM = 57376
-const IMPORT = 57377
-const FROM = 57378
-const AS = 57379
-const ASSIGN = 57380
-const COLON = 57381
-const LPAREN = 57382
-const RPAREN = 57383
-const COMMA = 57384
-const DOT = 57385
-const ARROW = 57386
-const LET_ASSIGN = 57387
-const SEMICOLON = 57388
-const INT = 57389
-const FLOAT = 57390
-const BOOL = 57391
-const STRING = 57392
-const DURATION = 57393
-const INT_LITERAL = 57394
-const FLOAT_LITERAL = 57395
-const STRING_LITERAL = 57396
-const BOOL_LITERAL = 57397
-const DURATION_LITERAL = 57398
-const IDENTIFIER = 57399
-const OR = 57400
-const AND = 57401
-const EQ = 57402
-const NEQ = 57403
-const LT = 57404
-const LTE = 57405
-const GT = 57406
-const GTE = 57407
-const PLUS = 57408
-const MUL = 57409
-const DIV = 57410
-const MOD = 57411
-const DUAL_OP = 57412
-const BINARY_NC_OP = 57413
-const BINARY_OP = 57414
-const UNARY_OP = 57415
-const MINUS = 57416
-const UMINUS = 57417
+const REQUIREALL = 57364
+const ANYOF = 57365
+const SWITCH = 57366
+const CASE = 57367
+const FOR = 57368
+const IN = 57369
+const SCENARIO = 57370
+const SPAWN = 57371
+const USE = 57372
+const NATIVE = 57373
+const LSQUARE = 57374
+const RSQUARE = 57375
+const LBRACE = 57376
+const RBRACE = 57377
+const OPTIONS = 57378
+const PROFILE = 57379
+const ENUM = 57380
+const COMPONENT = 57381
+const PARAM = 57382
+const IMPORT = 57383
+const FROM = 57384
+const AS = 57385
+const EXPORT = 57386
+const ASSIGN = 57387
+const COLON = 57388
+const LPAREN = 57389
+const RPAREN = 57390
+const COMMA = 57391
+const DOT = 57392
+const ARROW = 57393
+const LET_ASSIGN = 57394
+const SEMICOLON = 57395
+const AT = 57396
+const INT = 57397
+const FLOAT = 57398
+const BOOL = 57399
+const STRING = 57400
+const DURATION = 57401
+const INT_LITERAL = 57402
+const FLOAT_LITERAL = 57403
+const STRING_LITERAL = 57404
+const BOOL_LITERAL = 57405
+const DURATION_LITERAL = 57406
+const IDENTIFIER = 57407
+const OR = 57408
+const AND = 57409
+const EQ = 57410
+const NEQ = 57411
+const LT = 57412
+const LTE = 57413
+const GT = 57414
+const GTE = 57415
+const PLUS = 57416
+const MUL = 57417
+const DIV = 57418
+const MOD = 57419
+const DUAL_OP = 57420
+const BINARY_NC_OP = 57421
+const BINARY_OP = 57422
+const UNARY_OP = 57423
+const MINUS = 57424
+const UMINUS = 57425
 
 var SDLToknames = [...]string{
 	"$end",
@@ -192,9 +209,14 @@ var SDLToknames = [...]string{
 	"GO",
 	"GOBATCH",
 	"USING",
+	"REQUIREALL",
+	"ANYOF",
 	"SWITCH",
 	"CASE",
 	"FOR",
+	"IN",
+	"SCENARIO",
+	"SPAWN",
 	"USE",
 	"NATIVE",
 	"LSQUARE",
@@ -202,12 +224,14 @@ var SDLToknames = [...]string{
 	"LBRACE",
 	"RBRACE",
 	"OPTIONS",
+	"PROFILE",
 	"ENUM",
 	"COMPONENT",
 	"PARAM",
 	"IMPORT",
 	"FROM",
 	"AS",
+	"EXPORT",
 	"ASSIGN",
 	"COLON",
 	"LPAREN",
@@ -217,6 +241,7 @@ var SDLToknames = [...]string{
 	"ARROW",
 	"LET_ASSIGN",
 	"SEMICOLON",
+	"AT",
 	"INT",
 	"FLOAT",
 	"BOOL",
@@ -254,7 +279,7 @@ const SDLEofCode = 1
 const SDLErrCode = 2
 const SDLInitialStackSize = 16
 
-//line grammar.y:889
+//line grammar.y:1131
 // --- Go Code Section ---
 
 // Interface for the lexer required by the parser.
@@ -271,8 +296,11 @@ type LexerInterface interface {
 // Parse takes an input stream and attempts to parse it according to the SDL grammar. 22222
 // It returns the root of the Abstract Syntax Tree (*FileDecl) if successful, or an error.
 func Parse(input io.Reader) (*Lexer, *FileDecl, error) {
-	// Reset global result before parsing
-	lexer := NewLexer(input)
+	src, err := io.ReadAll(input)
+	if err != nil {
+		return nil, nil, err
+	}
+	lexer := NewLexer(bytes.NewReader(src))
 	// Set yyDebug = 3 for verbose parser debugging output
 	// yyDebug = 3
 	resultCode := SDLParse(lexer) // Call the LALR parser generated by goyacc
@@ -281,7 +309,7 @@ func Parse(input io.Reader) (*Lexer, *FileDecl, error) {
 		// A syntax error occurred. The lexer's Error method should have been called
 		// and stored the error message.
 		if lexer.lastError != nil {
-			return lexer, nil, lexer.lastError
+			return lexer, nil, keywordAsNameError(src, lexer)
 		}
 		// Fallback error message if lexer didn't store one
 		return lexer, nil, fmt.Errorf("syntax error near byte %d (Line %d, Col %d)", lexer.location.Pos, lexer.location.Line, lexer.location.Col)
@@ -296,6 +324,26 @@ func Parse(input io.Reader) (*Lexer, *FileDecl, error) {
 	return lexer, lexer.parseResult, nil
 }
 
+// ParseStream parses input and calls onDecl with each top level declaration
+// as soon as it is parsed instead of collecting them into a FileDecl, so that
+// memory use is bounded by the largest declaration rather than the whole
+// file.  Parsing stops at the first error returned by onDecl.
+func ParseStream(input io.Reader, onDecl func(Node) error) error {
+	lexer := NewLexer(input)
+	lexer.onDecl = onDecl
+	resultCode := SDLParse(lexer)
+	if lexer.declErr != nil {
+		return lexer.declErr
+	}
+	if resultCode != 0 {
+		if lexer.lastError != nil {
+			return lexer.lastError
+		}
+		return fmt.Errorf("syntax error near byte %d (Line %d, Col %d)", lexer.location.Pos, lexer.location.Line, lexer.location.Col)
+	}
+	return nil
+}
+
 // The parser expects the lexer variable to be named yyLex.
 // We can satisfy this by creating a global or passing it via SDLParseWithLexer.
 // Using SDLParseWithLexer is cleaner.
@@ -332,199 +380,254 @@ var SDLExca = [...]int16{
 	-1, 1,
 	1, -1,
 	-2, 0,
-	-1, 77,
-	40, 108,
-	-2, 149,
+	-1, 107,
+	47, 152,
+	-2, 193,
 }
 
 const SDLPrivate = 57344
 
-const SDLLast = 478
-
-var SDLAct = [...]uint8{
-	198, 131, 237, 128, 197, 124, 178, 170, 176, 168,
-	56, 113, 114, 133, 173, 55, 53, 156, 125, 157,
-	73, 169, 24, 214, 105, 57, 73, 43, 61, 154,
-	153, 135, 54, 106, 25, 67, 66, 72, 39, 20,
-	27, 96, 95, 72, 26, 23, 80, 126, 127, 22,
-	21, 37, 251, 248, 230, 220, 224, 77, 192, 79,
-	99, 78, 119, 118, 62, 191, 190, 71, 97, 118,
-	76, 249, 68, 151, 210, 107, 189, 188, 241, 104,
-	90, 91, 92, 93, 94, 83, 103, 110, 62, 102,
-	186, 116, 13, 98, 99, 205, 121, 132, 134, 144,
-	145, 129, 130, 28, 145, 99, 138, 163, 70, 29,
-	42, 221, 142, 136, 9, 204, 149, 164, 152, 100,
-	14, 12, 69, 11, 143, 141, 33, 159, 160, 147,
-	96, 95, 115, 158, 3, 139, 35, 32, 165, 228,
-	206, 137, 77, 77, 79, 79, 78, 78, 161, 162,
-	111, 30, 65, 193, 51, 79, 76, 97, 187, 63,
-	16, 202, 250, 229, 203, 101, 48, 201, 49, 90,
-	91, 92, 93, 94, 83, 199, 200, 17, 15, 183,
-	64, 19, 208, 209, 211, 212, 47, 50, 227, 213,
-	58, 207, 108, 215, 12, 47, 155, 108, 109, 36,
-	194, 219, 34, 31, 16, 117, 112, 223, 246, 218,
-	77, 38, 79, 234, 78, 222, 225, 46, 226, 123,
-	6, 231, 244, 245, 235, 232, 236, 177, 238, 239,
-	216, 240, 217, 84, 195, 196, 120, 238, 148, 247,
-	166, 243, 167, 242, 174, 45, 44, 52, 122, 87,
-	77, 81, 79, 77, 78, 79, 252, 78, 89, 253,
-	96, 95, 88, 82, 86, 80, 126, 127, 96, 95,
-	140, 85, 175, 80, 126, 127, 108, 172, 233, 18,
-	5, 10, 59, 60, 40, 41, 8, 97, 7, 4,
-	75, 2, 96, 95, 1, 97, 146, 0, 0, 90,
-	91, 92, 93, 94, 83, 0, 0, 90, 91, 92,
-	93, 94, 150, 0, 0, 0, 0, 96, 95, 97,
-	129, 130, 80, 126, 127, 0, 0, 0, 129, 130,
-	0, 90, 91, 92, 93, 94, 83, 0, 0, 0,
-	0, 0, 0, 0, 97, 0, 0, 0, 0, 0,
-	0, 0, 129, 130, 0, 0, 90, 91, 92, 93,
-	94, 83, 180, 183, 0, 96, 95, 0, 182, 0,
-	80, 0, 0, 0, 184, 0, 181, 129, 130, 0,
-	0, 108, 171, 180, 183, 0, 96, 95, 0, 182,
-	0, 80, 97, 0, 0, 184, 0, 181, 179, 0,
-	0, 0, 108, 0, 90, 91, 92, 93, 94, 83,
-	0, 0, 0, 97, 0, 96, 95, 0, 0, 179,
-	80, 0, 0, 0, 0, 90, 91, 92, 93, 94,
-	83, 0, 185, 96, 95, 0, 0, 0, 80, 0,
-	0, 0, 97, 0, 0, 0, 0, 0, 0, 0,
-	74, 0, 0, 0, 90, 91, 92, 93, 94, 83,
-	97, 0, 0, 0, 0, 0, 0, 0, 0, 0,
-	0, 0, 90, 91, 92, 93, 94, 83,
+const SDLLast = 658
+
+var SDLAct = [...]int16{
+	199, 141, 271, 270, 134, 322, 168, 229, 317, 138,
+	236, 44, 215, 223, 58, 180, 135, 160, 159, 232,
+	42, 75, 74, 72, 29, 56, 85, 171, 149, 172,
+	101, 324, 307, 238, 273, 101, 30, 304, 288, 206,
+	205, 45, 170, 76, 161, 73, 280, 150, 100, 126,
+	81, 285, 272, 100, 111, 136, 137, 33, 31, 96,
+	94, 91, 90, 51, 23, 32, 27, 26, 25, 68,
+	63, 52, 49, 67, 86, 55, 107, 282, 341, 108,
+	131, 337, 143, 127, 110, 203, 201, 202, 293, 264,
+	130, 99, 126, 81, 104, 286, 121, 122, 123, 124,
+	125, 114, 302, 166, 95, 315, 299, 284, 148, 296,
+	86, 146, 147, 129, 327, 254, 253, 139, 140, 62,
+	69, 67, 156, 251, 309, 165, 127, 167, 169, 152,
+	89, 79, 165, 163, 93, 92, 165, 174, 175, 121,
+	122, 123, 124, 125, 114, 66, 311, 255, 252, 251,
+	183, 65, 165, 14, 61, 298, 176, 177, 191, 299,
+	139, 140, 179, 291, 248, 204, 187, 128, 69, 197,
+	173, 193, 194, 126, 81, 189, 212, 192, 190, 216,
+	194, 129, 217, 295, 37, 225, 269, 34, 209, 210,
+	107, 35, 107, 108, 35, 108, 224, 207, 110, 220,
+	110, 228, 98, 78, 268, 257, 246, 127, 259, 211,
+	104, 208, 249, 110, 144, 216, 97, 40, 70, 266,
+	121, 122, 123, 124, 125, 114, 267, 263, 256, 184,
+	39, 162, 154, 265, 47, 182, 319, 308, 157, 115,
+	79, 46, 274, 276, 278, 279, 281, 36, 19, 340,
+	244, 292, 287, 181, 87, 227, 289, 290, 20, 18,
+	218, 22, 219, 328, 145, 294, 82, 88, 77, 153,
+	225, 305, 195, 153, 178, 155, 107, 300, 151, 108,
+	48, 224, 301, 297, 110, 306, 80, 61, 41, 38,
+	28, 19, 258, 164, 310, 158, 312, 50, 182, 313,
+	343, 335, 314, 316, 262, 81, 323, 318, 325, 326,
+	133, 333, 105, 303, 21, 11, 60, 334, 107, 6,
+	332, 108, 323, 320, 329, 338, 110, 336, 24, 330,
+	126, 81, 321, 237, 260, 111, 136, 137, 261, 107,
+	213, 214, 108, 107, 346, 342, 108, 110, 344, 345,
+	142, 110, 331, 126, 81, 198, 221, 222, 111, 136,
+	137, 43, 126, 81, 127, 233, 339, 111, 136, 137,
+	277, 64, 59, 185, 153, 186, 57, 121, 122, 123,
+	124, 125, 114, 71, 132, 126, 81, 127, 118, 112,
+	111, 136, 137, 120, 119, 113, 127, 196, 139, 140,
+	121, 122, 123, 124, 125, 114, 117, 188, 116, 121,
+	122, 123, 124, 125, 200, 235, 234, 231, 5, 127,
+	10, 139, 140, 283, 250, 106, 83, 84, 53, 54,
+	139, 140, 121, 122, 123, 124, 125, 114, 126, 81,
+	8, 7, 15, 111, 136, 137, 4, 103, 2, 1,
+	126, 81, 0, 139, 140, 111, 136, 137, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 9,
+	0, 0, 127, 0, 17, 0, 16, 14, 0, 12,
+	0, 0, 13, 0, 127, 121, 122, 123, 124, 125,
+	226, 3, 0, 0, 0, 0, 0, 121, 122, 123,
+	124, 125, 275, 0, 0, 0, 139, 140, 240, 244,
+	0, 126, 81, 0, 242, 0, 111, 0, 139, 140,
+	0, 0, 245, 0, 241, 0, 0, 243, 0, 0,
+	0, 0, 153, 230, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 127, 0, 0, 0, 0,
+	0, 239, 0, 0, 0, 0, 0, 0, 121, 122,
+	123, 124, 125, 114, 240, 244, 0, 126, 81, 0,
+	242, 0, 111, 0, 126, 81, 0, 0, 245, 111,
+	241, 0, 0, 243, 0, 0, 0, 0, 153, 109,
+	0, 0, 0, 0, 0, 0, 247, 17, 0, 0,
+	0, 127, 0, 0, 0, 126, 81, 239, 127, 0,
+	111, 0, 0, 0, 121, 122, 123, 124, 125, 114,
+	109, 121, 122, 123, 124, 125, 114, 102, 17, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 127,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 121, 122, 123, 124, 125, 114,
 }
 
 var SDLPact = [...]int16{
-	-1000, -1000, 88, -1000, -1000, -1000, -1000, -1000, -1000, 171,
-	-1000, -18, -7, -8, -12, -23, -13, -23, 67, -1000,
-	114, 174, 97, 173, -1000, 96, 170, -1000, -3, -18,
-	-19, 161, -25, -1000, -32, -25, 152, -1000, -1000, -1000,
-	150, 161, -1000, -1000, -1000, -1000, -1000, -21, -22, -23,
-	127, 81, 66, -1000, -20, 420, 63, -1000, 78, 135,
-	152, -1000, -1000, -23, -1000, -1000, -14, -24, 163, 169,
-	-25, 112, 179, -20, -1000, -1000, -1000, -1000, -1000, 92,
-	-32, 178, -1000, 26, -1000, -1000, -1000, -1000, 19, -1000,
-	-1000, -1000, -1000, -1000, -1000, 304, 304, 304, -1000, -26,
-	-20, -1000, -1000, -1000, 103, 304, 95, -1000, -1000, -1000,
-	-1000, 304, -20, 58, -1000, 255, 52, 304, -27, -28,
-	167, -1000, -55, -1000, -1000, -1000, 247, 304, 92, 279,
-	279, -1000, -1000, 65, 76, -1000, -1000, 304, -1000, -36,
-	352, 402, -1000, 62, -1000, -20, -1000, 35, 24, -1000,
-	20, 117, 172, -1000, -1000, 304, 279, 279, -1000, -1000,
-	247, -1000, -1000, 304, -1000, -1000, 74, 53, -1000, 102,
-	-1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000,
-	-32, 304, 28, 304, 304, -1000, -1000, -1000, 304, -1000,
-	-34, -1000, 304, -1000, -1000, 194, 304, -1000, 11, -1000,
-	-1000, -1000, -1000, 70, -1000, -36, 304, 18, 373, -1000,
-	-1000, 163, 159, -1000, 101, -1000, 133, -1000, 10, -1000,
-	304, -1000, -1000, -1000, 304, -1000, 201, 304, 304, -1000,
-	304, 36, -1000, -1000, 168, 193, 304, -1000, 9, -1000,
-	29, -1000, -1000, -1000, 132, -1000, 8, -1000, 373, -1000,
-	-1000, 373, -1000, -1000,
+	-32768, -32768, 438, -32768, -32768, -32768, -32768, -32768, -32768, 252,
+	-32768, -32768, -1, -1, 3, 2, 1, 256, -7, 0,
+	-7, 145, -32768, 204, 142, 255, 183, 254, -24, -32768,
+	196, 187, 246, -32768, 10, -1, -2, 9, 114, -20,
+	-32768, -22, 233, 154, -32768, 195, 291, -20, 247, -32768,
+	-32768, -32768, -32768, 232, 114, -32768, -32768, -32768, -32768, -32768,
+	-32768, -3, -4, -32768, 66, -5, 209, -7, -32768, -6,
+	168, 153, -32768, -12, 592, 132, -32768, -32768, -24, 372,
+	-32768, 372, 166, 229, 247, -32768, -32768, -7, -32768, -32768,
+	-17, -18, -32768, -32768, 244, 235, 185, 241, -20, 193,
+	263, -12, -32768, -32768, -32768, -32768, -32768, -32768, -32768, -21,
+	184, -22, 261, -32768, 82, -32768, -32768, -32768, -32768, 53,
+	-32768, -32768, -32768, -32768, -32768, -32768, 372, 372, -32768, -23,
+	-32768, -32768, -53, -32768, -32768, -32768, 340, 372, 184, 79,
+	79, -32768, 240, -32768, -12, -32768, -32768, -32768, 208, 372,
+	182, 66, -32768, -32768, -24, -32768, -32768, 372, -12, 123,
+	-32768, 238, 349, 64, 372, -25, -26, -32768, 148, 163,
+	-32768, 79, 79, -32768, -32768, 340, -32768, -32768, 372, -32768,
+	-32768, 372, 228, 271, 425, 220, 66, -32768, 498, 158,
+	561, -32768, 131, -32768, -12, -32768, -32768, 100, 67, -32768,
+	102, -32768, 181, 160, 259, -32768, -32768, 372, -32768, -32768,
+	-32768, -32768, -32768, 289, 372, -32768, 38, 271, 372, 372,
+	-32768, 156, 137, -32768, -32768, -32768, 86, -32768, -32768, -32768,
+	-32768, -32768, -32768, -32768, -32768, -32768, -32768, -32768, -32768, -32768,
+	-13, 437, 317, 372, 36, 372, -32768, -32768, -32768, -32768,
+	42, 372, -32768, -27, -32768, 372, 372, -32768, -32768, 115,
+	216, -32768, 37, -32768, 372, -32768, 134, 74, -32768, 425,
+	110, -32768, -32768, -13, 554, 75, -32768, -32768, -32768, 235,
+	-28, 237, -32768, -32768, 372, -33, -32768, -32768, 192, -32768,
+	76, -32768, -32768, 372, 97, 372, -32768, -32768, 372, -13,
+	57, -32768, 372, 295, 191, 372, -34, 372, 372, -32768,
+	65, -32768, 230, -32768, -32768, -32768, 554, -32768, 239, 372,
+	286, 372, -32768, 30, 372, -32768, -32768, -32768, -32768, -32768,
+	-32768, -32768, 235, 214, -32768, 27, -32768, 554, 274, 295,
+	-32768, 554, -32768, 372, -32768, -32768, -32768,
 }
 
 var SDLPgo = [...]int16{
-	0, 294, 291, 290, 289, 217, 288, 286, 110, 285,
-	284, 28, 283, 282, 15, 281, 10, 181, 280, 279,
-	7, 278, 277, 14, 272, 271, 6, 270, 264, 0,
-	18, 3, 263, 1, 262, 258, 251, 249, 5, 248,
-	27, 16, 247, 154, 12, 11, 246, 245, 22, 244,
-	9, 242, 240, 8, 13, 238, 236, 4, 235, 234,
-	233, 232, 230, 227, 2, 226, 224, 223, 222, 219,
+	0, 449, 448, 447, 446, 316, 441, 440, 75, 429,
+	428, 26, 427, 426, 22, 312, 425, 424, 423, 420,
+	21, 2, 3, 261, 418, 314, 7, 8, 417, 19,
+	416, 415, 408, 33, 407, 406, 0, 16, 9, 395,
+	1, 394, 393, 389, 388, 4, 384, 25, 23, 15,
+	383, 218, 17, 18, 376, 70, 36, 24, 14, 375,
+	373, 372, 69, 371, 365, 11, 361, 20, 357, 356,
+	13, 10, 6, 355, 350, 12, 341, 340, 239, 338,
+	334, 333, 5, 332, 323, 317, 311, 310,
 }
 
 var SDLR1 = [...]int8{
 	0, 1, 2, 2, 2, 2, 4, 4, 4, 4,
-	4, 5, 5, 15, 16, 16, 18, 19, 19, 17,
-	17, 48, 48, 13, 13, 12, 12, 11, 11, 10,
-	10, 9, 9, 8, 8, 8, 8, 40, 40, 40,
-	44, 44, 44, 45, 45, 46, 46, 47, 43, 43,
-	42, 42, 41, 41, 6, 6, 7, 14, 14, 3,
-	52, 52, 51, 51, 50, 27, 27, 20, 20, 20,
-	20, 20, 20, 20, 20, 26, 49, 22, 24, 24,
-	38, 38, 55, 55, 54, 54, 53, 21, 21, 21,
-	25, 56, 56, 28, 69, 69, 69, 69, 29, 29,
-	29, 39, 39, 39, 30, 30, 30, 31, 31, 36,
-	36, 36, 36, 36, 36, 36, 36, 37, 32, 32,
-	32, 32, 32, 35, 34, 34, 33, 33, 33, 60,
-	59, 59, 58, 58, 57, 57, 62, 62, 61, 61,
-	63, 66, 66, 65, 65, 64, 68, 68, 67, 23,
-	23,
+	4, 4, 15, 5, 5, 19, 20, 20, 24, 24,
+	25, 25, 23, 23, 56, 56, 57, 57, 13, 13,
+	12, 12, 11, 11, 10, 10, 9, 9, 8, 8,
+	8, 8, 8, 58, 58, 61, 60, 60, 59, 59,
+	63, 63, 62, 62, 47, 47, 47, 49, 49, 49,
+	52, 52, 52, 53, 53, 54, 54, 69, 69, 68,
+	68, 70, 70, 55, 51, 51, 50, 50, 48, 48,
+	6, 6, 7, 14, 14, 3, 3, 3, 16, 17,
+	17, 18, 18, 18, 67, 67, 66, 66, 65, 34,
+	34, 26, 26, 26, 26, 26, 26, 26, 26, 26,
+	33, 64, 64, 28, 22, 22, 21, 21, 30, 30,
+	31, 45, 45, 45, 45, 73, 73, 72, 72, 71,
+	71, 27, 27, 27, 32, 74, 74, 35, 87, 87,
+	87, 87, 36, 36, 36, 46, 46, 46, 37, 37,
+	37, 38, 38, 43, 43, 43, 43, 43, 43, 43,
+	43, 44, 39, 39, 39, 39, 39, 42, 41, 41,
+	40, 40, 40, 78, 77, 77, 76, 76, 75, 75,
+	80, 80, 79, 79, 81, 84, 84, 83, 83, 82,
+	86, 86, 85, 29, 29,
 }
 
 var SDLR2 = [...]int8{
 	0, 1, 0, 2, 2, 2, 1, 1, 1, 3,
-	1, 6, 5, 5, 1, 3, 4, 1, 3, 1,
-	3, 4, 5, 0, 1, 1, 2, 1, 2, 0,
-	1, 1, 2, 1, 1, 1, 1, 3, 4, 5,
-	1, 3, 4, 1, 3, 3, 6, 3, 0, 1,
-	1, 3, 2, 4, 8, 5, 3, 0, 2, 1,
-	0, 1, 1, 3, 3, 0, 2, 1, 1, 1,
-	1, 1, 1, 1, 1, 3, 3, 4, 2, 2,
-	2, 4, 3, 5, 1, 3, 4, 0, 2, 2,
-	2, 0, 1, 5, 2, 2, 3, 3, 1, 1,
-	1, 1, 3, 3, 1, 2, 2, 1, 1, 1,
-	1, 1, 1, 1, 1, 1, 1, 3, 1, 1,
-	1, 1, 1, 4, 3, 3, 3, 4, 4, 6,
-	0, 1, 1, 2, 3, 4, 0, 1, 3, 4,
-	6, 0, 1, 1, 2, 3, 0, 1, 3, 1,
-	1,
+	1, 1, 4, 6, 5, 5, 1, 3, 4, 4,
+	1, 3, 1, 3, 4, 5, 1, 3, 0, 1,
+	1, 2, 1, 2, 0, 1, 1, 2, 1, 1,
+	1, 1, 1, 1, 2, 5, 0, 1, 1, 2,
+	1, 2, 2, 5, 4, 5, 6, 0, 6, 4,
+	1, 3, 4, 1, 3, 3, 6, 0, 1, 1,
+	3, 1, 1, 3, 0, 1, 1, 3, 2, 4,
+	8, 5, 3, 0, 2, 1, 1, 1, 5, 0,
+	2, 6, 3, 1, 0, 1, 1, 3, 3, 0,
+	2, 1, 1, 1, 1, 1, 1, 1, 1, 1,
+	3, 3, 5, 4, 1, 3, 1, 3, 2, 2,
+	2, 2, 3, 6, 4, 3, 5, 1, 3, 4,
+	7, 0, 2, 2, 2, 0, 1, 5, 2, 2,
+	3, 3, 1, 1, 1, 1, 3, 3, 1, 2,
+	2, 1, 1, 1, 1, 1, 1, 1, 1, 1,
+	1, 3, 1, 1, 1, 1, 1, 4, 3, 3,
+	3, 4, 4, 6, 0, 1, 1, 2, 3, 4,
+	0, 1, 3, 4, 6, 0, 1, 1, 2, 3,
+	0, 1, 3, 1, 1,
 }
 
 var SDLChk = [...]int16{
-	-1000, -1, -2, 46, -4, -18, -5, -6, -7, 26,
-	-15, 35, 33, 4, 32, 7, 33, 6, -19, -17,
-	57, 57, 57, 57, -48, 57, 57, -48, 36, 42,
-	37, 29, 40, 29, 29, 40, 29, 54, -17, 57,
-	-10, -9, -8, -40, -46, -47, -5, 34, 5, 7,
-	26, -43, -42, -41, 57, -14, -16, 57, -43, -13,
-	-12, -11, -40, 7, 30, -8, 57, 57, -48, 41,
-	42, -44, 57, 40, 30, -3, -23, -33, -38, -31,
-	18, -36, -32, 57, -60, -25, -28, -37, -34, -35,
-	52, 53, 54, 55, 56, 14, 13, 40, 30, 42,
-	41, 30, -11, -48, -44, 38, 57, -26, 29, 29,
-	-41, 38, 27, -45, -44, 40, -16, 27, 43, 43,
-	-56, -29, -39, -69, -38, -30, 19, 20, -31, 73,
-	74, -33, -29, -54, -29, 57, -44, 38, -29, 40,
-	-27, -14, -29, -45, 41, 42, 41, -54, -55, -29,
-	57, 21, -29, 57, 57, 29, 72, 74, -26, -29,
-	-29, -30, -30, 42, 41, -29, -52, -51, -50, 57,
-	-20, 30, -22, -23, -49, -24, -53, -63, -26, 46,
-	10, 24, 16, 11, 22, 30, 28, -44, 42, 41,
-	42, 41, 38, -33, 28, -59, -58, -57, -29, -30,
-	-30, -26, -29, -29, 41, 42, 38, -16, -29, -29,
-	46, -29, -29, -29, 57, -29, -62, -61, 15, -57,
-	44, 41, -50, -29, 38, -20, -26, 29, 38, 30,
-	44, -29, -29, -21, 12, -66, -65, -64, -29, -29,
-	-29, 42, -53, -26, -68, -67, 15, -64, 44, 42,
-	30, 44, -20, -20,
+	-32768, -1, -2, 53, -4, -24, -5, -6, -7, 31,
+	-19, -15, 41, 44, 39, 4, 38, 36, 7, 39,
+	6, -25, -23, 65, -25, 65, 65, 65, 34, -57,
+	-56, 65, 65, -56, 42, 49, 43, 42, 34, 47,
+	34, 34, -67, -66, -65, 65, 45, 47, 34, 62,
+	-23, 65, 62, -10, -9, -8, -47, -54, -58, -61,
+	-5, 40, 5, -55, -63, 37, 31, 7, -62, 54,
+	-51, -50, -48, 65, -14, -20, 65, 35, 49, 45,
+	-78, 14, -51, -13, -12, -11, -47, 7, 35, -8,
+	65, 65, -55, -62, 65, -56, 65, 48, 49, -52,
+	65, 47, 35, -3, -29, -15, -16, -40, -45, 28,
+	-38, 18, -43, -39, 65, -78, -32, -35, -44, -41,
+	-42, 60, 61, 62, 63, 64, 13, 47, 35, 49,
+	-65, -36, -46, -87, -45, -37, 19, 20, -38, 81,
+	82, -40, -74, -36, 48, 35, -11, -57, -52, 45,
+	65, 34, -33, 34, 47, 34, -48, 45, 32, -53,
+	-52, 65, 47, -20, 32, 50, 50, -36, -72, -36,
+	65, 80, 82, -33, -36, -36, -37, -37, 34, -52,
+	-49, 45, 27, -36, 47, -60, -59, -58, -34, -67,
+	-14, -36, -53, 48, 49, 34, 48, -72, -73, -36,
+	65, 22, 23, 21, -36, 65, 65, 49, 48, -37,
+	-37, -33, -36, -77, -76, -75, -36, -36, 32, 34,
+	-49, -69, -68, -70, -65, -36, 65, 35, -58, -26,
+	35, -28, -29, -64, -30, -31, -71, -81, -33, 53,
+	10, 26, 16, 29, 11, 24, 48, 35, 33, -52,
+	-17, 49, 48, 49, 48, 45, 47, -40, 33, -36,
+	-80, -79, 15, -75, 51, -49, -36, -72, 48, 49,
+	-22, -21, 65, 47, -36, 65, -36, 53, -36, -36,
+	10, -36, 35, -18, 65, 9, 53, -36, 65, -36,
+	-36, 48, 35, 51, -36, 49, 35, -70, 45, 49,
+	-22, -26, 27, -33, 65, 34, -36, 65, 45, 48,
+	-36, 49, -36, -36, -21, 48, -36, -27, 12, 45,
+	-84, -83, -82, -36, 65, -36, -36, 49, 33, -26,
+	-71, -33, -36, -86, -85, 15, -82, 51, -36, -33,
+	35, 51, -26, 26, -27, -26, -36,
 }
 
 var SDLDef = [...]int16{
 	2, -2, 1, 3, 4, 5, 6, 7, 8, 0,
-	10, 0, 0, 0, 0, 0, 0, 0, 0, 17,
-	19, 0, 0, 0, 9, 0, 0, 56, 0, 0,
-	0, 29, 48, 57, 0, 48, 23, 16, 18, 20,
-	0, 30, 31, 33, 34, 35, 36, 0, 0, 0,
-	0, 0, 49, 50, 0, 0, 0, 14, 0, 0,
-	24, 25, 27, 0, 12, 32, 0, 0, 0, 0,
-	0, 52, 40, 0, 55, 58, 59, -2, 150, 0,
-	0, 107, 109, 110, 111, 112, 113, 114, 115, 116,
-	118, 119, 120, 121, 122, 91, 0, 0, 13, 0,
-	21, 11, 26, 28, 37, 0, 45, 47, 65, 57,
-	51, 0, 0, 0, 43, 0, 80, 0, 0, 0,
-	0, 92, 98, 99, 100, 101, 0, 0, 104, 0,
-	0, 108, 90, 0, 84, 15, 22, 0, 38, 60,
-	0, 0, 53, 0, 41, 0, 126, 0, 0, 84,
-	110, 0, 0, 124, 125, 130, 0, 0, 94, 95,
-	0, 105, 106, 0, 117, 39, 0, 61, 62, 0,
-	66, 75, 67, 68, 69, 70, 71, 72, 73, 74,
-	0, 0, 0, 0, 0, 54, 42, 44, 0, 127,
-	0, 128, 0, 81, 123, 136, 131, 132, 0, 102,
-	103, 96, 97, 85, 46, 0, 0, 0, 0, 78,
-	79, 0, 0, 85, 0, 82, 0, 137, 0, 133,
-	0, 93, 63, 64, 0, 76, 87, 141, 0, 129,
-	0, 134, 77, 86, 0, 146, 142, 143, 0, 83,
-	138, 135, 88, 89, 0, 147, 0, 144, 0, 139,
-	140, 0, 145, 148,
+	10, 11, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 20, 22, 0, 0, 0, 0, 94, 9,
+	26, 0, 0, 82, 0, 0, 0, 0, 34, 74,
+	83, 0, 0, 95, 96, 0, 0, 74, 28, 18,
+	21, 23, 19, 0, 35, 36, 38, 39, 40, 41,
+	42, 0, 0, 43, 0, 0, 0, 0, 50, 0,
+	0, 75, 76, 0, 0, 0, 16, 12, 0, 0,
+	27, 135, 0, 0, 29, 30, 32, 0, 14, 37,
+	0, 0, 44, 51, 0, 0, 52, 0, 0, 78,
+	60, 0, 81, 84, 85, 86, 87, -2, 194, 0,
+	0, 0, 151, 153, 154, 155, 156, 157, 158, 159,
+	160, 162, 163, 164, 165, 166, 0, 0, 15, 0,
+	97, 98, 142, 143, 144, 145, 0, 0, 148, 0,
+	0, 152, 0, 136, 24, 13, 31, 33, 57, 0,
+	65, 46, 73, 99, 94, 83, 77, 0, 0, 0,
+	63, 0, 0, 121, 0, 0, 0, 134, 0, 127,
+	17, 0, 0, 138, 139, 0, 149, 150, 174, 25,
+	54, 0, 0, 57, 67, 0, 47, 48, 0, 0,
+	0, 79, 0, 61, 0, 89, 170, 0, 0, 127,
+	154, 122, 0, 0, 0, 168, 169, 0, 161, 146,
+	147, 140, 141, 180, 175, 176, 0, 57, 0, 0,
+	55, 0, 68, 69, 71, 72, 154, 45, 49, 100,
+	110, 101, 102, 103, 104, 105, 106, 107, 108, 109,
+	0, 0, 0, 0, 0, 0, 53, 80, 62, 64,
+	0, 0, 171, 0, 172, 0, 0, 124, 167, 128,
+	0, 181, 0, 177, 0, 56, 0, 0, 66, 0,
+	0, 114, 116, 0, 0, 154, 118, 119, 120, 0,
+	0, 0, 88, 90, 0, 0, 93, 128, 0, 125,
+	0, 137, 173, 0, 178, 0, 59, 70, 0, 0,
+	0, 111, 0, 131, 0, 185, 0, 0, 0, 123,
+	182, 179, 0, 113, 115, 117, 0, 129, 0, 0,
+	190, 186, 187, 0, 0, 92, 126, 183, 58, 112,
+	132, 133, 0, 0, 191, 0, 188, 0, 0, 131,
+	184, 0, 189, 0, 130, 192, 91,
 }
 
 var SDLTok1 = [...]int8{
@@ -539,7 +642,8 @@ var SDLTok2 = [...]int8{
 	42, 43, 44, 45, 46, 47, 48, 49, 50, 51,
 	52, 53, 54, 55, 56, 57, 58, 59, 60, 61,
 	62, 63, 64, 65, 66, 67, 68, 69, 70, 71,
-	72, 73, 74, 75,
+	72, 73, 74, 75, 76, 77, 78, 79, 80, 81,
+	82, 83,
 }
 
 var SDLTok3 = [...]int8{
@@ -585,7 +689,7 @@ func SDLNewParser() SDLParser {
 	return &SDLParserImpl{}
 }
 
-const SDLFlag = -1000
+const SDLFlag = -32768
 
 func SDLTokname(c int) string {
 	if c >= 1 && c-1 < len(SDLToknames) {
@@ -885,7 +989,7 @@ SDLdefault:
 
 	case 1:
 		SDLDollar = SDLS[SDLpt-1 : SDLpt+1]
-//line grammar.y:183
+//line grammar.y:203
 		{
 			ni := NodeInfo{}
 			if len(SDLDollar[1].nodeList) > 0 {
@@ -897,65 +1001,80 @@ SDLdefault:
 		}
 	case 2:
 		SDLDollar = SDLS[SDLpt-0 : SDLpt+1]
-//line grammar.y:195
+//line grammar.y:215
 		{
 			SDLVAL.nodeList = []Node{}
 		}
 	case 3:
 		SDLDollar = SDLS[SDLpt-2 : SDLpt+1]
-//line grammar.y:196
+//line grammar.y:216
 		{
 			SDLVAL.nodeList = SDLDollar[1].nodeList
 		}
 	case 4:
 		SDLDollar = SDLS[SDLpt-2 : SDLpt+1]
-//line grammar.y:197
+//line grammar.y:217
 		{
-			SDLVAL.nodeList = append(SDLDollar[1].nodeList, SDLDollar[2].node)
+			SDLVAL.nodeList = SDLlex.(*Lexer).addDecl(SDLDollar[1].nodeList, SDLDollar[2].node)
 		}
 	case 5:
 		SDLDollar = SDLS[SDLpt-2 : SDLpt+1]
-//line grammar.y:200
+//line grammar.y:220
 		{
 			for _, imp := range SDLDollar[2].importDeclList {
-				SDLDollar[1].nodeList = append(SDLDollar[1].nodeList, imp)
+				SDLDollar[1].nodeList = SDLlex.(*Lexer).addDecl(SDLDollar[1].nodeList, imp)
 			}
 			SDLVAL.nodeList = SDLDollar[1].nodeList
 		}
 	case 6:
 		SDLDollar = SDLS[SDLpt-1 : SDLpt+1]
-//line grammar.y:209
+//line grammar.y:229
 		{
 			SDLVAL.node = SDLDollar[1].componentDecl
 		}
 	case 7:
 		SDLDollar = SDLS[SDLpt-1 : SDLpt+1]
-//line grammar.y:210
+//line grammar.y:230
 		{
 			SDLVAL.node = SDLDollar[1].systemDecl
 		}
 	case 8:
 		SDLDollar = SDLS[SDLpt-1 : SDLpt+1]
-//line grammar.y:211
+//line grammar.y:231
 		{
 			SDLVAL.node = SDLDollar[1].aggregatorDecl
 		}
 	case 9:
 		SDLDollar = SDLS[SDLpt-3 : SDLpt+1]
-//line grammar.y:212
+//line grammar.y:232
 		{
 			SDLDollar[3].methodDef.IsNative = true
 			SDLVAL.node = SDLDollar[3].methodDef
 		}
 	case 10:
 		SDLDollar = SDLS[SDLpt-1 : SDLpt+1]
-//line grammar.y:216
+//line grammar.y:236
 		{
 			SDLVAL.node = SDLDollar[1].enumDecl
 		}
 	case 11:
+		SDLDollar = SDLS[SDLpt-1 : SDLpt+1]
+//line grammar.y:237
+		{
+			SDLVAL.node = SDLDollar[1].optionsDecl
+		}
+	case 12:
+		SDLDollar = SDLS[SDLpt-4 : SDLpt+1]
+//line grammar.y:242
+		{
+			SDLVAL.optionsDecl = &OptionsDecl{
+				NodeInfo: NewNodeInfo(SDLDollar[1].node.(Node).Pos(), SDLDollar[4].node.(Node).End()),
+				Options:  SDLDollar[3].assignList,
+			}
+		}
+	case 13:
 		SDLDollar = SDLS[SDLpt-6 : SDLpt+1]
-//line grammar.y:222
+//line grammar.y:251
 		{ // COMPONENT($1) ... RBRACE($5)
 			SDLVAL.componentDecl = &ComponentDecl{
 				NodeInfo: NewNodeInfo(SDLDollar[1].node.(Node).Pos(), SDLDollar[6].node.(Node).End()),
@@ -964,9 +1083,9 @@ SDLdefault:
 				IsNative: true,
 			}
 		}
-	case 12:
+	case 14:
 		SDLDollar = SDLS[SDLpt-5 : SDLpt+1]
-//line grammar.y:230
+//line grammar.y:259
 		{ // COMPONENT($1) ... RBRACE($5)
 			SDLVAL.componentDecl = &ComponentDecl{
 				NodeInfo: NewNodeInfo(SDLDollar[1].node.(Node).Pos(), SDLDollar[5].node.(Node).End()),
@@ -974,9 +1093,9 @@ SDLdefault:
 				Body:     SDLDollar[4].compBodyItemList,
 			}
 		}
-	case 13:
+	case 15:
 		SDLDollar = SDLS[SDLpt-5 : SDLpt+1]
-//line grammar.y:240
+//line grammar.y:269
 		{ // ENUM($1) IDENTIFIER($2) ... RBRACE($5)
 			SDLVAL.enumDecl = &EnumDecl{
 				NodeInfo: NewNodeInfo(SDLDollar[1].node.(Node).Pos(), SDLDollar[5].node.(Node).End()),
@@ -984,21 +1103,21 @@ SDLdefault:
 				Values:   SDLDollar[4].identList,
 			}
 		}
-	case 14:
+	case 16:
 		SDLDollar = SDLS[SDLpt-1 : SDLpt+1]
-//line grammar.y:250
+//line grammar.y:279
 		{
 			SDLVAL.identList = []*IdentifierExpr{SDLDollar[1].ident}
 		}
-	case 15:
+	case 17:
 		SDLDollar = SDLS[SDLpt-3 : SDLpt+1]
-//line grammar.y:251
+//line grammar.y:280
 		{
 			SDLVAL.identList = append(SDLDollar[1].identList, SDLDollar[3].ident)
 		}
-	case 16:
+	case 18:
 		SDLDollar = SDLS[SDLpt-4 : SDLpt+1]
-//line grammar.y:255
+//line grammar.y:284
 		{ // IMPORT($1) STRING_LITERAL($2)
 			path := SDLDollar[4].expr.(*LiteralExpr)
 			for _, imp := range SDLDollar[2].importDeclList {
@@ -1006,33 +1125,44 @@ SDLdefault:
 			}
 			SDLVAL.importDeclList = SDLDollar[2].importDeclList
 		}
-	case 17:
+	case 19:
+		SDLDollar = SDLS[SDLpt-4 : SDLpt+1]
+//line grammar.y:291
+		{
+			path := SDLDollar[4].expr.(*LiteralExpr)
+			for _, imp := range SDLDollar[2].importDeclList {
+				imp.Path = path
+				imp.Exported = true
+			}
+			SDLVAL.importDeclList = SDLDollar[2].importDeclList
+		}
+	case 20:
 		SDLDollar = SDLS[SDLpt-1 : SDLpt+1]
-//line grammar.y:264
+//line grammar.y:301
 		{
 			SDLVAL.importDeclList = []*ImportDecl{SDLDollar[1].importDecl}
 		}
-	case 18:
+	case 21:
 		SDLDollar = SDLS[SDLpt-3 : SDLpt+1]
-//line grammar.y:265
+//line grammar.y:302
 		{
 			SDLVAL.importDeclList = append(SDLVAL.importDeclList, SDLDollar[3].importDecl)
 		}
-	case 19:
+	case 22:
 		SDLDollar = SDLS[SDLpt-1 : SDLpt+1]
-//line grammar.y:268
+//line grammar.y:305
 		{
 			SDLVAL.importDecl = &ImportDecl{ImportedItem: SDLDollar[1].ident, Alias: SDLDollar[1].ident}
 		}
-	case 20:
+	case 23:
 		SDLDollar = SDLS[SDLpt-3 : SDLpt+1]
-//line grammar.y:269
+//line grammar.y:306
 		{
 			SDLVAL.importDecl = &ImportDecl{ImportedItem: SDLDollar[1].ident, Alias: SDLDollar[3].ident}
 		}
-	case 21:
+	case 24:
 		SDLDollar = SDLS[SDLpt-4 : SDLpt+1]
-//line grammar.y:273
+//line grammar.y:310
 		{ // METHOD($1) ... BlockStmt($6)
 			SDLVAL.methodDef = &MethodDecl{
 				NodeInfo:   NewNodeInfo(SDLDollar[1].ident.Pos(), SDLDollar[4].node.End()),
@@ -1040,9 +1170,9 @@ SDLdefault:
 				Parameters: SDLDollar[3].paramList,
 			}
 		}
-	case 22:
+	case 25:
 		SDLDollar = SDLS[SDLpt-5 : SDLpt+1]
-//line grammar.y:280
+//line grammar.y:317
 		{ // METHOD($1) ... BlockStmt($8)
 			SDLVAL.methodDef = &MethodDecl{
 				NodeInfo:   NewNodeInfo(SDLDollar[1].ident.Pos(), SDLDollar[5].typeDecl.End()),
@@ -1051,124 +1181,252 @@ SDLdefault:
 				ReturnType: SDLDollar[5].typeDecl,
 			}
 		}
-	case 23:
+	case 26:
+		SDLDollar = SDLS[SDLpt-1 : SDLpt+1]
+//line grammar.y:329
+		{
+			SDLVAL.methodDef = SDLDollar[1].methodDef
+		}
+	case 27:
+		SDLDollar = SDLS[SDLpt-3 : SDLpt+1]
+//line grammar.y:330
+		{
+			SDLDollar[1].methodDef.Outcomes = SDLDollar[3].distributeExpr
+			SDLVAL.methodDef = SDLDollar[1].methodDef
+		}
+	case 28:
 		SDLDollar = SDLS[SDLpt-0 : SDLpt+1]
-//line grammar.y:291
+//line grammar.y:337
 		{
 			SDLVAL.compBodyItemList = []ComponentDeclBodyItem{}
 		}
-	case 24:
+	case 29:
 		SDLDollar = SDLS[SDLpt-1 : SDLpt+1]
-//line grammar.y:292
+//line grammar.y:338
 		{
 			SDLVAL.compBodyItemList = SDLDollar[1].compBodyItemList
 		}
-	case 25:
+	case 30:
 		SDLDollar = SDLS[SDLpt-1 : SDLpt+1]
-//line grammar.y:296
+//line grammar.y:342
 		{
 			SDLVAL.compBodyItemList = []ComponentDeclBodyItem{SDLDollar[1].compBodyItem}
 		}
-	case 26:
+	case 31:
 		SDLDollar = SDLS[SDLpt-2 : SDLpt+1]
-//line grammar.y:297
+//line grammar.y:343
 		{
 			SDLVAL.compBodyItemList = append(SDLDollar[1].compBodyItemList, SDLDollar[2].compBodyItem)
 		}
-	case 27:
+	case 32:
 		SDLDollar = SDLS[SDLpt-1 : SDLpt+1]
-//line grammar.y:301
+//line grammar.y:347
 		{
 			SDLVAL.compBodyItem = SDLDollar[1].paramDecl
 		}
-	case 28:
+	case 33:
 		SDLDollar = SDLS[SDLpt-2 : SDLpt+1]
-//line grammar.y:302
+//line grammar.y:348
 		{
 			SDLVAL.compBodyItem = SDLDollar[2].methodDef
 		}
-	case 29:
+	case 34:
 		SDLDollar = SDLS[SDLpt-0 : SDLpt+1]
-//line grammar.y:307
+//line grammar.y:353
 		{
 			SDLVAL.compBodyItemList = []ComponentDeclBodyItem{}
 		}
-	case 30:
+	case 35:
 		SDLDollar = SDLS[SDLpt-1 : SDLpt+1]
-//line grammar.y:308
+//line grammar.y:354
 		{
 			SDLVAL.compBodyItemList = SDLDollar[1].compBodyItemList
 		}
-	case 31:
+	case 36:
 		SDLDollar = SDLS[SDLpt-1 : SDLpt+1]
-//line grammar.y:312
+//line grammar.y:358
 		{
 			SDLVAL.compBodyItemList = []ComponentDeclBodyItem{SDLDollar[1].compBodyItem}
 		}
-	case 32:
+	case 37:
 		SDLDollar = SDLS[SDLpt-2 : SDLpt+1]
-//line grammar.y:313
+//line grammar.y:359
 		{
 			SDLVAL.compBodyItemList = append(SDLDollar[1].compBodyItemList, SDLDollar[2].compBodyItem)
 		}
-	case 33:
+	case 38:
 		SDLDollar = SDLS[SDLpt-1 : SDLpt+1]
-//line grammar.y:317
+//line grammar.y:363
 		{
 			SDLVAL.compBodyItem = SDLDollar[1].paramDecl
 		}
-	case 34:
+	case 39:
 		SDLDollar = SDLS[SDLpt-1 : SDLpt+1]
-//line grammar.y:318
+//line grammar.y:364
 		{
 			SDLVAL.compBodyItem = SDLDollar[1].usesDecl
 		}
-	case 35:
+	case 40:
 		SDLDollar = SDLS[SDLpt-1 : SDLpt+1]
-//line grammar.y:319
+//line grammar.y:365
 		{
 			SDLVAL.compBodyItem = SDLDollar[1].methodDef
 		}
-	case 36:
+	case 41:
+		SDLDollar = SDLS[SDLpt-1 : SDLpt+1]
+//line grammar.y:366
+		{
+			SDLVAL.compBodyItem = SDLDollar[1].profileDecl
+		}
+	case 42:
 		SDLDollar = SDLS[SDLpt-1 : SDLpt+1]
-//line grammar.y:320
+//line grammar.y:367
 		{
 			SDLVAL.compBodyItem = SDLDollar[1].componentDecl
 		}
-	case 37:
-		SDLDollar = SDLS[SDLpt-3 : SDLpt+1]
-//line grammar.y:324
-		{ // PARAM($1) ...
-			SDLVAL.paramDecl = &ParamDecl{
-				NodeInfo: NewNodeInfo(SDLDollar[1].node.(Node).Pos(), SDLDollar[3].typeDecl.End()),
+	case 43:
+		SDLDollar = SDLS[SDLpt-1 : SDLpt+1]
+//line grammar.y:371
+		{
+			SDLVAL.methodDef = SDLDollar[1].methodDef
+		}
+	case 44:
+		SDLDollar = SDLS[SDLpt-2 : SDLpt+1]
+//line grammar.y:372
+		{
+			SDLDollar[2].methodDef.Annotations = SDLDollar[1].annotationList
+			SDLDollar[2].methodDef.NodeInfo.StartPos = SDLDollar[1].annotationList[0].Pos()
+			SDLVAL.methodDef = SDLDollar[2].methodDef
+		}
+	case 45:
+		SDLDollar = SDLS[SDLpt-5 : SDLpt+1]
+//line grammar.y:380
+		{
+			SDLVAL.profileDecl = &ProfileDecl{
+				NodeInfo: NewNodeInfo(SDLDollar[1].node.(Node).Pos(), SDLDollar[5].node.(Node).End()),
 				Name:     SDLDollar[2].ident,
-				TypeDecl: SDLDollar[3].typeDecl, // TypeDecl also needs to have NodeInfo
+				Methods:  SDLDollar[4].methodSigItemList,
 			}
 		}
-	case 38:
+	case 46:
+		SDLDollar = SDLS[SDLpt-0 : SDLpt+1]
+//line grammar.y:390
+		{
+			SDLVAL.methodSigItemList = []*MethodDecl{}
+		}
+	case 47:
+		SDLDollar = SDLS[SDLpt-1 : SDLpt+1]
+//line grammar.y:391
+		{
+			SDLVAL.methodSigItemList = SDLDollar[1].methodSigItemList
+		}
+	case 48:
+		SDLDollar = SDLS[SDLpt-1 : SDLpt+1]
+//line grammar.y:395
+		{
+			SDLVAL.methodSigItemList = []*MethodDecl{SDLDollar[1].methodDef}
+		}
+	case 49:
+		SDLDollar = SDLS[SDLpt-2 : SDLpt+1]
+//line grammar.y:396
+		{
+			SDLVAL.methodSigItemList = append(SDLDollar[1].methodSigItemList, SDLDollar[2].methodDef)
+		}
+	case 50:
+		SDLDollar = SDLS[SDLpt-1 : SDLpt+1]
+//line grammar.y:400
+		{
+			SDLVAL.annotationList = []*Annotation{SDLDollar[1].annotation}
+		}
+	case 51:
+		SDLDollar = SDLS[SDLpt-2 : SDLpt+1]
+//line grammar.y:401
+		{
+			SDLVAL.annotationList = append(SDLDollar[1].annotationList, SDLDollar[2].annotation)
+		}
+	case 52:
+		SDLDollar = SDLS[SDLpt-2 : SDLpt+1]
+//line grammar.y:405
+		{
+			SDLVAL.annotation = &Annotation{NodeInfo: NewNodeInfo(SDLDollar[1].node.Pos(), SDLDollar[2].ident.End()), Name: SDLDollar[2].ident}
+		}
+	case 53:
+		SDLDollar = SDLS[SDLpt-5 : SDLpt+1]
+//line grammar.y:408
+		{
+			SDLVAL.annotation = &Annotation{NodeInfo: NewNodeInfo(SDLDollar[1].node.Pos(), SDLDollar[5].node.End()), Name: SDLDollar[2].ident, Args: SDLDollar[4].assignList}
+		}
+	case 54:
 		SDLDollar = SDLS[SDLpt-4 : SDLpt+1]
-//line grammar.y:331
+//line grammar.y:414
+		{ // PARAM($1) ...
+			SDLVAL.paramDecl = &ParamDecl{
+				NodeInfo:   NewNodeInfo(SDLDollar[1].node.(Node).Pos(), SDLDollar[3].typeDecl.End()),
+				Name:       SDLDollar[2].ident,
+				TypeDecl:   SDLDollar[3].typeDecl, // TypeDecl also needs to have NodeInfo
+				Constraint: SDLDollar[4].paramConstraint,
+			}
+			if SDLDollar[4].paramConstraint != nil {
+				SDLVAL.paramDecl.NodeInfo.StopPos = SDLDollar[4].paramConstraint.End()
+			}
+		}
+	case 55:
+		SDLDollar = SDLS[SDLpt-5 : SDLpt+1]
+//line grammar.y:423
 		{ // PARAM($1) ...
 			SDLVAL.paramDecl = &ParamDecl{
 				NodeInfo:     NewNodeInfo(SDLDollar[1].node.(Node).Pos(), SDLDollar[4].expr.End()),
 				Name:         SDLDollar[2].ident,
 				DefaultValue: SDLDollar[4].expr,
+				Constraint:   SDLDollar[5].paramConstraint,
+			}
+			if SDLDollar[5].paramConstraint != nil {
+				SDLVAL.paramDecl.NodeInfo.StopPos = SDLDollar[5].paramConstraint.End()
 			}
 		}
-	case 39:
-		SDLDollar = SDLS[SDLpt-5 : SDLpt+1]
-//line grammar.y:338
+	case 56:
+		SDLDollar = SDLS[SDLpt-6 : SDLpt+1]
+//line grammar.y:432
 		{ // PARAM($1) ...
 			SDLVAL.paramDecl = &ParamDecl{
 				NodeInfo:     NewNodeInfo(SDLDollar[1].node.(Node).Pos(), SDLDollar[5].expr.End()),
 				Name:         SDLDollar[2].ident,
 				TypeDecl:     SDLDollar[3].typeDecl,
 				DefaultValue: SDLDollar[5].expr,
+				Constraint:   SDLDollar[6].paramConstraint,
+			}
+			if SDLDollar[6].paramConstraint != nil {
+				SDLVAL.paramDecl.NodeInfo.StopPos = SDLDollar[6].paramConstraint.End()
 			}
 		}
-	case 40:
+	case 57:
+		SDLDollar = SDLS[SDLpt-0 : SDLpt+1]
+//line grammar.y:446
+		{
+			SDLVAL.paramConstraint = nil
+		}
+	case 58:
+		SDLDollar = SDLS[SDLpt-6 : SDLpt+1]
+//line grammar.y:447
+		{
+			SDLVAL.paramConstraint = &ParamConstraint{
+				NodeInfo: NewNodeInfo(SDLDollar[1].node.(Node).Pos(), SDLDollar[6].node.(Node).End()),
+				Min:      SDLDollar[3].expr,
+				Max:      SDLDollar[5].expr,
+			}
+		}
+	case 59:
+		SDLDollar = SDLS[SDLpt-4 : SDLpt+1]
+//line grammar.y:454
+		{
+			SDLVAL.paramConstraint = &ParamConstraint{
+				NodeInfo: NewNodeInfo(SDLDollar[1].node.(Node).Pos(), SDLDollar[4].node.(Node).End()),
+				Allowed:  SDLDollar[3].exprList,
+			}
+		}
+	case 60:
 		SDLDollar = SDLS[SDLpt-1 : SDLpt+1]
-//line grammar.y:350
+//line grammar.y:464
 		{
 			identNode := SDLDollar[1].ident
 			SDLVAL.typeDecl = &TypeDecl{
@@ -1176,9 +1434,9 @@ SDLdefault:
 				Name:     identNode.Value,
 			}
 		}
-	case 41:
+	case 61:
 		SDLDollar = SDLS[SDLpt-3 : SDLpt+1]
-//line grammar.y:357
+//line grammar.y:471
 		{ // Tuple type
 			if len(SDLDollar[2].typeDeclList) == 1 {
 				SDLVAL.typeDecl = SDLDollar[2].typeDeclList[0]
@@ -1190,9 +1448,9 @@ SDLdefault:
 				}
 			}
 		}
-	case 42:
+	case 62:
 		SDLDollar = SDLS[SDLpt-4 : SDLpt+1]
-//line grammar.y:368
+//line grammar.y:482
 		{
 			identNode := SDLDollar[1].ident
 			SDLVAL.typeDecl = &TypeDecl{
@@ -1201,21 +1459,21 @@ SDLdefault:
 				Args:     SDLDollar[3].typeDeclList,
 			}
 		}
-	case 43:
+	case 63:
 		SDLDollar = SDLS[SDLpt-1 : SDLpt+1]
-//line grammar.y:384
+//line grammar.y:498
 		{
 			SDLVAL.typeDeclList = []*TypeDecl{SDLDollar[1].typeDecl}
 		}
-	case 44:
+	case 64:
 		SDLDollar = SDLS[SDLpt-3 : SDLpt+1]
-//line grammar.y:385
+//line grammar.y:499
 		{
 			SDLVAL.typeDeclList = append(SDLDollar[1].typeDeclList, SDLDollar[3].typeDecl)
 		}
-	case 45:
+	case 65:
 		SDLDollar = SDLS[SDLpt-3 : SDLpt+1]
-//line grammar.y:389
+//line grammar.y:503
 		{ // USES($1) ...
 			SDLVAL.usesDecl = &UsesDecl{
 				NodeInfo:      NewNodeInfo(SDLDollar[1].node.(Node).Pos(), SDLDollar[3].ident.End()),
@@ -1223,52 +1481,94 @@ SDLdefault:
 				ComponentName: SDLDollar[3].ident,
 			}
 		}
-	case 46:
+	case 66:
 		SDLDollar = SDLS[SDLpt-6 : SDLpt+1]
-//line grammar.y:397
+//line grammar.y:511
 		{
 			SDLVAL.usesDecl = &UsesDecl{
 				NodeInfo:      NewNodeInfo(SDLDollar[1].node.(Node).Pos(), SDLDollar[6].node.End()),
 				Name:          SDLDollar[2].ident,
 				ComponentName: SDLDollar[3].ident,
-				Overrides:     SDLDollar[5].assignList,
+				Args:          SDLDollar[5].assignList,
+				Overrides:     []*AssignmentStmt{},
+			}
+			for _, arg := range SDLDollar[5].assignList {
+				if arg.Var != nil {
+					SDLVAL.usesDecl.Overrides = append(SDLVAL.usesDecl.Overrides, arg)
+				}
 			}
 		}
-	case 47:
+	case 67:
+		SDLDollar = SDLS[SDLpt-0 : SDLpt+1]
+//line grammar.y:529
+		{
+			SDLVAL.assignList = []*AssignmentStmt{}
+		}
+	case 68:
+		SDLDollar = SDLS[SDLpt-1 : SDLpt+1]
+//line grammar.y:530
+		{
+			SDLVAL.assignList = SDLDollar[1].assignList
+		}
+	case 69:
+		SDLDollar = SDLS[SDLpt-1 : SDLpt+1]
+//line grammar.y:534
+		{
+			SDLVAL.assignList = []*AssignmentStmt{SDLDollar[1].assignStmt}
+		}
+	case 70:
 		SDLDollar = SDLS[SDLpt-3 : SDLpt+1]
-//line grammar.y:408
+//line grammar.y:535
+		{
+			SDLVAL.assignList = append(SDLDollar[1].assignList, SDLDollar[3].assignStmt)
+		}
+	case 71:
+		SDLDollar = SDLS[SDLpt-1 : SDLpt+1]
+//line grammar.y:539
+		{
+			SDLVAL.assignStmt = SDLDollar[1].assignStmt
+		}
+	case 72:
+		SDLDollar = SDLS[SDLpt-1 : SDLpt+1]
+//line grammar.y:540
+		{
+			SDLVAL.assignStmt = &AssignmentStmt{NodeInfo: NewNodeInfo(SDLDollar[1].expr.Pos(), SDLDollar[1].expr.End()), Value: SDLDollar[1].expr}
+		}
+	case 73:
+		SDLDollar = SDLS[SDLpt-3 : SDLpt+1]
+//line grammar.y:544
 		{ // METHOD($1) ... BlockStmt($6)
 			SDLDollar[2].methodDef.Body = SDLDollar[3].blockStmt
 			SDLDollar[2].methodDef.NodeInfo.StopPos = SDLDollar[3].blockStmt.End()
 			SDLVAL.methodDef = SDLDollar[2].methodDef
 		}
-	case 48:
+	case 74:
 		SDLDollar = SDLS[SDLpt-0 : SDLpt+1]
-//line grammar.y:416
+//line grammar.y:552
 		{
 			SDLVAL.paramList = []*ParamDecl{}
 		}
-	case 49:
+	case 75:
 		SDLDollar = SDLS[SDLpt-1 : SDLpt+1]
-//line grammar.y:417
+//line grammar.y:553
 		{
 			SDLVAL.paramList = SDLDollar[1].paramList
 		}
-	case 50:
+	case 76:
 		SDLDollar = SDLS[SDLpt-1 : SDLpt+1]
-//line grammar.y:421
+//line grammar.y:557
 		{
 			SDLVAL.paramList = []*ParamDecl{SDLDollar[1].paramDecl}
 		}
-	case 51:
+	case 77:
 		SDLDollar = SDLS[SDLpt-3 : SDLpt+1]
-//line grammar.y:422
+//line grammar.y:558
 		{
 			SDLVAL.paramList = append(SDLDollar[1].paramList, SDLDollar[3].paramDecl)
 		}
-	case 52:
+	case 78:
 		SDLDollar = SDLS[SDLpt-2 : SDLpt+1]
-//line grammar.y:426
+//line grammar.y:562
 		{ // PARAM($1) ...
 			SDLVAL.paramDecl = &ParamDecl{
 				NodeInfo: NewNodeInfo(SDLDollar[1].ident.Pos(), SDLDollar[2].typeDecl.End()),
@@ -1276,9 +1576,9 @@ SDLdefault:
 				TypeDecl: SDLDollar[2].typeDecl, // TypeDecl also needs to have NodeInfo
 			}
 		}
-	case 53:
+	case 79:
 		SDLDollar = SDLS[SDLpt-4 : SDLpt+1]
-//line grammar.y:433
+//line grammar.y:569
 		{ // PARAM($1) ...
 			SDLVAL.paramDecl = &ParamDecl{
 				NodeInfo:     NewNodeInfo(SDLDollar[1].ident.Pos(), SDLDollar[4].expr.End()),
@@ -1287,9 +1587,9 @@ SDLdefault:
 				DefaultValue: SDLDollar[4].expr,
 			}
 		}
-	case 54:
+	case 80:
 		SDLDollar = SDLS[SDLpt-8 : SDLpt+1]
-//line grammar.y:448
+//line grammar.y:584
 		{
 			SDLVAL.systemDecl = &SystemDecl{
 				NodeInfo:   NewNodeInfo(SDLDollar[1].node.(Node).Pos(), SDLDollar[8].node.(Node).End()),
@@ -1298,9 +1598,9 @@ SDLdefault:
 				Body:       SDLDollar[7].sysBodyItemList,
 			}
 		}
-	case 55:
+	case 81:
 		SDLDollar = SDLS[SDLpt-5 : SDLpt+1]
-//line grammar.y:456
+//line grammar.y:592
 		{
 			SDLVAL.systemDecl = &SystemDecl{
 				NodeInfo: NewNodeInfo(SDLDollar[1].node.(Node).Pos(), SDLDollar[5].node.(Node).End()),
@@ -1308,9 +1608,9 @@ SDLdefault:
 				Body:     SDLDollar[4].sysBodyItemList,
 			}
 		}
-	case 56:
+	case 82:
 		SDLDollar = SDLS[SDLpt-3 : SDLpt+1]
-//line grammar.y:466
+//line grammar.y:602
 		{ // SYSTEM($1) ... RBRACE($5)
 			SDLVAL.aggregatorDecl = &AggregatorDecl{
 				NodeInfo:   NewNodeInfo(SDLDollar[1].node.(Node).Pos(), SDLDollar[3].methodDef.End()),
@@ -1319,51 +1619,125 @@ SDLdefault:
 				ReturnType: SDLDollar[3].methodDef.ReturnType,
 			}
 		}
-	case 57:
+	case 83:
 		SDLDollar = SDLS[SDLpt-0 : SDLpt+1]
-//line grammar.y:477
+//line grammar.y:613
 		{
 			SDLVAL.sysBodyItemList = []SystemDeclBodyItem{}
 		}
-	case 58:
+	case 84:
 		SDLDollar = SDLS[SDLpt-2 : SDLpt+1]
-//line grammar.y:478
+//line grammar.y:614
 		{
 			SDLVAL.sysBodyItemList = append(SDLDollar[1].sysBodyItemList, SDLDollar[2].node.(SystemDeclBodyItem))
 		}
-	case 59:
+	case 85:
 		SDLDollar = SDLS[SDLpt-1 : SDLpt+1]
-//line grammar.y:485
+//line grammar.y:621
 		{
 			SDLVAL.node = SDLDollar[1].stmt
 		}
-	case 60:
+	case 86:
+		SDLDollar = SDLS[SDLpt-1 : SDLpt+1]
+//line grammar.y:622
+		{
+			SDLVAL.node = SDLDollar[1].optionsDecl
+		}
+	case 87:
+		SDLDollar = SDLS[SDLpt-1 : SDLpt+1]
+//line grammar.y:623
+		{
+			SDLVAL.node = SDLDollar[1].scenarioDecl
+		}
+	case 88:
+		SDLDollar = SDLS[SDLpt-5 : SDLpt+1]
+//line grammar.y:630
+		{
+			scenario := &ScenarioDecl{
+				NodeInfo: NewNodeInfo(SDLDollar[1].node.(Node).Pos(), SDLDollar[5].node.(Node).End()),
+				Name:     SDLDollar[2].ident,
+			}
+			for _, item := range SDLDollar[4].scenarioItems {
+				switch it := item.(type) {
+				case *ScenarioGenerator:
+					scenario.Generators = append(scenario.Generators, it)
+				case *ScenarioExpect:
+					scenario.Expects = append(scenario.Expects, it)
+				}
+			}
+			SDLVAL.scenarioDecl = scenario
+		}
+	case 89:
+		SDLDollar = SDLS[SDLpt-0 : SDLpt+1]
+//line grammar.y:648
+		{
+			SDLVAL.scenarioItems = []Node{}
+		}
+	case 90:
+		SDLDollar = SDLS[SDLpt-2 : SDLpt+1]
+//line grammar.y:649
+		{
+			SDLVAL.scenarioItems = SDLDollar[1].scenarioItems
+			if SDLDollar[2].node != nil {
+				SDLVAL.scenarioItems = append(SDLVAL.scenarioItems, SDLDollar[2].node)
+			}
+		}
+	case 91:
+		SDLDollar = SDLS[SDLpt-6 : SDLpt+1]
+//line grammar.y:658
+		{
+			SDLVAL.node = &ScenarioGenerator{
+				NodeInfo: NewNodeInfo(SDLDollar[1].ident.Pos(), SDLDollar[6].expr.End()),
+				Keyword:  SDLDollar[1].ident,
+				Target:   SDLDollar[2].expr,
+				At:       SDLDollar[3].ident,
+				RateExpr: SDLDollar[4].expr,
+				For:      SDLDollar[6].expr,
+			}
+		}
+	case 92:
+		SDLDollar = SDLS[SDLpt-3 : SDLpt+1]
+//line grammar.y:668
+		{
+			SDLVAL.node = &ScenarioExpect{
+				NodeInfo:    NewNodeInfo(SDLDollar[1].node.(Node).Pos(), SDLDollar[3].expr.End()),
+				Aggregation: SDLDollar[2].ident,
+				Condition:   SDLDollar[3].expr,
+			}
+		}
+	case 93:
+		SDLDollar = SDLS[SDLpt-1 : SDLpt+1]
+//line grammar.y:675
+		{
+			SDLVAL.node = nil
+		}
+	case 94:
 		SDLDollar = SDLS[SDLpt-0 : SDLpt+1]
-//line grammar.y:489
+//line grammar.y:679
 		{
 			SDLVAL.assignList = []*AssignmentStmt{}
 		}
-	case 61:
+	case 95:
 		SDLDollar = SDLS[SDLpt-1 : SDLpt+1]
-//line grammar.y:490
+//line grammar.y:680
 		{
 			SDLVAL.assignList = SDLDollar[1].assignList
 		}
-	case 62:
+	case 96:
 		SDLDollar = SDLS[SDLpt-1 : SDLpt+1]
-//line grammar.y:494
+//line grammar.y:684
 		{
 			SDLVAL.assignList = []*AssignmentStmt{SDLDollar[1].assignStmt}
 		}
-	case 63:
+	case 97:
 		SDLDollar = SDLS[SDLpt-3 : SDLpt+1]
-//line grammar.y:495
+//line grammar.y:685
 		{
 			SDLVAL.assignList = append(SDLDollar[1].assignList, SDLDollar[3].assignStmt)
 		}
-	case 64:
+	case 98:
 		SDLDollar = SDLS[SDLpt-3 : SDLpt+1]
-//line grammar.y:499
+//line grammar.y:689
 		{ // IDENTIFIER($1) ...
 			SDLVAL.assignStmt = &AssignmentStmt{
 				NodeInfo: NewNodeInfo(SDLDollar[1].ident.Pos(), SDLDollar[3].expr.End()),
@@ -1371,115 +1745,182 @@ SDLdefault:
 				Value:    SDLDollar[3].expr,
 			}
 		}
-	case 65:
+	case 99:
 		SDLDollar = SDLS[SDLpt-0 : SDLpt+1]
-//line grammar.y:510
+//line grammar.y:700
 		{
 			SDLVAL.stmtList = []Stmt{}
 		}
-	case 66:
+	case 100:
 		SDLDollar = SDLS[SDLpt-2 : SDLpt+1]
-//line grammar.y:511
+//line grammar.y:701
 		{
 			SDLVAL.stmtList = SDLDollar[1].stmtList
 			if SDLDollar[2].stmt != nil {
 				SDLVAL.stmtList = append(SDLVAL.stmtList, SDLDollar[2].stmt)
 			}
 		}
-	case 67:
+	case 101:
 		SDLDollar = SDLS[SDLpt-1 : SDLpt+1]
-//line grammar.y:519
+//line grammar.y:709
 		{
 			SDLVAL.stmt = SDLDollar[1].stmt
 		}
-	case 68:
+	case 102:
 		SDLDollar = SDLS[SDLpt-1 : SDLpt+1]
-//line grammar.y:520
+//line grammar.y:710
 		{
 			SDLVAL.stmt = SDLDollar[1].stmt
 		}
-	case 69:
+	case 103:
 		SDLDollar = SDLS[SDLpt-1 : SDLpt+1]
-//line grammar.y:521
+//line grammar.y:711
 		{
 			SDLVAL.stmt = SDLDollar[1].forStmt
 		}
-	case 70:
+	case 104:
 		SDLDollar = SDLS[SDLpt-1 : SDLpt+1]
-//line grammar.y:522
+//line grammar.y:712
 		{
 			SDLVAL.stmt = SDLDollar[1].stmt
 		}
-	case 71:
+	case 105:
+		SDLDollar = SDLS[SDLpt-1 : SDLpt+1]
+//line grammar.y:713
+		{
+			SDLVAL.stmt = SDLDollar[1].stmt
+		}
+	case 106:
 		SDLDollar = SDLS[SDLpt-1 : SDLpt+1]
-//line grammar.y:523
+//line grammar.y:714
 		{
 			SDLVAL.stmt = SDLDollar[1].ifStmt
 		}
-	case 72:
+	case 107:
 		SDLDollar = SDLS[SDLpt-1 : SDLpt+1]
-//line grammar.y:524
+//line grammar.y:715
 		{
 			SDLVAL.stmt = SDLDollar[1].switchStmt
 		}
-	case 73:
+	case 108:
 		SDLDollar = SDLS[SDLpt-1 : SDLpt+1]
-//line grammar.y:525
+//line grammar.y:716
 		{
 			SDLVAL.stmt = SDLDollar[1].blockStmt
 		}
-	case 74:
+	case 109:
 		SDLDollar = SDLS[SDLpt-1 : SDLpt+1]
-//line grammar.y:526
+//line grammar.y:717
 		{
 			SDLVAL.stmt = nil
 		}
-	case 75:
+	case 110:
 		SDLDollar = SDLS[SDLpt-3 : SDLpt+1]
-//line grammar.y:531
+//line grammar.y:722
 		{
 			SDLVAL.blockStmt = &BlockStmt{NodeInfo: NewNodeInfo(SDLDollar[1].node.(Node).Pos(), SDLDollar[3].node.(Node).End()), Statements: SDLDollar[2].stmtList}
 		}
-	case 76:
+	case 111:
 		SDLDollar = SDLS[SDLpt-3 : SDLpt+1]
-//line grammar.y:536
+//line grammar.y:727
 		{
 			SDLVAL.forStmt = &ForStmt{NodeInfo: NewNodeInfo(SDLDollar[1].node.(Node).Pos(), SDLDollar[3].stmt.End()), Condition: SDLDollar[2].expr, Body: SDLDollar[3].stmt}
 		}
-	case 77:
+	case 112:
+		SDLDollar = SDLS[SDLpt-5 : SDLpt+1]
+//line grammar.y:730
+		{
+			SDLVAL.forStmt = &ForStmt{NodeInfo: NewNodeInfo(SDLDollar[1].node.(Node).Pos(), SDLDollar[5].stmt.End()), Var: SDLDollar[2].ident, Condition: SDLDollar[4].expr, Body: SDLDollar[5].stmt}
+		}
+	case 113:
 		SDLDollar = SDLS[SDLpt-4 : SDLpt+1]
-//line grammar.y:542
+//line grammar.y:736
 		{ // LET($1) ...
+			pattern := SDLDollar[2].letPatternList[0]
+			if len(SDLDollar[2].letPatternList) > 1 {
+				pattern = &LetPattern{NodeInfo: NewNodeInfo(SDLDollar[2].letPatternList[0].Pos(), SDLDollar[2].letPatternList[len(SDLDollar[2].letPatternList)-1].End()), Children: SDLDollar[2].letPatternList}
+			}
 			SDLVAL.stmt = &LetStmt{
 				NodeInfo:  NewNodeInfo(SDLDollar[1].node.(Node).Pos(), SDLDollar[4].expr.End()),
-				Variables: SDLDollar[2].identList,
+				Variables: pattern.Leaves(),
+				Pattern:   pattern,
 				Value:     SDLDollar[4].expr,
 			}
 		}
-	case 78:
+	case 114:
+		SDLDollar = SDLS[SDLpt-1 : SDLpt+1]
+//line grammar.y:751
+		{
+			SDLVAL.letPatternList = []*LetPattern{SDLDollar[1].letPattern}
+		}
+	case 115:
+		SDLDollar = SDLS[SDLpt-3 : SDLpt+1]
+//line grammar.y:752
+		{
+			SDLVAL.letPatternList = append(SDLDollar[1].letPatternList, SDLDollar[3].letPattern)
+		}
+	case 116:
+		SDLDollar = SDLS[SDLpt-1 : SDLpt+1]
+//line grammar.y:756
+		{
+			SDLVAL.letPattern = &LetPattern{NodeInfo: SDLDollar[1].ident.NodeInfo, Ident: SDLDollar[1].ident}
+		}
+	case 117:
+		SDLDollar = SDLS[SDLpt-3 : SDLpt+1]
+//line grammar.y:757
+		{
+			if len(SDLDollar[2].letPatternList) == 1 {
+				SDLVAL.letPattern = SDLDollar[2].letPatternList[0] // (a) is just a
+			} else {
+				SDLVAL.letPattern = &LetPattern{NodeInfo: NewNodeInfo(SDLDollar[1].node.(Node).Pos(), SDLDollar[3].node.(Node).End()), Children: SDLDollar[2].letPatternList}
+			}
+		}
+	case 118:
 		SDLDollar = SDLS[SDLpt-2 : SDLpt+1]
-//line grammar.y:567
+//line grammar.y:782
 		{
 			SDLVAL.stmt = &ReturnStmt{NodeInfo: NewNodeInfo(SDLDollar[1].node.(Node).Pos(), SDLDollar[2].expr.(Node).End()), ReturnValue: SDLDollar[2].expr}
 		}
-	case 79:
+	case 119:
 		SDLDollar = SDLS[SDLpt-2 : SDLpt+1]
-//line grammar.y:568
+//line grammar.y:783
 		{
 			SDLVAL.stmt = &ReturnStmt{NodeInfo: NewNodeInfo(SDLDollar[1].node.(Node).Pos(), SDLDollar[2].node.(Node).End()), ReturnValue: nil}
 		}
-	case 80:
+	case 120:
+		SDLDollar = SDLS[SDLpt-2 : SDLpt+1]
+//line grammar.y:789
+		{
+			SDLVAL.stmt = &SpawnStmt{NodeInfo: NewNodeInfo(SDLDollar[1].node.(Node).Pos(), SDLDollar[2].expr.End()), Call: SDLDollar[2].expr}
+		}
+	case 121:
 		SDLDollar = SDLS[SDLpt-2 : SDLpt+1]
-//line grammar.y:574
+//line grammar.y:795
 		{ // WAIT($1) IDENTIFIER($2) ...
 			idents := SDLDollar[2].identList
 			endNode := idents[len(idents)-1] // End at the last identifier in the list
 			SDLVAL.expr = &WaitExpr{FutureNames: idents}
 			SDLVAL.expr.(*WaitExpr).NodeInfo = NewNodeInfo(SDLDollar[1].node.Pos(), endNode.End())
 		}
-	case 81:
+	case 122:
+		SDLDollar = SDLS[SDLpt-3 : SDLpt+1]
+//line grammar.y:801
+		{
+			idents := SDLDollar[2].identList
+			SDLVAL.expr = &WaitExpr{FutureNames: idents, Policy: WaitRequireAll}
+			SDLVAL.expr.(*WaitExpr).NodeInfo = NewNodeInfo(SDLDollar[1].node.Pos(), SDLDollar[3].node.End())
+		}
+	case 123:
+		SDLDollar = SDLS[SDLpt-6 : SDLpt+1]
+//line grammar.y:806
+		{
+			idents := SDLDollar[2].identList
+			SDLVAL.expr = &WaitExpr{FutureNames: idents, Policy: WaitAnyOf, AnyOfCount: SDLDollar[5].expr}
+			SDLVAL.expr.(*WaitExpr).NodeInfo = NewNodeInfo(SDLDollar[1].node.Pos(), SDLDollar[6].node.End())
+		}
+	case 124:
 		SDLDollar = SDLS[SDLpt-4 : SDLpt+1]
-//line grammar.y:580
+//line grammar.y:811
 		{ // WAIT($1) IDENTIFIER($2) ...
 			idents := SDLDollar[2].identList
 			endNode := idents[len(idents)-1] // End at the last identifier in the list
@@ -1490,35 +1931,35 @@ SDLdefault:
 			}
 			SDLVAL.expr.(*WaitExpr).NodeInfo = NewNodeInfo(SDLDollar[1].node.Pos(), endNode.End())
 		}
-	case 82:
+	case 125:
 		SDLDollar = SDLS[SDLpt-3 : SDLpt+1]
-//line grammar.y:607
+//line grammar.y:838
 		{
 			SDLVAL.exprMap = map[string]Expr{SDLDollar[1].ident.Value: SDLDollar[3].expr}
 		}
-	case 83:
+	case 126:
 		SDLDollar = SDLS[SDLpt-5 : SDLpt+1]
-//line grammar.y:608
+//line grammar.y:839
 		{
 			name := SDLDollar[3].ident.Value
 			SDLDollar[1].exprMap[name] = SDLDollar[5].expr
 			SDLVAL.exprMap = SDLDollar[1].exprMap
 		}
-	case 84:
+	case 127:
 		SDLDollar = SDLS[SDLpt-1 : SDLpt+1]
-//line grammar.y:616
+//line grammar.y:847
 		{
 			SDLVAL.exprList = []Expr{SDLDollar[1].expr}
 		}
-	case 85:
+	case 128:
 		SDLDollar = SDLS[SDLpt-3 : SDLpt+1]
-//line grammar.y:617
+//line grammar.y:848
 		{
 			SDLVAL.exprList = append(SDLDollar[1].exprList, SDLDollar[3].expr)
 		}
-	case 86:
+	case 129:
 		SDLDollar = SDLS[SDLpt-4 : SDLpt+1]
-//line grammar.y:622
+//line grammar.y:853
 		{ // IF($1) ...
 			endNode := Stmt(SDLDollar[3].blockStmt)
 			if SDLDollar[4].stmt != nil {
@@ -1531,238 +1972,254 @@ SDLdefault:
 				Else:      SDLDollar[4].stmt,
 			}
 		}
-	case 87:
+	case 130:
+		SDLDollar = SDLS[SDLpt-7 : SDLpt+1]
+//line grammar.y:863
+		{
+			endNode := Stmt(SDLDollar[6].blockStmt)
+			if SDLDollar[7].stmt != nil {
+				endNode = SDLDollar[7].stmt
+			}
+			SDLVAL.ifStmt = &IfStmt{
+				NodeInfo:  NewNodeInfo(SDLDollar[1].node.(Node).Pos(), endNode.End()),
+				Binding:   SDLDollar[3].ident,
+				Condition: SDLDollar[5].expr,
+				Then:      SDLDollar[6].blockStmt,
+				Else:      SDLDollar[7].stmt,
+			}
+		}
+	case 131:
 		SDLDollar = SDLS[SDLpt-0 : SDLpt+1]
-//line grammar.y:635
+//line grammar.y:877
 		{
 			SDLVAL.stmt = nil
 		}
-	case 88:
+	case 132:
 		SDLDollar = SDLS[SDLpt-2 : SDLpt+1]
-//line grammar.y:636
+//line grammar.y:878
 		{
 			SDLVAL.stmt = SDLDollar[2].ifStmt
 		}
-	case 89:
+	case 133:
 		SDLDollar = SDLS[SDLpt-2 : SDLpt+1]
-//line grammar.y:637
+//line grammar.y:879
 		{
 			SDLVAL.stmt = SDLDollar[2].blockStmt
 		}
-	case 90:
+	case 134:
 		SDLDollar = SDLS[SDLpt-2 : SDLpt+1]
-//line grammar.y:641
+//line grammar.y:883
 		{ // DISTRIBUTE($1) ... RBRACE($6)
 			SDLVAL.sampleExpr = &SampleExpr{FromExpr: SDLDollar[2].expr}
 			SDLVAL.sampleExpr.NodeInfo = NewNodeInfo(SDLDollar[1].node.(Node).Pos(), SDLDollar[2].expr.(Node).End())
 		}
-	case 91:
+	case 135:
 		SDLDollar = SDLS[SDLpt-0 : SDLpt+1]
-//line grammar.y:647
+//line grammar.y:889
 		{
 			SDLVAL.expr = nil
 		}
-	case 92:
+	case 136:
 		SDLDollar = SDLS[SDLpt-1 : SDLpt+1]
-//line grammar.y:647
+//line grammar.y:889
 		{
 			SDLVAL.expr = SDLDollar[1].expr
 		}
-	case 93:
+	case 137:
 		SDLDollar = SDLS[SDLpt-5 : SDLpt+1]
-//line grammar.y:649
+//line grammar.y:891
 		{
 			SDLVAL.tupleExpr = &TupleExpr{Children: append(SDLDollar[2].exprList, SDLDollar[4].expr)}
 		}
-	case 94:
+	case 138:
 		SDLDollar = SDLS[SDLpt-2 : SDLpt+1]
-//line grammar.y:654
+//line grammar.y:896
 		{ // GO($1) ... BlockStmt($4)
 			SDLVAL.expr = &GoExpr{Stmt: SDLDollar[2].blockStmt}
 			SDLVAL.expr.(*GoExpr).NodeInfo = NewNodeInfo(SDLDollar[1].node.(Node).Pos(), SDLDollar[2].blockStmt.End())
 		}
-	case 95:
+	case 139:
 		SDLDollar = SDLS[SDLpt-2 : SDLpt+1]
-//line grammar.y:658
+//line grammar.y:900
 		{
 			SDLVAL.expr = &GoExpr{Expr: SDLDollar[2].expr}
 			SDLVAL.expr.(*GoExpr).NodeInfo = NewNodeInfo(SDLDollar[1].node.(Node).Pos(), SDLDollar[2].expr.End())
 		}
-	case 96:
+	case 140:
 		SDLDollar = SDLS[SDLpt-3 : SDLpt+1]
-//line grammar.y:662
+//line grammar.y:904
 		{ // GO($1) ... BlockStmt($4)
 			SDLVAL.expr = &GoExpr{LoopExpr: SDLDollar[2].expr, Stmt: SDLDollar[3].blockStmt}
 			SDLVAL.expr.(*GoExpr).NodeInfo = NewNodeInfo(SDLDollar[1].node.(Node).Pos(), SDLDollar[3].blockStmt.End())
 		}
-	case 97:
+	case 141:
 		SDLDollar = SDLS[SDLpt-3 : SDLpt+1]
-//line grammar.y:666
+//line grammar.y:908
 		{
 			SDLVAL.expr = &GoExpr{LoopExpr: SDLDollar[2].expr, Expr: SDLDollar[3].expr}
 			SDLVAL.expr.(*GoExpr).NodeInfo = NewNodeInfo(SDLDollar[1].node.(Node).Pos(), SDLDollar[3].expr.End())
 		}
-	case 98:
+	case 142:
 		SDLDollar = SDLS[SDLpt-1 : SDLpt+1]
-//line grammar.y:675
+//line grammar.y:917
 		{
 			SDLDollar[1].chainedExpr.Unchain(nil)
 			SDLVAL.expr = SDLDollar[1].chainedExpr.UnchainedExpr
 		}
-	case 99:
+	case 143:
 		SDLDollar = SDLS[SDLpt-1 : SDLpt+1]
-//line grammar.y:679
+//line grammar.y:921
 		{
 			SDLVAL.expr = SDLDollar[1].expr
 		}
-	case 100:
+	case 144:
 		SDLDollar = SDLS[SDLpt-1 : SDLpt+1]
-//line grammar.y:680
+//line grammar.y:922
 		{
 			SDLVAL.expr = SDLDollar[1].expr
 		}
-	case 101:
+	case 145:
 		SDLDollar = SDLS[SDLpt-1 : SDLpt+1]
-//line grammar.y:707
+//line grammar.y:949
 		{
 			SDLVAL.chainedExpr = &ChainedExpr{Children: []Expr{SDLDollar[1].expr}}
 		}
-	case 102:
+	case 146:
 		SDLDollar = SDLS[SDLpt-3 : SDLpt+1]
-//line grammar.y:710
+//line grammar.y:952
 		{
 			SDLDollar[1].chainedExpr.Children = append(SDLDollar[1].chainedExpr.Children, SDLDollar[3].expr)
 			SDLDollar[1].chainedExpr.Operators = append(SDLDollar[1].chainedExpr.Operators, SDLDollar[2].node.String())
 			SDLVAL.chainedExpr = SDLDollar[1].chainedExpr
 		}
-	case 103:
+	case 147:
 		SDLDollar = SDLS[SDLpt-3 : SDLpt+1]
-//line grammar.y:715
+//line grammar.y:957
 		{
 			SDLDollar[1].chainedExpr.Children = append(SDLDollar[1].chainedExpr.Children, SDLDollar[3].expr)
 			SDLDollar[1].chainedExpr.Operators = append(SDLDollar[1].chainedExpr.Operators, SDLDollar[2].node.String())
 			SDLVAL.chainedExpr = SDLDollar[1].chainedExpr
 		}
-	case 104:
+	case 148:
 		SDLDollar = SDLS[SDLpt-1 : SDLpt+1]
-//line grammar.y:722
+//line grammar.y:964
 		{
 			SDLVAL.expr = SDLDollar[1].expr
 		}
-	case 105:
+	case 149:
 		SDLDollar = SDLS[SDLpt-2 : SDLpt+1]
-//line grammar.y:724
+//line grammar.y:966
 		{
 			SDLVAL.expr = &UnaryExpr{Operator: SDLDollar[1].node.String(), Right: SDLDollar[2].expr}
 			SDLVAL.expr.(*UnaryExpr).NodeInfo = NewNodeInfo(SDLDollar[1].node.(Node).Pos(), SDLDollar[2].expr.(Node).End())
 		}
-	case 106:
+	case 150:
 		SDLDollar = SDLS[SDLpt-2 : SDLpt+1]
-//line grammar.y:729
+//line grammar.y:971
 		{
 			SDLVAL.expr = &UnaryExpr{Operator: SDLDollar[1].node.String(), Right: SDLDollar[2].expr}
 			SDLVAL.expr.(*UnaryExpr).NodeInfo = NewNodeInfo(SDLDollar[1].node.(Node).Pos(), SDLDollar[2].expr.(Node).End())
 		}
-	case 107:
+	case 151:
 		SDLDollar = SDLS[SDLpt-1 : SDLpt+1]
-//line grammar.y:737
+//line grammar.y:979
 		{
 			SDLVAL.expr = SDLDollar[1].expr
 		}
-	case 108:
+	case 152:
 		SDLDollar = SDLS[SDLpt-1 : SDLpt+1]
-//line grammar.y:738
+//line grammar.y:980
 		{
 			SDLVAL.expr = SDLDollar[1].expr
 		}
-	case 109:
+	case 153:
 		SDLDollar = SDLS[SDLpt-1 : SDLpt+1]
-//line grammar.y:742
+//line grammar.y:984
 		{
 			SDLVAL.expr = SDLDollar[1].expr
 		}
-	case 110:
+	case 154:
 		SDLDollar = SDLS[SDLpt-1 : SDLpt+1]
-//line grammar.y:743
+//line grammar.y:985
 		{
 			SDLVAL.expr = SDLDollar[1].ident
 		}
-	case 111:
+	case 155:
 		SDLDollar = SDLS[SDLpt-1 : SDLpt+1]
-//line grammar.y:744
+//line grammar.y:986
 		{
 			SDLVAL.expr = SDLDollar[1].distributeExpr
 		}
-	case 112:
+	case 156:
 		SDLDollar = SDLS[SDLpt-1 : SDLpt+1]
-//line grammar.y:745
+//line grammar.y:987
 		{
 			SDLVAL.expr = SDLDollar[1].sampleExpr
 		}
-	case 113:
+	case 157:
 		SDLDollar = SDLS[SDLpt-1 : SDLpt+1]
-//line grammar.y:746
+//line grammar.y:988
 		{
 			SDLVAL.expr = SDLDollar[1].tupleExpr
 		}
-	case 114:
+	case 158:
 		SDLDollar = SDLS[SDLpt-1 : SDLpt+1]
-//line grammar.y:747
+//line grammar.y:989
 		{
 			SDLVAL.expr = SDLDollar[1].expr
 		}
-	case 115:
+	case 159:
 		SDLDollar = SDLS[SDLpt-1 : SDLpt+1]
-//line grammar.y:748
+//line grammar.y:990
 		{
 			SDLVAL.expr = SDLDollar[1].expr
 		}
-	case 116:
+	case 160:
 		SDLDollar = SDLS[SDLpt-1 : SDLpt+1]
-//line grammar.y:749
+//line grammar.y:991
 		{
 			SDLVAL.expr = SDLDollar[1].expr
 		}
-	case 117:
+	case 161:
 		SDLDollar = SDLS[SDLpt-3 : SDLpt+1]
-//line grammar.y:752
+//line grammar.y:994
 		{
 			SDLVAL.expr = SDLDollar[2].expr
 		}
-	case 118:
+	case 162:
 		SDLDollar = SDLS[SDLpt-1 : SDLpt+1]
-//line grammar.y:755
+//line grammar.y:997
 		{
 			// SDLlex.(*Lexer).lval)
 			SDLVAL.expr = SDLDollar[1].expr
 		}
-	case 119:
+	case 163:
 		SDLDollar = SDLS[SDLpt-1 : SDLpt+1]
-//line grammar.y:759
+//line grammar.y:1001
 		{
 			SDLVAL.expr = SDLDollar[1].expr
 		}
-	case 120:
+	case 164:
 		SDLDollar = SDLS[SDLpt-1 : SDLpt+1]
-//line grammar.y:760
+//line grammar.y:1002
 		{
 			SDLVAL.expr = SDLDollar[1].expr
 		}
-	case 121:
+	case 165:
 		SDLDollar = SDLS[SDLpt-1 : SDLpt+1]
-//line grammar.y:761
+//line grammar.y:1003
 		{
 			SDLVAL.expr = SDLDollar[1].expr
 		}
-	case 122:
+	case 166:
 		SDLDollar = SDLS[SDLpt-1 : SDLpt+1]
-//line grammar.y:762
+//line grammar.y:1004
 		{
 			SDLVAL.expr = SDLDollar[1].expr
 		}
-	case 123:
+	case 167:
 		SDLDollar = SDLS[SDLpt-4 : SDLpt+1]
-//line grammar.y:766
+//line grammar.y:1008
 		{ // Expression "[" Key "]"
 			SDLVAL.expr = &IndexExpr{
 				Receiver: SDLDollar[1].expr,
@@ -1770,9 +2227,9 @@ SDLdefault:
 			}
 			SDLVAL.expr.(*IndexExpr).NodeInfo = NewNodeInfo(SDLDollar[1].expr.Pos(), SDLDollar[4].node.End())
 		}
-	case 124:
+	case 168:
 		SDLDollar = SDLS[SDLpt-3 : SDLpt+1]
-//line grammar.y:776
+//line grammar.y:1018
 		{ // PrimaryExpr($1) DOT($2) IDENTIFIER($3)
 			SDLVAL.expr = &MemberAccessExpr{
 				Receiver: SDLDollar[1].ident,
@@ -1780,9 +2237,9 @@ SDLdefault:
 			}
 			SDLVAL.expr.(*MemberAccessExpr).NodeInfo = NewNodeInfo(SDLDollar[1].ident.Pos(), SDLDollar[3].ident.End())
 		}
-	case 125:
+	case 169:
 		SDLDollar = SDLS[SDLpt-3 : SDLpt+1]
-//line grammar.y:783
+//line grammar.y:1025
 		{ // PrimaryExpr($1) DOT($2) IDENTIFIER($3)
 			SDLVAL.expr = &MemberAccessExpr{
 				Receiver: SDLDollar[1].expr,
@@ -1790,16 +2247,16 @@ SDLdefault:
 			}
 			SDLVAL.expr.(*MemberAccessExpr).NodeInfo = NewNodeInfo(SDLDollar[1].expr.Pos(), SDLDollar[3].ident.End())
 		}
-	case 126:
+	case 170:
 		SDLDollar = SDLS[SDLpt-3 : SDLpt+1]
-//line grammar.y:793
+//line grammar.y:1035
 		{ // PrimaryExpr($1) LPAREN($2) ArgList($3) RPAREN($4)
 			SDLVAL.expr = &CallExpr{Function: SDLDollar[1].expr}
 			SDLVAL.expr.(*CallExpr).NodeInfo = NewNodeInfo(SDLDollar[1].expr.Pos(), SDLDollar[3].node.End())
 		}
-	case 127:
+	case 171:
 		SDLDollar = SDLS[SDLpt-4 : SDLpt+1]
-//line grammar.y:797
+//line grammar.y:1039
 		{ // PrimaryExpr($1) LPAREN($2) ArgList($3) RPAREN($4)
 			endNode := SDLDollar[4].node.(Node) // End at RPAREN
 			if len(SDLDollar[3].exprList) > 0 {
@@ -1812,9 +2269,9 @@ SDLdefault:
 			}
 			SDLVAL.expr.(*CallExpr).NodeInfo = NewNodeInfo(SDLDollar[1].expr.Pos(), endNode.End())
 		}
-	case 128:
+	case 172:
 		SDLDollar = SDLS[SDLpt-4 : SDLpt+1]
-//line grammar.y:809
+//line grammar.y:1051
 		{ // PrimaryExpr($1) LPAREN($2) ArgList($3) RPAREN($4)
 			endNode := SDLDollar[4].node.(Node) // End at RPAREN
 			SDLVAL.expr = &CallExpr{
@@ -1824,135 +2281,135 @@ SDLdefault:
 			}
 			SDLVAL.expr.(*CallExpr).NodeInfo = NewNodeInfo(SDLDollar[1].expr.Pos(), endNode.End())
 		}
-	case 129:
+	case 173:
 		SDLDollar = SDLS[SDLpt-6 : SDLpt+1]
-//line grammar.y:821
+//line grammar.y:1063
 		{
 			SDLVAL.distributeExpr = &DistributeExpr{TotalProb: SDLDollar[2].expr, Cases: SDLDollar[4].caseExprList, Default: SDLDollar[5].expr} /* TODO: Pos */
 		}
-	case 130:
+	case 174:
 		SDLDollar = SDLS[SDLpt-0 : SDLpt+1]
-//line grammar.y:827
+//line grammar.y:1069
 		{
 			SDLVAL.caseExprList = []*CaseExpr{}
 		}
-	case 131:
+	case 175:
 		SDLDollar = SDLS[SDLpt-1 : SDLpt+1]
-//line grammar.y:828
+//line grammar.y:1070
 		{
 			SDLVAL.caseExprList = SDLDollar[1].caseExprList
 		}
-	case 132:
+	case 176:
 		SDLDollar = SDLS[SDLpt-1 : SDLpt+1]
-//line grammar.y:832
+//line grammar.y:1074
 		{
 			SDLVAL.caseExprList = []*CaseExpr{SDLDollar[1].caseExpr}
 		}
-	case 133:
+	case 177:
 		SDLDollar = SDLS[SDLpt-2 : SDLpt+1]
-//line grammar.y:833
+//line grammar.y:1075
 		{
 			SDLVAL.caseExprList = append(SDLDollar[1].caseExprList, SDLDollar[2].caseExpr)
 		}
-	case 134:
+	case 178:
 		SDLDollar = SDLS[SDLpt-3 : SDLpt+1]
-//line grammar.y:837
+//line grammar.y:1079
 		{
 			SDLVAL.caseExpr = &CaseExpr{Condition: SDLDollar[1].expr, Body: SDLDollar[3].expr}
 		}
-	case 135:
+	case 179:
 		SDLDollar = SDLS[SDLpt-4 : SDLpt+1]
-//line grammar.y:840
+//line grammar.y:1082
 		{ // allow optional comma
 			SDLVAL.caseExpr = &CaseExpr{Condition: SDLDollar[1].expr, Body: SDLDollar[3].expr}
 		}
-	case 136:
+	case 180:
 		SDLDollar = SDLS[SDLpt-0 : SDLpt+1]
-//line grammar.y:846
+//line grammar.y:1088
 		{
 			SDLVAL.expr = nil
 		}
-	case 137:
+	case 181:
 		SDLDollar = SDLS[SDLpt-1 : SDLpt+1]
-//line grammar.y:847
+//line grammar.y:1089
 		{
 			SDLVAL.expr = SDLDollar[1].expr
 		}
-	case 138:
+	case 182:
 		SDLDollar = SDLS[SDLpt-3 : SDLpt+1]
-//line grammar.y:851
+//line grammar.y:1093
 		{
 			SDLVAL.expr = SDLDollar[3].expr
 		}
-	case 139:
+	case 183:
 		SDLDollar = SDLS[SDLpt-4 : SDLpt+1]
-//line grammar.y:852
+//line grammar.y:1094
 		{
 			SDLVAL.expr = SDLDollar[3].expr
 		}
-	case 140:
+	case 184:
 		SDLDollar = SDLS[SDLpt-6 : SDLpt+1]
-//line grammar.y:856
+//line grammar.y:1098
 		{
 			SDLVAL.switchStmt = &SwitchStmt{Expr: SDLDollar[2].expr, Cases: SDLDollar[4].caseStmtList, Default: SDLDollar[5].stmt} /* TODO: Pos */
 		}
-	case 141:
+	case 185:
 		SDLDollar = SDLS[SDLpt-0 : SDLpt+1]
-//line grammar.y:862
+//line grammar.y:1104
 		{
 			SDLVAL.caseStmtList = []*CaseStmt{}
 		}
-	case 142:
+	case 186:
 		SDLDollar = SDLS[SDLpt-1 : SDLpt+1]
-//line grammar.y:863
+//line grammar.y:1105
 		{
 			SDLVAL.caseStmtList = SDLDollar[1].caseStmtList
 		}
-	case 143:
+	case 187:
 		SDLDollar = SDLS[SDLpt-1 : SDLpt+1]
-//line grammar.y:867
+//line grammar.y:1109
 		{
 			SDLVAL.caseStmtList = []*CaseStmt{SDLDollar[1].caseStmt}
 		}
-	case 144:
+	case 188:
 		SDLDollar = SDLS[SDLpt-2 : SDLpt+1]
-//line grammar.y:868
+//line grammar.y:1110
 		{
 			SDLVAL.caseStmtList = append(SDLDollar[1].caseStmtList, SDLDollar[2].caseStmt)
 		}
-	case 145:
+	case 189:
 		SDLDollar = SDLS[SDLpt-3 : SDLpt+1]
-//line grammar.y:872
+//line grammar.y:1114
 		{
 			SDLVAL.caseStmt = &CaseStmt{NodeInfo: NewNodeInfo(SDLDollar[1].expr.(Node).Pos(), SDLDollar[3].stmt.End()), Condition: SDLDollar[1].expr, Body: SDLDollar[3].stmt}
 		}
-	case 146:
+	case 190:
 		SDLDollar = SDLS[SDLpt-0 : SDLpt+1]
-//line grammar.y:876
+//line grammar.y:1118
 		{
 			SDLVAL.stmt = nil
 		}
-	case 147:
+	case 191:
 		SDLDollar = SDLS[SDLpt-1 : SDLpt+1]
-//line grammar.y:877
+//line grammar.y:1119
 		{
 			SDLVAL.stmt = SDLDollar[1].stmt
 		}
-	case 148:
+	case 192:
 		SDLDollar = SDLS[SDLpt-3 : SDLpt+1]
-//line grammar.y:881
+//line grammar.y:1123
 		{
 			SDLVAL.stmt = SDLDollar[3].stmt
 		}
-	case 149:
+	case 193:
 		SDLDollar = SDLS[SDLpt-1 : SDLpt+1]
-//line grammar.y:885
+//line grammar.y:1127
 		{
 			SDLVAL.stmt = &ExprStmt{NodeInfo: NewNodeInfo(SDLDollar[1].expr.(Node).Pos(), SDLDollar[1].expr.(Node).End()), Expression: SDLDollar[1].expr}
 		}
-	case 150:
+	case 194:
 		SDLDollar = SDLS[SDLpt-1 : SDLpt+1]
-//line grammar.y:886
+//line grammar.y:1128
 		{
 			SDLVAL.stmt = &ExprStmt{NodeInfo: NewNodeInfo(SDLDollar[1].expr.(Node).Pos(), SDLDollar[1].expr.(Node).End()), Expression: SDLDollar[1].expr}
 		}
@@ -203,6 +203,83 @@ func TestParseComponentParams(t *testing.T) {
 	// Add position check if needed
 }
 
+// TestParseParamConstraints verifies range and allowed value constraints on params.
+func TestParseParamConstraints(t *testing.T) {
+	input := `component C {
+        param Rate float = 0.8 in [0, 1]
+        param Offset int in [-10, 10]
+        param Policy = "lru" in {"lru", "lfu"}
+    }`
+	ast := parseString(t, input)
+	comp := firstDecl(t, ast).(*ComponentDecl)
+	require.Len(t, comp.Body, 3)
+
+	rate := comp.Body[0].(*ParamDecl)
+	assertLiteralWithValue(t, rate.DefaultValue, FloatType, 0.8)
+	require.NotNil(t, rate.Constraint)
+	assert.True(t, rate.Constraint.IsRange())
+	assertLiteralWithValue(t, rate.Constraint.Min, IntType, int64(0))
+	assertLiteralWithValue(t, rate.Constraint.Max, IntType, int64(1))
+	assert.Equal(t, rate.Constraint.End(), rate.End())
+
+	offset := comp.Body[1].(*ParamDecl)
+	assert.Nil(t, offset.DefaultValue)
+	require.NotNil(t, offset.Constraint)
+	assert.Equal(t, "-", offset.Constraint.Min.(*UnaryExpr).Operator)
+
+	policy := comp.Body[2].(*ParamDecl)
+	require.NotNil(t, policy.Constraint)
+	assert.False(t, policy.Constraint.IsRange())
+	require.Len(t, policy.Constraint.Allowed, 2)
+	assertLiteralWithValue(t, policy.Constraint.Allowed[1], StrType, "lfu")
+}
+
+func TestParseOptions(t *testing.T) {
+	input := `options { max_depth = 64, seed = 7 }
+    system App(server Server) {
+        options { max_depth = 8 }
+    }`
+	ast := parseString(t, input)
+	require.Len(t, ast.Declarations, 2)
+
+	opts := ast.Declarations[0].(*OptionsDecl)
+	require.Len(t, opts.Options, 2)
+	assert.Equal(t, "max_depth", opts.Options[0].Var.Value)
+	assertLiteralWithValue(t, opts.Options[1].Value, IntType, int64(7))
+
+	sys := ast.Declarations[1].(*SystemDecl)
+	require.Len(t, sys.Body, 1)
+	sysOpts := sys.Body[0].(*OptionsDecl)
+	require.Len(t, sysOpts.Options, 1)
+	assertLiteralWithValue(t, sysOpts.Options[0].Value, IntType, int64(8))
+}
+
+func TestParseMethodAnnotations(t *testing.T) {
+	input := `component Cache {
+        @memoize(ttl = 5s, hit_rate = 0.8)
+        method Get() Bool { return true }
+        @memoize
+        method Put() Bool { return true }
+    }`
+	ast := parseString(t, input)
+	comp := firstDecl(t, ast).(*ComponentDecl)
+	require.Len(t, comp.Body, 2)
+
+	get := comp.Body[0].(*MethodDecl)
+	require.Len(t, get.Annotations, 1)
+	assert.Equal(t, "memoize", get.Annotations[0].Name.Value)
+	assertLiteralWithValue(t, get.Annotations[0].Arg("ttl"), FloatType, 5.0)
+	assert.Equal(t, get.Annotations[0].Pos(), get.Pos())
+	ttl, hitRate, ok := get.Memoize()
+	assert.True(t, ok)
+	assert.Equal(t, 5.0, ttl)
+	assert.Equal(t, 0.8, hitRate)
+
+	put := comp.Body[1].(*MethodDecl)
+	require.Len(t, put.Annotations, 1)
+	assert.Empty(t, put.Annotations[0].Args)
+}
+
 // func TestParseBinaryOpsPrecedence(t *testing.T) {
 // 	input := "a + b * c;" // Expect (a + (b * c))
 // 	ast := parseString(t, input)
@@ -645,3 +722,73 @@ func TestParseErrors(t *testing.T) {
 		})
 	}
 }
+
+func TestParseReservedKeywordAsName(t *testing.T) {
+	testCases := []struct {
+		name    string
+		input   string
+		keyword string
+		line    int
+		col     int
+	}{
+		{"Component", "component system {}", "system", 1, 11},
+		{"Method", "component C {\n  method wait() Bool { return true }\n}", "wait", 2, 10},
+		{"Param", "component C {\n  param go Int = 1\n}", "go", 2, 9},
+		{"MethodParam", "component C {\n  method Get(for Int) Bool { return true }\n}", "for", 2, 14},
+		{"Uses", "component D {}\ncomponent C {\n  uses method D()\n}", "method", 3, 8},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := parseStringWithError(t, tc.input)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), fmt.Sprintf("'%s' is a reserved keyword and cannot be used as a name here", tc.keyword))
+			var syntaxErr *SyntaxError
+			require.ErrorAs(t, err, &syntaxErr)
+			assert.Equal(t, tc.line, syntaxErr.Pos.Line)
+			assert.Equal(t, tc.col, syntaxErr.Pos.Col)
+		})
+	}
+
+	// Keywords in the wrong place that are not names keep the generic error
+	_, err := parseStringWithError(t, "component C { return }")
+	require.Error(t, err)
+	assert.NotContains(t, err.Error(), "reserved keyword")
+}
+
+func TestParseCommentsInArgumentLists(t *testing.T) {
+	file := parseString(t, `
+component C {
+  uses db Database(
+    Timeout = 100, // milliseconds
+    /* before */ Retries = 3 /* after */
+  )
+  method Get(
+    key String, // the key
+    /* how many */ count Int
+  ) Bool {
+    return self.Other(
+      key, // the key
+      count /* trailing */
+    )
+  }
+}
+`)
+	comps, err := file.GetComponents()
+	require.NoError(t, err)
+	comp := comps["C"]
+	require.NotNil(t, comp)
+
+	uses, err := comp.GetDependency("db")
+	require.NoError(t, err)
+	require.Len(t, uses.Overrides, 2)
+	assert.Equal(t, "Retries", uses.Overrides[1].Var.Value)
+
+	method, err := comp.GetMethod("Get")
+	require.NoError(t, err)
+	require.Len(t, method.Parameters, 2)
+	assert.Equal(t, "count", method.Parameters[1].Name.Value)
+
+	ret := method.Body.Statements[0].(*ReturnStmt)
+	call := ret.ReturnValue.(*CallExpr)
+	assert.Len(t, call.ArgList, 2)
+}
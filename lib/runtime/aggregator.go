@@ -12,38 +12,115 @@ type Aggregator interface {
 	Eval(eval *SimpleEval, env *Env[Value], currTime *core.Duration, futures []Value) (result Value, returned bool)
 }
 
-type WaitAll struct {
-	TimeoutValue       core.Duration
-	SuccessResultCodes []Value
+// evalFuture runs the body of a future and returns its result and latency.
+func evalFuture(eval *SimpleEval, currTime *core.Duration, futureVal Value) (res Value, ret bool, futureLatency core.Duration) {
+	results, rets, latencies := evalFutureCalls(eval, currTime, futureVal, 1)
+	return results[0], rets[0], latencies[0]
 }
 
-func (t *WaitAll) Eval(eval *SimpleEval, env *Env[Value], currTime *core.Duration, futures []Value) (result Value, returned bool) {
-	maxLatency := 0.0
-	allFuturesSucceeded := true
+// evalFutureCalls evaluates a future once, running its body calls times (eg
+// once for each iteration of a gobatch), and returns the result and latency
+// of every call.  The future is traced as a single exit taking as long as its
+// slowest call.
+func evalFutureCalls(eval *SimpleEval, currTime *core.Duration, futureVal Value, calls int64) (results []Value, rets []bool, latencies []core.Duration) {
+	if futureVal.Type.Tag != TypeTagFuture {
+		panic(fmt.Sprintf("wait expected a future, but got %s", futureVal.Type.String()))
+	}
+	fval := futureVal.Value.(*FutureValue)
+
+	// Set the tracer's parent context before evaluating the deferred code
+	if eval.Tracer != nil && fval.TraceID > 0 {
+		eval.Tracer.PushParentID(fval.TraceID)
+	}
+
+	// A very simplified evaluation of the "gobatch" block.
+	// Each call evaluates the body once to get a representative latency and result.
+	var futureLatency core.Duration
+	for range calls {
+		var latency core.Duration
+		res, ret := eval.Eval(fval.Body.Stmt, fval.Body.SavedEnv, &latency)
+		results = append(results, res)
+		rets = append(rets, ret)
+		latencies = append(latencies, latency)
+		futureLatency = max(futureLatency, latency)
+	}
+
+	// Emit exit event for the future
+	if eval.Tracer != nil && fval.TraceID > 0 {
+		// For go expressions, we don't have component/method info
+		eval.Tracer.Exit(float64(*currTime)/1e9, futureLatency, nil, nil, results[len(results)-1], nil)
+		eval.Tracer.PopParent()
+	}
+	return
+}
 
+// waitWithPolicy waits on futures that run in parallel under a requireAll or
+// anyOf policy.  A failed wait returns false from the calling method.  With
+// requireAll the call fails as soon as the first failing future finishes,
+// otherwise it takes as long as the slowest future.
+func waitWithPolicy(eval *SimpleEval, currTime *core.Duration, policy decl.WaitPolicy, minSuccesses int64, futures []Value) (result Value, returned bool) {
+	var results, succeeded []Value
+	var latencies []core.Duration
+	var maxLatency, firstFailure core.Duration
+	failed := false
 	for _, futureVal := range futures {
-		if futureVal.Type.Tag != TypeTagFuture {
-			panic(fmt.Sprintf("wait expected a future, but got %s", futureVal.Type.String()))
+		res, _, latency := evalFuture(eval, currTime, futureVal)
+		maxLatency = max(maxLatency, latency)
+		latencies = append(latencies, latency)
+		results = append(results, res)
+		if res.Type.Equals(BoolType) && res.IsFalse() {
+			if !failed || latency < firstFailure {
+				firstFailure = latency
+			}
+			failed = true
+		} else {
+			succeeded = append(succeeded, res)
 		}
-		fval := futureVal.Value.(*FutureValue)
+	}
+	eval.logWait(latencies, *currTime)
 
-		// Set the tracer's parent context before evaluating the deferred code
-		if eval.Tracer != nil && fval.TraceID > 0 {
-			eval.Tracer.PushParentID(fval.TraceID)
+	if policy == decl.WaitRequireAll {
+		if failed {
+			*currTime += firstFailure
+			result = BoolValue(false)
+			result.Time = firstFailure
+			return result, true
 		}
+		if len(results) == 1 {
+			result = results[0]
+		} else {
+			result = TupleValue(results...)
+		}
+	} else {
+		*currTime += maxLatency
+		if int64(len(succeeded)) < minSuccesses {
+			result = BoolValue(false)
+			result.Time = maxLatency
+			return result, true
+		}
+		var err error
+		result, err = NewValue(decl.ListType(futures[0].Type.Info.(*decl.FutureTypeInfo).ResultType), succeeded)
+		ensureNoErr(err)
+		result.Time = maxLatency
+		return result, false
+	}
+	result.Time = maxLatency
+	*currTime += maxLatency
+	return result, false
+}
 
-		// A very simplified evaluation of the "gobatch" block.
-		// It just evaluates the body once to get a representative latency and result.
-		var futureLatency core.Duration
-		res, ret := eval.Eval(fval.Body.Stmt, fval.Body.SavedEnv, &futureLatency)
+type WaitAll struct {
+	TimeoutValue       core.Duration
+	SuccessResultCodes []Value
+}
 
-		// Emit exit event for the future
-		if eval.Tracer != nil && fval.TraceID > 0 {
-			// For go expressions, we don't have component/method info
-			eval.Tracer.Exit(float64(*currTime)/1e9, futureLatency, nil, nil, res, nil)
-			eval.Tracer.PopParent()
-		}
+func (t *WaitAll) Eval(eval *SimpleEval, env *Env[Value], currTime *core.Duration, futures []Value) (result Value, returned bool) {
+	maxLatency := 0.0
+	allFuturesSucceeded := true
+	var latencies []core.Duration
 
+	for _, futureVal := range futures {
+		res, ret, futureLatency := evalFuture(eval, currTime, futureVal)
 		if !ret {
 			allFuturesSucceeded = false
 		} else {
@@ -60,7 +137,9 @@ func (t *WaitAll) Eval(eval *SimpleEval, env *Env[Value], currTime *core.Duratio
 		}
 
 		maxLatency = math.Max(maxLatency, futureLatency)
+		latencies = append(latencies, futureLatency)
 	}
+	eval.logWait(latencies, *currTime)
 
 	// For now, let's just assume the aggregation returns the first success code provided.
 	if allFuturesSucceeded && len(t.SuccessResultCodes) > 0 {
@@ -104,6 +183,40 @@ func (t *WaitAny) Eval(eval *SimpleEval, env *Env[Value], currTime *core.Duratio
 	return wa.Eval(eval, env, currTime, futures)
 }
 
+// WaitMax waits for every future, evaluating a batch future once for all of
+// its iterations, and takes as long as the slowest call.  Fanning out to N
+// calls therefore shows the tail of the slowest of N (see core.MaxOf) rather
+// than that of one call.  The result is false if any call returned false.
+type WaitMax struct{}
+
+func (t *WaitMax) Eval(eval *SimpleEval, env *Env[Value], currTime *core.Duration, futures []Value) (result Value, returned bool) {
+	maxLatency := 0.0
+	succeeded := true
+	var latencies []core.Duration
+	for _, futureVal := range futures {
+		calls := int64(1)
+		if loopValue := futureVal.Value.(*FutureValue).LoopValue; !loopValue.IsNil() {
+			calls, _ = loopValue.GetInt()
+		}
+		if calls <= 0 {
+			continue
+		}
+		results, _, callLatencies := evalFutureCalls(eval, currTime, futureVal, calls)
+		for i, res := range results {
+			if res.Type != nil && res.Type.Equals(BoolType) && res.IsFalse() {
+				succeeded = false
+			}
+			maxLatency = math.Max(maxLatency, callLatencies[i])
+		}
+		latencies = append(latencies, callLatencies...)
+	}
+	eval.logWait(latencies, *currTime)
+	result = BoolValue(succeeded)
+	result.Time = maxLatency
+	*currTime += maxLatency
+	return
+}
+
 func (r *Runtime) CreateAggregator(name string, aggParams []Value) Aggregator {
 	if name == "WaitAll" {
 		return &WaitAll{SuccessResultCodes: aggParams}
@@ -111,5 +224,8 @@ func (r *Runtime) CreateAggregator(name string, aggParams []Value) Aggregator {
 	if name == "WaitAny" {
 		return &WaitAny{SuccessResultCodes: aggParams}
 	}
+	if name == "Max" {
+		return &WaitMax{}
+	}
 	panic(fmt.Sprintf("Native aggregator not registered: %s", name))
 }
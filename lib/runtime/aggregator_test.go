@@ -0,0 +1,179 @@
+package runtime
+
+import (
+	"testing"
+
+	"github.com/panyam/sdl/lib/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const waitPolicySource = `
+import delay from "@stdlib/common.sdl"
+
+component Backends {
+  method Ok() Bool {
+    delay(10ms)
+    return true
+  }
+  method Fail() Bool {
+    delay(5ms)
+    return false
+  }
+  method Slow() Bool {
+    delay(20ms)
+    return true
+  }
+  method FailFast() Bool {
+    let a = go self.Ok()
+    let b = go self.Fail()
+    let _a, _b = wait a, b requireAll
+    return true
+  }
+  method AllSucceed() Bool {
+    let a = go self.Ok()
+    let c = go self.Slow()
+    let _a, _c = wait a, c requireAll
+    return true
+  }
+  method BestEffort() Bool {
+    let a = go self.Ok()
+    let b = go self.Fail()
+    let c = go self.Slow()
+    let _results = wait a, b, c anyOf(2)
+    return true
+  }
+  method TooFew() Bool {
+    let a = go self.Ok()
+    let b = go self.Fail()
+    let c = go self.Slow()
+    let _results = wait a, b, c anyOf(3)
+    return true
+  }
+}
+component Arch { uses backends Backends() }
+system Waits(arch Arch) { }
+`
+
+// TestWaitPolicies checks when a failed branch of a fan out fails the call.
+func TestWaitPolicies(t *testing.T) {
+	sys := parseAndLoad(t, waitPolicySource)
+	eval := NewSimpleEval(sys.File, nil)
+	call := func(method string) (Value, core.Duration) {
+		var latency core.Duration
+		result, _ := eval.Eval(&CallExpr{Function: buildMemberAccessExpr([]string{"arch", "backends", method})}, sys.Env.Push(), &latency)
+		require.Empty(t, eval.Errors, method)
+		return result, latency
+	}
+
+	// requireAll fails the call as soon as the failing branch finishes
+	result, latency := call("FailFast")
+	assert.True(t, result.IsFalse())
+	assert.InDelta(t, 0.005, latency, 1e-9)
+
+	result, latency = call("AllSucceed")
+	assert.True(t, result.IsTrue())
+	assert.InDelta(t, 0.02, latency, 1e-9)
+
+	// anyOf tolerates failures as long as enough branches succeed
+	result, latency = call("BestEffort")
+	assert.True(t, result.IsTrue())
+	assert.InDelta(t, 0.02, latency, 1e-9)
+
+	result, _ = call("TooFew")
+	assert.True(t, result.IsFalse())
+}
+
+// TestWaitMaxFanOut verifies that waiting on a fan out to 10 backends with
+// Max shows the tail of the slowest backend, matching the max-of-N
+// distribution from core.MaxOfN.
+func TestWaitMaxFanOut(t *testing.T) {
+	sys := parseAndLoad(t, `
+import delay, Max from "@stdlib/common.sdl"
+
+component Backend {
+  method Fetch() Bool {
+    delay(dist { 90 => 10ms, 9 => 50ms, 1 => 200ms })
+    return true
+  }
+}
+component Frontend {
+  uses backend Backend()
+  method Fan() Bool {
+    let calls = gobatch 10 {
+      return self.backend.Fetch()
+    }
+    return wait calls using Max()
+  }
+}
+system FanOut(frontend Frontend) { }
+`)
+	backend := &core.Outcomes[core.AccessResult]{}
+	backend.Add(90, core.AccessResult{Success: true, Latency: core.Millis(10)})
+	backend.Add(9, core.AccessResult{Success: true, Latency: core.Millis(50)})
+	backend.Add(1, core.AccessResult{Success: true, Latency: core.Millis(200)})
+	expected := core.MaxOfN(backend, 10)
+
+	eval := NewSimpleEval(sys.File, nil)
+	eval.SetSeed(42)
+	sampled := &core.Outcomes[core.AccessResult]{}
+	for range 5000 {
+		var currTime core.Duration
+		result, err := eval.EvalCall(&CallExpr{Function: buildMemberAccessExpr([]string{"frontend", "Fan"})}, sys.Env.Push(), &currTime)
+		require.NoError(t, err)
+		require.True(t, result.BoolVal())
+		sampled.Add(1, core.AccessResult{Success: true, Latency: currTime})
+	}
+
+	assert.InDelta(t, core.Millis(200), core.PercentileLatency(expected, 0.99), 1e-9)
+	for _, p := range []float64{0.5, 0.99} {
+		assert.InDelta(t, core.PercentileLatency(expected, p), core.PercentileLatency(sampled, p), 1e-9, "p%v", p*100)
+	}
+	assert.InEpsilon(t, core.MeanLatency(expected), core.MeanLatency(sampled), 0.05)
+}
+
+// TestWaitMaxTracesBatchOnce verifies that a batch future waited on with Max
+// is evaluated once, so its go event is closed by a single exit while each
+// of its calls is still traced.
+func TestWaitMaxTracesBatchOnce(t *testing.T) {
+	sys := parseAndLoad(t, `
+import delay, Max from "@stdlib/common.sdl"
+
+component Backend {
+  method Fetch() Bool {
+    delay(5ms)
+    return true
+  }
+}
+component Frontend {
+  uses backend Backend()
+  method Fan() Bool {
+    let calls = gobatch 10 {
+      return self.backend.Fetch()
+    }
+    return wait calls using Max()
+  }
+}
+component Arch { uses frontend Frontend() }
+system FanOut(arch Arch) { }
+`)
+	data := traceCall(t, sys, "arch.frontend.Fan")
+	var goID int64
+	fetches, goExits := 0, 0
+	for _, event := range data.Events {
+		if event.Kind == EventGo {
+			goID = event.ID
+		}
+		if event.Kind == EventEnter && event.MethodName == "Fetch" {
+			fetches++
+		}
+	}
+	require.NotZero(t, goID)
+	for _, event := range data.Events {
+		if event.Kind == EventExit && event.EnterID == goID {
+			goExits++
+		}
+	}
+	assert.Equal(t, 10, fetches)
+	assert.Equal(t, 1, goExits)
+}
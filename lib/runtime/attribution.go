@@ -0,0 +1,85 @@
+package runtime
+
+import (
+	"sort"
+
+	"github.com/panyam/sdl/lib/core"
+)
+
+// LatencyAttribution accumulates the self latency of each method across many
+// traced runs so the latency of a whole run can be broken down by where it
+// was spent.  Methods are keyed as "Component.Method".  Calls to global
+// native methods (eg delay) and waits are charged to the calling method.
+// Calls started with go are skipped as they overlap their caller.
+type LatencyAttribution struct {
+	Runs     int
+	Total    core.Duration            // Sum of the latencies of every run
+	SelfTime map[string]core.Duration // Self latency summed across runs
+}
+
+// AttributionEntry is one method's share of the attributed latency.
+type AttributionEntry struct {
+	Method   string
+	SelfTime core.Duration
+	Fraction float64
+}
+
+func NewLatencyAttribution() *LatencyAttribution {
+	return &LatencyAttribution{SelfTime: map[string]core.Duration{}}
+}
+
+// Add accumulates the self latencies of a single run's trace.
+func (a *LatencyAttribution) Add(tree *TraceTree) {
+	a.Runs++
+	for _, root := range tree.Roots {
+		a.Total += root.TotalLatency
+		a.addNode(root, "")
+	}
+}
+
+func (a *LatencyAttribution) addNode(node *TraceTreeNode, owner string) {
+	if node.Kind == EventGo {
+		return // Runs concurrently with its caller
+	}
+	if node.Kind == EventEnter && node.Component != "" {
+		owner = node.Component + "." + node.Method
+	} else if owner == "" {
+		owner = node.Method
+	}
+	a.SelfTime[owner] += node.SelfLatency
+	for _, child := range node.Children {
+		a.addNode(child, owner)
+	}
+}
+
+// Fractions returns each method's share of the attributed latency.
+func (a *LatencyAttribution) Fractions() map[string]float64 {
+	out := map[string]float64{}
+	for _, entry := range a.Entries() {
+		out[entry.Method] = entry.Fraction
+	}
+	return out
+}
+
+// Entries returns the attribution sorted by decreasing self latency.
+func (a *LatencyAttribution) Entries() []AttributionEntry {
+	var total core.Duration
+	for _, t := range a.SelfTime {
+		total += t
+	}
+	entries := make([]AttributionEntry, 0, len(a.SelfTime))
+	for method, t := range a.SelfTime {
+		entry := AttributionEntry{Method: method, SelfTime: t}
+		if total > 0 {
+			entry.Fraction = t / total
+		}
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].SelfTime != entries[j].SelfTime {
+			return entries[i].SelfTime > entries[j].SelfTime
+		}
+		return entries[i].Method < entries[j].Method
+	})
+	return entries
+}
@@ -0,0 +1,69 @@
+package runtime
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+// RunBudget bounds the resources a single run may use so that untrusted
+// models cannot exhaust the host.  Zero fields are unbounded.
+type RunBudget struct {
+	MaxWallClock  time.Duration
+	MaxTraceNodes int // Method calls evaluated across the whole run
+}
+
+// Unbounded returns true if the budget sets no limits.
+func (b RunBudget) Unbounded() bool {
+	return b.MaxWallClock <= 0 && b.MaxTraceNodes <= 0
+}
+
+// start returns a meter charging the budget from now, or nil if it is
+// unbounded.  A meter is shared by every evaluator of a run.
+func (b RunBudget) start() *budgetMeter {
+	if b.Unbounded() {
+		return nil
+	}
+	return &budgetMeter{budget: b, start: time.Now()}
+}
+
+// BudgetExceededError is raised when a run exceeds its RunBudget.
+type BudgetExceededError struct {
+	Budget  RunBudget
+	Nodes   int64         // Trace nodes evaluated when the run was aborted
+	Elapsed time.Duration // Wall clock time when the run was aborted
+}
+
+func (e *BudgetExceededError) Error() string {
+	if e.Budget.MaxTraceNodes > 0 && e.Nodes > int64(e.Budget.MaxTraceNodes) {
+		return fmt.Sprintf("run budget exceeded: more than %d trace nodes", e.Budget.MaxTraceNodes)
+	}
+	return fmt.Sprintf("run budget exceeded: ran longer than %v", e.Budget.MaxWallClock)
+}
+
+type budgetMeter struct {
+	budget RunBudget
+	start  time.Time
+	nodes  atomic.Int64
+}
+
+// chargeNode counts a trace node and panics with a *BudgetExceededError if
+// the run is over budget.
+func (m *budgetMeter) chargeNode() {
+	nodes := m.nodes.Add(1)
+	if m.budget.MaxTraceNodes > 0 && nodes > int64(m.budget.MaxTraceNodes) {
+		panic(&BudgetExceededError{Budget: m.budget, Nodes: nodes, Elapsed: time.Since(m.start)})
+	}
+	m.checkClock()
+}
+
+// checkClock panics with a *BudgetExceededError if the run has taken
+// longer than its budget.
+func (m *budgetMeter) checkClock() {
+	if m.budget.MaxWallClock <= 0 {
+		return
+	}
+	if elapsed := time.Since(m.start); elapsed > m.budget.MaxWallClock {
+		panic(&BudgetExceededError{Budget: m.budget, Nodes: m.nodes.Load(), Elapsed: elapsed})
+	}
+}
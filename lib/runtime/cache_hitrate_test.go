@@ -4,6 +4,8 @@ import (
 	"testing"
 
 	"github.com/panyam/sdl/lib/components"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // TestCacheHitRate verifies that the Cache component properly reports its hit rate
@@ -21,3 +23,50 @@ func TestCacheHitRate(t *testing.T) {
 		t.Errorf("Expected Cache Read success rate to be 0.8, got %.2f", pattern.SuccessRate)
 	}
 }
+
+// TestCacheRoutesMissesToStore verifies that flow analysis sends only the
+// misses of a stdlib Cache on to the backing store, and that the hit rate
+// rises as the cache warms up and falls when entries expire under light load.
+func TestCacheRoutesMissesToStore(t *testing.T) {
+	storeRate := func(cacheArgs string, prime int) float64 {
+		sys := parseAndLoad(t, `
+import Cache from "@stdlib/common.sdl"
+
+component Store {
+  method Query() Bool { return true }
+}
+component Service {
+  uses cache Cache(`+cacheArgs+`)
+  uses store Store()
+  method Lookup() Bool {
+    if self.cache.Get() {
+      return true
+    } else {
+      return self.store.Query()
+    }
+  }
+}
+system S(service Service) { }
+`)
+		if prime > 0 {
+			_, err := RunCallInBatches(sys, "service", "Lookup", prime, 1, 1, nil)
+			require.NoError(t, err)
+		}
+		result, err := EvaluateFlowStrategy("runtime", sys, []GeneratorConfigAPI{{ID: "g", Component: "service", Method: "Lookup", Rate: 100}}, DefaultFlowSolverOptions())
+		require.NoError(t, err)
+		return result.Flows.ComponentRates["service.store.Query"]
+	}
+
+	// (1 - hitRate) of the incoming rate, and the 0.1% of reads that fail
+	// fall back to the store too
+	assert.InDelta(t, (1-0.8)*100, storeRate("HitRate = 0.8", 0), 0.1)
+	assert.InDelta(t, (1-0.95)*100, storeRate("HitRate = 0.95", 0), 0.1)
+
+	// A cold cache misses everything until primed
+	assert.InDelta(t, 100, storeRate("HitRate = 0.8, WarmupReads = 100", 0), 0.1)
+	assert.InDelta(t, (1-0.8)*100, storeRate("HitRate = 0.8, WarmupReads = 100", 100), 0.1)
+
+	// Each of 1000 entries sees 0.08 hits/s, so 0.8 in its 10s TTL, and only
+	// 0.8/1.8 of reads that would hit find their entry unexpired
+	assert.InDelta(t, (1-0.8*0.8/1.8)*100, storeRate("HitRate = 0.8, Size = 1000, TTL = 10", 0), 0.1)
+}
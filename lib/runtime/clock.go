@@ -0,0 +1,170 @@
+package runtime
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock is the source of wall time for generators, metric windows and the
+// simulation start time.  Tests substitute a FakeClock to control time.
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+	Sleep(d time.Duration)
+	NewTicker(d time.Duration) Ticker
+
+	// AfterFunc calls f once d has passed unless stop is called first.
+	AfterFunc(d time.Duration, f func()) (stop func())
+}
+
+// Ticker delivers ticks at regular intervals like time.Ticker.
+type Ticker interface {
+	C() <-chan time.Time
+	Stop()
+}
+
+// RealClock is the Clock backed by the time package.
+var RealClock Clock = realClock{}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time                         { return time.Now() }
+func (realClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+func (realClock) Sleep(d time.Duration)                  { time.Sleep(d) }
+func (realClock) NewTicker(d time.Duration) Ticker       { return realTicker{time.NewTicker(d)} }
+func (realClock) AfterFunc(d time.Duration, f func()) (stop func()) {
+	t := time.AfterFunc(d, f)
+	return func() { t.Stop() }
+}
+
+type realTicker struct{ *time.Ticker }
+
+func (t realTicker) C() <-chan time.Time { return t.Ticker.C }
+
+// FakeClock is a Clock that only moves when advanced.  Timers and tickers
+// fire, in time order, as Advance moves past them and AfterFunc callbacks run
+// on the goroutine calling Advance.
+type FakeClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	timers []*fakeTimer
+}
+
+type fakeTimer struct {
+	clock  *FakeClock
+	at     time.Time
+	period time.Duration // 0 for one shot timers
+	ch     chan time.Time
+	fn     func() // Called instead of sending on ch
+}
+
+// NewFakeClock creates a FakeClock set to the given time.
+func NewFakeClock(start time.Time) *FakeClock {
+	return &FakeClock{now: start}
+}
+
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *FakeClock) After(d time.Duration) <-chan time.Time {
+	return c.addTimer(d, 0, nil).ch
+}
+
+// Sleep blocks until another goroutine advances the clock by d.
+func (c *FakeClock) Sleep(d time.Duration) {
+	<-c.After(d)
+}
+
+func (c *FakeClock) NewTicker(d time.Duration) Ticker {
+	if d <= 0 {
+		panic("non-positive interval for FakeClock.NewTicker")
+	}
+	return c.addTimer(d, d, nil)
+}
+
+func (c *FakeClock) AfterFunc(d time.Duration, f func()) (stop func()) {
+	return c.addTimer(d, 0, f).Stop
+}
+
+// PendingTimers returns the number of timers and tickers waiting to fire so
+// tests can wait for a goroutine to start waiting before advancing.
+func (c *FakeClock) PendingTimers() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.timers)
+}
+
+// Advance moves the clock forward by d firing every timer and tick that
+// falls due on the way.  Like time.Ticker, ticks are dropped if the previous
+// one has not been received.
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	end := c.now.Add(d)
+	c.mu.Unlock()
+	for c.fireNext(end) {
+	}
+}
+
+// fireNext fires the earliest timer due by end, returning false once there
+// are none left and the clock has moved to end.
+func (c *FakeClock) fireNext(end time.Time) bool {
+	c.mu.Lock()
+	var next *fakeTimer
+	for _, t := range c.timers {
+		if !t.at.After(end) && (next == nil || t.at.Before(next.at)) {
+			next = t
+		}
+	}
+	if next == nil {
+		c.now = end
+		c.mu.Unlock()
+		return false
+	}
+	c.now = next.at
+	firedAt := next.at
+	if next.period > 0 {
+		next.at = next.at.Add(next.period)
+	} else {
+		c.removeLocked(next)
+	}
+	c.mu.Unlock()
+
+	// Callbacks run unlocked so they can use the clock
+	if next.fn != nil {
+		next.fn()
+	} else {
+		select {
+		case next.ch <- firedAt:
+		default:
+		}
+	}
+	return true
+}
+
+func (c *FakeClock) addTimer(d, period time.Duration, fn func()) *fakeTimer {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	t := &fakeTimer{clock: c, at: c.now.Add(d), period: period, ch: make(chan time.Time, 1), fn: fn}
+	c.timers = append(c.timers, t)
+	return t
+}
+
+func (c *FakeClock) removeLocked(t *fakeTimer) {
+	for i, other := range c.timers {
+		if other == t {
+			c.timers = append(c.timers[:i], c.timers[i+1:]...)
+			return
+		}
+	}
+}
+
+func (t *fakeTimer) C() <-chan time.Time { return t.ch }
+
+func (t *fakeTimer) Stop() {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+	t.clock.removeLocked(t)
+}
@@ -0,0 +1,67 @@
+package runtime
+
+import (
+	"maps"
+
+	"github.com/panyam/sdl/lib/core"
+)
+
+// CoalesceFactor returns how many calls to a @coalesce method share one
+// downstream invocation.  Each invocation stays in flight for window seconds
+// during which, by Little's law, arrivalRate * window identical calls arrive
+// and join it instead of making their own.
+func CoalesceFactor(window, arrivalRate float64) float64 {
+	if window <= 0 || arrivalRate <= 0 {
+		return 1
+	}
+	return 1 + arrivalRate*window
+}
+
+// coalescedCall is the outcome of a @coalesce call and the simulated time
+// it completed at.  Identical calls join it for window seconds after that.
+type coalescedCall struct {
+	value       Value
+	completedAt core.Duration
+	window      float64
+}
+
+func (c coalescedCall) expired(now core.Duration) bool {
+	return now-c.completedAt > c.window
+}
+
+// minCoalescedSweep is the fewest in flight calls a component keeps before
+// expired ones are swept.
+const minCoalescedSweep = 64
+
+// inFlightOutcome returns the outcome of the identical call a coalesced call
+// made at now joins.  A call whose window has passed has completed and is
+// evicted.
+func (ci *ComponentInstance) inFlightOutcome(key string, now core.Duration) (Value, bool) {
+	ci.memoLock.Lock()
+	defer ci.memoLock.Unlock()
+	call, ok := ci.coalesced[key]
+	if !ok {
+		return Nil, false
+	}
+	if call.expired(now) {
+		delete(ci.coalesced, key)
+		return Nil, false
+	}
+	return call.value, true
+}
+
+// setInFlightOutcome records the outcome of a call completed at now.  Calls
+// with distinct arguments are never looked up again once completed, so they
+// are swept whenever the number kept doubles.
+func (ci *ComponentInstance) setInFlightOutcome(key string, v Value, now core.Duration, window float64) {
+	ci.memoLock.Lock()
+	defer ci.memoLock.Unlock()
+	if ci.coalesced == nil {
+		ci.coalesced = map[string]coalescedCall{}
+	}
+	ci.coalesced[key] = coalescedCall{value: v, completedAt: now, window: window}
+	if len(ci.coalesced) >= minCoalescedSweep && len(ci.coalesced) >= ci.coalescedSweepAt {
+		maps.DeleteFunc(ci.coalesced, func(_ string, call coalescedCall) bool { return call.expired(now) })
+		ci.coalescedSweepAt = 2 * len(ci.coalesced)
+	}
+}
@@ -0,0 +1,73 @@
+package runtime
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/panyam/sdl/lib/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestCoalesceReducesDownstreamFlow verifies that coalescing cuts the load
+// reaching a dependency by more as concurrency rises.
+func TestCoalesceReducesDownstreamFlow(t *testing.T) {
+	sys := parseAndLoad(t, fmt.Sprintf(memoizeSource, ""))
+	cache, db := sys.FindComponent("arch.cache"), sys.FindComponent("arch.cache.db")
+	dbRate := FlowEvalRuntime(cache, "Get", 100, NewFlowScope(sys.Env)).GetRate(db, "Query")
+	assert.InDelta(t, 100, dbRate, 1e-9)
+
+	// 100 rps with calls in flight for 100ms has 10 more joining each one
+	sys = parseAndLoad(t, fmt.Sprintf(memoizeSource, "@coalesce(window = 100ms)"))
+	cache, db = sys.FindComponent("arch.cache"), sys.FindComponent("arch.cache.db")
+	dbRate = FlowEvalRuntime(cache, "Get", 100, NewFlowScope(sys.Env)).GetRate(db, "Query")
+	assert.InDelta(t, 100.0/11, dbRate, 1e-9)
+
+	lowRate := FlowEvalRuntime(cache, "Get", 1, NewFlowScope(sys.Env)).GetRate(db, "Query")
+	assert.InDelta(t, 1/1.1, lowRate, 1e-9, "little to coalesce at low concurrency")
+}
+
+// TestCoalescedCallsShareOutcome verifies that calls joining one in flight
+// return its outcome without calling the dependency again.
+func TestCoalescedCallsShareOutcome(t *testing.T) {
+	sys := parseAndLoad(t, fmt.Sprintf(memoizeSource, "@coalesce(window = 10ms)"))
+	cache := sys.FindComponent("arch.cache")
+	require.NoError(t, cache.SetArrivalRate("Get", 1e6))
+	eval := NewSimpleEval(sys.File, nil)
+	call := &CallExpr{Function: buildMemberAccessExpr([]string{"arch", "cache", "Get"})}
+
+	var first core.Duration
+	result, _ := eval.Eval(call, sys.Env.Push(), &first)
+	assert.InDelta(t, 0.01, first, 1e-9, "the first call is made downstream")
+	joined := 0
+	for range 100 {
+		var currTime core.Duration
+		value, _ := eval.Eval(call, sys.Env.Push(), &currTime)
+		require.Equal(t, result.String(), value.String())
+		if currTime < 0.01 {
+			joined++
+		}
+	}
+	assert.Greater(t, joined, 90)
+}
+
+// TestCoalescedCallsEvicted verifies that calls are dropped once their window
+// has passed, both when looked up again and when calls with other arguments
+// pile up.
+func TestCoalescedCallsEvicted(t *testing.T) {
+	sys := parseAndLoad(t, fmt.Sprintf(memoizeSource, "@coalesce(window = 10ms)"))
+	cache := sys.FindComponent("arch.cache")
+
+	cache.setInFlightOutcome("Get", BoolValue(true), 1, 0.01)
+	_, found := cache.inFlightOutcome("Get", 1.005)
+	assert.True(t, found, "joined within the window")
+	_, found = cache.inFlightOutcome("Get", 1.02)
+	assert.False(t, found, "completed after the window")
+	assert.Empty(t, cache.coalesced)
+
+	// One call with new arguments every millisecond keeps about a window's worth
+	for i := range 1000 {
+		cache.setInFlightOutcome(fmt.Sprintf("Get\x00%d", i), BoolValue(true), core.Duration(i)*0.001, 0.01)
+	}
+	assert.Less(t, len(cache.coalesced), 2*minCoalescedSweep)
+}
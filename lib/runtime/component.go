@@ -1,10 +1,12 @@
 package runtime
 
 import (
+	"errors"
 	"fmt"
 	"log"
 	"maps"
 	"slices"
+	"sync"
 
 	"github.com/panyam/sdl/lib/components"
 	"github.com/panyam/sdl/lib/decl"
@@ -20,13 +22,39 @@ type ComponentInstance struct {
 	// Arrival rates for SDL components (native components handle their own)
 	arrivalRates map[string]float64
 
+	// Outcomes of @memoize methods and of the calls in flight for @coalesce
+	// methods, keyed by method and arguments
+	memoLock         sync.Mutex
+	memoized         map[string]memoEntry
+	coalesced        map[string]coalescedCall
+	coalescedSweepAt int
+
+	// Selected profile whose methods replace the component's, nil for none
+	profile *decl.ProfileDecl
+
 	id string
+
+	// Path from a system parameter, eg "app.cache", set when the system is
+	// initialized
+	instanceID string
 }
 
+// ID identifies the instance in traces and flows: its InstanceID if it is
+// part of a system or else the id it was created with.
 func (c *ComponentInstance) ID() string {
+	if c.instanceID != "" {
+		return c.instanceID
+	}
 	return c.id
 }
 
+// InstanceID is the instance's path in its system, eg "app.cache", which is
+// the same on every run of the system.  It is empty for instances that are
+// not reachable from a system parameter.
+func (c *ComponentInstance) InstanceID() string {
+	return c.instanceID
+}
+
 // NewComponentInstance creates a new component instanceof the given type.
 func NewComponentInstance(id string, file *FileInstance, compDecl *ComponentDecl) (comp *ComponentInstance, result Value, err error) {
 	// Create the component instance
@@ -63,6 +91,17 @@ func NewComponentInstance(id string, file *FileInstance, compDecl *ComponentDecl
 	return compInst, compValue, nil
 }
 
+// Set sets a parameter or dependency, rejecting values that violate the
+// param's declared constraint.
+func (ci *ComponentInstance) Set(name string, value Value) error {
+	if param, _ := ci.ComponentDecl.GetParam(name); param != nil && param.Constraint != nil {
+		if err := param.Constraint.Check(value); err != nil {
+			return fmt.Errorf("cannot set %s.%s: %w", ci.ComponentDecl.Name.Value, name, err)
+		}
+	}
+	return ci.ObjectInstance.Set(name, value)
+}
+
 // A component declaration contains instantiations of components, params, methods etc
 // Specifically when a component is initialized in initializers it is important to not be bound by order.
 // This method compiles the System into a set of statements that can be executed so that
@@ -114,10 +153,70 @@ func (ci *ComponentInstance) Initializer() (blockStmt *BlockStmt, err error) {
 				Value: assign.Value,
 			})
 		}
+		stmts = append(stmts, rederiveDefaults(it)...)
 	}
 	return &BlockStmt{Statements: stmts}, nil
 }
 
+// rederiveDefaults re-evaluates, after a dependency's overrides are applied,
+// the defaults of its params that are derived from other params so they see
+// the overridden values.  Defaults are rebased onto the dependency, eg
+// `PoolSize * 2` becomes `db.PoolSize * 2`.
+func rederiveDefaults(usesDecl *decl.UsesDecl) (stmts []Stmt) {
+	comp := usesDecl.ResolvedComponent
+	if comp == nil || comp.IsNative {
+		return
+	}
+	overridden := map[string]bool{}
+	for _, assign := range usesDecl.Overrides {
+		overridden[assign.Var.Value] = true
+	}
+	params, _ := comp.Params()
+	for _, param := range params {
+		if param.DefaultValue == nil || overridden[param.Name.Value] || len(comp.ParamRefs(param.DefaultValue)) == 0 {
+			continue
+		}
+		stmts = append(stmts, &decl.SetStmt{
+			TargetExpr: &MemberAccessExpr{Receiver: usesDecl.Name, Member: param.Name},
+			Value:      rebaseParamRefs(param.DefaultValue, comp, usesDecl.Name),
+		})
+	}
+	return
+}
+
+// rebaseParamRefs copies e replacing references to comp's params with
+// accesses on receiver.
+func rebaseParamRefs(e Expr, comp *decl.ComponentDecl, receiver Expr) Expr {
+	paramRef := func(name string) Expr {
+		if p, _ := comp.GetParam(name); p != nil {
+			return &MemberAccessExpr{Receiver: receiver, Member: decl.NewIdent(name)}
+		}
+		return nil
+	}
+	switch e := e.(type) {
+	case *IdentifierExpr:
+		if ref := paramRef(e.Value); ref != nil {
+			return ref
+		}
+	case *MemberAccessExpr:
+		if recv, ok := e.Receiver.(*IdentifierExpr); ok && recv.Value == "self" {
+			if ref := paramRef(e.Member.Value); ref != nil {
+				return ref
+			}
+		}
+	case *BinaryExpr:
+		out := *e
+		out.Left = rebaseParamRefs(e.Left, comp, receiver)
+		out.Right = rebaseParamRefs(e.Right, comp, receiver)
+		return &out
+	case *UnaryExpr:
+		out := *e
+		out.Right = rebaseParamRefs(e.Right, comp, receiver)
+		return &out
+	}
+	return e
+}
+
 // SetArrivalRate sets the arrival rate for a specific method on this component.
 // For native components, this delegates to the native implementation if supported.
 // For SDL components, stores the rate internally.
@@ -128,6 +227,10 @@ func (ci *ComponentInstance) SetArrivalRate(methodName string, rate float64) err
 		if setter, ok := ci.NativeInstance.(interface{ SetArrivalRate(string, float64) error }); ok {
 
 			e := setter.SetArrivalRate(methodName, rate)
+			if errors.Is(e, components.ErrNoArrivalRates) {
+				// Not modeling contention just means infinite bandwidth
+				return nil
+			}
 			if e != nil {
 				panic(e)
 			}
@@ -202,6 +305,23 @@ func (c *ComponentInstance) GetFlowPattern(method string, inputRate float64) com
 	return result.GetFlowPattern(method, inputRate)
 }
 
+// HasRunState returns true if calls to the component can change the outcomes
+// of later calls to it: native components that are Stateful and SDL
+// components with @memoize methods, whose cached outcomes are reused.
+func (ci *ComponentInstance) HasRunState() bool {
+	if ci.IsNative {
+		stateful, ok := ci.NativeInstance.(components.Stateful)
+		return ok && stateful.HasRunState()
+	}
+	methods, _ := ci.ComponentDecl.Methods()
+	for name := range methods {
+		if _, _, memoized := ci.Method(name).Memoize(); memoized {
+			return true
+		}
+	}
+	return false
+}
+
 // GetUtilizationInfo returns utilization information for this component and its children.
 // For SDL components, it aggregates info from all child components that support utilization tracking.
 func (ci *ComponentInstance) GetUtilizationInfo() []components.UtilizationInfo {
@@ -252,7 +372,7 @@ type NeighborMethod struct {
 func (ci *ComponentInstance) NeighborsFromMethod(methodName string) []*NeighborMethod {
 	neighbors := map[string]*NeighborMethod{}
 
-	methodDecl, _ := ci.ComponentDecl.GetMethod(methodName)
+	methodDecl := ci.Method(methodName)
 	// analyze the body to see what methods are being called from this method
 
 	if methodDecl == nil || methodDecl.Body == nil {
@@ -296,6 +416,8 @@ func (ci *ComponentInstance) NeighborsFromMethod(methodName string) []*NeighborM
 			}
 		case *decl.ExprStmt:
 			appendNode(n.Expression)
+		case *decl.SpawnStmt:
+			appendNode(n.Call)
 		case *decl.CallExpr:
 			// See if args themselves call any functions
 			for _, arg := range n.ArgList {
@@ -0,0 +1,88 @@
+package runtime
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/panyam/sdl/lib/core"
+	"github.com/panyam/sdl/lib/decl"
+)
+
+// evalConversionCall evaluates a call to one of the conversion builtins.
+func (s *SimpleEval) evalConversionCall(name string, expr *CallExpr, env *Env[Value], currTime *core.Duration) (result Value, returned bool) {
+	args := make([]Value, len(expr.ArgList))
+	for i, argExpr := range expr.ArgList {
+		arg, _ := s.Eval(argExpr, env, currTime)
+		if deref, err := arg.Deref(); err == nil {
+			arg = *deref
+		}
+		args[i] = arg
+	}
+	result, err := Convert(name, args...)
+	if err != nil {
+		s.AddErrors(s.runtimeError(expr, err))
+		return decl.Nil, false
+	}
+	for _, arg := range args {
+		result.Time += arg.Time
+	}
+	return result, false
+}
+
+// Convert applies the conversion builtin name to args.  int() truncates
+// towards zero and fails for values outside the range of an Int, float() of
+// an Int beyond 2^53 rounds to the nearest Float.
+func Convert(name string, args ...Value) (Value, error) {
+	if len(args) == 0 {
+		return decl.Nil, fmt.Errorf("%s expects an argument", name)
+	}
+	if name == decl.BuiltinString {
+		switch v := args[0].Value.(type) {
+		case string:
+			return decl.StringValue(v), nil
+		case int64:
+			return decl.StringValue(strconv.FormatInt(v, 10)), nil
+		case float64:
+			return decl.StringValue(strconv.FormatFloat(v, 'g', -1, 64)), nil
+		case bool:
+			return decl.StringValue(strconv.FormatBool(v)), nil
+		}
+		return decl.Nil, fmt.Errorf("cannot convert %s to string", args[0].Type)
+	}
+
+	if i, ok := args[0].Value.(int64); ok && name == decl.BuiltinInt {
+		return decl.IntValue(i), nil
+	}
+	var x float64
+	switch v := args[0].Value.(type) {
+	case int64:
+		x = float64(v)
+	case float64:
+		x = v
+	default:
+		return decl.Nil, fmt.Errorf("%s expects a number, got %s", name, args[0].Type)
+	}
+
+	switch name {
+	case decl.BuiltinFloat:
+		return decl.FloatValue(x), nil
+	case decl.BuiltinInt:
+		// float64(math.MaxInt64) rounds up to 2^63 which is itself out of range
+		if math.IsNaN(x) || x >= math.MaxInt64 || x < math.MinInt64 {
+			return decl.Nil, fmt.Errorf("int(%g) overflows Int", x)
+		}
+		return decl.IntValue(int64(math.Trunc(x))), nil
+	case decl.BuiltinDuration:
+		if len(args) != 2 {
+			return decl.Nil, fmt.Errorf("duration expects a value and a unit")
+		}
+		unit, _ := args[1].Value.(string)
+		seconds, ok := decl.DurationUnits[unit]
+		if !ok {
+			return decl.Nil, fmt.Errorf("unknown duration unit '%s'", unit)
+		}
+		return decl.FloatValue(x * seconds), nil
+	}
+	return decl.Nil, fmt.Errorf("'%s' is not a conversion builtin", name)
+}
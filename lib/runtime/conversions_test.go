@@ -0,0 +1,102 @@
+package runtime
+
+import (
+	"math"
+	"testing"
+
+	"github.com/panyam/sdl/lib/core"
+	"github.com/panyam/sdl/lib/decl"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvert(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		args     []Value
+		expected Value
+	}{
+		{"float", []Value{decl.IntValue(5)}, decl.FloatValue(5)},
+		{"float", []Value{decl.FloatValue(2.5)}, decl.FloatValue(2.5)},
+		{"int", []Value{decl.FloatValue(1.9)}, decl.IntValue(1)},
+		{"int", []Value{decl.FloatValue(-1.9)}, decl.IntValue(-1)},
+		{"int", []Value{decl.IntValue(7)}, decl.IntValue(7)},
+		{"duration", []Value{decl.IntValue(250), decl.StringValue("ms")}, decl.FloatValue(0.25)},
+		{"duration", []Value{decl.FloatValue(1.5), decl.StringValue("min")}, decl.FloatValue(90)},
+		{"string", []Value{decl.IntValue(42)}, decl.StringValue("42")},
+		{"string", []Value{decl.FloatValue(0.1)}, decl.StringValue("0.1")},
+		{"string", []Value{decl.BoolValue(true)}, decl.StringValue("true")},
+	} {
+		result, err := Convert(tc.name, tc.args...)
+		require.NoError(t, err, tc.name)
+		assert.True(t, tc.expected.Equals(&result), "%s(%v) = %s", tc.name, tc.args, result.String())
+	}
+
+	for _, x := range []float64{math.NaN(), math.Inf(1), 1e19, -1e19} {
+		_, err := Convert("int", decl.FloatValue(x))
+		assert.ErrorContains(t, err, "overflows Int")
+	}
+	_, err := Convert("duration", decl.IntValue(1), decl.StringValue("day"))
+	assert.ErrorContains(t, err, "unknown duration unit 'day'")
+	_, err = Convert("int", decl.StringValue("abc"))
+	assert.ErrorContains(t, err, "int expects a number")
+}
+
+func TestConversionBuiltinsEval(t *testing.T) {
+	sys := parseAndLoad(t, `
+import delay from "@stdlib/common.sdl"
+component Server {
+  param Retries Int = 3
+  method Handle() String {
+    delay(duration(self.Retries, "ms"))
+    return string(int(float(self.Retries) * 1.9))
+  }
+}
+system Test(server Server) { }
+`)
+	eval := NewSimpleEval(sys.File, nil)
+	var currTime core.Duration
+	result, _ := eval.Eval(&CallExpr{Function: buildMemberAccessExpr([]string{"server", "Handle"})}, sys.Env.Push(), &currTime)
+	require.False(t, eval.HasErrors(), eval.ErrorCollector.Errors)
+	assert.Equal(t, "5", result.StringVal())
+	assert.InDelta(t, 0.003, float64(currTime), 1e-9)
+}
+
+func TestEnumBuiltinsEval(t *testing.T) {
+	sys := parseAndLoad(t, `
+import delay from "@stdlib/common.sdl"
+enum Status { Ok, Slow, Down }
+component Server {
+  method Count() Int {
+    return count(Status)
+  }
+  method Visit() Status {
+    for s in values(Status) {
+      delay(1ms)
+    }
+    return Status.Down
+  }
+  method First() Status {
+    for s in values(Status) {
+      return s
+    }
+    return Status.Down
+  }
+}
+system Test(server Server) { }
+`)
+	eval := NewSimpleEval(sys.File, nil)
+	var currTime core.Duration
+	result, _ := eval.Eval(&CallExpr{Function: buildMemberAccessExpr([]string{"server", "Count"})}, sys.Env.Push(), &currTime)
+	require.False(t, eval.HasErrors(), eval.ErrorCollector.Errors)
+	assert.Equal(t, int64(3), result.Value)
+
+	result, _ = eval.Eval(&CallExpr{Function: buildMemberAccessExpr([]string{"server", "Visit"})}, sys.Env.Push(), &currTime)
+	require.False(t, eval.HasErrors(), eval.ErrorCollector.Errors)
+	assert.Equal(t, 2, result.Value)
+	assert.InDelta(t, 0.003, float64(currTime), 1e-9)
+
+	result, _ = eval.Eval(&CallExpr{Function: buildMemberAccessExpr([]string{"server", "First"})}, sys.Env.Push(), &currTime)
+	require.False(t, eval.HasErrors(), eval.ErrorCollector.Errors)
+	assert.Equal(t, 0, result.Value)
+}
@@ -0,0 +1,119 @@
+package runtime
+
+import (
+	"cmp"
+	"encoding/json"
+	"io"
+	"slices"
+	"sync"
+
+	"github.com/panyam/sdl/lib/core"
+)
+
+// Decision is a random choice made while evaluating a call, written to a
+// DecisionLog to explain why the call took the path it did.  Where a trace
+// shows what was called, decisions show which way each sample went.
+type Decision struct {
+	// "sample" for a draw from a distribution, "wait" for futures completing
+	Kind string `json:"kind"`
+
+	// Position of the expression that made the decision
+	File string `json:"file"`
+	Line int    `json:"line"`
+	Col  int    `json:"col"`
+
+	// Innermost "Component.Method" being evaluated, if any
+	Method string `json:"method,omitempty"`
+
+	// Simulated time into the call the decision was made at
+	Time core.Duration `json:"time"`
+
+	// For samples: the index of the branch taken out of Branches, its
+	// probability and the value it gave
+	Branch      int     `json:"branch"`
+	Branches    int     `json:"branches,omitempty"`
+	Probability float64 `json:"probability,omitempty"`
+	Value       string  `json:"value,omitempty"`
+
+	// For waits: the indexes of the futures in the order they completed and
+	// the latency of each future
+	Order     []int           `json:"order,omitempty"`
+	Latencies []core.Duration `json:"latencies,omitempty"`
+}
+
+// DecisionLog writes decisions as newline delimited JSON.  It is safe for
+// concurrent use and keeps the first write error (see Err).
+type DecisionLog struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+	err error
+}
+
+// NewDecisionLog creates a DecisionLog writing to w.
+func NewDecisionLog(w io.Writer) *DecisionLog {
+	return &DecisionLog{enc: json.NewEncoder(w)}
+}
+
+// Log writes a decision.
+func (l *DecisionLog) Log(decision Decision) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.err == nil {
+		l.err = l.enc.Encode(decision)
+	}
+}
+
+// Err returns the first error writing a decision, or nil.
+func (l *DecisionLog) Err() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.err
+}
+
+// sample draws from outcomes and, when decisions are logged, logs the branch
+// taken at the innermost node being evaluated.
+func (s *SimpleEval) sample(outcomes *core.Outcomes[Value], currTime core.Duration) (result Value, ok bool) {
+	idx, ok := outcomes.SampleIndex(s.Rand)
+	if !ok {
+		return result, false
+	}
+	result = outcomes.Buckets[idx].Value
+	if s.Decisions != nil {
+		decision := s.decision("sample", currTime)
+		decision.Branch, decision.Branches = idx, outcomes.Len()
+		decision.Probability = outcomes.Buckets[idx].Weight / outcomes.TotalWeight()
+		decision.Value = result.String()
+		s.Decisions.Log(decision)
+	}
+	return result, true
+}
+
+// logWait logs the order in which futures with the given latencies
+// completed, when decisions are logged.
+func (s *SimpleEval) logWait(latencies []core.Duration, currTime core.Duration) {
+	if s.Decisions == nil {
+		return
+	}
+	decision := s.decision("wait", currTime)
+	decision.Latencies = latencies
+	decision.Order = make([]int, len(latencies))
+	for i := range decision.Order {
+		decision.Order[i] = i
+	}
+	slices.SortStableFunc(decision.Order, func(a, b int) int { return cmp.Compare(latencies[a], latencies[b]) })
+	s.Decisions.Log(decision)
+}
+
+func (s *SimpleEval) decision(kind string, currTime core.Duration) Decision {
+	decision := Decision{Kind: kind, Time: currTime}
+	if s.current != nil {
+		decision.Line, decision.Col = s.current.Pos().Line, s.current.Pos().Col
+	}
+	if len(s.fileStack) > 0 {
+		decision.File = s.fileStack[len(s.fileStack)-1]
+		decision.Method = s.callStack[len(s.callStack)-1]
+	} else if s.RootFile != nil && s.RootFile.Decl != nil {
+		decision.File = s.RootFile.Decl.FullPath
+	}
+	return decision
+}
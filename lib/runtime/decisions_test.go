@@ -0,0 +1,66 @@
+package runtime
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecisionLogFollowsSeed(t *testing.T) {
+	sys := parseAndLoad(t, `
+component App {
+  method Lookup() Bool {
+    let hit = sample dist {
+      70 => true
+      30 => false
+    }
+    return hit
+  }
+}
+system S(app App) { }
+`)
+	decisionsFor := func(seed int64) (Value, []Decision) {
+		var out bytes.Buffer
+		result, _, err := RunCallWithDecisions(sys, "app", "Lookup", seed, NewDecisionLog(&out))
+		require.NoError(t, err)
+		var decisions []Decision
+		for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+			var decision Decision
+			require.NoError(t, json.Unmarshal([]byte(line), &decision))
+			decisions = append(decisions, decision)
+		}
+		return result, decisions
+	}
+
+	sawBranches := map[int]bool{}
+	for seed := int64(1); seed <= 20; seed++ {
+		result, decisions := decisionsFor(seed)
+		require.Len(t, decisions, 1)
+		decision := decisions[0]
+		assert.Equal(t, "sample", decision.Kind)
+		assert.Equal(t, sys.File.Decl.FullPath, decision.File)
+		assert.Equal(t, "App.Lookup", decision.Method)
+		assert.Equal(t, 4, decision.Line)
+		assert.Equal(t, 2, decision.Branches)
+
+		// The logged branch is the one the call's result came from
+		assert.Equal(t, result.String(), decision.Value)
+		if result.IsTrue() {
+			assert.Equal(t, 0, decision.Branch)
+			assert.InDelta(t, 0.7, decision.Probability, 1e-9)
+		} else {
+			assert.Equal(t, 1, decision.Branch)
+			assert.InDelta(t, 0.3, decision.Probability, 1e-9)
+		}
+		sawBranches[decision.Branch] = true
+
+		// and the same seed makes the same decision
+		_, again := decisionsFor(seed)
+		assert.Equal(t, decisions, again)
+	}
+	assert.Len(t, sawBranches, 2, "20 seeds should take both branches")
+}
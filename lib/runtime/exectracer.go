@@ -13,6 +13,9 @@ type ExecutionTracer struct {
 	nextID  int64
 	stack   []int64
 	runtime *Runtime // Reference to runtime for metrics processing
+
+	// When set, each event is passed to emit as it happens instead of being kept in Events
+	emit func(*TraceEvent)
 }
 
 // NewExecutionTracer creates a new tracer.
@@ -24,6 +27,23 @@ func NewExecutionTracer() *ExecutionTracer {
 	}
 }
 
+// NewStreamingTracer creates a tracer that hands each event to emit as the call
+// unfolds rather than retaining it, so memory does not grow with the trace.
+// emit is called in event order and must not call back into the tracer.
+func NewStreamingTracer(emit func(*TraceEvent)) *ExecutionTracer {
+	t := NewExecutionTracer()
+	t.emit = emit
+	return t
+}
+
+func (t *ExecutionTracer) record(event *TraceEvent) {
+	if t.emit != nil {
+		t.emit(event)
+	} else {
+		t.Events = append(t.Events, event)
+	}
+}
+
 // SetRuntime sets the runtime reference for metrics processing
 func (t *ExecutionTracer) SetRuntime(runtime *Runtime) {
 	t.mu.Lock()
@@ -77,8 +97,9 @@ func (t *ExecutionTracer) Enter(ts core.Duration, kind TraceEventKind, comp *Com
 	// Set computed fields for JSON serialization
 	event.ComponentName = event.GetComponentName()
 	event.MethodName = event.GetMethodName()
+	event.InstanceID = event.GetInstanceID()
 
-	t.Events = append(t.Events, event)
+	t.record(event)
 
 	// If it's a standard call, it becomes the new parent for subsequent nested calls.
 	// For 'go' and 'wait', they are instantaneous events, not parent scopes.
@@ -95,7 +116,9 @@ func (t *ExecutionTracer) Exit(ts core.Duration, duration core.Duration, comp *C
 	defer t.mu.Unlock()
 
 	// Pop the corresponding "enter" event from the parent stack.
+	enterID := int64(0)
 	if len(t.stack) > 1 {
+		enterID = t.stack[len(t.stack)-1]
 		t.stack = t.stack[:len(t.stack)-1]
 	}
 
@@ -103,6 +126,7 @@ func (t *ExecutionTracer) Exit(ts core.Duration, duration core.Duration, comp *C
 		Kind:      EventExit,
 		ID:        t.nextID,
 		ParentID:  t.currentParentID(),
+		EnterID:   enterID,
 		Timestamp: ts,
 		Duration:  duration,
 		Component: comp,
@@ -113,6 +137,7 @@ func (t *ExecutionTracer) Exit(ts core.Duration, duration core.Duration, comp *C
 	// Set computed fields for JSON serialization
 	event.ComponentName = event.GetComponentName()
 	event.MethodName = event.GetMethodName()
+	event.InstanceID = event.GetInstanceID()
 
 	if !retVal.IsNil() {
 		event.ReturnValue = retVal.String()
@@ -121,7 +146,7 @@ func (t *ExecutionTracer) Exit(ts core.Duration, duration core.Duration, comp *C
 		event.ErrorMessage = err.Error()
 	}
 
-	t.Events = append(t.Events, event)
+	t.record(event)
 
 	// Process metrics if enabled
 	// if t.runtime != nil && t.runtime.metricStore != nil { t.runtime.metricStore.ProcessTraceEvent(event) }
@@ -53,29 +53,50 @@ func (f *FileInstance) Env() *Env[Value] {
 }
 
 // Initialize a new system with the given name.
-// Returns nil if system name is invalid
+// Returns nil if system name is invalid or its initializers fail
 // If init is true then the initializer commands are also run for the system along with a new env
 // that is created and set for the System as it's "initial" environment which will be used for
 // all further system/method invocations
 func (f *FileInstance) NewSystem(systemName string, init bool) (*SystemInstance, core.Duration) {
+	if init {
+		sysInst, currTime, err := f.InitSystem(systemName)
+		if err != nil {
+			log.Println("error initializing system: ", err)
+			return nil, 0
+		}
+		return sysInst, currTime
+	}
 	system, err := f.Decl.GetSystem(systemName)
 	if err != nil {
 		log.Println("error getting system: ", err)
 		return nil, 0
 	}
+	return NewSystemInstance(f, system), 0
+}
 
-	sysInst := NewSystemInstance(f, system)
-	var currTime core.Duration
-	if init {
-		// Initialize the system — wire components
-		se := NewSimpleEval(f, nil)
-		env := f.Env().Push() // Create new environment for system
-		se.EvalInitSystem(sysInst, env, &currTime)
-		sysInst.Env = env
-		sysInst.ResolveGenerators()
-		sysInst.ResolveMetrics()
+// InitSystem creates the named system and runs its initializers, returning
+// an error if an initializer fails, eg when a computed override violates a
+// param's constraint.
+func (f *FileInstance) InitSystem(systemName string) (sysInst *SystemInstance, currTime core.Duration, err error) {
+	system, err := f.Decl.GetSystem(systemName)
+	if err != nil {
+		return nil, 0, err
 	}
-	return sysInst, currTime
+
+	// Initialize the system — wire components
+	sysInst = NewSystemInstance(f, system)
+	se := NewSimpleEval(f, nil)
+	defer func() {
+		if r := recover(); r != nil {
+			sysInst, currTime, err = nil, 0, se.runtimeError(se.current, r)
+		}
+	}()
+	env := f.Env().Push() // Create new environment for system
+	se.EvalInitSystem(sysInst, env, &currTime)
+	sysInst.Env = env
+	sysInst.ResolveGenerators()
+	sysInst.ResolveMetrics()
+	return sysInst, currTime, nil
 }
 
 // GetComponentDecl returns the ComponentDecl for the given name even if it is an import by resolving to the original source
@@ -91,11 +112,10 @@ func (f *FileInstance) GetComponentDecl(name string) (*ComponentDecl, error) {
 		// see if it is an import
 		importDecl, ok := def.(*decl.ImportDecl)
 		if ok {
-			importedFS, err := f.Runtime.Loader.LoadFile(importDecl.ResolvedFullPath, f.Decl.FullPath, 0)
+			def, err = f.importedDefinition(importDecl)
 			if err != nil {
 				return nil, err
 			}
-			def, _ = importedFS.FileDecl.GetDefinition(importDecl.ImportedItem.Value)
 			compDecl, _ = def.(*decl.ComponentDecl)
 		}
 	}
@@ -120,11 +140,10 @@ func (f *FileInstance) GetEnumDecl(name string) (*EnumDecl, error) {
 		// see if it is an import
 		importDecl, ok := def.(*decl.ImportDecl)
 		if ok {
-			importedFS, err := f.Runtime.Loader.LoadFile(importDecl.ResolvedFullPath, f.Decl.FullPath, 0)
+			def, err = f.importedDefinition(importDecl)
 			if err != nil {
 				return nil, err
 			}
-			def, _ = importedFS.FileDecl.GetDefinition(importDecl.ImportedItem.Value)
 			enumDecl, _ = def.(*decl.EnumDecl)
 		}
 	}
@@ -135,3 +154,17 @@ func (f *FileInstance) GetEnumDecl(name string) (*EnumDecl, error) {
 	}
 	return enumDecl, nil
 }
+
+// importedDefinition returns the definition an import refers to, in the file
+// that declares it, which is not the file imported from if it re-exports it.
+func (f *FileInstance) importedDefinition(importDecl *ImportDecl) (Node, error) {
+	if importDecl.ResolvedItem != nil {
+		return importDecl.ResolvedItem, nil
+	}
+	importedFS, err := f.Runtime.Loader.LoadFile(importDecl.ResolvedFullPath, f.Decl.FullPath, 0)
+	if err != nil {
+		return nil, err
+	}
+	def, _ := importedFS.FileDecl.GetDefinition(importDecl.ImportedItem.Value)
+	return def, nil
+}
@@ -8,7 +8,7 @@ import (
 // FlowStrategy defines the interface for different flow evaluation strategies
 type FlowStrategy interface {
 	// Evaluate performs flow analysis given system and generators
-	Evaluate(system *SystemInstance, generators []GeneratorConfigAPI) (*FlowAnalysisResult, error)
+	Evaluate(system *SystemInstance, generators []GeneratorConfigAPI, opts FlowSolverOptions) (*FlowAnalysisResult, error)
 	
 	// GetInfo returns metadata about this strategy
 	GetInfo() StrategyInfo
@@ -31,12 +31,20 @@ type FlowAnalysisResult struct {
 	Strategy    string                    `json:"strategy"`
 	Status      FlowStatus                `json:"status"`
 	Iterations  int                       `json:"iterations,omitempty"`
+	Residual    float64                   `json:"residual"`
+	History     []FlowIteration           `json:"history,omitempty"`
 	System      string                    `json:"system"`
 	Generators  []GeneratorConfigAPI      `json:"generators"`
 	Flows       FlowData                  `json:"flows"`
 	Warnings    []string                  `json:"warnings,omitempty"`
 }
 
+// FlowIteration records the arrival rate changes made by one solver iteration.
+type FlowIteration struct {
+	MaxChange float64            `json:"maxChange"`
+	Changes   map[string]float64 `json:"changes"` // Keyed by component.method
+}
+
 // FlowStatus indicates the status of flow analysis
 type FlowStatus string
 
@@ -122,7 +130,7 @@ func ListFlowStrategies() map[string]StrategyInfo {
 }
 
 // EvaluateFlowStrategy runs flow analysis with the specified strategy
-func EvaluateFlowStrategy(strategyName string, system *SystemInstance, generators []GeneratorConfigAPI) (*FlowAnalysisResult, error) {
+func EvaluateFlowStrategy(strategyName string, system *SystemInstance, generators []GeneratorConfigAPI, opts FlowSolverOptions) (*FlowAnalysisResult, error) {
 	strategy, err := GetFlowStrategy(strategyName)
 	if err != nil {
 		return nil, err
@@ -132,7 +140,7 @@ func EvaluateFlowStrategy(strategyName string, system *SystemInstance, generator
 		return nil, fmt.Errorf("flow strategy '%s' is not available", strategyName)
 	}
 	
-	return strategy.Evaluate(system, generators)
+	return strategy.Evaluate(system, generators, opts)
 }
 
 // GetDefaultFlowStrategy returns the name of the default flow strategy
@@ -2,13 +2,15 @@ package runtime
 
 import (
 	"fmt"
+	"sort"
+	"strings"
 )
 
 // RuntimeFlowStrategy implements flow evaluation using the runtime-based approach
 type RuntimeFlowStrategy struct{}
 
 // Evaluate performs flow analysis using SolveSystemFlowsRuntime
-func (s *RuntimeFlowStrategy) Evaluate(system *SystemInstance, generators []GeneratorConfigAPI) (*FlowAnalysisResult, error) {
+func (s *RuntimeFlowStrategy) Evaluate(system *SystemInstance, generators []GeneratorConfigAPI, opts FlowSolverOptions) (*FlowAnalysisResult, error) {
 	if system == nil {
 		return nil, fmt.Errorf("system instance is required")
 	}
@@ -33,9 +35,10 @@ func (s *RuntimeFlowStrategy) Evaluate(system *SystemInstance, generators []Gene
 
 	// Create flow scope
 	scope := NewFlowScope(system.Env)
+	scope.DefaultBranchProbability = opts.BranchProbability
 
 	// Run flow evaluation
-	rateMap := SolveSystemFlowsRuntime(runtimeGenerators, scope)
+	rateMap, stats := SolveSystemFlowsRuntimeWithOptions(runtimeGenerators, scope, opts)
 
 	// Apply the calculated rates to components
 	for comp, methods := range rateMap {
@@ -50,13 +53,34 @@ func (s *RuntimeFlowStrategy) Evaluate(system *SystemInstance, generators []Gene
 	// Convert results to API format
 	result := &FlowAnalysisResult{
 		Strategy:   "runtime",
-		Status:     FlowStatusConverged, // TODO: Detect actual convergence status
-		Iterations: 10,                  // TODO: Track actual iterations
+		Status:     FlowStatusConverged,
+		Iterations: stats.Iterations,
+		Residual:   stats.Residual,
 		System:     system.GetSystemName(),
 		Generators: generators,
 		Flows:      s.convertToFlowData(rateMap, scope, system),
 		Warnings:   []string{"Control flow analysis may overestimate rates for early return patterns"},
 	}
+	result.Flows.Metadata["convergenceThreshold"] = opts.Tolerance
+	for _, changes := range stats.Changes {
+		iter := FlowIteration{Changes: s.convertRates(changes, system)}
+		for _, delta := range iter.Changes {
+			iter.MaxChange = max(iter.MaxChange, abs(delta))
+		}
+		result.History = append(result.History, iter)
+	}
+	if !stats.Converged && stats.Iterations > 0 {
+		result.Status = FlowStatusPartial
+		var changing []string
+		for name, delta := range result.History[len(result.History)-1].Changes {
+			if abs(delta) >= opts.Tolerance {
+				changing = append(changing, name)
+			}
+		}
+		sort.Strings(changing)
+		result.Warnings = append(result.Warnings, fmt.Sprintf("flows did not converge after %d iterations (residual %.4g), rates still changing at: %s",
+			stats.Iterations, stats.Residual, strings.Join(changing, ", ")))
+	}
 
 	return result, nil
 }
@@ -83,7 +107,6 @@ func (s *RuntimeFlowStrategy) IsAvailable() bool {
 // convertToFlowData converts runtime RateMap to API-friendly format
 func (s *RuntimeFlowStrategy) convertToFlowData(rateMap RateMap, scope *FlowScope, system *SystemInstance) FlowData {
 	edges := []FlowEdgeAPI{}
-	componentRates := make(map[string]float64)
 
 	// Convert flow edges if available
 	if scope.FlowEdges != nil {
@@ -109,23 +132,12 @@ func (s *RuntimeFlowStrategy) convertToFlowData(rateMap RateMap, scope *FlowScop
 	}
 
 	// Convert component rates
-	for component, methods := range rateMap {
-		componentName := s.findComponentName(component, system)
-		if componentName == "" {
-			continue
-		}
-
-		for method, rate := range methods {
-			key := fmt.Sprintf("%s.%s", componentName, method)
-			componentRates[key] = rate
-		}
-	}
+	componentRates := s.convertRates(rateMap, system)
 
 	// Metadata
 	metadata := map[string]interface{}{
-		"totalFlow":            s.calculateTotalFlow(componentRates),
-		"maxComponentRate":     s.findMaxRate(componentRates),
-		"convergenceThreshold": 0.01,
+		"totalFlow":        s.calculateTotalFlow(componentRates),
+		"maxComponentRate": s.findMaxRate(componentRates),
 	}
 
 	return FlowData{
@@ -135,37 +147,31 @@ func (s *RuntimeFlowStrategy) convertToFlowData(rateMap RateMap, scope *FlowScop
 	}
 }
 
-// findComponentName finds the variable name for a component instance
-func (s *RuntimeFlowStrategy) findComponentName(comp *ComponentInstance, system *SystemInstance) string {
-	if comp == nil || system == nil {
-		return ""
-	}
-
-	// Search through the system environment for this component instance
-	bindings := system.Env.All()
-	for name, value := range bindings {
-		if compValue, ok := value.Value.(*ComponentInstance); ok && compValue == comp {
-			return name
+// convertRates keys a RateMap by "component.method" using the system's variable
+// names, dropping internal components.
+func (s *RuntimeFlowStrategy) convertRates(rateMap RateMap, system *SystemInstance) map[string]float64 {
+	out := make(map[string]float64)
+	for component, methods := range rateMap {
+		componentName := s.findComponentName(component, system)
+		if componentName == "" {
+			continue
 		}
-	}
 
-	// If not found in system env, it might be a nested component
-	// Try to find it as a child of another component
-	for name, value := range bindings {
-		if parentComp, ok := value.Value.(*ComponentInstance); ok && parentComp != nil {
-			// Check if this component is a child of the parent
-			for childName, childBinding := range parentComp.Env.All() {
-				if childComp, ok := childBinding.Value.(*ComponentInstance); ok && childComp == comp {
-					// Return parent.child format for nested components
-					return fmt.Sprintf("%s.%s", name, childName)
-				}
-			}
+		for method, rate := range methods {
+			key := fmt.Sprintf("%s.%s", componentName, method)
+			out[key] = rate
 		}
 	}
+	return out
+}
 
-	// If still not found, it's an internal component that shouldn't be exposed
-	// Return empty string to filter it out
-	return ""
+// findComponentName returns the instance's path in the system, eg
+// "app.cache", or "" for internal components that should not be exposed.
+func (s *RuntimeFlowStrategy) findComponentName(comp *ComponentInstance, system *SystemInstance) string {
+	if comp == nil || system == nil {
+		return ""
+	}
+	return comp.InstanceID()
 }
 
 // calculateTotalFlow sums all component rates
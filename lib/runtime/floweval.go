@@ -476,6 +476,9 @@ func (fc *FlowContext) analyzeStatement(stmt Stmt, inputRate float64, outflows m
 	switch s := stmt.(type) {
 	case *ExprStmt:
 		fc.analyzeExprStatement(s, inputRate, outflows)
+	case *SpawnStmt:
+		// Spawned calls add load to their target like any other call
+		fc.analyzeExprStatement(&ExprStmt{NodeInfo: s.NodeInfo, Expression: s.Call}, inputRate, outflows)
 	case *IfStmt:
 		fc.analyzeIfStatement(s, inputRate, outflows)
 	case *AssignmentStmt:
@@ -0,0 +1,68 @@
+package runtime
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestFlowFanOutWeightedByDistribute verifies that a dependency only called
+// in a 30% distribute case receives 0.3x the caller's rate while an
+// unconditional call receives the full rate.
+func TestFlowFanOutWeightedByDistribute(t *testing.T) {
+	sys := parseAndLoad(t, `
+component DB { method Query() Bool { return true } }
+component Cache { method Get() Bool { return true } }
+component App {
+  uses db DB()
+  uses cache Cache()
+  method Serve() Bool {
+    self.cache.Get()
+    return dist {
+      30 => self.db.Query(),
+      70 => true,
+    }
+  }
+}
+component Arch { uses app App() }
+system FanOut(arch Arch) { }
+`)
+	app := sys.FindComponent("arch.app")
+	require.NotNil(t, app)
+
+	outflows := FlowEvalRuntime(app, "Serve", 100, NewFlowScope(sys.Env))
+	assert.InDelta(t, 30.0, outflows.GetRate(sys.FindComponent("arch.app.db"), "Query"), 1e-9)
+	assert.InDelta(t, 100.0, outflows.GetRate(sys.FindComponent("arch.app.cache"), "Get"), 1e-9)
+}
+
+// TestFlowFanOutConfigurableBranchProbability verifies that calls inside an
+// if whose outcome is unknown are weighted by the scope's branch probability.
+func TestFlowFanOutConfigurableBranchProbability(t *testing.T) {
+	sys := parseAndLoad(t, `
+component DB { method Query() Bool { return true } }
+component Auth { method Check() Bool { return true } }
+component App {
+  uses db DB()
+  uses auth Auth()
+  method Serve() Bool {
+    if self.auth.Check() {
+      self.db.Query()
+    }
+    return true
+  }
+}
+component Arch { uses app App() }
+system Branchy(arch Arch) { }
+`)
+	app := sys.FindComponent("arch.app")
+	require.NotNil(t, app)
+	db := sys.FindComponent("arch.app.db")
+
+	scope := NewFlowScope(sys.Env)
+	assert.InDelta(t, 50.0, FlowEvalRuntime(app, "Serve", 100, scope).GetRate(db, "Query"), 1e-9)
+
+	scope = NewFlowScope(sys.Env)
+	scope.DefaultBranchProbability = 0.3
+	assert.InDelta(t, 30.0, FlowEvalRuntime(app, "Serve", 100, scope).GetRate(db, "Query"), 1e-9)
+}
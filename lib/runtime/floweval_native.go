@@ -2,6 +2,7 @@ package runtime
 
 import (
 	"github.com/panyam/sdl/lib/core"
+	"github.com/panyam/sdl/lib/decl"
 )
 
 // FlowNativeMethodInfo describes flow and timing characteristics of a native method
@@ -48,6 +49,13 @@ var flowNativeMethods = map[string]*FlowNativeMethodInfo{
 		HasDelay:    false,
 		HasOutflows: false,
 	},
+	// Conversion and enum builtins take no time and make no calls
+	decl.BuiltinFloat:    {},
+	decl.BuiltinInt:      {},
+	decl.BuiltinDuration: {},
+	decl.BuiltinString:   {},
+	decl.BuiltinValues:   {},
+	decl.BuiltinCount:    {},
 }
 
 // RegisterFlowNativeMethod registers a native method for flow analysis
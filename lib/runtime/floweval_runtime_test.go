@@ -1,11 +1,36 @@
 package runtime
 
 import (
+	"errors"
+	"strings"
 	"testing"
 
+	"github.com/panyam/sdl/lib/components"
 	compdecl "github.com/panyam/sdl/lib/components/decl"
 )
 
+// TestNativeArrivalRateUnsupported verifies that a native component that does
+// not model contention reports which component rejected the rate, and that
+// applying flows to it treats it as having infinite capacity.
+func TestNativeArrivalRateUnsupported(t *testing.T) {
+	index := compdecl.NewHashIndex("userIndex")
+	err := index.SetArrivalRate("Find", 10)
+	if !errors.Is(err, components.ErrNoArrivalRates) {
+		t.Fatalf("Expected ErrNoArrivalRates, got: %v", err)
+	}
+	if !strings.Contains(err.Error(), "component userIndex") {
+		t.Errorf("Expected the error to name the component, got: %v", err)
+	}
+
+	compInst := &ComponentInstance{
+		ObjectInstance: ObjectInstance{IsNative: true, NativeInstance: index},
+		id:             "userIndex",
+	}
+	if err := compInst.SetArrivalRate("Find", 10); err != nil {
+		t.Errorf("Expected flows to skip the component, got: %v", err)
+	}
+}
+
 func TestFlowEvalRuntime(t *testing.T) {
 	t.Run("Native Component", func(t *testing.T) {
 		// Create a flow scope
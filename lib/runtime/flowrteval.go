@@ -52,12 +52,22 @@ func FlowEvalRuntime(component *ComponentInstance, method string, inputRate floa
 	}
 
 	// For SDL components, get the method declaration
-	methodDecl, err := component.ComponentDecl.GetMethod(method)
-	if err != nil || methodDecl == nil {
+	methodDecl := component.Method(method)
+	if methodDecl == nil {
 		Debug("FlowEvalRuntime: Method %s.%s not found", component.ID(), method)
 		return outflows
 	}
 
+	// Calls served from a @memoize cache never reach the method body
+	if ttl, hitRate, memoized := methodDecl.Memoize(); memoized {
+		inputRate *= 1 - MemoizeHitRate(ttl, hitRate, inputRate)
+	}
+
+	// Identical calls in flight together share one invocation of a @coalesce method
+	if window, coalesced := methodDecl.Coalesce(); coalesced {
+		inputRate /= CoalesceFactor(window, inputRate)
+	}
+
 	// Push new scope for this method evaluation
 	newScope := scope.Push(component, methodDecl)
 
@@ -84,6 +94,9 @@ func analyzeStatementRuntime(stmt Stmt, inputRate float64, scope *FlowScope, out
 	switch s := stmt.(type) {
 	case *ExprStmt:
 		analyzeExprStatementRuntime(s, inputRate, scope, outflows)
+	case *SpawnStmt:
+		// Spawned calls add load to their target like any other call
+		analyzeExprRuntime(s.Call, inputRate, scope, outflows)
 	case *IfStmt:
 		analyzeIfStatementRuntime(s, inputRate, scope, outflows)
 	case *AssignmentStmt:
@@ -104,12 +117,97 @@ func analyzeStatementRuntime(stmt Stmt, inputRate float64, scope *FlowScope, out
 
 // analyzeExprStatementRuntime processes expression statements that might contain calls
 func analyzeExprStatementRuntime(stmt *ExprStmt, inputRate float64, scope *FlowScope, outflows RateMap) {
-	// Check if the expression is a call
-	if callExpr, ok := stmt.Expression.(*CallExpr); ok {
-		analyzeCallExprRuntime(callExpr, inputRate, scope, outflows)
+	analyzeExprRuntime(stmt.Expression, inputRate, scope, outflows)
+}
+
+// analyzeExprRuntime walks an expression adding the expected rate of every call it makes.
+// Calls within distribute cases are weighted by the probability of their case so
+// that outflows reflect the expected number of calls per invocation.
+func analyzeExprRuntime(expr Expr, inputRate float64, scope *FlowScope, outflows RateMap) {
+	switch e := expr.(type) {
+	case *CallExpr:
+		for _, arg := range e.ArgList {
+			analyzeExprRuntime(arg, inputRate, scope, outflows)
+		}
+		analyzeCallExprRuntime(e, inputRate, scope, outflows)
+	case *DistributeExpr:
+		analyzeDistributeExprRuntime(e, inputRate, scope, outflows)
+	case *BinaryExpr:
+		analyzeExprRuntime(e.Left, inputRate, scope, outflows)
+		analyzeExprRuntime(e.Right, inputRate, scope, outflows)
+	case *UnaryExpr:
+		analyzeExprRuntime(e.Right, inputRate, scope, outflows)
+	case *SampleExpr:
+		analyzeExprRuntime(e.FromExpr, inputRate, scope, outflows)
+	case *TupleExpr:
+		for _, child := range e.Children {
+			analyzeExprRuntime(child, inputRate, scope, outflows)
+		}
+	case *GoExpr:
+		if e.Stmt != nil {
+			analyzeStatementRuntime(e.Stmt, inputRate, scope, outflows)
+		} else if e.Expr != nil {
+			analyzeExprRuntime(e.Expr, inputRate, scope, outflows)
+		}
 	}
 }
 
+// analyzeDistributeExprRuntime weights each case body by the probability of that case.
+// Case weights are normalized against the total (explicit or the sum of the cases),
+// and the default case receives the remaining probability.
+func analyzeDistributeExprRuntime(dist *DistributeExpr, inputRate float64, scope *FlowScope, outflows RateMap) {
+	if len(dist.Cases) == 0 {
+		if dist.Default != nil {
+			analyzeExprRuntime(dist.Default, inputRate, scope, outflows)
+		}
+		return
+	}
+
+	weights := make([]float64, len(dist.Cases))
+	casesTotal := 0.0
+	for i, c := range dist.Cases {
+		weight, ok := literalNumber(c.Condition)
+		if !ok {
+			// Probability not known statically - assume cases are equally likely
+			weight = 1.0
+		}
+		weights[i] = weight
+		casesTotal += weight
+	}
+
+	total := casesTotal
+	if dist.TotalProb != nil {
+		if t, ok := literalNumber(dist.TotalProb); ok && t > 0 {
+			total = t
+		}
+	}
+	if total <= 0 {
+		return
+	}
+
+	for i, c := range dist.Cases {
+		analyzeExprRuntime(c.Body, inputRate*weights[i]/total, scope, outflows)
+	}
+	if dist.Default != nil && total > casesTotal {
+		analyzeExprRuntime(dist.Default, inputRate*(total-casesTotal)/total, scope, outflows)
+	}
+}
+
+// literalNumber returns the numeric value of an int or float literal.
+func literalNumber(expr Expr) (float64, bool) {
+	lit, ok := expr.(*LiteralExpr)
+	if !ok {
+		return 0, false
+	}
+	switch v := lit.Value.Value.(type) {
+	case int64:
+		return float64(v), true
+	case float64:
+		return v, true
+	}
+	return 0, false
+}
+
 // analyzeCallExprRuntime processes a call expression and adds it to outflows
 func analyzeCallExprRuntime(callExpr *CallExpr, inputRate float64, scope *FlowScope, outflows RateMap) {
 	// Extract the target component and method from the call
@@ -261,6 +359,9 @@ func resolveComponentPath(path []string, scope *FlowScope) *ComponentInstance {
 
 // analyzeIfStatementRuntime handles conditional flows with runtime analysis
 func analyzeIfStatementRuntime(stmt *IfStmt, inputRate float64, scope *FlowScope, outflows RateMap) {
+	// Every invocation evaluates the condition, so calls in it see the full rate
+	analyzeExprRuntime(stmt.Condition, inputRate, scope, outflows)
+
 	// Evaluate condition probability
 	conditionProb := evaluateConditionProbabilityRuntime(stmt.Condition, scope)
 
@@ -299,25 +400,39 @@ func evaluateConditionProbabilityRuntime(condition Expr, scope *FlowScope) float
 		}
 	}
 
-	// Default to 50% probability for unknown conditions
-	// Debug("evaluateConditionProbabilityRuntime: No tracked outcome for condition, using default 0.5")
-	return 0.5
+	switch c := condition.(type) {
+	case *LiteralExpr:
+		if b, ok := c.Value.Value.(bool); ok {
+			if b {
+				return 1.0
+			}
+			return 0.0
+		}
+	case *UnaryExpr:
+		if c.Operator == "!" || c.Operator == "not" {
+			return 1.0 - evaluateConditionProbabilityRuntime(c.Right, scope)
+		}
+	case *CallExpr:
+		// A call to a native component is true as often as its method
+		// succeeds, eg a cache read hits
+		if targetComp, targetMethod := extractCallTargetRuntime(c, scope); targetComp != nil && targetComp.IsNative && targetMethod != "" {
+			return getMethodSuccessRateRuntime(targetComp, targetMethod, scope)
+		}
+	}
+
+	// Fall back to the configured branch probability for unknown conditions
+	return scope.DefaultBranchProbability
 }
 
 // analyzeAssignmentStatementRuntime handles assignments that might contain calls
 func analyzeAssignmentStatementRuntime(stmt *AssignmentStmt, inputRate float64, scope *FlowScope, outflows RateMap) {
-	// Check if the assigned expression contains a call
-	if callExpr, ok := stmt.Value.(*CallExpr); ok {
-		analyzeCallExprRuntime(callExpr, inputRate, scope, outflows)
-	}
+	analyzeExprRuntime(stmt.Value, inputRate, scope, outflows)
 }
 
 // analyzeReturnStatementRuntime handles return statements that might contain calls
 func analyzeReturnStatementRuntime(stmt *ReturnStmt, inputRate float64, scope *FlowScope, outflows RateMap) {
 	if stmt.ReturnValue != nil {
-		if callExpr, ok := stmt.ReturnValue.(*CallExpr); ok {
-			analyzeCallExprRuntime(callExpr, inputRate, scope, outflows)
-		}
+		analyzeExprRuntime(stmt.ReturnValue, inputRate, scope, outflows)
 	}
 }
 
@@ -341,9 +456,8 @@ func analyzeBlockStatementRuntime(stmt *BlockStmt, inputRate float64, scope *Flo
 func analyzeLetStatementRuntime(stmt *LetStmt, inputRate float64, scope *FlowScope, outflows RateMap) {
 	// Check if the assigned expression contains a call
 	if stmt.Value != nil {
+		analyzeExprRuntime(stmt.Value, inputRate, scope, outflows)
 		if callExpr, ok := stmt.Value.(*CallExpr); ok {
-			// Analyze the call
-			analyzeCallExprRuntime(callExpr, inputRate, scope, outflows)
 
 			// Track the success rate of this method call for the variable
 			if len(stmt.Variables) > 0 && stmt.Variables[0] != nil && stmt.Variables[0].Value != "" {
@@ -366,9 +480,14 @@ func getMethodSuccessRateRuntime(component *ComponentInstance, method string, sc
 		return rate
 	}
 
-	// For native components, get it from GetFlowPattern
+	// For native components, get it from GetFlowPattern at the rate the
+	// component was last seen to receive
 	if component.IsNative {
-		pattern := component.GetFlowPattern(method, 1.0)
+		inputRate := scope.ArrivalRates.GetRate(component, method)
+		if inputRate <= 0 {
+			inputRate = 1.0
+		}
+		pattern := component.GetFlowPattern(method, inputRate)
 		return pattern.SuccessRate
 	}
 
@@ -376,14 +495,45 @@ func getMethodSuccessRateRuntime(component *ComponentInstance, method string, sc
 	return 1.0
 }
 
+// FlowSolverOptions controls the fixed-point iteration of the runtime flow solver.
+type FlowSolverOptions struct {
+	Tolerance     float64 // Converged once no rate changes by this much (calls/s) in an iteration
+	MaxIterations int
+
+	// Probability assumed for if conditions whose outcome cannot be determined
+	BranchProbability float64
+}
+
+// DefaultFlowSolverOptions returns the tolerance, iteration cap and branch
+// probability used when none are set.
+func DefaultFlowSolverOptions() FlowSolverOptions {
+	return FlowSolverOptions{Tolerance: 0.01, MaxIterations: 30, BranchProbability: 0.5}
+}
+
+// FlowSolverStats describes how the flow solver converged.
+type FlowSolverStats struct {
+	Iterations int
+	Residual   float64   // Largest rate change in the final iteration
+	Converged  bool
+	Changes    []RateMap // Per iteration change in each arrival rate (new - old)
+}
+
 // SolveSystemFlowsRuntime performs flow analysis using runtime component instances
+// with the default solver options.
+func SolveSystemFlowsRuntime(generators []GeneratorEntryPointRuntime, scope *FlowScope) RateMap {
+	rates, _ := SolveSystemFlowsRuntimeWithOptions(generators, scope, DefaultFlowSolverOptions())
+	return rates
+}
+
+// SolveSystemFlowsRuntimeWithOptions performs flow analysis using runtime component instances
 // This implements a two-phase approach:
 // 1. Flow propagation through the component graph
 // 2. Iterative back-pressure adjustment until convergence
-func SolveSystemFlowsRuntime(generators []GeneratorEntryPointRuntime, scope *FlowScope) RateMap {
+func SolveSystemFlowsRuntimeWithOptions(generators []GeneratorEntryPointRuntime, scope *FlowScope, opts FlowSolverOptions) (RateMap, FlowSolverStats) {
+	var stats FlowSolverStats
 	if scope == nil {
 		// Debug("SolveSystemFlowsRuntime: scope is nil")
-		return NewRateMap()
+		return NewRateMap(), stats
 	}
 
 	// Initialize arrival rates with entry points
@@ -396,12 +546,17 @@ func SolveSystemFlowsRuntime(generators []GeneratorEntryPointRuntime, scope *Flo
 	// Debug("SolveSystemFlowsRuntime: Starting fixed-point iteration with %d entry points", len(generators))
 
 	// Configuration for convergence
-	maxIterations := 30
-	convergenceThreshold := 0.01 // Match string-based convergence threshold
+	defaults := DefaultFlowSolverOptions()
+	if opts.Tolerance <= 0 {
+		opts.Tolerance = defaults.Tolerance
+	}
+	if opts.MaxIterations <= 0 {
+		opts.MaxIterations = defaults.MaxIterations
+	}
 	dampingFactor := 0.3
 
 	// Iterate until convergence
-	for iteration := 0; iteration < maxIterations; iteration++ {
+	for iteration := 0; iteration < opts.MaxIterations; iteration++ {
 		// Save old rates for convergence check
 		oldRates := scope.ArrivalRates.Copy()
 
@@ -420,6 +575,7 @@ func SolveSystemFlowsRuntime(generators []GeneratorEntryPointRuntime, scope *Flo
 		iterScope := NewFlowScope(scope.SysEnv)
 		iterScope.ArrivalRates = scope.ArrivalRates.Copy()
 		iterScope.SuccessRates = NewRateMap() // Fresh success rates for this iteration
+		iterScope.DefaultBranchProbability = scope.DefaultBranchProbability
 		
 		// Clear flow edges at the start of each iteration to prevent accumulation
 		// We'll keep the final iteration's edges for visualization
@@ -452,21 +608,40 @@ func SolveSystemFlowsRuntime(generators []GeneratorEntryPointRuntime, scope *Flo
 
 		// Check convergence (all rates changed by < threshold)
 		maxChange := computeMaxChange(oldRates, newRates)
+		stats.Iterations = iteration + 1
+		stats.Residual = maxChange
+		stats.Changes = append(stats.Changes, rateChanges(oldRates, newRates))
 
 		// Debug("SolveSystemFlowsRuntime: Iteration %d, max change: %.6f", iteration, maxChange)
 
-		if maxChange < convergenceThreshold {
+		if maxChange < opts.Tolerance {
 			// Debug("SolveSystemFlowsRuntime: Converged after %d iterations", iteration+1)
+			stats.Converged = true
 			scope.ArrivalRates = newRates
-			return newRates
+			return newRates, stats
 		}
 
 		// Apply damping to prevent oscillation
 		scope.ArrivalRates = applyDamping(oldRates, newRates, dampingFactor)
 	}
 
-	Debug("SolveSystemFlowsRuntime: Did not converge after %d iterations", maxIterations)
-	return scope.ArrivalRates
+	Debug("SolveSystemFlowsRuntime: Did not converge after %d iterations", opts.MaxIterations)
+	return scope.ArrivalRates, stats
+}
+
+// rateChanges returns the change in every rate that differs between old and new.
+func rateChanges(oldRates, newRates RateMap) RateMap {
+	changes := NewRateMap()
+	for _, rates := range []RateMap{oldRates, newRates} {
+		for comp, methods := range rates {
+			for method := range methods {
+				if delta := newRates.GetRate(comp, method) - oldRates.GetRate(comp, method); delta != 0 {
+					changes.SetRate(comp, method, delta)
+				}
+			}
+		}
+	}
+	return changes
 }
 
 // computeMaxChange calculates the maximum rate change between old and new rates
@@ -19,6 +19,9 @@ type FlowScope struct {
 
 	// Variable outcome tracking for conditional flow analysis
 	VariableOutcomes map[string]float64
+
+	// Probability assumed for if conditions whose outcome cannot be determined
+	DefaultBranchProbability float64
 }
 
 // NewFlowScope creates a new root flow scope
@@ -35,6 +38,8 @@ func NewFlowScope(sysEnv *Env[Value]) *FlowScope {
 		CallStack:        make([]*ComponentInstance, 0),
 		FlowEdges:        NewFlowEdgeMap(),
 		VariableOutcomes: make(map[string]float64),
+
+		DefaultBranchProbability: 0.5,
 	}
 }
 
@@ -50,6 +55,8 @@ func (fs *FlowScope) Push(component *ComponentInstance, method *MethodDecl) *Flo
 		FlowEdges:        fs.FlowEdges, // Share flow edges with parent
 		CallStack:        append(fs.CallStack, component),
 		VariableOutcomes: make(map[string]float64), // Fresh variable tracking per method
+
+		DefaultBranchProbability: fs.DefaultBranchProbability,
 	}
 }
 
@@ -1,8 +1,10 @@
 package runtime
 
 import (
+	"fmt"
 	"log"
 	goruntime "runtime"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -22,6 +24,20 @@ type Generator struct {
 	// Rate configuration not in proto
 	RateInterval core.Duration // Interval in seconds (default 1.0 = per second)
 
+	// Number of requests after which the generator stops itself, 0 runs until stopped
+	MaxRequests int
+
+	// How often the generator's loop wakes to emit the requests due, 0 adapts
+	// it to the rate (see EffectiveTickInterval)
+	TickInterval time.Duration
+
+	// Limits the calls made between starting the generator and it stopping,
+	// after which it stops itself with a *BudgetExceededError (see Err)
+	Budget RunBudget
+
+	// Varies the rate while the generator runs, nil keeps it at Rate
+	Profile *RateProfile
+
 	// Resolved references (populated during system init)
 	ResolvedComponent *ComponentInstance
 	ResolvedMethod    *MethodDecl
@@ -33,8 +49,12 @@ type Generator struct {
 	System           *SystemInstance
 	nextVirtualTime  core.Duration
 	timeMutex        sync.Mutex
-	stopNotifyChan   chan bool
+	done             chan struct{} // Closed when the goroutine started by Start exits
 	eventAccumulator float64
+	profileRate      float64
+	emitted          atomic.Int64
+	budget           *budgetMeter
+	err              atomic.Pointer[error]
 	GenFunc          func(iter int)
 }
 
@@ -67,23 +87,63 @@ func (g *Generator) IsRunning() bool {
 	return g.Enabled && !g.stopped.Load()
 }
 
-// Stop stops the generator.
+// Emitted returns the number of requests emitted since the generator was last started.
+func (g *Generator) Emitted() int64 {
+	return g.emitted.Load()
+}
+
+// NoEffectWarning returns a warning when the generator's target method has
+// no measurable effect, so calling it produces trivial results, or "" when
+// it does or cannot be resolved.
+func (g *Generator) NoEffectWarning() string {
+	if g.ResolvedComponent == nil {
+		return ""
+	}
+	method := g.ResolvedMethod
+	if method == nil {
+		method, _ = g.ResolvedComponent.ComponentDecl.GetMethod(g.Method)
+	}
+	if method == nil || !decl.HasNoEffect(method) {
+		return ""
+	}
+	return fmt.Sprintf("generator '%s' targets %s.%s which makes no calls and has no modeled latency, it may not be the intended entry point",
+		g.Name, g.Component, g.Method)
+}
+
+// Err returns the error that stopped the generator since it was last
+// started, or nil.
+func (g *Generator) Err() error {
+	if err := g.err.Load(); err != nil {
+		return *err
+	}
+	return nil
+}
+
+func (g *Generator) exhausted() bool {
+	return g.Err() != nil || (g.MaxRequests > 0 && g.emitted.Load() >= int64(g.MaxRequests))
+}
+
+// clock returns the clock of the generator's simulation context, or the real clock.
+func (g *Generator) clock() Clock {
+	if g.SimCtx != nil {
+		return g.SimCtx.GetClock()
+	}
+	return RealClock
+}
+
+// Stop stops the generator and blocks until its goroutine has exited, so no
+// call it made is still running once it returns.  Stop always blocks, wait is
+// kept for existing callers.
 func (g *Generator) Stop(wait bool) error {
 	if g.stopped.Load() || g.stopChan == nil {
 		return nil
 	}
-	if wait {
-		g.stopNotifyChan = make(chan bool, 1)
-		defer func() {
-			close(g.stopNotifyChan)
-			g.stopNotifyChan = nil
-		}()
-	}
 	log.Printf("Generator %s: Stopping...", g.Name)
+	done := g.done
 	g.stopped.Store(true)
 	close(g.stopChan)
 	g.Enabled = false
-	<-g.stopNotifyChan
+	<-done
 	return nil
 }
 
@@ -94,31 +154,44 @@ func (g *Generator) Start() error {
 	}
 	g.Enabled = true
 	g.stopped.Store(false)
+	g.emitted.Store(0)
+	g.err.Store(nil)
+	g.budget = g.Budget.start()
 	g.stopChan = make(chan bool)
+	g.done = make(chan struct{})
 	go g.run()
 	return nil
 }
 
 func (g *Generator) run() {
+	completed := false
+	done := g.done
 	defer func() {
 		g.stopChan = nil
 		g.Enabled = false
 		log.Printf("Generator %s: Stopped", g.Name)
-		if g.stopNotifyChan != nil {
-			g.stopNotifyChan <- true
+		close(done)
+		if completed && g.SimCtx != nil {
+			g.SimCtx.OnGeneratorCompleted(g)
 		}
 	}()
 
-	if g.Rate > 100 {
-		g.runBatched()
+	if g.Rate > 100 || g.TickInterval > 0 || g.Profile != nil {
+		completed = g.runBatched()
 	} else {
-		g.runSimple()
+		completed = g.runSimple()
+	}
+	if completed {
+		g.stopped.Store(true)
+		log.Printf("Generator %s: Completed after %d requests", g.Name, g.Emitted())
 	}
 }
 
-func (g *Generator) runSimple() {
+// runSimple emits one request per tick and returns true if it stopped
+// because MaxRequests was reached.
+func (g *Generator) runSimple() bool {
 	interval := time.Second / time.Duration(g.Rate)
-	ticker := time.NewTicker(interval)
+	ticker := g.clock().NewTicker(interval)
 	defer ticker.Stop()
 
 	log.Printf("Generator %s: Starting Simple execution at %v RPS", g.Name, g.Rate)
@@ -134,16 +207,63 @@ func (g *Generator) runSimple() {
 		}
 		select {
 		case <-g.stopChan:
-			return
-		case <-ticker.C:
+			return false
+		case <-ticker.C():
 			g.GenFunc(i)
+			g.emitted.Add(1)
+			if g.exhausted() {
+				return true
+			}
 		}
 	}
 }
 
-func (g *Generator) runBatched() {
-	batchInterval := 10 * time.Millisecond
-	ticker := time.NewTicker(batchInterval)
+// MinTickInterval is the finest tick EffectiveTickInterval adapts to.
+const MinTickInterval = time.Millisecond
+
+// EffectiveTickInterval returns how often the generator's loop wakes to emit
+// the requests due.  Unless TickInterval is set this is the gap between
+// requests, so each tick emits one, down to MinTickInterval beyond which
+// ticks emit batches.  Finer ticks approximate the rate with a smoother
+// stream while coarser ones emit bursts, but every tick costs a wakeup so
+// a 1ms tick spends 1000 wakeups a second where a 100ms one spends 10.
+func (g *Generator) EffectiveTickInterval() time.Duration {
+	if g.TickInterval > 0 {
+		return g.TickInterval
+	}
+	if g.Rate <= 0 {
+		return time.Second
+	}
+	if interval := time.Duration(float64(time.Second) / g.Rate); interval > MinTickInterval {
+		return interval
+	}
+	return MinTickInterval
+}
+
+// CurrentRate returns the rate requests are being emitted at, which follows
+// the Profile if the generator has one.
+func (g *Generator) CurrentRate() float64 {
+	if g.Profile != nil {
+		return g.profileRate
+	}
+	return g.Rate
+}
+
+// due returns how many requests are due after another tick of interval,
+// carrying fractions of a request over to later ticks.
+func (g *Generator) due(interval time.Duration) int {
+	g.eventAccumulator += g.CurrentRate() * interval.Seconds()
+	n := int(g.eventAccumulator)
+	g.eventAccumulator -= float64(n)
+	return n
+}
+
+// runBatched emits the requests due on every tick and returns true if it
+// stopped because MaxRequests was reached.  It returns after in flight
+// requests finish.
+func (g *Generator) runBatched() bool {
+	batchInterval := g.EffectiveTickInterval()
+	ticker := g.clock().NewTicker(batchInterval)
 	defer ticker.Stop()
 
 	eventsPerBatch := float64(g.Rate) * batchInterval.Seconds()
@@ -153,18 +273,25 @@ func (g *Generator) runBatched() {
 
 	maxConcurrent := goruntime.NumCPU() * 2
 	sem := make(chan struct{}, maxConcurrent)
+	var inflight sync.WaitGroup
+	defer inflight.Wait()
 	batchCount := 0
+	start := g.clock().Now()
 
 	defer func() { log.Printf("Generator %s: Stopped after %d batches", g.Name, batchCount) }()
 
 	for {
 		select {
 		case <-g.stopChan:
-			return
-		case <-ticker.C:
-			g.eventAccumulator += eventsPerBatch
-			batchSize := int(g.eventAccumulator)
-			g.eventAccumulator -= float64(batchSize)
+			return false
+		case now := <-ticker.C():
+			if g.Profile != nil {
+				g.profileRate = g.Profile.RateAt(now.Sub(start))
+			}
+			batchSize := g.due(batchInterval)
+			if g.MaxRequests > 0 {
+				batchSize = min(batchSize, g.MaxRequests-int(g.emitted.Load()))
+			}
 
 			if batchSize > 0 {
 				batchCount++
@@ -180,20 +307,25 @@ func (g *Generator) runBatched() {
 
 			for i := range batchSize {
 				if g.stopped.Load() {
-					return
+					return false
 				}
 				vTime := virtualTimes[i]
 
 				select {
 				case <-g.stopChan:
-					return
+					return false
 				case sem <- struct{}{}:
+					inflight.Add(1)
+					g.emitted.Add(1)
 					go func(virtualTime core.Duration) {
-						defer func() { <-sem }()
+						defer func() { <-sem; inflight.Done() }()
 						g.executeAtVirtualTime(virtualTime)
 					}(vTime)
 				}
 			}
+			if g.exhausted() {
+				return true
+			}
 		}
 	}
 }
@@ -209,24 +341,31 @@ func (g *Generator) getNextVirtualTime() core.Duration {
 	g.timeMutex.Lock()
 	defer g.timeMutex.Unlock()
 	current := g.nextVirtualTime
-	g.nextVirtualTime += core.Duration(1.0 / float64(g.Rate))
+	g.nextVirtualTime += core.Duration(1.0 / g.CurrentRate())
 	return current
 }
 
 func (g *Generator) executeAtVirtualTime(virtualTime core.Duration) {
-	eval := NewSimpleEval(g.System.File, g.SimCtx.GetTracer())
+	tracer := g.SimCtx.GetTracer()
+	eval := NewSimpleEval(g.System.File, tracer)
+	eval.budget = g.budget
 	env := g.System.Env.Push()
 	currTime := virtualTime
 
 	callExpr := &decl.CallExpr{
-		Function: &decl.MemberAccessExpr{
-			Receiver: &decl.IdentifierExpr{Value: g.Component},
-			Member:   &decl.IdentifierExpr{Value: g.Method},
-		},
+		Function: buildMemberAccessExpr(append(strings.Split(g.Component, "."), g.Method)),
 	}
 
-	result, _ := eval.Eval(callExpr, env, &currTime)
-	if eval.HasErrors() {
+	result, err := eval.EvalCall(callExpr, env, &currTime)
+	if entryTracer, ok := tracer.(EntryCallTracer); ok && err == nil && !eval.HasErrors() {
+		entryTracer.EntryCall(virtualTime, currTime-virtualTime, result, nil)
+	}
+	if budgetErr, ok := err.(*BudgetExceededError); ok {
+		g.err.CompareAndSwap(nil, &err)
+		log.Printf("Generator %s stopping: %v", g.Name, budgetErr)
+	} else if err != nil {
+		log.Printf("Generator %s error during eval: %v", g.Name, err)
+	} else if eval.HasErrors() {
 		log.Printf("Generator %s error during eval", g.Name)
 	} else if result.IsNil() {
 		// Normal for void methods
@@ -1,9 +1,14 @@
 package runtime
 
 import (
+	"math"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	protos "github.com/panyam/sdl/gen/go/sdl/v1/models"
+	"github.com/panyam/sdl/lib/core"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -68,3 +73,111 @@ func TestGeneratorRPS(t *testing.T) {
 	g4 := &Generator{Generator: &protos.Generator{Rate: 42}, RateInterval: 0}
 	assert.Equal(t, 42.0, g4.RPS())
 }
+
+// TestGeneratorStopWaits verifies that Stop returns only once the call in
+// flight has finished, so callers can release what the generator uses.
+func TestGeneratorStopWaits(t *testing.T) {
+	var started, finished atomic.Int64
+	g := &Generator{Generator: &protos.Generator{Name: "slow", Rate: 50}}
+	g.GenFunc = func(int) {
+		started.Add(1)
+		time.Sleep(50 * time.Millisecond)
+		finished.Add(1)
+	}
+	require.NoError(t, g.Start())
+	require.Eventually(t, func() bool { return started.Load() > 0 }, time.Second, time.Millisecond)
+
+	require.NoError(t, g.Stop(false))
+	assert.Equal(t, started.Load(), finished.Load(), "no call should be in flight after Stop")
+	assert.False(t, g.IsRunning())
+	assert.NoError(t, g.Stop(false), "stopping again is a no-op")
+}
+
+func TestGeneratorEffectiveTickInterval(t *testing.T) {
+	tick := func(rate float64, interval time.Duration) time.Duration {
+		g := &Generator{Generator: &protos.Generator{Rate: rate}, TickInterval: interval}
+		return g.EffectiveTickInterval()
+	}
+	assert.Equal(t, 20*time.Millisecond, tick(50, 0), "one request per tick at low rates")
+	assert.Equal(t, 2*time.Millisecond, tick(500, 0))
+	assert.Equal(t, MinTickInterval, tick(100000, 0), "batches beyond the finest tick")
+	assert.Equal(t, 100*time.Millisecond, tick(500, 100*time.Millisecond))
+}
+
+// callTimesTracer records the clock time of every entry call a generator
+// completes.
+type callTimesTracer struct {
+	clock *FakeClock
+	mu    sync.Mutex
+	times []time.Time
+}
+
+func (c *callTimesTracer) Enter(core.Duration, TraceEventKind, *ComponentInstance, *MethodDecl, ...string) int64 {
+	return 0
+}
+func (c *callTimesTracer) Exit(core.Duration, core.Duration, *ComponentInstance, *MethodDecl, Value, error) {
+}
+func (c *callTimesTracer) PushParentID(int64) {}
+func (c *callTimesTracer) PopParent()         {}
+
+func (c *callTimesTracer) EntryCall(core.Duration, core.Duration, Value, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.times = append(c.times, c.clock.Now())
+}
+
+func (c *callTimesTracer) count() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.times)
+}
+
+// TestGeneratorTickSmoothness verifies that finer ticks emit a high rate as
+// a steadier stream: the gaps between the calls a running generator makes
+// vary far less than with coarse ticks, while both make the configured rate.
+func TestGeneratorTickSmoothness(t *testing.T) {
+	sys := parseAndLoad(t, `
+component Server { method Handle() Bool { return true } }
+system T(server Server) {}
+`)
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	gapStdDev := func(interval time.Duration) (float64, int) {
+		clock := NewFakeClock(start)
+		tracer := &callTimesTracer{clock: clock}
+		g := &Generator{
+			Generator:    &protos.Generator{Name: "smooth", Component: "server", Method: "Handle", Rate: 1000},
+			TickInterval: interval,
+			System:       sys,
+			SimCtx:       &fakeSimContext{clock: clock, start: start, tracer: tracer},
+		}
+		require.NoError(t, g.Start())
+		defer g.Stop(true)
+		require.Eventually(t, func() bool { return clock.PendingTimers() == 1 }, time.Second, time.Millisecond, "tick ticker should be waiting")
+
+		// Advance one tick at a time and let its calls finish, so every call
+		// records the time of the tick that made it.
+		for elapsed := interval; elapsed <= time.Second; elapsed += interval {
+			clock.Advance(interval)
+			want := int(elapsed / time.Millisecond)
+			require.Eventually(t, func() bool { return tracer.count() == want }, time.Second, time.Millisecond)
+		}
+
+		var gaps []float64
+		for i := 1; i < len(tracer.times); i++ {
+			gaps = append(gaps, tracer.times[i].Sub(tracer.times[i-1]).Seconds())
+		}
+		mean := Aggregate("avg", gaps)
+		variance := 0.0
+		for _, gap := range gaps {
+			variance += (gap - mean) * (gap - mean)
+		}
+		return math.Sqrt(variance / float64(len(gaps))), len(tracer.times)
+	}
+
+	fine, fineCount := gapStdDev(time.Millisecond)
+	coarse, coarseCount := gapStdDev(100 * time.Millisecond)
+	assert.Equal(t, 1000, fineCount)
+	assert.Equal(t, 1000, coarseCount)
+	assert.Less(t, fine, 1e-6, "1ms ticks make one call every 1ms")
+	assert.Greater(t, coarse, 0.005, "100ms ticks make bursts of 100 calls")
+}
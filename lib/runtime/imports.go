@@ -19,6 +19,7 @@ type SetStmt = decl.SetStmt
 type ForStmt = decl.ForStmt
 type ReturnStmt = decl.ReturnStmt
 type ExprStmt = decl.ExprStmt
+type SpawnStmt = decl.SpawnStmt
 type TypeDecl = decl.TypeDecl
 type ParamDecl = decl.ParamDecl
 type ComponentDecl = decl.ComponentDecl
@@ -0,0 +1,49 @@
+package runtime
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestLoadBalancerForwardsToBackends verifies that a call to Route is sent
+// on to one of the backends, in the ratio of the weights, and takes the
+// outcome and latency of the backend it went to.
+func TestLoadBalancerForwardsToBackends(t *testing.T) {
+	sys := parseAndLoad(t, `
+import LoadBalancer, delay from "@stdlib/common.sdl"
+
+component Fast {
+  method Handle() Bool { return true }
+}
+component Slow {
+  method Handle() Bool {
+    delay(10ms)
+    return false
+  }
+}
+component Arch {
+  uses lb LoadBalancer(Strategy = "weighted", Backends = "arch.fast, arch.slow", Weights = "3,1")
+  uses fast Fast()
+  uses slow Slow()
+}
+system S(arch Arch) { }
+`)
+	results, err := RunCallInBatchesWithSeed(sys, "arch.lb", "Route", 40, 100, 1, 42, nil)
+	require.NoError(t, err)
+
+	calls, slow := 0, 0
+	for _, batch := range results {
+		for _, res := range batch {
+			calls++
+			if res.IsFalse() {
+				slow++
+				assert.InDelta(t, 0.01005, float64(res.Time), 1e-6)
+			} else {
+				assert.InDelta(t, 0.00005, float64(res.Time), 1e-6)
+			}
+		}
+	}
+	assert.InDelta(t, 0.25, float64(slow)/float64(calls), 0.03)
+}
@@ -0,0 +1,59 @@
+package runtime
+
+import (
+	"strings"
+
+	"github.com/panyam/sdl/lib/core"
+)
+
+// MemoizeHitRate returns the probability that a call to a @memoize method is
+// served from the cache.  An explicit hitRate is used as is, otherwise it is
+// derived from the TTL and arrival rate: each miss caches its outcome for ttl
+// seconds during which arrivalRate * ttl further calls hit.
+func MemoizeHitRate(ttl, hitRate, arrivalRate float64) float64 {
+	if hitRate >= 0 {
+		return hitRate
+	}
+	if arrivalRate <= 0 || ttl <= 0 {
+		return 0
+	}
+	hits := arrivalRate * ttl
+	return hits / (1 + hits)
+}
+
+// memoEntry is an outcome cached by a @memoize method and the simulated
+// time at which it was cached.
+type memoEntry struct {
+	value    Value
+	cachedAt core.Duration
+}
+
+// cachedOutcome returns the outcome last cached for a memoized call.  Entries
+// cached more than ttl seconds before now have expired and are misses.
+func (ci *ComponentInstance) cachedOutcome(key string, now core.Duration, ttl float64) (Value, bool) {
+	ci.memoLock.Lock()
+	defer ci.memoLock.Unlock()
+	entry, ok := ci.memoized[key]
+	if !ok || (ttl > 0 && now-entry.cachedAt > ttl) {
+		return Nil, false
+	}
+	return entry.value, true
+}
+
+func (ci *ComponentInstance) cacheOutcome(key string, v Value, now core.Duration) {
+	ci.memoLock.Lock()
+	defer ci.memoLock.Unlock()
+	if ci.memoized == nil {
+		ci.memoized = map[string]memoEntry{}
+	}
+	ci.memoized[key] = memoEntry{value: v, cachedAt: now}
+}
+
+// memoKey identifies a memoized call by its method and arguments.
+func memoKey(method string, args []Value) string {
+	parts := []string{method}
+	for _, arg := range args {
+		parts = append(parts, arg.String())
+	}
+	return strings.Join(parts, "\x00")
+}
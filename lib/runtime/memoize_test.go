@@ -0,0 +1,84 @@
+package runtime
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/panyam/sdl/lib/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const memoizeSource = `
+import delay from "@stdlib/common.sdl"
+
+component DB {
+  method Query() Bool {
+    delay(10ms)
+    return true
+  }
+}
+component Cache {
+  uses db DB()
+  %s
+  method Get() Bool {
+    return self.db.Query()
+  }
+}
+component Arch { uses cache Cache() }
+system Memo(arch Arch) { }
+`
+
+// TestMemoizeReducesDownstreamFlow verifies that the load reaching the
+// dependency of a memoized method drops as its hit rate rises.
+func TestMemoizeReducesDownstreamFlow(t *testing.T) {
+	previous := 101.0
+	for _, hitRate := range []float64{0, 0.5, 0.9} {
+		sys := parseAndLoad(t, fmt.Sprintf(memoizeSource, fmt.Sprintf("@memoize(ttl = 5s, hit_rate = %.1f)", hitRate)))
+		cache, db := sys.FindComponent("arch.cache"), sys.FindComponent("arch.cache.db")
+		dbRate := FlowEvalRuntime(cache, "Get", 100, NewFlowScope(sys.Env)).GetRate(db, "Query")
+		assert.InDelta(t, 100*(1-hitRate), dbRate, 1e-9, "hit rate %.1f", hitRate)
+		assert.Less(t, dbRate, previous)
+		previous = dbRate
+	}
+
+	// Without a hit rate one miss is followed by rate * ttl hits
+	sys := parseAndLoad(t, fmt.Sprintf(memoizeSource, "@memoize(ttl = 1s)"))
+	cache, db := sys.FindComponent("arch.cache"), sys.FindComponent("arch.cache.db")
+	dbRate := FlowEvalRuntime(cache, "Get", 100, NewFlowScope(sys.Env)).GetRate(db, "Query")
+	assert.InDelta(t, 100.0/101, dbRate, 1e-9)
+}
+
+// TestMemoizeHitsSkipLatency verifies that once an outcome is cached, hits
+// return it without the downstream latency.
+func TestMemoizeHitsSkipLatency(t *testing.T) {
+	sys := parseAndLoad(t, fmt.Sprintf(memoizeSource, "@memoize(ttl = 5s, hit_rate = 1.0)"))
+	eval := NewSimpleEval(sys.File, nil)
+	call := &CallExpr{Function: buildMemberAccessExpr([]string{"arch", "cache", "Get"})}
+
+	var first, second core.Duration
+	result, _ := eval.Eval(call, sys.Env.Push(), &first)
+	assert.InDelta(t, 0.01, first, 1e-9, "the first call misses")
+	cached, _ := eval.Eval(call, sys.Env.Push(), &second)
+	assert.Zero(t, second)
+	require.Equal(t, result.String(), cached.String())
+}
+
+// TestMemoizeEntriesExpire verifies that outcomes cached longer than the TTL
+// ago are misses again.
+func TestMemoizeEntriesExpire(t *testing.T) {
+	sys := parseAndLoad(t, fmt.Sprintf(memoizeSource, "@memoize(ttl = 5s, hit_rate = 1.0)"))
+	eval := NewSimpleEval(sys.File, nil)
+	call := &CallExpr{Function: buildMemberAccessExpr([]string{"arch", "cache", "Get"})}
+
+	var first core.Duration
+	eval.Eval(call, sys.Env.Push(), &first)
+
+	withinTTL := first + 4
+	eval.Eval(call, sys.Env.Push(), &withinTTL)
+	assert.InDelta(t, first+4, withinTTL, 1e-9, "a call within the TTL hits")
+
+	pastTTL := first + 6
+	eval.Eval(call, sys.Env.Push(), &pastTTL)
+	assert.InDelta(t, first+6.01, pastTTL, 1e-9, "a call past the TTL misses")
+}
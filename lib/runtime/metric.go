@@ -2,7 +2,8 @@ package runtime
 
 import (
 	"context"
-	"sync/atomic"
+	"math/rand"
+	"sync"
 	"time"
 
 	protos "github.com/panyam/sdl/gen/go/sdl/v1/models"
@@ -15,8 +16,29 @@ const (
 	MetricCount       = "count"
 	MetricLatency     = "latency"
 	MetricUtilization = "utilization"
+	MetricValue       = "value" // Numeric return value, sampled when the method returns Outcomes
 )
 
+// SystemMetricTarget is the component of a metric that measures the system
+// as a whole: every call made by a generator is observed once, whichever
+// entry point it targets, so a latency metric is the end-to-end latency users
+// see with each entry point weighted by its traffic.
+const SystemMetricTarget = "system"
+
+// FormatMetricValue renders a metric value for display using the given number
+// of significant figures.  Latencies (in seconds) are shown in the most
+// natural unit (eg 150µs), utilizations (0-1) as percentages and counts as
+// plain numbers.
+func FormatMetricValue(metricType string, value float64, precision int) string {
+	switch metricType {
+	case MetricLatency:
+		return core.FormatDuration(value, precision)
+	case MetricUtilization:
+		return core.FormatSignificant(value*100, precision) + "%"
+	}
+	return core.FormatSignificant(value, precision)
+}
+
 // Metric represents a metric bound to a system.
 // Embeds the proto Metric for transport and adds runtime collection state.
 // This consolidates the old runtime.Metric + services.MetricSpec into one type.
@@ -32,10 +54,15 @@ type Metric struct {
 	// Runtime collection state
 	stopped   bool
 	stopChan  chan bool
-	eventChan chan *TraceEvent
-	idCounter atomic.Int64
+	eventChan chan metricSample
+	rngLock   sync.Mutex
+	rng       *rand.Rand
 	store     MetricStore
 	simCtx    SimulationContext
+
+	listenersLock sync.Mutex
+	listeners     map[int]func(*MetricPoint)
+	nextListener  int
 }
 
 // NewMetricFromSpec creates a Metric from a compile-time MetricSpec.
@@ -60,7 +87,7 @@ func NewMetricFromSpec(spec *MetricSpec) *Metric {
 
 // ProcessTraceEvent handles a trace event. Returns true if accepted.
 func (m *Metric) ProcessTraceEvent(ts core.Duration, duration core.Duration, comp *ComponentInstance, method *decl.MethodDecl, retVal decl.Value, err error) bool {
-	if method == nil || comp == nil {
+	if method == nil || comp == nil || m.IsSystemMetric() {
 		return false
 	}
 
@@ -80,30 +107,97 @@ func (m *Metric) ProcessTraceEvent(ts core.Duration, duration core.Duration, com
 		return false
 	}
 
+	return m.record(ts, duration, retVal)
+}
+
+// IsSystemMetric returns true if the metric observes generator calls rather
+// than a component's methods.
+func (m *Metric) IsSystemMetric() bool {
+	return m.Component == SystemMetricTarget
+}
+
+// ProcessEntryCall handles a call made by a generator.  Returns true if
+// accepted.
+func (m *Metric) ProcessEntryCall(ts core.Duration, duration core.Duration, retVal decl.Value, err error) bool {
+	if !m.IsSystemMetric() {
+		return false
+	}
+	return m.record(ts, duration, retVal)
+}
+
+func (m *Metric) record(ts core.Duration, duration core.Duration, retVal decl.Value) bool {
 	if m.Matcher != nil && m.Matcher.Matches(retVal) {
 		return false
 	}
 
-	nextId := m.idCounter.Add(1)
-	event := &TraceEvent{
-		Kind:      EventExit,
-		ID:        nextId,
-		Timestamp: ts,
-		Duration:  duration,
-		Component: comp,
-		Method:    method,
+	sample := metricSample{timestamp: ts, value: 1}
+	switch m.MetricType {
+	case MetricLatency:
+		sample.value = float64(duration)
+	case MetricValue:
+		value, ok := m.numericValue(retVal)
+		if !ok {
+			return false
+		}
+		sample.value = value
 	}
 
-	m.eventChan <- event
+	m.eventChan <- sample
 	return true
 }
 
+// metricSample is a single observation passed from the tracer to the collection loop.
+type metricSample struct {
+	timestamp core.Duration
+	value     float64
+}
+
+// numericValue converts a return value to a sample for value metrics.  Outcomes
+// are sampled so that aggregating many calls reflects the distribution.
+func (m *Metric) numericValue(v decl.Value) (float64, bool) {
+	if v.Type != nil && v.Type.Tag == decl.TypeTagOutcomes {
+		m.rngLock.Lock()
+		sampled, ok := v.OutcomesVal().Sample(m.rng)
+		m.rngLock.Unlock()
+		if !ok {
+			return 0, false
+		}
+		v = sampled
+	}
+	switch {
+	case v.Type == nil:
+		return 0, false
+	case v.Type.Equals(decl.IntType):
+		return float64(v.IntVal()), true
+	case v.Type.Equals(decl.FloatType):
+		return v.FloatVal(), true
+	case v.Type.Equals(decl.BoolType):
+		if v.BoolVal() {
+			return 1, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
+
+// Stop stops collection and returns once the current aggregation window has
+// been written to the store.
 func (m *Metric) Stop() {
 	if m.stopped || m.stopChan == nil {
 		return
 	}
 	m.stopped = true
-	m.stopChan <- true
+	stopChan := m.stopChan
+	stopChan <- true
+	<-stopChan // closed when run exits
+}
+
+// clock returns the clock of the metric's simulation context, or the real clock.
+func (m *Metric) clock() Clock {
+	if m.simCtx != nil {
+		return m.simCtx.GetClock()
+	}
+	return RealClock
 }
 
 func (m *Metric) Start() {
@@ -111,7 +205,10 @@ func (m *Metric) Start() {
 		return
 	}
 	m.stopped = false
-	m.eventChan = make(chan *TraceEvent, 1000)
+	if m.rng == nil {
+		m.rng = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+	m.eventChan = make(chan metricSample, 1000)
 	m.stopChan = make(chan bool)
 	go m.run()
 }
@@ -133,39 +230,39 @@ func (m *Metric) run() {
 	}
 
 	window := time.Duration(m.AggregationWindow) * time.Second
-	aggregationTicker := time.NewTicker(window)
+	aggregationTicker := m.clock().NewTicker(window)
 	defer aggregationTicker.Stop()
 
 	currentWindow := make([]float64, 0)
 	var currentWindowStart time.Time
+	add := func(evt metricSample) {
+		if m.store != nil {
+			if len(currentWindow) == 0 {
+				if m.simCtx != nil && m.simCtx.IsSimulationStarted() {
+					currentWindowStart = m.simCtx.GetSimulationStartTime().Add(time.Duration(evt.timestamp * float64(time.Second)))
+				} else {
+					currentWindowStart = m.clock().Now()
+				}
+			}
+
+			currentWindow = append(currentWindow, evt.value)
+		}
+	}
 
 	for {
 		select {
 		case <-m.stopChan:
+			// Samples queued before the stop belong to the final window
+			for len(m.eventChan) > 0 {
+				add(<-m.eventChan)
+			}
 			if len(currentWindow) > 0 && m.store != nil {
 				m.flushAggregatedWindow(ctx, currentWindow, currentWindowStart)
 			}
 			return
 		case evt := <-m.eventChan:
-			if evt != nil && m.store != nil {
-				var value float64
-				if m.MetricType == MetricLatency {
-					value = float64(evt.Duration)
-				} else {
-					value = 1.0
-				}
-
-				if len(currentWindow) == 0 {
-					if m.simCtx != nil && m.simCtx.IsSimulationStarted() {
-						currentWindowStart = m.simCtx.GetSimulationStartTime().Add(time.Duration(evt.Timestamp * float64(time.Second)))
-					} else {
-						currentWindowStart = time.Now()
-					}
-				}
-
-				currentWindow = append(currentWindow, value)
-			}
-		case <-aggregationTicker.C:
+			add(evt)
+		case <-aggregationTicker.C():
 			if len(currentWindow) > 0 && m.store != nil {
 				m.flushAggregatedWindow(ctx, currentWindow, currentWindowStart)
 				currentWindow = currentWindow[:0]
@@ -185,14 +282,52 @@ func (m *Metric) flushAggregatedWindow(ctx context.Context, values []float64, wi
 		Tags:      make(map[string]string),
 	}
 	m.store.WritePoint(ctx, m.Metric, point)
+	m.notifyListeners(point)
+}
+
+// OnWindowClose registers fn to be called with each point as it is written,
+// ie when an aggregation window closes or a utilization sample is taken.  fn
+// runs on the metric's collection goroutine.  The returned func unregisters it.
+func (m *Metric) OnWindowClose(fn func(*MetricPoint)) (remove func()) {
+	m.listenersLock.Lock()
+	defer m.listenersLock.Unlock()
+	if m.listeners == nil {
+		m.listeners = map[int]func(*MetricPoint){}
+	}
+	id := m.nextListener
+	m.nextListener++
+	m.listeners[id] = fn
+	return func() {
+		m.listenersLock.Lock()
+		defer m.listenersLock.Unlock()
+		delete(m.listeners, id)
+	}
+}
+
+func (m *Metric) notifyListeners(point *MetricPoint) {
+	m.listenersLock.Lock()
+	listeners := make([]func(*MetricPoint), 0, len(m.listeners))
+	for _, fn := range m.listeners {
+		listeners = append(listeners, fn)
+	}
+	m.listenersLock.Unlock()
+	for _, fn := range listeners {
+		fn(point)
+	}
 }
 
 func (m *Metric) computeAggregation(values []float64) float64 {
+	return Aggregate(m.Aggregation, values)
+}
+
+// Aggregate reduces values with an aggregation such as "avg" or "p99".
+// Unknown aggregations sum the values.
+func Aggregate(aggregation string, values []float64) float64 {
 	if len(values) == 0 {
 		return 0
 	}
 
-	switch m.Aggregation {
+	switch aggregation {
 	case "sum":
 		sum := 0.0
 		for _, v := range values {
@@ -234,7 +369,7 @@ func (m *Metric) computeAggregation(values []float64) float64 {
 			}
 		}
 		var percentile float64
-		switch m.Aggregation {
+		switch aggregation {
 		case "p50":
 			percentile = 0.50
 		case "p90":
@@ -261,14 +396,14 @@ func (m *Metric) runUtilizationCollection(ctx context.Context) {
 		interval = 10 * time.Second
 	}
 
-	ticker := time.NewTicker(interval)
+	ticker := m.clock().NewTicker(interval)
 	defer ticker.Stop()
 
 	for {
 		select {
 		case <-m.stopChan:
 			return
-		case <-ticker.C:
+		case <-ticker.C():
 			m.collectUtilizationMetrics(ctx)
 		}
 	}
@@ -300,7 +435,7 @@ func (m *Metric) collectUtilizationMetrics(ctx context.Context) {
 			simTime := m.simCtx.GetSimulationTime()
 			timestamp = m.simCtx.GetSimulationStartTime().Add(time.Duration(simTime * float64(time.Second)))
 		} else {
-			timestamp = time.Now()
+			timestamp = m.clock().Now()
 		}
 
 		point := &MetricPoint{
@@ -309,6 +444,7 @@ func (m *Metric) collectUtilizationMetrics(ctx context.Context) {
 			Tags:      make(map[string]string),
 		}
 		m.store.WritePoint(ctx, m.Metric, point)
+		m.notifyListeners(point)
 	}
 }
 
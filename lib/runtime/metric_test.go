@@ -1,8 +1,14 @@
 package runtime
 
 import (
+	"context"
+	"math/rand"
 	"testing"
+	"time"
 
+	protos "github.com/panyam/sdl/gen/go/sdl/v1/models"
+	"github.com/panyam/sdl/lib/core"
+	"github.com/panyam/sdl/lib/decl"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -92,3 +98,173 @@ system T(a Arch) {
 		parseAndLoadSystem(invalidSDL)
 	}, "Invalid metric type should be rejected during inference")
 }
+
+// TestValueMetricAveragesOutcomes verifies that a "value" metric on a method
+// returning Outcomes[Int] averages values sampled from the distribution, and
+// that one on a method returning a sampled Int averages the returned values.
+func TestValueMetricAveragesOutcomes(t *testing.T) {
+	sys := parseAndLoad(t, `
+component Store {
+    method Size() Outcomes[Int] {
+        return dist {
+            50 => 100,
+            50 => 300,
+        }
+    }
+    method Fetch() Int {
+        let sizes = self.Size()
+        return sample sizes
+    }
+}
+system T(store Store) {
+    metric("size", store.Size, "value", "avg", 60s)
+    metric("fetched", store.Fetch, "value", "avg", 60s)
+}
+`)
+	require.Len(t, sys.Metrics, 2)
+	assert.Equal(t, "avg", sys.Metrics[0].Aggregation, "value metrics default to avg")
+
+	tracer := NewMetricTracer(sys, nil)
+	for _, spec := range sys.Metrics {
+		require.NoError(t, tracer.AddMetric(spec))
+	}
+
+	eval := NewSimpleEval(sys.File, tracer)
+	eval.Rand = rand.New(rand.NewSource(1))
+	for range 2000 {
+		var currTime core.Duration
+		_, err := eval.EvalCall(&CallExpr{Function: buildMemberAccessExpr([]string{"store", "Fetch"})}, sys.Env, &currTime)
+		require.NoError(t, err)
+	}
+	tracer.StopAll()
+
+	for _, name := range []string{"size", "fetched"} {
+		result, err := tracer.QueryMetrics(context.Background(), name, QueryOptions{EndTime: time.Now()})
+		require.NoError(t, err)
+		require.Len(t, result.Points, 1, name)
+		assert.InDelta(t, 200, result.Points[0].Value, 15, name)
+	}
+}
+
+// fakeSimContext is a started simulation whose clock is controlled by the test.
+type fakeSimContext struct {
+	clock  *FakeClock
+	start  time.Time
+	tracer Tracer
+}
+
+func (f *fakeSimContext) GetTracer() Tracer                   { return f.tracer }
+func (f *fakeSimContext) GetSimulationStartTime() time.Time   { return f.start }
+func (f *fakeSimContext) IsSimulationStarted() bool           { return true }
+func (f *fakeSimContext) GetSimulationTime() float64          { return f.clock.Now().Sub(f.start).Seconds() }
+func (f *fakeSimContext) OnGeneratorCompleted(gen *Generator) {}
+func (f *fakeSimContext) GetClock() Clock                     { return f.clock }
+
+// TestMetricWindowRolloverFakeClock verifies that aggregation windows are
+// flushed when the clock passes the window boundary and not before.
+func TestMetricWindowRolloverFakeClock(t *testing.T) {
+	sys := parseAndLoad(t, `
+component Server { method Handle() Bool { return true } }
+system T(server Server) {
+    metric("calls", server.Handle, "count", "sum", 5s)
+}
+`)
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := NewFakeClock(start)
+	tracer := NewMetricTracer(sys, &fakeSimContext{clock: clock, start: start})
+	calls := sys.Metrics[0]
+	require.NoError(t, tracer.AddMetric(calls))
+	defer tracer.Clear()
+	require.Eventually(t, func() bool { return clock.PendingTimers() == 1 }, time.Second, time.Millisecond, "window ticker should be waiting")
+
+	record := func(at core.Duration, n int) {
+		for range n {
+			calls.ProcessTraceEvent(at, 0, calls.ResolvedComponent, calls.ResolvedMethod, decl.BoolValue(true), nil)
+		}
+		require.Eventually(t, func() bool { return len(calls.eventChan) == 0 }, time.Second, time.Millisecond)
+	}
+	points := func() []*MetricPoint {
+		result, err := tracer.QueryMetrics(context.Background(), "calls", QueryOptions{EndTime: start.Add(time.Hour)})
+		require.NoError(t, err)
+		return result.Points
+	}
+
+	record(1, 3)
+	clock.Advance(4 * time.Second)
+	assert.Never(t, func() bool { return len(points()) > 0 }, 50*time.Millisecond, 5*time.Millisecond, "window should not roll over before 5s")
+
+	clock.Advance(time.Second)
+	require.Eventually(t, func() bool { return len(points()) == 1 }, time.Second, time.Millisecond)
+	assert.Equal(t, start.Add(time.Second), points()[0].Timestamp)
+	assert.Equal(t, 3.0, points()[0].Value)
+
+	record(6, 2)
+	clock.Advance(5 * time.Second)
+	require.Eventually(t, func() bool { return len(points()) == 2 }, time.Second, time.Millisecond)
+	latest := points()[0] // Most recent first
+	assert.Equal(t, start.Add(6*time.Second), latest.Timestamp)
+	assert.Equal(t, 2.0, latest.Value)
+}
+
+// TestSystemMetricWeightsEntryPoints verifies that a system metric observes
+// each generator call once, so its latency averages the entry points
+// weighted by their rates and ignores the nested calls they make.
+func TestSystemMetricWeightsEntryPoints(t *testing.T) {
+	sys := parseAndLoad(t, `
+import delay from "@stdlib/common.sdl"
+
+component Backend {
+    method Query() Bool {
+        delay(10ms)
+        return true
+    }
+}
+component App {
+    uses backend Backend()
+    method Browse() Bool {
+        return self.backend.Query()
+    }
+    method Checkout() Bool {
+        delay(90ms)
+        return self.backend.Query()
+    }
+}
+system Shop(app App) {
+}
+`)
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	simCtx := &fakeSimContext{clock: NewFakeClock(start), start: start}
+	tracer := NewMetricTracer(sys, simCtx)
+	simCtx.tracer = tracer
+
+	newMetric := func(name, metricType, aggregation string) *Metric {
+		return &Metric{Metric: &protos.Metric{
+			Name: name, Component: SystemMetricTarget, MetricType: metricType,
+			Aggregation: aggregation, AggregationWindow: 60,
+		}}
+	}
+	require.NoError(t, tracer.AddMetric(newMetric("e2e", MetricLatency, "avg")))
+	require.NoError(t, tracer.AddMetric(newMetric("calls", MetricCount, "sum")))
+	assert.ErrorContains(t, tracer.AddMetric(newMetric("busy", MetricUtilization, "avg")), "need a component")
+
+	for _, gen := range []*Generator{
+		{Generator: &protos.Generator{Name: "browse", Component: "app", Method: "Browse", Rate: 30}},
+		{Generator: &protos.Generator{Name: "checkout", Component: "app", Method: "Checkout", Rate: 10}},
+	} {
+		gen.System, gen.SimCtx = sys, simCtx
+		for range int(gen.Rate) {
+			gen.executeAtVirtualTime(gen.getNextVirtualTime())
+		}
+	}
+	tracer.StopAll()
+
+	value := func(name string) float64 {
+		result, err := tracer.QueryMetrics(context.Background(), name, QueryOptions{EndTime: start.Add(time.Hour)})
+		require.NoError(t, err)
+		require.Len(t, result.Points, 1, name)
+		return result.Points[0].Value
+	}
+	assert.Equal(t, 40.0, value("calls"), "nested Backend.Query calls are not counted")
+	// (30 * 10ms + 10 * 100ms) / 40
+	assert.InDelta(t, 0.0325, value("e2e"), 1e-9)
+}
@@ -0,0 +1,210 @@
+package runtime
+
+import (
+	"bytes"
+	"fmt"
+	"log/slog"
+	"maps"
+	"net"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ExportedPoint is a closed metric window as pushed to an external store.
+type ExportedPoint struct {
+	Metric    string
+	Tags      map[string]string // eg system, target and type
+	Timestamp time.Time
+	Value     float64
+}
+
+// MetricExporter pushes metric points to an external time series store.
+type MetricExporter interface {
+	Export(point ExportedPoint) error
+	Close() error
+}
+
+// NewMetricExporter creates an exporter from a URL of the form
+//
+//	statsd://host:port
+//	influxdb://host:port/database
+func NewMetricExporter(rawURL string) (MetricExporter, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid exporter url '%s': %w", rawURL, err)
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("exporter url '%s' has no host", rawURL)
+	}
+	switch u.Scheme {
+	case "statsd":
+		return NewStatsDExporter(u.Host)
+	case "influxdb":
+		database := strings.Trim(u.Path, "/")
+		if database == "" {
+			return nil, fmt.Errorf("exporter url '%s' has no database, eg influxdb://%s/sdl", rawURL, u.Host)
+		}
+		query := url.Values{"db": {database}, "precision": {"ns"}}
+		return NewInfluxExporter("http://" + u.Host + "/write?" + query.Encode()), nil
+	}
+	return nil, fmt.Errorf("unsupported exporter '%s', expected statsd:// or influxdb://", u.Scheme)
+}
+
+// StatsDExporter sends each point as a gauge over UDP in the DogStatsD
+// format, eg "p99:0.012|g|#system:App,target:server.Handle,type:latency".
+type StatsDExporter struct {
+	conn net.Conn
+}
+
+// NewStatsDExporter creates an exporter sending to a StatsD agent at addr.
+func NewStatsDExporter(addr string) (*StatsDExporter, error) {
+	conn, err := net.Dial("udp", addr)
+	if err != nil {
+		return nil, err
+	}
+	return &StatsDExporter{conn: conn}, nil
+}
+
+func (e *StatsDExporter) Export(point ExportedPoint) error {
+	_, err := e.conn.Write([]byte(FormatStatsD(point)))
+	return err
+}
+
+func (e *StatsDExporter) Close() error {
+	return e.conn.Close()
+}
+
+// FormatStatsD renders a point as a DogStatsD gauge with its tags sorted.
+func FormatStatsD(point ExportedPoint) string {
+	var tags []string
+	for _, k := range slices.Sorted(maps.Keys(point.Tags)) {
+		tags = append(tags, k+":"+point.Tags[k])
+	}
+	line := point.Metric + ":" + strconv.FormatFloat(point.Value, 'g', -1, 64) + "|g"
+	if len(tags) > 0 {
+		line += "|#" + strings.Join(tags, ",")
+	}
+	return line
+}
+
+// InfluxExporter writes each point in InfluxDB line protocol to a write
+// endpoint over HTTP.
+type InfluxExporter struct {
+	WriteURL string
+	Client   *http.Client
+}
+
+// NewInfluxExporter creates an exporter posting to writeURL, eg
+// "http://localhost:8086/write?db=sdl&precision=ns".
+func NewInfluxExporter(writeURL string) *InfluxExporter {
+	return &InfluxExporter{WriteURL: writeURL, Client: &http.Client{Timeout: 5 * time.Second}}
+}
+
+func (e *InfluxExporter) Export(point ExportedPoint) error {
+	resp, err := e.Client.Post(e.WriteURL, "text/plain; charset=utf-8", bytes.NewBufferString(FormatInfluxLine(point)+"\n"))
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("influxdb write failed: %s", resp.Status)
+	}
+	return nil
+}
+
+func (e *InfluxExporter) Close() error {
+	e.Client.CloseIdleConnections()
+	return nil
+}
+
+var influxEscaper = strings.NewReplacer(",", `\,`, "=", `\=`, " ", `\ `)
+
+// FormatInfluxLine renders a point in InfluxDB line protocol with its tags
+// sorted and a nanosecond timestamp.
+func FormatInfluxLine(point ExportedPoint) string {
+	var sb strings.Builder
+	sb.WriteString(influxEscaper.Replace(point.Metric))
+	for _, k := range slices.Sorted(maps.Keys(point.Tags)) {
+		sb.WriteString("," + influxEscaper.Replace(k) + "=" + influxEscaper.Replace(point.Tags[k]))
+	}
+	sb.WriteString(" value=" + strconv.FormatFloat(point.Value, 'g', -1, 64))
+	sb.WriteString(" " + strconv.FormatInt(point.Timestamp.UnixNano(), 10))
+	return sb.String()
+}
+
+// MetricExportQueue pushes points to an exporter from a background goroutine
+// so that slow or failing stores never hold up metric collection.  Failed
+// pushes are logged and retried with exponential backoff.  Points are dropped
+// once their retries run out or when the queue is full.
+type MetricExportQueue struct {
+	Exporter   MetricExporter
+	MaxRetries int
+	Backoff    time.Duration // Delay before the first retry, doubled on each one
+
+	points    chan ExportedPoint
+	done      chan struct{}
+	closeOnce sync.Once
+	dropped   atomic.Int64
+}
+
+// NewMetricExportQueue starts a queue pushing to exporter.
+func NewMetricExportQueue(exporter MetricExporter) *MetricExportQueue {
+	q := &MetricExportQueue{
+		Exporter:   exporter,
+		MaxRetries: 3,
+		Backoff:    100 * time.Millisecond,
+		points:     make(chan ExportedPoint, 1024),
+		done:       make(chan struct{}),
+	}
+	go q.run()
+	return q
+}
+
+// Push queues a point without blocking.
+func (q *MetricExportQueue) Push(point ExportedPoint) {
+	select {
+	case q.points <- point:
+	default:
+		q.dropped.Add(1)
+		slog.Warn("Metric export queue full, dropping point", "metric", point.Metric)
+	}
+}
+
+// Dropped returns how many points could not be pushed.
+func (q *MetricExportQueue) Dropped() int64 {
+	return q.dropped.Load()
+}
+
+// Close pushes the points already queued and closes the exporter.
+func (q *MetricExportQueue) Close() error {
+	q.closeOnce.Do(func() { close(q.points) })
+	<-q.done
+	return q.Exporter.Close()
+}
+
+func (q *MetricExportQueue) run() {
+	defer close(q.done)
+	for point := range q.points {
+		backoff := q.Backoff
+		for attempt := 0; ; attempt++ {
+			err := q.Exporter.Export(point)
+			if err == nil {
+				break
+			}
+			if attempt >= q.MaxRetries {
+				q.dropped.Add(1)
+				slog.Warn("Metric export failed, dropping point", "metric", point.Metric, "error", err)
+				break
+			}
+			slog.Warn("Metric export failed, retrying", "metric", point.Metric, "attempt", attempt+1, "backoff", backoff, "error", err)
+			time.Sleep(backoff)
+			backoff *= 2
+		}
+	}
+}
@@ -42,6 +42,9 @@ type MetricStore interface {
 	// GetMetricStats returns statistics for a metric
 	GetMetricStats(metric *protos.Metric) MetricStats
 
+	// Stats returns statistics across all metrics held by the store
+	Stats() MetricStoreStats
+
 	// Close cleanly shuts down the store
 	Close() error
 }
@@ -64,6 +67,16 @@ type MetricStats struct {
 	NewestTimestamp float64
 }
 
+// MetricStoreStats summarizes everything held by a store so growth can be monitored
+type MetricStoreStats struct {
+	TotalRows       int64
+	RowsPerMetric   map[string]int64
+	OldestTimestamp time.Time // Zero if the store is empty
+	NewestTimestamp time.Time
+	SizeBytes       int64   // Approximate memory (or disk) used by stored points
+	WriteRate       float64 // Points written per second since the first write
+}
+
 // QueryOptions specifies parameters for metric queries
 type QueryOptions struct {
 	// Time range
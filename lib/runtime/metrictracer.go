@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"maps"
 	"slices"
+	"strings"
 	"sync"
 	"time"
 
@@ -82,12 +83,16 @@ func (mt *MetricTracer) AddMetric(spec *Metric) error {
 		return status.Error(codes.InvalidArgument, fmt.Sprintf("component cannot be empty"))
 	}
 
-	// For utilization metrics, methods are optional
-	if spec.MetricType != MetricUtilization && len(spec.Methods) == 0 {
+	if spec.IsSystemMetric() && spec.MetricType == MetricUtilization {
+		return status.Error(codes.InvalidArgument, "utilization metrics need a component")
+	}
+
+	// For utilization and system metrics, methods are optional
+	if spec.MetricType != MetricUtilization && !spec.IsSystemMetric() && len(spec.Methods) == 0 {
 		return status.Error(codes.InvalidArgument, fmt.Sprintf("at least one method must be specified for %s metrics", spec.MetricType))
 	}
 
-	if spec.MetricType != MetricCount && spec.MetricType != MetricLatency && spec.MetricType != MetricUtilization {
+	if spec.MetricType != MetricCount && spec.MetricType != MetricLatency && spec.MetricType != MetricUtilization && spec.MetricType != MetricValue {
 		return status.Error(codes.InvalidArgument, fmt.Sprintf("invalid metric type: %s", spec.MetricType))
 	}
 
@@ -98,8 +103,11 @@ func (mt *MetricTracer) AddMetric(spec *Metric) error {
 	if mt.system == nil || mt.system.Env == nil {
 		return status.Error(codes.FailedPrecondition, "system or its env not defined")
 	}
-	resolvedComponent := mt.system.FindComponent(spec.Component)
-	if resolvedComponent == nil {
+	var resolvedComponent *ComponentInstance
+	if !spec.IsSystemMetric() {
+		resolvedComponent = mt.system.FindComponent(spec.Component)
+	}
+	if resolvedComponent == nil && !spec.IsSystemMetric() {
 		return status.Error(codes.InvalidArgument, fmt.Sprintf("component '%s' not found in system", spec.Component))
 	}
 
@@ -125,6 +133,16 @@ func (mt *MetricTracer) Clear() {
 	mt.seriesMap = map[string]*Metric{}
 }
 
+// StopAll stops collection for every metric, flushing partially filled
+// windows, while keeping the metrics and their data queryable.
+func (mt *MetricTracer) StopAll() {
+	mt.seriesLock.RLock()
+	defer mt.seriesLock.RUnlock()
+	for _, ms := range mt.seriesMap {
+		ms.Stop()
+	}
+}
+
 func (mt *MetricTracer) RemoveMetric(specId string) {
 	mt.seriesLock.Lock()
 	defer mt.seriesLock.Unlock()
@@ -134,7 +152,7 @@ func (mt *MetricTracer) RemoveMetric(specId string) {
 	}
 }
 
-// ListMetrics returns all configured metrics with statistics
+// ListMetrics returns all configured metrics with statistics, ordered by name
 func (mt *MetricTracer) ListMetrics() []*protos.Metric {
 	mt.seriesLock.RLock()
 	defer mt.seriesLock.RUnlock()
@@ -166,6 +184,7 @@ func (mt *MetricTracer) ListMetrics() []*protos.Metric {
 			metrics = append(metrics, metricCopy)
 		}
 	}
+	slices.SortFunc(metrics, func(a, b *protos.Metric) int { return strings.Compare(a.Name, b.Name) })
 	return metrics
 }
 
@@ -192,6 +211,16 @@ func (mt *MetricTracer) Exit(ts core.Duration, duration core.Duration, comp *Com
 	}
 }
 
+// EntryCall feeds a generator call to the system metrics.
+func (mt *MetricTracer) EntryCall(ts core.Duration, duration core.Duration, retVal decl.Value, err error) {
+	mt.seriesLock.RLock()
+	defer mt.seriesLock.RUnlock()
+
+	for _, m := range mt.seriesMap {
+		m.ProcessEntryCall(ts, duration, retVal, err)
+	}
+}
+
 func (mt *MetricTracer) Enter(ts core.Duration, kind TraceEventKind, comp *ComponentInstance, method *decl.MethodDecl, args ...string) int64 {
 	return 0
 }
@@ -247,3 +276,15 @@ func (mt *MetricTracer) AggregateMetrics(ctx context.Context, specId string, opt
 }
 
 // ResultMatcher, ExactMatcher, NotMatcher, CreateResultMatcher are in metric.go
+
+// StoreStats returns statistics across all metrics in the tracer's store.
+func (mt *MetricTracer) StoreStats() (MetricStoreStats, error) {
+	mt.seriesLock.RLock()
+	store := mt.store
+	mt.seriesLock.RUnlock()
+
+	if store == nil {
+		return MetricStoreStats{}, fmt.Errorf("no metric store configured")
+	}
+	return store.Stats(), nil
+}
@@ -187,10 +187,20 @@ func (n *NativeWrapper) Set(name string, value Value) error {
 // Taking a middle ground - we have now added a Time member to the Value type - this is only used by native functions.
 // If this works well we can port the rest too
 
+// nativeMethodName returns the Go method implementing an SDL method of a
+// native component.  Get and Set are the parameter accessors of NativeObject
+// so SDL methods of those names are implemented as GetMethod and SetMethod.
+func nativeMethodName(methodName string) string {
+	if methodName == "Get" || methodName == "Set" {
+		return methodName + "Method"
+	}
+	return methodName
+}
+
 func InvokeMethod(nativeValue any, methodName string, args []Value, env *Env[Value], currTime *core.Duration, rnd *rand.Rand, shouldSample bool) (val Value, err error) {
 	// 1. Find the method on the GoInstance using reflection.
 	instanceVal := reflect.ValueOf(nativeValue)
-	methodVal := instanceVal.MethodByName(methodName)
+	methodVal := instanceVal.MethodByName(nativeMethodName(methodName))
 	// log.Println("MV: ", methodVal)
 	// compInstance := nativeValue.(*ComponentInstance)
 	// log.Println("CompInst: ", compInstance, compInstance.ComponentDecl)
@@ -0,0 +1,161 @@
+package runtime
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/panyam/sdl/lib/core"
+	"github.com/panyam/sdl/lib/decl"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const nativeOutcomesSource = `
+import delay from "@stdlib/common.sdl"
+
+native method Query() Float = dist {
+  90 => 5ms,
+  10 => 50ms,
+}
+
+native component TestNative {
+  method Healthy() Bool = dist { 1 => true }
+}
+
+component DB {
+  uses probe TestNative()
+  method Lookup() Float {
+    let latency = Query()
+    delay(latency)
+    return latency
+  }
+}
+component Arch { uses db DB() }
+system Native(arch Arch) { }
+`
+
+// TestNativeMethodOutcomes verifies that calls to native methods with an
+// attached distribution return samples from it without native code.
+func TestNativeMethodOutcomes(t *testing.T) {
+	sys := parseAndLoad(t, nativeOutcomesSource)
+	eval := NewSimpleEval(sys.File, nil)
+	call := &CallExpr{Function: buildMemberAccessExpr([]string{"arch", "db", "Lookup"})}
+
+	slow := 0
+	for range 1000 {
+		var currTime core.Duration
+		result, _ := eval.Eval(call, sys.Env.Push(), &currTime)
+		latency, err := result.GetFloat()
+		require.NoError(t, err)
+		assert.Contains(t, []float64{0.005, 0.05}, latency)
+		assert.InDelta(t, latency, currTime, 1e-9)
+		if latency == 0.05 {
+			slow++
+		}
+	}
+	assert.InDelta(t, 100, slow, 40)
+
+	var currTime core.Duration
+	healthy := &CallExpr{Function: buildMemberAccessExpr([]string{"arch", "db", "probe", "Healthy"})}
+	result, _ := eval.Eval(healthy, sys.Env.Push(), &currTime)
+	assert.True(t, result.BoolVal())
+}
+
+// TestDelayDistribution verifies that a delay given a distribution samples
+// it on each call from the evaluator's seedable RNG.
+func TestDelayDistribution(t *testing.T) {
+	sys := parseAndLoad(t, `
+import delay from "@stdlib/common.sdl"
+
+component Server {
+  method Handle() Bool {
+    delay(dist { 90 => 5ms, 10 => 50ms })
+    return true
+  }
+}
+component Arch { uses server Server() }
+system Delays(arch Arch) { }
+`)
+	call := &CallExpr{Function: buildMemberAccessExpr([]string{"arch", "server", "Handle"})}
+	latencies := func(seed int64) (out []core.Duration) {
+		eval := NewSimpleEval(sys.File, nil)
+		eval.Rand = rand.New(rand.NewSource(seed))
+		for range 50 {
+			var currTime core.Duration
+			eval.Eval(call, sys.Env.Push(), &currTime)
+			require.Empty(t, eval.Errors)
+			assert.Contains(t, []float64{0.005, 0.05}, currTime)
+			out = append(out, currTime)
+		}
+		return
+	}
+
+	assert.Equal(t, latencies(1), latencies(1))
+	assert.NotEqual(t, latencies(1), latencies(2))
+	assert.Contains(t, latencies(1), 0.05)
+}
+
+// TestDistributeNormalizesWeights verifies that distribution weights a
+// little off from summing to 1 are accepted and renormalized while invalid
+// ones are reported.
+func TestDistributeNormalizesWeights(t *testing.T) {
+	sys := parseAndLoad(t, `
+component Arch { }
+system Dists(arch Arch) { }
+`)
+	dist := func(weights ...float64) *decl.DistributeExpr {
+		d := &decl.DistributeExpr{}
+		for idx, w := range weights {
+			d.Cases = append(d.Cases, &decl.CaseExpr{
+				Condition: &decl.LiteralExpr{Value: decl.FloatValue(w)},
+				Body:      &decl.LiteralExpr{Value: decl.IntValue(int64(idx))},
+			})
+		}
+		return d
+	}
+
+	eval := NewSimpleEval(sys.File, nil)
+	var currTime core.Duration
+	result, _ := eval.Eval(dist(0.6, 0.3999), sys.Env.Push(), &currTime)
+	require.False(t, eval.HasErrors(), eval.ErrorCollector.Errors)
+	outcomes := result.OutcomesVal()
+	assert.InDelta(t, 1.0, outcomes.TotalWeight(), 1e-12)
+	assert.InDelta(t, 0.6/0.9999, outcomes.Buckets[0].Weight, 1e-12)
+
+	// Collect the error rather than stopping at it
+	eval.MaxErrors = 0
+	result, _ = eval.Eval(dist(0.2, -0.3), sys.Env.Push(), &currTime)
+	assert.True(t, result.IsNil())
+	require.Len(t, eval.ErrorCollector.Errors, 1)
+	assert.ErrorContains(t, eval.ErrorCollector.Errors[0], "invalid distribution: weight 1 is negative (-0.3)")
+}
+
+// TestDistributeParamWeights verifies that distribution weights can be
+// params of the component.
+func TestDistributeParamWeights(t *testing.T) {
+	sys := parseAndLoad(t, `
+component Service {
+  param ErrorRate Float = 0.02
+  method Send() Bool {
+    return sample dist {
+      (1 - self.ErrorRate) => true
+      self.ErrorRate => false
+    }
+  }
+}
+component Arch { uses service Service(ErrorRate = 0.25) }
+system Dists(arch Arch) { }
+`)
+	eval := NewSimpleEval(sys.File, nil)
+	eval.SetSeed(7)
+	failed := 0
+	for range 2000 {
+		var currTime core.Duration
+		result, err := eval.EvalCall(&CallExpr{Function: buildMemberAccessExpr([]string{"arch", "service", "Send"})}, sys.Env.Push(), &currTime)
+		require.NoError(t, err)
+		if result.IsFalse() {
+			failed++
+		}
+	}
+	assert.InDelta(t, 0.25, float64(failed)/2000, 0.03)
+}
@@ -0,0 +1,69 @@
+package runtime
+
+import (
+	"testing"
+
+	"github.com/panyam/sdl/lib/core"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestDerivedParamDefaults verifies that defaults referring to earlier params
+// are evaluated at instantiation, including after a uses override changes the
+// param they are derived from, whether by name or by position.
+func TestDerivedParamDefaults(t *testing.T) {
+	sys := parseAndLoad(t, `
+component Pool {
+  param PoolSize Int = 10
+  param MaxConns Int = PoolSize * 2
+  param Spare Int = MaxConns - PoolSize
+  method Get() Bool { return true }
+}
+component App {
+  uses small Pool()
+  uses large Pool(PoolSize = 50)
+  uses fixed Pool(PoolSize = 50, MaxConns = 60)
+  uses mixed Pool(50, Spare = 5)
+}
+system Pools(app App) { }
+`)
+	for path, expected := range map[string][2]int64{
+		"app.small": {20, 10},
+		"app.large": {100, 50},
+		"app.fixed": {60, 10},
+		"app.mixed": {100, 5},
+	} {
+		pool := sys.FindComponent(path)
+		require.NotNil(t, pool, path)
+		maxConns, _ := pool.Get("MaxConns")
+		spare, _ := pool.Get("Spare")
+		assert.Equal(t, expected[0], maxConns.Value, "%s.MaxConns", path)
+		assert.Equal(t, expected[1], spare.Value, "%s.Spare", path)
+	}
+}
+
+// TestDerivedParamDefaultErrors verifies that a derived default that cannot be
+// evaluated leaves the param unset instead of failing the initializer, while
+// the same division in a method body fails the call.
+func TestDerivedParamDefaultErrors(t *testing.T) {
+	sys := parseAndLoad(t, `
+component Ratio {
+  param N Int = 10
+  param D Int = 0
+  param Share Int = N / D
+  method Get() Int { return self.N / self.D }
+}
+component App { uses ratio Ratio() }
+system Ratios(app App) { }
+`)
+	ratio := sys.FindComponent("app.ratio")
+	require.NotNil(t, ratio)
+	_, found := ratio.Get("Share")
+	assert.False(t, found)
+
+	eval := NewSimpleEval(sys.File, nil)
+	var currTime core.Duration
+	_, err := eval.EvalCall(&CallExpr{Function: buildMemberAccessExpr([]string{"app", "ratio", "Get"})}, sys.Env.Push(), &currTime)
+	assert.ErrorContains(t, err, "integer division by zero")
+}
@@ -208,6 +208,11 @@ func (pt *PathTraversal) analyzeStatement(currentCompName string, currentComp *d
 			edges = append(edges, exprEdges...)
 		}
 
+	case *decl.SpawnStmt:
+		if spawnEdges, err := pt.analyzeExpression(currentCompName, currentComp, s.Call, maxDepth, currentDepth); err == nil {
+			edges = append(edges, spawnEdges...)
+		}
+
 	case *decl.IfStmt:
 		// Analyze both branches of the if statement
 		conditionStr := pt.expressionToString(s.Condition)
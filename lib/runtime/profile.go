@@ -0,0 +1,50 @@
+package runtime
+
+import (
+	"fmt"
+)
+
+// SetProfile selects one of the component's profiles so its methods are
+// used in place of the component's own, or the component's own methods
+// again when name is empty.  Outcomes cached by @memoize methods, and those
+// in flight for @coalesce methods, are dropped since they came from the
+// previous bodies.
+func (ci *ComponentInstance) SetProfile(name string) error {
+	if name == "" {
+		ci.profile = nil
+	} else {
+		profile, err := ci.ComponentDecl.GetProfile(name)
+		if err != nil {
+			return err
+		}
+		if profile == nil {
+			return fmt.Errorf("component '%s' has no profile '%s'", ci.ComponentDecl.Name.Value, name)
+		}
+		ci.profile = profile
+	}
+	ci.memoLock.Lock()
+	ci.memoized = nil
+	ci.coalesced = nil
+	ci.memoLock.Unlock()
+	return nil
+}
+
+// Profile returns the name of the selected profile, or "" if none is.
+func (ci *ComponentInstance) Profile() string {
+	if ci.profile == nil {
+		return ""
+	}
+	return ci.profile.Name.Value
+}
+
+// Method returns the method that calls to name run, ie the selected
+// profile's override if it has one or else the component's own method.
+func (ci *ComponentInstance) Method(name string) *MethodDecl {
+	if ci.profile != nil {
+		if method := ci.profile.GetMethod(name); method != nil {
+			return method
+		}
+	}
+	method, _ := ci.ComponentDecl.GetMethod(name)
+	return method
+}
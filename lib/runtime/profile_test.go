@@ -0,0 +1,64 @@
+package runtime
+
+import (
+	"testing"
+
+	"github.com/panyam/sdl/lib/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestProfileSwitchesMethodLatency verifies that selecting a profile swaps
+// in its method bodies and that clearing it restores the component's own.
+func TestProfileSwitchesMethodLatency(t *testing.T) {
+	sys := parseAndLoad(t, `
+import delay from "@stdlib/common.sdl"
+
+component DB {
+  method Query() Bool {
+    delay(50ms)
+    return true
+  }
+  method Ping() Bool {
+    delay(1ms)
+    return true
+  }
+
+  profile Fast {
+    method Query() Bool {
+      delay(5ms)
+      return true
+    }
+  }
+}
+component Server {
+  uses db DB()
+  method Handle() Bool {
+    return self.db.Query()
+  }
+}
+system App(server Server) { }
+`)
+	eval := NewSimpleEval(sys.File, nil)
+	call := &CallExpr{Function: buildMemberAccessExpr([]string{"server", "Handle"})}
+	latency := func() core.Duration {
+		var currTime core.Duration
+		eval.Eval(call, sys.Env.Push(), &currTime)
+		return currTime
+	}
+	db := sys.FindComponent("server.db")
+	require.NotNil(t, db)
+
+	assert.InDelta(t, 0.05, latency(), 1e-9)
+
+	require.NoError(t, db.SetProfile("Fast"))
+	assert.Equal(t, "Fast", db.Profile())
+	assert.InDelta(t, 0.005, latency(), 1e-9)
+	ping, _ := db.ComponentDecl.GetMethod("Ping")
+	assert.Same(t, ping, db.Method("Ping"), "methods the profile does not override are kept")
+
+	require.NoError(t, db.SetProfile(""))
+	assert.InDelta(t, 0.05, latency(), 1e-9)
+
+	assert.ErrorContains(t, db.SetProfile("Detailed"), "component 'DB' has no profile 'Detailed'")
+}
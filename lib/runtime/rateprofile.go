@@ -0,0 +1,153 @@
+package runtime
+
+import (
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RateProfile varies a generator's rate over time, eg to follow a daily
+// traffic pattern.  Time is measured on the generator's clock so a simulated
+// clock plays the profile back at its own pace.
+type RateProfile struct {
+	// Preset the profile was built from, eg "diurnal"
+	Name string
+
+	// Highest rate the profile reaches, in requests per second
+	Peak float64
+
+	// Rate, in requests per second, after the generator has run for elapsed
+	RateAt func(elapsed time.Duration) float64
+}
+
+// RateProfileBuilder builds a RateProfile from the options of a preset, eg
+// {"peak": "100", "period": "24h"}.
+type RateProfileBuilder func(opts map[string]string) (*RateProfile, error)
+
+// RateProfilePresets are the named traffic shapes NewRateProfile can build.
+var RateProfilePresets = map[string]RateProfileBuilder{
+	"diurnal": buildDiurnalProfile,
+	"spike":   buildSpikeProfile,
+}
+
+// NewRateProfile builds the profile of the named preset from its options.
+func NewRateProfile(preset string, opts map[string]string) (*RateProfile, error) {
+	build, ok := RateProfilePresets[preset]
+	if !ok {
+		return nil, fmt.Errorf("unknown rate profile preset '%s', expected one of: %s", preset, strings.Join(slices.Sorted(maps.Keys(RateProfilePresets)), ", "))
+	}
+	return build(opts)
+}
+
+// DiurnalProfile follows a day's traffic: it starts at base, rises smoothly
+// to peak halfway through period and falls back to base by its end.
+func DiurnalProfile(base, peak float64, period time.Duration) *RateProfile {
+	return &RateProfile{
+		Name: "diurnal",
+		Peak: peak,
+		RateAt: func(elapsed time.Duration) float64 {
+			phase := 2 * math.Pi * float64(elapsed%period) / float64(period)
+			return base + (peak-base)*(1-math.Cos(phase))/2
+		},
+	}
+}
+
+// SpikeProfile runs at base except for a burst at peak lasting width from
+// at.
+func SpikeProfile(base, peak float64, at, width time.Duration) *RateProfile {
+	return &RateProfile{
+		Name: "spike",
+		Peak: max(base, peak),
+		RateAt: func(elapsed time.Duration) float64 {
+			if elapsed >= at && elapsed < at+width {
+				return peak
+			}
+			return base
+		},
+	}
+}
+
+func buildDiurnalProfile(opts map[string]string) (*RateProfile, error) {
+	parsed := profileOptions{opts: opts}
+	peak := parsed.rate("peak", -1)
+	base := parsed.rate("base", 0)
+	period := parsed.duration("period", 24*time.Hour)
+	if err := parsed.finish("diurnal"); err != nil {
+		return nil, err
+	}
+	if base > peak {
+		return nil, fmt.Errorf("diurnal base %g is above its peak %g", base, peak)
+	}
+	return DiurnalProfile(base, peak, period), nil
+}
+
+func buildSpikeProfile(opts map[string]string) (*RateProfile, error) {
+	parsed := profileOptions{opts: opts}
+	base := parsed.rate("base", -1)
+	peak := parsed.rate("peak", -1)
+	at := parsed.duration("at", -1)
+	width := parsed.duration("for", 10*time.Second)
+	if err := parsed.finish("spike"); err != nil {
+		return nil, err
+	}
+	return SpikeProfile(base, peak, at, width), nil
+}
+
+// profileOptions parses the options of a preset, keeping the first error.
+// A negative default marks an option as required.
+type profileOptions struct {
+	opts map[string]string
+	seen []string
+	err  error
+}
+
+func (p *profileOptions) value(name string, required bool) (string, bool) {
+	p.seen = append(p.seen, name)
+	value, ok := p.opts[name]
+	if !ok && required && p.err == nil {
+		p.err = fmt.Errorf("missing --%s", name)
+	}
+	return value, ok
+}
+
+func (p *profileOptions) rate(name string, def float64) float64 {
+	value, ok := p.value(name, def < 0)
+	if !ok {
+		return def
+	}
+	rate, err := strconv.ParseFloat(value, 64)
+	if (err != nil || rate < 0) && p.err == nil {
+		p.err = fmt.Errorf("invalid --%s '%s': must be a non-negative number", name, value)
+	}
+	return rate
+}
+
+func (p *profileOptions) duration(name string, def time.Duration) time.Duration {
+	value, ok := p.value(name, def < 0)
+	if !ok {
+		return def
+	}
+	d, err := time.ParseDuration(value)
+	if (err != nil || d < 0 || (d == 0 && name != "at")) && p.err == nil {
+		p.err = fmt.Errorf("invalid --%s '%s': must be a duration like 30s or 24h", name, value)
+	}
+	return d
+}
+
+// finish returns the first error parsing the options, or an error naming
+// an option the preset does not take.
+func (p *profileOptions) finish(preset string) error {
+	if p.err != nil {
+		return fmt.Errorf("%s preset: %w", preset, p.err)
+	}
+	for name := range p.opts {
+		if !slices.Contains(p.seen, name) {
+			return fmt.Errorf("%s preset: unknown option --%s, expected one of --%s", preset, name, strings.Join(p.seen, ", --"))
+		}
+	}
+	return nil
+}
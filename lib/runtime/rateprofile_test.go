@@ -0,0 +1,69 @@
+package runtime
+
+import (
+	"testing"
+	"time"
+
+	protos "github.com/panyam/sdl/gen/go/sdl/v1/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestDiurnalPreset verifies that the diurnal preset runs between its base
+// and peak over each period, peaking halfway through.
+func TestDiurnalPreset(t *testing.T) {
+	profile, err := NewRateProfile("diurnal", map[string]string{"peak": "100", "base": "10", "period": "24h"})
+	require.NoError(t, err)
+	assert.Equal(t, 100.0, profile.Peak)
+
+	low, high := profile.RateAt(0), profile.RateAt(0)
+	for elapsed := time.Duration(0); elapsed < 48*time.Hour; elapsed += time.Minute {
+		rate := profile.RateAt(elapsed)
+		low, high = min(low, rate), max(high, rate)
+	}
+	assert.InDelta(t, 10, low, 1e-9)
+	assert.InDelta(t, 100, high, 1e-9)
+	assert.InDelta(t, 100, profile.RateAt(12*time.Hour), 1e-9)
+	assert.InDelta(t, 55, profile.RateAt(6*time.Hour), 1e-9)
+	assert.InDelta(t, profile.RateAt(3*time.Hour), profile.RateAt(27*time.Hour), 1e-9)
+
+	profile, err = NewRateProfile("diurnal", map[string]string{"peak": "100"})
+	require.NoError(t, err)
+	assert.InDelta(t, 0, profile.RateAt(0), 1e-9)
+	assert.InDelta(t, 100, profile.RateAt(12*time.Hour), 1e-9)
+}
+
+// TestSpikePreset verifies that the spike preset runs at its peak only for
+// the burst and that bad options are rejected.
+func TestSpikePreset(t *testing.T) {
+	profile, err := NewRateProfile("spike", map[string]string{"base": "10", "peak": "200", "at": "30s"})
+	require.NoError(t, err)
+	assert.Equal(t, 10.0, profile.RateAt(29*time.Second))
+	assert.Equal(t, 200.0, profile.RateAt(30*time.Second))
+	assert.Equal(t, 200.0, profile.RateAt(39*time.Second))
+	assert.Equal(t, 10.0, profile.RateAt(40*time.Second))
+
+	for msg, opts := range map[string]map[string]string{
+		"missing --at":          {"base": "10", "peak": "200"},
+		"invalid --peak 'lots'": {"base": "10", "peak": "lots", "at": "30s"},
+		"unknown option --rate": {"base": "10", "peak": "200", "at": "30s", "rate": "5"},
+	} {
+		_, err := NewRateProfile("spike", opts)
+		assert.ErrorContains(t, err, msg)
+	}
+	_, err = NewRateProfile("weekly", nil)
+	assert.ErrorContains(t, err, "expected one of: diurnal, spike")
+}
+
+// TestGeneratorFollowsProfile verifies that a generator emits requests at the
+// rate of its profile rather than its nominal rate.
+func TestGeneratorFollowsProfile(t *testing.T) {
+	g := &Generator{Generator: &protos.Generator{Rate: 200}, Profile: SpikeProfile(10, 200, 30*time.Second, 10*time.Second)}
+	emitted := map[bool]int{}
+	for elapsed := time.Duration(0); elapsed < time.Minute; elapsed += 100 * time.Millisecond {
+		g.profileRate = g.Profile.RateAt(elapsed)
+		emitted[g.CurrentRate() == 200] += g.due(100 * time.Millisecond)
+	}
+	assert.Equal(t, 2000, emitted[true], "10s at the peak")
+	assert.Equal(t, 500, emitted[false], "50s at the base")
+}
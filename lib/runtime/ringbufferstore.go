@@ -6,6 +6,7 @@ import (
 	"sort"
 	"sync"
 	"time"
+	"unsafe"
 
 	protos "github.com/panyam/sdl/gen/go/sdl/v1/models"
 )
@@ -20,6 +21,10 @@ type RingBufferStore struct {
 	buffers map[string]*ringBuffer
 	mu      sync.RWMutex
 
+	// Write accounting for Stats
+	totalWrites int64
+	firstWrite  time.Time
+
 	// Closed flag
 	closed bool
 }
@@ -63,14 +68,7 @@ func (s *RingBufferStore) WritePoint(ctx context.Context, metric *protos.Metric,
 		return fmt.Errorf("store is closed")
 	}
 
-	s.mu.Lock()
-	rb, ok := s.buffers[metric.Name]
-	if !ok {
-		rb = newRingBuffer(s.maxPointsPerMetric)
-		s.buffers[metric.Name] = rb
-	}
-	s.mu.Unlock()
-
+	rb := s.bufferFor(metric.Name, 1)
 	rb.add(point)
 	return nil
 }
@@ -81,18 +79,28 @@ func (s *RingBufferStore) WriteBatch(ctx context.Context, metric *protos.Metric,
 		return fmt.Errorf("store is closed")
 	}
 
+	rb := s.bufferFor(metric.Name, len(points))
+	for _, point := range points {
+		rb.add(point)
+	}
+	return nil
+}
+
+// bufferFor returns the ring buffer for a metric, creating it if needed, and
+// records that numPoints are about to be written.
+func (s *RingBufferStore) bufferFor(metricName string, numPoints int) *ringBuffer {
 	s.mu.Lock()
-	rb, ok := s.buffers[metric.Name]
+	defer s.mu.Unlock()
+	rb, ok := s.buffers[metricName]
 	if !ok {
 		rb = newRingBuffer(s.maxPointsPerMetric)
-		s.buffers[metric.Name] = rb
+		s.buffers[metricName] = rb
 	}
-	s.mu.Unlock()
-
-	for _, point := range points {
-		rb.add(point)
+	if s.totalWrites == 0 {
+		s.firstWrite = time.Now()
 	}
-	return nil
+	s.totalWrites += int64(numPoints)
+	return rb
 }
 
 // Query retrieves raw metric points
@@ -300,6 +308,47 @@ func (s *RingBufferStore) GetMetricStats(metric *protos.Metric) MetricStats {
 	}
 }
 
+// Stats returns row counts, the time span and approximate memory used across all metrics.
+// Memory is estimated from the allocated buffer slots, the stored points and their tags.
+func (s *RingBufferStore) Stats() MetricStoreStats {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	stats := MetricStoreStats{RowsPerMetric: make(map[string]int64)}
+	pointSize := int64(unsafe.Sizeof(MetricPoint{}))
+	slotSize := int64(unsafe.Sizeof((*MetricPoint)(nil)))
+	for name, rb := range s.buffers {
+		rb.mu.RLock()
+		stats.RowsPerMetric[name] = int64(rb.count)
+		stats.TotalRows += int64(rb.count)
+		stats.SizeBytes += int64(rb.size) * slotSize
+		for i := 0; i < rb.count; i++ {
+			point := rb.points[(rb.readStart+i)%rb.size]
+			if point == nil {
+				continue
+			}
+			stats.SizeBytes += pointSize
+			for k, v := range point.Tags {
+				stats.SizeBytes += int64(len(k) + len(v))
+			}
+			if stats.OldestTimestamp.IsZero() || point.Timestamp.Before(stats.OldestTimestamp) {
+				stats.OldestTimestamp = point.Timestamp
+			}
+			if point.Timestamp.After(stats.NewestTimestamp) {
+				stats.NewestTimestamp = point.Timestamp
+			}
+		}
+		rb.mu.RUnlock()
+	}
+
+	if s.totalWrites > 0 {
+		if elapsed := time.Since(s.firstWrite).Seconds(); elapsed > 0 {
+			stats.WriteRate = float64(s.totalWrites) / elapsed
+		}
+	}
+	return stats
+}
+
 func (s *RingBufferStore) Close() error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
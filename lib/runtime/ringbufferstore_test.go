@@ -0,0 +1,50 @@
+package runtime
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	protos "github.com/panyam/sdl/gen/go/sdl/v1/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRingBufferStoreStats writes known samples for two metrics and verifies
+// the store reports matching row counts, time span, size and write rate.
+func TestRingBufferStoreStats(t *testing.T) {
+	store, err := NewRingBufferStore(MetricStoreConfig{
+		Type:   "ringbuffer",
+		Config: map[string]interface{}{ConfigRingBufferSize: 100},
+	})
+	require.NoError(t, err)
+	defer store.Close()
+
+	empty := store.Stats()
+	assert.Equal(t, int64(0), empty.TotalRows)
+	assert.True(t, empty.OldestTimestamp.IsZero())
+	assert.Equal(t, 0.0, empty.WriteRate)
+
+	ctx := context.Background()
+	base := time.Now().Add(-time.Minute)
+	latency := &protos.Metric{Name: "latency"}
+	count := &protos.Metric{Name: "count"}
+
+	var points []*MetricPoint
+	for i := range 10 {
+		points = append(points, &MetricPoint{Timestamp: base.Add(time.Duration(i) * time.Second), Value: float64(i)})
+	}
+	require.NoError(t, store.WriteBatch(ctx, latency, points))
+	for i := range 4 {
+		point := &MetricPoint{Timestamp: base.Add(time.Duration(20+i) * time.Second), Value: 1, Tags: map[string]string{"cache_hit": "true"}}
+		require.NoError(t, store.WritePoint(ctx, count, point))
+	}
+
+	stats := store.Stats()
+	assert.Equal(t, int64(14), stats.TotalRows)
+	assert.Equal(t, map[string]int64{"latency": 10, "count": 4}, stats.RowsPerMetric)
+	assert.True(t, stats.OldestTimestamp.Equal(base))
+	assert.True(t, stats.NewestTimestamp.Equal(base.Add(23*time.Second)))
+	assert.Greater(t, stats.SizeBytes, int64(0))
+	assert.Greater(t, stats.WriteRate, 0.0)
+}
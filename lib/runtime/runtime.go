@@ -22,6 +22,9 @@ type Runtime struct {
 	nativeAggrs    map[string]Aggregator
 	nativeComps    map[string]any
 	nativeCompCons map[string]func(name string) any
+
+	// Maximum nested method calls in a single evaluation, see SimpleEval.MaxCallDepth
+	MaxCallDepth int
 }
 
 func NewRuntime(loader *loader.Loader) (r *Runtime) {
@@ -29,6 +32,7 @@ func NewRuntime(loader *loader.Loader) (r *Runtime) {
 		Loader:        loader,
 		fileInstances: make(map[string]*FileInstance),
 		nativeMethods: make(map[string]NativeMethod),
+		MaxCallDepth:  DefaultMaxCallDepth,
 	}
 	r.RegisterNativeMethod("log", Native_log)
 	r.RegisterNativeMethod("delay", Native_delay)
@@ -112,9 +116,9 @@ func (r *Runtime) BatchSetParams(system *SystemInstance, paramPaths []string, ne
 		componentPath := strings.Join(parts[:len(parts)-1], ".")
 
 		// Find the component instance using FindComponent
-		componentInstance := system.FindComponent(componentPath)
-		if componentInstance == nil {
-			err = fmt.Errorf("component '%s' not found", componentPath)
+		componentInstance, resolveErr := system.ResolveComponent(componentPath)
+		if resolveErr != nil {
+			err = fmt.Errorf("cannot set '%s': %w", paramPath, resolveErr)
 			return
 		}
 
@@ -172,10 +176,11 @@ func (r *Runtime) NewSystem(systemName string) (sysInst *SystemInstance, err err
 		if sysDecl == nil {
 			continue
 		}
-		sysInst, _ = finst.NewSystem(systemName, true)
-		if sysInst != nil {
-			return sysInst, nil
+		sysInst, _, err = finst.InitSystem(systemName)
+		if err != nil {
+			return nil, fmt.Errorf("system '%s': %w", systemName, err)
 		}
+		return sysInst, nil
 	}
 	return nil, fmt.Errorf("system '%s' not found in any loaded file", systemName)
 }
@@ -205,6 +210,8 @@ func (r *Runtime) CreateNativeComponent(compDecl *ComponentDecl) NativeObject {
 		return cd.NewResourcePool(name)
 	case "Link":
 		return cd.NewNetworkLink(name)
+	case "LoadBalancer":
+		return cd.NewLoadBalancer(name)
 	case "SortedFile":
 		return cd.NewSortedFile(name)
 	case "HeapFile":
@@ -227,14 +234,22 @@ func Native_delay(eval *SimpleEval, env *Env[Value], currTime *core.Duration, ar
 		panic("delay expects exactly one argument")
 	}
 	result = args[0]
-	if i, err := result.GetInt(); err == nil {
-		*currTime += core.Duration(i)
-		result.Time += core.Duration(i)
-	} else if f, err := result.GetFloat(); err == nil {
-		*currTime += f
-		result.Time += f
-	} else {
+	// A distribution of durations is sampled on each call
+	if result.Type.Tag == decl.TypeTagOutcomes {
+		result, _ = eval.sample(result.OutcomesVal(), *currTime)
+	}
+	// Switch on the type rather than trying GetInt first so float delays do
+	// not build an error on every call
+	var delay core.Duration
+	switch value, _ := result.Deref(); value.Type {
+	case decl.IntType:
+		delay = core.Duration(value.IntVal())
+	case decl.FloatType:
+		delay = value.FloatVal()
+	default:
 		panic("delay value should have been int or float. type checking failed")
 	}
+	*currTime += delay
+	result.Time += delay
 	return
 }
@@ -0,0 +1,189 @@
+package runtime
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+
+	"github.com/panyam/sdl/lib/core"
+	"github.com/panyam/sdl/lib/decl"
+)
+
+// ScenarioResult is the outcome of running a scenario.
+type ScenarioResult struct {
+	System  string
+	Name    string
+	Passed  bool
+	Calls   int // Calls made by the scenario's generators
+	Expects []ExpectResult
+}
+
+// ExpectResult is an expectation of a scenario with the value it observed.
+type ExpectResult struct {
+	Expect *decl.ScenarioExpect
+	Value  float64 // Seconds for latencies, calls for count and calls whose trace matched for paths
+	Passed bool
+}
+
+func (e ExpectResult) String() string {
+	if e.Expect.Path != "" {
+		return fmt.Sprintf("%s %q (matched %d calls)", e.Expect.Aggregation.Value, e.Expect.Path, int(e.Value))
+	}
+	metricType := MetricLatency
+	if e.Expect.Aggregation.Value == "count" {
+		metricType = MetricCount
+	}
+	return fmt.Sprintf("%s %s.%s %s %s (got %s)", e.Expect.Aggregation.Value, e.Expect.ComponentPath, e.Expect.MethodName,
+		e.Expect.Operator, FormatMetricValue(metricType, e.Expect.Threshold, 0), FormatMetricValue(metricType, e.Value, 0))
+}
+
+// RunScenario runs a scenario's generators against the system in virtual time
+// and checks its expectations against the calls the targeted methods saw.
+// Path expectations are checked against the trace of every generated call.
+// The generators' rates are applied as arrival rates first so components
+// see the scenario's load.  The system should be freshly created as those
+// rates are left on its components.  A seed of 0 uses a time based seed.
+func RunScenario(system *SystemInstance, scenario *decl.ScenarioDecl, seed int64) (*ScenarioResult, error) {
+	var generators []GeneratorConfigAPI
+	type call struct {
+		at     core.Duration
+		target Expr
+	}
+	var calls []call
+	for idx, gen := range scenario.Generators {
+		generators = append(generators, GeneratorConfigAPI{
+			ID:        fmt.Sprintf("%s-%d", scenario.Name.Value, idx),
+			Component: gen.ComponentPath,
+			Method:    gen.MethodName,
+			Rate:      gen.Rate,
+		})
+		target := buildMemberAccessExpr(append(strings.Split(gen.ComponentPath, "."), gen.MethodName))
+		for i := range int(math.Round(gen.Rate * gen.Duration)) {
+			calls = append(calls, call{at: core.Duration(float64(i) / gen.Rate), target: target})
+		}
+	}
+	slices.SortStableFunc(calls, func(a, b call) int { return cmp.Compare(a.at, b.at) })
+	if _, err := EvaluateFlowStrategy("runtime", system, generators, DefaultFlowSolverOptions()); err != nil {
+		return nil, err
+	}
+
+	tracer := &scenarioTracer{latencies: map[*ComponentInstance]map[string][]float64{}}
+	paths := map[*decl.ScenarioExpect]*TracePath{}
+	matched := map[*decl.ScenarioExpect]int{}
+	for _, expect := range scenario.Expects {
+		if expect.Path != "" {
+			path, err := ParseTracePath(expect.Path)
+			if err != nil {
+				return nil, err
+			}
+			paths[expect] = path
+			continue
+		}
+		comp := system.FindComponent(expect.ComponentPath)
+		if comp == nil {
+			return nil, fmt.Errorf("component '%s' not found in system", expect.ComponentPath)
+		}
+		if tracer.latencies[comp] == nil {
+			tracer.latencies[comp] = map[string][]float64{}
+		}
+		tracer.latencies[comp][expect.MethodName] = []float64{}
+	}
+
+	eval := NewSimpleEval(system.File, tracer)
+	if seed != 0 {
+		eval.SetSeed(seed)
+	}
+	for _, c := range calls {
+		if len(paths) > 0 {
+			tracer.trace = NewExecutionTracer()
+		}
+		currTime := c.at
+		if _, err := eval.EvalCall(&CallExpr{Function: c.target}, system.Env.Push(), &currTime); err != nil {
+			return nil, err
+		}
+		if eval.HasErrors() {
+			return nil, eval.ErrorCollector.Errors[0]
+		}
+		if len(paths) > 0 {
+			tree := BuildTraceTree(&TraceData{Events: tracer.trace.Events})
+			for expect, path := range paths {
+				if path.Match(tree) {
+					matched[expect]++
+				}
+			}
+		}
+	}
+
+	result := &ScenarioResult{System: system.GetSystemName(), Name: scenario.Name.Value, Passed: true, Calls: len(calls)}
+	for _, expect := range scenario.Expects {
+		if paths[expect] != nil {
+			er := ExpectResult{Expect: expect, Value: float64(matched[expect])}
+			if expect.Aggregation.Value == "nopath" {
+				er.Passed = matched[expect] == 0
+			} else {
+				er.Passed = matched[expect] == len(calls)
+			}
+			result.Passed = result.Passed && er.Passed
+			result.Expects = append(result.Expects, er)
+			continue
+		}
+		latencies := tracer.latencies[system.FindComponent(expect.ComponentPath)][expect.MethodName]
+		er := ExpectResult{Expect: expect, Value: Aggregate(expect.Aggregation.Value, latencies)}
+		switch expect.Operator {
+		case "<":
+			er.Passed = er.Value < expect.Threshold
+		case "<=":
+			er.Passed = er.Value <= expect.Threshold
+		case ">":
+			er.Passed = er.Value > expect.Threshold
+		case ">=":
+			er.Passed = er.Value >= expect.Threshold
+		}
+		result.Passed = result.Passed && er.Passed
+		result.Expects = append(result.Expects, er)
+	}
+	return result, nil
+}
+
+// scenarioTracer records the latencies of calls to the methods a scenario's
+// expectations are about, and the trace of the current call for path
+// expectations.
+type scenarioTracer struct {
+	latencies map[*ComponentInstance]map[string][]float64
+	trace     *ExecutionTracer
+}
+
+func (t *scenarioTracer) Enter(ts core.Duration, kind TraceEventKind, comp *ComponentInstance, method *MethodDecl, args ...string) int64 {
+	if t.trace != nil {
+		return t.trace.Enter(ts, kind, comp, method, args...)
+	}
+	return 0
+}
+
+func (t *scenarioTracer) Exit(ts core.Duration, duration core.Duration, comp *ComponentInstance, method *MethodDecl, retVal Value, err error) {
+	if t.trace != nil {
+		t.trace.Exit(ts, duration, comp, method, retVal, err)
+	}
+	if comp == nil || method == nil {
+		return
+	}
+	if methods := t.latencies[comp]; methods != nil {
+		if latencies, ok := methods[method.Name.Value]; ok {
+			methods[method.Name.Value] = append(latencies, float64(duration))
+		}
+	}
+}
+
+func (t *scenarioTracer) PushParentID(id int64) {
+	if t.trace != nil {
+		t.trace.PushParentID(id)
+	}
+}
+
+func (t *scenarioTracer) PopParent() {
+	if t.trace != nil {
+		t.trace.PopParent()
+	}
+}
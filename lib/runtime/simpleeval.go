@@ -2,9 +2,12 @@ package runtime
 
 import (
 	"fmt"
+	"hash/fnv"
+	"log"
 	"math/rand"
 	"slices"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/panyam/sdl/lib/core"
@@ -29,6 +32,47 @@ type Tracer interface {
 	PopParent()
 }
 
+// EntryCallTracer is implemented by tracers that also observe each call a
+// generator makes as a whole, eg for system-wide metrics.
+type EntryCallTracer interface {
+	EntryCall(ts core.Duration, duration core.Duration, retVal Value, err error)
+}
+
+// DefaultMaxCallDepth is the default limit on nested method calls in a single evaluation.
+const DefaultMaxCallDepth = 256
+
+// CallDepthError is raised when nested method calls exceed the evaluator's MaxCallDepth,
+// usually because of unbounded (mutual) recursion in a model.
+type CallDepthError struct {
+	MaxDepth int
+	Stack    []string // "Component.Method" for each call, outermost first
+}
+
+func (e *CallDepthError) Error() string {
+	const shown = 8
+	frames := e.Stack
+	if len(frames) > shown {
+		frames = append([]string{fmt.Sprintf("... %d more", len(frames)-shown)}, frames[len(frames)-shown:]...)
+	}
+	return fmt.Sprintf("maximum call depth %d exceeded: %s", e.MaxDepth, strings.Join(frames, " -> "))
+}
+
+// RuntimeError is an error raised while evaluating a model, at the position
+// of the innermost expression or statement being evaluated.  It renders as an
+// SDL diagnostic, eg "app.sdl:12:5: runtime error: identifier not found 'x'".
+type RuntimeError struct {
+	File  string
+	Pos   Location
+	Stack []string // "Component.Method" for each call, outermost first
+	Err   error
+}
+
+func (e *RuntimeError) Error() string {
+	return fmt.Sprintf("%s:%d:%d: runtime error: %v", e.File, e.Pos.Line, e.Pos.Col, e.Err)
+}
+
+func (e *RuntimeError) Unwrap() error { return e.Err }
+
 // A simple evaluator
 type SimpleEval struct {
 	ErrorCollector
@@ -37,24 +81,114 @@ type SimpleEval struct {
 	Rand     *rand.Rand
 	Tracer   Tracer
 	Errors   []error
+
+	// Maximum nested method calls before evaluation fails with a CallDepthError
+	MaxCallDepth int
+	callStack    []string
+
+	// File of each method on callStack and the innermost node being
+	// evaluated, for the position of runtime errors
+	fileStack []string
+	current   Node
+
+	// Checked on every call and loop iteration when the run has a budget
+	budget *budgetMeter
+
+	// Where the random decisions of the run are logged, nil to not log them
+	Decisions *DecisionLog
+
+	// Random sources of each system instance by InstanceID, derived from the
+	// seed given to SetSeed, nil until then
+	seed          int64
+	instanceRands map[string]*rand.Rand
+
+	// Environment of the outermost call, where the system's instances are
+	// found by name, eg for the backends of a Forwarder
+	sysEnv *Env[Value]
+}
+
+// Forwarder is implemented by native components that send a call of a
+// method on to another instance of the system, eg a load balancer calling
+// the backend it picked.
+type Forwarder interface {
+	// ForwardTarget returns the path of the instance and the method a call
+	// of method is forwarded to, or "" when it is not forwarded.
+	ForwardTarget(method string, rnd *rand.Rand) (target, targetMethod string)
 }
 
 func NewSimpleEval(fi *FileInstance, tracer Tracer) *SimpleEval {
 	out := &SimpleEval{
-		RootFile: fi,
-		Rand:     rand.New(rand.NewSource(time.Now().UnixMicro())),
-		Tracer:   tracer,
+		RootFile:     fi,
+		Rand:         rand.New(rand.NewSource(time.Now().UnixMicro())),
+		Tracer:       tracer,
+		MaxCallDepth: DefaultMaxCallDepth,
+	}
+	if fi != nil && fi.Runtime != nil && fi.Runtime.MaxCallDepth > 0 {
+		out.MaxCallDepth = fi.Runtime.MaxCallDepth
 	}
 	out.MaxErrors = 1
 	return out
 }
 
+// SetSeed seeds Rand and gives every component instance of a system its own
+// random source, derived from seed and the instance's InstanceID (see
+// InstanceSeed), which is used for the samples taken in its methods.  Replicas
+// of a component then vary independently of each other yet the same way on
+// every run.
+func (s *SimpleEval) SetSeed(seed int64) {
+	s.Rand = rand.New(rand.NewSource(seed))
+	s.seed = seed
+	s.instanceRands = map[string]*rand.Rand{}
+}
+
+// InstanceSeed returns the seed of the random source of the instance with
+// the given InstanceID in runs seeded with seed, see SimpleEval.SetSeed.
+func InstanceSeed(seed int64, instanceID string) int64 {
+	hash := fnv.New64a()
+	hash.Write([]byte(instanceID))
+	return seed ^ int64(hash.Sum64())
+}
+
+// instanceRand returns the random source for samples taken in the methods of
+// compInst, or nil if it does not have its own.
+func (s *SimpleEval) instanceRand(compInst *ComponentInstance) *rand.Rand {
+	if s.instanceRands == nil || compInst == nil || compInst.InstanceID() == "" {
+		return nil
+	}
+	rng := s.instanceRands[compInst.InstanceID()]
+	if rng == nil {
+		rng = rand.New(rand.NewSource(InstanceSeed(s.seed, compInst.InstanceID())))
+		s.instanceRands[compInst.InstanceID()] = rng
+	}
+	return rng
+}
+
+// EvalCall evaluates a call and returns a *CallDepthError instead of panicking
+// if the call recursed past MaxCallDepth, a *BudgetExceededError if the run
+// went over its budget, or a *RuntimeError for any other failure.
+func (s *SimpleEval) EvalCall(call *CallExpr, env *Env[Value], currTime *core.Duration) (result Value, err error) {
+	depth, outer := len(s.callStack), s.current
+	if depth == 0 {
+		s.sysEnv = env
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = s.runtimeError(s.current, r)
+			s.current = outer
+			s.callStack, s.fileStack = s.callStack[:depth], s.fileStack[:depth]
+		}
+	}()
+	result, _ = s.Eval(call, env, currTime)
+	return
+}
+
 func (s *SimpleEval) EvalInitSystem(sys *SystemInstance, env *Env[Value], currTime *core.Duration) (result Value, returned bool) {
 	stmts, err := sys.Initializer()
 	ensureNoErr(err)
 	_, returned, timeTaken := s.EvalStatements(stmts.Statements, env)
 	*currTime += timeTaken
 	result.Time = timeTaken
+	sys.assignInstanceIDs(env)
 
 	// Now check if all things are initialized
 	uninited := sys.GetUninitializedComponents(env)
@@ -87,7 +221,15 @@ func (s *SimpleEval) EvalStatements(stmts []Stmt, env *Env[Value]) (result []Val
 
 // The main Eval loop of an expression/statement
 func (s *SimpleEval) Eval(node Node, env *Env[Value], currTime *core.Duration) (result Value, returned bool) {
-	// ... (rest of the Eval method remains the same)
+	// Left as is on a panic so it is the innermost node being evaluated
+	outer := s.current
+	s.current = node
+	result, returned = s.evalNode(node, env, currTime)
+	s.current = outer
+	return
+}
+
+func (s *SimpleEval) evalNode(node Node, env *Env[Value], currTime *core.Duration) (result Value, returned bool) {
 	switch n := node.(type) {
 	// --- Statement Nodes ---
 	case *BlockStmt:
@@ -104,6 +246,8 @@ func (s *SimpleEval) Eval(node Node, env *Env[Value], currTime *core.Duration) (
 		return s.evalReturnStmt(n, env, currTime)
 	case *ExprStmt:
 		return s.evalExprStmt(n, env, currTime)
+	case *SpawnStmt:
+		return s.evalSpawnStmt(n, env, currTime)
 	case *IfStmt:
 		return s.evalIfStmt(n, env, currTime)
 	case *AssignmentStmt:
@@ -139,6 +283,45 @@ func (s *SimpleEval) Eval(node Node, env *Env[Value], currTime *core.Duration) (
 	}
 }
 
+// runtimeError wraps a value panicked with while evaluating node in a
+// *RuntimeError at node's position.  Errors that already carry a position,
+// or that end the run as a whole, are returned as is.
+func (s *SimpleEval) runtimeError(node Node, r any) error {
+	switch r := r.(type) {
+	case *RuntimeError:
+		return r
+	case *CallDepthError:
+		return r
+	case *BudgetExceededError:
+		return r
+	}
+	err, ok := r.(error)
+	if !ok {
+		err = fmt.Errorf("%v", r)
+	}
+	out := &RuntimeError{Stack: slices.Clone(s.callStack), Err: err}
+	if node != nil {
+		out.Pos = node.Pos()
+	}
+	if len(s.fileStack) > 0 {
+		out.File = s.fileStack[len(s.fileStack)-1]
+	} else if s.RootFile != nil && s.RootFile.Decl != nil {
+		out.File = s.RootFile.Decl.FullPath
+	}
+	return out
+}
+
+// methodFile returns the path of the file methodDecl is declared in.
+func (s *SimpleEval) methodFile(methodDecl *MethodDecl) string {
+	if methodDecl.BoundComponent != nil && methodDecl.BoundComponent.ParentFileDecl != nil {
+		return methodDecl.BoundComponent.ParentFileDecl.FullPath
+	}
+	if s.RootFile != nil && s.RootFile.Decl != nil {
+		return s.RootFile.Decl.FullPath
+	}
+	return ""
+}
+
 func (s *SimpleEval) evalBlockStmt(b *BlockStmt, env *Env[Value], currTime *core.Duration) (result Value, returned bool) {
 	for _, statement := range b.Statements {
 		result, returned = s.Eval(statement, env, currTime)
@@ -169,7 +352,11 @@ func (s *SimpleEval) evalLiteralExpr(e *LiteralExpr, _ *Env[Value], _ *core.Dura
 
 func (s *SimpleEval) evalSetStmt(set *SetStmt, env *Env[Value], currTime *core.Duration) (result Value, returned bool) {
 	// evaluate the Expression and unzip and assign to variables in the same environment
-	result, _ = s.Eval(set.Value, env, currTime)
+	result, err := s.evalSetValue(set.Value, env, currTime)
+	if err != nil {
+		log.Printf("Warning: cannot evaluate %s: %v", set.Value, err)
+		return Nil, false
+	}
 	switch lhs := set.TargetExpr.(type) {
 	case *IdentifierExpr:
 		env.Set(lhs.Value, result)
@@ -178,7 +365,10 @@ func (s *SimpleEval) evalSetStmt(set *SetStmt, env *Env[Value], currTime *core.D
 		if maeTarget.Type.Tag != decl.TypeTagComponent {
 			panic(fmt.Sprintf("Expected mae to be a component, found: %s -> %s", maeTarget.String(), maeTarget.Type))
 		}
-		maeTarget.Value.(*ComponentInstance).Set(lhs.Member.Value, result)
+		// Literal overrides are checked against constraints during inference, computed ones here
+		if err := maeTarget.Value.(*ComponentInstance).Set(lhs.Member.Value, result); err != nil {
+			panic(err)
+		}
 	default:
 		panic(fmt.Sprintf("Expected Identifier or MAE, Expected: %v", lhs))
 	}
@@ -186,6 +376,26 @@ func (s *SimpleEval) evalSetStmt(set *SetStmt, env *Env[Value], currTime *core.D
 	return
 }
 
+// evalSetValue evaluates the value of a set, eg a param default derived from
+// other params like `PoolSize * 2`, returning operator errors instead of
+// panicking with them.
+func (s *SimpleEval) evalSetValue(e Expr, env *Env[Value], currTime *core.Duration) (Value, error) {
+	b, ok := e.(*BinaryExpr)
+	if !ok {
+		v, _ := s.Eval(e, env, currTime)
+		return v, nil
+	}
+	lr, err := s.evalSetValue(b.Left, env, currTime)
+	if err != nil {
+		return Nil, err
+	}
+	rr, err := s.evalSetValue(b.Right, env, currTime)
+	if err != nil {
+		return Nil, err
+	}
+	return binaryOp(b.Operator, lr, rr)
+}
+
 func (s *SimpleEval) evalReturnStmt(r *ReturnStmt, env *Env[Value], currTime *core.Duration) (result Value, returned bool) {
 	if r.ReturnValue != nil {
 		result, _ = s.Eval(r.ReturnValue, env, currTime)
@@ -194,6 +404,9 @@ func (s *SimpleEval) evalReturnStmt(r *ReturnStmt, env *Env[Value], currTime *co
 }
 
 func (s *SimpleEval) evalForStmt(f *ForStmt, env *Env[Value], currTime *core.Duration) (result Value, returned bool) {
+	if f.Var != nil {
+		return s.evalForInStmt(f, env, currTime)
+	}
 	var err error
 	counter := int64(0)
 	for {
@@ -214,6 +427,9 @@ func (s *SimpleEval) evalForStmt(f *ForStmt, env *Env[Value], currTime *core.Dur
 		} else if !condBoolVal {
 			return
 		}
+		if s.budget != nil {
+			s.budget.checkClock()
+		}
 		bodyRes, bodyReturned := s.Eval(f.Body, env, currTime)
 		if bodyReturned {
 			return bodyRes, bodyReturned
@@ -222,24 +438,51 @@ func (s *SimpleEval) evalForStmt(f *ForStmt, env *Env[Value], currTime *core.Dur
 	}
 }
 
+// evalForInStmt runs the body once for each element of the list, bound to
+// the loop variable in a scope of its own.
+func (s *SimpleEval) evalForInStmt(f *ForStmt, env *Env[Value], currTime *core.Duration) (result Value, returned bool) {
+	listVal, _ := s.Eval(f.Condition, env, currTime)
+	items, err := listVal.GetList()
+	ensureNoErr(err)
+	loopEnv := env.Push()
+	for _, item := range items {
+		if s.budget != nil {
+			s.budget.checkClock()
+		}
+		loopEnv.Set(f.Var.Value, item)
+		bodyRes, bodyReturned := s.Eval(f.Body, loopEnv, currTime)
+		if bodyReturned {
+			return bodyRes, bodyReturned
+		}
+	}
+	return
+}
+
 func (s *SimpleEval) evalLetStmt(l *LetStmt, env *Env[Value], currTime *core.Duration) (result Value, returned bool) {
 	// evaluate the Expression and unzip and assign to variables in the same environment
 	result, returned = s.Eval(l.Value, env, currTime)
-
-	if len(l.Variables) == 1 {
-		letvar := l.Variables[0].Value
-		env.Set(letvar, result)
-	} else {
-		tupleValues, err := result.GetTuple()
-		ensureNoErr(err)
-		for i, val := range tupleValues {
-			letvar := l.Variables[i].Value
-			env.Set(letvar, val)
-		}
+	if returned {
+		// eg a wait that failed the call
+		return
 	}
+
+	s.bindLetPattern(l.TargetPattern(), result, env)
 	return
 }
 
+// bindLetPattern recursively unzips a (possibly nested) tuple value into the pattern's identifiers
+func (s *SimpleEval) bindLetPattern(pattern *decl.LetPattern, value Value, env *Env[Value]) {
+	if !pattern.IsTuple() {
+		env.Set(pattern.Ident.Value, value)
+		return
+	}
+	tupleValues, err := value.GetTuple()
+	ensureNoErr(err)
+	for i, child := range pattern.Children {
+		s.bindLetPattern(child, tupleValues[i], env)
+	}
+}
+
 func (s *SimpleEval) evalDistributeExpr(dist *decl.DistributeExpr, env *Env[Value], currTime *core.Duration) (result Value, returned bool) {
 	var totalValue Value
 	totalProb := 0.0
@@ -251,15 +494,16 @@ func (s *SimpleEval) evalDistributeExpr(dist *decl.DistributeExpr, env *Env[Valu
 		} else {
 			totalProb = totalValue.FloatVal()
 		}
+		if totalProb <= 0 {
+			s.AddErrors(s.runtimeError(dist, fmt.Errorf("distribution total must be positive, got %g", totalProb)))
+			return decl.Nil, false
+		}
 	}
-	var caseConds []Value
+	var caseProbs []float64
 	var caseBodies []Value
-	var totalCasesProb = 0.0
-	outcomes := &core.Outcomes[Value]{}
 	var outcomeType *decl.Type
 	for idx, caseExp := range dist.Cases {
 		condVal, _ := s.Eval(caseExp.Condition, env, currTime)
-		caseConds = append(caseConds, condVal)
 		ensureTypes(condVal.Type, IntType, FloatType)
 		condProb := 0.0
 		if condVal.Type.Equals(IntType) {
@@ -267,7 +511,7 @@ func (s *SimpleEval) evalDistributeExpr(dist *decl.DistributeExpr, env *Env[Valu
 		} else {
 			condProb = condVal.FloatVal()
 		}
-		totalCasesProb += condProb
+		caseProbs = append(caseProbs, condProb)
 		bodyVal, _ := s.Eval(caseExp.Body, env, currTime)
 		if idx == 0 {
 			outcomeType = bodyVal.Type
@@ -275,7 +519,19 @@ func (s *SimpleEval) evalDistributeExpr(dist *decl.DistributeExpr, env *Env[Valu
 			panic("type mismatch - should have been checked by type checker")
 		}
 		caseBodies = append(caseBodies, bodyVal)
-		outcomes.Add(condProb, bodyVal)
+	}
+
+	// Weights computed from expressions may have drifted slightly
+	caseProbs, err := core.NormalizeWeights(caseProbs, totalProb, dist.Default != nil)
+	if err != nil {
+		s.AddErrors(s.runtimeError(dist, fmt.Errorf("invalid distribution: %w", err)))
+		return decl.Nil, false
+	}
+	outcomes := &core.Outcomes[Value]{}
+	totalCasesProb := 0.0
+	for idx, condProb := range caseProbs {
+		totalCasesProb += condProb
+		outcomes.Add(condProb, caseBodies[idx])
 	}
 	if dist.Default != nil {
 		if dist.TotalProb == nil {
@@ -297,8 +553,7 @@ func (s *SimpleEval) evalDistributeExpr(dist *decl.DistributeExpr, env *Env[Valu
 
 func (s *SimpleEval) evalSampleExpr(samp *decl.SampleExpr, env *Env[Value], currTime *core.Duration) (result Value, returned bool) {
 	res, _ := s.Eval(samp.FromExpr, env, currTime)
-	outcomes := res.OutcomesVal()
-	result, _ = outcomes.Sample(s.Rand)
+	result, _ = s.sample(res.OutcomesVal(), *currTime)
 	return
 }
 
@@ -324,7 +579,7 @@ func (s *SimpleEval) evalUnaryExpr(u *UnaryExpr, env *Env[Value], currTime *core
 		} else if lr.Type.Tag == decl.TypeTagOutcomes {
 			// Sample from outcomes and apply 'not' to the result
 			outcomesVal := lr.OutcomesVal()
-			sampledVal, ok := outcomesVal.Sample(s.Rand)
+			sampledVal, ok := s.sample(outcomesVal, *currTime)
 			if !ok {
 				panic("failed to sample from outcomes in unary not expression")
 			}
@@ -346,21 +601,87 @@ func (s *SimpleEval) evalUnaryExpr(u *UnaryExpr, env *Env[Value], currTime *core
 }
 
 func (s *SimpleEval) evalBinaryExpr(b *BinaryExpr, env *Env[Value], currTime *core.Duration) (result Value, returned bool) {
-	_, _ = s.Eval(b.Left, env, currTime)
-	_, _ = s.Eval(b.Right, env, currTime)
-
-	// TODO - Evaluate based on the operator
-	// log.Println("Left Result: ", lr)
-	// log.Println("Right Result: ", rr)
+	lr, _ := s.Eval(b.Left, env, currTime)
+	lr = s.sampledOperand(lr, currTime)
+	// && and || short circuit so calls on the right only happen when needed
+	if lr.Type != nil && lr.Type.Equals(BoolType) {
+		if (b.Operator == "&&" && !lr.BoolVal()) || (b.Operator == "||" && lr.BoolVal()) {
+			return lr, false
+		}
+	}
+	rr, _ := s.Eval(b.Right, env, currTime)
+	rr = s.sampledOperand(rr, currTime)
+	result, err := binaryOp(b.Operator, lr, rr)
+	if err != nil {
+		// Panic so EvalCall reports the error at this expression's position
+		panic(err)
+	}
 	return
 }
 
+// sampledOperand samples an Outcomes operand down to a single value, adding
+// the sampled latency to currTime, so operators see plain values.
+func (s *SimpleEval) sampledOperand(v Value, currTime *core.Duration) Value {
+	if v.Type == nil || v.Type.Tag != decl.TypeTagOutcomes {
+		return v
+	}
+	sampled, ok := s.sample(v.OutcomesVal(), *currTime)
+	if !ok {
+		panic("failed to sample from outcomes in binary expression")
+	}
+	*currTime += sampled.Time
+	return sampled
+}
+
+// binaryOp applies a binary operator to two evaluated operands.
+func binaryOp(op string, lr, rr Value) (Value, error) {
+	if lr.Type == nil || rr.Type == nil {
+		return Nil, fmt.Errorf("operator '%s' cannot be applied to %s and %s", op, lr.Type, rr.Type)
+	}
+	switch op {
+	case "+", "-", "*", "/", "%":
+		if lr.Type.Tag != decl.TypeTagSimple || rr.Type.Tag != decl.TypeTagSimple {
+			return Nil, fmt.Errorf("operator '%s' cannot be applied to %s and %s", op, lr.Type, rr.Type)
+		}
+		return decl.Arithmetic(op, lr, rr)
+	case "==", "!=", "<", "<=", ">", ">=":
+		return decl.Compare(op, lr, rr)
+	case "&&", "||":
+		if !lr.Type.Equals(BoolType) || !rr.Type.Equals(BoolType) {
+			return Nil, fmt.Errorf("operator '%s' cannot be applied to %s and %s", op, lr.Type, rr.Type)
+		}
+		if op == "&&" {
+			return BoolValue(lr.BoolVal() && rr.BoolVal()), nil
+		}
+		return BoolValue(lr.BoolVal() || rr.BoolVal()), nil
+	}
+	return Nil, fmt.Errorf("unsupported binary operator '%s'", op)
+}
+
 func (s *SimpleEval) evalExprStmt(stmt *ExprStmt, env *Env[Value], currTime *core.Duration) (result Value, returned bool) {
 	return s.Eval(stmt.Expression, env, currTime)
 }
 
+// evalSpawnStmt makes a fire and forget call.  The call starts now and runs
+// on its own clock so the caller carries on without waiting for it.
+func (s *SimpleEval) evalSpawnStmt(stmt *SpawnStmt, env *Env[Value], currTime *core.Duration) (result Value, returned bool) {
+	spawnTime := *currTime
+	s.Eval(stmt.Call, env, &spawnTime)
+	return
+}
+
 func (s *SimpleEval) evalIfStmt(stmt *IfStmt, env *Env[Value], currTime *core.Duration) (result Value, returned bool) {
 	condResult, _ := s.Eval(stmt.Condition, env, currTime)
+	if stmt.Binding != nil {
+		if !condResult.IsNil() {
+			thenEnv := env.Push()
+			thenEnv.Set(stmt.Binding.Value, condResult)
+			return s.Eval(stmt.Then, thenEnv, currTime)
+		} else if stmt.Else != nil {
+			return s.Eval(stmt.Else, env, currTime)
+		}
+		return
+	}
 	if condResult.IsTrue() {
 		return s.Eval(stmt.Then, env, currTime)
 	} else if stmt.Else != nil {
@@ -381,7 +702,10 @@ func (s *SimpleEval) evalTupleExpr(m *TupleExpr, env *Env[Value], currTime *core
 
 func (s *SimpleEval) evalGoExpr(m *GoExpr, env *Env[Value], currTime *core.Duration) (result Value, returned bool) {
 	var traceID int64
-	loopValue, _ := s.Eval(m.LoopExpr, env, currTime)
+	var loopValue Value
+	if m.LoopExpr != nil {
+		loopValue, _ = s.Eval(m.LoopExpr, env, currTime)
+	}
 	if s.Tracer != nil {
 		loopCount := "1"
 		if !loopValue.IsNil() {
@@ -425,6 +749,15 @@ func (s *SimpleEval) evalWaitExpr(expr *WaitExpr, env *Env[Value], currTime *cor
 		futureValues = append(futureValues, futureVal)
 	}
 
+	if expr.Policy != decl.WaitDefault {
+		var minSuccesses int64
+		if expr.AnyOfCount != nil {
+			count, _ := s.Eval(expr.AnyOfCount, env, currTime)
+			minSuccesses, _ = count.GetInt()
+		}
+		return waitWithPolicy(s, currTime, expr.Policy, minSuccesses, futureValues)
+	}
+
 	var aggParams []Value
 	for _, aggParam := range expr.AggregatorParams {
 		aggVal, _ := s.Eval(aggParam, env, currTime)
@@ -459,7 +792,7 @@ func (s *SimpleEval) evalMemberAccessExpr(m *MemberAccessExpr, env *Env[Value],
 			// TODO - This is a runtime error - but a user one so we should flag instead of panicking
 			// This means a "set" needs to be called - for example in DB, the ByShortCode dependency is not
 			// set - should we require that these are set manually each time or allow default values somehow for components too?
-			err := fmt.Errorf("Dependency %s not set. Either override it or set it", refVal.Attrib)
+			err := s.runtimeError(m, fmt.Errorf("Dependency %s not set. Either override it or set it", refVal.Attrib))
 			s.AddErrors(err)
 			panic(err)
 		}
@@ -496,12 +829,12 @@ func (s *SimpleEval) evalMemberAccessExpr(m *MemberAccessExpr, env *Env[Value],
 		ensureNoErr(err)
 		return
 	}
-	methodDecl, _ := compDecl.GetMethod(m.Member.Value)
+	methodDecl := compInst.Method(m.Member.Value)
 	if methodDecl != nil {
 		methodType := decl.MethodType(methodDecl)
 		methodVal := &decl.MethodValue{
 			Method:        methodDecl,
-			SavedEnv:      compInst.Env.Push(),
+			SavedEnv:      compInst.Env, // Calls push their own scope on it
 			IsNative:      compDecl.IsNative,
 			BoundInstance: compInst, // Always set to ComponentInstance
 		}
@@ -509,14 +842,35 @@ func (s *SimpleEval) evalMemberAccessExpr(m *MemberAccessExpr, env *Env[Value],
 		ensureNoErr(err)
 		return result, false
 	}
-	err = fmt.Errorf("in file %s at line %d, col %d: member '%s' not found on component of type '%s'",
-		s.RootFile.Decl.FullPath, m.Member.Pos().Line, m.Member.Pos().Col, m.Member.Value, compDecl.Name.Value)
-	s.AddErrors(err)
+	s.AddErrors(s.runtimeError(m.Member, fmt.Errorf("member '%s' not found on component of type '%s'", m.Member.Value, compDecl.Name.Value)))
 	result = decl.Nil
 	return result, false
 }
 
+// evalEnumCall evaluates a call to one of the enum builtins from the enum
+// its argument was inferred to name.
+func (s *SimpleEval) evalEnumCall(name string, expr *CallExpr) (result Value, returned bool) {
+	enumType := expr.ArgList[0].InferredType()
+	enumDecl := enumType.Info.(*EnumDecl)
+	if name == decl.BuiltinCount {
+		return decl.IntValue(int64(len(enumDecl.Values))), false
+	}
+	values := make([]Value, len(enumDecl.Values))
+	for idx := range enumDecl.Values {
+		values[idx], _ = NewValue(enumType, idx)
+	}
+	result, err := NewValue(decl.ListType(enumType), values)
+	ensureNoErr(err, "Error creating enum values: %v", err)
+	return result, false
+}
+
 func (s *SimpleEval) evalCallExpr(expr *CallExpr, env *Env[Value], currTime *core.Duration) (result Value, returned bool) {
+	if ident, ok := expr.Function.(*IdentifierExpr); ok && decl.IsConversionBuiltin(ident.Value) {
+		return s.evalConversionCall(ident.Value, expr, env, currTime)
+	}
+	if ident, ok := expr.Function.(*IdentifierExpr); ok && decl.IsEnumBuiltin(ident.Value) {
+		return s.evalEnumCall(ident.Value, expr)
+	}
 	receiver, _ := s.Eval(expr.Function, env, currTime)
 	methodValue := receiver.Value.(*decl.MethodValue)
 	methodDecl := methodValue.Method
@@ -547,15 +901,47 @@ func (s *SimpleEval) evalCallExpr(expr *CallExpr, env *Env[Value], currTime *cor
 		}()
 	}
 
+	frame := methodDecl.Name.Value
+	if compInst != nil {
+		frame = compInst.ComponentDecl.Name.Value + "." + frame
+	}
+	s.callStack = append(s.callStack, frame)
+	s.fileStack = append(s.fileStack, s.methodFile(methodDecl))
+	if s.MaxCallDepth > 0 && len(s.callStack) > s.MaxCallDepth {
+		panic(&CallDepthError{MaxDepth: s.MaxCallDepth, Stack: slices.Clone(s.callStack)})
+	}
+	if s.budget != nil {
+		s.budget.chargeNode()
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			panic(s.runtimeError(s.current, r))
+		}
+		s.callStack = s.callStack[:len(s.callStack)-1]
+		s.fileStack = s.fileStack[:len(s.fileStack)-1]
+	}()
+	if rng := s.instanceRand(compInst); rng != nil {
+		callerRand := s.Rand
+		s.Rand = rng
+		defer func() { s.Rand = callerRand }()
+	}
+
 	newenv := methodValue.SavedEnv.Push()
 	for idx, param := range methodDecl.Parameters {
 		newenv.Set(param.Name.Value, argValues[idx])
 	}
 
-	if methodValue.IsNative {
+	if methodValue.IsNative && methodDecl.Outcomes != nil {
+		result, _ = s.Eval(&decl.SampleExpr{FromExpr: methodDecl.Outcomes}, newenv, currTime)
+	} else if methodValue.IsNative {
 		if compInst != nil {
 			result, err := InvokeMethod(compInst.NativeInstance, methodValue.Method.Name.Value, argValues, env, currTime, s.Rand, true)
 			ensureNoErr(err, "Error calling method: ", err)
+			if fwd, ok := compInst.NativeInstance.(Forwarder); ok && s.sysEnv != nil {
+				if target, targetMethod := fwd.ForwardTarget(methodDecl.Name.Value, s.Rand); target != "" {
+					result = s.forward(result, target, targetMethod, currTime)
+				}
+			}
 			return result, false
 		} else {
 			// It's a global native method
@@ -565,12 +951,47 @@ func (s *SimpleEval) evalCallExpr(expr *CallExpr, env *Env[Value], currTime *cor
 			}
 			return nativeFunc(s, newenv, currTime, argValues...)
 		}
+	} else if ttl, hitRate, memoized := methodDecl.Memoize(); memoized && compInst != nil {
+		// Hits return the cached outcome without any of the method's latency
+		key := memoKey(methodDecl.Name.Value, argValues)
+		hitRate = MemoizeHitRate(ttl, hitRate, compInst.GetArrivalRate(methodDecl.Name.Value))
+		if s.Rand.Float64() < hitRate {
+			if cached, found := compInst.cachedOutcome(key, *currTime, ttl); found {
+				return cached, false
+			}
+		}
+		result, _ = s.Eval(methodDecl.Body, newenv, currTime)
+		compInst.cacheOutcome(key, result, *currTime)
+	} else if window, coalesced := methodDecl.Coalesce(); coalesced && compInst != nil {
+		// Joining calls share the in-flight outcome and wait out what is left of its call
+		key := memoKey(methodDecl.Name.Value, argValues)
+		factor := CoalesceFactor(window, compInst.GetArrivalRate(methodDecl.Name.Value))
+		if s.Rand.Float64() < 1-1/factor {
+			if inFlight, found := compInst.inFlightOutcome(key, *currTime); found {
+				*currTime += core.Duration(s.Rand.Float64() * window)
+				return inFlight, false
+			}
+		}
+		result, _ = s.Eval(methodDecl.Body, newenv, currTime)
+		compInst.setInFlightOutcome(key, result, *currTime, window)
 	} else {
 		result, _ = s.Eval(methodDecl.Body, newenv, currTime)
 	}
 	return
 }
 
+// forward calls method on the system instance at path target after a
+// Forwarder's own method returned routed.  The forwarded call's outcome
+// replaces routed when both are Bools.
+func (s *SimpleEval) forward(routed Value, target, method string, currTime *core.Duration) Value {
+	call := &CallExpr{Function: buildMemberAccessExpr(append(strings.Split(target, "."), method))}
+	result, _ := s.Eval(call, s.sysEnv, currTime)
+	if routed.Type != nil && routed.Type.Equals(BoolType) && result.Type != nil && result.Type.Equals(BoolType) {
+		return result
+	}
+	return routed
+}
+
 func (s *SimpleEval) evalAssignmentStmt(stmt *AssignmentStmt, env *Env[Value], currTime *core.Duration) (result Value, returned bool) {
 	panic("to be implemented")
 }
@@ -0,0 +1,224 @@
+package runtime
+
+import (
+	"testing"
+
+	"github.com/panyam/sdl/lib/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestIfLetEval verifies that an if let runs its then branch with the value
+// bound when it is not nil and its else branch otherwise.
+func TestIfLetEval(t *testing.T) {
+	sys := parseAndLoad(t, `
+component DB {
+  method Query() Bool { return true }
+  method Find(hit Bool) Int {
+    if hit {
+      return 5
+    }
+  }
+}
+component App {
+  uses db DB()
+  method Run() Bool {
+    if let d = self.db {
+      return d.Query()
+    }
+    return false
+  }
+  method Lookup(hit Bool) Int {
+    if let n = self.db.Find(hit) {
+      return n + 1
+    } else {
+      return 0
+    }
+  }
+}
+system S(app App) { }
+`)
+	call := func(method string, args ...Expr) Value {
+		eval := NewSimpleEval(sys.File, nil)
+		var currTime core.Duration
+		result, _ := eval.Eval(&CallExpr{Function: buildMemberAccessExpr([]string{"app", method}), ArgList: args}, sys.Env.Push(), &currTime)
+		require.False(t, eval.HasErrors(), eval.ErrorCollector.Errors)
+		return result
+	}
+	result := call("Run")
+	assert.True(t, result.BoolVal())
+	result = call("Lookup", &LiteralExpr{Value: BoolValue(true)})
+	assert.Equal(t, int64(6), result.IntVal())
+	result = call("Lookup", &LiteralExpr{Value: BoolValue(false)})
+	assert.Equal(t, int64(0), result.IntVal())
+}
+
+// TestBinaryOperatorsEval verifies that comparisons and logical operators in
+// method bodies are evaluated, and that && only calls its right side when the
+// left side is true.
+func TestBinaryOperatorsEval(t *testing.T) {
+	sys := parseAndLoad(t, `
+import delay from "@stdlib/common.sdl"
+component DB {
+  method Query() Bool {
+    delay(10ms)
+    return true
+  }
+}
+component App {
+  uses db DB()
+  param Limit Int = 3
+  method Allow(n Int) Bool {
+    return n < self.Limit && self.db.Query()
+  }
+  method Tier(n Int) String {
+    if n >= self.Limit * 2 {
+      return "high"
+    }
+    if n != 0 || false {
+      return "low"
+    }
+    return "none"
+  }
+}
+system S(app App) { }
+`)
+	call := func(method string, n int64) (Value, core.Duration) {
+		eval := NewSimpleEval(sys.File, nil)
+		var currTime core.Duration
+		result, _ := eval.Eval(&CallExpr{Function: buildMemberAccessExpr([]string{"app", method}), ArgList: []Expr{&LiteralExpr{Value: IntValue(n)}}}, sys.Env.Push(), &currTime)
+		require.False(t, eval.HasErrors(), eval.ErrorCollector.Errors)
+		return result, currTime
+	}
+	result, elapsed := call("Allow", 1)
+	assert.True(t, result.BoolVal())
+	assert.InDelta(t, 0.01, float64(elapsed), 1e-9)
+	result, elapsed = call("Allow", 5)
+	assert.False(t, result.BoolVal())
+	assert.Zero(t, elapsed)
+	for n, expected := range map[int64]string{0: "none", 2: "low", 6: "high"} {
+		result, _ = call("Tier", n)
+		assert.Equal(t, expected, result.StringVal(), "Tier(%d)", n)
+	}
+}
+
+// TestInstanceRandomStreams verifies that replicas of a component sample from
+// their own streams, which differ from each other, are not disturbed by calls
+// to other instances and repeat under the same seed.
+func TestInstanceRandomStreams(t *testing.T) {
+	sys := parseAndLoad(t, `
+import delay from "@stdlib/common.sdl"
+
+component Replica {
+  method Handle() Bool {
+    delay(dist { 50 => 1ms, 30 => 5ms, 20 => 20ms })
+    return true
+  }
+}
+component Pool {
+  uses first Replica()
+  uses second Replica()
+}
+system Replicas(pool Pool) { }
+`)
+	// latencies returns the latency of each call to the given replicas, in
+	// turn, under a fresh evaluator seeded with 42
+	latencies := func(calls int, replicas ...string) map[string][]core.Duration {
+		eval := NewSimpleEval(sys.File, nil)
+		eval.SetSeed(42)
+		out := map[string][]core.Duration{}
+		for range calls {
+			for _, replica := range replicas {
+				var currTime core.Duration
+				_, err := eval.EvalCall(&CallExpr{Function: buildMemberAccessExpr([]string{"pool", replica, "Handle"})}, sys.Env.Push(), &currTime)
+				require.NoError(t, err)
+				out[replica] = append(out[replica], currTime)
+			}
+		}
+		return out
+	}
+
+	both := latencies(200, "first", "second")
+	assert.NotEqual(t, both["first"], both["second"], "replicas should not share a stream")
+	assert.Equal(t, both, latencies(200, "first", "second"), "streams should repeat under the same seed")
+	assert.Equal(t, both["first"], latencies(200, "first")["first"], "calls to another replica should not shift a replica's stream")
+	assert.NotEqual(t, InstanceSeed(42, "pool.first"), InstanceSeed(42, "pool.second"))
+}
+
+// TestRuntimeErrorPosition verifies that a failure while evaluating a model that
+// passed type checking is returned as an SDL diagnostic at the position of the offending expression.
+func TestRuntimeErrorPosition(t *testing.T) {
+	sys := parseAndLoad(t, `
+component Counter {
+  method Share(total Int, count Int) Int {
+    return total / count
+  }
+}
+component App {
+  uses counter Counter()
+  method Run() Int {
+    return self.counter.Share(10, 0)
+  }
+}
+system S(app App) { }
+`)
+	eval := NewSimpleEval(sys.File, nil)
+	var currTime core.Duration
+	_, err := eval.EvalCall(&CallExpr{Function: buildMemberAccessExpr([]string{"app", "Run"})}, sys.Env.Push(), &currTime)
+	require.Error(t, err)
+
+	var runtimeErr *RuntimeError
+	require.ErrorAs(t, err, &runtimeErr)
+	assert.Equal(t, sys.File.Decl.FullPath, runtimeErr.File)
+	assert.Equal(t, []string{"App.Run", "Counter.Share"}, runtimeErr.Stack)
+	assert.Equal(t, runtimeErr.File+":4:12: runtime error: integer division by zero", err.Error())
+
+	// The evaluator is left ready for the next call
+	assert.Empty(t, eval.callStack)
+	_, err = eval.EvalCall(&CallExpr{Function: buildMemberAccessExpr([]string{"app", "Run"})}, sys.Env.Push(), &currTime)
+	assert.ErrorAs(t, err, &runtimeErr)
+}
+
+// TestSpawnStmt verifies that a spawned call adds to the load of its target,
+// both when evaluated and in flow analysis, but not to the caller's latency.
+func TestSpawnStmt(t *testing.T) {
+	sys := parseAndLoad(t, `
+import delay from "@stdlib/common.sdl"
+
+component Analytics {
+  method Record() Bool {
+    delay(50ms)
+    return true
+  }
+}
+component App {
+  uses analytics Analytics()
+  method Handle() Bool {
+    delay(10ms)
+    spawn self.analytics.Record()
+    return true
+  }
+}
+system S(app App) { }
+`)
+	tracer := NewExecutionTracer()
+	eval := NewSimpleEval(sys.File, tracer)
+	var currTime core.Duration
+	result, err := eval.EvalCall(&CallExpr{Function: buildMemberAccessExpr([]string{"app", "Handle"})}, sys.Env.Push(), &currTime)
+	require.NoError(t, err)
+	assert.True(t, result.BoolVal())
+	assert.InDelta(t, core.Millis(10), currTime, 1e-9, "the caller does not wait for the spawned call")
+
+	var recorded bool
+	for _, event := range tracer.Events {
+		if event.Kind == EventExit && event.Method != nil && event.Method.Name.Value == "Record" {
+			recorded = true
+			assert.InDelta(t, core.Millis(50), event.Duration, 1e-9)
+		}
+	}
+	assert.True(t, recorded, "the spawned call should still be made")
+
+	flows, err := EvaluateFlowStrategy("runtime", sys, []GeneratorConfigAPI{{ID: "g", Component: "app", Method: "Handle", Rate: 20}}, DefaultFlowSolverOptions())
+	require.NoError(t, err)
+	assert.InDelta(t, 20, flows.Flows.ComponentRates["app.analytics.Record"], 1e-9)
+}
@@ -16,4 +16,11 @@ type SimulationContext interface {
 
 	// GetSimulationTime returns the current virtual simulation time in seconds.
 	GetSimulationTime() float64
+
+	// GetClock returns the clock generators and metrics read wall time from.
+	GetClock() Clock
+
+	// OnGeneratorCompleted is called when a generator stops itself after
+	// emitting its MaxRequests.
+	OnGeneratorCompleted(gen *Generator)
 }
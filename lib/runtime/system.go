@@ -71,31 +71,44 @@ func (s *SystemInstance) GetSystemName() string {
 }
 
 // FindComponent resolves a dotted path like "a.b.c" to a nested ComponentInstance.
+// Returns nil if any segment of the path does not resolve.
 func (s *SystemInstance) FindComponent(fqn string) (out *ComponentInstance) {
+	out, _ = s.ResolveComponent(fqn)
+	return
+}
+
+// ResolveComponent walks a dotted path like "a.b.c" through the system's
+// components and their `uses` dependencies, returning the innermost instance.
+// The error names the first segment that could not be resolved.
+func (s *SystemInstance) ResolveComponent(fqn string) (*ComponentInstance, error) {
+	if s.Env == nil {
+		return nil, fmt.Errorf("system '%s' is not initialized", s.GetSystemName())
+	}
 	parts := strings.Split(fqn, ".")
 
 	currentEnv := s.Env
 	var currentComponent *ComponentInstance
-
-	for i, part := range parts {
+	for _, part := range parts {
 		value, ok := currentEnv.Get(part)
 		if !ok {
-			return nil
+			if currentComponent == nil {
+				return nil, fmt.Errorf("no component '%s' in system %s", part, s.GetSystemName())
+			}
+			return nil, fmt.Errorf("no dependency '%s' on component %s", part, currentComponent.ComponentDecl.Name.Value)
 		}
 
 		comp, ok := value.Value.(*ComponentInstance)
 		if !ok {
-			return nil
+			if currentComponent == nil {
+				return nil, fmt.Errorf("'%s' in system %s is not a component", part, s.GetSystemName())
+			}
+			return nil, fmt.Errorf("'%s' on component %s is not a dependency", part, currentComponent.ComponentDecl.Name.Value)
 		}
 
 		currentComponent = comp
-
-		if i < len(parts)-1 {
-			currentEnv = comp.Env
-		}
+		currentEnv = comp.Env
 	}
-
-	return currentComponent
+	return currentComponent, nil
 }
 
 // Initializer compiles the system into initialization statements.
@@ -126,6 +139,12 @@ func (s *SystemInstance) Initializer() (blockStmt *BlockStmt, err error) {
 		case *ExprStmt:
 			// generator(...), metric(...) calls — processed by Canvas.Use(), not here
 			continue
+		case *OptionsDecl:
+			// Resolved into System.Options during inference
+			continue
+		case *decl.ScenarioDecl:
+			// Run by RunScenario, not when the system is initialized
+			continue
 		default:
 			Error("Invalid system body item type: %T", item)
 		}
@@ -134,6 +153,12 @@ func (s *SystemInstance) Initializer() (blockStmt *BlockStmt, err error) {
 	return &BlockStmt{Statements: stmts}, nil
 }
 
+// Options returns the system's effective options: the file's options
+// overridden by the system's own.
+func (s *SystemInstance) Options() map[string]Value {
+	return s.System.Options
+}
+
 type InitStmt struct {
 	From     *InitStmt
 	Pos      Location
@@ -189,6 +214,41 @@ func (s *SystemInstance) GetUninitializedComponents(env *Env[Value]) (items []*I
 	return
 }
 
+// assignInstanceIDs sets the InstanceID of every component reachable from
+// the system's parameters to its path.  Parameters and uses are walked
+// breadth first in declaration order so an instance shared by several
+// components gets its shortest path, the same one on every run.
+func (s *SystemInstance) assignInstanceIDs(env *Env[Value]) {
+	type pending struct {
+		comp *ComponentInstance
+		path string
+	}
+	var queue []pending
+	for _, param := range s.System.Parameters {
+		if value, ok := env.Get(param.Name.Value); ok {
+			if comp, ok := value.Value.(*ComponentInstance); ok {
+				queue = append(queue, pending{comp, param.Name.Value})
+			}
+		}
+	}
+	for len(queue) > 0 {
+		next := queue[0]
+		queue = queue[1:]
+		if next.comp.instanceID != "" {
+			continue
+		}
+		next.comp.instanceID = next.path
+		deps, _ := next.comp.ComponentDecl.Dependencies()
+		for _, dep := range deps {
+			if value, ok := next.comp.Get(dep.Name.Value); ok && !value.IsNil() {
+				if child, ok := value.Value.(*ComponentInstance); ok {
+					queue = append(queue, pending{child, next.path + "." + dep.Name.Value})
+				}
+			}
+		}
+	}
+}
+
 // UpdateMethodArrivalRate updates the arrival rate for a specific method on a component
 // and triggers FlowEval to recompute downstream effects.
 func (si *SystemInstance) UpdateMethodArrivalRate(componentName, methodName string, rate float64) error {
@@ -317,3 +377,83 @@ func (si *SystemInstance) AllComponents() []*ComponentInstance {
 
 	return components
 }
+
+// HasRunState returns true if any component reachable from the system's
+// parameters has state that calls can change (see ComponentInstance.HasRunState),
+// so repeating a seeded run need not give the same results.
+func (s *SystemInstance) HasRunState() bool {
+	if s.Env == nil {
+		return false
+	}
+	var queue []*ComponentInstance
+	for _, param := range s.System.Parameters {
+		if value, ok := s.Env.Get(param.Name.Value); ok {
+			if comp, ok := value.Value.(*ComponentInstance); ok {
+				queue = append(queue, comp)
+			}
+		}
+	}
+	seen := map[*ComponentInstance]bool{}
+	for len(queue) > 0 {
+		comp := queue[0]
+		queue = queue[1:]
+		if seen[comp] {
+			continue
+		}
+		seen[comp] = true
+		if comp.HasRunState() {
+			return true
+		}
+		deps, _ := comp.ComponentDecl.Dependencies()
+		for _, dep := range deps {
+			if value, ok := comp.Get(dep.Name.Value); ok && !value.IsNil() {
+				if child, ok := value.Value.(*ComponentInstance); ok {
+					queue = append(queue, child)
+				}
+			}
+		}
+	}
+	return false
+}
+
+// ArrivalRates returns the current arrival rate of each method of every
+// component reachable from the system's parameters.  Components that do not
+// track arrival rates are left out.
+func (s *SystemInstance) ArrivalRates() RateMap {
+	rates := NewRateMap()
+	if s.Env == nil {
+		return rates
+	}
+	var queue []*ComponentInstance
+	for _, param := range s.System.Parameters {
+		if value, ok := s.Env.Get(param.Name.Value); ok {
+			if comp, ok := value.Value.(*ComponentInstance); ok {
+				queue = append(queue, comp)
+			}
+		}
+	}
+	seen := map[*ComponentInstance]bool{}
+	for len(queue) > 0 {
+		comp := queue[0]
+		queue = queue[1:]
+		if seen[comp] {
+			continue
+		}
+		seen[comp] = true
+		methods, _ := comp.ComponentDecl.Methods()
+		for name := range methods {
+			if rate := comp.GetArrivalRate(name); rate >= 0 {
+				rates.SetRate(comp, name, rate)
+			}
+		}
+		deps, _ := comp.ComponentDecl.Dependencies()
+		for _, dep := range deps {
+			if value, ok := comp.Get(dep.Name.Value); ok && !value.IsNil() {
+				if child, ok := value.Value.(*ComponentInstance); ok {
+					queue = append(queue, child)
+				}
+			}
+		}
+	}
+	return rates
+}
@@ -1,6 +1,11 @@
 package runtime
 
 import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+
 	"github.com/panyam/sdl/lib/components"
 )
 
@@ -44,3 +49,72 @@ func GetSystemUtilization(sys *SystemInstance) []*ComponentUtilization {
 	}
 	return results
 }
+
+// Saturation describes a component whose offered load exceeds the rate it can
+// serve, so its queue grows without bound and any finite latency is misleading.
+type Saturation struct {
+	Component   string  // Path of the saturated component, eg "server.pool"
+	Resource    string  // Resource that is saturated, eg "pool"
+	ArrivalRate float64 // λ: calls per second offered
+	ServiceRate float64 // µ: calls per second the component can serve
+	Utilization float64 // λ / µ
+}
+
+func (s *Saturation) String() string {
+	return fmt.Sprintf("system saturated at component %s (λ=%.4g/s µ=%.4g/s)", s.Component, s.ArrivalRate, s.ServiceRate)
+}
+
+// FindSaturation returns the components of a system whose utilization is at
+// least 1, the bottleneck (highest utilization) first.  Arrival rates must
+// already be applied to the components, eg by evaluating flows.
+func FindSaturation(sys *SystemInstance) (out []*Saturation) {
+	if sys == nil || sys.Env == nil {
+		return nil
+	}
+	visited := map[*ComponentInstance]bool{}
+	var visit func(path string, comp *ComponentInstance)
+	visit = func(path string, comp *ComponentInstance) {
+		if visited[comp] {
+			return
+		}
+		visited[comp] = true
+		if comp.IsNative {
+			provider, ok := comp.NativeInstance.(components.UtilizationProvider)
+			if !ok {
+				return
+			}
+			for _, info := range provider.GetUtilizationInfo() {
+				if info.Utilization >= 1 && info.CurrentLoad > 0 {
+					out = append(out, &Saturation{
+						Component:   path,
+						Resource:    info.ResourceName,
+						ArrivalRate: info.CurrentLoad,
+						ServiceRate: info.CurrentLoad / info.Utilization,
+						Utilization: info.Utilization,
+					})
+				}
+			}
+			return
+		}
+		deps, _ := comp.ComponentDecl.Dependencies()
+		for _, dep := range deps {
+			if binding, ok := comp.Env.Get(dep.Name.Value); ok {
+				if child, ok := binding.Value.(*ComponentInstance); ok && child != nil {
+					visit(path+"."+dep.Name.Value, child)
+				}
+			}
+		}
+	}
+	for varName, value := range sys.Env.All() {
+		if varName == "self" {
+			continue
+		}
+		if comp, ok := value.Value.(*ComponentInstance); ok && comp != nil {
+			visit(varName, comp)
+		}
+	}
+	slices.SortFunc(out, func(a, b *Saturation) int {
+		return cmp.Or(cmp.Compare(b.Utilization, a.Utilization), strings.Compare(a.Component, b.Component))
+	})
+	return
+}
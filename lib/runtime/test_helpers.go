@@ -44,7 +44,7 @@ func newTestLoader() *loader.Loader {
 		defaultResolver: loader.NewDefaultFileResolver(),
 		fsResolver:      loader.NewFileSystemResolver(cfs),
 	}
-	return loader.NewLoader(nil, resolver, 10)
+	return loader.NewLoader(nil, resolver, loader.DefaultMaxImportDepth)
 }
 
 // parseAndLoad parses inline SDL content, loads the first system, and returns it.
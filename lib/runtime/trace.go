@@ -19,16 +19,18 @@ type TraceEvent struct {
 	Kind         TraceEventKind     `json:"kind"`
 	ParentID     int64              `json:"parent_id,omitempty"`
 	ID           int64              `json:"id"`
-	Timestamp    core.Duration      `json:"ts"`            // Virtual time in simulation
-	Duration     core.Duration      `json:"dur,omitempty"` // Duration in virtual time
-	Component    *ComponentInstance `json:"-"`             // Component instance (nil for native/global methods)
-	Method       *MethodDecl        `json:"-"`             // Method declaration
+	EnterID      int64              `json:"enter_id,omitempty"` // For exits, the ID of the enter event being closed
+	Timestamp    core.Duration      `json:"ts"`                 // Virtual time in simulation
+	Duration     core.Duration      `json:"dur,omitempty"`      // Duration in virtual time
+	Component    *ComponentInstance `json:"-"`                  // Component instance (nil for native/global methods)
+	Method       *MethodDecl        `json:"-"`                  // Method declaration
 	Arguments    []string           `json:"args,omitempty"`
 	ReturnValue  string             `json:"ret,omitempty"`
 	ErrorMessage string             `json:"err,omitempty"`
 	// Computed fields for JSON serialization
 	ComponentName string `json:"component,omitempty"`
 	MethodName    string `json:"method,omitempty"`
+	InstanceID    string `json:"instance,omitempty"` // Component's path in the system, eg app.cache
 }
 
 // GetComponentName returns the component name for metrics/display
@@ -39,6 +41,14 @@ func (e *TraceEvent) GetComponentName() string {
 	return ""
 }
 
+// GetInstanceID returns the component's InstanceID or "" for native/global methods
+func (e *TraceEvent) GetInstanceID() string {
+	if e.Component != nil {
+		return e.Component.InstanceID()
+	}
+	return ""
+}
+
 // GetMethodName returns the method name for metrics/display
 func (e *TraceEvent) GetMethodName() string {
 	if e.Method != nil {
@@ -0,0 +1,153 @@
+package runtime
+
+import (
+	"github.com/panyam/sdl/lib/core"
+)
+
+// TraceDiff aligns two traces of the same call by structure so a change in
+// latency can be pinned to the calls it came from.  Calls made in only one of
+// the traces (eg a different branch of a distribute was sampled) are kept as
+// one sided nodes instead of failing the diff.
+type TraceDiff struct {
+	Before *TraceTree
+	After  *TraceTree
+	Roots  []*TraceDiffNode
+	Delta  core.Duration // Change in the total latency of the roots
+}
+
+// TraceDiffNode pairs a call in the before trace with the matching call in
+// the after trace.  Before or After is nil when the call only happened in
+// the other trace.
+type TraceDiffNode struct {
+	Kind      TraceEventKind
+	Component string
+	Method    string
+	Before    *TraceTreeNode
+	After     *TraceTreeNode
+	Delta     core.Duration // Change in total latency
+	SelfDelta core.Duration // Change in latency not spent in child calls
+	Children  []*TraceDiffNode
+}
+
+// Mismatched is true when the call only happened in one of the traces.
+func (n *TraceDiffNode) Mismatched() bool {
+	return n.Before == nil || n.After == nil
+}
+
+// BranchChanged is true when the call happened in both traces but returned a
+// different sampled outcome.
+func (n *TraceDiffNode) BranchChanged() bool {
+	return !n.Mismatched() && n.Before.Branch != n.After.Branch
+}
+
+// DiffTraces aligns the calls of two traces.  Children are matched by kind,
+// component and method in the order they were made, keeping the longest
+// common sequence, so an extra or missing call does not misalign its
+// siblings.
+func DiffTraces(before, after *TraceTree) *TraceDiff {
+	diff := &TraceDiff{Before: before, After: after}
+	diff.Roots = diffTraceNodes(before.Roots, after.Roots)
+	for _, root := range diff.Roots {
+		diff.Delta += root.Delta
+	}
+	return diff
+}
+
+// Walk visits every node in pre-order along with its depth.
+func (d *TraceDiff) Walk(visit func(node *TraceDiffNode, depth int)) {
+	var walk func(nodes []*TraceDiffNode, depth int)
+	walk = func(nodes []*TraceDiffNode, depth int) {
+		for _, node := range nodes {
+			visit(node, depth)
+			walk(node.Children, depth+1)
+		}
+	}
+	walk(d.Roots, 0)
+}
+
+// Mismatches returns the outermost calls that only happened in one trace.
+// Their children are necessarily one sided too and are not repeated.
+func (d *TraceDiff) Mismatches() (out []*TraceDiffNode) {
+	var walk func(nodes []*TraceDiffNode)
+	walk = func(nodes []*TraceDiffNode) {
+		for _, node := range nodes {
+			if node.Mismatched() {
+				out = append(out, node)
+			} else {
+				walk(node.Children)
+			}
+		}
+	}
+	walk(d.Roots)
+	return
+}
+
+// LargestIncrease returns the call present in both traces whose self latency
+// grew the most, or nil if none grew.
+func (d *TraceDiff) LargestIncrease() (largest *TraceDiffNode) {
+	d.Walk(func(node *TraceDiffNode, depth int) {
+		if !node.Mismatched() && node.SelfDelta > 0 && (largest == nil || node.SelfDelta > largest.SelfDelta) {
+			largest = node
+		}
+	})
+	return
+}
+
+func diffTraceNodes(before, after []*TraceTreeNode) (out []*TraceDiffNode) {
+	// lcs[i][j] is the longest common sequence of before[i:] and after[j:]
+	lcs := make([][]int, len(before)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(after)+1)
+	}
+	for i := len(before) - 1; i >= 0; i-- {
+		for j := len(after) - 1; j >= 0; j-- {
+			if sameCall(before[i], after[j]) {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < len(before) || j < len(after) {
+		switch {
+		case i < len(before) && j < len(after) && sameCall(before[i], after[j]):
+			out = append(out, newTraceDiffNode(before[i], after[j]))
+			i++
+			j++
+		case j == len(after) || (i < len(before) && lcs[i+1][j] >= lcs[i][j+1]):
+			out = append(out, newTraceDiffNode(before[i], nil))
+			i++
+		default:
+			out = append(out, newTraceDiffNode(nil, after[j]))
+			j++
+		}
+	}
+	return
+}
+
+func newTraceDiffNode(before, after *TraceTreeNode) *TraceDiffNode {
+	node := &TraceDiffNode{Before: before, After: after}
+	var beforeChildren, afterChildren []*TraceTreeNode
+	if before != nil {
+		node.Kind, node.Component, node.Method = before.Kind, before.Component, before.Method
+		node.Delta -= before.TotalLatency
+		node.SelfDelta -= before.SelfLatency
+		beforeChildren = before.Children
+	}
+	if after != nil {
+		node.Kind, node.Component, node.Method = after.Kind, after.Component, after.Method
+		node.Delta += after.TotalLatency
+		node.SelfDelta += after.SelfLatency
+		afterChildren = after.Children
+	}
+	node.Children = diffTraceNodes(beforeChildren, afterChildren)
+	return node
+}
+
+func sameCall(a, b *TraceTreeNode) bool {
+	return a.Kind == b.Kind && a.Component == b.Component && a.Method == b.Method
+}
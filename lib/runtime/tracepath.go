@@ -0,0 +1,165 @@
+package runtime
+
+import (
+	"fmt"
+	"strings"
+)
+
+// TracePath is a parsed path spec that a trace can be checked against.  A
+// spec is a chain of calls joined by operators:
+//
+//	HandleLookup > cache.Get -> db.Query
+//
+// A call is a method name, optionally qualified by a component type or the
+// trailing part of an instance path (eg `Cache.Get` or `cache.Get`) and
+// optionally followed by the value it returned (eg `cache.Get=true`).
+//
+//   - `a > b` holds when a call of b is made somewhere inside a call of a.
+//   - `a -> b` holds when a call of b is made after a call of a returned,
+//     within the call the chain was last inside of (or anywhere after it
+//     when there is none).
+//
+// A path matches a trace if some calls of the trace satisfy the whole chain.
+type TracePath struct {
+	steps []tracePathStep
+}
+
+type tracePathStep struct {
+	target string // Component type or instance path suffix, empty for any
+	method string
+	branch string // Value returned by the call, empty for any
+	after  bool   // Joined to the previous step with -> rather than >
+}
+
+// ParseTracePath parses a path spec, see TracePath.
+func ParseTracePath(spec string) (*TracePath, error) {
+	path := &TracePath{}
+	after := false
+	for {
+		idx := strings.Index(spec, ">")
+		text, nextAfter := spec, false
+		if idx >= 0 {
+			text = spec[:idx]
+			if strings.HasSuffix(text, "-") {
+				text, nextAfter = text[:len(text)-1], true
+			}
+		}
+		step, err := parseTracePathStep(strings.TrimSpace(text))
+		if err != nil {
+			return nil, err
+		}
+		step.after = after
+		path.steps = append(path.steps, step)
+		if idx < 0 {
+			return path, nil
+		}
+		spec, after = spec[idx+1:], nextAfter
+	}
+}
+
+func parseTracePathStep(text string) (step tracePathStep, err error) {
+	call, branch, hasBranch := strings.Cut(text, "=")
+	if hasBranch {
+		if step.branch = strings.TrimSpace(branch); step.branch == "" {
+			return step, fmt.Errorf("missing value after '=' in trace path step '%s'", text)
+		}
+	}
+	call = strings.TrimSpace(call)
+	if idx := strings.LastIndex(call, "."); idx >= 0 {
+		step.target, step.method = call[:idx], call[idx+1:]
+	} else {
+		step.method = call
+	}
+	if step.method == "" || strings.ContainsAny(call, " \t") || (step.target == "" && strings.Contains(call, ".")) {
+		return step, fmt.Errorf("invalid trace path step '%s', expected a call such as db.Query", text)
+	}
+	return step, nil
+}
+
+// String returns the path in its canonical spec form.
+func (p *TracePath) String() string {
+	var sb strings.Builder
+	for idx, step := range p.steps {
+		if idx > 0 {
+			if step.after {
+				sb.WriteString(" -> ")
+			} else {
+				sb.WriteString(" > ")
+			}
+		}
+		if step.target != "" {
+			sb.WriteString(step.target + ".")
+		}
+		sb.WriteString(step.method)
+		if step.branch != "" {
+			sb.WriteString("=" + step.branch)
+		}
+	}
+	return sb.String()
+}
+
+// Match reports whether some calls of the tree satisfy the path.
+func (p *TracePath) Match(tree *TraceTree) bool {
+	// Flatten in pre-order so a node's subtree is the range up to ends[i]
+	var nodes []*TraceTreeNode
+	var ends []int
+	var flatten func(children []*TraceTreeNode)
+	flatten = func(children []*TraceTreeNode) {
+		for _, node := range children {
+			idx := len(nodes)
+			nodes = append(nodes, node)
+			ends = append(ends, 0)
+			flatten(node.Children)
+			ends[idx] = len(nodes)
+		}
+	}
+	flatten(tree.Roots)
+
+	var match func(step, lo, hi, scopeEnd int) bool
+	match = func(step, lo, hi, scopeEnd int) bool {
+		for i := lo; i < hi; i++ {
+			if !p.steps[step].matches(nodes[i]) {
+				continue
+			}
+			if step == len(p.steps)-1 {
+				return true
+			}
+			if p.steps[step+1].after {
+				if match(step+1, ends[i], scopeEnd, scopeEnd) {
+					return true
+				}
+			} else if match(step+1, i+1, ends[i], ends[i]) {
+				return true
+			}
+		}
+		return false
+	}
+	return match(0, 0, len(nodes), len(nodes))
+}
+
+func (s tracePathStep) matches(node *TraceTreeNode) bool {
+	if node.Kind != EventEnter || node.Method != s.method {
+		return false
+	}
+	if s.target != "" && s.target != node.Component && node.Instance != s.target && !strings.HasSuffix(node.Instance, "."+s.target) {
+		return false
+	}
+	return s.branch == "" || s.branch == node.Branch || s.branch == branchValue(node.Branch)
+}
+
+// branchValue returns the value of a returned value string, eg "true" for
+// "RV(bool: true)".
+func branchValue(branch string) string {
+	if _, value, found := strings.Cut(strings.TrimSuffix(branch, ")"), ": "); found {
+		return value
+	}
+	return branch
+}
+
+// Matches reports whether some calls of the tree satisfy the path spec, see
+// TracePath.  Specs that do not parse never match, use ParseTracePath to
+// check them.
+func (t *TraceTree) Matches(pathSpec string) bool {
+	path, err := ParseTracePath(pathSpec)
+	return err == nil && path.Match(t)
+}
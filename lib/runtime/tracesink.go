@@ -0,0 +1,155 @@
+package runtime
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+
+	protos "github.com/panyam/sdl/gen/go/sdl/v1/models"
+)
+
+// TraceSink is where trace events go as they are recorded, so collecting a
+// trace is decoupled from keeping it.  Write is called in event order.
+type TraceSink interface {
+	Write(event *TraceEvent) error
+	Close() error
+}
+
+// MemoryTraceSink keeps every event in memory.
+type MemoryTraceSink struct {
+	mu     sync.Mutex
+	Events []*TraceEvent
+}
+
+func NewMemoryTraceSink() *MemoryTraceSink {
+	return &MemoryTraceSink{}
+}
+
+func (s *MemoryTraceSink) Write(event *TraceEvent) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.Events = append(s.Events, event)
+	return nil
+}
+
+func (s *MemoryTraceSink) Close() error { return nil }
+
+// FileTraceSink writes events to a file as newline delimited JSON.  Once the
+// file would grow past MaxBytes it is rotated to path.1 (and path.1 to
+// path.2 and so on) keeping at most MaxBackups old files.
+type FileTraceSink struct {
+	Path       string
+	MaxBytes   int64 // 0 for no rotation
+	MaxBackups int
+
+	mu   sync.Mutex
+	file *os.File
+	size int64
+}
+
+// NewFileTraceSink creates (or truncates) the file at path.
+func NewFileTraceSink(path string, maxBytes int64, maxBackups int) (*FileTraceSink, error) {
+	s := &FileTraceSink{Path: path, MaxBytes: maxBytes, MaxBackups: maxBackups}
+	if err := s.open(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *FileTraceSink) open() (err error) {
+	s.file, err = os.Create(s.Path)
+	s.size = 0
+	return
+}
+
+func (s *FileTraceSink) Write(event *TraceEvent) error {
+	line, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.file == nil {
+		return fmt.Errorf("trace sink %s is closed", s.Path)
+	}
+	if s.MaxBytes > 0 && s.size > 0 && s.size+int64(len(line)) > s.MaxBytes {
+		if err := s.rotate(); err != nil {
+			return err
+		}
+	}
+	n, err := s.file.Write(line)
+	s.size += int64(n)
+	return err
+}
+
+func (s *FileTraceSink) rotate() error {
+	if err := s.file.Close(); err != nil {
+		return err
+	}
+	if s.MaxBackups > 0 {
+		for i := s.MaxBackups - 1; i > 0; i-- {
+			os.Rename(fmt.Sprintf("%s.%d", s.Path, i), fmt.Sprintf("%s.%d", s.Path, i+1))
+		}
+		if err := os.Rename(s.Path, s.Path+".1"); err != nil {
+			return err
+		}
+	}
+	return s.open()
+}
+
+func (s *FileTraceSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.file == nil {
+		return nil
+	}
+	err := s.file.Close()
+	s.file = nil
+	return err
+}
+
+// TraceEventSender is satisfied by the server side of a gRPC stream of trace
+// events.
+type TraceEventSender interface {
+	Send(*protos.TraceEvent) error
+}
+
+// StreamTraceSink forwards each event to a gRPC stream.  Closing the sink
+// does not end the stream, that happens when the handler returns.
+type StreamTraceSink struct {
+	stream TraceEventSender
+}
+
+func NewStreamTraceSink(stream TraceEventSender) *StreamTraceSink {
+	return &StreamTraceSink{stream: stream}
+}
+
+func (s *StreamTraceSink) Write(event *TraceEvent) error {
+	return s.stream.Send(event.ToProto())
+}
+
+func (s *StreamTraceSink) Close() error { return nil }
+
+// ToProto converts the event to its proto form.  Component is the instance ID
+// when the event has an instance and the recorded name otherwise.
+func (e *TraceEvent) ToProto() *protos.TraceEvent {
+	te := &protos.TraceEvent{
+		Kind:         string(e.Kind),
+		Id:           e.ID,
+		ParentId:     e.ParentID,
+		Timestamp:    float64(e.Timestamp),
+		Duration:     float64(e.Duration),
+		Args:         e.Arguments,
+		ReturnValue:  e.ReturnValue,
+		ErrorMessage: e.ErrorMessage,
+		Component:    e.ComponentName,
+		Method:       e.MethodName,
+	}
+	if e.Component != nil {
+		te.Component = e.Component.ID()
+	}
+	return te
+}
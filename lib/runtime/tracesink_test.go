@@ -0,0 +1,85 @@
+package runtime
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// readNDJSONEvents reads back the events written by a FileTraceSink.
+func readNDJSONEvents(t *testing.T, path string) (events []*TraceEvent) {
+	t.Helper()
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var event TraceEvent
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &event), "each line should be a JSON event")
+		events = append(events, &event)
+	}
+	require.NoError(t, scanner.Err())
+	return
+}
+
+// TestFileTraceSinkNDJSON verifies that a traced call written to a file sink
+// reads back as one JSON event per line, in order, and that the file is
+// rotated once it outgrows its size limit.
+func TestFileTraceSinkNDJSON(t *testing.T) {
+	sys := parseAndLoad(t, `
+import delay from "@stdlib/common.sdl"
+
+component DB {
+  method Query() Bool {
+    delay(5ms)
+    return true
+  }
+}
+component App {
+  uses db DB()
+  method Serve() Bool {
+    return self.db.Query()
+  }
+}
+component Arch { uses app App() }
+system Traced(arch Arch) { }
+`)
+	data := traceCall(t, sys, "arch.app.Serve")
+	path := filepath.Join(t.TempDir(), "trace.ndjson")
+
+	sink, err := NewFileTraceSink(path, 0, 0)
+	require.NoError(t, err)
+	for _, event := range data.Events {
+		require.NoError(t, sink.Write(event))
+	}
+	require.NoError(t, sink.Close())
+
+	events := readNDJSONEvents(t, path)
+	require.Len(t, events, len(data.Events))
+	for i, event := range events {
+		assert.Equal(t, data.Events[i].ID, event.ID)
+		assert.Equal(t, data.Events[i].Kind, event.Kind)
+		assert.Equal(t, data.Events[i].MethodName, event.MethodName)
+		assert.Equal(t, data.Events[i].Timestamp, event.Timestamp)
+	}
+	assert.Equal(t, "Serve", events[0].MethodName)
+
+	// A limit smaller than two events forces a rotation on every write
+	line, _ := json.Marshal(data.Events[0])
+	sink, err = NewFileTraceSink(path, int64(len(line)+1), 2)
+	require.NoError(t, err)
+	for _, event := range data.Events[:3] {
+		require.NoError(t, sink.Write(event))
+	}
+	require.NoError(t, sink.Close())
+	assert.Len(t, readNDJSONEvents(t, path), 1)
+	assert.Equal(t, data.Events[1].ID, readNDJSONEvents(t, path+".1")[0].ID)
+	assert.Equal(t, data.Events[0].ID, readNDJSONEvents(t, path+".2")[0].ID)
+	assert.NoFileExists(t, path+".3")
+	assert.Error(t, sink.Write(data.Events[0]), "closed sinks reject writes")
+}
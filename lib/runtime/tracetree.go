@@ -0,0 +1,254 @@
+package runtime
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	protos "github.com/panyam/sdl/gen/go/sdl/v1/models"
+	"github.com/panyam/sdl/lib/core"
+)
+
+// TraceExportSchemaVersion is the version of the exported trace JSON schema.
+// Bump it whenever fields are renamed, removed or change meaning.
+const TraceExportSchemaVersion = 1
+
+// TraceTree is the call structure of a single traced run, built from the flat
+// enter/exit events of a TraceData.
+//
+// Exported JSON (schema_version 1) is a single object:
+//
+//	{
+//	  "schema_version": 1,
+//	  "system": "...",
+//	  "entry_point": "comp.Method",
+//	  "nodes": [ TraceTreeNode, ... ]
+//	}
+//
+// Nodes are flat and in pre-order (a parent always precedes its children) and
+// are linked through parent_id, with 0 meaning a root.  All times are in
+// simulated seconds.  The flat layout lets large traces be written and read
+// one node at a time.
+type TraceTree struct {
+	SchemaVersion int              `json:"schema_version"`
+	System        string           `json:"system"`
+	EntryPoint    string           `json:"entry_point"`
+	Roots         []*TraceTreeNode `json:"-"`
+}
+
+// TraceTreeNode is a single call (or go/wait event) in a TraceTree.
+type TraceTreeNode struct {
+	ID           int64          `json:"id"`
+	ParentID     int64          `json:"parent_id"`
+	Kind         TraceEventKind `json:"kind"`
+	Component    string         `json:"component,omitempty"`
+	Instance     string         `json:"instance,omitempty"`
+	Method       string         `json:"method,omitempty"`
+	Start        core.Duration  `json:"start"`            // Simulated time the call started
+	End          core.Duration  `json:"end"`              // Simulated time the call returned
+	TotalLatency core.Duration  `json:"total_latency"`    // End - Start
+	SelfLatency  core.Duration  `json:"self_latency"`     // Total latency not spent in child calls
+	Branch       string         `json:"branch,omitempty"` // Sampled outcome returned by the call
+	Args         []string       `json:"args,omitempty"`
+	Error        string         `json:"error,omitempty"`
+
+	Children []*TraceTreeNode `json:"-"`
+}
+
+// BuildTraceTree pairs the enter and exit events of a trace into a tree of calls.
+// An exit is matched with the enter it closes through its EnterID, so
+// overlapping calls to the same method (eg from go blocks) are not confused.
+func BuildTraceTree(data *TraceData) *TraceTree {
+	tree := &TraceTree{
+		SchemaVersion: TraceExportSchemaVersion,
+		System:        data.System,
+		EntryPoint:    data.EntryPoint,
+	}
+
+	nodes := map[int64]*TraceTreeNode{}
+	for _, event := range data.Events {
+		if event.Kind == EventExit {
+			if node := nodes[event.EnterID]; node != nil && node.Kind == EventEnter {
+				node.End = event.Timestamp
+				node.TotalLatency = event.Duration
+				node.Branch = event.ReturnValue
+				node.Error = event.ErrorMessage
+			}
+			continue
+		}
+
+		node := &TraceTreeNode{
+			ID:        event.ID,
+			ParentID:  event.ParentID,
+			Kind:      event.Kind,
+			Component: event.ComponentName,
+			Instance:  event.InstanceID,
+			Method:    event.MethodName,
+			Start:     event.Timestamp,
+			End:       event.Timestamp,
+		}
+		if len(event.Arguments) > 0 {
+			node.Args = event.Arguments
+		}
+		nodes[node.ID] = node
+		if parent := nodes[node.ParentID]; parent != nil {
+			parent.Children = append(parent.Children, node)
+		} else {
+			tree.Roots = append(tree.Roots, node)
+		}
+	}
+
+	tree.Walk(func(node *TraceTreeNode) {
+		node.SelfLatency = node.TotalLatency
+		for _, child := range node.Children {
+			node.SelfLatency -= child.TotalLatency
+		}
+		// Concurrent (go) children may overlap their parent
+		node.SelfLatency = max(node.SelfLatency, 0)
+	})
+	return tree
+}
+
+// Walk visits every node in pre-order.
+func (t *TraceTree) Walk(visit func(node *TraceTreeNode)) {
+	var walk func(nodes []*TraceTreeNode)
+	walk = func(nodes []*TraceTreeNode) {
+		for _, node := range nodes {
+			visit(node)
+			walk(node.Children)
+		}
+	}
+	walk(t.Roots)
+}
+
+// WriteJSON streams the tree to w, encoding one node at a time.
+func (t *TraceTree) WriteJSON(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	header, err := json.Marshal(t)
+	if err != nil {
+		return err
+	}
+	// Splice the nodes array into the header object
+	if _, err := bw.Write(header[:len(header)-1]); err != nil {
+		return err
+	}
+	if _, err := bw.WriteString(`,"nodes":[`); err != nil {
+		return err
+	}
+
+	first := true
+	enc := json.NewEncoder(bw)
+	t.Walk(func(node *TraceTreeNode) {
+		if err != nil {
+			return
+		}
+		if !first {
+			if _, err = bw.WriteString(","); err != nil {
+				return
+			}
+		}
+		first = false
+		err = enc.Encode(node)
+	})
+	if err != nil {
+		return err
+	}
+	if _, err := bw.WriteString("]}\n"); err != nil {
+		return err
+	}
+	return bw.Flush()
+}
+
+// ReadTraceTreeJSON decodes a tree written by WriteJSON, reading nodes one at a time.
+func ReadTraceTreeJSON(r io.Reader) (*TraceTree, error) {
+	dec := json.NewDecoder(bufio.NewReader(r))
+	if err := expectDelim(dec, '{'); err != nil {
+		return nil, err
+	}
+
+	tree := &TraceTree{}
+	nodes := map[int64]*TraceTreeNode{}
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		switch key := tok.(string); key {
+		case "schema_version":
+			err = dec.Decode(&tree.SchemaVersion)
+		case "system":
+			err = dec.Decode(&tree.System)
+		case "entry_point":
+			err = dec.Decode(&tree.EntryPoint)
+		case "nodes":
+			err = readTraceNodes(dec, tree, nodes)
+		default:
+			var skip json.RawMessage
+			err = dec.Decode(&skip)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := expectDelim(dec, '}'); err != nil {
+		return nil, err
+	}
+	if tree.SchemaVersion != TraceExportSchemaVersion {
+		return nil, fmt.Errorf("unsupported trace schema version %d, expected %d", tree.SchemaVersion, TraceExportSchemaVersion)
+	}
+	return tree, nil
+}
+
+func readTraceNodes(dec *json.Decoder, tree *TraceTree, nodes map[int64]*TraceTreeNode) error {
+	if err := expectDelim(dec, '['); err != nil {
+		return err
+	}
+	for dec.More() {
+		node := &TraceTreeNode{}
+		if err := dec.Decode(node); err != nil {
+			return err
+		}
+		nodes[node.ID] = node
+		if node.ParentID == 0 {
+			tree.Roots = append(tree.Roots, node)
+		} else if parent := nodes[node.ParentID]; parent != nil {
+			parent.Children = append(parent.Children, node)
+		} else {
+			return fmt.Errorf("trace node %d appears before its parent %d", node.ID, node.ParentID)
+		}
+	}
+	return expectDelim(dec, ']')
+}
+
+func expectDelim(dec *json.Decoder, delim json.Delim) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if d, ok := tok.(json.Delim); !ok || d != delim {
+		return fmt.Errorf("invalid trace JSON: expected '%v', found '%v'", delim, tok)
+	}
+	return nil
+}
+
+// TraceDataFromProto converts a proto trace back into TraceData.  Component and
+// Method declarations are not available so only their names are populated.
+func TraceDataFromProto(td *protos.TraceData) *TraceData {
+	data := &TraceData{System: td.System, EntryPoint: td.EntryPoint}
+	for _, evt := range td.Events {
+		data.Events = append(data.Events, &TraceEvent{
+			Kind:          TraceEventKind(evt.Kind),
+			ID:            evt.Id,
+			ParentID:      evt.ParentId,
+			Timestamp:     evt.Timestamp,
+			Duration:      evt.Duration,
+			Arguments:     evt.Args,
+			ReturnValue:   evt.ReturnValue,
+			ErrorMessage:  evt.ErrorMessage,
+			ComponentName: evt.Component,
+			MethodName:    evt.Method,
+		})
+	}
+	return data
+}
@@ -0,0 +1,245 @@
+package runtime
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/panyam/sdl/lib/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// traceCall runs a single traced call of target (eg "arch.app.Serve") on the system.
+func traceCall(t *testing.T, sys *SystemInstance, target string) *TraceData {
+	t.Helper()
+	tracer := NewExecutionTracer()
+	eval := NewSimpleEval(sys.File, tracer)
+	var currTime core.Duration
+	eval.Eval(&CallExpr{Function: buildMemberAccessExpr(strings.Split(target, "."))}, sys.Env.Push(), &currTime)
+	require.NotEmpty(t, tracer.Events)
+	return &TraceData{System: sys.GetSystemName(), EntryPoint: target, Events: tracer.Events}
+}
+
+// TestTraceTreeJSONRoundTrip verifies that a trace exported to JSON and read
+// back yields an equivalent tree with parent links and latencies intact.
+func TestTraceTreeJSONRoundTrip(t *testing.T) {
+	sys := parseAndLoad(t, `
+import delay from "@stdlib/common.sdl"
+
+component DB {
+  method Query() Bool {
+    delay(5ms)
+    return true
+  }
+}
+component App {
+  uses db DB()
+  method Serve() Bool {
+    delay(1ms)
+    self.db.Query()
+    return self.db.Query()
+  }
+}
+component Arch { uses app App() }
+system Traced(arch Arch) { }
+`)
+	tree := BuildTraceTree(traceCall(t, sys, "arch.app.Serve"))
+	require.Len(t, tree.Roots, 1)
+	root := tree.Roots[0]
+	assert.Equal(t, "Serve", root.Method)
+	// The native delay call followed by the two queries
+	require.Len(t, root.Children, 3)
+	assert.Equal(t, "delay", root.Children[0].Method)
+	assert.InDelta(t, 0.001, root.Children[0].TotalLatency, 1e-9)
+	for _, child := range root.Children[1:] {
+		assert.Equal(t, root.ID, child.ParentID)
+		assert.Equal(t, "Query", child.Method)
+		assert.Contains(t, child.Branch, "true")
+		assert.InDelta(t, 0.005, child.TotalLatency, 1e-9)
+	}
+	assert.InDelta(t, 0.011, root.TotalLatency, 1e-9)
+	assert.InDelta(t, 0.0, root.SelfLatency, 1e-9, "all of Serve's time is spent in its calls")
+
+	var buf bytes.Buffer
+	require.NoError(t, tree.WriteJSON(&buf))
+	assert.True(t, json.Valid(buf.Bytes()), "export should be a single JSON document")
+	assert.Contains(t, buf.String(), `"schema_version":1`)
+
+	decoded, err := ReadTraceTreeJSON(&buf)
+	require.NoError(t, err)
+	assert.Equal(t, tree, decoded)
+}
+
+// TestTraceTreeOverlappingCalls verifies that exits are paired with the
+// enter they close even when calls to the same method overlap.
+func TestTraceTreeOverlappingCalls(t *testing.T) {
+	data := &TraceData{System: "S", EntryPoint: "app.Serve", Events: []*TraceEvent{
+		{Kind: EventEnter, ID: 1, ComponentName: "App", MethodName: "Serve", Timestamp: 0},
+		{Kind: EventEnter, ID: 2, ComponentName: "App", MethodName: "Serve", Timestamp: 0.001},
+		{Kind: EventExit, ID: 3, EnterID: 1, ComponentName: "App", MethodName: "Serve", Timestamp: 0.003, Duration: 0.003, ReturnValue: "first"},
+		{Kind: EventExit, ID: 4, EnterID: 2, ComponentName: "App", MethodName: "Serve", Timestamp: 0.005, Duration: 0.004, ReturnValue: "second"},
+	}}
+	tree := BuildTraceTree(data)
+	require.Len(t, tree.Roots, 2)
+	assert.Equal(t, "first", tree.Roots[0].Branch)
+	assert.InDelta(t, 0.003, tree.Roots[0].TotalLatency, 1e-9)
+	assert.Equal(t, "second", tree.Roots[1].Branch)
+	assert.InDelta(t, 0.004, tree.Roots[1].TotalLatency, 1e-9)
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write(p []byte) (int, error) { return 0, errors.New("disk full") }
+
+// TestTraceTreeWriteJSONError verifies that write failures are returned.
+func TestTraceTreeWriteJSONError(t *testing.T) {
+	tree := &TraceTree{SchemaVersion: TraceExportSchemaVersion, Roots: []*TraceTreeNode{{ID: 1, Kind: EventEnter, Method: "Serve"}}}
+	assert.ErrorContains(t, tree.WriteJSON(failingWriter{}), "disk full")
+}
+
+// TestTraceTreeJSONRejectsUnknownVersion verifies that traces with another
+// schema version are not silently misread.
+func TestTraceTreeJSONRejectsUnknownVersion(t *testing.T) {
+	_, err := ReadTraceTreeJSON(bytes.NewBufferString(`{"schema_version":99,"system":"S","entry_point":"a.B","nodes":[]}`))
+	assert.ErrorContains(t, err, "unsupported trace schema version 99")
+}
+
+// TestDiffTracesIsolatesSlowerCall verifies that when one call gets slower
+// the diff charges the added time to that call and finds no structural change.
+func TestDiffTracesIsolatesSlowerCall(t *testing.T) {
+	source := `
+import delay from "@stdlib/common.sdl"
+
+component Cache {
+  method Get() Bool {
+    delay(2ms)
+    return true
+  }
+}
+component DB {
+  method Query() Bool {
+    delay(%dms)
+    return true
+  }
+}
+component App {
+  uses cache Cache()
+  uses db DB()
+  method Serve() Bool {
+    self.cache.Get()
+    return self.db.Query()
+  }
+}
+component Arch { uses app App() }
+system Traced(arch Arch) { }
+`
+	before := BuildTraceTree(traceCall(t, parseAndLoad(t, fmt.Sprintf(source, 5)), "arch.app.Serve"))
+	after := BuildTraceTree(traceCall(t, parseAndLoad(t, fmt.Sprintf(source, 25)), "arch.app.Serve"))
+
+	diff := DiffTraces(before, after)
+	assert.InDelta(t, 0.020, diff.Delta, 1e-9)
+	assert.Empty(t, diff.Mismatches())
+
+	slowest := diff.LargestIncrease()
+	require.NotNil(t, slowest)
+	// The added time is spent in the delay made by Query
+	assert.Equal(t, "delay", slowest.Method)
+	assert.InDelta(t, 0.020, slowest.SelfDelta, 1e-9)
+
+	diff.Walk(func(node *TraceDiffNode, depth int) {
+		if node.Method == "Get" {
+			assert.InDelta(t, 0.0, node.Delta, 1e-9, "cache calls are unchanged")
+		}
+		if node.Method == "Query" {
+			assert.InDelta(t, 0.020, node.Delta, 1e-9)
+		}
+	})
+}
+
+// TestDiffTracesFlagsDifferentBranches verifies that calls made in only one
+// trace are reported as mismatches without misaligning their siblings.
+func TestDiffTracesFlagsDifferentBranches(t *testing.T) {
+	call := func(method string, latency core.Duration, children ...*TraceTreeNode) *TraceTreeNode {
+		return &TraceTreeNode{Kind: EventEnter, Component: "App", Method: method, TotalLatency: latency, SelfLatency: latency, Children: children}
+	}
+	before := &TraceTree{Roots: []*TraceTreeNode{call("Serve", 0.003, call("Auth", 0.001), call("Hit", 0.001), call("Log", 0.001))}}
+	after := &TraceTree{Roots: []*TraceTreeNode{call("Serve", 0.012, call("Auth", 0.001), call("Miss", 0.010), call("Log", 0.001))}}
+
+	diff := DiffTraces(before, after)
+	mismatches := diff.Mismatches()
+	require.Len(t, mismatches, 2)
+	assert.Equal(t, "Hit", mismatches[0].Method)
+	assert.Nil(t, mismatches[0].After)
+	assert.Equal(t, "Miss", mismatches[1].Method)
+	assert.Nil(t, mismatches[1].Before)
+	assert.InDelta(t, 0.010, mismatches[1].Delta, 1e-9)
+
+	children := diff.Roots[0].Children
+	require.Len(t, children, 4)
+	assert.Equal(t, "Log", children[3].Method)
+	assert.False(t, children[3].Mismatched(), "calls after the branch stay aligned")
+}
+
+const tracePathSource = `
+import delay from "@stdlib/common.sdl"
+
+component Cache {
+  method Get() Bool { return false }
+}
+component DB {
+  method Query() Bool {
+    delay(5ms)
+    return true
+  }
+}
+component App {
+  uses cache Cache()
+  uses db DB()
+  method Lookup() Bool {
+    if self.cache.Get() {
+      return true
+    }
+    return self.db.Query()
+  }
+  method Refresh() Bool {
+    self.db.Query()
+    return self.cache.Get()
+  }
+}
+component Arch { uses app App() }
+system Traced(arch Arch) { }
+`
+
+// TestTraceTreeMatchesPath verifies that a path spec holds when the calls
+// are nested and ordered as written.
+func TestTraceTreeMatchesPath(t *testing.T) {
+	tree := BuildTraceTree(traceCall(t, parseAndLoad(t, tracePathSource), "arch.app.Lookup"))
+
+	assert.True(t, tree.Matches("Lookup > cache.Get -> db.Query"))
+	assert.True(t, tree.Matches("App.Lookup > Cache.Get=false -> app.db.Query=true"))
+	assert.True(t, tree.Matches("Lookup > Query > delay"))
+	assert.False(t, tree.Matches("Lookup > cache.Get=true -> db.Query"), "the cache missed")
+	assert.False(t, tree.Matches("cache.Get > db.Query"), "the query is not made inside Get")
+	assert.False(t, tree.Matches("Lookup > .Get"))
+
+	_, err := ParseTracePath("Lookup > > db.Query")
+	assert.ErrorContains(t, err, "invalid trace path step ''")
+	path, err := ParseTracePath("Lookup>cache.Get->db.Query=true")
+	require.NoError(t, err)
+	assert.Equal(t, "Lookup > cache.Get -> db.Query=true", path.String())
+}
+
+// TestTraceTreeMatchesPathOrdering verifies that an ordering assertion fails
+// when the calls are made the other way around.
+func TestTraceTreeMatchesPathOrdering(t *testing.T) {
+	tree := BuildTraceTree(traceCall(t, parseAndLoad(t, tracePathSource), "arch.app.Refresh"))
+
+	assert.False(t, tree.Matches("Refresh > cache.Get -> db.Query"))
+	assert.True(t, tree.Matches("Refresh > db.Query -> cache.Get"))
+	// The delay is inside the query so it is not after it
+	assert.False(t, tree.Matches("db.Query -> delay"))
+}
@@ -85,3 +85,44 @@ func TestUnifiedSystemMethodExecution(t *testing.T) {
 	// The method should return a Bool value (true or false depending on pool)
 	assert.NotNil(t, result)
 }
+
+// TestComponentInstanceIDs verifies that instances are identified by their
+// path in the system, that the ids are the same on every run and that
+// instances of the same component are told apart in traces and flows.
+func TestComponentInstanceIDs(t *testing.T) {
+	first := parseAndLoad(t, `
+component DB {
+  method Query() Bool { return true }
+}
+component Server {
+  uses primary DB()
+  uses replica DB()
+  method Handle() Bool { return self.replica.Query() }
+}
+component Web { uses server Server() }
+system Replicated(web Web, backup Server) { }
+`)
+	second, _ := first.File.NewSystem("Replicated", true)
+	require.NotNil(t, second)
+	for _, path := range []string{"web", "web.server", "web.server.primary", "web.server.replica", "backup", "backup.primary", "backup.replica"} {
+		comp := first.FindComponent(path)
+		require.NotNil(t, comp, path)
+		assert.Equal(t, path, comp.InstanceID())
+		assert.Equal(t, path, comp.ID())
+		assert.Equal(t, comp.ID(), second.FindComponent(path).ID(), "ids should be stable across runs")
+	}
+	assert.NotEqual(t, first.FindComponent("web.server.primary").ID(), first.FindComponent("web.server.replica").ID())
+
+	var instances []string
+	for _, event := range traceCall(t, first, "web.server.Handle").Events {
+		if event.Kind == EventEnter {
+			instances = append(instances, event.InstanceID)
+		}
+	}
+	assert.Equal(t, []string{"web.server", "web.server.replica"}, instances)
+
+	result, err := EvaluateFlowStrategy("runtime", first, []GeneratorConfigAPI{{ID: "g", Component: "web.server", Method: "Handle", Rate: 10}}, DefaultFlowSolverOptions())
+	require.NoError(t, err)
+	assert.InDelta(t, 10, result.Flows.ComponentRates["web.server.replica.Query"], 1e-9)
+	assert.NotContains(t, result.Flows.ComponentRates, "web.server.primary.Query")
+}
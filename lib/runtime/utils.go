@@ -4,13 +4,30 @@ import (
 	"log"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/panyam/sdl/lib/core"
+	"github.com/panyam/sdl/lib/types"
 )
 
-func RunCallInBatches(system *SystemInstance, obj, method string, nbatches, batchsize int, numworkers int, onBatch func(batch int, batchVals []Value)) (results [][]Value) {
+func RunCallInBatches(system *SystemInstance, obj, method string, nbatches, batchsize int, numworkers int, onBatch func(batch int, batchVals []Value)) (results [][]Value, err error) {
+	return RunCallInBatchesWithSeed(system, obj, method, nbatches, batchsize, numworkers, 0, onBatch)
+}
+
+// RunCallInBatchesWithSeed is like RunCallInBatches but seeds each worker's random source
+// from seed so that runs are reproducible.  A seed of 0 uses a time based seed.
+// If any call fails (eg by exceeding the maximum call depth) all workers stop
+// and the first error is returned.
+func RunCallInBatchesWithSeed(system *SystemInstance, obj, method string, nbatches, batchsize int, numworkers int, seed int64, onBatch func(batch int, batchVals []Value)) (results [][]Value, err error) {
+	return RunCallInBatchesWithBudget(system, obj, method, nbatches, batchsize, numworkers, seed, RunBudget{}, onBatch)
+}
+
+// RunCallInBatchesWithBudget is like RunCallInBatchesWithSeed but fails with a
+// *BudgetExceededError once the calls of all batches together exceed budget.
+func RunCallInBatchesWithBudget(system *SystemInstance, obj, method string, nbatches, batchsize int, numworkers int, seed int64, budget RunBudget, onBatch func(batch int, batchVals []Value)) (results [][]Value, err error) {
 	fi := system.File
+	meter := budget.start()
 	se := NewSimpleEval(fi, nil)
 	var totalSimTime core.Duration
 	var simTimeMutex sync.Mutex
@@ -36,7 +53,9 @@ func RunCallInBatches(system *SystemInstance, obj, method string, nbatches, batc
 	}
 
 	var wg sync.WaitGroup
+	var failed atomic.Bool
 	batchesPerWorker := (nbatches + numworkers - 1) / numworkers
+	callTarget := buildMemberAccessExpr(append(strings.Split(obj, "."), method))
 
 	for i := range numworkers {
 		wg.Add(1)
@@ -44,25 +63,39 @@ func RunCallInBatches(system *SystemInstance, obj, method string, nbatches, batc
 			defer wg.Done()
 			workerEnv := env.Push() // Each worker gets its own environment to avoid data races
 			workerSE := NewSimpleEval(fi, nil)
+			workerSE.budget = meter
+			if seed != 0 {
+				workerSE.SetSeed(seed + int64(workerIndex))
+			}
 			var workerSimTime core.Duration
 
 			startBatch := workerIndex * batchesPerWorker
 			endBatch := min((workerIndex+1)*batchesPerWorker, nbatches)
 			// log.Printf("Starting worker %d, Batch Range: %d -> %d", workerIndex, startBatch, endBatch)
 
-			for batch := startBatch; batch < endBatch; batch++ {
+			for batch := startBatch; batch < endBatch && !failed.Load(); batch++ {
 				var batchVals []Value
 				// For simulations, we don't advance a single shared clock.
 				// Each run is independent. We capture the latency of each run.
 				for range batchsize {
 					var runLatency core.Duration
-					ce := &CallExpr{Function: &MemberAccessExpr{Receiver: &IdentifierExpr{Value: obj}, Member: &IdentifierExpr{Value: method}}}
-					res, _ := workerSE.Eval(ce, workerEnv, &runLatency) // a fresh runLatency for each call
-					res.Time = runLatency                               // The latency is the duration of this single run
-					workerSimTime += runLatency                         // Accumulate worker's simulation time
+					ce := &CallExpr{Function: callTarget}
+					res, callErr := workerSE.EvalCall(ce, workerEnv, &runLatency) // a fresh runLatency for each call
+					if callErr != nil {
+						simTimeMutex.Lock()
+						if !failed.Swap(true) {
+							err = callErr
+						}
+						simTimeMutex.Unlock()
+						return
+					}
+					res.Time = runLatency       // The latency is the duration of this single run
+					workerSimTime += runLatency // Accumulate worker's simulation time
 					batchVals = append(batchVals, res)
 				}
+				simTimeMutex.Lock()
 				results = append(results, batchVals)
+				simTimeMutex.Unlock()
 				if onBatch != nil {
 					onBatch(batch, batchVals)
 				}
@@ -79,6 +112,93 @@ func RunCallInBatches(system *SystemInstance, obj, method string, nbatches, batc
 	return
 }
 
+// RunCallAggregates is the aggregate only counterpart of
+// RunCallInBatchesWithBudget for sweeps too large to keep a value per call.
+// Calls are evaluated without a tracer and only their latencies (in
+// milliseconds) are kept, in a histogram per worker that is merged at the
+// end, so memory use does not grow with ncalls.  Results match a traced run
+// of the same calls within the histogram's precision.
+func RunCallAggregates(system *SystemInstance, obj, method string, ncalls, numworkers int, seed int64, budget RunBudget) (*types.LatencyHistogram, error) {
+	fi := system.File
+	meter := budget.start()
+	env := system.Env
+	if env == nil {
+		env = fi.Env()
+		var initTime core.Duration
+		NewSimpleEval(fi, nil).EvalInitSystem(system, env, &initTime)
+	}
+	if numworkers = min(numworkers, ncalls); numworkers < 1 {
+		numworkers = 1
+	}
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var failed atomic.Bool
+	var err error
+	total := types.NewLatencyHistogram()
+	callTarget := buildMemberAccessExpr(append(strings.Split(obj, "."), method))
+
+	for i := range numworkers {
+		wg.Add(1)
+		go func(workerIndex int) {
+			defer wg.Done()
+			workerEnv := env.Push()
+			workerSE := NewSimpleEval(fi, nil)
+			workerSE.budget = meter
+			if seed != 0 {
+				workerSE.SetSeed(seed + int64(workerIndex))
+			}
+			hist := types.NewLatencyHistogram()
+			ce := &CallExpr{Function: callTarget}
+			for call := workerIndex; call < ncalls && !failed.Load(); call += numworkers {
+				var runLatency core.Duration
+				if _, callErr := workerSE.EvalCall(ce, workerEnv, &runLatency); callErr != nil {
+					mu.Lock()
+					if !failed.Swap(true) {
+						err = callErr
+					}
+					mu.Unlock()
+					return
+				}
+				hist.Add(runLatency * 1000)
+			}
+			mu.Lock()
+			total.Merge(hist)
+			mu.Unlock()
+		}(i)
+	}
+
+	wg.Wait()
+	if err != nil {
+		return nil, err
+	}
+	return total, nil
+}
+
+// RunCallWithDecisions evaluates a single call of obj.method, logging every
+// random decision it makes to decisions.  With the same seed it makes the
+// same decisions as the first call of the first worker of a batched run.
+func RunCallWithDecisions(system *SystemInstance, obj, method string, seed int64, decisions *DecisionLog) (result Value, latency core.Duration, err error) {
+	fi := system.File
+	env := system.Env
+	if env == nil {
+		env = fi.Env()
+		var initTime core.Duration
+		NewSimpleEval(fi, nil).EvalInitSystem(system, env, &initTime)
+	}
+	se := NewSimpleEval(fi, nil)
+	if seed != 0 {
+		se.SetSeed(seed)
+	}
+	se.Decisions = decisions
+	ce := &CallExpr{Function: buildMemberAccessExpr(append(strings.Split(obj, "."), method))}
+	result, err = se.EvalCall(ce, env.Push(), &latency)
+	if err == nil {
+		err = decisions.Err()
+	}
+	return
+}
+
 // buildMemberAccessExpr builds a nested MemberAccessExpr from a dotted path.
 // e.g., "arch.app.Shorten" → MemberAccessExpr{MemberAccessExpr{Ident("arch"), "app"}, "Shorten"}
 func buildMemberAccessExpr(parts []string) Expr {
@@ -0,0 +1,55 @@
+package runtime
+
+import (
+	"testing"
+
+	"github.com/panyam/sdl/lib/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const aggregateSource = `
+import delay from "@stdlib/common.sdl"
+
+component DB {
+  method Query() Bool {
+    delay(dist { 80 => 2ms, 15 => 10ms, 5 => 40ms })
+    return true
+  }
+}
+component App {
+  uses db DB()
+  method Serve() Bool {
+    delay(1ms)
+    self.db.Query()
+    return self.db.Query()
+  }
+}
+component Arch { uses app App() }
+system Sweep(arch Arch) { }
+`
+
+// TestRunCallAggregatesMatchesBatches verifies that the aggregate only path
+// gives the same statistics as keeping every call's result, as
+// RunCallInBatchesWithBudget does for DevEnv.RunSimulation.
+func TestRunCallAggregatesMatchesBatches(t *testing.T) {
+	sys := parseAndLoad(t, aggregateSource)
+	batches, err := RunCallInBatchesWithBudget(sys, "arch.app", "Serve", 20, 1000, 4, 7, RunBudget{}, nil)
+	require.NoError(t, err)
+	var runs []types.RunResult
+	for _, batch := range batches {
+		for _, val := range batch {
+			runs = append(runs, types.RunResult{Latency: val.Time * 1000})
+		}
+	}
+	perCall := types.SummarizeRuns(runs)
+
+	hist, err := RunCallAggregates(sys, "arch.app", "Serve", 20000, 4, 42, RunBudget{})
+	require.NoError(t, err)
+	aggregate := types.SummarizeHistogram(hist)
+
+	assert.Equal(t, 20000, aggregate.Count)
+	assert.False(t, perCall.SignificantlyDifferent(aggregate), "per call %v vs aggregate %v", perCall.Mean, aggregate.Mean)
+	assert.InEpsilon(t, perCall.P50.Value, aggregate.P50.Value, 0.05)
+	assert.InEpsilon(t, perCall.P99.Value, aggregate.P99.Value, 0.05)
+}
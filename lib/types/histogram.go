@@ -0,0 +1,142 @@
+package types
+
+import "math"
+
+const (
+	// Relative width of each histogram bucket, so a quantile read from the
+	// histogram is within about 1% of the exact one
+	histogramGrowth = 1.02
+
+	// Smallest latency (in milliseconds) told apart from zero, ie 1ns
+	histogramMinLatency = 1e-6
+
+	// Buckets cover latencies from histogramMinLatency up to about 3 hours
+	histogramBuckets = 1600
+)
+
+var histogramLogGrowth = math.Log(histogramGrowth)
+
+// LatencyHistogram accumulates latencies (in milliseconds) into log sized
+// buckets so the statistics of any number of runs take constant memory.
+// The mean is exact while quantiles are within a bucket's width.
+type LatencyHistogram struct {
+	Count int
+	Sum   float64
+	SumSq float64
+	Min   float64
+	Max   float64
+
+	zeros  int // Latencies below histogramMinLatency
+	counts [histogramBuckets]int
+}
+
+// NewLatencyHistogram creates an empty histogram.
+func NewLatencyHistogram() *LatencyHistogram {
+	return &LatencyHistogram{}
+}
+
+// Add records a latency.
+func (h *LatencyHistogram) Add(latency float64) {
+	if h.Count == 0 || latency < h.Min {
+		h.Min = latency
+	}
+	if h.Count == 0 || latency > h.Max {
+		h.Max = latency
+	}
+	h.Count++
+	h.Sum += latency
+	h.SumSq += latency * latency
+	if latency < histogramMinLatency {
+		h.zeros++
+		return
+	}
+	idx := int(math.Log(latency/histogramMinLatency) / histogramLogGrowth)
+	h.counts[min(idx, histogramBuckets-1)]++
+}
+
+// Merge adds the latencies recorded in another histogram to h.
+func (h *LatencyHistogram) Merge(other *LatencyHistogram) {
+	if other.Count == 0 {
+		return
+	}
+	if h.Count == 0 || other.Min < h.Min {
+		h.Min = other.Min
+	}
+	if h.Count == 0 || other.Max > h.Max {
+		h.Max = other.Max
+	}
+	h.Count += other.Count
+	h.Sum += other.Sum
+	h.SumSq += other.SumSq
+	h.zeros += other.zeros
+	for i, c := range other.counts {
+		h.counts[i] += c
+	}
+}
+
+// Mean returns the mean latency or 0 if nothing was recorded.
+func (h *LatencyHistogram) Mean() float64 {
+	if h.Count == 0 {
+		return 0
+	}
+	return h.Sum / float64(h.Count)
+}
+
+// Quantile returns the nearest-rank q-th quantile, eg 0.99 for the p99.
+func (h *LatencyHistogram) Quantile(q float64) float64 {
+	if h.Count == 0 {
+		return 0
+	}
+	return h.valueAtRank(percentileRank(h.Count, q))
+}
+
+// valueAtRank returns the latency of the rank-th smallest run, taken as
+// the middle of its bucket.
+func (h *LatencyHistogram) valueAtRank(rank int) float64 {
+	cumulative := h.zeros
+	if rank < cumulative {
+		return h.Min
+	}
+	for i, c := range h.counts {
+		cumulative += c
+		if rank < cumulative {
+			mid := histogramMinLatency * math.Pow(histogramGrowth, float64(i)+0.5)
+			return min(max(mid, h.Min), h.Max)
+		}
+	}
+	return h.Max
+}
+
+// SummarizeHistogram computes the same summary as SummarizeRuns from a
+// histogram.  Percentile intervals are the distribution free ones given by
+// the ranks a quantile's estimate falls between 95% of the time, instead of
+// bootstrapped, since the runs themselves are not kept.
+func SummarizeHistogram(h *LatencyHistogram) (summary RunSummary) {
+	summary.Count = h.Count
+	if h.Count == 0 {
+		return
+	}
+
+	n := float64(h.Count)
+	mean := h.Mean()
+	halfWidth := 0.0
+	if h.Count > 1 {
+		variance := max(h.SumSq-n*mean*mean, 0) / (n - 1)
+		halfWidth = z95 * math.Sqrt(variance) / math.Sqrt(n)
+	}
+	summary.Mean = Estimate{Value: mean, CI: ConfidenceInterval{Low: mean - halfWidth, High: mean + halfWidth}}
+
+	quantile := func(q float64) Estimate {
+		spread := z95 * math.Sqrt(n*q*(1-q))
+		low := min(max(int(math.Floor(n*q-spread))-1, 0), h.Count-1)
+		high := min(max(int(math.Ceil(n*q+spread))-1, 0), h.Count-1)
+		return Estimate{
+			Value: h.Quantile(q),
+			CI:    ConfidenceInterval{Low: h.valueAtRank(low), High: h.valueAtRank(high)},
+		}
+	}
+	summary.P50 = quantile(0.5)
+	summary.P95 = quantile(0.95)
+	summary.P99 = quantile(0.99)
+	return
+}
@@ -0,0 +1,35 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestSummarizeHistogramMatchesRuns verifies that a histogram of runs gives
+// the same summary as the runs themselves, within a bucket's width.
+func TestSummarizeHistogramMatchesRuns(t *testing.T) {
+	runs := sampleRuns(10000, 7)
+	first, second := NewLatencyHistogram(), NewLatencyHistogram()
+	for i, run := range runs {
+		if i%2 == 0 {
+			first.Add(run.Latency)
+		} else {
+			second.Add(run.Latency)
+		}
+	}
+	first.Merge(second)
+
+	exact := SummarizeRuns(runs)
+	summary := SummarizeHistogram(first)
+	assert.Equal(t, exact.Count, summary.Count)
+	assert.InDelta(t, exact.Mean.Value, summary.Mean.Value, 1e-9)
+	assert.InDelta(t, exact.Mean.CI.HalfWidth(), summary.Mean.CI.HalfWidth(), 1e-6)
+	for _, pair := range [][2]Estimate{{exact.P50, summary.P50}, {exact.P95, summary.P95}, {exact.P99, summary.P99}} {
+		assert.InEpsilon(t, pair[0].Value, pair[1].Value, 0.02)
+		assert.LessOrEqual(t, pair[1].CI.Low, pair[1].Value)
+		assert.GreaterOrEqual(t, pair[1].CI.High, pair[1].Value)
+	}
+
+	assert.Equal(t, RunSummary{}, SummarizeHistogram(NewLatencyHistogram()))
+}
@@ -0,0 +1,132 @@
+package types
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// z-score for a two sided 95% confidence interval
+const z95 = 1.96
+
+// Number of bootstrap resamples used for percentile confidence intervals
+const bootstrapResamples = 200
+
+// ConfidenceInterval is a 95% confidence interval around an estimate.
+type ConfidenceInterval struct {
+	Low  float64 `json:"low"`
+	High float64 `json:"high"`
+}
+
+// HalfWidth returns half the width of the interval, ie the "±" part.
+func (c ConfidenceInterval) HalfWidth() float64 {
+	return (c.High - c.Low) / 2
+}
+
+// Overlaps returns true if the two intervals share any value.
+func (c ConfidenceInterval) Overlaps(other ConfidenceInterval) bool {
+	return c.Low <= other.High && other.Low <= c.High
+}
+
+// Estimate is a statistic computed from a sample along with its confidence interval.
+type Estimate struct {
+	Value float64            `json:"value"`
+	CI    ConfidenceInterval `json:"ci"`
+}
+
+// RunSummary aggregates the latencies (in milliseconds) of a set of runs.
+type RunSummary struct {
+	Count  int      `json:"count"`
+	Errors int      `json:"errors"`
+	Mean   Estimate `json:"mean"`
+	P50    Estimate `json:"p50"`
+	P95    Estimate `json:"p95"`
+	P99    Estimate `json:"p99"`
+}
+
+// SummarizeRuns computes the mean and percentile latencies of a set of runs.
+// The mean's interval uses the normal approximation and percentile intervals
+// are bootstrapped with a fixed seed so the same runs always give the same summary.
+func SummarizeRuns(results []RunResult) (summary RunSummary) {
+	summary.Count = len(results)
+	if summary.Count == 0 {
+		return
+	}
+
+	samples := make([]float64, len(results))
+	var sum float64
+	for i, res := range results {
+		samples[i] = res.Latency
+		sum += res.Latency
+		if res.IsError {
+			summary.Errors++
+		}
+	}
+	sort.Float64s(samples)
+
+	n := float64(len(samples))
+	mean := sum / n
+	var sqDiff float64
+	for _, s := range samples {
+		sqDiff += (s - mean) * (s - mean)
+	}
+	halfWidth := 0.0
+	if len(samples) > 1 {
+		halfWidth = z95 * math.Sqrt(sqDiff/(n-1)) / math.Sqrt(n)
+	}
+	summary.Mean = Estimate{Value: mean, CI: ConfidenceInterval{Low: mean - halfWidth, High: mean + halfWidth}}
+
+	quantiles := []float64{0.5, 0.95, 0.99}
+	cis := bootstrapPercentiles(samples, quantiles)
+	summary.P50 = Estimate{Value: percentile(samples, 0.5), CI: cis[0]}
+	summary.P95 = Estimate{Value: percentile(samples, 0.95), CI: cis[1]}
+	summary.P99 = Estimate{Value: percentile(samples, 0.99), CI: cis[2]}
+	return
+}
+
+// SignificantlyDifferent returns true if the mean latencies of the two summaries
+// differ by more than their sampling error, ie their confidence intervals do not overlap.
+func (s RunSummary) SignificantlyDifferent(other RunSummary) bool {
+	return !s.Mean.CI.Overlaps(other.Mean.CI)
+}
+
+// percentile returns the nearest-rank q-th percentile of sorted samples.
+func percentile(sorted []float64, q float64) float64 {
+	return sorted[percentileRank(len(sorted), q)]
+}
+
+func percentileRank(n int, q float64) int {
+	return min(max(int(math.Ceil(q*float64(n)))-1, 0), n-1)
+}
+
+// bootstrapPercentiles resamples the sorted samples with replacement and returns
+// the 2.5th and 97.5th percentile of each quantile across the resamples.
+// Resamples are drawn as counts over the sorted samples so no sorting is needed.
+func bootstrapPercentiles(sorted []float64, quantiles []float64) []ConfidenceInterval {
+	n := len(sorted)
+	rng := rand.New(rand.NewSource(1))
+	counts := make([]int, n)
+	estimates := make([][]float64, len(quantiles))
+	for range bootstrapResamples {
+		clear(counts)
+		for range n {
+			counts[rng.Intn(n)]++
+		}
+
+		cumulative, qi := 0, 0
+		for i, c := range counts {
+			cumulative += c
+			for qi < len(quantiles) && cumulative > percentileRank(n, quantiles[qi]) {
+				estimates[qi] = append(estimates[qi], sorted[i])
+				qi++
+			}
+		}
+	}
+
+	out := make([]ConfidenceInterval, len(quantiles))
+	for i, values := range estimates {
+		sort.Float64s(values)
+		out[i] = ConfidenceInterval{Low: percentile(values, 0.025), High: percentile(values, 0.975)}
+	}
+	return out
+}
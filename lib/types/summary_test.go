@@ -0,0 +1,57 @@
+package types
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// sampleRuns returns n runs with normally distributed latencies around 10ms.
+func sampleRuns(n int, seed int64) []RunResult {
+	rng := rand.New(rand.NewSource(seed))
+	results := make([]RunResult, n)
+	for i := range results {
+		results[i] = RunResult{Latency: 10 + rng.NormFloat64()*2}
+	}
+	return results
+}
+
+// TestSummarizeRunsCINarrows verifies that the confidence intervals for the
+// mean and percentiles contain the estimate and shrink as more runs are collected.
+func TestSummarizeRunsCINarrows(t *testing.T) {
+	var prevMean, prevP95 float64
+	for i, n := range []int{100, 1000, 10000} {
+		summary := SummarizeRuns(sampleRuns(n, 42))
+		require.Equal(t, n, summary.Count)
+
+		for _, est := range []Estimate{summary.Mean, summary.P50, summary.P95, summary.P99} {
+			assert.LessOrEqual(t, est.CI.Low, est.Value)
+			assert.GreaterOrEqual(t, est.CI.High, est.Value)
+		}
+		assert.InDelta(t, 10, summary.Mean.Value, summary.Mean.CI.HalfWidth()*2)
+
+		if i > 0 {
+			assert.Less(t, summary.Mean.CI.HalfWidth(), prevMean, "mean CI should narrow at n=%d", n)
+			assert.Less(t, summary.P95.CI.HalfWidth(), prevP95, "p95 CI should narrow at n=%d", n)
+		}
+		prevMean, prevP95 = summary.Mean.CI.HalfWidth(), summary.P95.CI.HalfWidth()
+	}
+}
+
+// TestRunSummarySignificance verifies that two samples of the same distribution
+// are within noise while a shifted distribution is reported as different.
+func TestRunSummarySignificance(t *testing.T) {
+	base := SummarizeRuns(sampleRuns(2000, 1))
+	same := SummarizeRuns(sampleRuns(2000, 2))
+	assert.False(t, base.SignificantlyDifferent(same))
+
+	shifted := sampleRuns(2000, 3)
+	for i := range shifted {
+		shifted[i].Latency += 1
+	}
+	assert.True(t, base.SignificantlyDifferent(SummarizeRuns(shifted)))
+
+	assert.Equal(t, RunSummary{}, SummarizeRuns(nil))
+}
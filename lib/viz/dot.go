@@ -10,7 +10,22 @@ import (
 
 // --- DOT Generator ---
 
-type DotGenerator struct{}
+type DotGenerator struct {
+	// Optional fill colors of nodes by id, eg from UtilizationColor
+	NodeColors map[string]string
+}
+
+// UtilizationColor returns the heatmap color for a utilization: green below
+// 70%, yellow below 90% and red from there on.
+func UtilizationColor(utilization float64) string {
+	switch {
+	case utilization < 0.7:
+		return "green"
+	case utilization < 0.9:
+		return "yellow"
+	}
+	return "red"
+}
 
 func (g *DotGenerator) Generate(diagram *protos.SystemDiagram) (string, error) {
 	var b bytes.Buffer
@@ -38,6 +53,10 @@ func (g *DotGenerator) Generate(diagram *protos.SystemDiagram) (string, error) {
 			}
 		}
 		
+		if color, ok := g.NodeColors[node.Id]; ok {
+			b.WriteString(fmt.Sprintf("  \"%s\" [label=\"%s\", style=\"rounded,filled\", fillcolor=\"%s\"];\n", node.Id, label, color))
+			continue
+		}
 		b.WriteString(fmt.Sprintf("  \"%s\" [label=\"%s\"];\n", node.Id, label))
 	}
 
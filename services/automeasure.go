@@ -0,0 +1,74 @@
+package services
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+
+	protos "github.com/panyam/sdl/gen/go/sdl/v1/models"
+	"github.com/panyam/sdl/lib/runtime"
+)
+
+// AutoMetricNames returns the names of the metrics AddAutoMetrics creates for
+// a generator: its latency and its throughput.
+func AutoMetricNames(generator string) []string {
+	return []string{generator + "_latency", generator + "_throughput"}
+}
+
+// AddAutoMetrics adds an average latency and a throughput (calls per window)
+// metric on the target of every generator, named after the generator, see
+// AutoMetricNames.  Metrics that already exist are left alone so it can be
+// run again after adding generators.  It returns the metrics it added in
+// generator name order.
+func (d *DevEnv) AddAutoMetrics() ([]*protos.Metric, error) {
+	if d.metricTracer == nil {
+		return nil, fmt.Errorf("no active system")
+	}
+	generators := d.ListGenerators()
+	sort.Slice(generators, func(i, j int) bool { return generators[i].Name < generators[j].Name })
+	var existing []string
+	for _, metric := range d.ListMetrics() {
+		existing = append(existing, metric.Name)
+	}
+
+	var added []*protos.Metric
+	for _, gen := range generators {
+		names := AutoMetricNames(gen.Name)
+		for idx, metricType := range []string{runtime.MetricLatency, runtime.MetricCount} {
+			if slices.Contains(existing, names[idx]) {
+				continue
+			}
+			metric := &protos.Metric{
+				Name:              names[idx],
+				Component:         gen.Component,
+				Methods:           []string{gen.Method},
+				MetricType:        metricType,
+				Aggregation:       "avg",
+				AggregationWindow: 10,
+				Enabled:           true,
+			}
+			if metricType == runtime.MetricCount {
+				metric.Aggregation = "sum"
+			}
+			if err := d.AddMetric(&runtime.Metric{Metric: metric}); err != nil {
+				return added, fmt.Errorf("failed to add metric '%s' for generator '%s': %w", metric.Name, gen.Name, err)
+			}
+			added = append(added, metric)
+		}
+	}
+	return added, nil
+}
+
+// RemoveAutoMetrics removes the metrics AddAutoMetrics created for a
+// generator, if any.
+func (d *DevEnv) RemoveAutoMetrics(generator string) error {
+	if d.metricTracer == nil {
+		return nil
+	}
+	for _, name := range AutoMetricNames(generator) {
+		if err := d.RemoveMetric(name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
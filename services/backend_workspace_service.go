@@ -25,6 +25,16 @@ type WorkspaceStorageProvider interface {
 	LoadAllDesignContents(ctx context.Context, workspaceId string) (map[string]string, error)
 }
 
+// CanvasStore is implemented by backends (fsbe, S3) that persist canvas
+// snapshots keyed by canvas id so canvases survive restarts.  Snapshots are
+// opaque to the store.
+type CanvasStore interface {
+	Save(ctx context.Context, id string, snapshot []byte) error
+	Load(ctx context.Context, id string) ([]byte, error)
+	List(ctx context.Context) ([]string, error)
+	Delete(ctx context.Context, id string) error
+}
+
 // BackendWorkspaceService wraps a WorkspaceStorageProvider with common logic.
 type BackendWorkspaceService struct {
 	storage WorkspaceStorageProvider
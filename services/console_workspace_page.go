@@ -5,6 +5,7 @@ import (
 	"sync"
 
 	protos "github.com/panyam/sdl/gen/go/sdl/v1/models"
+	"github.com/panyam/sdl/lib/loader"
 )
 
 // ConsoleWorkspacePage implements WorkspacePage for CLI and test usage.
@@ -20,6 +21,8 @@ type ConsoleWorkspacePage struct {
 	// Recorded state — latest snapshot of what the presenter pushed
 	ActiveSystem     string
 	AvailableSystems []string
+	Manifest         *loader.Manifest
+	Diagnostics      map[string][]Diagnostic // By file, of the last failed recompile
 	Diagram          *SystemDiagram
 	Generators       map[string]*protos.Generator
 	Metrics          map[string]*protos.Metric
@@ -66,6 +69,34 @@ func (c *ConsoleWorkspacePage) OnAvailableSystemsChanged(systemNames []string) {
 	}
 }
 
+func (c *ConsoleWorkspacePage) OnModelChanged(manifest *loader.Manifest) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.Manifest = manifest
+	c.AvailableSystems = nil
+	for _, system := range manifest.Systems {
+		c.AvailableSystems = append(c.AvailableSystems, system.Name)
+	}
+	c.Diagnostics = nil
+	if c.Verbose {
+		fmt.Printf("Model changed: %d systems, %d components\n", len(manifest.Systems), len(manifest.Components))
+	}
+}
+
+func (c *ConsoleWorkspacePage) OnDiagnostics(filePath string, diagnostics []Diagnostic) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.Diagnostics == nil {
+		c.Diagnostics = make(map[string][]Diagnostic)
+	}
+	c.Diagnostics[filePath] = diagnostics
+	if c.Verbose {
+		for _, diag := range diagnostics {
+			fmt.Printf("%s:%d:%d: %s\n", filePath, diag.Line, diag.Col, diag.Message)
+		}
+	}
+}
+
 func (c *ConsoleWorkspacePage) UpdateDiagram(diagram *SystemDiagram) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
@@ -0,0 +1,225 @@
+package services
+
+import (
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/panyam/sdl/lib/core"
+	"github.com/panyam/sdl/lib/decl"
+	"github.com/panyam/sdl/lib/runtime"
+)
+
+// ControllerSpec binds a parameter to a metric for closed-loop experiments,
+// eg "if p99 > 100ms for 10s, increase server.pool.Size by 1 up to 50" is
+//
+//	ControllerSpec{Metric: "p99", Operator: core.GT, Threshold: 0.1,
+//		Window: 10 * time.Second, Param: "server.pool.Size", Step: 1, Limit: 50}
+type ControllerSpec struct {
+	Name      string
+	Metric    string            // Name of the metric to watch
+	Operator  core.OperatorType // How the metric is compared to Threshold
+	Threshold float64           // In the metric's units, ie seconds for latencies
+	Window    time.Duration     // How long the condition must hold before acting
+	Param     string            // Parameter path, eg "server.pool.Size"
+	Step      float64           // Added to the parameter on each action, negative to decrease
+	Limit     float64           // The parameter is never moved past this
+}
+
+func (s ControllerSpec) String() string {
+	verb, step, bound := "increase", s.Step, "up"
+	if step < 0 {
+		verb, step, bound = "decrease", -step, "down"
+	}
+	return fmt.Sprintf("if %s %s %g for %s, %s %s by %g %s to %g",
+		s.Metric, core.OperatorTypeToString(s.Operator), s.Threshold, s.Window, verb, s.Param, step, bound, s.Limit)
+}
+
+// ControllerAction records a parameter change made by a controller.
+type ControllerAction struct {
+	Timestamp time.Time // End of the metric window that triggered it
+	Value     float64   // The metric value at the time
+	From, To  float64
+}
+
+// Controller evaluates its spec each time a window of its metric closes.
+// Once the condition has held for the whole Window the parameter is moved
+// by Step, and the condition must hold for another Window before the next
+// move so the change has time to show in the metric.
+type Controller struct {
+	ControllerSpec
+
+	env          *DevEnv
+	system       *runtime.SystemInstance
+	metricWindow time.Duration
+	remove       func()
+
+	mu          sync.Mutex
+	breaching   bool
+	breachStart time.Time
+	actions     []ControllerAction
+}
+
+// Actions returns the parameter changes made so far.
+func (c *Controller) Actions() []ControllerAction {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]ControllerAction(nil), c.actions...)
+}
+
+// Observe evaluates a closed window of the metric.  Points are expected in
+// time order.  It runs on the metric's goroutine so it holds the DevEnv's
+// model lock throughout, keeping the system from being swapped by Use or
+// Reload while its parameter is read and set.
+func (c *Controller) Observe(point *runtime.MetricPoint) {
+	c.env.modelLock.Lock()
+	defer c.env.modelLock.Unlock()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.env.activeSystem != c.system {
+		return
+	}
+	if !core.CheckCondition(point.Value, c.Operator, c.Threshold) {
+		c.breaching = false
+		return
+	}
+	if !c.breaching {
+		c.breaching = true
+		c.breachStart = point.Timestamp
+	}
+	end := point.Timestamp.Add(c.metricWindow)
+	if end.Sub(c.breachStart) < c.Window {
+		return
+	}
+	c.breaching = false
+
+	current, isInt, err := c.env.numericParameter(c.Param)
+	if err == nil {
+		next := current + c.Step
+		if (c.Step > 0 && next > c.Limit) || (c.Step < 0 && next < c.Limit) {
+			next = c.Limit
+		}
+		if next == current {
+			return
+		}
+		if isInt {
+			err = c.env.SetParameter(c.Param, int64(next))
+		} else {
+			err = c.env.SetParameter(c.Param, next)
+		}
+		if err == nil {
+			c.actions = append(c.actions, ControllerAction{Timestamp: end, Value: point.Value, From: current, To: next})
+			return
+		}
+	}
+	if page := c.env.getPage(); page != nil {
+		page.LogMessage("error", fmt.Sprintf("controller '%s' could not update '%s': %v", c.Name, c.Param, err), "controller")
+	}
+}
+
+// AddController starts evaluating spec against the active system.  The
+// metric must already exist and the parameter must be numeric.  Controllers
+// are dropped when another system is used.
+func (d *DevEnv) AddController(spec ControllerSpec) (*Controller, error) {
+	if d.metricTracer == nil {
+		return nil, fmt.Errorf("no active system")
+	}
+	d.modelLock.Lock()
+	defer d.modelLock.Unlock()
+	if spec.Name == "" {
+		spec.Name = spec.Metric + ":" + spec.Param
+	}
+	if d.controllers[spec.Name] != nil {
+		return nil, fmt.Errorf("controller '%s' already exists", spec.Name)
+	}
+	metric := d.metricTracer.GetMetric(spec.Metric)
+	if metric == nil {
+		return nil, fmt.Errorf("metric '%s' not found", spec.Metric)
+	}
+	if spec.Step == 0 {
+		return nil, fmt.Errorf("controller '%s' needs a non-zero step", spec.Name)
+	}
+	_, isInt, err := d.numericParameter(spec.Param)
+	if err != nil {
+		return nil, err
+	}
+	if isInt && (spec.Step != math.Trunc(spec.Step) || spec.Limit != math.Trunc(spec.Limit)) {
+		return nil, fmt.Errorf("'%s' is an integer, step and limit must be whole numbers", spec.Param)
+	}
+
+	c := &Controller{
+		ControllerSpec: spec,
+		env:            d,
+		system:         d.activeSystem,
+		metricWindow:   time.Duration(metric.AggregationWindow) * time.Second,
+	}
+	c.remove = metric.OnWindowClose(c.Observe)
+	d.controllers[spec.Name] = c
+	return c, nil
+}
+
+// RemoveController stops a controller.  Changes it already made are kept.
+func (d *DevEnv) RemoveController(name string) error {
+	d.modelLock.Lock()
+	defer d.modelLock.Unlock()
+	return d.removeController(name)
+}
+
+func (d *DevEnv) removeController(name string) error {
+	c := d.controllers[name]
+	if c == nil {
+		return fmt.Errorf("controller '%s' not found", name)
+	}
+	c.remove()
+	delete(d.controllers, name)
+	return nil
+}
+
+// ListControllers returns the controllers on the active system.
+func (d *DevEnv) ListControllers() []*Controller {
+	d.modelLock.RLock()
+	defer d.modelLock.RUnlock()
+	out := make([]*Controller, 0, len(d.controllers))
+	for _, name := range slices.Sorted(maps.Keys(d.controllers)) {
+		out = append(out, d.controllers[name])
+	}
+	return out
+}
+
+func (d *DevEnv) clearControllers() {
+	d.modelLock.Lock()
+	defer d.modelLock.Unlock()
+	for name := range d.controllers {
+		d.removeController(name)
+	}
+}
+
+// numericParameter returns the current value of an int or float parameter.
+// Callers hold the model lock.
+func (d *DevEnv) numericParameter(path string) (value float64, isInt bool, err error) {
+	if d.activeSystem == nil {
+		return 0, false, fmt.Errorf("no active system")
+	}
+	parts := strings.Split(path, ".")
+	if len(parts) < 2 {
+		return 0, false, fmt.Errorf("invalid parameter path '%s': expected comp1.comp2...compN.ParamName", path)
+	}
+	comp, err := d.activeSystem.ResolveComponent(strings.Join(parts[:len(parts)-1], "."))
+	if err != nil {
+		return 0, false, fmt.Errorf("cannot read '%s': %w", path, err)
+	}
+	v, ok := comp.Get(parts[len(parts)-1])
+	switch {
+	case !ok || v.Type == nil:
+		return 0, false, fmt.Errorf("parameter '%s' not found", path)
+	case v.Type.Equals(decl.IntType):
+		return float64(v.IntVal()), true, nil
+	case v.Type.Equals(decl.FloatType):
+		return v.FloatVal(), false, nil
+	}
+	return 0, false, fmt.Errorf("parameter '%s' is %s, not a number", path, v.Type)
+}
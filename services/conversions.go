@@ -1,7 +1,10 @@
 package services
 
 import (
+	"encoding/json"
+
 	protos "github.com/panyam/sdl/gen/go/sdl/v1/models"
+	"github.com/panyam/sdl/lib/loader"
 )
 
 // SystemDiagram conversions - kept because diagram types are built dynamically
@@ -64,3 +67,60 @@ func ToProtoDiagramEdge(e DiagramEdge) *protos.DiagramEdge {
 		Color:       e.Color,
 	}
 }
+
+// ToProtoManifest converts a manifest for transport.  Param defaults are JSON
+// encoded since they can be of any constant type.
+func ToProtoManifest(m *loader.Manifest) *protos.Manifest {
+	if m == nil {
+		return nil
+	}
+	out := &protos.Manifest{Version: int32(m.Version)}
+	for _, sys := range m.Systems {
+		ps := &protos.ManifestSystem{Name: sys.Name, File: sys.File}
+		for _, inst := range sys.Parameters {
+			ps.Parameters = append(ps.Parameters, toProtoManifestInstance(inst))
+		}
+		for _, inst := range sys.Instances {
+			ps.Instances = append(ps.Instances, toProtoManifestInstance(inst))
+		}
+		out.Systems = append(out.Systems, ps)
+	}
+	for _, comp := range m.Components {
+		pc := &protos.ManifestComponent{Name: comp.Name, File: comp.File, Native: comp.Native}
+		for _, param := range comp.Params {
+			pc.Params = append(pc.Params, toProtoManifestParam(param))
+		}
+		for _, uses := range comp.Uses {
+			pc.Uses = append(pc.Uses, &protos.ManifestUses{Name: uses.Name, Component: uses.Component})
+		}
+		for _, method := range comp.Methods {
+			pm := &protos.ManifestMethod{Name: method.Name, Returns: method.Returns}
+			for _, param := range method.Params {
+				pm.Params = append(pm.Params, toProtoManifestParam(param))
+			}
+			pc.Methods = append(pc.Methods, pm)
+		}
+		for _, profile := range comp.Profiles {
+			pc.Profiles = append(pc.Profiles, &protos.ManifestProfile{Name: profile.Name, Methods: profile.Methods})
+		}
+		out.Components = append(out.Components, pc)
+	}
+	for _, enum := range m.Enums {
+		out.Enums = append(out.Enums, &protos.ManifestEnum{Name: enum.Name, File: enum.File, Values: enum.Values})
+	}
+	return out
+}
+
+func toProtoManifestInstance(inst loader.ManifestInstance) *protos.ManifestInstance {
+	return &protos.ManifestInstance{Path: inst.Path, Component: inst.Component, Uses: inst.Uses}
+}
+
+func toProtoManifestParam(param loader.ManifestParam) *protos.ManifestParam {
+	out := &protos.ManifestParam{Name: param.Name, Type: param.Type}
+	if param.Default != nil {
+		if data, err := json.Marshal(param.Default); err == nil {
+			out.DefaultValue = string(data)
+		}
+	}
+	return out
+}
@@ -1,11 +1,16 @@
 package services
 
 import (
+	"cmp"
 	"context"
+	"crypto/sha256"
+	"encoding/json"
 	"fmt"
+	"io"
 	"log"
 	"log/slog"
 	"maps"
+	"math"
 	"slices"
 	"strings"
 	"sync"
@@ -16,16 +21,27 @@ import (
 	"github.com/panyam/sdl/lib/decl"
 	"github.com/panyam/sdl/lib/loader"
 	"github.com/panyam/sdl/lib/runtime"
+	"github.com/panyam/sdl/lib/types"
 )
 
 // DevEnv is the primary simulation coordinator, replacing Canvas + CanvasViewPresenter.
 // It is constructed with a FileResolver, owns a Runtime internally, and pushes typed
 // updates to an attached WorkspacePage.
 type DevEnv struct {
+	resolver loader.FileResolver
+
+	// Guards the runtime, systems and loaded files below, which Reload
+	// replaces from the WatchFiles goroutine
+	modelLock     sync.RWMutex
 	runtime       *runtime.Runtime
 	activeSystem  *runtime.SystemInstance
 	loadedSystems map[string]*runtime.SystemInstance
 
+	// Files loaded with LoadFile, in order, so Reload can load them again,
+	// and checksums of them and their imports as of the last load
+	loadedFiles []string
+	fileSums    map[string][sha256.Size]byte
+
 	// Generator management
 	generators     map[string]*runtime.Generator
 	generatorsLock sync.RWMutex
@@ -33,16 +49,60 @@ type DevEnv struct {
 	// Metrics
 	metricTracer *runtime.MetricTracer
 
+	// Metrics created for every instance of a component type, see AddMetricTemplate
+	metricTemplates []MetricTemplate
+
 	// Flow analysis
 	currentFlowScope    *runtime.FlowScope
 	currentFlowRates    runtime.RateMap
 	currentFlowStrategy string
 	manualRateOverrides map[string]float64
+	flowSolverOptions   runtime.FlowSolverOptions
 
 	// Simulation time
+	clock               runtime.Clock
 	simulationStartTime time.Time
 	simulationStarted   bool
 
+	// Cached simulation results and a counter bumped on every parameter change
+	// or priming run, since both change what a run returns
+	runCache      *RunCache
+	paramsVersion int64
+
+	// Summaries of the latest runs, see ListRuns
+	runHistory *RunHistory
+
+	// Significant figures used when formatting values for display
+	displayPrecision int
+
+	// Reject runtime sets of params fixed by a uses override instead of warning
+	strictParams bool
+
+	// Seed for runs that do not set one, from the system's seed option
+	defaultSeed int64
+
+	// Optional destination every traced event is also written to
+	traceSink     runtime.TraceSink
+	traceSinkLock sync.Mutex
+
+	// Limits of each run and of each generator, unbounded by default
+	runBudget runtime.RunBudget
+
+	// Where debug runs log their random decisions, see SetDecisionLog
+	decisionLog *runtime.DecisionLog
+
+	// Whether summaries of runs are computed from traced calls or aggregates
+	// only, guarded by modelLock
+	evalMode EvalMode
+
+	// Controllers moving parameters in response to metrics, by name, guarded
+	// by modelLock
+	controllers map[string]*Controller
+
+	// Queues every metric's closed windows are pushed to
+	exportersLock sync.RWMutex
+	exporters     []*runtime.MetricExportQueue
+
 	// Page handler (single panel endpoint, like CanvasDashboardPage)
 	page     WorkspacePage
 	pageLock sync.RWMutex
@@ -50,13 +110,20 @@ type DevEnv struct {
 
 // NewDevEnv creates a new DevEnv with the given file resolver.
 func NewDevEnv(resolver loader.FileResolver) *DevEnv {
-	sdlLoader := loader.NewLoader(nil, resolver, 10)
+	sdlLoader := loader.NewLoader(nil, resolver, loader.DefaultMaxImportDepth)
 	rt := runtime.NewRuntime(sdlLoader)
 	return &DevEnv{
+		resolver:            resolver,
 		runtime:             rt,
 		loadedSystems:       make(map[string]*runtime.SystemInstance),
 		generators:          make(map[string]*runtime.Generator),
 		manualRateOverrides: make(map[string]float64),
+		controllers:         make(map[string]*Controller),
+		flowSolverOptions:   runtime.DefaultFlowSolverOptions(),
+		runCache:            NewRunCache(DefaultRunCacheSize),
+		runHistory:          NewRunHistory(DefaultRunHistorySize),
+		displayPrecision:    core.DefaultDisplayPrecision,
+		clock:               runtime.RealClock,
 	}
 }
 
@@ -66,6 +133,14 @@ func (d *DevEnv) GetTracer() runtime.Tracer          { return d.metricTracer }
 func (d *DevEnv) GetSimulationStartTime() time.Time   { return d.simulationStartTime }
 func (d *DevEnv) IsSimulationStarted() bool            { return d.simulationStarted }
 func (d *DevEnv) GetSimulationTime() float64           { return 0 } // TODO: virtual time tracking
+func (d *DevEnv) GetClock() runtime.Clock               { return d.clock }
+
+// SetClock replaces the clock generators, metric windows and scheduled sets
+// read time from, eg with a runtime.FakeClock in tests.  Generators and metrics
+// already running keep the clock they started with.
+func (d *DevEnv) SetClock(clock runtime.Clock) {
+	d.clock = clock
+}
 
 // Page handler management
 
@@ -93,10 +168,16 @@ func (d *DevEnv) getPage() WorkspacePage {
 
 // LoadFile parses an SDL file and makes its systems available.
 func (d *DevEnv) LoadFile(filePath string) error {
+	d.modelLock.Lock()
 	_, err := d.runtime.LoadFile(filePath)
+	if err == nil && !slices.Contains(d.loadedFiles, filePath) {
+		d.loadedFiles = append(d.loadedFiles, filePath)
+	}
+	d.modelLock.Unlock()
 	if err != nil {
 		return err
 	}
+	d.updateFileSums()
 	if page := d.getPage(); page != nil {
 		page.OnAvailableSystemsChanged(d.AvailableSystems())
 	}
@@ -105,24 +186,122 @@ func (d *DevEnv) LoadFile(filePath string) error {
 
 // AvailableSystems returns the names of all systems discovered across loaded files.
 func (d *DevEnv) AvailableSystems() []string {
-	systems := d.runtime.AvailableSystems()
+	systems := d.currentRuntime().AvailableSystems()
 	return slices.Collect(maps.Keys(systems))
 }
 
+// ParamSchema returns a JSON Schema of the settable params of every loaded
+// component and the param paths each loaded system can override, for
+// generating parameter forms.
+func (d *DevEnv) ParamSchema() (json.RawMessage, error) {
+	return loader.ParamSchema(d.validatedFiles()...)
+}
+
+// Manifest returns a versioned summary of the loaded systems, components and
+// enums for tools that consume the model without parsing it.
+func (d *DevEnv) Manifest() (*loader.Manifest, error) {
+	return loader.BuildManifest(d.validatedFiles()...)
+}
+
+// LoadedSystems loads the file if it is not already and summarises the
+// systems it declares, ordered by name, so callers know what they can use
+// and which methods generators can target.
+func (d *DevEnv) LoadedSystems(filePath string) ([]LoadedSystem, error) {
+	d.modelLock.Lock()
+	finst, err := d.runtime.LoadFile(filePath)
+	d.modelLock.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	m, err := loader.BuildManifest(finst.Decl)
+	if err != nil {
+		return nil, err
+	}
+	components := map[string]loader.ManifestComponent{}
+	for _, comp := range m.Components {
+		components[comp.Name] = comp
+	}
+	systems := []LoadedSystem{}
+	for _, ms := range m.Systems {
+		system := LoadedSystem{Name: ms.Name, Parameters: []SystemParameter{}, EntryPoints: []string{}}
+		for _, param := range ms.Parameters {
+			system.Parameters = append(system.Parameters, SystemParameter{Name: param.Path, Component: param.Component})
+			for _, method := range components[param.Component].Methods {
+				system.EntryPoints = append(system.EntryPoints, param.Path+"."+method.Name)
+			}
+		}
+		systems = append(systems, system)
+	}
+	return systems, nil
+}
+
+// RunScenarios runs the scenarios of every loaded system in virtual time,
+// ordered by system and then as declared.  Each runs on a newly created
+// system so it does not see the load of the others, seeded by the system's
+// seed option if it has one.  With failFast the scenarios after the first
+// one to fail are not run.
+func (d *DevEnv) RunScenarios(failFast bool) (results []*runtime.ScenarioResult, err error) {
+	rt := d.currentRuntime()
+	systems := rt.AvailableSystems()
+	for _, name := range slices.Sorted(maps.Keys(systems)) {
+		for _, scenario := range systems[name].Scenarios {
+			system, err := rt.NewSystem(name)
+			if err != nil {
+				return nil, err
+			}
+			var seed int64
+			if value, ok := system.Options()["seed"]; ok {
+				seed = value.Value.(int64)
+			}
+			result, err := runtime.RunScenario(system, scenario, seed)
+			if err != nil {
+				return nil, fmt.Errorf("scenario %s.%s: %w", name, scenario.Name.Value, err)
+			}
+			results = append(results, result)
+			if failFast && !result.Passed {
+				return results, nil
+			}
+		}
+	}
+	return
+}
+
+func (d *DevEnv) validatedFiles() (files []*decl.FileDecl) {
+	d.modelLock.RLock()
+	defer d.modelLock.RUnlock()
+	for _, fs := range d.runtime.Loader.GetAllLoadedFiles() {
+		if fs.FileDecl != nil && !fs.HasErrors() {
+			files = append(files, fs.FileDecl)
+		}
+	}
+	return
+}
+
 // ActiveSystem returns the currently active system instance, or nil.
 func (d *DevEnv) ActiveSystem() *runtime.SystemInstance {
+	d.modelLock.RLock()
+	defer d.modelLock.RUnlock()
 	return d.activeSystem
 }
 
 // GetActiveSystemName returns the name of the active system, or empty string.
 func (d *DevEnv) GetActiveSystemName() string {
-	if d.activeSystem == nil || d.activeSystem.System == nil {
+	system := d.ActiveSystem()
+	if system == nil || system.System == nil {
 		return ""
 	}
-	return d.activeSystem.System.Name.Value
+	return system.System.Name.Value
+}
+
+// currentRuntime returns the runtime the loaded files were last compiled into.
+func (d *DevEnv) currentRuntime() *runtime.Runtime {
+	d.modelLock.RLock()
+	defer d.modelLock.RUnlock()
+	return d.runtime
 }
 
-// ListGenerators returns proto Generator copies for all registered generators.
+// ListGenerators returns proto Generator copies for all registered generators,
+// ordered by name so listings of the same state are identical.
 func (d *DevEnv) ListGenerators() []*protos.Generator {
 	d.generatorsLock.RLock()
 	defer d.generatorsLock.RUnlock()
@@ -130,10 +309,12 @@ func (d *DevEnv) ListGenerators() []*protos.Generator {
 	for _, gen := range d.generators {
 		result = append(result, gen.Generator)
 	}
+	slices.SortFunc(result, func(a, b *protos.Generator) int { return cmp.Compare(a.Name, b.Name) })
 	return result
 }
 
-// ListMetrics returns proto Metric copies for all tracked metrics.
+// ListMetrics returns proto Metric copies for all tracked metrics, ordered by
+// name.
 func (d *DevEnv) ListMetrics() []*protos.Metric {
 	if d.metricTracer == nil {
 		return nil
@@ -141,21 +322,42 @@ func (d *DevEnv) ListMetrics() []*protos.Metric {
 	return d.metricTracer.ListMetrics()
 }
 
+// loadedSystem returns the instance of the named system, creating it on
+// first use.
+func (d *DevEnv) loadedSystem(systemName string) (*runtime.SystemInstance, error) {
+	d.modelLock.Lock()
+	defer d.modelLock.Unlock()
+	if system := d.loadedSystems[systemName]; system != nil {
+		return system, nil
+	}
+	system, err := d.runtime.NewSystem(systemName)
+	if err != nil {
+		return nil, err
+	}
+	d.loadedSystems[systemName] = system
+	return system, nil
+}
+
 // Use activates a system by name. Creates the SystemInstance if needed,
 // wires up declared generators and metrics, and notifies the page handler.
 func (d *DevEnv) Use(systemName string) error {
-	if d.loadedSystems[systemName] == nil {
-		system, err := d.runtime.NewSystem(systemName)
-		if err != nil {
-			return err
+	system, err := d.loadedSystem(systemName)
+	if err != nil {
+		if suggestions := closestNames(systemName, d.AvailableSystems()); len(suggestions) > 0 {
+			return fmt.Errorf("%w%s", err, didYouMean(suggestions))
 		}
-		d.loadedSystems[systemName] = system
+		return err
 	}
 
 	// Stop existing generators before switching
 	d.stopAllGeneratorsInternal()
 
-	d.activeSystem = d.loadedSystems[systemName]
+	d.modelLock.Lock()
+	d.activeSystem = system
+	d.modelLock.Unlock()
+
+	// Controllers watch the old system's metrics
+	d.clearControllers()
 
 	// Reset metric tracer
 	if d.metricTracer != nil {
@@ -166,6 +368,10 @@ func (d *DevEnv) Use(systemName string) error {
 	// Reset simulation time
 	d.simulationStarted = false
 
+	if err := d.applySystemOptions(); err != nil {
+		return err
+	}
+
 	// Initialize flow contexts
 	d.initializeFlowContexts()
 
@@ -176,6 +382,7 @@ func (d *DevEnv) Use(systemName string) error {
 	if err := d.createDeclaredMetrics(); err != nil {
 		return err
 	}
+	d.applyMetricTemplates()
 
 	// Notify page handler
 	if page := d.getPage(); page != nil {
@@ -217,6 +424,9 @@ func (d *DevEnv) AddGenerator(gen *runtime.Generator) error {
 
 	gen.SimCtx = d
 	gen.System = d.activeSystem
+	if gen.Budget.Unbounded() {
+		gen.Budget = d.runBudget
+	}
 
 	// Resolve component and method if not already resolved
 	if gen.ResolvedComponent == nil && gen.Component != "" {
@@ -231,6 +441,9 @@ func (d *DevEnv) AddGenerator(gen *runtime.Generator) error {
 	d.generators[gen.Name] = gen
 	d.generatorsLock.Unlock()
 
+	if warning := gen.NoEffectWarning(); warning != "" {
+		log.Printf("Warning: %s", warning)
+	}
 	gen.Start()
 
 	if page := d.getPage(); page != nil {
@@ -292,7 +505,7 @@ func (d *DevEnv) StartGenerator(name string) error {
 	// Mark simulation as started on first generator start
 	if !d.simulationStarted {
 		d.simulationStarted = true
-		d.simulationStartTime = time.Now()
+		d.simulationStartTime = d.clock.Now()
 	}
 
 	if page := d.getPage(); page != nil {
@@ -331,7 +544,7 @@ func (d *DevEnv) StartAllGenerators() error {
 
 	if !d.simulationStarted && len(gens) > 0 {
 		d.simulationStarted = true
-		d.simulationStartTime = time.Now()
+		d.simulationStartTime = d.clock.Now()
 	}
 
 	for _, gen := range gens {
@@ -340,6 +553,15 @@ func (d *DevEnv) StartAllGenerators() error {
 	return nil
 }
 
+// OnGeneratorCompleted pushes the stopped state of a generator that reached
+// its MaxRequests to the page.
+func (d *DevEnv) OnGeneratorCompleted(gen *runtime.Generator) {
+	if page := d.getPage(); page != nil {
+		page.UpdateGenerator(gen.Name, gen.Generator)
+		page.LogMessage("info", fmt.Sprintf("Generator '%s' completed after %d requests", gen.Name, gen.Emitted()), "generator")
+	}
+}
+
 // StopAllGenerators stops all registered generators.
 func (d *DevEnv) StopAllGenerators() error {
 	d.stopAllGeneratorsInternal()
@@ -369,12 +591,21 @@ func (d *DevEnv) AddMetric(spec *runtime.Metric) error {
 	if err := d.metricTracer.AddMetric(spec); err != nil {
 		return err
 	}
+	d.exportMetric(spec)
 	if page := d.getPage(); page != nil {
 		page.UpdateMetric(spec.Name, spec.Metric)
 	}
 	return nil
 }
 
+// StopMetrics stops metric collection and writes any partially aggregated
+// windows so the final values can be queried, eg at the end of a headless run.
+func (d *DevEnv) StopMetrics() {
+	if d.metricTracer != nil {
+		d.metricTracer.StopAll()
+	}
+}
+
 // RemoveMetric removes a metric by ID and notifies the page.
 func (d *DevEnv) RemoveMetric(id string) error {
 	if d.metricTracer == nil {
@@ -397,14 +628,16 @@ func (d *DevEnv) SetParameter(path string, value any) error {
 	}
 
 	parts := strings.Split(path, ".")
+	if len(parts) < 2 {
+		return fmt.Errorf("invalid parameter path '%s': expected comp1.comp2...compN.ParamName", path)
+	}
 	componentPath, paramName := strings.Join(parts[:len(parts)-1], "."), parts[len(parts)-1]
-	componentInstance := d.activeSystem.FindComponent(componentPath)
-	if componentInstance == nil {
-		return fmt.Errorf("component '%s' not found", componentPath)
+	componentInstance, err := d.activeSystem.ResolveComponent(componentPath)
+	if err != nil {
+		return fmt.Errorf("cannot set '%s': %w", path, err)
 	}
 
 	var newValue decl.Value
-	var err error
 	switch v := value.(type) {
 	case int:
 		newValue, err = decl.NewValue(decl.IntType, int64(v))
@@ -423,9 +656,92 @@ func (d *DevEnv) SetParameter(path string, value any) error {
 		return err
 	}
 
+	if err := d.checkOverrideShadowing(path, parts, paramName); err != nil {
+		return err
+	}
+
+	d.paramsVersion++
 	return componentInstance.Set(paramName, newValue)
 }
 
+// SetProfile switches the component at path to one of its declared
+// profiles, or back to its own methods when profile is empty, and recomputes
+// the flows as the profile's methods may call other components.
+func (d *DevEnv) SetProfile(path, profile string) error {
+	if d.activeSystem == nil {
+		return fmt.Errorf("no active system")
+	}
+	comp, err := d.activeSystem.ResolveComponent(path)
+	if err != nil {
+		return fmt.Errorf("cannot set profile of '%s': %w", path, err)
+	}
+	if err := comp.SetProfile(profile); err != nil {
+		return err
+	}
+	d.paramsVersion++
+	return d.recomputeSystemFlows()
+}
+
+// ScheduleParameter sets a parameter once delay has passed on the clock.  The
+// set is skipped if the active system has changed by then or cancel is called,
+// and failures are reported to the page.
+func (d *DevEnv) ScheduleParameter(path string, value any, delay time.Duration) (cancel func()) {
+	system := d.activeSystem
+	return d.clock.AfterFunc(delay, func() {
+		if d.activeSystem != system {
+			return
+		}
+		if err := d.SetParameter(path, value); err != nil {
+			slog.Warn("Scheduled parameter set failed", "path", path, "error", err)
+			if page := d.getPage(); page != nil {
+				page.LogMessage("error", fmt.Sprintf("scheduled set of '%s' failed: %v", path, err), "parameter")
+			}
+		}
+	})
+}
+
+// SetStrictParameters controls whether SetParameter rejects (strict) or only
+// warns about setting a parameter that a uses override also declares.
+func (d *DevEnv) SetStrictParameters(strict bool) {
+	d.strictParams = strict
+}
+
+// checkOverrideShadowing warns (or errors when strict) if the parameter being
+// set is also fixed declaratively by the override on the uses declaration
+// that created its component, since the two compete for the same value.
+func (d *DevEnv) checkOverrideShadowing(path string, parts []string, paramName string) error {
+	if len(parts) < 3 {
+		return nil // System level components are not created by a uses declaration
+	}
+	depName := parts[len(parts)-2]
+	parent, err := d.activeSystem.ResolveComponent(strings.Join(parts[:len(parts)-2], "."))
+	if err != nil {
+		return nil
+	}
+	uses, _ := parent.ComponentDecl.GetDependency(depName)
+	if uses == nil {
+		return nil
+	}
+	for _, override := range uses.Overrides {
+		if override.Var.Value != paramName {
+			continue
+		}
+		msg := fmt.Sprintf("parameter '%s' is also set by the override '%s' on 'uses %s %s' in component %s (line %d). "+
+			"Precedence: a runtime set replaces the override's value until the system is reinitialized, when the override applies again; "+
+			"the override in turn replaces the param's default",
+			path, paramName, depName, uses.ComponentName.Value, parent.ComponentDecl.Name.Value, override.Pos().Line)
+		if d.strictParams {
+			return fmt.Errorf("%s", msg)
+		}
+		slog.Warn(msg)
+		if page := d.getPage(); page != nil {
+			page.LogMessage("warning", msg, "parameter")
+		}
+		return nil
+	}
+	return nil
+}
+
 // Diagram
 
 // GetSystemDiagram builds and returns the current system topology.
@@ -433,7 +749,97 @@ func (d *DevEnv) GetSystemDiagram() (*SystemDiagram, error) {
 	if d.activeSystem == nil {
 		return nil, fmt.Errorf("no active system")
 	}
-	return BuildSystemDiagram(d.activeSystem, d.generators, d.currentFlowScope, d.getCurrentFlowRates())
+	return BuildSystemDiagram(d.activeSystem, d.generators, d.currentFlowScope, d.getCurrentFlowRates(), d.displayPrecision)
+}
+
+// GetFlowDiagram returns the system diagram annotated with the evaluated
+// flows so it doubles as a heatmap: edges are labelled with the rate of calls
+// along them and the mean latency the called method adds, estimated from
+// samples calls, and nodes carry their component's utilization.  Without
+// evaluated flows this is the plain diagram.
+func (d *DevEnv) GetFlowDiagram(samples int) (*SystemDiagram, error) {
+	diagram, err := d.GetSystemDiagram()
+	if err != nil || len(d.getCurrentFlowRates()) == 0 {
+		return diagram, err
+	}
+	latencies := map[string]float64{}
+	for i := range diagram.Edges {
+		edge := &diagram.Edges[i]
+		if edge.ToMethod == "" {
+			continue
+		}
+		target := strings.TrimSuffix(edge.ToID, ":"+edge.ToMethod) + "." + edge.ToMethod
+		latency, ok := latencies[target]
+		if !ok {
+			summary, err := d.EstimateDistribution(target, samples)
+			if err != nil {
+				return nil, err
+			}
+			latency = summary.Mean.Value / 1000
+			latencies[target] = latency
+		}
+		edge.Latency = latency
+		if edge.Label != "" {
+			edge.Label += ", " + runtime.FormatMetricValue(runtime.MetricLatency, latency, d.DisplayPrecision())
+		}
+	}
+	for i := range diagram.Nodes {
+		node := &diagram.Nodes[i]
+		if comp := d.activeSystem.FindComponent(node.FullPath); comp != nil {
+			for _, info := range comp.GetUtilizationInfo() {
+				node.Utilization = max(node.Utilization, info.Utilization)
+			}
+		}
+	}
+	return diagram, nil
+}
+
+// GetFlows returns the evaluated flow through every component method with a
+// non zero arrival rate, sorted by component and method.  Service rates and
+// utilization come from the component's busiest resource and are 0 for SDL
+// components, which have no capacity of their own.  The latency a method
+// contributes is the mean latency of a call to it estimated from samples
+// calls.  Without evaluated flows there are no entries.
+func (d *DevEnv) GetFlows(samples int) ([]FlowEntry, error) {
+	if d.activeSystem == nil {
+		return nil, fmt.Errorf("no active system")
+	}
+	entries := []FlowEntry{}
+	for comp, methods := range d.currentFlowRates {
+		if comp == nil {
+			continue
+		}
+		var serviceRate, utilization float64
+		if comp.IsNative {
+			for _, info := range comp.GetUtilizationInfo() {
+				if info.Utilization > utilization {
+					utilization = info.Utilization
+					serviceRate = info.CurrentLoad / info.Utilization
+				}
+			}
+		}
+		for method, rate := range methods {
+			if rate <= 0 {
+				continue
+			}
+			summary, err := d.EstimateDistribution(comp.ID()+"."+method, samples)
+			if err != nil {
+				return nil, err
+			}
+			entries = append(entries, FlowEntry{
+				Component:           comp.ID(),
+				Method:              method,
+				ArrivalRate:         rate,
+				ServiceRate:         serviceRate,
+				Utilization:         utilization,
+				LatencyContribution: summary.Mean.Value / 1000,
+			})
+		}
+	}
+	slices.SortFunc(entries, func(a, b FlowEntry) int {
+		return cmp.Or(strings.Compare(a.Component, b.Component), strings.Compare(a.Method, b.Method))
+	})
+	return entries, nil
 }
 
 // Flow analysis
@@ -460,7 +866,7 @@ func (d *DevEnv) EvaluateFlows(strategy string) (*runtime.FlowAnalysisResult, er
 	}
 	d.generatorsLock.RUnlock()
 
-	result, err := runtime.EvaluateFlowStrategy(strategy, d.activeSystem, generators)
+	result, err := runtime.EvaluateFlowStrategy(strategy, d.activeSystem, generators, d.flowSolverOptions)
 	if err != nil {
 		return nil, err
 	}
@@ -496,14 +902,361 @@ func (d *DevEnv) EvaluateFlows(strategy string) (*runtime.FlowAnalysisResult, er
 	return result, nil
 }
 
+// Simulation runs
+
+// RunOptions configures a batch simulation run via RunSimulation.
+type RunOptions struct {
+	Target  string // "component.method" to invoke
+	Runs    int    // Total number of calls
+	Workers int    // Concurrent workers (defaults to 10)
+	Seed    int64  // Random seed, 0 for the system's seed option or else a time based seed
+	NoCache bool   // Bypass the run cache
+	Prime   int    // Calls made before the measured runs to warm up stateful components
+
+	// Measure while every generator drives background load, so latencies show
+	// the contention its traffic causes.  Results are never cached.
+	UnderLoad bool
+	Warmup    time.Duration // Generator traffic replayed before measuring under load, defaults to 10s
+
+	// Make a single call, ignoring Runs, and log every random decision it
+	// makes to the decision log (see SetDecisionLog).  Results are never
+	// cached.
+	Debug bool
+}
+
+// RunSimulation invokes the target method Runs times on the active system and
+// returns the per run results.  Identical seeded runs (same loaded files,
+// system, parameters, seed, target and run count) are served from a bounded
+// cache unless NoCache is set.  Runs with a time based seed are never cached
+// as each gives a different sample, nor are runs of systems whose components
+// have state the runs change (eg caches still warming up), as repeating them
+// gives different results.  cached reports whether results came from the
+// cache.
+func (d *DevEnv) RunSimulation(opts RunOptions) (results []types.RunResult, cached bool, err error) {
+	if d.activeSystem == nil {
+		return nil, false, fmt.Errorf("no active system")
+	}
+	if opts.Debug {
+		opts.Runs, opts.NoCache = 1, true
+	}
+	if opts.Runs <= 0 {
+		return nil, false, fmt.Errorf("run count must be positive, got %d", opts.Runs)
+	}
+	componentName, methodName, err := d.runTarget(opts.Target)
+	if err != nil {
+		return nil, false, err
+	}
+	defer func() {
+		if err == nil {
+			d.runHistory.Add(newRunRecord(opts.Target, d.activeSystem, results, cached, d.clock.Now()))
+		}
+	}()
+	if opts.Seed == 0 {
+		opts.Seed = d.defaultSeed
+	}
+	if opts.Debug && d.decisionLog == nil {
+		return nil, false, fmt.Errorf("debug runs need a decision log to write to")
+	}
+	if opts.UnderLoad {
+		restore, err := d.applyBackgroundLoad(opts.Warmup)
+		if err != nil {
+			return nil, false, err
+		}
+		defer restore()
+		opts.NoCache = true
+	}
+	if opts.Prime > 0 {
+		if err := d.Prime(opts.Target, opts.Prime); err != nil {
+			return nil, false, err
+		}
+	}
+
+	if opts.Debug {
+		val, latency, err := runtime.RunCallWithDecisions(d.activeSystem, componentName, methodName, opts.Seed, d.decisionLog)
+		if err != nil {
+			return nil, false, err
+		}
+		return []types.RunResult{{Latency: latency * 1000, ResultValue: val.String()}}, false, nil
+	}
+
+	if opts.Seed == 0 || d.activeSystem.HasRunState() {
+		opts.NoCache = true
+	}
+	key := runCacheKey(d.currentRuntime().Loader, d.activeSystem, d.paramsVersion, opts)
+	if !opts.NoCache {
+		if results, ok := d.runCache.Get(key); ok {
+			return results, true, nil
+		}
+	}
+
+	numWorkers := opts.Workers
+	if numWorkers <= 0 {
+		numWorkers = 10
+	}
+	batchSize := min(max(opts.Runs/100, 1), 1000)
+	numBatches := (opts.Runs + batchSize - 1) / batchSize
+
+	batches := make([][]types.RunResult, numBatches)
+	_, err = runtime.RunCallInBatchesWithBudget(d.activeSystem, componentName, methodName, numBatches, batchSize, numWorkers, opts.Seed, d.runBudget, func(batch int, batchVals []decl.Value) {
+		batchResults := make([]types.RunResult, len(batchVals))
+		for i, val := range batchVals {
+			batchResults[i] = types.RunResult{
+				Latency:     val.Time * 1000,
+				ResultValue: val.String(),
+			}
+		}
+		batches[batch] = batchResults
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	// Assign synthetic timestamps in batch order so results are deterministic for a seed
+	var simTime float64
+	for _, batch := range batches {
+		for _, r := range batch {
+			if len(results) == opts.Runs {
+				break
+			}
+			simTime += r.Latency
+			r.Timestamp = int64(simTime)
+			results = append(results, r)
+		}
+	}
+
+	if !opts.NoCache {
+		d.runCache.Put(key, results)
+	}
+	return results, false, nil
+}
+
+// EstimateDistribution approximates the latency distribution of the target
+// method for a quick preview by sampling it samples times in isolation, ie
+// without generators or metrics.  More samples give a tighter estimate and
+// the summary's confidence intervals show how far to trust it.  Estimates
+// are neither cached nor recorded in the run history.
+func (d *DevEnv) EstimateDistribution(target string, samples int) (types.RunSummary, error) {
+	if samples <= 0 {
+		return types.RunSummary{}, fmt.Errorf("sample count must be positive, got %d", samples)
+	}
+	if d.activeSystem == nil {
+		return types.RunSummary{}, fmt.Errorf("no active system")
+	}
+	componentName, methodName, err := d.runTarget(target)
+	if err != nil {
+		return types.RunSummary{}, err
+	}
+	hist, err := runtime.RunCallAggregates(d.activeSystem, componentName, methodName, samples, 10, d.defaultSeed, d.runBudget)
+	if err != nil {
+		return types.RunSummary{}, err
+	}
+	return types.SummarizeHistogram(hist), nil
+}
+
+// Prime invokes the target method calls times and discards the results so
+// stateful components (eg caches with WarmupReads) reach steady state before
+// measurement.  Priming calls are not traced so they never reach metrics.
+func (d *DevEnv) Prime(target string, calls int) error {
+	if d.activeSystem == nil {
+		return fmt.Errorf("no active system")
+	}
+	if calls <= 0 {
+		return fmt.Errorf("priming call count must be positive, got %d", calls)
+	}
+	parts := strings.Split(target, ".")
+	if len(parts) < 2 {
+		return fmt.Errorf("invalid target '%s', expected component.method", target)
+	}
+	componentName, methodName := strings.Join(parts[:len(parts)-1], "."), parts[len(parts)-1]
+	if _, err := d.activeSystem.ResolveComponent(componentName); err != nil {
+		return err
+	}
+
+	batchSize := min(max(calls/100, 1), 1000)
+	numBatches := (calls + batchSize - 1) / batchSize
+	_, err := runtime.RunCallInBatches(d.activeSystem, componentName, methodName, numBatches, batchSize, 10, func(int, []decl.Value) {})
+	d.paramsVersion++
+	return err
+}
+
+// applyBackgroundLoad starts every generator, applies the arrival rates their
+// traffic causes and replays warmup worth of that traffic so stateful
+// components reach steady state.  The returned func stops the generators it
+// started and restores the previous arrival rates.
+func (d *DevEnv) applyBackgroundLoad(warmup time.Duration) (restore func(), err error) {
+	if warmup <= 0 {
+		warmup = 10 * time.Second
+	}
+	d.generatorsLock.RLock()
+	gens := make([]*runtime.Generator, 0, len(d.generators))
+	var configs []runtime.GeneratorConfigAPI
+	for _, gen := range d.generators {
+		gens = append(gens, gen)
+		configs = append(configs, runtime.GeneratorConfigAPI{
+			ID:        gen.Name,
+			Component: gen.Component,
+			Method:    gen.Method,
+			Rate:      float64(gen.Rate),
+		})
+	}
+	d.generatorsLock.RUnlock()
+	if len(gens) == 0 {
+		return nil, fmt.Errorf("no generators to run under load, add one with a rate first")
+	}
+
+	// Evaluating flows applies their rates so take the previous ones first
+	previous := runtime.NewFlowScope(d.activeSystem.Env)
+	previous.ArrivalRates = d.activeSystem.ArrivalRates()
+	var started []*runtime.Generator
+	restore = func() {
+		for _, gen := range started {
+			gen.Stop(false)
+		}
+		if err := previous.ApplyToComponents(); err != nil {
+			slog.Warn("Failed to restore some arrival rates", "error", err)
+		}
+	}
+	strategy := d.currentFlowStrategy
+	if strategy == "" {
+		strategy = "runtime"
+	}
+	if _, err := runtime.EvaluateFlowStrategy(strategy, d.activeSystem, configs, d.flowSolverOptions); err != nil {
+		restore()
+		return nil, err
+	}
+	for _, gen := range gens {
+		if !gen.Enabled {
+			gen.Start()
+			started = append(started, gen)
+		}
+	}
+
+	for _, gen := range gens {
+		calls := int(math.Ceil(float64(gen.Rate) * warmup.Seconds()))
+		if calls <= 0 {
+			continue
+		}
+		batchSize := min(max(calls/100, 1), 1000)
+		numBatches := (calls + batchSize - 1) / batchSize
+		if _, err := runtime.RunCallInBatches(d.activeSystem, gen.Component, gen.Method, numBatches, batchSize, 10, func(int, []decl.Value) {}); err != nil {
+			restore()
+			return nil, fmt.Errorf("warming up generator '%s': %w", gen.Name, err)
+		}
+	}
+	d.paramsVersion++
+	return restore, nil
+}
+
+// SetMaxDepth sets the maximum number of nested method calls allowed in a
+// single run.  Runs that recurse deeper fail with a runtime.CallDepthError
+// carrying the call stack.
+func (d *DevEnv) SetMaxDepth(depth int) error {
+	if depth <= 0 {
+		return fmt.Errorf("max depth must be positive, got %d", depth)
+	}
+	d.currentRuntime().MaxCallDepth = depth
+	d.paramsVersion++
+	return nil
+}
+
+// applySystemOptions applies the active system's effective options (file
+// options overridden by the system's) as the environment's defaults.  Options
+// it does not set are reset to their defaults so none carry over from the
+// previously used system.
+func (d *DevEnv) applySystemOptions() error {
+	d.defaultSeed = 0
+	d.currentRuntime().MaxCallDepth = runtime.DefaultMaxCallDepth
+	d.displayPrecision = core.DefaultDisplayPrecision
+	d.strictParams = false
+	d.flowSolverOptions.BranchProbability = runtime.DefaultFlowSolverOptions().BranchProbability
+	for name, value := range d.activeSystem.Options() {
+		var err error
+		switch name {
+		case "seed":
+			d.defaultSeed = value.Value.(int64)
+		case "max_depth":
+			err = d.SetMaxDepth(int(value.Value.(int64)))
+		case "precision":
+			err = d.SetDisplayPrecision(int(value.Value.(int64)))
+		case "strict_params":
+			d.SetStrictParameters(value.Value.(bool))
+		case "branch_probability":
+			err = d.SetBranchProbability(value.Value.(float64))
+		}
+		if err != nil {
+			return fmt.Errorf("option %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// ShutdownReport summarizes what Shutdown stopped and flushed.
+type ShutdownReport struct {
+	GeneratorsStopped int
+	MetricsFlushed    int
+	PointsStored      int64 // Points held by the store once all windows were flushed
+	TimedOut          bool  // Some generators had not drained before ctx expired
+}
+
+// Shutdown stops every generator, waiting for in-flight calls to finish,
+// flushes partially filled metric windows into the store and closes it.
+// Generators that have not drained by the time ctx expires are reported as
+// TimedOut, but the store is only closed once they have all exited so none
+// of their calls write to a closed store.
+func (d *DevEnv) Shutdown(ctx context.Context) (report ShutdownReport, err error) {
+	d.generatorsLock.RLock()
+	var running []*runtime.Generator
+	for _, gen := range d.generators {
+		if gen.IsRunning() {
+			running = append(running, gen)
+		}
+	}
+	d.generatorsLock.RUnlock()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		var wg sync.WaitGroup
+		for _, gen := range running {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				gen.Stop(true)
+			}()
+		}
+		wg.Wait()
+	}()
+	select {
+	case <-done:
+	case <-ctx.Done():
+		report.TimedOut = true
+		log.Printf("Shutdown: generators still draining, waiting for them before closing the store")
+		<-done
+	}
+	report.GeneratorsStopped = len(running)
+
+	if d.metricTracer == nil {
+		return report, nil
+	}
+	d.metricTracer.StopAll()
+	report.MetricsFlushed = len(d.metricTracer.ListMetric())
+	if store := d.metricTracer.GetMetricStore(); store != nil {
+		report.PointsStored = store.Stats().TotalRows
+		err = store.Close()
+	}
+	return report, err
+}
+
 // Close stops all generators, clears metrics, and releases resources.
 func (d *DevEnv) Close() error {
 	d.stopAllGeneratorsInternal()
+	d.clearControllers()
 	if d.metricTracer != nil {
 		d.metricTracer.Clear()
 		d.metricTracer = nil
 	}
-	return nil
+	d.closeMetricExporters()
+	return d.SetTraceSink(nil)
 }
 
 // GetGenerator returns a generator by name, or nil.
@@ -532,22 +1285,75 @@ func (d *DevEnv) BatchSetParameters(updates map[string]any) error {
 // ExecuteTrace runs a single simulated call through a component method
 // and returns the full execution trace.
 func (d *DevEnv) ExecuteTrace(componentName, methodName string) (*runtime.TraceData, error) {
+	data := &runtime.TraceData{
+		EntryPoint: fmt.Sprintf("%s.%s", componentName, methodName),
+		Events:     []*runtime.TraceEvent{},
+	}
+	err := d.TraceStream(data.EntryPoint, func(event *runtime.TraceEvent) {
+		data.Events = append(data.Events, event)
+	})
+	if err != nil {
+		return nil, err
+	}
+	data.System = d.activeSystem.System.Name.Value
+	return data, nil
+}
+
+// Attribution traces target ("component.method") runs times and breaks the
+// accumulated latency down by the method it was spent in.
+func (d *DevEnv) Attribution(target string, runs int) (*runtime.LatencyAttribution, error) {
+	if runs <= 0 {
+		return nil, fmt.Errorf("run count must be positive, got %d", runs)
+	}
+	attribution := runtime.NewLatencyAttribution()
+	for range runs {
+		data := &runtime.TraceData{EntryPoint: target}
+		err := d.TraceStream(target, func(event *runtime.TraceEvent) {
+			data.Events = append(data.Events, event)
+		})
+		if err != nil {
+			return nil, err
+		}
+		attribution.Add(runtime.BuildTraceTree(data))
+	}
+	return attribution, nil
+}
+
+// TraceStream runs a single traced execution of target ("component.method")
+// and passes each enter and exit event to emit as the call unfolds, so a
+// consumer can render the tree live or discard closed subtrees instead of
+// holding the whole trace.  Exit events carry the ID of the enter they close.
+func (d *DevEnv) TraceStream(target string, emit func(*runtime.TraceEvent)) error {
 	if d.activeSystem == nil {
-		return nil, fmt.Errorf("no active system")
+		return fmt.Errorf("no active system")
+	}
+	lastDot := strings.LastIndex(target, ".")
+	if lastDot <= 0 || lastDot == len(target)-1 {
+		return fmt.Errorf("invalid target '%s': expected component.method", target)
 	}
+	componentName, methodName := target[:lastDot], target[lastDot+1:]
 
 	compInst := d.activeSystem.FindComponent(componentName)
 	if compInst == nil {
-		return nil, fmt.Errorf("component '%s' not found", componentName)
+		return fmt.Errorf("component '%s' not found", componentName)
 	}
 
-	methodDecl, err := compInst.ComponentDecl.GetMethod(methodName)
-	if err != nil || methodDecl == nil {
-		return nil, fmt.Errorf("method '%s' not found in component '%s'", methodName, componentName)
+	methodDecl := compInst.Method(methodName)
+	if methodDecl == nil {
+		return fmt.Errorf("method '%s' not found in component '%s'", methodName, componentName)
 	}
 
-	tracer := runtime.NewExecutionTracer()
-	tracer.SetRuntime(d.runtime)
+	d.traceSinkLock.Lock()
+	sink := d.traceSink
+	d.traceSinkLock.Unlock()
+	var sinkErr error
+	tracer := runtime.NewStreamingTracer(func(event *runtime.TraceEvent) {
+		emit(event)
+		if sink != nil && sinkErr == nil {
+			sinkErr = sink.Write(event)
+		}
+	})
+	tracer.SetRuntime(d.currentRuntime())
 
 	eval := runtime.NewSimpleEval(d.activeSystem.File, tracer)
 	env := d.activeSystem.Env.Push()
@@ -569,13 +1375,38 @@ func (d *DevEnv) ExecuteTrace(componentName, methodName string) (*runtime.TraceD
 		},
 	}
 
-	eval.Eval(callExpr, env, &currTime)
+	if _, err := eval.EvalCall(callExpr, env, &currTime); err != nil {
+		return err
+	}
+	if sinkErr != nil {
+		return fmt.Errorf("writing trace: %w", sinkErr)
+	}
+	return nil
+}
 
-	return &runtime.TraceData{
-		System:     d.activeSystem.System.Name.Value,
-		EntryPoint: fmt.Sprintf("%s.%s", componentName, methodName),
-		Events:     tracer.Events,
-	}, nil
+// SetTraceSink sets where the events of every traced run are also written,
+// eg a runtime.FileTraceSink for long captures.  The previous sink is closed.
+// A nil sink stops writing traces.
+func (d *DevEnv) SetTraceSink(sink runtime.TraceSink) error {
+	d.traceSinkLock.Lock()
+	previous := d.traceSink
+	d.traceSink = sink
+	d.traceSinkLock.Unlock()
+	if previous != nil {
+		return previous.Close()
+	}
+	return nil
+}
+
+// SetDecisionLog sets where debug runs (RunOptions.Debug) write the random
+// decisions of their call, as newline delimited JSON of runtime.Decision.  A
+// nil writer stops logging decisions.
+func (d *DevEnv) SetDecisionLog(w io.Writer) {
+	if w == nil {
+		d.decisionLog = nil
+		return
+	}
+	d.decisionLog = runtime.NewDecisionLog(w)
 }
 
 // TraceAllPaths performs breadth-first traversal to discover all possible
@@ -605,6 +1436,105 @@ func (d *DevEnv) GetUtilization() []*runtime.ComponentUtilization {
 	return runtime.GetSystemUtilization(d.activeSystem)
 }
 
+// SetFlowSolverOptions sets the tolerance (the largest rate change, in calls
+// per second, still treated as converged) and iteration cap of the flow solver.
+func (d *DevEnv) SetFlowSolverOptions(tolerance float64, maxIters int) error {
+	if tolerance <= 0 {
+		return fmt.Errorf("flow tolerance must be positive, got %g", tolerance)
+	}
+	if maxIters <= 0 {
+		return fmt.Errorf("flow iteration cap must be positive, got %d", maxIters)
+	}
+	d.flowSolverOptions.Tolerance = tolerance
+	d.flowSolverOptions.MaxIterations = maxIters
+	return nil
+}
+
+// SetBranchProbability sets the probability flow evaluation assumes for if
+// conditions whose outcome it cannot determine, eg comparisons of params.
+func (d *DevEnv) SetBranchProbability(probability float64) error {
+	if probability < 0 || probability > 1 {
+		return fmt.Errorf("branch probability must be between 0 and 1, got %g", probability)
+	}
+	d.flowSolverOptions.BranchProbability = probability
+	return nil
+}
+
+// FlowSolverOptions returns the options used when evaluating flows.
+func (d *DevEnv) FlowSolverOptions() runtime.FlowSolverOptions {
+	return d.flowSolverOptions
+}
+
+// CheckSaturation evaluates flows under the rates of the enabled generators
+// and returns the components whose offered load exceeds their capacity, the
+// bottleneck first.  Latencies measured under such load are not meaningful as
+// the saturated component's queue grows without bound.
+func (d *DevEnv) CheckSaturation() ([]*runtime.Saturation, error) {
+	strategy := d.currentFlowStrategy
+	if strategy == "" {
+		strategy = "runtime"
+	}
+	if _, err := d.EvaluateFlows(strategy); err != nil {
+		return nil, err
+	}
+	return runtime.FindSaturation(d.activeSystem), nil
+}
+
+// GeneratorWarnings returns a warning for each generator whose target method
+// has no measurable effect, eg an empty body, which usually means the
+// generator is not on the intended entry point.
+func (d *DevEnv) GeneratorWarnings() (warnings []string) {
+	d.generatorsLock.RLock()
+	defer d.generatorsLock.RUnlock()
+	for _, name := range slices.Sorted(maps.Keys(d.generators)) {
+		if warning := d.generators[name].NoEffectWarning(); warning != "" {
+			warnings = append(warnings, warning)
+		}
+	}
+	return
+}
+
+// DisplayPrecision returns the number of significant figures used for display.
+func (d *DevEnv) DisplayPrecision() int {
+	return d.displayPrecision
+}
+
+// SetRunBudget limits the wall clock time and the trace nodes (method calls)
+// of every run and of each generator added afterwards, which abort with a
+// *runtime.BudgetExceededError when over budget.  Zero leaves a limit
+// unbounded, as it is by default.
+func (d *DevEnv) SetRunBudget(maxWallClock time.Duration, maxTraceNodes int) error {
+	if maxWallClock < 0 || maxTraceNodes < 0 {
+		return fmt.Errorf("run budget must not be negative")
+	}
+	d.runBudget = runtime.RunBudget{MaxWallClock: maxWallClock, MaxTraceNodes: maxTraceNodes}
+	return nil
+}
+
+// SetDisplayPrecision sets the number of significant figures used for display.
+func (d *DevEnv) SetDisplayPrecision(precision int) error {
+	if precision <= 0 {
+		return fmt.Errorf("display precision must be positive, got %d", precision)
+	}
+	d.displayPrecision = precision
+	return nil
+}
+
+// FormatMetricValue formats a value of the named metric for display using the
+// current display precision.  Raw values should be used for machine consumption.
+func (d *DevEnv) FormatMetricValue(metricName string, value float64) string {
+	metricType := ""
+	if d.metricTracer != nil {
+		for _, m := range d.metricTracer.ListMetrics() {
+			if m.Name == metricName {
+				metricType = m.MetricType
+				break
+			}
+		}
+	}
+	return runtime.FormatMetricValue(metricType, value, d.displayPrecision)
+}
+
 // QueryMetrics queries metric data points from the tracer's store.
 func (d *DevEnv) QueryMetrics(metricName string, opts runtime.QueryOptions) (runtime.QueryResult, error) {
 	if d.metricTracer == nil {
@@ -613,6 +1543,30 @@ func (d *DevEnv) QueryMetrics(metricName string, opts runtime.QueryOptions) (run
 	return d.metricTracer.QueryMetrics(context.Background(), metricName, opts)
 }
 
+// RecentMetricPoints returns up to count of the latest points of a metric,
+// oldest first.
+func (d *DevEnv) RecentMetricPoints(metricName string, count int) ([]*runtime.MetricPoint, error) {
+	result, err := d.QueryMetrics(metricName, runtime.QueryOptions{EndTime: d.clock.Now(), Limit: count})
+	if err != nil {
+		return nil, err
+	}
+	points := slices.Clone(result.Points)
+	slices.Reverse(points)
+	return points, nil
+}
+
+// MeasurementStats reports how much data the metric store currently holds.
+func (d *DevEnv) MeasurementStats() (*runtime.MetricStoreStats, error) {
+	if d.metricTracer == nil {
+		return nil, fmt.Errorf("no metric tracer")
+	}
+	stats, err := d.metricTracer.StoreStats()
+	if err != nil {
+		return nil, err
+	}
+	return &stats, nil
+}
+
 // Internal helpers
 
 func (d *DevEnv) createDeclaredGenerators() error {
@@ -672,7 +1626,9 @@ func (d *DevEnv) createDeclaredMetrics() error {
 		}
 		if err := d.metricTracer.AddMetric(metricSpec); err != nil {
 			log.Printf("Warning: failed to create declared metric '%s': %v", m.Name, err)
+			continue
 		}
+		d.exportMetric(metricSpec)
 	}
 	return nil
 }
@@ -709,7 +1665,7 @@ func (d *DevEnv) recomputeSystemFlows() error {
 		strategy = "runtime"
 	}
 
-	result, err := runtime.EvaluateFlowStrategy(strategy, d.activeSystem, generators)
+	result, err := runtime.EvaluateFlowStrategy(strategy, d.activeSystem, generators, d.flowSolverOptions)
 	if err != nil {
 		return err
 	}
@@ -745,30 +1701,14 @@ func (d *DevEnv) getCurrentFlowRates() map[string]float64 {
 
 func (d *DevEnv) rateMapToStringMap(rateMap runtime.RateMap) map[string]float64 {
 	result := make(map[string]float64)
-
-	instanceToName := make(map[*runtime.ComponentInstance]string)
-	if d.activeSystem != nil && d.activeSystem.System != nil {
-		for _, param := range d.activeSystem.System.Parameters {
-			instanceName := param.Name.Value
-			if comp := d.activeSystem.FindComponent(instanceName); comp != nil {
-				instanceToName[comp] = instanceName
-			}
-		}
-	}
-
 	for component, methods := range rateMap {
 		if component != nil {
-			componentName := component.ID()
-			if name, found := instanceToName[component]; found {
-				componentName = name
-			}
 			for method, rate := range methods {
-				key := fmt.Sprintf("%s.%s", componentName, method)
+				key := fmt.Sprintf("%s.%s", component.ID(), method)
 				result[key] = rate
 			}
 		}
 	}
-
 	return result
 }
 
@@ -776,25 +1716,20 @@ func (d *DevEnv) convertFlowResultToRateMap(result *runtime.FlowAnalysisResult)
 	rateMap := runtime.NewRateMap()
 
 	for compMethod, rate := range result.Flows.ComponentRates {
-		parts := strings.Split(compMethod, ".")
-		if len(parts) >= 2 {
-			componentName := parts[0]
-			methodName := strings.Join(parts[1:], ".")
-			compInst := d.activeSystem.FindComponent(componentName)
+		// Components are paths so the method follows the last dot
+		if dot := strings.LastIndex(compMethod, "."); dot > 0 {
+			compInst := d.activeSystem.FindComponent(compMethod[:dot])
 			if compInst != nil {
-				rateMap.SetRate(compInst, methodName, rate)
+				rateMap.SetRate(compInst, compMethod[dot+1:], rate)
 			}
 		}
 	}
 
 	for compMethod, rate := range d.manualRateOverrides {
-		parts := strings.Split(compMethod, ".")
-		if len(parts) >= 2 {
-			componentName := parts[0]
-			methodName := strings.Join(parts[1:], ".")
-			compInst := d.activeSystem.FindComponent(componentName)
+		if dot := strings.LastIndex(compMethod, "."); dot > 0 {
+			compInst := d.activeSystem.FindComponent(compMethod[:dot])
 			if compInst != nil {
-				rateMap.SetRate(compInst, methodName, rate)
+				rateMap.SetRate(compInst, compMethod[dot+1:], rate)
 			}
 		}
 	}
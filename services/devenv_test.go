@@ -1,11 +1,27 @@
 package services
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"net/http"
+	"net/http/httptest"
 	"path/filepath"
 	"runtime"
+	"slices"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
+	protos "github.com/panyam/sdl/gen/go/sdl/v1/models"
+	"github.com/panyam/sdl/lib/core"
 	"github.com/panyam/sdl/lib/loader"
+	sdlruntime "github.com/panyam/sdl/lib/runtime"
+	"github.com/panyam/sdl/lib/types"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -80,6 +96,22 @@ func TestDevEnvUseSystemNotFound(t *testing.T) {
 
 	err = dev.Use("NonExistentSystem")
 	assert.Error(t, err)
+	assert.NotContains(t, err.Error(), "did you mean")
+}
+
+// TestDevEnvUseSuggestsSystem verifies that a near miss of a system name
+// suggests the loaded system it was probably meant to be.
+func TestDevEnvUseSuggestsSystem(t *testing.T) {
+	dev := newTestDevEnv()
+	require.NoError(t, dev.LoadFile(testFixturePath("system_with_generators.sdl")))
+
+	err := dev.Use("SimpleAppLoadTst")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "did you mean 'SimpleAppLoadTest'?")
+
+	err = dev.Use("simpleapploadtest")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "did you mean 'SimpleAppLoadTest'?")
 }
 
 // TestDevEnvPanelNotificationsOnUse verifies that when Use() activates a system,
@@ -265,6 +297,50 @@ func TestDevEnvGetSystemDiagram(t *testing.T) {
 	assert.NotEmpty(t, diagram.Nodes, "diagram should have nodes for the component topology")
 }
 
+// TestDevEnvGetFlowDiagram verifies that once flows are evaluated edges are
+// labelled with their rates and the latency of the called method, and nodes
+// with their utilization, and that it is the plain diagram before.
+func TestDevEnvGetFlowDiagram(t *testing.T) {
+	dev := newTestDevEnv()
+	require.NoError(t, dev.LoadFile(testFixturePath("attribution.sdl")))
+	require.NoError(t, dev.Use("App"))
+	plain, err := dev.GetFlowDiagram(10)
+	require.NoError(t, err)
+	for _, edge := range plain.Edges {
+		assert.Empty(t, edge.Label)
+		assert.Zero(t, edge.Latency)
+	}
+
+	gen := &sdlruntime.Generator{Generator: &protos.Generator{Name: "load", Component: "server", Method: "Handle", Rate: 10}}
+	require.NoError(t, dev.AddGenerator(gen))
+	defer dev.StopAllGenerators()
+	_, err = dev.EvaluateFlows("runtime")
+	require.NoError(t, err)
+	diagram, err := dev.GetFlowDiagram(10)
+	require.NoError(t, err)
+	labels := map[string]string{}
+	for _, edge := range diagram.Edges {
+		labels[edge.FromID+" -> "+edge.ToID] = edge.Label
+	}
+	assert.Equal(t, map[string]string{
+		"server:Handle -> server.cache:Get": "10 rps, 20ms",
+		"server:Handle -> server.db:Query":  "10 rps, 80ms",
+	}, labels)
+
+	dev = newTestDevEnv()
+	require.NoError(t, dev.LoadFile(testFixturePath("saturation.sdl")))
+	require.NoError(t, dev.Use("App"))
+	require.NoError(t, dev.AddGenerator(&sdlruntime.Generator{Generator: &protos.Generator{Name: "load", Component: "server", Method: "Handle", Rate: 10}}))
+	defer dev.StopAllGenerators()
+	_, err = dev.EvaluateFlows("runtime")
+	require.NoError(t, err)
+	diagram, err = dev.GetFlowDiagram(10)
+	require.NoError(t, err)
+	for _, node := range diagram.Nodes {
+		assert.InDelta(t, 0.5, node.Utilization, 1e-6, node.ID)
+	}
+}
+
 // TestDevEnvClose verifies that Close() stops all generators and cleans up
 // the metric tracer without panicking, even when called multiple times.
 func TestDevEnvClose(t *testing.T) {
@@ -281,3 +357,1430 @@ func TestDevEnvClose(t *testing.T) {
 	err = dev.Close()
 	require.NoError(t, err)
 }
+
+// TestDevEnvRunSimulationCache verifies that an identical second seeded run is
+// served from the run cache, that NoCache and time based seeds bypass it, and
+// that changing a parameter invalidates the cached results and forces a
+// recompute.
+func TestDevEnvRunSimulationCache(t *testing.T) {
+	dev := newTestDevEnv()
+	require.NoError(t, dev.LoadFile(testFixturePath("system_with_generators.sdl")))
+	require.NoError(t, dev.Use("SimpleAppLoadTest"))
+
+	opts := RunOptions{Target: "app.server.HandleRequest", Runs: 20, Seed: 42}
+	first, cached, err := dev.RunSimulation(opts)
+	require.NoError(t, err)
+	assert.False(t, cached)
+	assert.Len(t, first, 20)
+
+	second, cached, err := dev.RunSimulation(opts)
+	require.NoError(t, err)
+	assert.True(t, cached, "identical run should be served from cache")
+	assert.Equal(t, first, second)
+
+	// Callers get their own copy of cached results
+	second[0].Latency = -1
+	third, _, err := dev.RunSimulation(opts)
+	require.NoError(t, err)
+	assert.Equal(t, first[0].Latency, third[0].Latency)
+
+	// Time seeded runs each draw a new sample so they are never cached
+	for range 2 {
+		_, cached, err = dev.RunSimulation(RunOptions{Target: opts.Target, Runs: 20})
+		require.NoError(t, err)
+		assert.False(t, cached, "time seeded runs should not be cached")
+	}
+
+	opts.NoCache = true
+	_, cached, err = dev.RunSimulation(opts)
+	require.NoError(t, err)
+	assert.False(t, cached, "NoCache should bypass the cache")
+	opts.NoCache = false
+
+	// Different run counts are different inputs
+	_, cached, err = dev.RunSimulation(RunOptions{Target: opts.Target, Runs: 10, Seed: 42})
+	require.NoError(t, err)
+	assert.False(t, cached)
+
+	// A parameter change must invalidate
+	require.NoError(t, dev.SetParameter("app.server.Timeout", 5.0))
+	_, cached, err = dev.RunSimulation(opts)
+	require.NoError(t, err)
+	assert.False(t, cached, "parameter change should force a recompute")
+}
+
+// TestDevEnvSetParameterNested verifies that SetParameter walks through
+// multiple levels of `uses` dependencies and sets the value on the leaf instance.
+func TestDevEnvSetParameterNested(t *testing.T) {
+	dev := newTestDevEnv()
+	require.NoError(t, dev.LoadFile(testFixturePath("system_with_metrics.sdl")))
+	require.NoError(t, dev.Use("SimpleAppTest"))
+
+	require.NoError(t, dev.SetParameter("app.server.db.Timeout", 5.0))
+
+	db := dev.ActiveSystem().FindComponent("app.server.db")
+	require.NotNil(t, db)
+	value, ok := db.Get("Timeout")
+	require.True(t, ok, "parameter should be set on the db instance")
+	assert.Equal(t, 5.0, value.Value)
+
+	server := dev.ActiveSystem().FindComponent("app.server")
+	_, ok = server.Get("Timeout")
+	assert.False(t, ok, "parameter should not leak onto the parent instance")
+}
+
+// TestDevEnvSetParameterNestedError verifies that an unresolvable path segment
+// is reported by name along with the component it was looked up on.
+func TestDevEnvSetParameterNestedError(t *testing.T) {
+	dev := newTestDevEnv()
+	require.NoError(t, dev.LoadFile(testFixturePath("system_with_metrics.sdl")))
+	require.NoError(t, dev.Use("SimpleAppTest"))
+
+	err := dev.SetParameter("app.cache.db.Timeout", 5.0)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no dependency 'cache' on component SimpleApp")
+
+	err = dev.SetParameter("web.server.Timeout", 5.0)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no component 'web' in system SimpleAppTest")
+}
+
+// hitRate returns the fraction of runs that returned true.
+func hitRate(results []types.RunResult) float64 {
+	hits := 0
+	for _, r := range results {
+		if strings.Contains(r.ResultValue, "true") {
+			hits++
+		}
+	}
+	return float64(hits) / float64(len(results))
+}
+
+// TestDevEnvPrimeCache verifies that a cold cache has a low hit rate, and that
+// after priming the measured runs see the steady state hit rate.
+func TestDevEnvPrimeCache(t *testing.T) {
+	dev := newTestDevEnv()
+	require.NoError(t, dev.LoadFile(testFixturePath("cache_warmup.sdl")))
+	require.NoError(t, dev.Use("CacheWarmupTest"))
+
+	cold, _, err := dev.RunSimulation(RunOptions{Target: "app.Get", Runs: 500, Seed: 1})
+	require.NoError(t, err)
+	// Hit rate ramps from 0 to 40% over the first 500 reads
+	assert.InDelta(t, 0.2, hitRate(cold), 0.08)
+
+	require.NoError(t, dev.Prime("app.Get", 1000))
+	warm, cached, err := dev.RunSimulation(RunOptions{Target: "app.Get", Runs: 500, Seed: 1})
+	require.NoError(t, err)
+	assert.False(t, cached, "priming should invalidate cached runs")
+	assert.InDelta(t, 0.8, hitRate(warm), 0.08)
+
+	assert.Error(t, dev.Prime("app.Missing.Get", 10))
+}
+
+// TestDevEnvRunSimulationStateful verifies that runs of a cache still
+// warming up are not cached, since each run warms it further, and that runs
+// are cached again once it is warm.
+func TestDevEnvRunSimulationStateful(t *testing.T) {
+	dev := newTestDevEnv()
+	require.NoError(t, dev.LoadFile(testFixturePath("cache_warmup.sdl")))
+	require.NoError(t, dev.Use("CacheWarmupTest"))
+
+	opts := RunOptions{Target: "app.Get", Runs: 500, Seed: 1}
+	first, _, err := dev.RunSimulation(opts)
+	require.NoError(t, err)
+	second, cached, err := dev.RunSimulation(opts)
+	require.NoError(t, err)
+	assert.False(t, cached, "the first run changed the cache's state")
+	assert.Greater(t, hitRate(second), hitRate(first))
+
+	warm, cached, err := dev.RunSimulation(opts)
+	require.NoError(t, err)
+	assert.False(t, cached)
+	again, cached, err := dev.RunSimulation(opts)
+	require.NoError(t, err)
+	assert.True(t, cached, "a warm cache no longer changes")
+	assert.Equal(t, warm, again)
+}
+
+// TestDevEnvRunWithPrime verifies that RunOptions.Prime excludes the priming
+// calls from the results and measures the primed cache at steady state.
+func TestDevEnvRunWithPrime(t *testing.T) {
+	dev := newTestDevEnv()
+	require.NoError(t, dev.LoadFile(testFixturePath("cache_warmup.sdl")))
+	require.NoError(t, dev.Use("CacheWarmupTest"))
+
+	results, _, err := dev.RunSimulation(RunOptions{Target: "app.Get", Runs: 500, Seed: 1, Prime: 1000})
+	require.NoError(t, err)
+	assert.Len(t, results, 500)
+	assert.InDelta(t, 0.8, hitRate(results), 0.08)
+}
+
+// TestDevEnvRunBudget verifies that a run exploding into millions of calls,
+// or looping without making any, fails cleanly with a budget error.
+func TestDevEnvRunBudget(t *testing.T) {
+	dev := newTestDevEnv()
+	require.NoError(t, dev.LoadFile(testFixturePath("budget.sdl")))
+	require.NoError(t, dev.Use("BudgetTest"))
+	require.NoError(t, dev.SetRunBudget(0, 10000))
+
+	_, _, err := dev.RunSimulation(RunOptions{Target: "tree.Fan0", Runs: 5, Seed: 1})
+	var budgetErr *sdlruntime.BudgetExceededError
+	require.ErrorAs(t, err, &budgetErr)
+	assert.Greater(t, budgetErr.Nodes, int64(10000))
+	assert.Contains(t, err.Error(), "run budget exceeded: more than 10000 trace nodes")
+
+	// The budget covers the whole run, not each call
+	_, _, err = dev.RunSimulation(RunOptions{Target: "tree.Fan11", Runs: 20, Seed: 1})
+	require.ErrorAs(t, err, &budgetErr)
+	_, _, err = dev.RunSimulation(RunOptions{Target: "tree.Fan11", Runs: 2, Seed: 1})
+	require.NoError(t, err)
+
+	require.NoError(t, dev.SetRunBudget(50*time.Millisecond, 0))
+	_, _, err = dev.RunSimulation(RunOptions{Target: "tree.Spin", Runs: 1, Seed: 1})
+	require.ErrorAs(t, err, &budgetErr)
+	assert.Contains(t, err.Error(), "ran longer than 50ms")
+
+	assert.Error(t, dev.SetRunBudget(-time.Second, 0))
+}
+
+// TestDevEnvMaxCallDepth verifies that unbounded recursion fails the run with
+// the call stack that overflowed instead of crashing, and that recursion
+// within the limit still succeeds.
+func TestDevEnvMaxCallDepth(t *testing.T) {
+	dev := newTestDevEnv()
+	require.NoError(t, dev.LoadFile(testFixturePath("recursion.sdl")))
+	require.NoError(t, dev.Use("RecursionTest"))
+	require.NoError(t, dev.SetMaxDepth(10))
+
+	_, _, err := dev.RunSimulation(RunOptions{Target: "node.Loop", Runs: 5, Seed: 1})
+	require.Error(t, err)
+	var depthErr *sdlruntime.CallDepthError
+	require.ErrorAs(t, err, &depthErr)
+	assert.Equal(t, 10, depthErr.MaxDepth)
+	require.Len(t, depthErr.Stack, 11)
+	for _, frame := range depthErr.Stack {
+		assert.Equal(t, "Node.Loop", frame)
+	}
+	assert.Contains(t, err.Error(), "maximum call depth 10 exceeded")
+
+	// Start -> Middle -> Leaf needs a depth of exactly 3
+	require.NoError(t, dev.SetMaxDepth(2))
+	_, _, err = dev.RunSimulation(RunOptions{Target: "node.Start", Runs: 5, Seed: 1})
+	require.ErrorAs(t, err, &depthErr)
+	assert.Equal(t, []string{"Node.Start", "Node.Middle", "Node.Leaf"}, depthErr.Stack)
+
+	require.NoError(t, dev.SetMaxDepth(3))
+	results, _, err := dev.RunSimulation(RunOptions{Target: "node.Start", Runs: 5, Seed: 1})
+	require.NoError(t, err)
+	assert.Len(t, results, 5)
+
+	assert.Error(t, dev.SetMaxDepth(0))
+}
+
+// TestDevEnvGeneratorMaxRequests verifies that a generator with MaxRequests
+// emits exactly that many calls, stops itself and reports enabled=false,
+// for both the simple (low rate) and batched (high rate) loops.
+func TestDevEnvGeneratorMaxRequests(t *testing.T) {
+	dev := newTestDevEnv()
+	page := NewConsoleWorkspacePage(false)
+	dev.SetPage(page)
+	require.NoError(t, dev.LoadFile(testFixturePath("system_with_generators.sdl")))
+	require.NoError(t, dev.Use("SimpleAppLoadTest"))
+	require.NoError(t, dev.StopAllGenerators())
+
+	for _, tc := range []struct {
+		name  string
+		rate  float64
+		count int
+	}{
+		{"simple", 100, 10},
+		{"batched", 5000, 300},
+	} {
+		gen := &sdlruntime.Generator{
+			Generator:   &protos.Generator{Name: tc.name, Component: "app.server", Method: "HealthCheck", Rate: tc.rate},
+			MaxRequests: tc.count,
+		}
+		require.NoError(t, dev.AddGenerator(gen))
+		require.Eventually(t, func() bool { return !gen.IsRunning() }, 5*time.Second, 10*time.Millisecond, tc.name)
+
+		assert.Equal(t, int64(tc.count), gen.Emitted(), tc.name)
+		assert.False(t, gen.Enabled, tc.name)
+		require.Eventually(t, func() bool { return !page.Generators[tc.name].Enabled }, time.Second, 10*time.Millisecond, tc.name)
+	}
+
+	// Restarting runs another capped batch
+	require.NoError(t, dev.StartAllGenerators())
+	gen := dev.GetGenerator("simple")
+	require.Eventually(t, func() bool { return !gen.IsRunning() }, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, int64(10), gen.Emitted())
+	require.NoError(t, dev.StopAllGenerators())
+}
+
+// TestDevEnvShutdown verifies that Shutdown stops running generators, flushes
+// metric windows that have not closed yet into the store and closes it.
+func TestDevEnvShutdown(t *testing.T) {
+	dev := newTestDevEnv()
+	require.NoError(t, dev.LoadFile(testFixturePath("system_with_generators.sdl")))
+	require.NoError(t, dev.Use("SimpleAppLoadTest"))
+	// Drop the declared generators so only the one below is running
+	require.NoError(t, dev.RemoveGenerator("traffic"))
+	require.NoError(t, dev.RemoveGenerator("health"))
+
+	// A window far longer than the test so nothing is written before shutdown
+	require.NoError(t, dev.AddMetric(&sdlruntime.Metric{Metric: &protos.Metric{
+		Name:              "health_calls",
+		Component:         "app.server",
+		Methods:           []string{"HealthCheck"},
+		MetricType:        sdlruntime.MetricCount,
+		Aggregation:       "sum",
+		AggregationWindow: 60,
+		Enabled:           true,
+	}}))
+	gen := &sdlruntime.Generator{Generator: &protos.Generator{Name: "load", Component: "app.server", Method: "HealthCheck", Rate: 200}}
+	require.NoError(t, dev.AddGenerator(gen))
+	require.Eventually(t, func() bool { return gen.Emitted() > 10 }, 5*time.Second, 10*time.Millisecond)
+
+	stats, err := dev.MeasurementStats()
+	require.NoError(t, err)
+	assert.Zero(t, stats.TotalRows, "window should still be open")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	report, err := dev.Shutdown(ctx)
+	require.NoError(t, err)
+	assert.False(t, report.TimedOut)
+	assert.Equal(t, 1, report.GeneratorsStopped)
+	assert.Equal(t, len(dev.ListMetrics()), report.MetricsFlushed)
+	assert.Positive(t, report.PointsStored, "open window should be flushed")
+
+	assert.False(t, gen.IsRunning())
+	emitted := gen.Emitted()
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, emitted, gen.Emitted(), "no calls after shutdown")
+
+	_, err = dev.QueryMetrics("health_calls", sdlruntime.QueryOptions{})
+	assert.Error(t, err, "store should be closed")
+}
+
+// TestDevEnvShutdownTimeout verifies that when generators outlast ctx the
+// timeout is reported but the store is only closed after they have stopped.
+func TestDevEnvShutdownTimeout(t *testing.T) {
+	dev := newTestDevEnv()
+	require.NoError(t, dev.LoadFile(testFixturePath("system_with_generators.sdl")))
+	require.NoError(t, dev.Use("SimpleAppLoadTest"))
+	require.NoError(t, dev.RemoveGenerator("traffic"))
+	require.NoError(t, dev.RemoveGenerator("health"))
+	require.NoError(t, dev.AddMetric(&sdlruntime.Metric{Metric: &protos.Metric{
+		Name:              "health_calls",
+		Component:         "app.server",
+		Methods:           []string{"HealthCheck"},
+		MetricType:        sdlruntime.MetricCount,
+		Aggregation:       "sum",
+		AggregationWindow: 60,
+		Enabled:           true,
+	}}))
+	gen := &sdlruntime.Generator{Generator: &protos.Generator{Name: "load", Component: "app.server", Method: "HealthCheck", Rate: 200}}
+	require.NoError(t, dev.AddGenerator(gen))
+	require.Eventually(t, func() bool { return gen.Emitted() > 10 }, 5*time.Second, 10*time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	report, err := dev.Shutdown(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, report.GeneratorsStopped)
+	assert.Positive(t, report.PointsStored)
+
+	assert.False(t, gen.IsRunning())
+	emitted := gen.Emitted()
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, emitted, gen.Emitted(), "generators have exited before Shutdown returns")
+}
+
+// TestDevEnvSetParameterConstraint verifies that values inside a param's
+// declared constraint are accepted and values outside it are rejected with
+// the allowed values, leaving the previous value in place.
+func TestDevEnvSetParameterConstraint(t *testing.T) {
+	dev := newTestDevEnv()
+	require.NoError(t, dev.LoadFile(testFixturePath("param_constraints.sdl")))
+	require.NoError(t, dev.Use("CacheSystem"))
+	cache := dev.ActiveSystem().FindComponent("app.cache")
+	require.NotNil(t, cache)
+
+	require.NoError(t, dev.SetParameter("app.cache.HitRate", 0.5))
+	require.NoError(t, dev.SetParameter("app.cache.Size", 1000))
+	require.NoError(t, dev.SetParameter("app.cache.Policy", "lfu"))
+	value, _ := cache.Get("HitRate")
+	assert.Equal(t, 0.5, value.Value)
+
+	err := dev.SetParameter("app.cache.HitRate", 5.0)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "[0, 1]")
+	value, _ = cache.Get("HitRate")
+	assert.Equal(t, 0.5, value.Value, "rejected value should not be applied")
+
+	err = dev.SetParameter("app.cache.Size", -1)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "[1, 1000]")
+
+	err = dev.SetParameter("app.cache.Policy", "random")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "must be one of")
+}
+
+// TestDevEnvComputedOverrideViolatesConstraint verifies that a uses override
+// computed from another param fails Use when it violates the constraint.
+func TestDevEnvComputedOverrideViolatesConstraint(t *testing.T) {
+	fs := loader.NewMemoryFS()
+	fs.WriteFile("/models/main.sdl", []byte(`
+component Cache {
+  param HitRate Float = 0.8 in [0, 1]
+}
+component App {
+  param Boost Float = 2.0
+  uses cache Cache(HitRate = Boost * 0.8)
+}
+system Shop(app App) {
+}
+`))
+	dev := NewDevEnv(loader.NewFileSystemResolver(fs))
+	require.NoError(t, dev.LoadFile("/models/main.sdl"))
+	err := dev.Use("Shop")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cannot set Cache.HitRate")
+	assert.Contains(t, err.Error(), "[0, 1]")
+	assert.Empty(t, dev.GetActiveSystemName())
+}
+
+// TestDevEnvSetParameterShadowedByOverride verifies that setting a parameter
+// that a uses override also declares warns with both the runtime path and the
+// override, and is rejected in strict mode.
+func TestDevEnvSetParameterShadowedByOverride(t *testing.T) {
+	dev := newTestDevEnv()
+	page := NewConsoleWorkspacePage(false)
+	dev.SetPage(page)
+	require.NoError(t, dev.LoadFile(testFixturePath("param_overrides.sdl")))
+	require.NoError(t, dev.Use("App"))
+	db := dev.ActiveSystem().FindComponent("server.db")
+	require.NotNil(t, db)
+
+	warnings := func() (out []string) {
+		for _, entry := range page.LogEntries {
+			if entry.Level == "warning" {
+				out = append(out, entry.Message)
+			}
+		}
+		return
+	}
+
+	require.NoError(t, dev.SetParameter("server.db.Timeout", 200))
+	assert.Empty(t, warnings(), "params without an override should not warn")
+
+	require.NoError(t, dev.SetParameter("server.db.PoolSize", 80))
+	value, _ := db.Get("PoolSize")
+	assert.Equal(t, int64(80), value.Value, "runtime set should apply")
+	require.Len(t, warnings(), 1)
+	assert.Contains(t, warnings()[0], "server.db.PoolSize")
+	assert.Contains(t, warnings()[0], "'uses db Database' in component Server (line 13)")
+	assert.Contains(t, warnings()[0], "Precedence")
+
+	dev.SetStrictParameters(true)
+	err := dev.SetParameter("server.db.PoolSize", 90)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "uses db Database")
+	value, _ = db.Get("PoolSize")
+	assert.Equal(t, int64(80), value.Value, "strict mode should not apply the value")
+}
+
+// TestDevEnvSystemOptions verifies that the active system's effective options
+// become the environment's defaults when it is used.
+func TestDevEnvSystemOptions(t *testing.T) {
+	dev := newTestDevEnv()
+	require.NoError(t, dev.LoadFile(testFixturePath("options.sdl")))
+
+	require.NoError(t, dev.Use("App"))
+	assert.Equal(t, 8, dev.runtime.MaxCallDepth, "system option should override the file's")
+	assert.Equal(t, 5, dev.displayPrecision)
+	assert.Equal(t, int64(42), dev.defaultSeed)
+
+	require.NoError(t, dev.Use("Plain"))
+	assert.Equal(t, 64, dev.runtime.MaxCallDepth)
+	assert.Zero(t, dev.defaultSeed)
+}
+
+// TestDevEnvSystemOptionsReset verifies that switching to a system from a
+// file without options resets every option the previous system set.
+func TestDevEnvSystemOptionsReset(t *testing.T) {
+	dev := newTestDevEnv()
+	require.NoError(t, dev.LoadFile(testFixturePath("options.sdl")))
+	require.NoError(t, dev.LoadFile(testFixturePath("branch_probability.sdl")))
+
+	require.NoError(t, dev.Use("App"))
+	assert.True(t, dev.strictParams)
+	assert.Equal(t, 0.3, dev.FlowSolverOptions().BranchProbability)
+
+	require.NoError(t, dev.Use("Default"))
+	assert.Equal(t, sdlruntime.DefaultMaxCallDepth, dev.runtime.MaxCallDepth)
+	assert.Equal(t, core.DefaultDisplayPrecision, dev.DisplayPrecision())
+	assert.Zero(t, dev.defaultSeed)
+	assert.False(t, dev.strictParams)
+	assert.Equal(t, sdlruntime.DefaultFlowSolverOptions().BranchProbability, dev.FlowSolverOptions().BranchProbability)
+}
+
+// TestDevEnvCheckSaturation verifies that offered load beyond a component's
+// capacity is reported with its arrival and service rates, naming the bottleneck.
+func TestDevEnvCheckSaturation(t *testing.T) {
+	dev := newTestDevEnv()
+	require.NoError(t, dev.LoadFile(testFixturePath("saturation.sdl")))
+	require.NoError(t, dev.Use("App"))
+	gen := &sdlruntime.Generator{Generator: &protos.Generator{Name: "load", Component: "server", Method: "Handle", Rate: 10}}
+	require.NoError(t, dev.AddGenerator(gen))
+	defer dev.StopAllGenerators()
+
+	saturated, err := dev.CheckSaturation()
+	require.NoError(t, err)
+	assert.Empty(t, saturated, "10/s is within the pool's 20/s capacity")
+
+	require.NoError(t, dev.UpdateGenerator("load", 50))
+	saturated, err = dev.CheckSaturation()
+	require.NoError(t, err)
+	require.Len(t, saturated, 1)
+	assert.Equal(t, "server.pool", saturated[0].Component)
+	assert.InDelta(t, 50, saturated[0].ArrivalRate, 1e-6)
+	assert.InDelta(t, 20, saturated[0].ServiceRate, 1e-6)
+	assert.Equal(t, "system saturated at component server.pool (λ=50/s µ=20/s)", saturated[0].String())
+}
+
+// TestDevEnvThroughputCapacity verifies that a pool specified by throughput
+// saturates once the offered load exceeds that throughput.
+func TestDevEnvThroughputCapacity(t *testing.T) {
+	dev := newTestDevEnv()
+	require.NoError(t, dev.LoadFile(testFixturePath("throughput.sdl")))
+	require.NoError(t, dev.Use("App"))
+	gen := &sdlruntime.Generator{Generator: &protos.Generator{Name: "load", Component: "server", Method: "Handle", Rate: 19}}
+	require.NoError(t, dev.AddGenerator(gen))
+	defer dev.StopAllGenerators()
+
+	saturated, err := dev.CheckSaturation()
+	require.NoError(t, err)
+	assert.Empty(t, saturated, "19/s is within the pool's 20/s throughput")
+
+	require.NoError(t, dev.UpdateGenerator("load", 21))
+	saturated, err = dev.CheckSaturation()
+	require.NoError(t, err)
+	require.Len(t, saturated, 1)
+	assert.Equal(t, "server.pool", saturated[0].Component)
+	assert.InDelta(t, 20, saturated[0].ServiceRate, 1e-6)
+}
+
+// TestDevEnvGeneratorWarnings verifies that a generator on a method that
+// makes no calls is warned about while ones on methods with calls are not.
+func TestDevEnvGeneratorWarnings(t *testing.T) {
+	dev := newTestDevEnv()
+	require.NoError(t, dev.LoadFile(testFixturePath("system_with_metrics.sdl")))
+	require.NoError(t, dev.Use("SimpleAppTest"))
+	assert.Empty(t, dev.GeneratorWarnings(), "the declared generator calls the db")
+
+	gen := &sdlruntime.Generator{Generator: &protos.Generator{Name: "health", Component: "app.server", Method: "HealthCheck", Rate: 5}}
+	require.NoError(t, dev.AddGenerator(gen))
+	defer dev.StopAllGenerators()
+	assert.Equal(t, []string{
+		"generator 'health' targets app.server.HealthCheck which makes no calls and has no modeled latency, it may not be the intended entry point",
+	}, dev.GeneratorWarnings())
+}
+
+// TestDevEnvFlowSolverOptions verifies that flow evaluation reports how many
+// iterations it took and that capping them below convergence is a warning
+// naming the rates still changing.
+func TestDevEnvFlowSolverOptions(t *testing.T) {
+	dev := newTestDevEnv()
+	require.NoError(t, dev.LoadFile(testFixturePath("saturation.sdl")))
+	require.NoError(t, dev.Use("App"))
+	gen := &sdlruntime.Generator{Generator: &protos.Generator{Name: "load", Component: "server", Method: "Handle", Rate: 10}}
+	require.NoError(t, dev.AddGenerator(gen))
+	defer dev.StopAllGenerators()
+
+	result, err := dev.EvaluateFlows("runtime")
+	require.NoError(t, err)
+	assert.Equal(t, sdlruntime.FlowStatusConverged, result.Status)
+	assert.Greater(t, result.Iterations, 0)
+	assert.Less(t, result.Iterations, dev.FlowSolverOptions().MaxIterations)
+	assert.Less(t, result.Residual, dev.FlowSolverOptions().Tolerance)
+	assert.Len(t, result.History, result.Iterations)
+
+	require.NoError(t, dev.SetFlowSolverOptions(0.01, 1))
+	result, err = dev.EvaluateFlows("runtime")
+	require.NoError(t, err)
+	assert.Equal(t, sdlruntime.FlowStatusPartial, result.Status)
+	assert.Equal(t, 1, result.Iterations)
+	assert.Contains(t, result.Warnings[len(result.Warnings)-1], "did not converge after 1 iterations")
+	assert.Contains(t, result.Warnings[len(result.Warnings)-1], "server.pool.Acquire")
+
+	assert.Error(t, dev.SetFlowSolverOptions(0, 10))
+	assert.Error(t, dev.SetFlowSolverOptions(0.01, 0))
+}
+
+// TestDevEnvBranchProbability verifies that calls under an if whose outcome
+// flow evaluation cannot determine are weighted by the branch probability set
+// by the system's option or the setter.
+func TestDevEnvBranchProbability(t *testing.T) {
+	dev := newTestDevEnv()
+	require.NoError(t, dev.LoadFile(testFixturePath("branch_probability.sdl")))
+	queryRate := func() float64 {
+		gen := &sdlruntime.Generator{Generator: &protos.Generator{Name: "load", Component: "server", Method: "Handle", Rate: 100}}
+		require.NoError(t, dev.AddGenerator(gen))
+		defer dev.RemoveGenerator("load")
+		result, err := dev.EvaluateFlows("runtime")
+		require.NoError(t, err)
+		return result.Flows.ComponentRates["server.db.Query"]
+	}
+
+	require.NoError(t, dev.Use("Default"))
+	assert.InDelta(t, 50, queryRate(), 1e-9)
+
+	require.NoError(t, dev.Use("Rare"))
+	assert.InDelta(t, 30, queryRate(), 1e-9)
+
+	require.NoError(t, dev.SetBranchProbability(0.9))
+	assert.InDelta(t, 90, queryRate(), 1e-9)
+
+	assert.Error(t, dev.SetBranchProbability(1.5))
+}
+
+// TestDevEnvAttribution verifies that latency accumulated over many runs is
+// attributed to the methods it was modeled in, delays included.
+func TestDevEnvAttribution(t *testing.T) {
+	dev := newTestDevEnv()
+	require.NoError(t, dev.LoadFile(testFixturePath("attribution.sdl")))
+	require.NoError(t, dev.Use("App"))
+
+	attribution, err := dev.Attribution("server.Handle", 200)
+	require.NoError(t, err)
+	assert.Equal(t, 200, attribution.Runs)
+	assert.InDelta(t, 200*0.1, attribution.Total, 1e-6)
+
+	entries := attribution.Entries()
+	require.Len(t, entries, 3)
+	assert.Equal(t, "Database.Query", entries[0].Method)
+	assert.InDelta(t, 0.8, entries[0].Fraction, 1e-6)
+	assert.InDelta(t, 200*0.08, entries[0].SelfTime, 1e-6)
+	assert.InDelta(t, 0.2, attribution.Fractions()["Cache.Get"], 1e-6)
+	assert.Zero(t, attribution.Fractions()["Server.Handle"])
+
+	_, err = dev.Attribution("server.Missing", 10)
+	assert.Error(t, err)
+}
+
+// TestDevEnvScheduleParameter verifies that a scheduled set fires when the
+// clock reaches its delay and not before, and that a cancelled one never does.
+func TestDevEnvScheduleParameter(t *testing.T) {
+	dev := newTestDevEnv()
+	clock := sdlruntime.NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	dev.SetClock(clock)
+	require.NoError(t, dev.LoadFile(testFixturePath("param_overrides.sdl")))
+	require.NoError(t, dev.Use("App"))
+	db := dev.ActiveSystem().FindComponent("server.db")
+	require.NotNil(t, db)
+	timeout := func() any {
+		value, _ := db.Get("Timeout")
+		return value.Value
+	}
+
+	dev.ScheduleParameter("server.db.Timeout", 500, 10*time.Second)
+	cancel := dev.ScheduleParameter("server.db.Timeout", 900, 20*time.Second)
+	cancel()
+
+	clock.Advance(9 * time.Second)
+	assert.Equal(t, int64(100), timeout(), "set should not fire before 10s")
+
+	clock.Advance(time.Second)
+	assert.Equal(t, int64(500), timeout())
+
+	clock.Advance(time.Minute)
+	assert.Equal(t, int64(500), timeout(), "cancelled set should not fire")
+}
+
+// TestDevEnvTraceStream verifies that streamed enter/exit events nest: each
+// enter's parent is the innermost open call and each exit closes it.
+func TestDevEnvTraceStream(t *testing.T) {
+	dev := newTestDevEnv()
+	require.NoError(t, dev.LoadFile(testFixturePath("recursion.sdl")))
+	require.NoError(t, dev.Use("RecursionTest"))
+
+	var open []int64
+	var calls []string
+	err := dev.TraceStream("node.Start", func(event *sdlruntime.TraceEvent) {
+		parent := int64(0)
+		if len(open) > 0 {
+			parent = open[len(open)-1]
+		}
+		switch event.Kind {
+		case sdlruntime.EventEnter:
+			assert.Equal(t, parent, event.ParentID, "enter %s", event.MethodName)
+			open = append(open, event.ID)
+			calls = append(calls, "enter "+event.MethodName)
+		case sdlruntime.EventExit:
+			require.NotEmpty(t, open, "exit without an open call")
+			assert.Equal(t, parent, event.EnterID, "exit %s", event.MethodName)
+			open = open[:len(open)-1]
+			calls = append(calls, "exit "+event.MethodName)
+		}
+	})
+	require.NoError(t, err)
+	assert.Empty(t, open, "every call should be closed")
+	assert.Equal(t, []string{"enter Start", "enter Middle", "enter Leaf", "exit Leaf", "exit Middle", "exit Start"}, calls)
+
+	// ExecuteTrace collects the same events
+	data, err := dev.ExecuteTrace("node", "Start")
+	require.NoError(t, err)
+	assert.Len(t, data.Events, len(calls))
+	assert.Equal(t, "RecursionTest", data.System)
+
+	assert.Error(t, dev.TraceStream("node", func(*sdlruntime.TraceEvent) {}))
+	assert.Error(t, dev.TraceStream("node.Missing", func(*sdlruntime.TraceEvent) {}))
+}
+
+// TestDevEnvParamSchema verifies that the param schema lists a system's
+// overridable paths with the type, default and range of a constrained param.
+func TestDevEnvParamSchema(t *testing.T) {
+	dev := newTestDevEnv()
+	require.NoError(t, dev.LoadFile(testFixturePath("param_constraints.sdl")))
+
+	raw, err := dev.ParamSchema()
+	require.NoError(t, err)
+	var schema struct {
+		Defs       map[string]map[string]any `json:"$defs"`
+		Properties map[string]struct {
+			Properties map[string]map[string]any `json:"properties"`
+		} `json:"properties"`
+	}
+	require.NoError(t, json.Unmarshal(raw, &schema))
+
+	paths := schema.Properties["CacheSystem"].Properties
+	require.Contains(t, paths, "app.cache.HitRate")
+	hitRate := paths["app.cache.HitRate"]
+	assert.Equal(t, "number", hitRate["type"])
+	assert.Equal(t, 0.8, hitRate["default"])
+	assert.Equal(t, 0.0, hitRate["minimum"])
+	assert.Equal(t, 1.0, hitRate["maximum"])
+	assert.Equal(t, []any{"lru", "lfu", "fifo"}, paths["app.cache.Policy"]["enum"])
+	assert.Equal(t, []any{"Hot", "Warm"}, paths["app.cache.Placement"]["enum"], "an enum param lists its allowed variants")
+	assert.Equal(t, "Hot", paths["app.cache.Placement"]["default"])
+	assert.Contains(t, schema.Defs, "Cache")
+}
+
+// TestDevEnvTraceSink verifies that every traced run is also written to the
+// configured sink until the sink is cleared.
+func TestDevEnvTraceSink(t *testing.T) {
+	dev := newTestDevEnv()
+	require.NoError(t, dev.LoadFile(testFixturePath("attribution.sdl")))
+	require.NoError(t, dev.Use("App"))
+
+	sink := sdlruntime.NewMemoryTraceSink()
+	require.NoError(t, dev.SetTraceSink(sink))
+	data, err := dev.ExecuteTrace("server", "Handle")
+	require.NoError(t, err)
+	_, err = dev.ExecuteTrace("server", "Handle")
+	require.NoError(t, err)
+	assert.Len(t, sink.Events, 2*len(data.Events))
+
+	require.NoError(t, dev.SetTraceSink(nil))
+	_, err = dev.ExecuteTrace("server", "Handle")
+	require.NoError(t, err)
+	assert.Len(t, sink.Events, 2*len(data.Events))
+}
+
+// TestDevEnvController raises the load on a pool until its p99 breaches the
+// threshold and checks the controller grows the pool until p99 recovers.
+// Windows are fed to the controller directly instead of waiting on the
+// metric's real time ticker.
+func TestDevEnvController(t *testing.T) {
+	dev := newTestDevEnv()
+	require.NoError(t, dev.LoadFile(testFixturePath("saturation.sdl")))
+	require.NoError(t, dev.Use("App"))
+	require.NoError(t, dev.AddMetric(&sdlruntime.Metric{Metric: &protos.Metric{
+		Name: "p99", Component: "server", Methods: []string{"Handle"},
+		MetricType: sdlruntime.MetricLatency, Aggregation: "p99", AggregationWindow: 5,
+	}}))
+
+	_, err := dev.AddController(ControllerSpec{Metric: "missing", Param: "server.pool.Size", Step: 1, Limit: 50})
+	assert.ErrorContains(t, err, "metric 'missing' not found")
+	_, err = dev.AddController(ControllerSpec{Metric: "p99", Param: "server.pool.Size", Step: 0.5, Limit: 50})
+	assert.ErrorContains(t, err, "whole numbers")
+
+	controller, err := dev.AddController(ControllerSpec{
+		Metric: "p99", Operator: core.GT, Threshold: 0.2, Window: 10 * time.Second,
+		Param: "server.pool.Size", Step: 1, Limit: 50,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "if p99 > 0.2 for 10s, increase server.pool.Size by 1 up to 50", controller.String())
+
+	p99 := func() float64 {
+		results, _, err := dev.RunSimulation(RunOptions{Target: "server.Handle", Runs: 200, Seed: 1, NoCache: true})
+		require.NoError(t, err)
+		latencies := make([]float64, len(results))
+		for i, r := range results {
+			latencies[i] = r.Latency / 1000
+		}
+		slices.Sort(latencies)
+		return latencies[int(float64(len(latencies)-1)*0.99)]
+	}
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	window := 0
+	observe := func() float64 {
+		value := p99()
+		controller.Observe(&sdlruntime.MetricPoint{Timestamp: start.Add(time.Duration(window) * 5 * time.Second), Value: value})
+		window++
+		return value
+	}
+
+	require.NoError(t, dev.SetParameter("server.pool.ArrivalRate", 10.0))
+	for range 4 {
+		assert.Less(t, observe(), 0.2)
+	}
+	assert.Empty(t, controller.Actions())
+
+	require.NoError(t, dev.SetParameter("server.pool.ArrivalRate", 19.0))
+	var latest float64
+	for range 10 {
+		if latest = observe(); latest < 0.2 {
+			break
+		}
+	}
+	assert.Less(t, latest, 0.2, "p99 should recover once the pool grows")
+	actions := controller.Actions()
+	require.NotEmpty(t, actions)
+	assert.Equal(t, 2.0, actions[0].From)
+	assert.Equal(t, 3.0, actions[0].To)
+	size, _ := dev.ActiveSystem().FindComponent("server.pool").Get("Size")
+	assert.Equal(t, int64(2+len(actions)), size.Value)
+
+	require.NoError(t, dev.RemoveController(controller.Name))
+	assert.Empty(t, dev.ListControllers())
+}
+
+// TestDevEnvControllerConcurrentUse feeds a controller breaching windows from
+// another goroutine, as the metric's goroutine does, while the system is
+// used again, and checks every action it took landed on the system.
+func TestDevEnvControllerConcurrentUse(t *testing.T) {
+	dev := newTestDevEnv()
+	require.NoError(t, dev.LoadFile(testFixturePath("saturation.sdl")))
+	require.NoError(t, dev.Use("App"))
+	require.NoError(t, dev.AddMetric(&sdlruntime.Metric{Metric: &protos.Metric{
+		Name: "p99", Component: "server", Methods: []string{"Handle"},
+		MetricType: sdlruntime.MetricLatency, Aggregation: "p99", AggregationWindow: 1,
+	}}))
+	controller, err := dev.AddController(ControllerSpec{
+		Metric: "p99", Operator: core.GT, Threshold: 0.2, Window: time.Second,
+		Param: "server.pool.Size", Step: 1, Limit: 50,
+	})
+	require.NoError(t, err)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		for i := range 40 {
+			controller.Observe(&sdlruntime.MetricPoint{Timestamp: start.Add(time.Duration(i) * time.Second), Value: 1})
+		}
+	}()
+	for range 5 {
+		require.NoError(t, dev.Use("App"))
+	}
+	<-done
+
+	assert.Empty(t, dev.ListControllers(), "using a system drops its controllers")
+	size, _ := dev.ActiveSystem().FindComponent("server.pool").Get("Size")
+	assert.Equal(t, int64(2+len(controller.Actions())), size.Value)
+}
+
+// TestDevEnvSetProfileRecomputesFlows verifies that switching a component to
+// a profile that calls other components recomputes the system's flows.
+func TestDevEnvSetProfileRecomputesFlows(t *testing.T) {
+	fs := loader.NewMemoryFS()
+	fs.WriteFile("/models/main.sdl", []byte(`
+component Store {
+  method Get() Bool { return true }
+}
+component Cache {
+  uses store Store()
+  method Get() Bool { return true }
+
+  profile Cold {
+    method Get() Bool { return self.store.Get() }
+  }
+}
+system Shop(cache Cache) {
+  generator("traffic", cache.Get, rate(20))
+}
+`))
+	dev := NewDevEnv(loader.NewFileSystemResolver(fs))
+	require.NoError(t, dev.LoadFile("/models/main.sdl"))
+	require.NoError(t, dev.Use("Shop"))
+	require.NoError(t, dev.StartAllGenerators())
+	defer dev.StopAllGenerators()
+
+	flows, _ := dev.GetFlowState()
+	assert.InDelta(t, 20.0, flows["cache.Get"], 1e-9)
+	assert.Zero(t, flows["cache.store.Get"], "the default Get calls nothing")
+
+	require.NoError(t, dev.SetProfile("cache", "Cold"))
+	flows, _ = dev.GetFlowState()
+	assert.InDelta(t, 20.0, flows["cache.store.Get"], 1e-9, "the cold profile reads every call through")
+
+	require.NoError(t, dev.SetProfile("cache", ""))
+	flows, _ = dev.GetFlowState()
+	assert.Zero(t, flows["cache.store.Get"])
+}
+
+// TestDevEnvEstimateDistribution verifies that sampled percentiles converge
+// on the ones derived from the model as the sample count grows.
+func TestDevEnvEstimateDistribution(t *testing.T) {
+	dev := newTestDevEnv()
+	require.NoError(t, dev.LoadFile(testFixturePath("estimate.sdl")))
+	require.NoError(t, dev.Use("App"))
+
+	// Latencies are in milliseconds
+	expected := types.RunSummary{
+		Mean: types.Estimate{Value: 15.4},
+		P50:  types.Estimate{Value: 10},
+		P95:  types.Estimate{Value: 100},
+		P99:  types.Estimate{Value: 100},
+	}
+	estimateError := func(samples int) float64 {
+		summary, err := dev.EstimateDistribution("server.Handle", samples)
+		require.NoError(t, err)
+		require.Equal(t, samples, summary.Count)
+		return math.Abs(summary.Mean.Value-expected.Mean.Value) +
+			math.Abs(summary.P50.Value-expected.P50.Value) +
+			math.Abs(summary.P95.Value-expected.P95.Value) +
+			math.Abs(summary.P99.Value-expected.P99.Value)
+	}
+
+	coarse, fine := estimateError(10), estimateError(20000)
+	assert.Less(t, fine, coarse)
+	assert.Less(t, fine, 1.0)
+
+	_, total := dev.ListRuns(0, 10)
+	assert.Zero(t, total, "estimates are not recorded as runs")
+	assert.Zero(t, dev.runCache.Len(), "estimates are not cached")
+
+	_, err := dev.EstimateDistribution("server.Handle", 0)
+	assert.ErrorContains(t, err, "sample count must be positive")
+}
+
+// TestDevEnvAggregateOnlyEvalMode verifies that summaries computed without
+// keeping each call's result agree with the per call ones.
+func TestDevEnvAggregateOnlyEvalMode(t *testing.T) {
+	dev := newTestDevEnv()
+	require.NoError(t, dev.LoadFile(testFixturePath("estimate.sdl")))
+	require.NoError(t, dev.Use("App"))
+
+	opts := RunOptions{Target: "server.Handle", Runs: 20000, Seed: 7}
+	perCall, err := dev.SummarizeRun(opts)
+	require.NoError(t, err)
+
+	dev.SetEvalMode(EvalModeAggregateOnly)
+	assert.Equal(t, "aggregate-only", dev.EvalMode().String())
+	aggregate, err := dev.SummarizeRun(opts)
+	require.NoError(t, err)
+	assert.Equal(t, opts.Runs, aggregate.Count)
+	assert.False(t, perCall.SignificantlyDifferent(aggregate))
+	assert.InEpsilon(t, perCall.P50.Value, aggregate.P50.Value, 0.02)
+	assert.InEpsilon(t, perCall.P95.Value, aggregate.P95.Value, 0.02)
+
+	_, err = dev.SummarizeRun(RunOptions{Target: "missing.Handle", Runs: 10})
+	assert.ErrorContains(t, err, "component 'missing' not found")
+}
+
+// TestDevEnvManifest verifies that the manifest of a file with two systems
+// lists both along with the instance graph each one builds.
+func TestDevEnvManifest(t *testing.T) {
+	dev := newTestDevEnv()
+	require.NoError(t, dev.LoadFile(testFixturePath("manifest.sdl")))
+
+	m, err := dev.Manifest()
+	require.NoError(t, err)
+	assert.Equal(t, loader.ManifestVersion, m.Version)
+
+	require.Len(t, m.Systems, 2)
+	pair, single := m.Systems[0], m.Systems[1]
+	assert.Equal(t, "Pair", pair.Name)
+	assert.Equal(t, "Single", single.Name)
+	assert.Equal(t, []loader.ManifestInstance{
+		{Path: "primary", Component: "Server"},
+		{Path: "replica", Component: "Server"},
+	}, pair.Parameters)
+	assert.Equal(t, []loader.ManifestInstance{
+		{Path: "primary", Component: "Server", Uses: []string{"primary.cache"}},
+		{Path: "primary.cache", Component: "Cache"},
+		{Path: "replica", Component: "Server", Uses: []string{"replica.cache"}},
+		{Path: "replica.cache", Component: "Cache"},
+	}, pair.Instances)
+	assert.Equal(t, []loader.ManifestInstance{
+		{Path: "server", Component: "Server", Uses: []string{"server.cache"}},
+		{Path: "server.cache", Component: "Cache"},
+	}, single.Instances)
+
+	require.Len(t, m.Components, 2)
+	cache, server := m.Components[0], m.Components[1]
+	assert.Equal(t, []loader.ManifestParam{
+		{Name: "Eviction", Type: "Policy", Default: "LRU"},
+		{Name: "HitRate", Type: "Float", Default: 0.8},
+	}, cache.Params)
+	assert.Equal(t, []loader.ManifestUses{{Name: "cache", Component: "Cache"}}, server.Uses)
+	assert.Equal(t, []loader.ManifestMethod{{
+		Name:    "Handle",
+		Params:  []loader.ManifestParam{{Name: "size", Type: "Int"}},
+		Returns: "Bool",
+	}}, server.Methods)
+	assert.Equal(t, []loader.ManifestEnum{{Name: "Policy", File: m.Enums[0].File, Values: []string{"LRU", "FIFO"}}}, m.Enums)
+
+	// The JSON form is stable across calls
+	first, err := json.Marshal(m)
+	require.NoError(t, err)
+	again, err := dev.Manifest()
+	require.NoError(t, err)
+	second, err := json.Marshal(again)
+	require.NoError(t, err)
+	assert.Equal(t, string(first), string(second))
+}
+
+// TestDevEnvLoadedSystems verifies that loading a file with two systems
+// reports each with its parameters and the methods generators can target.
+func TestDevEnvLoadedSystems(t *testing.T) {
+	dev := newTestDevEnv()
+	systems, err := dev.LoadedSystems(testFixturePath("manifest.sdl"))
+	require.NoError(t, err)
+	assert.Equal(t, []LoadedSystem{{
+		Name: "Pair",
+		Parameters: []SystemParameter{
+			{Name: "primary", Component: "Server"},
+			{Name: "replica", Component: "Server"},
+		},
+		EntryPoints: []string{"primary.Handle", "replica.Handle"},
+	}, {
+		Name:        "Single",
+		Parameters:  []SystemParameter{{Name: "server", Component: "Server"}},
+		EntryPoints: []string{"server.Handle"},
+	}}, systems)
+	assert.ElementsMatch(t, []string{"Pair", "Single"}, dev.AvailableSystems())
+}
+
+// TestDevEnvMetricExporters verifies that closing a metric window pushes its
+// value to StatsD over UDP and to InfluxDB over HTTP, and that a failed
+// InfluxDB write is retried.
+func TestDevEnvMetricExporters(t *testing.T) {
+	statsd, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer statsd.Close()
+
+	var mu sync.Mutex
+	var influxLines []string
+	requests := 0
+	influx := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		defer mu.Unlock()
+		if requests++; requests == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		assert.Equal(t, "sdl", r.URL.Query().Get("db"))
+		influxLines = append(influxLines, string(body))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer influx.Close()
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	dev := newTestDevEnv()
+	dev.SetClock(sdlruntime.NewFakeClock(start))
+	require.NoError(t, dev.LoadFile(testFixturePath("saturation.sdl")))
+	require.NoError(t, dev.Use("App"))
+	require.NoError(t, dev.AddMetric(&sdlruntime.Metric{Metric: &protos.Metric{
+		Name: "calls", Component: "server", Methods: []string{"Handle"},
+		MetricType: sdlruntime.MetricCount, Aggregation: "sum", AggregationWindow: 5,
+	}}))
+
+	_, err = sdlruntime.NewMetricExporter("kafka://localhost:9092")
+	assert.ErrorContains(t, err, "unsupported exporter")
+	statsdExporter, err := sdlruntime.NewMetricExporter("statsd://" + statsd.LocalAddr().String())
+	require.NoError(t, err)
+	dev.AddMetricExporter(statsdExporter)
+	influxExporter, err := sdlruntime.NewMetricExporter("influxdb://" + strings.TrimPrefix(influx.URL, "http://") + "/sdl")
+	require.NoError(t, err)
+	dev.AddMetricExporter(influxExporter).Backoff = time.Millisecond
+
+	calls := dev.metricTracer.GetMetric("calls")
+	for range 3 {
+		calls.ProcessTraceEvent(1, 0, calls.ResolvedComponent, calls.ResolvedComponent.Method("Handle"), sdlruntime.BoolValue(true), nil)
+	}
+	require.NoError(t, dev.Close(), "closing flushes the open window and drains the exporters")
+
+	buf := make([]byte, 1024)
+	require.NoError(t, statsd.SetReadDeadline(time.Now().Add(5*time.Second)))
+	n, _, err := statsd.ReadFrom(buf)
+	require.NoError(t, err)
+	assert.Equal(t, "calls:3|g|#aggregation:sum,system:App,target:server.Handle,type:count", string(buf[:n]))
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, 2, requests, "failed write should be retried once")
+	assert.Equal(t, []string{fmt.Sprintf("calls,aggregation=sum,system=App,target=server.Handle,type=count value=3 %d\n", start.UnixNano())}, influxLines)
+}
+
+// TestDevEnvRunUnderLoad verifies that a run under load sees the contention
+// of the generators' traffic and that the arrival rates are restored after.
+func TestDevEnvRunUnderLoad(t *testing.T) {
+	dev := newTestDevEnv()
+	dev.SetClock(sdlruntime.NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)))
+	require.NoError(t, dev.LoadFile(testFixturePath("saturation.sdl")))
+	require.NoError(t, dev.Use("App"))
+
+	opts := RunOptions{Target: "server.Handle", Runs: 500, Seed: 1, NoCache: true}
+	_, _, err := dev.RunSimulation(RunOptions{Target: "server.Handle", Runs: 10, UnderLoad: true})
+	assert.ErrorContains(t, err, "no generators")
+
+	mean := func(results []types.RunResult) float64 {
+		return types.SummarizeRuns(results).Mean.Value
+	}
+	isolated, _, err := dev.RunSimulation(opts)
+	require.NoError(t, err)
+
+	require.NoError(t, dev.AddGenerator(&sdlruntime.Generator{Generator: &protos.Generator{
+		Name: "background", Component: "server", Method: "Handle", Rate: 18, Enabled: true,
+	}}))
+	pool := dev.ActiveSystem().FindComponent("server.pool")
+	rate := pool.GetArrivalRate("Acquire")
+	opts.UnderLoad = true
+	loaded, cached, err := dev.RunSimulation(opts)
+	require.NoError(t, err)
+	assert.False(t, cached)
+	assert.Greater(t, mean(loaded), 2*mean(isolated), "background load should raise latency")
+
+	assert.Equal(t, rate, pool.GetArrivalRate("Acquire"), "arrival rates should be restored after the run")
+	opts.UnderLoad = false
+	after, _, err := dev.RunSimulation(opts)
+	require.NoError(t, err)
+	assert.Equal(t, mean(isolated), mean(after))
+}
+
+// TestDevEnvExportGrafanaDashboard verifies that the dashboard has a panel
+// per metric querying the series the metric exporters push.
+func TestDevEnvExportGrafanaDashboard(t *testing.T) {
+	dev := newTestDevEnv()
+	_, err := dev.ExportGrafanaDashboard()
+	assert.ErrorContains(t, err, "no active system")
+
+	require.NoError(t, dev.LoadFile(testFixturePath("saturation.sdl")))
+	require.NoError(t, dev.Use("App"))
+	for _, m := range []*protos.Metric{
+		{Name: "p99-latency", Component: "server", Methods: []string{"Handle"}, MetricType: sdlruntime.MetricLatency, Aggregation: "p99", AggregationWindow: 5},
+		{Name: "calls", Component: "server", Methods: []string{"Handle"}, MetricType: sdlruntime.MetricCount, Aggregation: "sum", AggregationWindow: 5},
+		{Name: "busy", Component: "server.pool", MetricType: sdlruntime.MetricUtilization, Aggregation: "avg", AggregationWindow: 5},
+	} {
+		require.NoError(t, dev.AddMetric(&sdlruntime.Metric{Metric: m}))
+	}
+
+	data, err := dev.ExportGrafanaDashboard()
+	require.NoError(t, err)
+	var dashboard struct {
+		Title         string
+		SchemaVersion int
+		Panels        []struct {
+			Type        string
+			Title       string
+			FieldConfig struct{ Defaults map[string]any }
+			Targets     []struct{ Expr string }
+		}
+	}
+	require.NoError(t, json.Unmarshal(data, &dashboard))
+	assert.Equal(t, "SDL App", dashboard.Title)
+	assert.Equal(t, 37, dashboard.SchemaVersion)
+	require.Len(t, dashboard.Panels, 3)
+
+	type panel struct{ Type, Title, Unit, Expr string }
+	var panels []panel
+	for _, p := range dashboard.Panels {
+		require.Len(t, p.Targets, 1)
+		panels = append(panels, panel{p.Type, p.Title, p.FieldConfig.Defaults["unit"].(string), p.Targets[0].Expr})
+	}
+	assert.Equal(t, []panel{
+		{"timeseries", "busy", "percentunit", `busy{system="App",target="server.pool"}`},
+		{"stat", "calls", "short", `calls{system="App",target="server.Handle"}`},
+		{"timeseries", "p99-latency", "s", `p99_latency{system="App",target="server.Handle"}`},
+	}, panels)
+}
+
+// TestCompileWarnings verifies that warnings, eg an unused import, are kept
+// apart from errors and do not stop a file from compiling.
+func TestCompileWarnings(t *testing.T) {
+	fs := loader.NewMemoryFS()
+	fs.WriteFile("/models/lib.sdl", []byte(`
+component Cache {
+  method Get() Bool { return true }
+}
+component Store {
+  method Get() Bool { return true }
+}
+`))
+	fs.WriteFile("/models/main.sdl", []byte(`
+import Cache, Store from "./lib.sdl"
+
+component App {
+  uses store Store()
+  method Handle() Bool { return self.store.Get() }
+}
+system Shop(app App) { }
+`))
+	result := Compile(loader.NewFileSystemResolver(fs), "/models/main.sdl")
+	require.True(t, result.Success, "%v", result.Errors)
+	assert.Empty(t, result.Errors)
+	require.Len(t, result.Warnings, 1)
+	assert.Equal(t, Diagnostic{Line: 2, Col: 8, Message: "import 'Cache' is never used"}, result.Warnings[0])
+	assert.Equal(t, []string{"Shop"}, result.Systems)
+
+	data, err := json.Marshal(result)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"warnings":[{"line":2,"col":8,"message":"import 'Cache' is never used"}]`)
+
+	fs.WriteFile("/models/broken.sdl", []byte("component Broken {\n  method Get() Bool { return missing }\n}\n"))
+	result = Compile(loader.NewFileSystemResolver(fs), "/models/broken.sdl")
+	assert.False(t, result.Success)
+	assert.NotEmpty(t, result.Errors)
+}
+
+// TestDevEnvWatchFiles verifies that editing a loaded file, or a file it
+// imports, pushes the recompiled model to the page and that an edit that
+// does not compile pushes its diagnostics, keeps the previous model and fails
+// a save from the page.
+func TestDevEnvWatchFiles(t *testing.T) {
+	fs := loader.NewMemoryFS()
+	fs.WriteFile("/models/lib.sdl", []byte(`
+component Store {
+  method Get() Bool { return true }
+}
+`))
+	fs.WriteFile("/models/main.sdl", []byte(`
+import Store from "./lib.sdl"
+
+component App {
+  uses store Store()
+  method Handle() Bool { return self.store.Get() }
+}
+system Shop(app App) { }
+`))
+	dev := NewDevEnv(loader.NewFileSystemResolver(fs))
+	page := NewConsoleWorkspacePage(false)
+	dev.SetPage(page)
+	require.NoError(t, dev.LoadFile("/models/main.sdl"))
+	require.NoError(t, dev.Use("Shop"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go dev.WatchFiles(ctx, 5*time.Millisecond)
+
+	latestManifest := func() *loader.Manifest {
+		page.mu.Lock()
+		defer page.mu.Unlock()
+		return page.Manifest
+	}
+	latestDiagnostics := func() []Diagnostic {
+		page.mu.Lock()
+		defer page.mu.Unlock()
+		return page.Diagnostics["/models/main.sdl"]
+	}
+
+	// A new system in the loaded file
+	fs.WriteFile("/models/main.sdl", []byte(`
+import Store from "./lib.sdl"
+
+component App {
+  uses store Store()
+  method Handle() Bool { return self.store.Get() }
+}
+system Shop(app App) { }
+system Outlet(app App) { }
+`))
+	require.Eventually(t, func() bool { return latestManifest() != nil }, time.Second, 5*time.Millisecond)
+	assert.ElementsMatch(t, []string{"Outlet", "Shop"}, page.AvailableSystems)
+	assert.Equal(t, "Shop", dev.GetActiveSystemName())
+
+	// A new method in an imported file
+	fs.WriteFile("/models/lib.sdl", []byte(`
+component Store {
+  method Get() Bool { return true }
+  method Put() Bool { return true }
+}
+`))
+	require.Eventually(t, func() bool {
+		for _, comp := range latestManifest().Components {
+			if comp.Name == "Store" {
+				return len(comp.Methods) == 2
+			}
+		}
+		return false
+	}, time.Second, 5*time.Millisecond)
+
+	// An edit that does not compile
+	fs.WriteFile("/models/main.sdl", []byte(`
+component App {
+  method Handle() Bool { return missing }
+}
+system Shop(app App) { }
+`))
+	require.Eventually(t, func() bool { return len(latestDiagnostics()) > 0 }, time.Second, 5*time.Millisecond)
+	assert.Contains(t, latestDiagnostics()[0].Message, "missing")
+	assert.ElementsMatch(t, []string{"Outlet", "Shop"}, dev.AvailableSystems())
+
+	// Saving it from the page reports the failure too
+	_, err := NewWorkspacePresenter(dev).FileSaved(ctx, &protos.FileSavedRequest{FilePath: "/models/main.sdl"})
+	assert.ErrorContains(t, err, "failed to compile")
+}
+
+// TestDevEnvListingsAreStable verifies that the generators and metrics of the
+// same state serialize to identical bytes however their maps iterate.
+func TestDevEnvListingsAreStable(t *testing.T) {
+	dev := newTestDevEnv()
+	require.NoError(t, dev.LoadFile(testFixturePath("system_with_generators.sdl")))
+	require.NoError(t, dev.Use("SimpleAppLoadTest"))
+	for _, name := range []string{"zeta", "alpha", "mid", "beta", "omega", "kappa"} {
+		require.NoError(t, dev.AddGenerator(&sdlruntime.Generator{Generator: &protos.Generator{Name: name, Component: "app.server", Method: "HealthCheck", Rate: 1}}))
+		require.NoError(t, dev.AddMetric(&sdlruntime.Metric{Metric: &protos.Metric{
+			Name:              name + "_calls",
+			Component:         "app.server",
+			Methods:           []string{"HealthCheck"},
+			MetricType:        sdlruntime.MetricCount,
+			Aggregation:       "sum",
+			AggregationWindow: 60,
+		}}))
+	}
+	require.NoError(t, dev.StopAllGenerators())
+
+	snapshot := func() []byte {
+		data, err := json.Marshal(map[string]any{"generators": dev.ListGenerators(), "metrics": dev.ListMetrics()})
+		require.NoError(t, err)
+		return data
+	}
+	first := snapshot()
+	for range 20 {
+		assert.Equal(t, string(first), string(snapshot()))
+	}
+	names := []string{}
+	for _, gen := range dev.ListGenerators() {
+		names = append(names, gen.Name)
+	}
+	assert.True(t, slices.IsSorted(names), names)
+}
+
+// TestDevEnvMetricTemplate verifies that a metric template measures every
+// instance of its component type, by instance id, and follows instances
+// added and removed by a reload.
+func TestDevEnvMetricTemplate(t *testing.T) {
+	model := func(shards ...string) []byte {
+		src := "component Shard {\n  method Get() Bool { return true }\n  method Put() Bool { return true }\n}\ncomponent App {\n"
+		for _, shard := range shards {
+			src += "  uses " + shard + " Shard()\n"
+		}
+		return []byte(src + "  method Handle() Bool { return self.a.Get() }\n}\nsystem Shop(app App) { }\n")
+	}
+	fs := loader.NewMemoryFS()
+	fs.WriteFile("/models/main.sdl", model("a", "b", "c"))
+	dev := NewDevEnv(loader.NewFileSystemResolver(fs))
+	require.NoError(t, dev.LoadFile("/models/main.sdl"))
+	require.NoError(t, dev.Use("Shop"))
+
+	added, err := dev.AddMetricTemplate("Shard", sdlruntime.MetricLatency)
+	require.NoError(t, err)
+	require.Len(t, added, 3)
+	for i, path := range []string{"app.a", "app.b", "app.c"} {
+		assert.Equal(t, path+"_latency", added[i].Name)
+		assert.Equal(t, path, added[i].Component)
+		assert.Equal(t, []string{"Get", "Put"}, added[i].Methods)
+	}
+	metricNames := func() (names []string) {
+		for _, metric := range dev.ListMetrics() {
+			names = append(names, metric.Name)
+		}
+		return
+	}
+	assert.Equal(t, []string{"app.a_latency", "app.b_latency", "app.c_latency"}, metricNames())
+
+	// Adding it again adds nothing
+	added, err = dev.AddMetricTemplate("Shard", sdlruntime.MetricLatency)
+	require.NoError(t, err)
+	assert.Empty(t, added)
+
+	fs.WriteFile("/models/main.sdl", model("a", "c", "d"))
+	require.NoError(t, dev.Reload())
+	assert.Equal(t, []string{"app.a_latency", "app.c_latency", "app.d_latency"}, metricNames())
+
+	_, err = dev.AddMetricTemplate("Shards", sdlruntime.MetricLatency)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "did you mean 'Shard'")
+}
+
+// TestDevEnvListRuns verifies that past runs page newest first with a total
+// count, that pages are the same however they are cut and that the history
+// forgets the oldest runs once full.
+func TestDevEnvListRuns(t *testing.T) {
+	fs := loader.NewMemoryFS()
+	fs.WriteFile("/models/main.sdl", []byte(`
+component Server {
+  param Timeout Float = 1.0
+  method HandleRequest() Bool { return true }
+}
+component App {
+  uses server Server()
+}
+system Shop(app App) { }
+`))
+	dev := NewDevEnv(loader.NewFileSystemResolver(fs))
+	require.NoError(t, dev.LoadFile("/models/main.sdl"))
+	require.NoError(t, dev.Use("Shop"))
+	dev.runHistory = NewRunHistory(4)
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := sdlruntime.NewFakeClock(start)
+	dev.SetClock(clock)
+
+	opts := RunOptions{Target: "app.server.HandleRequest", Seed: 42}
+	for runs := 1; runs <= 5; runs++ {
+		opts.Runs = runs * 10
+		_, _, err := dev.RunSimulation(opts)
+		require.NoError(t, err)
+		clock.Advance(time.Second)
+	}
+	paramsBefore, _ := dev.ListRuns(0, 1)
+	require.NoError(t, dev.SetParameter("app.server.Timeout", 5.0))
+	_, cached, err := dev.RunSimulation(opts)
+	require.NoError(t, err)
+	require.False(t, cached)
+
+	all, total := dev.ListRuns(0, 0)
+	assert.Equal(t, 4, total)
+	var ids []int
+	for _, run := range all {
+		ids = append(ids, run.ID)
+		assert.Equal(t, "app.server.HandleRequest", run.Target)
+	}
+	assert.Equal(t, []int{6, 5, 4, 3}, ids)
+	assert.Equal(t, 50, all[0].Summary.Count)
+	assert.Equal(t, 30, all[3].Summary.Count)
+	assert.NotEqual(t, paramsBefore[0].ParamsHash, all[0].ParamsHash)
+	assert.Equal(t, all[1].ParamsHash, all[2].ParamsHash)
+	assert.Equal(t, start.Add(5*time.Second), all[0].Timestamp, "runs are stamped with the DevEnv's clock")
+	assert.Equal(t, start.Add(4*time.Second), all[1].Timestamp)
+
+	var paged []RunRecord
+	for offset := 0; offset < total; offset += 3 {
+		page, pageTotal := dev.ListRuns(offset, 3)
+		assert.Equal(t, total, pageTotal)
+		paged = append(paged, page...)
+	}
+	assert.Equal(t, all, paged)
+	again, _ := dev.ListRuns(1, 2)
+	assert.Equal(t, all[1:3], again)
+
+	past, total := dev.ListRuns(10, 2)
+	assert.Empty(t, past)
+	assert.Equal(t, 4, total)
+}
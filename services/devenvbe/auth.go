@@ -0,0 +1,142 @@
+package devenvbe
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Operations a WorkspaceService checks with its Authorizer before running.
+const (
+	OpRead    = "read"    // Listing and querying state
+	OpLoad    = "load"    // Loading SDL files
+	OpUse     = "use"     // Selecting the active system
+	OpSet     = "set"     // Changing parameters
+	OpGen     = "gen"     // Adding, changing, starting and stopping generators
+	OpMeasure = "measure" // Adding and removing metrics
+)
+
+// DefaultWorkspaceID is used for requests that do not name a workspace.
+const DefaultWorkspaceID = "default"
+
+// WorkspaceRef identifies a workspace by its owning principal and its id
+// within the owner's namespace.  Anonymous callers share the "" namespace.
+type WorkspaceRef struct {
+	Owner string
+	ID    string
+}
+
+func (w WorkspaceRef) String() string {
+	return w.Owner + "/" + w.ID
+}
+
+// Authorizer decides whether a principal may perform op on a workspace.  A
+// non-nil error denies the request and is returned to the caller.
+type Authorizer interface {
+	Authorize(ctx context.Context, principal string, workspace WorkspaceRef, op string) error
+}
+
+// AuthorizerFunc adapts a function to an Authorizer.
+type AuthorizerFunc func(ctx context.Context, principal string, workspace WorkspaceRef, op string) error
+
+func (f AuthorizerFunc) Authorize(ctx context.Context, principal string, workspace WorkspaceRef, op string) error {
+	return f(ctx, principal, workspace, op)
+}
+
+// AllowAll permits every request, including changes to other principals'
+// workspaces.
+var AllowAll Authorizer = AuthorizerFunc(func(context.Context, string, WorkspaceRef, string) error { return nil })
+
+// OwnerOnly is the default Authorizer and only lets principals operate on
+// their own workspaces.
+var OwnerOnly Authorizer = AuthorizerFunc(func(_ context.Context, principal string, workspace WorkspaceRef, _ string) error {
+	if workspace.Owner != principal {
+		return fmt.Errorf("workspace is owned by another principal")
+	}
+	return nil
+})
+
+// Authenticator verifies the credentials of an incoming request, eg its
+// bearer token, and returns the principal making it, or "" for anonymous
+// requests.  A non-nil error rejects the request as unauthenticated.
+type Authenticator interface {
+	Authenticate(ctx context.Context) (principal string, err error)
+}
+
+// AuthenticatorFunc adapts a function to an Authenticator.
+type AuthenticatorFunc func(ctx context.Context) (string, error)
+
+func (f AuthenticatorFunc) Authenticate(ctx context.Context) (string, error) {
+	return f(ctx)
+}
+
+// Anonymous is the default Authenticator and treats every caller as anonymous.
+var Anonymous Authenticator = AuthenticatorFunc(func(context.Context) (string, error) { return "", nil })
+
+type principalKey struct{}
+
+// WithPrincipal returns a copy of ctx carrying the authenticated principal.
+func WithPrincipal(ctx context.Context, principal string) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// PrincipalFromContext returns the principal set by WithPrincipal, or "" for
+// anonymous requests.  Nothing a client sends is trusted as the principal.
+func PrincipalFromContext(ctx context.Context) string {
+	principal, _ := ctx.Value(principalKey{}).(string)
+	return principal
+}
+
+// AuthInterceptor returns a gRPC interceptor that authenticates each request
+// with authn and passes the principal on to the handler.
+func AuthInterceptor(authn Authenticator) grpc.UnaryServerInterceptor {
+	if authn == nil {
+		authn = Anonymous
+	}
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		principal, err := authn.Authenticate(ctx)
+		if err != nil {
+			return nil, status.Errorf(codes.Unauthenticated, "%v", err)
+		}
+		return handler(WithPrincipal(ctx, principal), req)
+	}
+}
+
+// StreamAuthInterceptor is AuthInterceptor for streaming RPCs.
+func StreamAuthInterceptor(authn Authenticator) grpc.StreamServerInterceptor {
+	if authn == nil {
+		authn = Anonymous
+	}
+	return func(srv any, stream grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		principal, err := authn.Authenticate(stream.Context())
+		if err != nil {
+			return status.Errorf(codes.Unauthenticated, "%v", err)
+		}
+		return handler(srv, &principalStream{ServerStream: stream, ctx: WithPrincipal(stream.Context(), principal)})
+	}
+}
+
+// principalStream is a server stream whose context carries the principal.
+type principalStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *principalStream) Context() context.Context { return s.ctx }
+
+// resolveWorkspaceRef namespaces a requested workspace id by principal.  Ids
+// of the form "owner/id" address another principal's workspace explicitly,
+// which the default OwnerOnly Authorizer denies.
+func resolveWorkspaceRef(principal, workspaceID string) WorkspaceRef {
+	if owner, id, qualified := strings.Cut(workspaceID, "/"); qualified {
+		return WorkspaceRef{Owner: owner, ID: id}
+	}
+	if workspaceID == "" {
+		workspaceID = DefaultWorkspaceID
+	}
+	return WorkspaceRef{Owner: principal, ID: workspaceID}
+}
@@ -2,116 +2,229 @@ package devenvbe
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strconv"
+	"sync"
 	"time"
 
 	protos "github.com/panyam/sdl/gen/go/sdl/v1/models"
 	protoservices "github.com/panyam/sdl/gen/go/sdl/v1/services"
 	"github.com/panyam/sdl/lib/loader"
 	"github.com/panyam/sdl/lib/runtime"
+	"github.com/panyam/sdl/lib/types"
 	"github.com/panyam/sdl/services"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // WorkspaceService implements the generated WorkspaceServiceServer by wrapping DevEnv.
 // This is the local-mode backend — no server needed. Follows the lilbattle
 // fsbe.GamesService pattern where a backend wraps a local storage/runtime.
+//
+// Each principal gets its own namespace of workspaces so users sharing a
+// server do not stomp on each other's "default".  DevEnv is the anonymous
+// caller's default workspace.  Authn identifies the caller when served over
+// gRPC, see AuthInterceptor, and Auth is consulted before every operation.
 type WorkspaceService struct {
 	protoservices.UnimplementedWorkspaceServiceServer
 	DevEnv *services.DevEnv
+	Authn  Authenticator
+	Auth   Authorizer
+
+	resolver       loader.FileResolver
+	workspacesLock sync.Mutex
+	workspaces     map[WorkspaceRef]*services.DevEnv
 }
 
 // NewWorkspaceService creates a local workspace service backed by DevEnv.
 func NewWorkspaceService(resolver loader.FileResolver) *WorkspaceService {
+	dev := services.NewDevEnv(resolver)
 	return &WorkspaceService{
-		DevEnv: services.NewDevEnv(resolver),
+		DevEnv:     dev,
+		Authn:      Anonymous,
+		Auth:       OwnerOnly,
+		resolver:   resolver,
+		workspaces: map[WorkspaceRef]*services.DevEnv{{ID: DefaultWorkspaceID}: dev},
 	}
 }
 
+// workspace authorizes op for the calling principal and returns the DevEnv
+// of the workspace it addresses, creating it on first use.
+func (s *WorkspaceService) workspace(ctx context.Context, workspaceID string, op string) (*services.DevEnv, error) {
+	principal := PrincipalFromContext(ctx)
+	ref := resolveWorkspaceRef(principal, workspaceID)
+	auth := s.Auth
+	if auth == nil {
+		auth = OwnerOnly
+	}
+	if err := auth.Authorize(ctx, principal, ref, op); err != nil {
+		return nil, status.Errorf(codes.PermissionDenied, "%s on workspace '%s' denied: %v", op, ref, err)
+	}
+	s.workspacesLock.Lock()
+	defer s.workspacesLock.Unlock()
+	dev := s.workspaces[ref]
+	if dev == nil {
+		dev = services.NewDevEnv(s.resolver)
+		s.workspaces[ref] = dev
+	}
+	return dev, nil
+}
+
+// Shutdown shuts down every workspace, see DevEnv.Shutdown, and returns the
+// combined report.
+func (s *WorkspaceService) Shutdown(ctx context.Context) (report services.ShutdownReport, err error) {
+	s.workspacesLock.Lock()
+	defer s.workspacesLock.Unlock()
+	for _, dev := range s.workspaces {
+		r, shutdownErr := dev.Shutdown(ctx)
+		report.GeneratorsStopped += r.GeneratorsStopped
+		report.MetricsFlushed += r.MetricsFlushed
+		report.PointsStored += r.PointsStored
+		report.TimedOut = report.TimedOut || r.TimedOut
+		err = errors.Join(err, shutdownErr)
+	}
+	return report, err
+}
+
 // File and system management
 
-func (s *WorkspaceService) LoadFile(_ context.Context, req *protos.LoadFileRequest) (*protos.LoadFileResponse, error) {
-	if err := s.DevEnv.LoadFile(req.SdlFilePath); err != nil {
+func (s *WorkspaceService) LoadFile(ctx context.Context, req *protos.LoadFileRequest) (*protos.LoadFileResponse, error) {
+	dev, err := s.workspace(ctx, req.WorkspaceId, OpLoad)
+	if err != nil {
+		return nil, err
+	}
+	if err := dev.LoadFile(req.SdlFilePath); err != nil {
 		return nil, err
 	}
 	return &protos.LoadFileResponse{}, nil
 }
 
-func (s *WorkspaceService) UseSystem(_ context.Context, req *protos.UseSystemRequest) (*protos.UseSystemResponse, error) {
-	if err := s.DevEnv.Use(req.SystemName); err != nil {
+func (s *WorkspaceService) UseSystem(ctx context.Context, req *protos.UseSystemRequest) (*protos.UseSystemResponse, error) {
+	dev, err := s.workspace(ctx, req.WorkspaceId, OpUse)
+	if err != nil {
+		return nil, err
+	}
+	if err := dev.Use(req.SystemName); err != nil {
 		return nil, err
 	}
 	return &protos.UseSystemResponse{}, nil
 }
 
+// GetManifest summarises the systems, components and enums of the files
+// loaded into the workspace.
+func (s *WorkspaceService) GetManifest(ctx context.Context, req *protos.GetManifestRequest) (*protos.GetManifestResponse, error) {
+	dev, err := s.workspace(ctx, req.WorkspaceId, OpRead)
+	if err != nil {
+		return nil, err
+	}
+	manifest, err := dev.Manifest()
+	if err != nil {
+		return nil, err
+	}
+	return &protos.GetManifestResponse{Manifest: services.ToProtoManifest(manifest)}, nil
+}
+
 // Generator management
 
-func (s *WorkspaceService) AddGenerator(_ context.Context, req *protos.AddGeneratorRequest) (*protos.AddGeneratorResponse, error) {
+func (s *WorkspaceService) AddGenerator(ctx context.Context, req *protos.AddGeneratorRequest) (*protos.AddGeneratorResponse, error) {
+	dev, err := s.workspace(ctx, req.WorkspaceId, OpGen)
+	if err != nil {
+		return nil, err
+	}
 	gen := req.Generator
 	if gen == nil {
 		return nil, fmt.Errorf("generator is required")
 	}
 	genInfo := &runtime.Generator{Generator: gen}
-	if err := s.DevEnv.AddGenerator(genInfo); err != nil {
+	if err := dev.AddGenerator(genInfo); err != nil {
 		return nil, err
 	}
 	if req.ApplyFlows {
-		s.DevEnv.EvaluateFlows("runtime")
+		dev.EvaluateFlows("runtime")
 	}
 	return &protos.AddGeneratorResponse{Generator: gen}, nil
 }
 
-func (s *WorkspaceService) UpdateGenerator(_ context.Context, req *protos.UpdateGeneratorRequest) (*protos.UpdateGeneratorResponse, error) {
+func (s *WorkspaceService) UpdateGenerator(ctx context.Context, req *protos.UpdateGeneratorRequest) (*protos.UpdateGeneratorResponse, error) {
+	dev, err := s.workspace(ctx, req.WorkspaceId, OpGen)
+	if err != nil {
+		return nil, err
+	}
 	gen := req.Generator
 	if gen == nil {
 		return nil, fmt.Errorf("generator is required")
 	}
-	if err := s.DevEnv.UpdateGenerator(gen.Name, gen.Rate); err != nil {
+	if err := dev.UpdateGenerator(gen.Name, gen.Rate); err != nil {
 		return nil, err
 	}
 	if req.ApplyFlows {
-		s.DevEnv.EvaluateFlows("runtime")
+		dev.EvaluateFlows("runtime")
 	}
 	return &protos.UpdateGeneratorResponse{Generator: gen}, nil
 }
 
-func (s *WorkspaceService) DeleteGenerator(_ context.Context, req *protos.DeleteGeneratorRequest) (*protos.DeleteGeneratorResponse, error) {
-	if err := s.DevEnv.RemoveGenerator(req.GeneratorName); err != nil {
+func (s *WorkspaceService) DeleteGenerator(ctx context.Context, req *protos.DeleteGeneratorRequest) (*protos.DeleteGeneratorResponse, error) {
+	dev, err := s.workspace(ctx, req.WorkspaceId, OpGen)
+	if err != nil {
+		return nil, err
+	}
+	if err := dev.RemoveGenerator(req.GeneratorName); err != nil {
 		return nil, err
 	}
 	if req.ApplyFlows {
-		s.DevEnv.EvaluateFlows("runtime")
+		dev.EvaluateFlows("runtime")
 	}
 	return &protos.DeleteGeneratorResponse{}, nil
 }
 
-func (s *WorkspaceService) ListGenerators(_ context.Context, _ *protos.ListGeneratorsRequest) (*protos.ListGeneratorsResponse, error) {
-	return &protos.ListGeneratorsResponse{Generators: s.DevEnv.ListGenerators()}, nil
+func (s *WorkspaceService) ListGenerators(ctx context.Context, req *protos.ListGeneratorsRequest) (*protos.ListGeneratorsResponse, error) {
+	dev, err := s.workspace(ctx, req.WorkspaceId, OpRead)
+	if err != nil {
+		return nil, err
+	}
+	return &protos.ListGeneratorsResponse{Generators: dev.ListGenerators()}, nil
 }
 
-func (s *WorkspaceService) StartGenerator(_ context.Context, req *protos.StartGeneratorRequest) (*protos.StartGeneratorResponse, error) {
-	if err := s.DevEnv.StartGenerator(req.GeneratorName); err != nil {
+func (s *WorkspaceService) StartGenerator(ctx context.Context, req *protos.StartGeneratorRequest) (*protos.StartGeneratorResponse, error) {
+	dev, err := s.workspace(ctx, req.WorkspaceId, OpGen)
+	if err != nil {
+		return nil, err
+	}
+	if err := dev.StartGenerator(req.GeneratorName); err != nil {
 		return nil, err
 	}
 	return &protos.StartGeneratorResponse{}, nil
 }
 
-func (s *WorkspaceService) StopGenerator(_ context.Context, req *protos.StopGeneratorRequest) (*protos.StopGeneratorResponse, error) {
-	if err := s.DevEnv.StopGenerator(req.GeneratorName); err != nil {
+func (s *WorkspaceService) StopGenerator(ctx context.Context, req *protos.StopGeneratorRequest) (*protos.StopGeneratorResponse, error) {
+	dev, err := s.workspace(ctx, req.WorkspaceId, OpGen)
+	if err != nil {
+		return nil, err
+	}
+	if err := dev.StopGenerator(req.GeneratorName); err != nil {
 		return nil, err
 	}
 	return &protos.StopGeneratorResponse{}, nil
 }
 
-func (s *WorkspaceService) StartAllGenerators(_ context.Context, _ *protos.StartAllGeneratorsRequest) (*protos.StartAllGeneratorsResponse, error) {
-	if err := s.DevEnv.StartAllGenerators(); err != nil {
+func (s *WorkspaceService) StartAllGenerators(ctx context.Context, req *protos.StartAllGeneratorsRequest) (*protos.StartAllGeneratorsResponse, error) {
+	dev, err := s.workspace(ctx, req.WorkspaceId, OpGen)
+	if err != nil {
+		return nil, err
+	}
+	if err := dev.StartAllGenerators(); err != nil {
 		return nil, err
 	}
 	return &protos.StartAllGeneratorsResponse{}, nil
 }
 
-func (s *WorkspaceService) StopAllGenerators(_ context.Context, _ *protos.StopAllGeneratorsRequest) (*protos.StopAllGeneratorsResponse, error) {
-	if err := s.DevEnv.StopAllGenerators(); err != nil {
+func (s *WorkspaceService) StopAllGenerators(ctx context.Context, req *protos.StopAllGeneratorsRequest) (*protos.StopAllGeneratorsResponse, error) {
+	dev, err := s.workspace(ctx, req.WorkspaceId, OpGen)
+	if err != nil {
+		return nil, err
+	}
+	if err := dev.StopAllGenerators(); err != nil {
 		return nil, err
 	}
 	return &protos.StopAllGeneratorsResponse{}, nil
@@ -119,49 +232,72 @@ func (s *WorkspaceService) StopAllGenerators(_ context.Context, _ *protos.StopAl
 
 // Metric management
 
-func (s *WorkspaceService) AddMetric(_ context.Context, req *protos.AddMetricRequest) (*protos.AddMetricResponse, error) {
+func (s *WorkspaceService) AddMetric(ctx context.Context, req *protos.AddMetricRequest) (*protos.AddMetricResponse, error) {
+	dev, err := s.workspace(ctx, req.WorkspaceId, OpMeasure)
+	if err != nil {
+		return nil, err
+	}
 	m := req.Metric
 	if m == nil {
 		return nil, fmt.Errorf("metric is required")
 	}
 	spec := &runtime.Metric{Metric: m}
-	if err := s.DevEnv.AddMetric(spec); err != nil {
+	if err := dev.AddMetric(spec); err != nil {
 		return nil, err
 	}
 	return &protos.AddMetricResponse{Metric: m}, nil
 }
 
-func (s *WorkspaceService) DeleteMetric(_ context.Context, req *protos.DeleteMetricRequest) (*protos.DeleteMetricResponse, error) {
-	if err := s.DevEnv.RemoveMetric(req.MetricName); err != nil {
+func (s *WorkspaceService) DeleteMetric(ctx context.Context, req *protos.DeleteMetricRequest) (*protos.DeleteMetricResponse, error) {
+	dev, err := s.workspace(ctx, req.WorkspaceId, OpMeasure)
+	if err != nil {
+		return nil, err
+	}
+	if err := dev.RemoveMetric(req.MetricName); err != nil {
 		return nil, err
 	}
 	return &protos.DeleteMetricResponse{}, nil
 }
 
-func (s *WorkspaceService) ListMetrics(_ context.Context, _ *protos.ListMetricsRequest) (*protos.ListMetricsResponse, error) {
-	return &protos.ListMetricsResponse{Metrics: s.DevEnv.ListMetrics()}, nil
+func (s *WorkspaceService) ListMetrics(ctx context.Context, req *protos.ListMetricsRequest) (*protos.ListMetricsResponse, error) {
+	dev, err := s.workspace(ctx, req.WorkspaceId, OpRead)
+	if err != nil {
+		return nil, err
+	}
+	return &protos.ListMetricsResponse{Metrics: dev.ListMetrics()}, nil
 }
 
 // Parameters
 
-func (s *WorkspaceService) SetParameter(_ context.Context, req *protos.SetParameterRequest) (*protos.SetParameterResponse, error) {
+func (s *WorkspaceService) SetParameter(ctx context.Context, req *protos.SetParameterRequest) (*protos.SetParameterResponse, error) {
+	dev, err := s.workspace(ctx, req.WorkspaceId, OpSet)
+	if err != nil {
+		return nil, err
+	}
 	value := parseParameterValue(req.NewValue)
-	if err := s.DevEnv.SetParameter(req.Path, value); err != nil {
+	if err := dev.SetParameter(req.Path, value); err != nil {
 		return nil, err
 	}
-	s.DevEnv.EvaluateFlows("runtime")
+	dev.EvaluateFlows("runtime")
 	return &protos.SetParameterResponse{}, nil
 }
 
-func (s *WorkspaceService) GetParameters(_ context.Context, _ *protos.GetParametersRequest) (*protos.GetParametersResponse, error) {
+func (s *WorkspaceService) GetParameters(ctx context.Context, req *protos.GetParametersRequest) (*protos.GetParametersResponse, error) {
+	if _, err := s.workspace(ctx, req.WorkspaceId, OpRead); err != nil {
+		return nil, err
+	}
 	// DevEnv doesn't have a GetParameters equivalent yet
 	return &protos.GetParametersResponse{}, nil
 }
 
 // Diagram and flow analysis
 
-func (s *WorkspaceService) GetSystemDiagram(_ context.Context, _ *protos.GetSystemDiagramRequest) (*protos.GetSystemDiagramResponse, error) {
-	diagram, err := s.DevEnv.GetSystemDiagram()
+func (s *WorkspaceService) GetSystemDiagram(ctx context.Context, req *protos.GetSystemDiagramRequest) (*protos.GetSystemDiagramResponse, error) {
+	dev, err := s.workspace(ctx, req.WorkspaceId, OpRead)
+	if err != nil {
+		return nil, err
+	}
+	diagram, err := dev.GetSystemDiagram()
 	if err != nil {
 		return nil, err
 	}
@@ -170,24 +306,36 @@ func (s *WorkspaceService) GetSystemDiagram(_ context.Context, _ *protos.GetSyst
 	}, nil
 }
 
-func (s *WorkspaceService) EvaluateFlows(_ context.Context, req *protos.EvaluateFlowsRequest) (*protos.EvaluateFlowsResponse, error) {
+func (s *WorkspaceService) EvaluateFlows(ctx context.Context, req *protos.EvaluateFlowsRequest) (*protos.EvaluateFlowsResponse, error) {
+	dev, err := s.workspace(ctx, req.WorkspaceId, OpSet)
+	if err != nil {
+		return nil, err
+	}
 	strategy := req.Strategy
 	if strategy == "" {
 		strategy = "runtime"
 	}
-	result, err := s.DevEnv.EvaluateFlows(strategy)
+	result, err := dev.EvaluateFlows(strategy)
 	if err != nil {
 		return nil, err
 	}
 	return &protos.EvaluateFlowsResponse{
 		Strategy:       strategy,
-		Status:         "applied",
+		Status:         string(result.Status),
+		Iterations:     int32(result.Iterations),
+		Warnings:       result.Warnings,
 		ComponentRates: result.Flows.ComponentRates,
+		Converged:      result.Status == runtime.FlowStatusConverged,
+		Residual:       result.Residual,
 	}, nil
 }
 
-func (s *WorkspaceService) GetFlowState(_ context.Context, _ *protos.GetFlowStateRequest) (*protos.GetFlowStateResponse, error) {
-	rates, strategy := s.DevEnv.GetFlowState()
+func (s *WorkspaceService) GetFlowState(ctx context.Context, req *protos.GetFlowStateRequest) (*protos.GetFlowStateResponse, error) {
+	dev, err := s.workspace(ctx, req.WorkspaceId, OpRead)
+	if err != nil {
+		return nil, err
+	}
+	rates, strategy := dev.GetFlowState()
 	return &protos.GetFlowStateResponse{
 		State: &protos.FlowState{
 			Strategy: strategy,
@@ -196,19 +344,57 @@ func (s *WorkspaceService) GetFlowState(_ context.Context, _ *protos.GetFlowStat
 	}, nil
 }
 
-func (s *WorkspaceService) BatchSetParameters(_ context.Context, req *protos.BatchSetParametersRequest) (*protos.BatchSetParametersResponse, error) {
+// GetFlows returns the evaluated flow through every component method of the
+// active system.
+func (s *WorkspaceService) GetFlows(ctx context.Context, req *protos.GetFlowsRequest) (*protos.GetFlowsResponse, error) {
+	dev, err := s.workspace(ctx, req.WorkspaceId, OpRead)
+	if err != nil {
+		return nil, err
+	}
+	samples := int(req.Samples)
+	if samples <= 0 {
+		samples = 100
+	}
+	flows, err := dev.GetFlows(samples)
+	if err != nil {
+		return nil, err
+	}
+	_, strategy := dev.GetFlowState()
+	resp := &protos.GetFlowsResponse{System: dev.GetActiveSystemName(), Strategy: strategy}
+	for _, flow := range flows {
+		resp.Flows = append(resp.Flows, &protos.FlowEntry{
+			Component:           flow.Component,
+			Method:              flow.Method,
+			ArrivalRate:         flow.ArrivalRate,
+			ServiceRate:         flow.ServiceRate,
+			Utilization:         flow.Utilization,
+			LatencyContribution: flow.LatencyContribution,
+		})
+	}
+	return resp, nil
+}
+
+func (s *WorkspaceService) BatchSetParameters(ctx context.Context, req *protos.BatchSetParametersRequest) (*protos.BatchSetParametersResponse, error) {
+	dev, err := s.workspace(ctx, req.WorkspaceId, OpSet)
+	if err != nil {
+		return nil, err
+	}
 	updates := make(map[string]any)
 	for _, u := range req.Updates {
 		updates[u.Path] = parseParameterValue(u.NewValue)
 	}
-	if err := s.DevEnv.BatchSetParameters(updates); err != nil {
+	if err := dev.BatchSetParameters(updates); err != nil {
 		return &protos.BatchSetParametersResponse{Success: false, ErrorMessage: err.Error()}, nil
 	}
 	return &protos.BatchSetParametersResponse{Success: true}, nil
 }
 
-func (s *WorkspaceService) ExecuteTrace(_ context.Context, req *protos.ExecuteTraceRequest) (*protos.ExecuteTraceResponse, error) {
-	traceData, err := s.DevEnv.ExecuteTrace(req.Component, req.Method)
+func (s *WorkspaceService) ExecuteTrace(ctx context.Context, req *protos.ExecuteTraceRequest) (*protos.ExecuteTraceResponse, error) {
+	dev, err := s.workspace(ctx, req.WorkspaceId, OpRead)
+	if err != nil {
+		return nil, err
+	}
+	traceData, err := dev.ExecuteTrace(req.Component, req.Method)
 	if err != nil {
 		return nil, err
 	}
@@ -217,24 +403,37 @@ func (s *WorkspaceService) ExecuteTrace(_ context.Context, req *protos.ExecuteTr
 		EntryPoint: traceData.EntryPoint,
 	}
 	for _, evt := range traceData.Events {
-		te := &protos.TraceEvent{
-			Kind:      string(evt.Kind),
-			Timestamp: float64(evt.Timestamp),
-			Duration:  float64(evt.Duration),
-		}
-		if evt.Component != nil {
-			te.Component = evt.Component.ID()
-		}
-		if evt.Method != nil {
-			te.Method = evt.Method.Name.Value
-		}
-		td.Events = append(td.Events, te)
+		td.Events = append(td.Events, evt.ToProto())
 	}
 	return &protos.ExecuteTraceResponse{TraceData: td}, nil
 }
 
-func (s *WorkspaceService) TraceAllPaths(_ context.Context, req *protos.TraceAllPathsRequest) (*protos.TraceAllPathsResponse, error) {
-	data, err := s.DevEnv.TraceAllPaths(req.Component, req.Method, req.MaxDepth)
+// StreamTraces runs one traced call of the method and sends each enter and
+// exit event to the client as the call unfolds.
+func (s *WorkspaceService) StreamTraces(req *protos.StreamTracesRequest, stream protoservices.WorkspaceService_StreamTracesServer) error {
+	dev, err := s.workspace(stream.Context(), req.WorkspaceId, OpRead)
+	if err != nil {
+		return err
+	}
+	sink := runtime.NewStreamTraceSink(stream)
+	var sendErr error
+	err = dev.TraceStream(req.Component+"."+req.Method, func(event *runtime.TraceEvent) {
+		if sendErr == nil {
+			sendErr = sink.Write(event)
+		}
+	})
+	if err != nil {
+		return err
+	}
+	return sendErr
+}
+
+func (s *WorkspaceService) TraceAllPaths(ctx context.Context, req *protos.TraceAllPathsRequest) (*protos.TraceAllPathsResponse, error) {
+	dev, err := s.workspace(ctx, req.WorkspaceId, OpRead)
+	if err != nil {
+		return nil, err
+	}
+	data, err := dev.TraceAllPaths(req.Component, req.Method, req.MaxDepth)
 	if err != nil {
 		return nil, err
 	}
@@ -247,8 +446,12 @@ func (s *WorkspaceService) TraceAllPaths(_ context.Context, req *protos.TraceAll
 	}, nil
 }
 
-func (s *WorkspaceService) GetUtilization(_ context.Context, _ *protos.GetUtilizationRequest) (*protos.GetUtilizationResponse, error) {
-	utils := s.DevEnv.GetUtilization()
+func (s *WorkspaceService) GetUtilization(ctx context.Context, req *protos.GetUtilizationRequest) (*protos.GetUtilizationResponse, error) {
+	dev, err := s.workspace(ctx, req.WorkspaceId, OpRead)
+	if err != nil {
+		return nil, err
+	}
+	utils := dev.GetUtilization()
 	resp := &protos.GetUtilizationResponse{}
 	for _, u := range utils {
 		for _, info := range u.Infos {
@@ -263,13 +466,17 @@ func (s *WorkspaceService) GetUtilization(_ context.Context, _ *protos.GetUtiliz
 	return resp, nil
 }
 
-func (s *WorkspaceService) QueryMetrics(_ context.Context, req *protos.QueryMetricsRequest) (*protos.QueryMetricsResponse, error) {
+func (s *WorkspaceService) QueryMetrics(ctx context.Context, req *protos.QueryMetricsRequest) (*protos.QueryMetricsResponse, error) {
+	dev, err := s.workspace(ctx, req.WorkspaceId, OpRead)
+	if err != nil {
+		return nil, err
+	}
 	opts := runtime.QueryOptions{
 		StartTime: time.Unix(int64(req.StartTime), 0),
 		EndTime:   time.Unix(int64(req.EndTime), 0),
 		Limit:     int(req.Limit),
 	}
-	result, err := s.DevEnv.QueryMetrics(req.MetricName, opts)
+	result, err := dev.QueryMetrics(req.MetricName, opts)
 	if err != nil {
 		return nil, err
 	}
@@ -283,6 +490,109 @@ func (s *WorkspaceService) QueryMetrics(_ context.Context, req *protos.QueryMetr
 	return resp, nil
 }
 
+func (s *WorkspaceService) GetMeasurementStats(ctx context.Context, req *protos.GetMeasurementStatsRequest) (*protos.GetMeasurementStatsResponse, error) {
+	dev, err := s.workspace(ctx, req.WorkspaceId, OpRead)
+	if err != nil {
+		return nil, err
+	}
+	stats, err := dev.MeasurementStats()
+	if err != nil {
+		return nil, err
+	}
+	resp := &protos.GetMeasurementStatsResponse{
+		TotalRows:     stats.TotalRows,
+		RowsPerMetric: stats.RowsPerMetric,
+		SizeBytes:     stats.SizeBytes,
+		WriteRate:     stats.WriteRate,
+	}
+	if !stats.OldestTimestamp.IsZero() {
+		resp.OldestTimestamp = float64(stats.OldestTimestamp.Unix())
+		resp.NewestTimestamp = float64(stats.NewestTimestamp.Unix())
+	}
+	return resp, nil
+}
+
+// ListRuns pages through the workspace's run history, newest first.
+func (s *WorkspaceService) ListRuns(ctx context.Context, req *protos.ListRunsRequest) (*protos.ListRunsResponse, error) {
+	dev, err := s.workspace(ctx, req.WorkspaceId, OpRead)
+	if err != nil {
+		return nil, err
+	}
+	runs, total := dev.ListRuns(int(req.Offset), int(req.Limit))
+	resp := &protos.ListRunsResponse{Total: int32(total)}
+	for _, run := range runs {
+		resp.Runs = append(resp.Runs, &protos.RunRecord{
+			Id:         int32(run.ID),
+			Target:     run.Target,
+			Timestamp:  float64(run.Timestamp.UnixNano()) / 1e9,
+			ParamsHash: run.ParamsHash,
+			Cached:     run.Cached,
+			Summary: &protos.RunSummary{
+				Count:  int32(run.Summary.Count),
+				Errors: int32(run.Summary.Errors),
+				Mean:   toProtoEstimate(run.Summary.Mean),
+				P50:    toProtoEstimate(run.Summary.P50),
+				P95:    toProtoEstimate(run.Summary.P95),
+				P99:    toProtoEstimate(run.Summary.P99),
+			},
+		})
+	}
+	return resp, nil
+}
+
+func toProtoEstimate(e types.Estimate) *protos.LatencyEstimate {
+	return &protos.LatencyEstimate{Value: e.Value, CiLow: e.CI.Low, CiHigh: e.CI.High}
+}
+
+// Limits on a single Simulate call so one request cannot tie up the server.
+const (
+	MaxSimulateRuns     = 100000
+	MaxSimulateDuration = 5 * time.Minute
+)
+
+// Simulate compiles and runs a self contained model in an ephemeral DevEnv,
+// independent of this workspace's state.  Runs and Duration are capped at
+// MaxSimulateRuns and MaxSimulateDuration.
+func (s *WorkspaceService) Simulate(ctx context.Context, req *protos.SimulateRequest) (*protos.SimulateResponse, error) {
+	if req.Runs > MaxSimulateRuns {
+		return nil, status.Errorf(codes.InvalidArgument, "runs %d exceeds the limit of %d", req.Runs, MaxSimulateRuns)
+	}
+	duration := time.Duration(req.Duration * float64(time.Second))
+	if req.Duration > MaxSimulateDuration.Seconds() {
+		return nil, status.Errorf(codes.InvalidArgument, "duration %gs exceeds the limit of %s", req.Duration, MaxSimulateDuration)
+	}
+	result, err := services.Simulate(ctx, &services.SimulateRequest{
+		SDLContent: req.SdlContent,
+		SystemName: req.SystemName,
+		Generators: req.Generators,
+		Duration:   duration,
+		Runs:       int(req.Runs),
+		Metrics:    req.Metrics,
+		Budget: runtime.RunBudget{
+			MaxWallClock:  time.Duration(req.MaxWallClock * float64(time.Second)),
+			MaxTraceNodes: int(req.MaxTraceNodes),
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	resp := &protos.SimulateResponse{MetricSeries: map[string]*protos.MetricSeries{}}
+	for name, points := range result.MetricSeries {
+		series := &protos.MetricSeries{}
+		for _, p := range points {
+			series.Points = append(series.Points, &protos.MetricPoint{
+				Timestamp: float64(p.Timestamp.UnixNano()) / 1e9,
+				Value:     p.Value,
+			})
+		}
+		resp.MetricSeries[name] = series
+	}
+	for _, d := range result.Errors {
+		resp.Errors = append(resp.Errors, &protos.SimulationDiagnostic{Line: int32(d.Line), Col: int32(d.Col), Message: d.Message})
+	}
+	return resp, nil
+}
+
 // parseParameterValue converts a string value to the most appropriate Go type.
 func parseParameterValue(s string) any {
 	if v, err := strconv.ParseInt(s, 10, 64); err == nil {
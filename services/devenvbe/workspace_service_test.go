@@ -2,14 +2,28 @@ package devenvbe
 
 import (
 	"context"
+	"fmt"
+	"io"
+	"net"
 	"path/filepath"
 	goruntime "runtime"
+	"strings"
 	"testing"
+	"time"
 
 	protos "github.com/panyam/sdl/gen/go/sdl/v1/models"
+	protoservices "github.com/panyam/sdl/gen/go/sdl/v1/services"
 	"github.com/panyam/sdl/lib/loader"
+	sdlruntime "github.com/panyam/sdl/lib/runtime"
+	"github.com/panyam/sdl/services"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
 )
 
 func testFixturePath(name string) string {
@@ -30,6 +44,27 @@ func loadAndUse(t *testing.T, svc *WorkspaceService, fixture, system string) {
 	require.NoError(t, err)
 }
 
+// newTestClient serves svc over an in-process gRPC connection, with the
+// interceptors the server installs, and returns a client for it.
+func newTestClient(t *testing.T, svc *WorkspaceService) protoservices.WorkspaceServiceClient {
+	t.Helper()
+	listener := bufconn.Listen(1 << 20)
+	server := grpc.NewServer(
+		grpc.UnaryInterceptor(AuthInterceptor(svc.Authn)),
+		grpc.StreamInterceptor(StreamAuthInterceptor(svc.Authn)),
+	)
+	protoservices.RegisterWorkspaceServiceServer(server, svc)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return protoservices.NewWorkspaceServiceClient(conn)
+}
+
 // TestDevEnvWorkspaceServiceLoadAndUse verifies that the devenvbe backend
 // can load an SDL file and activate a system. This is the core lifecycle
 // that all other operations depend on.
@@ -50,6 +85,40 @@ func TestDevEnvWorkspaceServiceLoadAndUse(t *testing.T) {
 	assert.Equal(t, "SimpleAppLoadTest", svc.DevEnv.GetActiveSystemName())
 }
 
+// TestDevEnvWorkspaceServiceGetManifest verifies that the manifest of the
+// loaded files reaches clients with its systems, instances and components.
+func TestDevEnvWorkspaceServiceGetManifest(t *testing.T) {
+	svc := newTestService()
+	ctx := context.Background()
+	loadAndUse(t, svc, "saturation.sdl", "App")
+
+	resp, err := svc.GetManifest(ctx, &protos.GetManifestRequest{})
+	require.NoError(t, err)
+	manifest := resp.Manifest
+	require.NotNil(t, manifest)
+	assert.EqualValues(t, loader.ManifestVersion, manifest.Version)
+
+	require.Len(t, manifest.Systems, 1)
+	system := manifest.Systems[0]
+	assert.Equal(t, "App", system.Name)
+	require.Len(t, system.Parameters, 1)
+	assert.Equal(t, "server", system.Parameters[0].Path)
+	assert.Equal(t, "Server", system.Parameters[0].Component)
+	require.Len(t, system.Instances, 2)
+	assert.Equal(t, []string{"server.pool"}, system.Instances[0].Uses)
+	assert.Equal(t, "server.pool", system.Instances[1].Path)
+
+	components := map[string]*protos.ManifestComponent{}
+	for _, comp := range manifest.Components {
+		components[comp.Name] = comp
+	}
+	require.Contains(t, components, "Server")
+	assert.Equal(t, "Handle", components["Server"].Methods[0].Name)
+	assert.Equal(t, "Bool", components["Server"].Methods[0].Returns)
+	require.Contains(t, components, "ResourcePool")
+	assert.True(t, components["ResourcePool"].Native)
+}
+
 // TestDevEnvWorkspaceServiceGeneratorLifecycle verifies CRUD operations on
 // generators through the proto-typed WorkspaceService interface. Tests that
 // declared generators appear in ListGenerators after Use(), and that
@@ -98,6 +167,72 @@ func TestDevEnvWorkspaceServiceMetricLifecycle(t *testing.T) {
 	assert.Len(t, listResp.Metrics, 2)
 }
 
+// TestDevEnvWorkspaceServiceGetMeasurementStats verifies that the metric
+// store's stats are reported through the proto interface.
+func TestDevEnvWorkspaceServiceGetMeasurementStats(t *testing.T) {
+	svc := newTestService()
+	ctx := context.Background()
+	loadAndUse(t, svc, "system_with_metrics.sdl", "SimpleAppTest")
+
+	resp, err := svc.GetMeasurementStats(ctx, &protos.GetMeasurementStatsRequest{})
+	require.NoError(t, err)
+	assert.Zero(t, resp.TotalRows, "nothing has been measured yet")
+	assert.Zero(t, resp.OldestTimestamp, "an empty store has no timestamps")
+
+	stats, err := svc.DevEnv.MeasurementStats()
+	require.NoError(t, err)
+	assert.Equal(t, stats.SizeBytes, resp.SizeBytes)
+
+	// Send traffic and flush the open windows into the store
+	require.NoError(t, svc.DevEnv.RemoveGenerator("traffic"))
+	gen := &sdlruntime.Generator{Generator: &protos.Generator{Name: "load", Component: "app.server", Method: "HandleRequest", Rate: 200}}
+	require.NoError(t, svc.DevEnv.AddGenerator(gen))
+	require.Eventually(t, func() bool { return gen.Emitted() > 10 }, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, svc.DevEnv.StopAllGenerators())
+	svc.DevEnv.StopMetrics()
+
+	resp, err = svc.GetMeasurementStats(ctx, &protos.GetMeasurementStatsRequest{})
+	require.NoError(t, err)
+	assert.Positive(t, resp.TotalRows)
+	assert.Positive(t, resp.RowsPerMetric["throughput"])
+	assert.Positive(t, resp.RowsPerMetric["request_latency"])
+	assert.Zero(t, resp.RowsPerMetric["health_latency"], "HealthCheck was never called")
+	var total int64
+	for _, rows := range resp.RowsPerMetric {
+		total += rows
+	}
+	assert.Equal(t, resp.TotalRows, total)
+	assert.Positive(t, resp.SizeBytes)
+	assert.Positive(t, resp.WriteRate)
+	assert.Positive(t, resp.OldestTimestamp)
+	assert.LessOrEqual(t, resp.OldestTimestamp, resp.NewestTimestamp)
+}
+
+// TestDevEnvWorkspaceServiceListRuns verifies that the run history pages
+// through the service newest first with its summaries.
+func TestDevEnvWorkspaceServiceListRuns(t *testing.T) {
+	svc := newTestService()
+	ctx := context.Background()
+	loadAndUse(t, svc, "system_with_metrics.sdl", "SimpleAppTest")
+	for _, runs := range []int{10, 20, 30} {
+		_, _, err := svc.DevEnv.RunSimulation(services.RunOptions{Target: "app.server.HandleRequest", Runs: runs, Seed: 7})
+		require.NoError(t, err)
+	}
+
+	resp, err := svc.ListRuns(ctx, &protos.ListRunsRequest{Offset: 1, Limit: 1})
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), resp.Total)
+	require.Len(t, resp.Runs, 1)
+	run := resp.Runs[0]
+	expected, _ := svc.DevEnv.ListRuns(1, 1)
+	assert.Equal(t, int32(2), run.Id)
+	assert.Equal(t, "app.server.HandleRequest", run.Target)
+	assert.Equal(t, expected[0].ParamsHash, run.ParamsHash)
+	assert.Equal(t, int32(20), run.Summary.Count)
+	assert.Equal(t, expected[0].Summary.P95.Value, run.Summary.P95.Value)
+	assert.InDelta(t, float64(expected[0].Timestamp.UnixNano())/1e9, run.Timestamp, 1e-6)
+}
+
 // TestDevEnvWorkspaceServiceEvaluateFlows verifies that flow evaluation
 // returns component rates for the active system's generators.
 func TestDevEnvWorkspaceServiceEvaluateFlows(t *testing.T) {
@@ -111,6 +246,28 @@ func TestDevEnvWorkspaceServiceEvaluateFlows(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, "runtime", resp.Strategy)
 	assert.NotEmpty(t, resp.ComponentRates, "should have flow rates for components")
+	assert.Equal(t, "converged", resp.Status)
+	assert.True(t, resp.Converged)
+}
+
+// TestDevEnvWorkspaceServiceEvaluateFlowsNotConverged verifies that the
+// solver's status and residual are passed through when it runs out of
+// iterations.
+func TestDevEnvWorkspaceServiceEvaluateFlowsNotConverged(t *testing.T) {
+	svc := newTestService()
+	ctx := context.Background()
+	loadAndUse(t, svc, "saturation.sdl", "App")
+	gen := &sdlruntime.Generator{Generator: &protos.Generator{Name: "load", Component: "server", Method: "Handle", Rate: 10}}
+	require.NoError(t, svc.DevEnv.AddGenerator(gen))
+	defer svc.DevEnv.StopAllGenerators()
+	require.NoError(t, svc.DevEnv.SetFlowSolverOptions(0.01, 1))
+
+	resp, err := svc.EvaluateFlows(ctx, &protos.EvaluateFlowsRequest{Strategy: "runtime"})
+	require.NoError(t, err)
+	assert.Equal(t, "partial", resp.Status)
+	assert.False(t, resp.Converged)
+	assert.Equal(t, int32(1), resp.Iterations)
+	assert.Greater(t, resp.Residual, 0.01)
 }
 
 // TestDevEnvWorkspaceServiceGetDiagram verifies that GetSystemDiagram returns
@@ -160,3 +317,287 @@ func TestDevEnvWorkspaceServiceGetFlowState(t *testing.T) {
 	assert.Equal(t, "runtime", resp.State.Strategy)
 	assert.NotEmpty(t, resp.State.Rates)
 }
+
+// TestDevEnvWorkspaceServiceGetFlows verifies that GetFlows reports the
+// arrival rate, capacity and latency of each method carrying traffic.
+func TestDevEnvWorkspaceServiceGetFlows(t *testing.T) {
+	svc := newTestService()
+	ctx := context.Background()
+	loadAndUse(t, svc, "saturation.sdl", "App")
+
+	_, err := svc.AddGenerator(ctx, &protos.AddGeneratorRequest{
+		Generator:  &protos.Generator{Name: "load", Component: "server", Method: "Handle", Rate: 10},
+		ApplyFlows: true,
+	})
+	require.NoError(t, err)
+
+	resp, err := svc.GetFlows(ctx, &protos.GetFlowsRequest{Samples: 20})
+	require.NoError(t, err)
+	assert.Equal(t, "App", resp.System)
+	assert.Equal(t, "runtime", resp.Strategy)
+
+	flows := map[string]*protos.FlowEntry{}
+	for _, flow := range resp.Flows {
+		flows[flow.Component+"."+flow.Method] = flow
+	}
+	require.Contains(t, flows, "server.Handle")
+	assert.InDelta(t, 10, flows["server.Handle"].ArrivalRate, 0.01)
+	assert.Greater(t, flows["server.Handle"].LatencyContribution, 0.0)
+
+	require.Contains(t, flows, "server.pool.Acquire")
+	acquire := flows["server.pool.Acquire"]
+	assert.InDelta(t, 10, acquire.ArrivalRate, 0.01)
+	assert.InDelta(t, 0.5, acquire.Utilization, 0.01)
+	assert.InDelta(t, 20, acquire.ServiceRate, 0.1)
+
+	runs, err := svc.ListRuns(ctx, &protos.ListRunsRequest{})
+	require.NoError(t, err)
+	assert.Zero(t, runs.Total, "sampling flow latencies does not record runs")
+}
+
+func asPrincipal(principal string) context.Context {
+	return WithPrincipal(context.Background(), principal)
+}
+
+// TestDevEnvWorkspaceServicePrincipalIsolation verifies that each principal's
+// default workspace is their own, and that an Authorizer can stop a
+// principal from mutating another's workspace while still allowing reads.
+func TestDevEnvWorkspaceServicePrincipalIsolation(t *testing.T) {
+	svc := newTestService()
+	svc.Auth = AuthorizerFunc(func(_ context.Context, principal string, ws WorkspaceRef, op string) error {
+		if op != OpRead && ws.Owner != principal {
+			return fmt.Errorf("%s does not own the workspace", principal)
+		}
+		return nil
+	})
+	alice, bob := asPrincipal("alice"), asPrincipal("bob")
+
+	_, err := svc.LoadFile(alice, &protos.LoadFileRequest{SdlFilePath: testFixturePath("system_with_generators.sdl")})
+	require.NoError(t, err)
+	_, err = svc.UseSystem(alice, &protos.UseSystemRequest{SystemName: "SimpleAppLoadTest"})
+	require.NoError(t, err)
+	assert.Empty(t, svc.DevEnv.GetActiveSystemName(), "the anonymous default is not alice's")
+
+	resp, err := svc.ListGenerators(bob, &protos.ListGeneratorsRequest{})
+	require.NoError(t, err)
+	assert.Empty(t, resp.Generators, "bob's default is not alice's")
+
+	_, err = svc.DeleteGenerator(bob, &protos.DeleteGeneratorRequest{WorkspaceId: "alice/default", GeneratorName: "traffic"})
+	require.Error(t, err)
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	_, err = svc.UseSystem(bob, &protos.UseSystemRequest{WorkspaceId: "alice/default", SystemName: "SimpleAppLoadTest"})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	resp, err = svc.ListGenerators(bob, &protos.ListGeneratorsRequest{WorkspaceId: "alice/default"})
+	require.NoError(t, err)
+	assert.Len(t, resp.Generators, 2, "alice's generators are untouched")
+
+	_, err = svc.DeleteGenerator(alice, &protos.DeleteGeneratorRequest{GeneratorName: "traffic"})
+	require.NoError(t, err)
+}
+
+// TestDevEnvWorkspaceServiceDefaultAuth verifies that by default principals
+// cannot touch each other's workspaces, and that the principal comes from the
+// server's Authenticator rather than anything the client sends.
+func TestDevEnvWorkspaceServiceDefaultAuth(t *testing.T) {
+	svc := newTestService()
+	alice := asPrincipal("alice")
+	_, err := svc.LoadFile(alice, &protos.LoadFileRequest{SdlFilePath: testFixturePath("system_with_generators.sdl")})
+	require.NoError(t, err)
+	_, err = svc.UseSystem(alice, &protos.UseSystemRequest{SystemName: "SimpleAppLoadTest"})
+	require.NoError(t, err)
+
+	_, err = svc.ListGenerators(asPrincipal("bob"), &protos.ListGeneratorsRequest{WorkspaceId: "alice/default"})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	_, err = svc.DeleteGenerator(context.Background(), &protos.DeleteGeneratorRequest{WorkspaceId: "alice/default", GeneratorName: "traffic"})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	// Claiming to be alice in request metadata leaves the caller anonymous
+	tokens := map[string]string{"alice-token": "alice"}
+	svc.Authn = AuthenticatorFunc(func(ctx context.Context) (string, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		if values := md.Get("authorization"); len(values) > 0 {
+			if principal, ok := tokens[strings.TrimPrefix(values[0], "Bearer ")]; ok {
+				return principal, nil
+			}
+			return "", fmt.Errorf("invalid token")
+		}
+		return "", nil
+	})
+	intercept := AuthInterceptor(svc.Authn)
+	listAs := func(md metadata.MD) (*protos.ListGeneratorsResponse, error) {
+		resp, err := intercept(metadata.NewIncomingContext(context.Background(), md), &protos.ListGeneratorsRequest{}, nil,
+			func(ctx context.Context, req any) (any, error) {
+				return svc.ListGenerators(ctx, req.(*protos.ListGeneratorsRequest))
+			})
+		if err != nil {
+			return nil, err
+		}
+		return resp.(*protos.ListGeneratorsResponse), nil
+	}
+	resp, err := listAs(metadata.Pairs("loggedinuserid", "alice"))
+	require.NoError(t, err)
+	assert.Empty(t, resp.Generators)
+	resp, err = listAs(metadata.Pairs("authorization", "Bearer alice-token"))
+	require.NoError(t, err)
+	assert.Len(t, resp.Generators, 2)
+	_, err = listAs(metadata.Pairs("authorization", "Bearer forged"))
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+// TestDevEnvWorkspaceServiceStreamTraces verifies that a traced call streams
+// its enter and exit events to the client in order, and that streams are
+// authenticated like unary calls.
+func TestDevEnvWorkspaceServiceStreamTraces(t *testing.T) {
+	svc := newTestService()
+	loadAndUse(t, svc, "saturation.sdl", "App")
+	client := newTestClient(t, svc)
+
+	stream, err := client.StreamTraces(context.Background(), &protos.StreamTracesRequest{Component: "server", Method: "Handle"})
+	require.NoError(t, err)
+	var events []*protos.TraceEvent
+	for {
+		event, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		events = append(events, event)
+	}
+	require.NotEmpty(t, events)
+	assert.Equal(t, "enter", events[0].Kind)
+	assert.Equal(t, "server", events[0].Component)
+	assert.Equal(t, "Handle", events[0].Method)
+	assert.Equal(t, "exit", events[len(events)-1].Kind)
+	enters, exits := 0, 0
+	for _, event := range events {
+		switch event.Kind {
+		case "enter":
+			enters++
+		case "exit":
+			exits++
+		}
+	}
+	assert.Equal(t, enters, exits)
+
+	// Unknown methods fail the stream
+	stream, err = client.StreamTraces(context.Background(), &protos.StreamTracesRequest{Component: "server", Method: "Missing"})
+	require.NoError(t, err)
+	_, err = stream.Recv()
+	assert.ErrorContains(t, err, "method 'Missing' not found")
+
+	svc.Authn = AuthenticatorFunc(func(ctx context.Context) (string, error) {
+		return "", fmt.Errorf("invalid token")
+	})
+	client = newTestClient(t, svc)
+	stream, err = client.StreamTraces(context.Background(), &protos.StreamTracesRequest{Component: "server", Method: "Handle"})
+	require.NoError(t, err)
+	_, err = stream.Recv()
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+const simulateModel = `
+component Server {
+    method Handle() Bool {
+        return true
+    }
+}
+
+system App(server Server) {
+}
+`
+
+// TestDevEnvWorkspaceServiceSimulate verifies that a model submitted as
+// content is compiled, driven for a fixed number of calls and its metric
+// points returned, without touching the workspace's own state.
+func TestDevEnvWorkspaceServiceSimulate(t *testing.T) {
+	svc := newTestService()
+	client := newTestClient(t, svc)
+	resp, err := client.Simulate(context.Background(), &protos.SimulateRequest{
+		SdlContent: simulateModel,
+		SystemName: "App",
+		Generators: []*protos.Generator{{Name: "load", Component: "server", Method: "Handle", Rate: 200}},
+		Runs:       20,
+		Metrics: []*protos.Metric{{
+			Name:              "calls",
+			Component:         "server",
+			Methods:           []string{"Handle"},
+			MetricType:        "count",
+			Aggregation:       "sum",
+			AggregationWindow: 60,
+			Enabled:           true,
+		}},
+	})
+	require.NoError(t, err)
+	require.Empty(t, resp.Errors)
+	require.NotEmpty(t, resp.MetricSeries["calls"].GetPoints())
+	total := 0.0
+	for _, p := range resp.MetricSeries["calls"].Points {
+		total += p.Value
+	}
+	assert.Equal(t, 20.0, total)
+	assert.Empty(t, svc.DevEnv.GetActiveSystemName(), "workspace should be untouched")
+}
+
+// TestDevEnvWorkspaceServiceSimulateBudget verifies that a simulation whose
+// calls explode past its budget fails with a budget error.
+func TestDevEnvWorkspaceServiceSimulateBudget(t *testing.T) {
+	svc := newTestService()
+	client := newTestClient(t, svc)
+	resp, err := client.Simulate(context.Background(), &protos.SimulateRequest{
+		SdlContent: `
+component Server {
+    method Handle() Bool { self.Fan() return self.Fan() }
+    method Fan() Bool { self.Leaf() return self.Leaf() }
+    method Leaf() Bool { return true }
+}
+
+system App(server Server) {
+}
+`,
+		SystemName:    "App",
+		Generators:    []*protos.Generator{{Name: "load", Component: "server", Method: "Handle", Rate: 200}},
+		Runs:          100,
+		MaxTraceNodes: 50,
+	})
+	assert.Nil(t, resp)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "generator load: run budget exceeded")
+}
+
+// TestDevEnvWorkspaceServiceSimulateDiagnostics verifies that syntax and type
+// errors come back as positioned diagnostics and bad requests as errors.
+func TestDevEnvWorkspaceServiceSimulateDiagnostics(t *testing.T) {
+	svc := newTestService()
+	client := newTestClient(t, svc)
+	ctx := context.Background()
+	gens := []*protos.Generator{{Name: "load", Component: "server", Method: "Handle", Rate: 10}}
+
+	resp, err := client.Simulate(ctx, &protos.SimulateRequest{SdlContent: "component Server {\n  method Handle( {", SystemName: "App", Generators: gens, Runs: 1})
+	require.NoError(t, err)
+	require.Len(t, resp.Errors, 1)
+	assert.Equal(t, int32(2), resp.Errors[0].Line)
+
+	resp, err = client.Simulate(ctx, &protos.SimulateRequest{
+		SdlContent: "component Server {\n  param Size Int = \"big\"\n}\nsystem App(server Server) { }",
+		SystemName: "App", Generators: gens, Runs: 1,
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Errors, 1)
+	assert.Equal(t, int32(2), resp.Errors[0].Line)
+	assert.Contains(t, resp.Errors[0].Message, "type mismatch")
+
+	resp, err = client.Simulate(ctx, &protos.SimulateRequest{SdlContent: simulateModel, SystemName: "Nope", Generators: gens, Runs: 1})
+	require.NoError(t, err)
+	require.Len(t, resp.Errors, 1)
+
+	_, err = client.Simulate(ctx, &protos.SimulateRequest{SdlContent: simulateModel, SystemName: "App", Generators: gens})
+	assert.Error(t, err, "one of duration or runs is required")
+
+	_, err = client.Simulate(ctx, &protos.SimulateRequest{SdlContent: simulateModel, SystemName: "App", Generators: gens, Runs: MaxSimulateRuns + 1})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.ErrorContains(t, err, "runs 100001 exceeds the limit of 100000")
+	_, err = client.Simulate(ctx, &protos.SimulateRequest{SdlContent: simulateModel, SystemName: "App", Generators: gens, Duration: 3600})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.ErrorContains(t, err, "duration 3600s exceeds the limit of 5m0s")
+}
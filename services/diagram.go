@@ -5,16 +5,19 @@ import (
 	"log"
 	"strings"
 
+	"github.com/panyam/sdl/lib/core"
 	"github.com/panyam/sdl/lib/runtime"
 )
 
 // BuildSystemDiagram creates a system topology diagram from the given system state.
-// This is a standalone function so both Canvas and DevEnv can use it.
+// This is a standalone function so both Canvas and DevEnv can use it.  Edge
+// rates are labelled with precision significant figures.
 func BuildSystemDiagram(
 	system *runtime.SystemInstance,
 	generators map[string]*runtime.Generator,
 	flowScope *runtime.FlowScope,
 	currentFlowRates map[string]float64,
+	precision int,
 ) (*SystemDiagram, error) {
 	if system == nil {
 		return nil, fmt.Errorf("no active system set")
@@ -151,7 +154,7 @@ func BuildSystemDiagram(
 						Label:      "",
 					}
 					if rate > 0 {
-						newedge.Label = fmt.Sprintf("%.1f rps", rate)
+						newedge.Label = core.FormatSignificant(rate, precision) + " rps"
 					}
 					*edges = append(*edges, *newedge)
 				}
@@ -0,0 +1,106 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/panyam/sdl/lib/runtime"
+	"github.com/panyam/sdl/lib/types"
+)
+
+// EvalMode selects how SummarizeRun evaluates the calls it summarizes.
+type EvalMode int
+
+const (
+	// EvalModePerCall keeps the result of every call, as RunSimulation does,
+	// and summarizes them.  This is the default.
+	EvalModePerCall EvalMode = iota
+
+	// EvalModeAggregateOnly keeps only running statistics of the calls, see
+	// runtime.RunCallAggregates, for capacity sweeps too large to keep every
+	// result.  Results are never cached.
+	EvalModeAggregateOnly
+)
+
+func (m EvalMode) String() string {
+	if m == EvalModeAggregateOnly {
+		return "aggregate-only"
+	}
+	return "per-call"
+}
+
+// SetEvalMode sets how runs are summarized from now on.
+func (d *DevEnv) SetEvalMode(mode EvalMode) {
+	d.modelLock.Lock()
+	defer d.modelLock.Unlock()
+	d.evalMode = mode
+}
+
+// EvalMode returns how runs are summarized, see SetEvalMode.
+func (d *DevEnv) EvalMode() EvalMode {
+	d.modelLock.RLock()
+	defer d.modelLock.RUnlock()
+	return d.evalMode
+}
+
+// SummarizeRun invokes the target method Runs times on the active system,
+// like RunSimulation, and returns the summary of their latencies.  In
+// EvalModeAggregateOnly the results of the calls are not kept.
+func (d *DevEnv) SummarizeRun(opts RunOptions) (types.RunSummary, error) {
+	if d.EvalMode() != EvalModeAggregateOnly {
+		results, _, err := d.RunSimulation(opts)
+		if err != nil {
+			return types.RunSummary{}, err
+		}
+		return types.SummarizeRuns(results), nil
+	}
+
+	if d.activeSystem == nil {
+		return types.RunSummary{}, fmt.Errorf("no active system")
+	}
+	if opts.Runs <= 0 {
+		return types.RunSummary{}, fmt.Errorf("run count must be positive, got %d", opts.Runs)
+	}
+	componentName, methodName, err := d.runTarget(opts.Target)
+	if err != nil {
+		return types.RunSummary{}, err
+	}
+	if opts.Seed == 0 {
+		opts.Seed = d.defaultSeed
+	}
+	if opts.UnderLoad {
+		restore, err := d.applyBackgroundLoad(opts.Warmup)
+		if err != nil {
+			return types.RunSummary{}, err
+		}
+		defer restore()
+	}
+	if opts.Prime > 0 {
+		if err := d.Prime(opts.Target, opts.Prime); err != nil {
+			return types.RunSummary{}, err
+		}
+	}
+	numWorkers := opts.Workers
+	if numWorkers <= 0 {
+		numWorkers = 10
+	}
+	hist, err := runtime.RunCallAggregates(d.activeSystem, componentName, methodName, opts.Runs, numWorkers, opts.Seed, d.runBudget)
+	if err != nil {
+		return types.RunSummary{}, err
+	}
+	return types.SummarizeHistogram(hist), nil
+}
+
+// runTarget splits a "component.method" run target and checks the component
+// exists in the active system.
+func (d *DevEnv) runTarget(target string) (componentName, methodName string, err error) {
+	parts := strings.Split(target, ".")
+	if len(parts) < 2 {
+		return "", "", fmt.Errorf("invalid target '%s', expected component.method", target)
+	}
+	componentName, methodName = strings.Join(parts[:len(parts)-1], "."), parts[len(parts)-1]
+	if d.activeSystem.FindComponent(componentName) == nil {
+		return "", "", fmt.Errorf("component '%s' not found", componentName)
+	}
+	return componentName, methodName, nil
+}
@@ -0,0 +1,44 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/panyam/sdl/lib/types"
+)
+
+// BenchmarkSummarizeRun compares the throughput of summarizing runs through
+// RunSimulation with the aggregate only path, and fails if their summaries
+// disagree.
+func BenchmarkSummarizeRun(b *testing.B) {
+	dev := newTestDevEnv()
+	if err := dev.LoadFile(testFixturePath("estimate.sdl")); err != nil {
+		b.Fatal(err)
+	}
+	if err := dev.Use("App"); err != nil {
+		b.Fatal(err)
+	}
+
+	opts := RunOptions{Target: "server.Handle", Runs: 20000, Seed: 7, NoCache: true}
+	summaries := map[EvalMode]types.RunSummary{}
+	for _, mode := range []EvalMode{EvalModePerCall, EvalModeAggregateOnly} {
+		b.Run(mode.String(), func(b *testing.B) {
+			dev.SetEvalMode(mode)
+			for range b.N {
+				summary, err := dev.SummarizeRun(opts)
+				if err != nil {
+					b.Fatal(err)
+				}
+				summaries[mode] = summary
+			}
+			b.ReportMetric(float64(b.N*opts.Runs)/b.Elapsed().Seconds(), "calls/s")
+		})
+	}
+
+	perCall, aggregate := summaries[EvalModePerCall], summaries[EvalModeAggregateOnly]
+	if perCall.Count != opts.Runs || aggregate.Count != opts.Runs {
+		b.Fatalf("summarized %d and %d runs, expected %d", perCall.Count, aggregate.Count, opts.Runs)
+	}
+	if perCall.SignificantlyDifferent(aggregate) {
+		b.Errorf("per call mean %v differs from aggregate only mean %v", perCall.Mean, aggregate.Mean)
+	}
+}
@@ -0,0 +1,106 @@
+//go:build !wasm
+// +build !wasm
+
+package fsbe
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/panyam/sdl/services"
+)
+
+// CanvasStorage is a filesystem implementation of CanvasStore.  Each
+// snapshot is a file under the base directory:
+//
+//	<baseDir>/<id>.canvas
+type CanvasStorage struct {
+	mu      sync.RWMutex
+	baseDir string
+}
+
+const canvasExt = ".canvas"
+
+func NewCanvasStorage(baseDir string) (*CanvasStorage, error) {
+	if err := os.MkdirAll(baseDir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create canvas store %s: %w", baseDir, err)
+	}
+	return &CanvasStorage{baseDir: baseDir}, nil
+}
+
+func (s *CanvasStorage) canvasPath(id string) (string, error) {
+	if err := validateCanvasId(id); err != nil {
+		return "", err
+	}
+	return filepath.Join(s.baseDir, id+canvasExt), nil
+}
+
+func (s *CanvasStorage) Save(_ context.Context, id string, snapshot []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	path, err := s.canvasPath(id)
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(path, snapshot)
+}
+
+func (s *CanvasStorage) Load(_ context.Context, id string) ([]byte, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	path, err := s.canvasPath(id)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("canvas %s not found", id)
+	}
+	return data, err
+}
+
+func (s *CanvasStorage) List(_ context.Context) ([]string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	entries, err := os.ReadDir(s.baseDir)
+	if err != nil {
+		return nil, err
+	}
+	ids := []string{}
+	for _, entry := range entries {
+		if name := entry.Name(); !entry.IsDir() && strings.HasSuffix(name, canvasExt) {
+			ids = append(ids, strings.TrimSuffix(name, canvasExt))
+		}
+	}
+	sort.Strings(ids)
+	return ids, nil
+}
+
+func (s *CanvasStorage) Delete(_ context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	path, err := s.canvasPath(id)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// validateCanvasId rejects ids that cannot be used as a single path
+// segment or object key.
+func validateCanvasId(id string) error {
+	if id == "" || id == "." || id == ".." || strings.ContainsAny(id, `/\`) {
+		return fmt.Errorf("invalid canvas id %q", id)
+	}
+	return nil
+}
+
+var _ services.CanvasStore = (*CanvasStorage)(nil)
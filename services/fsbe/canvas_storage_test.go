@@ -0,0 +1,118 @@
+//go:build !wasm
+// +build !wasm
+
+package fsbe
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/panyam/sdl/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeS3 is an in-memory S3Client.
+type fakeS3 struct {
+	mu      sync.Mutex
+	objects map[string][]byte // "bucket/key" -> body
+}
+
+func newFakeS3() *fakeS3 { return &fakeS3{objects: map[string][]byte{}} }
+
+func (f *fakeS3) PutObject(_ context.Context, bucket, key string, body []byte) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.objects[bucket+"/"+key] = append([]byte(nil), body...)
+	return nil
+}
+
+func (f *fakeS3) GetObject(_ context.Context, bucket, key string) ([]byte, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	body, ok := f.objects[bucket+"/"+key]
+	if !ok {
+		return nil, ErrObjectNotFound
+	}
+	return body, nil
+}
+
+func (f *fakeS3) ListObjects(_ context.Context, bucket, prefix string) (keys []string, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for name := range f.objects {
+		if key, ok := strings.CutPrefix(name, bucket+"/"); ok && strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return
+}
+
+func (f *fakeS3) DeleteObject(_ context.Context, bucket, key string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.objects, bucket+"/"+key)
+	return nil
+}
+
+// testCanvasStore saves, lists, reloads and deletes snapshots through store.
+func testCanvasStore(t *testing.T, store services.CanvasStore) {
+	ctx := context.Background()
+	snapshot := []byte(`{"activeSystem":"App","generators":[{"name":"load","rate":50}]}`)
+	require.NoError(t, store.Save(ctx, "checkout", snapshot))
+	require.NoError(t, store.Save(ctx, "contacts", []byte("v1")))
+	require.NoError(t, store.Save(ctx, "contacts", []byte("v2")))
+
+	ids, err := store.List(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"checkout", "contacts"}, ids)
+
+	loaded, err := store.Load(ctx, "checkout")
+	require.NoError(t, err)
+	assert.Equal(t, snapshot, loaded)
+	loaded, err = store.Load(ctx, "contacts")
+	require.NoError(t, err)
+	assert.Equal(t, "v2", string(loaded), "saving again replaces the snapshot")
+
+	require.NoError(t, store.Delete(ctx, "contacts"))
+	require.NoError(t, store.Delete(ctx, "contacts"), "deleting a missing canvas is not an error")
+	_, err = store.Load(ctx, "contacts")
+	assert.ErrorContains(t, err, "canvas contacts not found")
+	ids, err = store.List(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"checkout"}, ids)
+
+	assert.ErrorContains(t, store.Save(ctx, "../checkout", snapshot), "invalid canvas id")
+}
+
+// TestCanvasStorageRoundTrip verifies the filesystem store, including that a
+// store opened later on the same directory reads the snapshots back.
+func TestCanvasStorageRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewCanvasStorage(dir)
+	require.NoError(t, err)
+	testCanvasStore(t, store)
+
+	reopened, err := NewCanvasStorage(dir)
+	require.NoError(t, err)
+	ids, err := reopened.List(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"checkout"}, ids)
+}
+
+// TestS3CanvasStorage verifies the S3 store against a fake client, and that
+// it only sees snapshots under its own prefix.
+func TestS3CanvasStorage(t *testing.T) {
+	client := newFakeS3()
+	require.NoError(t, client.PutObject(context.Background(), "sdl", "canvases/notes.txt", []byte("not a canvas")))
+	require.NoError(t, client.PutObject(context.Background(), "sdl", "other/app.canvas", []byte("other prefix")))
+	testCanvasStore(t, NewS3CanvasStorage(client, "sdl", "canvases/"))
+
+	body, err := client.GetObject(context.Background(), "sdl", "canvases/checkout.canvas")
+	require.NoError(t, err)
+	assert.Contains(t, string(body), `"activeSystem":"App"`)
+}
@@ -0,0 +1,103 @@
+//go:build !wasm
+// +build !wasm
+
+package fsbe
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/panyam/sdl/services"
+)
+
+// ErrObjectNotFound is returned by an S3Client when a key does not exist.
+var ErrObjectNotFound = errors.New("object not found")
+
+// S3Client is the subset of an S3 client the canvas store needs.  It is kept
+// small so any SDK (or a fake in tests) can be adapted to it.
+type S3Client interface {
+	PutObject(ctx context.Context, bucket, key string, body []byte) error
+	// GetObject returns ErrObjectNotFound if the key does not exist
+	GetObject(ctx context.Context, bucket, key string) ([]byte, error)
+	ListObjects(ctx context.Context, bucket, prefix string) ([]string, error)
+	DeleteObject(ctx context.Context, bucket, key string) error
+}
+
+// S3CanvasStorage is an S3 implementation of CanvasStore.  Each snapshot is
+// an object in the bucket:
+//
+//	<prefix><id>.canvas
+type S3CanvasStorage struct {
+	client S3Client
+	bucket string
+	prefix string
+}
+
+func NewS3CanvasStorage(client S3Client, bucket, prefix string) *S3CanvasStorage {
+	return &S3CanvasStorage{client: client, bucket: bucket, prefix: prefix}
+}
+
+func (s *S3CanvasStorage) key(id string) (string, error) {
+	if err := validateCanvasId(id); err != nil {
+		return "", err
+	}
+	return s.prefix + id + canvasExt, nil
+}
+
+func (s *S3CanvasStorage) Save(ctx context.Context, id string, snapshot []byte) error {
+	key, err := s.key(id)
+	if err != nil {
+		return err
+	}
+	if err := s.client.PutObject(ctx, s.bucket, key, snapshot); err != nil {
+		return fmt.Errorf("failed to save canvas %s: %w", id, err)
+	}
+	return nil
+}
+
+func (s *S3CanvasStorage) Load(ctx context.Context, id string) ([]byte, error) {
+	key, err := s.key(id)
+	if err != nil {
+		return nil, err
+	}
+	data, err := s.client.GetObject(ctx, s.bucket, key)
+	if errors.Is(err, ErrObjectNotFound) {
+		return nil, fmt.Errorf("canvas %s not found", id)
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to load canvas %s: %w", id, err)
+	}
+	return data, nil
+}
+
+func (s *S3CanvasStorage) List(ctx context.Context) ([]string, error) {
+	keys, err := s.client.ListObjects(ctx, s.bucket, s.prefix)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list canvases: %w", err)
+	}
+	ids := []string{}
+	for _, key := range keys {
+		id := strings.TrimPrefix(key, s.prefix)
+		// Skip objects in nested "directories" and anything that is not a snapshot
+		if strings.HasSuffix(id, canvasExt) && !strings.Contains(id, "/") {
+			ids = append(ids, strings.TrimSuffix(id, canvasExt))
+		}
+	}
+	sort.Strings(ids)
+	return ids, nil
+}
+
+func (s *S3CanvasStorage) Delete(ctx context.Context, id string) error {
+	key, err := s.key(id)
+	if err != nil {
+		return err
+	}
+	if err := s.client.DeleteObject(ctx, s.bucket, key); err != nil && !errors.Is(err, ErrObjectNotFound) {
+		return fmt.Errorf("failed to delete canvas %s: %w", id, err)
+	}
+	return nil
+}
+
+var _ services.CanvasStore = (*S3CanvasStorage)(nil)
@@ -0,0 +1,191 @@
+//go:build !wasm
+// +build !wasm
+
+package fsbe
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	protos "github.com/panyam/sdl/gen/go/sdl/v1/models"
+	"github.com/panyam/sdl/services"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// WorkspaceStorage is a filesystem implementation of WorkspaceStorageProvider
+// so workspaces survive restarts.  Each workspace is a directory under the
+// base directory holding its manifest and the content of its designs:
+//
+//	<baseDir>/<id>/workspace.json
+//	<baseDir>/<id>/designs/<design name>.sdl
+type WorkspaceStorage struct {
+	mu      sync.RWMutex
+	baseDir string
+}
+
+func NewWorkspaceStorage(baseDir string) (*WorkspaceStorage, error) {
+	if err := os.MkdirAll(baseDir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create workspace store %s: %w", baseDir, err)
+	}
+	return &WorkspaceStorage{baseDir: baseDir}, nil
+}
+
+func (s *WorkspaceStorage) workspaceDir(id string) (string, error) {
+	if id == "" || id == "." || id == ".." || strings.ContainsAny(id, `/\`) {
+		return "", fmt.Errorf("invalid workspace id %q", id)
+	}
+	return filepath.Join(s.baseDir, id), nil
+}
+
+func (s *WorkspaceStorage) designPath(workspaceId, designName string) (string, error) {
+	dir, err := s.workspaceDir(workspaceId)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "designs", url.PathEscape(designName)+".sdl"), nil
+}
+
+func (s *WorkspaceStorage) LoadWorkspace(_ context.Context, id string) (*protos.Workspace, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.loadWorkspace(id)
+}
+
+func (s *WorkspaceStorage) loadWorkspace(id string) (*protos.Workspace, error) {
+	dir, err := s.workspaceDir(id)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "workspace.json"))
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("workspace %s not found", id)
+	} else if err != nil {
+		return nil, err
+	}
+	ws := &protos.Workspace{}
+	if err := protojson.Unmarshal(data, ws); err != nil {
+		return nil, fmt.Errorf("failed to parse workspace %s: %w", id, err)
+	}
+	return ws, nil
+}
+
+func (s *WorkspaceStorage) SaveWorkspace(_ context.Context, id string, ws *protos.Workspace) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	dir, err := s.workspaceDir(id)
+	if err != nil {
+		return err
+	}
+	data, err := protojson.MarshalOptions{Multiline: true}.Marshal(ws)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	return writeFileAtomic(filepath.Join(dir, "workspace.json"), data)
+}
+
+func (s *WorkspaceStorage) DeleteWorkspace(_ context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	dir, err := s.workspaceDir(id)
+	if err != nil {
+		return err
+	}
+	return os.RemoveAll(dir)
+}
+
+func (s *WorkspaceStorage) ListWorkspaces(_ context.Context) ([]*protos.Workspace, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	entries, err := os.ReadDir(s.baseDir)
+	if err != nil {
+		return nil, err
+	}
+	var out []*protos.Workspace
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		// Skip directories that are not workspaces
+		if ws, err := s.loadWorkspace(entry.Name()); err == nil {
+			out = append(out, ws)
+		}
+	}
+	return out, nil
+}
+
+func (s *WorkspaceStorage) LoadDesignContent(_ context.Context, workspaceId, designName string) (string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	path, err := s.designPath(workspaceId, designName)
+	if err != nil {
+		return "", err
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return "", fmt.Errorf("design %s not found in workspace %s", designName, workspaceId)
+	}
+	return string(data), err
+}
+
+func (s *WorkspaceStorage) SaveDesignContent(_ context.Context, workspaceId, designName, content string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	path, err := s.designPath(workspaceId, designName)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return writeFileAtomic(path, []byte(content))
+}
+
+func (s *WorkspaceStorage) LoadAllDesignContents(_ context.Context, workspaceId string) (map[string]string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	ws, err := s.loadWorkspace(workspaceId)
+	if err != nil {
+		return nil, err
+	}
+
+	contents := make(map[string]string)
+	for _, d := range ws.Designs {
+		path, err := s.designPath(workspaceId, d.Name)
+		if err != nil {
+			return nil, err
+		}
+		if data, err := os.ReadFile(path); err == nil {
+			contents[d.Name] = string(data)
+		}
+	}
+	return contents, nil
+}
+
+// writeFileAtomic writes through a temporary file so a crash never leaves a
+// partially written file behind.
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+var _ services.WorkspaceStorageProvider = (*WorkspaceStorage)(nil)
@@ -0,0 +1,55 @@
+//go:build !wasm
+// +build !wasm
+
+package fsbe
+
+import (
+	"context"
+	"testing"
+
+	protos "github.com/panyam/sdl/gen/go/sdl/v1/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+// TestWorkspaceStorageRoundTrip verifies that a workspace and its designs
+// saved by one store are read back unchanged by a store opened later on the
+// same directory, as after a restart.
+func TestWorkspaceStorageRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	ws := &protos.Workspace{
+		Id:           "contacts",
+		Name:         "Contacts",
+		Designs:      []*protos.WorkspaceDesign{{Name: "v1", File: "v1.sdl"}, {Name: "with cache", File: "cache.sdl"}},
+		ActiveDesign: "with cache",
+		Tags:         []string{"caching"},
+	}
+
+	store, err := NewWorkspaceStorage(dir)
+	require.NoError(t, err)
+	require.NoError(t, store.SaveWorkspace(ctx, ws.Id, ws))
+	require.NoError(t, store.SaveDesignContent(ctx, ws.Id, "v1", "component A {}"))
+	require.NoError(t, store.SaveDesignContent(ctx, ws.Id, "with cache", "component B {}"))
+
+	reopened, err := NewWorkspaceStorage(dir)
+	require.NoError(t, err)
+	loaded, err := reopened.LoadWorkspace(ctx, ws.Id)
+	require.NoError(t, err)
+	assert.True(t, proto.Equal(ws, loaded), "got %v", loaded)
+
+	contents, err := reopened.LoadAllDesignContents(ctx, ws.Id)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"v1": "component A {}", "with cache": "component B {}"}, contents)
+
+	all, err := reopened.ListWorkspaces(ctx)
+	require.NoError(t, err)
+	require.Len(t, all, 1)
+
+	require.NoError(t, reopened.DeleteWorkspace(ctx, ws.Id))
+	_, err = reopened.LoadWorkspace(ctx, ws.Id)
+	assert.ErrorContains(t, err, "workspace contacts not found")
+	_, err = reopened.LoadWorkspace(ctx, "../contacts")
+	assert.ErrorContains(t, err, "invalid workspace id")
+}
@@ -0,0 +1,149 @@
+package services
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	protos "github.com/panyam/sdl/gen/go/sdl/v1/models"
+	"github.com/panyam/sdl/lib/runtime"
+)
+
+// grafanaPanel is the subset of a Grafana v9 panel the generated dashboards use.
+type grafanaPanel struct {
+	ID          int               `json:"id"`
+	Type        string            `json:"type"`
+	Title       string            `json:"title"`
+	Description string            `json:"description,omitempty"`
+	GridPos     grafanaGridPos    `json:"gridPos"`
+	Datasource  grafanaDatasource `json:"datasource"`
+	Targets     []grafanaTarget   `json:"targets"`
+	FieldConfig grafanaFieldConf  `json:"fieldConfig"`
+	Options     map[string]any    `json:"options"`
+}
+
+type grafanaGridPos struct {
+	H int `json:"h"`
+	W int `json:"w"`
+	X int `json:"x"`
+	Y int `json:"y"`
+}
+
+type grafanaDatasource struct {
+	Type string `json:"type"`
+	UID  string `json:"uid"`
+}
+
+type grafanaTarget struct {
+	RefID        string            `json:"refId"`
+	Datasource   grafanaDatasource `json:"datasource"`
+	Expr         string            `json:"expr"`
+	LegendFormat string            `json:"legendFormat"`
+}
+
+type grafanaFieldConf struct {
+	Defaults  map[string]any `json:"defaults"`
+	Overrides []any          `json:"overrides"`
+}
+
+// prometheusDatasource is filled in when the dashboard is imported.
+var prometheusDatasource = grafanaDatasource{Type: "prometheus", UID: "${DS_PROMETHEUS}"}
+
+var invalidPrometheusChars = regexp.MustCompile(`[^a-zA-Z0-9_:]`)
+
+// prometheusMetricName maps a metric name to a valid Prometheus name the way
+// the StatsD and InfluxDB bridges do, eg "p99-latency" to "p99_latency".
+func prometheusMetricName(name string) string {
+	name = invalidPrometheusChars.ReplaceAllString(name, "_")
+	if name == "" || (name[0] >= '0' && name[0] <= '9') {
+		name = "_" + name
+	}
+	return name
+}
+
+// ExportGrafanaDashboard generates a Grafana v9 dashboard for the active
+// system's metrics as exported by AddMetricExporter and scraped into
+// Prometheus.  Counts are shown as throughput stats and the other metrics as
+// time series graphs, one panel per metric.  The Prometheus datasource is
+// chosen when the dashboard is imported.
+func (d *DevEnv) ExportGrafanaDashboard() (json.RawMessage, error) {
+	if d.activeSystem == nil || d.metricTracer == nil {
+		return nil, fmt.Errorf("no active system")
+	}
+	system := d.activeSystem.GetSystemName()
+	metrics := d.metricTracer.ListMetrics()
+	slices.SortFunc(metrics, func(a, b *protos.Metric) int { return cmp.Compare(a.Name, b.Name) })
+
+	panels := []grafanaPanel{}
+	for idx, metric := range metrics {
+		target := metricTarget(metric)
+		panel := grafanaPanel{
+			ID:          idx + 1,
+			Type:        "timeseries",
+			Title:       metric.Name,
+			Description: fmt.Sprintf("%s %s of %s", metric.Aggregation, metric.MetricType, target),
+			GridPos:     grafanaGridPos{H: 8, W: 12, X: (idx % 2) * 12, Y: (idx / 2) * 8},
+			Datasource:  prometheusDatasource,
+			Targets: []grafanaTarget{{
+				RefID:        "A",
+				Datasource:   prometheusDatasource,
+				Expr:         fmt.Sprintf(`%s{system=%q,target=%q}`, prometheusMetricName(metric.Name), system, target),
+				LegendFormat: target,
+			}},
+			FieldConfig: grafanaFieldConf{Defaults: map[string]any{}, Overrides: []any{}},
+			Options: map[string]any{
+				"legend":  map[string]any{"displayMode": "list", "placement": "bottom", "showLegend": true},
+				"tooltip": map[string]any{"mode": "single", "sort": "none"},
+			},
+		}
+		switch metric.MetricType {
+		case runtime.MetricLatency:
+			panel.FieldConfig.Defaults["unit"] = "s"
+		case runtime.MetricUtilization:
+			panel.FieldConfig.Defaults["unit"] = "percentunit"
+			panel.FieldConfig.Defaults["max"] = 1
+			panel.FieldConfig.Defaults["min"] = 0
+		case runtime.MetricCount:
+			panel.Type = "stat"
+			panel.FieldConfig.Defaults["unit"] = "short"
+			panel.Options = map[string]any{
+				"colorMode":   "value",
+				"graphMode":   "area",
+				"justifyMode": "auto",
+				"reduceOptions": map[string]any{
+					"calcs":  []string{"lastNotNull"},
+					"fields": "",
+					"values": false,
+				},
+			}
+		}
+		panels = append(panels, panel)
+	}
+
+	dashboard := map[string]any{
+		"__inputs": []map[string]any{{
+			"name":        "DS_PROMETHEUS",
+			"label":       "Prometheus",
+			"type":        "datasource",
+			"pluginId":    "prometheus",
+			"pluginName":  "Prometheus",
+			"description": "Prometheus scraping the exported SDL metrics",
+		}},
+		"title":         "SDL " + system,
+		"uid":           "sdl-" + strings.ToLower(prometheusMetricName(system)),
+		"tags":          []string{"sdl"},
+		"editable":      true,
+		"schemaVersion": 37,
+		"version":       1,
+		"refresh":       "5s",
+		"time":          map[string]string{"from": "now-15m", "to": "now"},
+		"timezone":      "browser",
+		"templating":    map[string]any{"list": []any{}},
+		"annotations":   map[string]any{"list": []any{}},
+		"panels":        panels,
+	}
+	return json.MarshalIndent(dashboard, "", "  ")
+}
@@ -0,0 +1,58 @@
+package services
+
+import (
+	protos "github.com/panyam/sdl/gen/go/sdl/v1/models"
+	"github.com/panyam/sdl/lib/runtime"
+)
+
+// AddMetricExporter pushes the value of every metric, current and future, to
+// exporter each time one of its windows closes.  Points are tagged with the
+// system, the measured target and the metric type and aggregation.  Pushes
+// happen in the background and failures are logged and retried rather than
+// failing the run.  The exporter is closed with the DevEnv.
+func (d *DevEnv) AddMetricExporter(exporter runtime.MetricExporter) *runtime.MetricExportQueue {
+	queue := runtime.NewMetricExportQueue(exporter)
+	d.exportersLock.Lock()
+	defer d.exportersLock.Unlock()
+	d.exporters = append(d.exporters, queue)
+	return queue
+}
+
+// exportMetric forwards the closed windows of a newly added metric to the
+// exporters registered at the time each window closes.
+func (d *DevEnv) exportMetric(metric *runtime.Metric) {
+	tags := map[string]string{
+		"target":      metricTarget(metric.Metric),
+		"type":        metric.MetricType,
+		"aggregation": metric.Aggregation,
+	}
+	if d.activeSystem != nil {
+		tags["system"] = d.activeSystem.GetSystemName()
+	}
+	name := metric.Name
+	metric.OnWindowClose(func(point *runtime.MetricPoint) {
+		d.exportersLock.RLock()
+		defer d.exportersLock.RUnlock()
+		for _, queue := range d.exporters {
+			queue.Push(runtime.ExportedPoint{Metric: name, Tags: tags, Timestamp: point.Timestamp, Value: point.Value})
+		}
+	})
+}
+
+// metricTarget names what a metric measures, eg "server.Handle".
+func metricTarget(metric *protos.Metric) string {
+	if len(metric.Methods) == 1 {
+		return metric.Component + "." + metric.Methods[0]
+	}
+	return metric.Component
+}
+
+func (d *DevEnv) closeMetricExporters() {
+	d.exportersLock.Lock()
+	exporters := d.exporters
+	d.exporters = nil
+	d.exportersLock.Unlock()
+	for _, queue := range exporters {
+		queue.Close()
+	}
+}
@@ -0,0 +1,128 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"slices"
+
+	protos "github.com/panyam/sdl/gen/go/sdl/v1/models"
+	"github.com/panyam/sdl/lib/loader"
+	"github.com/panyam/sdl/lib/runtime"
+)
+
+// MetricTemplate measures every instance of a component type, see
+// AddMetricTemplate.
+type MetricTemplate struct {
+	Component  string // Component type, eg "Database"
+	MetricType string
+}
+
+// TemplateMetricName returns the name of the metric a template creates for
+// an instance, eg "app.db_latency".
+func TemplateMetricName(instanceID, metricType string) string {
+	return instanceID + "_" + metricType
+}
+
+// AddMetricTemplate adds a metric of the given type on all the methods of
+// every instance of a component type in the active system, named after the
+// instance, see TemplateMetricName.  The template is kept so that systems
+// used later, and the active one when a reload adds or removes instances,
+// are measured the same way.  It returns the metrics it added in instance
+// order.
+func (d *DevEnv) AddMetricTemplate(component, metricType string) ([]*protos.Metric, error) {
+	if d.activeSystem == nil || d.metricTracer == nil {
+		return nil, fmt.Errorf("no active system")
+	}
+	template := MetricTemplate{Component: component, MetricType: metricType}
+	added, err := d.applyMetricTemplate(template)
+	if err != nil {
+		return added, err
+	}
+	if !slices.Contains(d.metricTemplates, template) {
+		d.metricTemplates = append(d.metricTemplates, template)
+	}
+	return added, nil
+}
+
+// ListMetricTemplates returns the templates added with AddMetricTemplate.
+func (d *DevEnv) ListMetricTemplates() []MetricTemplate {
+	return slices.Clone(d.metricTemplates)
+}
+
+// applyMetricTemplates adds the metrics of every template to a newly used
+// system.
+func (d *DevEnv) applyMetricTemplates() {
+	for _, template := range d.metricTemplates {
+		if _, err := d.applyMetricTemplate(template); err != nil {
+			log.Printf("Warning: failed to apply metric template for %s: %v", template.Component, err)
+		}
+	}
+}
+
+// applyMetricTemplate adds the template's metric for each instance of its
+// component in the active system that does not have one yet.
+func (d *DevEnv) applyMetricTemplate(template MetricTemplate) ([]*protos.Metric, error) {
+	manifest, err := d.Manifest()
+	if err != nil {
+		return nil, err
+	}
+	var methods, components []string
+	for _, comp := range manifest.Components {
+		components = append(components, comp.Name)
+		if comp.Name == template.Component {
+			for _, method := range comp.Methods {
+				methods = append(methods, method.Name)
+			}
+		}
+	}
+	if !slices.Contains(components, template.Component) {
+		err := fmt.Errorf("unknown component type '%s'", template.Component)
+		if suggestions := closestNames(template.Component, components); len(suggestions) > 0 {
+			err = fmt.Errorf("%w%s", err, didYouMean(suggestions))
+		}
+		return nil, err
+	}
+
+	var instances []loader.ManifestInstance
+	for _, sys := range manifest.Systems {
+		if sys.Name == d.activeSystem.GetSystemName() {
+			instances = sys.Instances
+		}
+	}
+	var existing []string
+	for _, metric := range d.ListMetrics() {
+		existing = append(existing, metric.Name)
+	}
+
+	var added []*protos.Metric
+	for _, inst := range instances {
+		if inst.Component != template.Component {
+			continue
+		}
+		// An instance shared by several components is measured once, at its id
+		if comp := d.activeSystem.FindComponent(inst.Path); comp == nil || comp.InstanceID() != inst.Path {
+			continue
+		}
+		name := TemplateMetricName(inst.Path, template.MetricType)
+		if slices.Contains(existing, name) {
+			continue
+		}
+		metric := &protos.Metric{
+			Name:              name,
+			Component:         inst.Path,
+			Methods:           methods,
+			MetricType:        template.MetricType,
+			Aggregation:       "avg",
+			AggregationWindow: 10,
+			Enabled:           true,
+		}
+		if template.MetricType == runtime.MetricCount {
+			metric.Aggregation = "sum"
+		}
+		if err := d.AddMetric(&runtime.Metric{Metric: metric}); err != nil {
+			return added, fmt.Errorf("failed to add metric '%s' for %s: %w", name, inst.Path, err)
+		}
+		added = append(added, metric)
+	}
+	return added, nil
+}
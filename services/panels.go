@@ -2,6 +2,7 @@ package services
 
 import (
 	protos "github.com/panyam/sdl/gen/go/sdl/v1/models"
+	"github.com/panyam/sdl/lib/loader"
 )
 
 // WorkspacePage is the Go interface that mirrors the WorkspacePage proto service.
@@ -14,6 +15,12 @@ type WorkspacePage interface {
 	// System panel: available systems list updated (e.g. after loading a new file)
 	OnAvailableSystemsChanged(systemNames []string)
 
+	// System panel: the loaded files were recompiled into a new model
+	OnModelChanged(manifest *loader.Manifest)
+
+	// Console panel: a changed file failed to recompile and the previous model is kept
+	OnDiagnostics(filePath string, diagnostics []Diagnostic)
+
 	// Diagram panel: system topology updated
 	UpdateDiagram(diagram *SystemDiagram)
 
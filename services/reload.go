@@ -0,0 +1,134 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"log/slog"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/panyam/sdl/lib/loader"
+	"github.com/panyam/sdl/lib/runtime"
+)
+
+// Reload recompiles the files loaded with LoadFile, along with the files they
+// import, so that edits take effect.  If any fails to compile its diagnostics
+// are pushed to the page and the current model is kept.  Otherwise the model
+// is replaced, the active system is recreated if it still exists and the page
+// is sent the new manifest.
+func (d *DevEnv) Reload() error {
+	d.modelLock.RLock()
+	loadedFiles := slices.Clone(d.loadedFiles)
+	maxCallDepth := d.runtime.MaxCallDepth
+	d.modelLock.RUnlock()
+
+	for _, path := range loadedFiles {
+		if result := Compile(d.resolver, path); !result.Success {
+			if page := d.getPage(); page != nil {
+				page.OnDiagnostics(path, result.Errors)
+			}
+			return fmt.Errorf("%s failed to compile, keeping the previous model", path)
+		}
+	}
+
+	rt := runtime.NewRuntime(loader.NewLoader(nil, d.resolver, loader.DefaultMaxImportDepth))
+	rt.MaxCallDepth = maxCallDepth
+	for _, path := range loadedFiles {
+		if _, err := rt.LoadFile(path); err != nil {
+			return err
+		}
+	}
+
+	activeName := d.GetActiveSystemName()
+	d.stopAllGeneratorsInternal()
+	d.modelLock.Lock()
+	d.runtime = rt
+	d.loadedSystems = make(map[string]*runtime.SystemInstance)
+	d.activeSystem = nil
+	d.modelLock.Unlock()
+	d.paramsVersion++
+	if activeName != "" && slices.Contains(d.AvailableSystems(), activeName) {
+		if err := d.Use(activeName); err != nil {
+			return err
+		}
+	} else {
+		// The active system is gone along with what ran on it
+		d.generatorsLock.Lock()
+		d.generators = make(map[string]*runtime.Generator)
+		d.generatorsLock.Unlock()
+		d.clearControllers()
+		if d.metricTracer != nil {
+			d.metricTracer.Clear()
+			d.metricTracer = nil
+		}
+	}
+
+	manifest, err := d.Manifest()
+	if err != nil {
+		return err
+	}
+	d.updateFileSums()
+	if page := d.getPage(); page != nil {
+		page.OnModelChanged(manifest)
+	}
+	return nil
+}
+
+// WatchFiles checks the loaded files, and the files they import, for changes
+// every interval and reloads them when any changed, see Reload, until ctx is
+// done.
+func (d *DevEnv) WatchFiles(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		latest := d.fileChecksums()
+		// Remember the edit even if it does not compile so its diagnostics
+		// are only pushed once
+		d.modelLock.Lock()
+		changed := !maps.Equal(d.fileSums, latest)
+		d.fileSums = latest
+		d.modelLock.Unlock()
+		if !changed {
+			continue
+		}
+		if err := d.Reload(); err != nil {
+			slog.Warn("Reload failed", "error", err)
+		}
+	}
+}
+
+// updateFileSums records the checksums of the loaded files as of now.
+func (d *DevEnv) updateFileSums() {
+	sums := d.fileChecksums()
+	d.modelLock.Lock()
+	d.fileSums = sums
+	d.modelLock.Unlock()
+}
+
+// fileChecksums returns a checksum of the contents of every loaded file by
+// path.  Files that cannot be read are left out.
+func (d *DevEnv) fileChecksums() map[string][sha256.Size]byte {
+	d.modelLock.RLock()
+	defer d.modelLock.RUnlock()
+	sums := map[string][sha256.Size]byte{}
+	for path := range d.runtime.Loader.GetAllLoadedFiles() {
+		reader, _, err := d.resolver.Resolve("", path, true)
+		if err != nil {
+			continue
+		}
+		content, err := io.ReadAll(reader)
+		reader.Close()
+		if err == nil {
+			sums[path] = sha256.Sum256(content)
+		}
+	}
+	return sums
+}
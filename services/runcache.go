@@ -0,0 +1,163 @@
+package services
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"sync"
+
+	"github.com/panyam/sdl/lib/loader"
+	"github.com/panyam/sdl/lib/runtime"
+	"github.com/panyam/sdl/lib/types"
+)
+
+// DefaultRunCacheSize is the number of run results retained by a DevEnv.
+const DefaultRunCacheSize = 32
+
+// RunCache is a bounded LRU cache of simulation results keyed by a hash of
+// every input that can affect a run.
+type RunCache struct {
+	mu      sync.Mutex
+	maxSize int
+	order   *list.List // front = most recently used
+	entries map[string]*list.Element
+	hits    int
+	misses  int
+}
+
+type runCacheEntry struct {
+	key     string
+	results []types.RunResult
+}
+
+// NewRunCache creates a cache holding at most maxSize results.
+func NewRunCache(maxSize int) *RunCache {
+	if maxSize <= 0 {
+		maxSize = DefaultRunCacheSize
+	}
+	return &RunCache{
+		maxSize: maxSize,
+		order:   list.New(),
+		entries: make(map[string]*list.Element),
+	}
+}
+
+// Get returns a copy of the cached results for a key.
+func (c *RunCache) Get(key string) ([]types.RunResult, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	elem, ok := c.entries[key]
+	if !ok {
+		c.misses++
+		return nil, false
+	}
+	c.hits++
+	c.order.MoveToFront(elem)
+	return slices.Clone(elem.Value.(*runCacheEntry).results), true
+}
+
+// Put stores a copy of results for a key evicting the least recently used
+// entry if full.
+func (c *RunCache) Put(key string, results []types.RunResult) {
+	results = slices.Clone(results)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if elem, ok := c.entries[key]; ok {
+		elem.Value.(*runCacheEntry).results = results
+		c.order.MoveToFront(elem)
+		return
+	}
+	c.entries[key] = c.order.PushFront(&runCacheEntry{key: key, results: results})
+	for c.order.Len() > c.maxSize {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*runCacheEntry).key)
+	}
+}
+
+// Clear drops all cached results.
+func (c *RunCache) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.order.Init()
+	c.entries = make(map[string]*list.Element)
+}
+
+// Len returns the number of cached results.
+func (c *RunCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+// Stats returns the number of cache hits and misses so far.
+func (c *RunCache) Stats() (hits, misses int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.hits, c.misses
+}
+
+// runCacheKey hashes all inputs of a run - the loaded compilation units, the
+// system, its effective parameter values, the seed, the target and the run count.
+// paramsVersion guards against parameter changes not visible via declared params
+// (eg undeclared fields on native components).
+func runCacheKey(l *loader.Loader, sys *runtime.SystemInstance, paramsVersion int64, opts RunOptions) string {
+	h := sha256.New()
+
+	// Any (re)load of a file changes its parse time so this invalidates on source changes
+	files := l.GetAllLoadedFiles()
+	for _, path := range slices.Sorted(maps.Keys(files)) {
+		fmt.Fprintf(h, "file:%s@%d\n", path, files[path].LastParsed.UnixNano())
+	}
+
+	fmt.Fprintf(h, "system:%s\n", sys.GetSystemName())
+	fmt.Fprintf(h, "params-version:%d\n", paramsVersion)
+	writeEffectiveParams(h, sys)
+	fmt.Fprintf(h, "seed:%d\ntarget:%s\nruns:%d\n", opts.Seed, opts.Target, opts.Runs)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// writeEffectiveParams writes the current value of every parameter of every
+// component reachable from the system, in a deterministic order.
+func writeEffectiveParams(w io.Writer, sys *runtime.SystemInstance) {
+	if sys.Env == nil {
+		return
+	}
+	bindings := sys.Env.All()
+	visited := map[*runtime.ComponentInstance]bool{}
+	for _, name := range slices.Sorted(maps.Keys(bindings)) {
+		if name == "self" {
+			continue
+		}
+		if comp, ok := bindings[name].Value.(*runtime.ComponentInstance); ok && comp != nil {
+			writeComponentParams(w, name, comp, visited)
+		}
+	}
+}
+
+func writeComponentParams(w io.Writer, path string, comp *runtime.ComponentInstance, visited map[*runtime.ComponentInstance]bool) {
+	if visited[comp] || comp.ComponentDecl == nil {
+		return
+	}
+	visited[comp] = true
+
+	params, _ := comp.ComponentDecl.Params()
+	for _, param := range params {
+		if value, ok := comp.Get(param.Name.Value); ok {
+			fmt.Fprintf(w, "param:%s.%s=%s\n", path, param.Name.Value, value.String())
+		}
+	}
+
+	deps, _ := comp.ComponentDecl.Dependencies()
+	for _, dep := range deps {
+		if value, ok := comp.Get(dep.Name.Value); ok {
+			if child, ok := value.Value.(*runtime.ComponentInstance); ok && child != nil {
+				writeComponentParams(w, path+"."+dep.Name.Value, child, visited)
+			}
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package services
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sync"
+	"time"
+
+	"github.com/panyam/sdl/lib/runtime"
+	"github.com/panyam/sdl/lib/types"
+)
+
+// DefaultRunHistorySize is the number of runs a DevEnv remembers.
+const DefaultRunHistorySize = 100
+
+// RunRecord describes a past run kept in a RunHistory.
+type RunRecord struct {
+	// Position of the run in the history, 1 for the first, so a record is
+	// identified the same way on every page
+	ID int `json:"id"`
+
+	Target    string    `json:"target"`
+	Timestamp time.Time `json:"timestamp"`
+
+	// Hash of the effective parameter values the run saw, equal for runs
+	// made with the same parameters
+	ParamsHash string `json:"paramsHash"`
+
+	Cached  bool             `json:"cached,omitempty"`
+	Summary types.RunSummary `json:"summary"`
+}
+
+// RunHistory is a bounded history of runs that drops the oldest once full.
+type RunHistory struct {
+	mu      sync.Mutex
+	maxSize int
+	records []RunRecord // oldest first
+	lastID  int
+}
+
+// NewRunHistory creates a history holding at most maxSize runs.
+func NewRunHistory(maxSize int) *RunHistory {
+	if maxSize <= 0 {
+		maxSize = DefaultRunHistorySize
+	}
+	return &RunHistory{maxSize: maxSize}
+}
+
+// Add records a run, giving it the next ID, and returns the record.
+func (h *RunHistory) Add(record RunRecord) RunRecord {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.lastID++
+	record.ID = h.lastID
+	h.records = append(h.records, record)
+	if len(h.records) > h.maxSize {
+		h.records = append(h.records[:0:0], h.records[len(h.records)-h.maxSize:]...)
+	}
+	return record
+}
+
+// List returns up to limit runs, newest first, after skipping offset of
+// them, along with the number of runs in the history.  A limit of 0 or less
+// returns every run after offset.
+func (h *RunHistory) List(offset, limit int) (records []RunRecord, total int) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	total = len(h.records)
+	offset = max(offset, 0)
+	if limit <= 0 || limit > total-offset {
+		limit = total - offset
+	}
+	for i := 0; i < limit; i++ {
+		records = append(records, h.records[total-1-offset-i])
+	}
+	return records, total
+}
+
+// Clear drops every run.  IDs keep counting from the last run.
+func (h *RunHistory) Clear() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.records = nil
+}
+
+// newRunRecord summarises the results of a run of target made at now.
+func newRunRecord(target string, sys *runtime.SystemInstance, results []types.RunResult, cached bool, now time.Time) RunRecord {
+	hist := types.NewLatencyHistogram()
+	errors := 0
+	for _, res := range results {
+		hist.Add(res.Latency)
+		if res.IsError {
+			errors++
+		}
+	}
+	summary := types.SummarizeHistogram(hist)
+	summary.Errors = errors
+	return RunRecord{
+		Target:     target,
+		Timestamp:  now,
+		ParamsHash: paramsHash(sys),
+		Cached:     cached,
+		Summary:    summary,
+	}
+}
+
+// paramsHash hashes the effective parameter values of every component of a
+// system, see writeEffectiveParams.
+func paramsHash(sys *runtime.SystemInstance) string {
+	h := sha256.New()
+	writeEffectiveParams(h, sys)
+	return hex.EncodeToString(h.Sum(nil))[:12]
+}
+
+// ListRuns returns up to limit of the runs made, newest first, after
+// skipping offset of them, along with the number of runs remembered.
+func (d *DevEnv) ListRuns(offset, limit int) ([]RunRecord, int) {
+	return d.runHistory.List(offset, limit)
+}
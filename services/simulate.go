@@ -0,0 +1,228 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	protos "github.com/panyam/sdl/gen/go/sdl/v1/models"
+	"github.com/panyam/sdl/lib/decl"
+	"github.com/panyam/sdl/lib/loader"
+	"github.com/panyam/sdl/lib/parser"
+	"github.com/panyam/sdl/lib/runtime"
+)
+
+// simulatePath is where the submitted content lives in the ephemeral filesystem.
+const simulatePath = "/simulate.sdl"
+
+// SimulateRequest is a self contained simulation: the model, the system to
+// activate, the traffic to drive it with and the metrics to collect.
+// Exactly one of Duration or Runs must be set.
+type SimulateRequest struct {
+	SDLContent string
+	SystemName string
+	Generators []*protos.Generator
+	Duration   time.Duration // Run the generators for this long
+	Runs       int           // Or stop each generator after this many calls
+	Metrics    []*protos.Metric
+	Budget     runtime.RunBudget // Limits of each generator, unbounded if zero
+}
+
+// SimulateResponse holds the collected points for each metric (those requested
+// and those declared by the system) keyed by metric name.  Errors is set
+// instead when the model fails to compile.
+type SimulateResponse struct {
+	MetricSeries map[string][]*runtime.MetricPoint
+	Errors       []Diagnostic
+}
+
+// Diagnostic is a compile error or warning in the submitted content.  Line and Col are 0
+// when the error has no position.
+type Diagnostic struct {
+	Line    int    `json:"line"`
+	Col     int    `json:"col"`
+	Message string `json:"message"`
+}
+
+// Simulate compiles the content in an ephemeral DevEnv, runs the generators,
+// collects the metrics and tears everything down.  Compile errors are returned
+// as diagnostics in the response; invalid requests and runtime failures as errors.
+func Simulate(ctx context.Context, req *SimulateRequest) (*SimulateResponse, error) {
+	if req.SystemName == "" {
+		return nil, fmt.Errorf("system name is required")
+	}
+	if len(req.Generators) == 0 {
+		return nil, fmt.Errorf("at least one generator is required")
+	}
+	if (req.Duration > 0) == (req.Runs > 0) {
+		return nil, fmt.Errorf("exactly one of duration or runs must be positive")
+	}
+
+	fs := loader.NewMemoryFS()
+	fs.WriteFile(simulatePath, []byte(req.SDLContent))
+	resolver := loader.NewFileSystemResolver(fs)
+	if diags, _ := CompileDiagnostics(resolver, simulatePath); len(diags) > 0 {
+		return &SimulateResponse{Errors: diags}, nil
+	}
+
+	dev := NewDevEnv(resolver)
+	defer dev.Close()
+	if err := dev.SetRunBudget(req.Budget.MaxWallClock, req.Budget.MaxTraceNodes); err != nil {
+		return nil, err
+	}
+	if err := dev.LoadFile(simulatePath); err != nil {
+		return &SimulateResponse{Errors: []Diagnostic{{Message: err.Error()}}}, nil
+	}
+	if err := dev.Use(req.SystemName); err != nil {
+		return &SimulateResponse{Errors: []Diagnostic{{Message: err.Error()}}}, nil
+	}
+	for _, m := range req.Metrics {
+		if err := dev.AddMetric(&runtime.Metric{Metric: m}); err != nil {
+			return nil, fmt.Errorf("metric %s: %w", m.Name, err)
+		}
+	}
+
+	startTime := time.Now()
+	var gens []*runtime.Generator
+	for _, g := range req.Generators {
+		gen := &runtime.Generator{Generator: g, MaxRequests: req.Runs}
+		if err := dev.AddGenerator(gen); err != nil {
+			dev.StopAllGenerators()
+			return nil, fmt.Errorf("generator %s: %w", g.Name, err)
+		}
+		gens = append(gens, gen)
+	}
+	err := waitForGenerators(ctx, gens, req.Duration)
+	for _, gen := range gens {
+		gen.Stop(true)
+	}
+	dev.StopMetrics()
+	if err != nil {
+		return nil, err
+	}
+	for _, gen := range gens {
+		if err := gen.Err(); err != nil {
+			return nil, fmt.Errorf("generator %s: %w", gen.Name, err)
+		}
+	}
+
+	resp := &SimulateResponse{MetricSeries: map[string][]*runtime.MetricPoint{}}
+	endTime := time.Now()
+	for _, m := range dev.ListMetrics() {
+		result, err := dev.QueryMetrics(m.Name, runtime.QueryOptions{StartTime: startTime, EndTime: endTime})
+		if err != nil {
+			return nil, err
+		}
+		resp.MetricSeries[m.Name] = result.Points
+	}
+	return resp, nil
+}
+
+// waitForGenerators waits for the duration or, without one, until every
+// generator has made its capped number of calls.  It returns early if every
+// generator stops, eg by going over its budget.
+func waitForGenerators(ctx context.Context, gens []*runtime.Generator, duration time.Duration) error {
+	var deadline <-chan time.Time
+	if duration > 0 {
+		deadline = time.After(duration)
+	}
+	ticker := time.NewTicker(10 * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		running := false
+		for _, gen := range gens {
+			running = running || gen.IsRunning()
+		}
+		if !running {
+			return nil
+		}
+		select {
+		case <-deadline:
+			return nil
+		case <-ticker.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// CompileResult is the outcome of compiling a file, with errors and warnings
+// kept apart so tools can show them distinctly.  A file with only warnings
+// compiles successfully.
+type CompileResult struct {
+	Success  bool         `json:"success"`
+	Errors   []Diagnostic `json:"errors"`
+	Warnings []Diagnostic `json:"warnings"`
+	Systems  []string     `json:"systems"` // Systems declared by the file, when it compiles
+}
+
+// Compile parses and validates the file at path.  Warnings come from type
+// inference, eg shadowed or unused let variables, and from linting the file,
+// eg unused imports or params.
+func Compile(resolver loader.FileResolver, path string) (result *CompileResult) {
+	result = &CompileResult{Errors: []Diagnostic{}, Warnings: []Diagnostic{}, Systems: []string{}}
+	var errs []error
+	// Type inference panics with its first error
+	defer func() {
+		if r := recover(); r != nil {
+			err, ok := r.(error)
+			if !ok {
+				panic(r)
+			}
+			errs = append(errs, err)
+		}
+		for _, err := range errs {
+			result.Errors = append(result.Errors, newDiagnostic(err))
+		}
+		result.Success = len(result.Errors) == 0
+	}()
+	l := loader.NewLoader(nil, resolver, loader.DefaultMaxImportDepth)
+	status, err := l.LoadFile(path, "", 0)
+	if err != nil {
+		if status != nil && len(status.Errors) > 0 {
+			errs = status.Errors
+		} else {
+			errs = []error{err}
+		}
+		return
+	}
+	if !l.Validate(status) {
+		errs = status.Errors
+		if len(errs) == 0 {
+			errs = []error{fmt.Errorf("%s failed validation", path)}
+		}
+	}
+	for _, warning := range status.Warnings {
+		result.Warnings = append(result.Warnings, newDiagnostic(warning))
+	}
+	if len(errs) == 0 {
+		for _, issue := range decl.Lint(status.FileDecl) {
+			result.Warnings = append(result.Warnings, Diagnostic{Line: issue.Pos.Line, Col: issue.Pos.Col, Message: issue.Message})
+		}
+		systems, _ := status.FileDecl.GetSystems()
+		result.Systems = slices.Sorted(maps.Keys(systems))
+	}
+	return
+}
+
+// CompileDiagnostics parses and validates the file at path, returning its
+// errors and warnings as diagnostics.
+func CompileDiagnostics(resolver loader.FileResolver, path string) (diags, warnings []Diagnostic) {
+	result := Compile(resolver, path)
+	return result.Errors, result.Warnings
+}
+
+func newDiagnostic(err error) Diagnostic {
+	var syntaxErr *parser.SyntaxError
+	var inferErr *loader.InferenceError
+	switch {
+	case errors.As(err, &syntaxErr):
+		return Diagnostic{Line: syntaxErr.Pos.Line, Col: syntaxErr.Pos.Col, Message: syntaxErr.Msg}
+	case errors.As(err, &inferErr):
+		return Diagnostic{Line: inferErr.Pos.Line, Col: inferErr.Pos.Col, Message: inferErr.Msg}
+	}
+	return Diagnostic{Message: err.Error()}
+}
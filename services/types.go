@@ -20,6 +20,9 @@ type DiagramNode struct {
 	Traffic  string // Current traffic flow (e.g., "0 rps")
 	FullPath string // Full path from system root
 	Icon     string // Icon identifier
+
+	// Highest utilization of the component's resources, see GetFlowDiagram
+	Utilization float64
 }
 
 // MethodInfo represents information about a component method
@@ -41,4 +44,31 @@ type DiagramEdge struct {
 	Probability float64 // Probability of this path
 	GeneratorID string  // ID of originating generator
 	Color       string  // Visualization color
+	Latency     float64 // Mean latency of the called method in seconds, see GetFlowDiagram
+}
+
+// LoadedSystem summarises a system declared in a loaded file, see
+// DevEnv.LoadedSystems.
+type LoadedSystem struct {
+	Name       string            `json:"name"`
+	Parameters []SystemParameter `json:"parameters"`
+	// EntryPoints are the methods of the system's parameters a generator can
+	// target, eg "server.Handle".
+	EntryPoints []string `json:"entryPoints"`
+}
+
+// SystemParameter is a component instance a system declares.
+type SystemParameter struct {
+	Name      string `json:"name"`
+	Component string `json:"component"`
+}
+
+// FlowEntry is the evaluated flow through one component method, see GetFlows.
+type FlowEntry struct {
+	Component           string  `json:"component"`
+	Method              string  `json:"method"`
+	ArrivalRate         float64 `json:"arrivalRate"`         // Calls per second
+	ServiceRate         float64 `json:"serviceRate"`         // Calls per second the component can serve, 0 if unbounded
+	Utilization         float64 `json:"utilization"`         // Of the component's busiest resource
+	LatencyContribution float64 `json:"latencyContribution"` // Mean latency of a call in seconds
 }
@@ -75,6 +75,11 @@ func (p *WorkspacePresenter) FileSaved(ctx context.Context, req *protos.FileSave
 	if err := p.DevEnv.LoadFile(req.FilePath); err != nil {
 		return nil, fmt.Errorf("failed to reload file: %w", err)
 	}
+	// Loading is cached so recompile to pick up the saved changes.  Compile
+	// errors also reach the page as diagnostics.
+	if err := p.DevEnv.Reload(); err != nil {
+		return nil, fmt.Errorf("failed to reload file: %w", err)
+	}
 	return &protos.FileSavedResponse{}, nil
 }
 
@@ -206,8 +211,12 @@ func (p *WorkspacePresenter) EvaluateFlows(ctx context.Context, req *protos.Eval
 
 	return &protos.EvaluateFlowsResponse{
 		Strategy:       strategy,
-		Status:         "applied",
+		Status:         string(result.Status),
+		Iterations:     int32(result.Iterations),
+		Warnings:       result.Warnings,
 		ComponentRates: result.Flows.ComponentRates,
+		Converged:      result.Status == runtime.FlowStatusConverged,
+		Residual:       result.Residual,
 	}, nil
 }
 
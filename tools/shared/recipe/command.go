@@ -11,6 +11,7 @@ const (
 	CommandTypeEcho    RecipeCommandType = "echo"
 	CommandTypePause   RecipeCommandType = "pause"
 	CommandTypeCommand RecipeCommandType = "command"
+	CommandTypeInclude RecipeCommandType = "include"
 )
 
 // RecipeCommand represents a single command in a recipe
@@ -21,6 +22,7 @@ type RecipeCommand struct {
 	Command     string            `json:"command,omitempty"`
 	Args        []string          `json:"args,omitempty"`
 	Description string            `json:"description,omitempty"`
+	Source      string            `json:"source,omitempty"` // Recipe file the command came from, set by LoadRecipe
 }
 
 // RecipeValidationError represents a validation error in a recipe
@@ -28,6 +30,7 @@ type RecipeValidationError struct {
 	LineNumber int    `json:"lineNumber"`
 	Message    string `json:"message"`
 	Severity   string `json:"severity"` // "error" or "warning"
+	Source     string `json:"source,omitempty"` // Recipe file with the error, set by LoadRecipe
 }
 
 // Error implements the error interface for RecipeValidationError
@@ -83,7 +86,7 @@ func IsUnsupportedCommand(command string) bool {
 	return false
 }
 
-// IsAllowedCommand checks if a command is one of the three allowed types
+// IsAllowedCommand checks if a command is one of the allowed types
 func IsAllowedCommand(command string) bool {
-	return command == "sdl" || command == "echo" || command == "read"
+	return command == "sdl" || command == "echo" || command == "read" || command == "include"
 }
\ No newline at end of file
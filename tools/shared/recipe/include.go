@@ -0,0 +1,59 @@
+package recipe
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/panyam/sdl/lib/loader"
+)
+
+// LoadRecipe reads and parses the recipe at path, replacing each include
+// directive with the commands of the included recipe so a base scenario can be
+// reused and extended.  Included commands run in the including recipe's
+// context.  Relative include paths resolve against the including recipe's
+// directory.  Unreadable includes and include cycles are returned as errors.
+func LoadRecipe(fs loader.FileSystem, path string) (*RecipeParseResult, error) {
+	result := &RecipeParseResult{Commands: []RecipeCommand{}}
+	if err := loadRecipe(fs, path, nil, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// loadRecipe appends the expanded commands of the recipe at path to result.
+// stack holds the recipes currently being included, outermost first.
+func loadRecipe(fs loader.FileSystem, path string, stack []string, result *RecipeParseResult) error {
+	path = filepath.Clean(path)
+	for i, including := range stack {
+		if including == path {
+			return fmt.Errorf("include cycle: %s -> %s", strings.Join(stack[i:], " -> "), path)
+		}
+	}
+	content, err := fs.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("reading recipe %s: %w", path, err)
+	}
+	stack = append(stack, path)
+
+	parsed := ParseRecipe(string(content))
+	for _, e := range parsed.Errors {
+		e.Source = path
+		result.Errors = append(result.Errors, e)
+	}
+	for _, cmd := range parsed.Commands {
+		if cmd.Type != CommandTypeInclude || len(cmd.Args) != 1 {
+			cmd.Source = path
+			result.Commands = append(result.Commands, cmd)
+			continue
+		}
+		included := cmd.Args[0]
+		if !filepath.IsAbs(included) {
+			included = filepath.Join(filepath.Dir(path), included)
+		}
+		if err := loadRecipe(fs, included, stack, result); err != nil {
+			return fmt.Errorf("%s line %d: %w", path, cmd.LineNumber, err)
+		}
+	}
+	return nil
+}
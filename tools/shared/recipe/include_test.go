@@ -0,0 +1,75 @@
+package recipe
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/panyam/sdl/lib/loader"
+)
+
+func TestLoadRecipeIncludes(t *testing.T) {
+	fs := loader.NewMemoryFS()
+	fs.WriteFile("/recipes/base/ramp.recipe", []byte(`echo "Ramp up"
+sdl gen add load server.Handle 10
+sdl gen update load 50`))
+	fs.WriteFile("/recipes/spike.recipe", []byte(`sdl load model.sdl
+sdl use App
+include base/ramp.recipe
+sdl gen update load 500`))
+
+	result, err := LoadRecipe(fs, "/recipes/spike.recipe")
+	if err != nil {
+		t.Fatalf("LoadRecipe() error = %v", err)
+	}
+	if result.HasErrors() {
+		t.Fatalf("LoadRecipe() validation errors = %v", result.Errors)
+	}
+
+	var lines []string
+	for _, cmd := range result.Commands {
+		lines = append(lines, cmd.RawLine)
+	}
+	expected := []string{
+		"sdl load model.sdl",
+		"sdl use App",
+		`echo "Ramp up"`,
+		"sdl gen add load server.Handle 10",
+		"sdl gen update load 50",
+		"sdl gen update load 500",
+	}
+	if strings.Join(lines, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("LoadRecipe() commands = %q, expected %q", lines, expected)
+	}
+	if got := result.Commands[2].Source; got != "/recipes/base/ramp.recipe" {
+		t.Errorf("included command source = %q, expected the included recipe", got)
+	}
+	if got := result.Commands[2].LineNumber; got != 1 {
+		t.Errorf("included command line = %d, expected its line in the included recipe", got)
+	}
+}
+
+func TestLoadRecipeIncludeErrors(t *testing.T) {
+	fs := loader.NewMemoryFS()
+	fs.WriteFile("/a.recipe", []byte("echo \"a\"\ninclude b.recipe"))
+	fs.WriteFile("/b.recipe", []byte("include a.recipe"))
+	fs.WriteFile("/missing.recipe", []byte("include nowhere.recipe"))
+	fs.WriteFile("/invalid.recipe", []byte("include one.recipe two.recipe"))
+
+	_, err := LoadRecipe(fs, "/a.recipe")
+	if err == nil || !strings.Contains(err.Error(), "include cycle: /a.recipe -> /b.recipe -> /a.recipe") {
+		t.Errorf("LoadRecipe() error = %v, expected an include cycle", err)
+	}
+
+	_, err = LoadRecipe(fs, "/missing.recipe")
+	if err == nil || !strings.Contains(err.Error(), "/nowhere.recipe") {
+		t.Errorf("LoadRecipe() error = %v, expected the missing include", err)
+	}
+
+	result, err := LoadRecipe(fs, "/invalid.recipe")
+	if err != nil {
+		t.Fatalf("LoadRecipe() error = %v", err)
+	}
+	if len(result.Errors) != 1 || result.Errors[0].Source != "/invalid.recipe" {
+		t.Errorf("LoadRecipe() errors = %v, expected one for the include line", result.Errors)
+	}
+}
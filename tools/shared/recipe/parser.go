@@ -81,6 +81,21 @@ func ParseRecipe(content string) *RecipeParseResult {
 			continue
 		}
 
+		// Include another recipe, expanded by LoadRecipe
+		if trimmed == "include" || strings.HasPrefix(trimmed, "include ") {
+			parts := ParseCommandLine(trimmed)
+			errors = append(errors, ValidateIncludeCommand(parts[1:], lineNumber)...)
+
+			commands = append(commands, RecipeCommand{
+				LineNumber: lineNumber,
+				RawLine:    line,
+				Type:       CommandTypeInclude,
+				Command:    parts[0],
+				Args:       parts[1:],
+			})
+			continue
+		}
+
 		// SDL command - handles both "sdl ..." and standalone "sdl"
 		if strings.HasPrefix(trimmed, "sdl ") || trimmed == "sdl" {
 			parts := ParseCommandLine(trimmed)
@@ -114,6 +114,27 @@ func ValidateReadCommand(line string, lineNumber int) []RecipeValidationError {
 	return errors
 }
 
+// ValidateIncludeCommand validates the arguments of an include directive
+func ValidateIncludeCommand(args []string, lineNumber int) []RecipeValidationError {
+	var errors []RecipeValidationError
+
+	if len(args) != 1 || args[0] == "" {
+		errors = append(errors, RecipeValidationError{
+			LineNumber: lineNumber,
+			Message:    "include requires a single recipe path",
+			Severity:   "error",
+		})
+	} else if ContainsUnquotedVariable(args[0]) {
+		errors = append(errors, RecipeValidationError{
+			LineNumber: lineNumber,
+			Message:    "Variable expansion not supported in include paths",
+			Severity:   "error",
+		})
+	}
+
+	return errors
+}
+
 // ValidateSDLCommand validates an SDL command and its arguments
 func ValidateSDLCommand(command string, args []string, lineNumber int, fullLine string) []RecipeValidationError {
 	var errors []RecipeValidationError
@@ -23,7 +23,10 @@ func (s *Server) Start(ctx context.Context, srvErr chan error, srvChan chan bool
 		return fmt.Errorf("WorkspaceService is required")
 	}
 
-	server := grpc.NewServer()
+	server := grpc.NewServer(
+		grpc.UnaryInterceptor(devenvbe.AuthInterceptor(s.WorkspaceService.Authn)),
+		grpc.StreamInterceptor(devenvbe.StreamAuthInterceptor(s.WorkspaceService.Authn)),
+	)
 
 	// Register WorkspaceService (replaces old CanvasService)
 	v1services.RegisterWorkspaceServiceServer(server, s.WorkspaceService)
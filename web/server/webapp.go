@@ -14,6 +14,7 @@ import (
 	goal "github.com/panyam/goapplib"
 	protos "github.com/panyam/sdl/gen/go/sdl/v1/models"
 	"github.com/panyam/sdl/services"
+	"github.com/panyam/sdl/services/fsbe"
 	"github.com/panyam/sdl/services/inmem"
 	gotl "github.com/panyam/goutils/template"
 	gohttp "github.com/panyam/servicekit/http"
@@ -84,8 +85,10 @@ func (a *SdlApp) LoadViteManifest(distDir string) {
 }
 
 // NewSdlApp creates a new SdlApp and its associated goal.App.
+// Workspaces are kept in workspacesDir so they survive restarts, or in memory
+// (seeded from examples/) if it is empty.
 // Returns the SdlApp and the goal.App wrapper.
-func NewSdlApp(grpcAddress string) (sdlApp *SdlApp, goalApp *goal.App[*SdlApp], err error) {
+func NewSdlApp(grpcAddress string, workspacesDir string) (sdlApp *SdlApp, goalApp *goal.App[*SdlApp], err error) {
 	// Create client manager for gRPC calls
 	clientMgr := services.NewClientMgr(grpcAddress)
 
@@ -142,9 +145,17 @@ func NewSdlApp(grpcAddress string) (sdlApp *SdlApp, goalApp *goal.App[*SdlApp],
 		clients: make(map[string]*CanvasWSConn),
 	}
 
-	// Initialize workspace service (in-memory, seeded from examples/)
-	wsStorage := inmem.NewWorkspaceStorage()
-	wsStorage.SeedFromExamples("examples")
+	// Initialize workspace service
+	var wsStorage services.WorkspaceStorageProvider
+	if workspacesDir != "" {
+		if wsStorage, err = fsbe.NewWorkspaceStorage(workspacesDir); err != nil {
+			return
+		}
+	} else {
+		memStorage := inmem.NewWorkspaceStorage()
+		memStorage.SeedFromExamples("examples")
+		wsStorage = memStorage
+	}
 	sdlApp.WorkspaceSvc = services.NewBackendWorkspaceService(wsStorage)
 
 	// Create WorkspacesGroup